@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/parts"
+	equipmentApp "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/app"
+	equipmentDomain "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	equipmentInfra "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/infra"
+	inventoryApp "github.com/aby-med/medical-platform/internal/service-domain/inventory/app"
+	inventoryDomain "github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+	serviceTicketDomain "github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+	serviceTicketInfra "github.com/aby-med/medical-platform/internal/service-domain/service-ticket/infra"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// partsCompatibility checks parts recommendations against the inventory
+// compatibility graph
+type partsCompatibility struct {
+	service *inventoryApp.CompatibilityService
+}
+
+func newPartsCompatibility(service *inventoryApp.CompatibilityService) *partsCompatibility {
+	return &partsCompatibility{service: service}
+}
+
+// SelectPart implements parts.CompatibilityChecker
+func (c *partsCompatibility) SelectPart(ctx context.Context, partNumber, equipmentID, modelNumber string) (*parts.PartSelection, error) {
+	var selection *inventoryDomain.PartSelection
+	var err error
+	if equipmentID == "" && modelNumber != "" {
+		selection, err = c.service.SelectModelPart(ctx, partNumber, modelNumber)
+	} else {
+		selection, err = c.service.SelectPart(ctx, partNumber, equipmentID)
+	}
+	if err != nil {
+		if errors.Is(err, inventoryDomain.ErrIncompatiblePart) {
+			return nil, parts.ErrIncompatiblePart
+		}
+		return nil, err
+	}
+	return &parts.PartSelection{
+		PartNumber: selection.PartNumber,
+		PartName:   selection.PartName,
+		Superseded: selection.Superseded,
+		Alternate:  selection.Alternate,
+		Confirmed:  selection.Fit == inventoryDomain.FitConfirmed,
+	}, nil
+}
+
+// partsUsage reads the units of service tickets and their meter readings
+// for parts recommendations
+type partsUsage struct {
+	tickets *serviceTicketInfra.TicketRepository
+	meters  *equipmentApp.MeterService
+}
+
+func newPartsUsage(pool *pgxpool.Pool, logger *slog.Logger) *partsUsage {
+	return &partsUsage{
+		tickets: serviceTicketInfra.NewTicketRepository(pool),
+		meters:  equipmentApp.NewMeterService(equipmentInfra.NewMeterRepository(pool), equipmentInfra.NewEquipmentRepository(pool), logger),
+	}
+}
+
+// TicketEquipment implements parts.UsageReader
+func (u *partsUsage) TicketEquipment(ctx context.Context, ticketRef string) (string, error) {
+	var ticket *serviceTicketDomain.ServiceTicket
+	var err error
+	if _, parseErr := uuid.Parse(ticketRef); parseErr == nil {
+		ticket, err = u.tickets.GetByID(ctx, ticketRef)
+	} else {
+		ticket, err = u.tickets.GetByTicketNumber(ctx, ticketRef)
+	}
+	if err != nil {
+		return "", err
+	}
+	return ticket.EquipmentID, nil
+}
+
+// EquipmentUsage implements parts.UsageReader. Exposure counts stand in for
+// cycles on units without a cycle meter.
+func (u *partsUsage) EquipmentUsage(ctx context.Context, equipmentID string) (*parts.Usage, error) {
+	snapshot, err := u.meters.GetUsageSnapshot(ctx, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	counter := func(types ...equipmentDomain.MeterType) *int {
+		for _, meterType := range types {
+			if meter, ok := snapshot.ByType[meterType]; ok {
+				value := int(meter.CumulativeValue)
+				return &value
+			}
+		}
+		return nil
+	}
+	return &parts.Usage{
+		OperatingHours: counter(equipmentDomain.MeterOperatingHours),
+		TotalCycles:    counter(equipmentDomain.MeterCycleCount, equipmentDomain.MeterExposureCount),
+	}, nil
+}
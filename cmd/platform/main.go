@@ -31,7 +31,11 @@ import (
 	"github.com/aby-med/medical-platform/internal/service-domain/quote"
 	"github.com/aby-med/medical-platform/internal/service-domain/comparison"
 	"github.com/aby-med/medical-platform/internal/service-domain/contract"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement"
 	equipment "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry"
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory"
+	inventoryApp "github.com/aby-med/medical-platform/internal/service-domain/inventory/app"
+	inventoryInfra "github.com/aby-med/medical-platform/internal/service-domain/inventory/infra"
 	// equipmentApp "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/app" // Only used by WhatsApp (disabled)
 	serviceticket "github.com/aby-med/medical-platform/internal/service-domain/service-ticket"
 	// serviceticketApp "github.com/aby-med/medical-platform/internal/service-domain/service-ticket/app" // Disabled - used only by WhatsApp
@@ -360,6 +364,13 @@ func initializeModules(ctx context.Context, router *chi.Mux, enabledModules []st
 	}
 	registry.Register(contract.NewModule(*contractConfig, logger))
 	
+	// Register Procurement module (RFQ award saga across rfq/quote/comparison/contract)
+	procurementConfig := &procurement.Config{
+		DatabaseURL:  cfg.GetDSN(),
+		KafkaBrokers: cfg.Kafka.Brokers,
+	}
+	registry.Register(procurement.NewModule(*procurementConfig, logger))
+	
 	// Setup common variables for Equipment Registry and Service Ticket modules
 	baseURL := os.Getenv("BASE_URL")
 	if baseURL == "" {
@@ -378,19 +389,36 @@ func initializeModules(ctx context.Context, router *chi.Mux, enabledModules []st
 	
 	// Register Equipment Registry module (Field Service Management)
 	// This module has multi-tenant filtering built-in
+	equipmentImportDir := os.Getenv("EQUIPMENT_IMPORT_DIR")
+	if equipmentImportDir == "" {
+		equipmentImportDir = "./data/imports"
+	}
 	equipmentModule, err := equipment.NewModule(equipment.ModuleConfig{
-		DBHost:      cfg.Database.Host,
-		DBPort:      dbPort,
-		DBUser:      cfg.Database.User,
-		DBPassword:  cfg.Database.Password,
-		DBName:      cfg.Database.Name,
-		BaseURL:     baseURL,
-		QROutputDir: qrOutputDir,
+		DBHost:           cfg.Database.Host,
+		DBPort:           dbPort,
+		DBUser:           cfg.Database.User,
+		DBPassword:       cfg.Database.Password,
+		DBName:           cfg.Database.Name,
+		BaseURL:          baseURL,
+		QROutputDir:      qrOutputDir,
+		ImportStorageDir: equipmentImportDir,
 	}, logger)
 	if err == nil {
 		registry.Register(equipmentModule)
 	}
 	
+	// Register Inventory module (spare parts stock in warehouses and engineers' vans)
+	inventoryModule, err := inventory.NewModule(inventory.ModuleConfig{
+		DBHost:     cfg.Database.Host,
+		DBPort:     dbPort,
+		DBUser:     cfg.Database.User,
+		DBPassword: cfg.Database.Password,
+		DBName:     cfg.Database.Name,
+	}, logger)
+	if err == nil {
+		registry.Register(inventoryModule)
+	}
+	
 	// Register Service Ticket module (includes WhatsApp integration)
 	whatsappVerifyToken := os.Getenv("WHATSAPP_VERIFY_TOKEN")
 	if whatsappVerifyToken == "" {
@@ -415,6 +443,7 @@ func initializeModules(ctx context.Context, router *chi.Mux, enabledModules []st
 		WhatsAppAccessToken: whatsappAccessToken,
 		WhatsAppPhoneID:     whatsappPhoneID,
 		WhatsAppMediaDir:    whatsappMediaDir,
+		AssignmentRadiusBands: os.Getenv("ASSIGNMENT_RADIUS_BANDS"),
 	}, logger)
 	if err == nil {
 		registry.Register(serviceTicketModule)
@@ -459,7 +488,7 @@ func initializeModules(ctx context.Context, router *chi.Mux, enabledModules []st
 		},
 	}
 	
-	_, err = aimanager.NewManager(aiConfig)
+	aiMgr, err := aimanager.NewManager(aiConfig)
 	if err != nil {
 		logger.Warn("Failed to initialize AI manager (AI features will be disabled)", 
 			slog.String("error", err.Error()))
@@ -486,8 +515,7 @@ func initializeModules(ctx context.Context, router *chi.Mux, enabledModules []st
 				logger.Info("Initializing AI Assignment Optimizer")
 				// _ = assignment.NewEngine(aiMgr, db)
 				
-				logger.Info("Initializing AI Parts Recommender")
-				// _ = parts.NewEngine(aiMgr, db)
+				// The parts recommender is mounted under /api/v1 with the modules
 				
 				logger.Info("Initializing AI Feedback Loop Manager")
 				// _ = feedback.NewCollector(db)
@@ -539,9 +567,20 @@ func initializeModules(ctx context.Context, router *chi.Mux, enabledModules []st
 		if err != nil {
 			logger.Error("Failed to create pool for parts import", slog.String("error", err.Error()))
 		} else {
+			compatibility := inventoryApp.NewCompatibilityService(inventoryInfra.NewCompatibilityRepository(importPool), logger)
 			partsImportHandler := api.NewPartsImportHandler(importPool, logger)
+			partsImportHandler.SetCompatibility(compatibility)
 			apiRouter.Post("/parts/import", partsImportHandler.ImportParts)
 			logger.Info("Parts import endpoint registered")
+
+			// Add parts recommendation endpoint, checked against the compatibility graph
+			if authDB != nil {
+				partsHandler := api.NewPartsHandler(aiMgr, authDB.DB)
+				partsHandler.SetCompatibility(newPartsCompatibility(compatibility))
+				partsHandler.SetUsageReader(newPartsUsage(importPool, logger))
+				apiRouter.Post("/parts/recommend", partsHandler.RecommendParts)
+				logger.Info("Parts recommendation endpoint registered")
+			}
 		}
 		
 		// Add partner association endpoints (inside the existing /api/v1 route)
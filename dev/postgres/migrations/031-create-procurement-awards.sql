@@ -0,0 +1,37 @@
+-- Migration: Create procurement awards for the RFQ award saga
+-- Each row is one attempt to award an RFQ from a completed comparison,
+-- with the per-step audit trail stored as JSONB
+
+CREATE TABLE IF NOT EXISTS procurement_awards (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    rfq_id VARCHAR(32) NOT NULL,
+    comparison_id VARCHAR(32) NOT NULL,
+    quote_id VARCHAR(32) NOT NULL,
+    supplier_id VARCHAR(32) NOT NULL,
+    contract_id VARCHAR(32),
+    status VARCHAR(50) NOT NULL DEFAULT 'in_progress'
+        CHECK (status IN ('in_progress', 'completed', 'compensated', 'failed')),
+    reason TEXT,
+    error TEXT,
+
+    -- Audit trail of saga steps and compensations
+    steps JSONB NOT NULL DEFAULT '[]'::jsonb,
+
+    -- Metadata
+    awarded_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_procurement_awards_tenant ON procurement_awards(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_procurement_awards_rfq ON procurement_awards(tenant_id, rfq_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_procurement_awards_status ON procurement_awards(status);
+
+-- At most one running or completed award per RFQ, so concurrent or repeated
+-- award requests cannot both run the saga
+CREATE UNIQUE INDEX IF NOT EXISTS idx_procurement_awards_rfq_active
+    ON procurement_awards(tenant_id, rfq_id)
+    WHERE status IN ('in_progress', 'completed');
@@ -0,0 +1,33 @@
+-- Migration: Supplier portal for RFQ invitations, clarification Q&A and quote submission
+-- Links suppliers to platform organizations so supplier users can authenticate,
+-- records decline reasons and stores the per-RFQ Q&A thread
+
+-- Supplier organization link
+ALTER TABLE suppliers ADD COLUMN IF NOT EXISTS organization_id VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_suppliers_tenant_organization
+    ON suppliers(tenant_id, organization_id)
+    WHERE organization_id IS NOT NULL;
+
+-- Invitation decline reason
+ALTER TABLE rfq_invitations ADD COLUMN IF NOT EXISTS decline_reason TEXT;
+
+-- Clarification questions (visible to all invited suppliers)
+CREATE TABLE IF NOT EXISTS rfq_questions (
+    id VARCHAR(32) PRIMARY KEY,
+    rfq_id VARCHAR(26) NOT NULL REFERENCES rfqs(id) ON DELETE CASCADE,
+    supplier_id VARCHAR(255) NOT NULL,
+
+    -- Question
+    question TEXT NOT NULL,
+    asked_by VARCHAR(255) NOT NULL,
+    asked_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Answer
+    answer TEXT,
+    answered_by VARCHAR(255),
+    answered_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_rfq_questions_rfq_id ON rfq_questions(rfq_id, asked_at);
+CREATE INDEX IF NOT EXISTS idx_rfq_questions_supplier_id ON rfq_questions(supplier_id);
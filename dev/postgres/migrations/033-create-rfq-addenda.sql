@@ -0,0 +1,49 @@
+-- Migration: RFQ addenda, public Q&A and bidder notifications
+-- Published RFQs change only through versioned addenda; quotes record the
+-- RFQ version they respond to so comparisons can flag stale quotes
+
+-- RFQ version (bumped by each addendum)
+ALTER TABLE rfqs ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- Quote pinned to the RFQ version it responds to
+ALTER TABLE quotes ADD COLUMN IF NOT EXISTS rfq_version INTEGER NOT NULL DEFAULT 1;
+
+-- Addenda
+CREATE TABLE IF NOT EXISTS rfq_addenda (
+    id VARCHAR(32) PRIMARY KEY,
+    rfq_id VARCHAR(26) NOT NULL REFERENCES rfqs(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    summary TEXT NOT NULL,
+
+    -- Changes
+    previous_deadline TIMESTAMPTZ NOT NULL,
+    new_deadline TIMESTAMPTZ,
+    item_changes JSONB NOT NULL DEFAULT '[]',
+    new_items JSONB NOT NULL DEFAULT '[]',
+
+    issued_by VARCHAR(255) NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (rfq_id, version)
+);
+
+-- Public Q&A: answers are shared with all bidders unless kept private.
+-- Answers given before this migration were already shared.
+ALTER TABLE rfq_questions ADD COLUMN IF NOT EXISTS public BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE rfq_questions SET public = TRUE WHERE answered_at IS NOT NULL;
+
+-- Bidder notifications (addenda and published answers)
+CREATE TABLE IF NOT EXISTS rfq_bidder_notifications (
+    id VARCHAR(32) PRIMARY KEY,
+    rfq_id VARCHAR(26) NOT NULL REFERENCES rfqs(id) ON DELETE CASCADE,
+    supplier_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    reference_id VARCHAR(32) NOT NULL,
+    subject VARCHAR(500) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_rfq_bidder_notifications_supplier
+    ON rfq_bidder_notifications(supplier_id, created_at DESC);
@@ -0,0 +1,24 @@
+-- Migration: sealed-bid RFQs and deadline enforcement
+-- Quotes of a sealed RFQ stay hidden from buyers until an explicit, audited
+-- bid opening after the response deadline
+
+-- Sealed-bid mode and opening state
+ALTER TABLE rfqs ADD COLUMN IF NOT EXISTS sealed_bid BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE rfqs ADD COLUMN IF NOT EXISTS bids_opened_at TIMESTAMPTZ;
+ALTER TABLE rfqs ADD COLUMN IF NOT EXISTS bids_opened_by VARCHAR(255);
+
+-- Deadline closer scans published RFQs by deadline
+CREATE INDEX IF NOT EXISTS idx_rfqs_published_deadline
+    ON rfqs(response_deadline) WHERE status = 'published';
+
+-- Bid opening audit record (one per RFQ)
+CREATE TABLE IF NOT EXISTS rfq_bid_openings (
+    id VARCHAR(32) PRIMARY KEY,
+    rfq_id VARCHAR(26) NOT NULL UNIQUE REFERENCES rfqs(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(50) NOT NULL,
+    opened_by VARCHAR(255) NOT NULL,
+    opened_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    quote_count INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_rfq_bid_openings_tenant ON rfq_bid_openings(tenant_id);
@@ -0,0 +1,50 @@
+-- Migration: Create reverse auctions for RFQs
+-- Suppliers with qualifying quotes bid their price down during a timed
+-- auction; the final bids reprice their quotes and feed a comparison
+
+CREATE TABLE IF NOT EXISTS rfq_auctions (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    rfq_id VARCHAR(32) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'scheduled'
+        CHECK (status IN ('scheduled', 'closed', 'cancelled')),
+    currency VARCHAR(3) NOT NULL,
+
+    -- Bidding window and anti-sniping
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    scheduled_end_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    min_decrement DECIMAL(15,2) NOT NULL CHECK (min_decrement > 0),
+    extension_seconds INTEGER NOT NULL DEFAULT 0,
+    max_extensions INTEGER NOT NULL DEFAULT 0,
+    extensions INTEGER NOT NULL DEFAULT 0,
+    bid_count INTEGER NOT NULL DEFAULT 0, -- Optimistic concurrency guard for bids
+
+    -- Suppliers admitted with their qualifying quote and opening price
+    participants JSONB NOT NULL DEFAULT '[]'::jsonb,
+
+    -- Comparison created from the final prices
+    comparison_id VARCHAR(32),
+
+    -- Metadata
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS rfq_auction_bids (
+    id VARCHAR(32) PRIMARY KEY,
+    auction_id VARCHAR(32) NOT NULL REFERENCES rfq_auctions(id) ON DELETE CASCADE,
+    supplier_id VARCHAR(32) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    placed_by VARCHAR(255) NOT NULL,
+    placed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    extended BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_rfq_auctions_rfq ON rfq_auctions(tenant_id, rfq_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_rfq_auctions_ended ON rfq_auctions(ends_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_rfq_auctions_participants ON rfq_auctions USING GIN (participants);
+CREATE INDEX IF NOT EXISTS idx_rfq_auction_bids_auction ON rfq_auction_bids(auction_id, placed_at);
@@ -0,0 +1,27 @@
+-- Migration: Create scoring templates for quote comparisons
+-- A template is a named set of weighted criteria (price, TCO, delivery,
+-- warranty, supplier rating, past performance, compliance). Comparisons copy
+-- the criteria into scoring_criteria so later template edits do not change them.
+
+CREATE TABLE IF NOT EXISTS comparison_scoring_templates (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    criteria JSONB NOT NULL DEFAULT '[]'::jsonb,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- Metadata
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    UNIQUE (tenant_id, name)
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_scoring_templates_tenant ON comparison_scoring_templates(tenant_id);
+
+-- At most one default template per tenant
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scoring_templates_default
+    ON comparison_scoring_templates(tenant_id) WHERE is_default;
@@ -0,0 +1,29 @@
+-- Migration: Multi-currency procurement
+-- RFQs declare a base currency and an optional rate date (defaulting to the
+-- response deadline); quotes in other currencies are converted to it using
+-- the tenant's exchange rates when they are compared.
+
+ALTER TABLE rfqs ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE rfqs ADD COLUMN IF NOT EXISTS rate_date DATE;
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    from_currency VARCHAR(3) NOT NULL,
+    to_currency VARCHAR(3) NOT NULL,
+    rate NUMERIC(20, 10) NOT NULL CHECK (rate > 0), -- Units of to_currency per unit of from_currency
+    rate_date DATE NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual', -- manual, csv
+
+    -- Metadata
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    UNIQUE (tenant_id, from_currency, to_currency, rate_date),
+    CHECK (from_currency <> to_currency)
+);
+
+-- Rate lookups take the latest rate of a pair on or before a day
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_lookup
+    ON exchange_rates(tenant_id, from_currency, to_currency, rate_date DESC);
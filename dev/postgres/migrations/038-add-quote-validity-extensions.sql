@@ -0,0 +1,48 @@
+-- Migration: Quote expiry sweep and validity extensions
+-- Quotes past valid_until are expired by a background sweeper. Suppliers ask
+-- for more time through extension requests the buyer approves; an approved
+-- extension is recorded as a quote revision.
+
+CREATE TABLE IF NOT EXISTS quote_validity_extensions (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    quote_id VARCHAR(32) NOT NULL REFERENCES quotes(id) ON DELETE CASCADE,
+    previous_valid_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    requested_valid_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, approved, rejected
+
+    requested_by VARCHAR(255) NOT NULL,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    decided_by VARCHAR(255),
+    decided_at TIMESTAMP WITH TIME ZONE,
+    decision_notes TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_quote_extensions_quote ON quote_validity_extensions(quote_id, requested_at DESC);
+
+-- At most one pending request per quote
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quote_extensions_pending
+    ON quote_validity_extensions(quote_id) WHERE status = 'pending';
+
+-- Expiry reminders and extension outcomes for suppliers and buyers
+CREATE TABLE IF NOT EXISTS quote_notifications (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    quote_id VARCHAR(32) NOT NULL REFERENCES quotes(id) ON DELETE CASCADE,
+    rfq_id VARCHAR(32) NOT NULL,
+    supplier_id VARCHAR(255) NOT NULL,
+    recipient VARCHAR(20) NOT NULL, -- supplier, buyer
+    kind VARCHAR(30) NOT NULL,      -- expiring, expired, extension_requested, extension_approved, extension_rejected
+    subject TEXT NOT NULL,
+    message TEXT,
+    valid_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_quote_notifications_tenant ON quote_notifications(tenant_id, recipient, created_at DESC);
+
+-- One expiry reminder per recipient and validity date
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quote_notifications_reminder
+    ON quote_notifications(quote_id, recipient, valid_until) WHERE kind = 'expiring';
@@ -0,0 +1,8 @@
+-- Migration: Supplier scorecard indexes
+-- Scorecards are computed on demand from contracts, quotes, registered
+-- equipment and service tickets; these indexes back the per-supplier
+-- aggregates.
+
+CREATE INDEX IF NOT EXISTS idx_equipment_contract ON equipment_registry(contract_id);
+CREATE INDEX IF NOT EXISTS idx_contracts_tenant_supplier ON contracts(tenant_id, supplier_id);
+CREATE INDEX IF NOT EXISTS idx_quotes_tenant_supplier ON quotes(tenant_id, supplier_id);
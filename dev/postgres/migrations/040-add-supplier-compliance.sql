@@ -0,0 +1,38 @@
+-- Migration: Supplier certification compliance
+-- A monitor warns suppliers and buyers ahead of certification expiry and
+-- moves suppliers whose mandatory certifications lapsed to 'restricted', in
+-- which they cannot be invited to new RFQs. An admin reinstates them by
+-- re-verifying the renewed certificates.
+
+ALTER TABLE suppliers DROP CONSTRAINT IF EXISTS suppliers_status_check;
+ALTER TABLE suppliers ADD CONSTRAINT suppliers_status_check
+    CHECK (status IN ('pending', 'active', 'suspended', 'inactive', 'restricted'));
+
+ALTER TABLE suppliers ADD COLUMN IF NOT EXISTS restricted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE suppliers ADD COLUMN IF NOT EXISTS restriction_reason TEXT;
+
+CREATE TABLE IF NOT EXISTS supplier_compliance_notifications (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL,
+    supplier_id VARCHAR(32) NOT NULL REFERENCES suppliers(id) ON DELETE CASCADE,
+    certification_id VARCHAR(64),
+    certification_name VARCHAR(255),
+    expiry_date TIMESTAMP WITH TIME ZONE,
+    recipient VARCHAR(20) NOT NULL CHECK (recipient IN ('supplier', 'buyer')),
+    kind VARCHAR(40) NOT NULL,
+    subject VARCHAR(500) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_supplier_compliance_notifications_tenant
+    ON supplier_compliance_notifications(tenant_id, recipient, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_supplier_compliance_notifications_supplier
+    ON supplier_compliance_notifications(supplier_id);
+
+-- Each certification expiry is warned about once per kind and recipient;
+-- a renewed certificate has a new expiry date and is warned about again
+CREATE UNIQUE INDEX IF NOT EXISTS idx_supplier_compliance_notifications_once
+    ON supplier_compliance_notifications(supplier_id, certification_id, expiry_date, kind, recipient)
+    WHERE kind IN ('certification_expiring', 'certification_expired');
@@ -0,0 +1,51 @@
+-- Migration: Contract documents and e-signature
+-- Contracts are rendered to versioned PDFs, each tied to the latest amendment
+-- and kept with a snapshot of the contract it was rendered from. A signing
+-- request collects buyer and supplier signatures, in order, on one rendered
+-- version; the last signature produces a sealed PDF with an audit page.
+
+CREATE TABLE IF NOT EXISTS contract_documents (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    contract_id VARCHAR(32) NOT NULL REFERENCES contracts(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL CHECK (version > 0),
+    amendment_id VARCHAR(32),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('rendered', 'sealed')),
+    signing_request_id VARCHAR(32),
+    content_hash CHAR(64) NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    content BYTEA NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (contract_id, version, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_contract_documents_contract
+    ON contract_documents(tenant_id, contract_id, version DESC);
+
+CREATE TABLE IF NOT EXISTS contract_signing_requests (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    contract_id VARCHAR(32) NOT NULL REFERENCES contracts(id) ON DELETE CASCADE,
+    document_id VARCHAR(32) NOT NULL REFERENCES contract_documents(id),
+    document_version INTEGER NOT NULL,
+    document_hash CHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL
+        CHECK (status IN ('pending', 'completed', 'declined', 'expired', 'cancelled')),
+    signatories JSONB NOT NULL DEFAULT '[]',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sealed_document_id VARCHAR(32) REFERENCES contract_documents(id),
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    revision INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_contract_signing_requests_contract
+    ON contract_signing_requests(tenant_id, contract_id, created_at DESC);
+
+-- A contract has at most one signing request in progress
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contract_signing_requests_open
+    ON contract_signing_requests(contract_id)
+    WHERE status = 'pending';
@@ -0,0 +1,35 @@
+-- Migration: Contract obligation reminders
+-- Payment terms now carry partial payments and retention in the
+-- payment_schedule JSONB, and delivery milestones the equipment records of
+-- the units delivered. A monitor reminds buyers and suppliers of obligations
+-- coming due and escalates overdue ones as they move through aging buckets.
+
+CREATE TABLE IF NOT EXISTS contract_obligation_reminders (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    contract_id VARCHAR(32) NOT NULL REFERENCES contracts(id) ON DELETE CASCADE,
+    obligation_kind VARCHAR(20) NOT NULL CHECK (obligation_kind IN ('payment', 'retention', 'delivery')),
+    obligation_index INTEGER NOT NULL,
+    due_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    aging_bucket VARCHAR(10) NOT NULL,
+    recipient VARCHAR(20) NOT NULL CHECK (recipient IN ('buyer', 'supplier')),
+    kind VARCHAR(40) NOT NULL,
+    subject VARCHAR(500) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_contract_obligation_reminders_tenant
+    ON contract_obligation_reminders(tenant_id, recipient, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_contract_obligation_reminders_contract
+    ON contract_obligation_reminders(contract_id);
+
+-- Each obligation is reminded about once before it is due, and once per
+-- aging bucket while overdue; a rescheduled due date is reminded about again
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contract_obligation_reminders_once
+    ON contract_obligation_reminders(contract_id, obligation_kind, obligation_index, due_date, kind, aging_bucket, recipient);
+
+CREATE INDEX IF NOT EXISTS idx_contracts_open_status
+    ON contracts(status)
+    WHERE status IN ('active', 'suspended');
@@ -0,0 +1,46 @@
+-- Migration: Contract amendment approvals and version history
+-- Amendments are proposed as structured change sets with a field-level diff
+-- and applied once every required role (procurement manager, and finance or
+-- legal depending on what changes) has approved. Each version of a contract
+-- is kept as a snapshot so any past state can be reconstructed.
+
+CREATE TABLE IF NOT EXISTS contract_amendment_requests (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    contract_id VARCHAR(32) NOT NULL REFERENCES contracts(id) ON DELETE CASCADE,
+    base_version INTEGER NOT NULL,
+    description TEXT NOT NULL,
+    change_set JSONB NOT NULL,
+    diff JSONB NOT NULL DEFAULT '[]',
+    previous_total DECIMAL(15, 2) NOT NULL,
+    new_total DECIMAL(15, 2) NOT NULL,
+    required_roles TEXT[] NOT NULL,
+    approvals JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'withdrawn')),
+    requested_by VARCHAR(255) NOT NULL,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decided_by VARCHAR(255),
+    decided_at TIMESTAMP WITH TIME ZONE,
+    decision_notes TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_contract_amendment_requests_contract
+    ON contract_amendment_requests(contract_id, requested_at DESC);
+
+-- One pending amendment per contract
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contract_amendment_requests_pending
+    ON contract_amendment_requests(contract_id)
+    WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS contract_versions (
+    contract_id VARCHAR(32) NOT NULL REFERENCES contracts(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(255) NOT NULL,
+    version INTEGER NOT NULL,
+    amendment_id VARCHAR(32),
+    total_amount DECIMAL(15, 2) NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (contract_id, version)
+);
@@ -0,0 +1,24 @@
+-- Migration: Contract renewal and expiry automation
+-- A lifecycle job expires contracts past their end date and sends renewal
+-- notices at configurable lead times through the obligation reminder feed. A
+-- contract can be renewed once, by a renewal RFQ or a renewal draft contract.
+
+ALTER TABLE contract_obligation_reminders
+    DROP CONSTRAINT IF EXISTS contract_obligation_reminders_obligation_kind_check;
+ALTER TABLE contract_obligation_reminders
+    ADD CONSTRAINT contract_obligation_reminders_obligation_kind_check
+    CHECK (obligation_kind IN ('payment', 'retention', 'delivery', 'renewal'));
+
+CREATE TABLE IF NOT EXISTS contract_renewals (
+    contract_id VARCHAR(32) PRIMARY KEY REFERENCES contracts(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('rfq', 'draft')),
+    rfq_id VARCHAR(32),
+    renewal_contract_id VARCHAR(32) REFERENCES contracts(id) ON DELETE SET NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_contracts_end_date_active
+    ON contracts(end_date)
+    WHERE status IN ('active', 'suspended');
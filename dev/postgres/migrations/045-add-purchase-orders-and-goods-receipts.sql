@@ -0,0 +1,63 @@
+-- Migration: Purchase orders, goods receipts and supplier invoices
+-- Purchase orders call off contract items up to the contract quantities.
+-- Goods receipts record the serial numbers of received units, which are
+-- registered in the installed base, and supplier invoices are matched three
+-- ways against the order and its receipts.
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    po_number VARCHAR(50) NOT NULL,
+    contract_id VARCHAR(32) NOT NULL REFERENCES contracts(id) ON DELETE RESTRICT,
+    contract_number VARCHAR(50) NOT NULL,
+    supplier_id VARCHAR(255) NOT NULL,
+    supplier_name VARCHAR(255) NOT NULL DEFAULT '',
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(30) NOT NULL
+        CHECK (status IN ('open', 'partially_received', 'received', 'closed', 'cancelled')),
+    lines JSONB NOT NULL DEFAULT '[]',
+    total_amount DECIMAL(15, 2) NOT NULL,
+    delivery_date TIMESTAMP WITH TIME ZONE,
+    notes TEXT,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revision INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_contract ON purchase_orders(contract_id, tenant_id);
+
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    purchase_order_id VARCHAR(32) NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    receipt_number VARCHAR(50) NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    received_by VARCHAR(255) NOT NULL,
+    installation_location TEXT,
+    lines JSONB NOT NULL DEFAULT '[]',
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_goods_receipts_purchase_order ON goods_receipts(purchase_order_id);
+
+CREATE TABLE IF NOT EXISTS supplier_invoices (
+    id VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    purchase_order_id VARCHAR(32) NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    supplier_id VARCHAR(255) NOT NULL,
+    invoice_number VARCHAR(100) NOT NULL,
+    invoice_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    lines JSONB NOT NULL DEFAULT '[]',
+    tax_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    total_amount DECIMAL(15, 2) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('matched', 'exception')),
+    match JSONB NOT NULL DEFAULT '[]',
+    recorded_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tenant_id, supplier_id, invoice_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_supplier_invoices_purchase_order ON supplier_invoices(purchase_order_id);
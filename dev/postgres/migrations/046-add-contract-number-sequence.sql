@@ -0,0 +1,14 @@
+-- Migration: Unique contract numbers
+-- Contract numbers were derived from the clock or a per-tenant count, so two
+-- contracts created close together could get the same number. They now take
+-- the next value of a sequence, which never repeats.
+
+CREATE SEQUENCE IF NOT EXISTS contract_number_seq;
+
+CREATE OR REPLACE FUNCTION generate_contract_number(p_tenant_id VARCHAR)
+RETURNS VARCHAR AS $$
+BEGIN
+    -- Generate number: CT-YYYYMMDD-XXXX, the suffix growing past four digits as needed
+    RETURN 'CT-' || TO_CHAR(NOW(), 'YYYYMMDD') || '-' || LPAD(nextval('contract_number_seq')::TEXT, 4, '0');
+END;
+$$ LANGUAGE plpgsql;
@@ -3,11 +3,14 @@
 import (
 	"database/sql"
 	"encoding/json"
+	"log"
 	"net/http"
+	"os"
 	"strconv"
 
 	"github.com/aby-med/medical-platform/internal/ai"
 	"github.com/aby-med/medical-platform/internal/assignment"
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
 	"github.com/gorilla/mux"
 )
 
@@ -19,8 +22,15 @@ type AssignmentHandler struct {
 
 // NewAssignmentHandler creates a new assignment handler
 func NewAssignmentHandler(aiManager *ai.Manager, db *sql.DB) *AssignmentHandler {
+	engine := assignment.NewEngine(aiManager, db)
+	if bands, err := geo.ParseBands(os.Getenv("ASSIGNMENT_RADIUS_BANDS")); err != nil {
+		log.Printf("Invalid ASSIGNMENT_RADIUS_BANDS, using defaults: %v", err)
+	} else {
+		engine.SetRadiusBands(bands)
+	}
+
 	return &AssignmentHandler{
-		engine: assignment.NewEngine(aiManager, db),
+		engine: engine,
 		db:     db,
 	}
 }
@@ -25,6 +25,18 @@ func NewPartsHandler(aiManager *ai.Manager, db *sql.DB) *PartsHandler {
 	}
 }
 
+// SetCompatibility checks recommended parts against the spare parts
+// compatibility graph, dropping parts that do not fit the unit
+func (h *PartsHandler) SetCompatibility(checker parts.CompatibilityChecker) {
+	h.engine.SetCompatibility(checker)
+}
+
+// SetUsageReader fills the usage counters of recommendations from the
+// meter readings of the unit in the equipment registry
+func (h *PartsHandler) SetUsageReader(reader parts.UsageReader) {
+	h.engine.SetUsageReader(reader)
+}
+
 // RegisterRoutes registers parts routes
 func (h *PartsHandler) RegisterRoutes(r *mux.Router) {
 	r.HandleFunc("/api/parts/recommend", h.RecommendParts).Methods("POST")
@@ -15,10 +15,17 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// PartsCompatibilityImporter records the models an imported part fits and
+// the part numbers it supersedes
+type PartsCompatibilityImporter interface {
+	ImportCompatibility(ctx context.Context, partID string, models, supersedes []string, createdBy string) error
+}
+
 // PartsImportHandler handles parts CSV import
 type PartsImportHandler struct {
-	pool   *pgxpool.Pool
-	logger *slog.Logger
+	pool          *pgxpool.Pool
+	compatibility PartsCompatibilityImporter
+	logger        *slog.Logger
 }
 
 // NewPartsImportHandler creates a new parts import handler
@@ -29,6 +36,13 @@ func NewPartsImportHandler(pool *pgxpool.Pool, logger *slog.Logger) *PartsImport
 	}
 }
 
+// SetCompatibility enables the optional compatible_models (column 17) and
+// supersedes (column 18) CSV columns. Both are semicolon-separated; a model
+// may carry a serial range as MODEL:FROM..TO.
+func (h *PartsImportHandler) SetCompatibility(importer PartsCompatibilityImporter) {
+	h.compatibility = importer
+}
+
 // PartCSVRow represents a row from parts CSV import
 type PartCSVRow struct {
 	PartNumber       string
@@ -47,6 +61,8 @@ type PartCSVRow struct {
 	Dimensions       string
 	WarrantyMonths   int
 	Specifications   string
+	CompatibleModels []string
+	Supersedes       []string
 }
 
 // PartsImportResult represents the result of parts import
@@ -95,6 +111,16 @@ func (h *PartsImportHandler) ImportParts(w http.ResponseWriter, r *http.Request)
 		ImportedIDs: []string{},
 	}
 
+	// Compatibility is applied once all rows are imported so that rows can
+	// supersede parts further down the file
+	type pendingCompatibility struct {
+		rowNum     int
+		partID     string
+		models     []string
+		supersedes []string
+	}
+	pending := []pendingCompatibility{}
+
 	rowNum := 1
 	for {
 		row, err := reader.Read()
@@ -117,7 +143,7 @@ func (h *PartsImportHandler) ImportParts(w http.ResponseWriter, r *http.Request)
 		}
 
 		// Create part from CSV row
-		partID, err := h.createPartFromCSV(ctx, row)
+		partID, csvRow, err := h.createPartFromCSV(ctx, row)
 		if err != nil {
 			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: %v", rowNum, err))
 			result.FailureCount++
@@ -127,9 +153,22 @@ func (h *PartsImportHandler) ImportParts(w http.ResponseWriter, r *http.Request)
 
 		result.ImportedIDs = append(result.ImportedIDs, partID)
 		result.SuccessCount++
+		if len(csvRow.CompatibleModels) > 0 || len(csvRow.Supersedes) > 0 {
+			pending = append(pending, pendingCompatibility{rowNum, partID, csvRow.CompatibleModels, csvRow.Supersedes})
+		}
 		rowNum++
 	}
 
+	for _, p := range pending {
+		if h.compatibility == nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: compatibility columns ignored: not configured", p.rowNum))
+			continue
+		}
+		if err := h.compatibility.ImportCompatibility(ctx, p.partID, p.models, p.supersedes, "parts_import"); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: compatibility: %v", p.rowNum, err))
+		}
+	}
+
 	h.logger.Info("Parts import complete",
 		slog.Int("success", result.SuccessCount),
 		slog.Int("failures", result.FailureCount))
@@ -137,11 +176,11 @@ func (h *PartsImportHandler) ImportParts(w http.ResponseWriter, r *http.Request)
 	h.respondJSON(w, http.StatusOK, result)
 }
 
-func (h *PartsImportHandler) createPartFromCSV(ctx context.Context, row []string) (string, error) {
+func (h *PartsImportHandler) createPartFromCSV(ctx context.Context, row []string) (string, *PartCSVRow, error) {
 	// Parse CSV row
 	csvRow, err := h.parseCSVRow(row)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	partID := uuid.New().String()
@@ -192,7 +231,7 @@ func (h *PartsImportHandler) createPartFromCSV(ctx context.Context, row []string
 	).Scan(&partID)
 
 	if err != nil {
-		return "", fmt.Errorf("failed to insert part: %w", err)
+		return "", nil, fmt.Errorf("failed to insert part: %w", err)
 	}
 
 	h.logger.Info("Part created",
@@ -200,7 +239,7 @@ func (h *PartsImportHandler) createPartFromCSV(ctx context.Context, row []string
 		slog.String("part_number", csvRow.PartNumber),
 		slog.String("part_name", csvRow.PartName))
 
-	return partID, nil
+	return partID, csvRow, nil
 }
 
 func (h *PartsImportHandler) parseCSVRow(row []string) (*PartCSVRow, error) {
@@ -261,9 +300,25 @@ func (h *PartsImportHandler) parseCSVRow(row []string) (*PartCSVRow, error) {
 		Dimensions:       row[13],
 		WarrantyMonths:   warrantyMonths,
 		Specifications:   row[15],
+		CompatibleModels: splitCSVList(row, 16),
+		Supersedes:       splitCSVList(row, 17),
 	}, nil
 }
 
+// splitCSVList splits an optional semicolon-separated column
+func splitCSVList(row []string, column int) []string {
+	if len(row) <= column {
+		return nil
+	}
+	values := []string{}
+	for _, value := range strings.Split(row[column], ";") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func (h *PartsImportHandler) respondJSON(w http.ResponseWriter, code int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
@@ -5,10 +5,12 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"math"
 	"sort"
 	"time"
 
 	"github.com/aby-med/medical-platform/internal/ai"
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
 	"github.com/google/uuid"
 )
 
@@ -30,6 +32,11 @@ func NewEngine(aiManager *ai.Manager, db *sql.DB) *Engine {
 	}
 }
 
+// SetRadiusBands configures the distance bands used for location scoring
+func (e *Engine) SetRadiusBands(bands geo.Bands) {
+	e.scorer.SetRadiusBands(bands)
+}
+
 // RecommendEngineers returns ranked engineer recommendations for a ticket
 func (e *Engine) RecommendEngineers(ctx context.Context, req *AssignmentRequest) (*AssignmentResponse, error) {
 	startTime := time.Now()
@@ -45,6 +52,9 @@ func (e *Engine) RecommendEngineers(ctx context.Context, req *AssignmentRequest)
 	// Generate request ID
 	requestID := uuid.New().String()
 
+	// Locate the installation site for distance scoring
+	e.resolveSiteLocation(ctx, req)
+
 	// Step 1: Load available engineers
 	engineers, err := e.loadEngineers(ctx, req)
 	if err != nil {
@@ -164,13 +174,20 @@ func (e *Engine) scoreAndBuildRecommendation(ctx context.Context, engineer *Engi
 		Warnings:              warnings,
 		CurrentWorkload:       engineer.OpenTicketsCount,
 		AvailabilityInfo:      &availInfo,
-		CurrentLocation:       engineer.CurrentLocation,
 		MatchingSkills:        matchingSkills,
 		MissingSkills:         missingSkills,
 		AverageResolutionTime: &engineer.AverageResolutionTime,
 		SuccessRate:           &engineer.SuccessRate,
 		RecentTicketsCount:    len(engineer.RecentTickets),
 	}
+	if origin, _, ok := travelOrigin(engineer, time.Now()); ok {
+		location := origin.String()
+		rec.CurrentLocation = &location
+		if req.SiteLocation != nil && req.SiteLocation.Valid() {
+			distanceKm := math.Round(geo.DistanceKm(origin, *req.SiteLocation)*10) / 10
+			rec.DistanceKM = &distanceKm
+		}
+	}
 
 	return rec, nil
 }
@@ -475,6 +492,26 @@ func (e *Engine) enrichEngineerProfile(ctx context.Context, engineer *EngineerPr
 		}
 	}
 
+	// Load base and current coordinates from the engineers directory (matched by email)
+	var baseLat, baseLng, currentLat, currentLng *float64
+	var currentAt *time.Time
+	locationQuery := `
+		SELECT base_latitude, base_longitude, current_latitude, current_longitude, current_location_at
+		FROM engineers
+		WHERE LOWER(email) = LOWER($1)
+		LIMIT 1
+	`
+	err = e.db.QueryRowContext(ctx, locationQuery, engineer.Email).Scan(&baseLat, &baseLng, &currentLat, &currentLng, &currentAt)
+	if err == nil {
+		if baseLat != nil && baseLng != nil {
+			engineer.BaseLocation = &geo.Point{Lat: *baseLat, Lng: *baseLng}
+		}
+		if currentLat != nil && currentLng != nil {
+			engineer.CurrentPosition = &geo.Point{Lat: *currentLat, Lng: *currentLng}
+			engineer.CurrentPositionAt = currentAt
+		}
+	}
+
 	// Set default availability (in production, track this in database)
 	engineer.AvailabilityStatus = AvailabilityAvailable
 	if engineer.OpenTicketsCount > 5 {
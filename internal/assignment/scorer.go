@@ -6,21 +6,30 @@ import (
 	"fmt"
 	"math"
 	"strings"
-	
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
 )
 
 // Scorer calculates engineer matching scores
 type Scorer struct {
-	db *sql.DB
+	db    *sql.DB
+	bands geo.Bands
 }
 
 // NewScorer creates a new scorer
 func NewScorer(db *sql.DB) *Scorer {
 	return &Scorer{
-		db: db,
+		db:    db,
+		bands: geo.DefaultBands(),
 	}
 }
 
+// SetRadiusBands configures the distance bands used for location scoring
+func (s *Scorer) SetRadiusBands(bands geo.Bands) {
+	s.bands = bands
+}
+
 // ScoreEngineer calculates overall score for an engineer
 func (s *Scorer) ScoreEngineer(ctx context.Context, engineer *EngineerProfile, req *AssignmentRequest) (*ScoreBreakdown, []MatchReason, []string, error) {
 	breakdown := &ScoreBreakdown{
@@ -166,50 +175,46 @@ func (s *Scorer) calculateExpertiseScore(engineer *EngineerProfile, req *Assignm
 	return score, reasons
 }
 
-// calculateLocationScore scores based on proximity to ticket location
+// travelOrigin returns the engineer's current (or base) location and its source
+func travelOrigin(engineer *EngineerProfile, now time.Time) (geo.Point, string, bool) {
+	return geo.TravelOrigin(engineer.BaseLocation, engineer.CurrentPosition, engineer.CurrentPositionAt, now, geo.CurrentLocationMaxAge)
+}
+
+// calculateLocationScore scores based on travel distance to the ticket site
 func (s *Scorer) calculateLocationScore(engineer *EngineerProfile, req *AssignmentRequest) (float64, []MatchReason, []string) {
 	var reasons []MatchReason
 	var warnings []string
 
-	// If no location info, return neutral score
-	if engineer.CurrentLocation == nil {
-		return 50.0, reasons, []string{"Engineer location unknown"}
+	// If no coordinates are known, return neutral score
+	if req.SiteLocation == nil || !req.SiteLocation.Valid() {
+		return 50.0, reasons, []string{"Ticket site has no coordinates"}
 	}
-
-	// Simple location matching (in production, use actual distance calculation)
-	// For now, check if locations match or are nearby
-
-	if strings.Contains(strings.ToLower(*engineer.CurrentLocation), strings.ToLower(req.LocationName)) {
-		reasons = append(reasons, MatchReason{
-			Category: CategoryLocation,
-			Reason:   "Currently at same location",
-			Impact:   ImpactHigh,
-			Evidence: fmt.Sprintf("Location: %s", *engineer.CurrentLocation),
-		})
-		return 100.0, reasons, warnings
+	origin, source, ok := travelOrigin(engineer, time.Now())
+	if !ok {
+		return 50.0, reasons, []string{"Engineer location unknown"}
 	}
-
-	// Check if in same building/area (simplified)
-	if strings.Contains(strings.ToLower(req.LocationName), "icu") &&
-		strings.Contains(strings.ToLower(*engineer.CurrentLocation), "icu") {
-		reasons = append(reasons, MatchReason{
-			Category: CategoryLocation,
-			Reason:   "In same department area",
-			Impact:   ImpactMedium,
-			Evidence: "ICU area",
-		})
-		return 80.0, reasons, warnings
+	distance := geo.DistanceKm(origin, *req.SiteLocation)
+
+	band, inRange := s.bands.Classify(distance)
+	impact := ImpactLow
+	switch {
+	case band.Score >= 75:
+		impact = ImpactHigh
+	case band.Score >= 50:
+		impact = ImpactMedium
 	}
 
-	// Different location - lower score but not critical
 	reasons = append(reasons, MatchReason{
 		Category: CategoryLocation,
-		Reason:   "At different location, travel time required",
-		Impact:   ImpactMedium,
-		Evidence: fmt.Sprintf("Current: %s, Ticket: %s", *engineer.CurrentLocation, req.LocationName),
+		Reason:   fmt.Sprintf("%.1f km from site (%s)", distance, band.Name),
+		Impact:   impact,
+		Evidence: fmt.Sprintf("Measured from %s location, about %d min travel", source, geo.TravelMinutes(distance, 0)),
 	})
+	if !inRange {
+		warnings = append(warnings, fmt.Sprintf("Engineer is %.0f km from site, beyond the configured service radius", distance))
+	}
 
-	return 40.0, reasons, warnings
+	return band.Score, reasons, warnings
 }
 
 // calculatePerformanceScore scores based on historical performance
@@ -0,0 +1,60 @@
+package assignment
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
+)
+
+// resolveSiteLocation fills the site coordinates from the installation
+// address of the unit on the service ticket when the request has none
+func (e *Engine) resolveSiteLocation(ctx context.Context, req *AssignmentRequest) {
+	if req.SiteLocation != nil && req.SiteLocation.Valid() {
+		return
+	}
+	if req.ServiceTicketID == nil || *req.ServiceTicketID == "" {
+		return
+	}
+
+	var address []byte
+	err := e.db.QueryRowContext(ctx, `
+		SELECT COALESCE(er.installation_address, '{}'::jsonb)
+		FROM service_tickets st
+		JOIN equipment_registry er ON er.id = st.equipment_id
+		WHERE st.id = $1 OR st.ticket_number = $1
+		LIMIT 1
+	`, *req.ServiceTicketID).Scan(&address)
+	if err != nil {
+		return
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(address, &fields); err != nil {
+		return
+	}
+	lat, okLat := addressCoordinate(fields, "latitude", "lat")
+	lng, okLng := addressCoordinate(fields, "longitude", "lng", "lon")
+	if !okLat || !okLng {
+		return
+	}
+	if site := (geo.Point{Lat: lat, Lng: lng}); site.Valid() {
+		req.SiteLocation = &site
+	}
+}
+
+// addressCoordinate reads the first numeric value among the given keys
+func addressCoordinate(address map[string]interface{}, keys ...string) (float64, bool) {
+	for _, key := range keys {
+		switch v := address[key].(type) {
+		case float64:
+			return v, true
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, true
+			}
+		}
+	}
+	return 0, false
+}
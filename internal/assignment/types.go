@@ -3,6 +3,8 @@ package assignment
 
 import (
 	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
 )
 
 // AssignmentRequest represents a request for engineer recommendations
@@ -10,6 +12,9 @@ type AssignmentRequest struct {
 	// TicketID to assign
 	TicketID int64
 
+	// ServiceTicketID of the service ticket, used to find the installation site
+	ServiceTicketID *string
+
 	// Equipment information
 	EquipmentTypeID int64
 	EquipmentType   string
@@ -22,6 +27,7 @@ type AssignmentRequest struct {
 	Description  string
 	LocationID   int64
 	LocationName string
+	SiteLocation *geo.Point // Coordinates of the installation site
 
 	// Requirements
 	RequiredSkills      []string
@@ -222,7 +228,9 @@ type EngineerProfile struct {
 	CurrentWorkload   int
 	OpenTicketsCount  int
 	AvailabilityStatus string // Available, Busy, OnLeave, etc.
-	CurrentLocation    *string
+	BaseLocation       *geo.Point
+	CurrentPosition    *geo.Point
+	CurrentPositionAt  *time.Time
 
 	// Recent activity
 	LastTicketDate     *time.Time
@@ -0,0 +1,118 @@
+package parts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrIncompatiblePart is returned by a CompatibilityChecker when neither the
+// part nor any of its replacements fits the unit
+var ErrIncompatiblePart = errors.New("part does not fit the equipment")
+
+// PartSelection is the part to fit in place of a recommended part
+type PartSelection struct {
+	PartNumber string
+	PartName   string
+	Superseded []string // Part numbers replaced along the chain, oldest first
+	Alternate  bool     // Substituted because the requested line does not fit
+	Confirmed  bool     // Fitment recorded for the unit's model
+}
+
+// CompatibilityChecker picks the part to fit on a unit for a recommended
+// part number, following supersessions and alternates. The unit is given by
+// its registry ID or else its model number; either may be empty.
+type CompatibilityChecker interface {
+	SelectPart(ctx context.Context, partNumber, equipmentID, modelNumber string) (*PartSelection, error)
+}
+
+// SetCompatibility enables checking recommended parts against the spare
+// parts compatibility graph
+func (e *Engine) SetCompatibility(checker CompatibilityChecker) {
+	e.compatibility = checker
+}
+
+// applyCompatibility checks recommended parts against the compatibility graph
+// of the spare parts catalog. Parts that do not fit the unit are dropped,
+// superseded parts are replaced by their current part and obsolete parts by
+// a fitting alternate. Parts the checker cannot resolve are kept as they are.
+func (e *Engine) applyCompatibility(ctx context.Context, req *RecommendationRequest, parts []PartRecommendation) []PartRecommendation {
+	if e.compatibility == nil || len(parts) == 0 {
+		return parts
+	}
+	var equipmentID, modelNumber string
+	if req.RegistryEquipmentID != nil {
+		equipmentID = *req.RegistryEquipmentID
+	}
+	if req.ModelNumber != nil {
+		modelNumber = *req.ModelNumber
+	}
+
+	result := []PartRecommendation{}
+	for _, part := range parts {
+		selection, err := e.compatibility.SelectPart(ctx, part.PartNumber, equipmentID, modelNumber)
+		if errors.Is(err, ErrIncompatiblePart) {
+			continue
+		}
+		if err != nil {
+			result = append(result, part)
+			continue
+		}
+
+		if selection.PartNumber != part.PartNumber {
+			requested := part.PartNumber
+			part.PartNumber = selection.PartNumber
+			part.PartName = selection.PartName
+			if legacyID, err := e.legacyPartID(ctx, selection.PartNumber); err == nil {
+				part.PartID = legacyID
+			}
+			note := fmt.Sprintf("Supersedes %s", strings.Join(selection.Superseded, ", "))
+			if selection.Alternate {
+				note = fmt.Sprintf("Alternate for %s", requested)
+			}
+			part.Evidence = append(part.Evidence, note)
+			part.CompatibilityNotes = &note
+		}
+		if selection.Confirmed && part.CompatibilityNotes == nil {
+			note := "Fitment confirmed for the equipment model"
+			part.CompatibilityNotes = &note
+		}
+		result = append(result, part)
+	}
+
+	return mergeByPartNumber(result)
+}
+
+// legacyPartID finds the parts catalog ID of a part number, for supplier pricing
+func (e *Engine) legacyPartID(ctx context.Context, partNumber string) (int64, error) {
+	var id int64
+	err := e.db.QueryRowContext(ctx, `SELECT part_id FROM parts_catalog WHERE part_number = $1 LIMIT 1`, partNumber).Scan(&id)
+	return id, err
+}
+
+// mergeByPartNumber merges recommendations resolved to the same part,
+// keeping the higher confidence and combining evidence
+func mergeByPartNumber(parts []PartRecommendation) []PartRecommendation {
+	index := map[string]int{}
+	result := []PartRecommendation{}
+	for _, part := range parts {
+		i, found := index[part.PartNumber]
+		if !found {
+			index[part.PartNumber] = len(result)
+			result = append(result, part)
+			continue
+		}
+		existing := &result[i]
+		if part.Confidence > existing.Confidence {
+			existing.Confidence = part.Confidence
+			existing.ReasonCode = part.ReasonCode
+			existing.ReasonText = part.ReasonText
+		}
+		existing.Evidence = append(existing.Evidence, part.Evidence...)
+		if existing.CompatibilityNotes == nil {
+			existing.CompatibilityNotes = part.CompatibilityNotes
+		}
+	}
+	return result
+}
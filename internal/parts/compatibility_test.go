@@ -0,0 +1,62 @@
+package parts
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeChecker struct {
+	selections map[string]*PartSelection
+	calls      []string
+}
+
+func (f *fakeChecker) SelectPart(ctx context.Context, partNumber, equipmentID, modelNumber string) (*PartSelection, error) {
+	f.calls = append(f.calls, partNumber+"@"+equipmentID+"/"+modelNumber)
+	selection, ok := f.selections[partNumber]
+	if !ok {
+		return nil, errors.New("unknown part")
+	}
+	if selection == nil {
+		return nil, ErrIncompatiblePart
+	}
+	return selection, nil
+}
+
+func TestApplyCompatibilityDropsIncompatibleParts(t *testing.T) {
+	checker := &fakeChecker{selections: map[string]*PartSelection{
+		"TUBE-64":  {PartNumber: "TUBE-64", PartName: "X-ray tube", Confirmed: true},
+		"TUBE-128": nil,
+	}}
+	engine := NewEngine(nil, nil)
+	engine.SetCompatibility(checker)
+
+	equipmentID := "unit-1"
+	req := &RecommendationRequest{RegistryEquipmentID: &equipmentID}
+	got := engine.applyCompatibility(context.Background(), req, []PartRecommendation{
+		{PartNumber: "TUBE-64", Confidence: 0.9},
+		{PartNumber: "TUBE-128", Confidence: 0.8},
+		{PartNumber: "CABLE", Confidence: 0.5},
+	})
+
+	if len(got) != 2 || got[0].PartNumber != "TUBE-64" || got[1].PartNumber != "CABLE" {
+		t.Fatalf("expected TUBE-64 and the unresolved CABLE, got %+v", got)
+	}
+	if got[0].CompatibilityNotes == nil {
+		t.Errorf("expected a confirmed fitment note on TUBE-64")
+	}
+	if got[1].CompatibilityNotes != nil {
+		t.Errorf("expected no note on a part the checker cannot resolve, got %q", *got[1].CompatibilityNotes)
+	}
+	if len(checker.calls) != 3 || checker.calls[1] != "TUBE-128@unit-1/" {
+		t.Errorf("expected every part checked against the unit, got %v", checker.calls)
+	}
+}
+
+func TestApplyCompatibilityWithoutChecker(t *testing.T) {
+	engine := NewEngine(nil, nil)
+	parts := []PartRecommendation{{PartNumber: "TUBE-128"}}
+	if got := engine.applyCompatibility(context.Background(), &RecommendationRequest{}, parts); len(got) != 1 {
+		t.Fatalf("expected parts unchanged without a checker, got %+v", got)
+	}
+}
@@ -17,8 +17,10 @@ const partsEngineVersion = "1.0.0"
 
 // Engine handles intelligent parts recommendations
 type Engine struct {
-	aiManager *ai.Manager
-	db        *sql.DB
+	aiManager     *ai.Manager
+	db            *sql.DB
+	compatibility CompatibilityChecker
+	usage         UsageReader
 }
 
 // NewEngine creates a new parts recommendation engine
@@ -46,6 +48,10 @@ func (e *Engine) RecommendParts(ctx context.Context, req *RecommendationRequest)
 		CreatedAt: time.Now(),
 	}
 
+	// Fill usage counters from the installed unit's meters when not provided
+	e.fillRegistryEquipment(ctx, req)
+	e.fillUsageFromMeters(ctx, req)
+
 	// Step 1: Get replacement parts (if diagnosis/problem indicates)
 	if req.Options.IncludeReplacementParts {
 		parts, err := e.getReplacementParts(ctx, req)
@@ -77,7 +83,7 @@ func (e *Engine) RecommendParts(ctx context.Context, req *RecommendationRequest)
 	}
 
 	// Step 4: Use AI to refine recommendations (if enabled)
-	if req.Options.UseAI && len(response.ReplacementParts) > 0 {
+	if req.Options.UseAI && e.aiManager != nil && len(response.ReplacementParts) > 0 {
 		refined, cost, provider, model, err := e.aiRefineRecommendations(ctx, req, response)
 		if err == nil && refined != nil {
 			response.ReplacementParts = refined
@@ -129,6 +135,9 @@ func (e *Engine) getReplacementParts(ctx context.Context, req *RecommendationReq
 	// Deduplicate and merge
 	parts = e.deduplicateParts(parts)
 
+	// Keep compatible parts only, resolving superseded part numbers
+	parts = e.applyCompatibility(ctx, req, parts)
+
 	// Enrich with inventory and pricing
 	if req.Options.CheckInventory || req.Options.IncludePricing {
 		for i := range parts {
@@ -486,7 +495,7 @@ func (e *Engine) getPreventiveParts(ctx context.Context, req *RecommendationRequ
 		reason := ""
 
 		// Check time-based interval
-		if interval.Valid {
+		if interval.Valid && req.LastMaintenanceDate != nil {
 			// Parse interval (simplified - in production use proper interval parsing)
 			if strings.Contains(interval.String, "6 months") {
 				if time.Since(*req.LastMaintenanceDate) > 6*30*24*time.Hour {
@@ -522,7 +531,7 @@ func (e *Engine) getPreventiveParts(ctx context.Context, req *RecommendationRequ
 		}
 	}
 
-	return parts, nil
+	return e.applyCompatibility(ctx, req, parts), nil
 }
 
 // getAccessories gets accessories for upselling based on variant
@@ -729,15 +738,16 @@ func (e *Engine) deduplicateParts(parts []PartRecommendation) []PartRecommendati
 }
 
 func (e *Engine) enrichPartWithInventoryAndPricing(ctx context.Context, part *PartRecommendation, opts RecommendationOptions) {
-	// Get inventory
+	// Get inventory: stock not reserved for tickets across active warehouses and vans
 	if opts.CheckInventory {
 		var qtyAvailable sql.NullInt64
 		err := e.db.QueryRowContext(ctx, `
-			SELECT quantity_available 
-			FROM parts_inventory 
-			WHERE part_id = $1 AND is_active = true
-			LIMIT 1
-		`, part.PartID).Scan(&qtyAvailable)
+			SELECT SUM(ps.on_hand - ps.reserved)
+			FROM part_stock ps
+			JOIN spare_parts_catalog sp ON sp.id = ps.spare_part_id
+			JOIN stock_locations sl ON sl.id = ps.location_id AND sl.active = true
+			WHERE sp.part_number = $1
+		`, part.PartNumber).Scan(&qtyAvailable)
 
 		if err == nil && qtyAvailable.Valid {
 			part.QuantityAvailable = int(qtyAvailable.Int64)
@@ -8,62 +8,70 @@ import (
 // RecommendationRequest represents a request for parts recommendations
 type RecommendationRequest struct {
 	// TicketID for context
-	TicketID int64
+	TicketID int64 `json:"ticket_id"`
 
 	// Equipment information
-	EquipmentID     *int64 // Specific equipment instance
-	EquipmentTypeID int64
-	EquipmentType   string
-	VariantID       *int64 // Installation variant
-	VariantName     *string
-	Manufacturer    *string
-	ModelNumber     *string
+	EquipmentID     *int64  `json:"equipment_id,omitempty"` // Specific equipment instance
+	EquipmentTypeID int64   `json:"equipment_type_id"`
+	EquipmentType   string  `json:"equipment_type"`
+	VariantID       *int64  `json:"variant_id,omitempty"` // Installation variant
+	VariantName     *string `json:"variant_name,omitempty"`
+	Manufacturer    *string `json:"manufacturer,omitempty"`
+	ModelNumber     *string `json:"model_number,omitempty"`
 
 	// Problem context
-	ProblemType        string
-	ProblemDescription string
-	Severity           string
+	ProblemType        string `json:"problem_type"`
+	ProblemDescription string `json:"problem_description"`
+	Severity           string `json:"severity"`
 
 	// Diagnosis context (if available)
-	DiagnosisID         *string
-	DiagnosisConfidence *float64
-	IdentifiedIssues    []string
-	RecommendedActions  []string
+	DiagnosisID         *string  `json:"diagnosis_id,omitempty"`
+	DiagnosisConfidence *float64 `json:"diagnosis_confidence,omitempty"`
+	IdentifiedIssues    []string `json:"identified_issues,omitempty"`
+	RecommendedActions  []string `json:"recommended_actions,omitempty"`
 
 	// Equipment history
-	LastMaintenanceDate *time.Time
-	OperatingHours      *int
-	TotalCycles         *int
+	LastMaintenanceDate *time.Time `json:"last_maintenance_date,omitempty"`
+	OperatingHours      *int       `json:"operating_hours,omitempty"`
+	TotalCycles         *int       `json:"total_cycles,omitempty"`
+
+	// RegistryEquipmentID links to the installed unit in the equipment registry;
+	// when set, nil OperatingHours/TotalCycles are filled from its meter readings
+	RegistryEquipmentID *string `json:"registry_equipment_id,omitempty"`
+
+	// ServiceTicketID is the service ticket being worked; the unit on the
+	// ticket takes precedence over RegistryEquipmentID
+	ServiceTicketID *string `json:"service_ticket_id,omitempty"`
 
 	// Request options
-	Options RecommendationOptions
+	Options RecommendationOptions `json:"options"`
 }
 
 // RecommendationOptions controls recommendation behavior
 type RecommendationOptions struct {
 	// IncludeReplacementParts whether to recommend replacement parts
-	IncludeReplacementParts bool
+	IncludeReplacementParts bool `json:"include_replacement_parts"`
 
 	// IncludeAccessories whether to show accessories for upselling
-	IncludeAccessories bool
+	IncludeAccessories bool `json:"include_accessories"`
 
 	// IncludePreventiveParts parts due for replacement based on intervals
-	IncludePreventiveParts bool
+	IncludePreventiveParts bool `json:"include_preventive_parts"`
 
 	// CheckInventory whether to check stock availability
-	CheckInventory bool
+	CheckInventory bool `json:"check_inventory"`
 
 	// IncludePricing whether to include pricing information
-	IncludePricing bool
+	IncludePricing bool `json:"include_pricing"`
 
 	// UseAI whether to use AI for recommendations
-	UseAI bool
+	UseAI bool `json:"use_ai"`
 
 	// MaxRecommendations maximum parts to return
-	MaxRecommendations int
+	MaxRecommendations int `json:"max_recommendations"`
 
 	// MinConfidence minimum confidence threshold (0-100)
-	MinConfidence float64
+	MinConfidence float64 `json:"min_confidence"`
 }
 
 // RecommendationResponse represents parts recommendations
@@ -0,0 +1,61 @@
+package parts
+
+import "context"
+
+// Usage holds the usage counters of an installed unit; nil when not metered
+type Usage struct {
+	OperatingHours *int
+	TotalCycles    *int
+}
+
+// UsageReader reads installed units and their meter readings from the
+// equipment registry
+type UsageReader interface {
+	// TicketEquipment returns the registry unit of a service ticket, given by
+	// ID or ticket number; empty when the ticket has no unit
+	TicketEquipment(ctx context.Context, ticketRef string) (string, error)
+
+	// EquipmentUsage returns the latest usage counters of a registry unit
+	EquipmentUsage(ctx context.Context, equipmentID string) (*Usage, error)
+}
+
+// SetUsageReader enables filling usage counters from the equipment registry
+func (e *Engine) SetUsageReader(reader UsageReader) {
+	e.usage = reader
+}
+
+// fillRegistryEquipment sets RegistryEquipmentID to the unit on the service
+// ticket, if any
+func (e *Engine) fillRegistryEquipment(ctx context.Context, req *RecommendationRequest) {
+	if e.usage == nil || req.ServiceTicketID == nil || *req.ServiceTicketID == "" {
+		return
+	}
+
+	equipmentID, err := e.usage.TicketEquipment(ctx, *req.ServiceTicketID)
+	if err != nil || equipmentID == "" {
+		return
+	}
+	req.RegistryEquipmentID = &equipmentID
+}
+
+// fillUsageFromMeters populates OperatingHours and TotalCycles from the latest
+// meter readings of the installed unit. Explicit request values take precedence.
+func (e *Engine) fillUsageFromMeters(ctx context.Context, req *RecommendationRequest) {
+	if e.usage == nil || req.RegistryEquipmentID == nil || *req.RegistryEquipmentID == "" {
+		return
+	}
+	if req.OperatingHours != nil && req.TotalCycles != nil {
+		return
+	}
+
+	usage, err := e.usage.EquipmentUsage(ctx, *req.RegistryEquipmentID)
+	if err != nil {
+		return
+	}
+	if req.OperatingHours == nil {
+		req.OperatingHours = usage.OperatingHours
+	}
+	if req.TotalCycles == nil {
+		req.TotalCycles = usage.TotalCycles
+	}
+}
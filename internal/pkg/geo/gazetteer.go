@@ -0,0 +1,280 @@
+package geo
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PostalPlace is a gazetteer entry mapping a postal code to coordinates
+type PostalPlace struct {
+	Country    string `json:"country"` // ISO 3166-1 alpha-2, e.g. "IN"
+	PostalCode string `json:"postal_code"`
+	PlaceName  string `json:"place_name,omitempty"`
+	Region     string `json:"region,omitempty"`   // State or province
+	District   string `json:"district,omitempty"` // County or district
+	Point      Point  `json:"point"`
+}
+
+// Key returns the lookup key of the place
+func (p PostalPlace) Key() string {
+	return strings.ToUpper(p.Country) + "|" + NormalizePostalCode(p.PostalCode)
+}
+
+var postalCodeCleaner = regexp.MustCompile(`[\s\-]+`)
+
+// NormalizePostalCode uppercases a postal code and removes spaces and dashes
+func NormalizePostalCode(code string) string {
+	return postalCodeCleaner.ReplaceAllString(strings.ToUpper(strings.TrimSpace(code)), "")
+}
+
+// Gazetteer geocodes postal codes offline. Postal codes shared by several
+// places (e.g. an Indian PIN served by several post offices) resolve to the
+// centroid of those places.
+type Gazetteer struct {
+	byKey    map[string]PostalPlace
+	byPostal map[string][]string // normalized postal code -> keys, for lookups without country
+}
+
+// NewGazetteer builds a gazetteer from places
+func NewGazetteer(places []PostalPlace) *Gazetteer {
+	type acc struct {
+		place    PostalPlace
+		lat, lng float64
+		n        int
+	}
+	merged := map[string]*acc{}
+	order := []string{}
+	for _, p := range places {
+		if !p.Point.Valid() || NormalizePostalCode(p.PostalCode) == "" {
+			continue
+		}
+		key := p.Key()
+		a, ok := merged[key]
+		if !ok {
+			a = &acc{place: p}
+			merged[key] = a
+			order = append(order, key)
+		}
+		a.lat += p.Point.Lat
+		a.lng += p.Point.Lng
+		a.n++
+	}
+
+	g := &Gazetteer{byKey: make(map[string]PostalPlace, len(merged)), byPostal: map[string][]string{}}
+	for _, key := range order {
+		a := merged[key]
+		place := a.place
+		place.Country = strings.ToUpper(place.Country)
+		place.PostalCode = NormalizePostalCode(place.PostalCode)
+		place.Point = Point{Lat: a.lat / float64(a.n), Lng: a.lng / float64(a.n)}
+		g.byKey[key] = place
+		g.byPostal[place.PostalCode] = append(g.byPostal[place.PostalCode], key)
+	}
+	return g
+}
+
+// Len returns the number of distinct postal codes
+func (g *Gazetteer) Len() int {
+	if g == nil {
+		return 0
+	}
+	return len(g.byKey)
+}
+
+// Lookup finds a postal code. Without a country the code must be unambiguous.
+func (g *Gazetteer) Lookup(country, postalCode string) (PostalPlace, bool) {
+	if g == nil {
+		return PostalPlace{}, false
+	}
+	code := NormalizePostalCode(postalCode)
+	if country = strings.ToUpper(strings.TrimSpace(country)); len(country) == 2 {
+		place, ok := g.byKey[country+"|"+code]
+		return place, ok
+	}
+	keys := g.byPostal[code]
+	if len(keys) != 1 {
+		return PostalPlace{}, false
+	}
+	return g.byKey[keys[0]], true
+}
+
+// addressPostalKeys are installation address keys that may hold a postal code
+var addressPostalKeys = []string{"postal_code", "postalcode", "pincode", "pin_code", "pin", "zip", "zip_code", "zipcode", "postcode"}
+
+// addressCountryKeys are installation address keys that may hold a country code
+var addressCountryKeys = []string{"country_code", "country"}
+
+// postalCodeInText finds Indian PIN codes (6 digits) or 5-digit ZIP codes in free text
+var postalCodeInText = regexp.MustCompile(`\b(\d{3}\s?\d{3}|\d{5})\b`)
+
+// GeocodeAddress resolves a structured address, or free text as a fallback, to
+// coordinates through its postal code
+func (g *Gazetteer) GeocodeAddress(address map[string]interface{}, text string) (PostalPlace, bool) {
+	country := ""
+	for _, key := range addressCountryKeys {
+		if v, ok := address[key].(string); ok && len(strings.TrimSpace(v)) == 2 {
+			country = v
+			break
+		}
+	}
+
+	for _, key := range addressPostalKeys {
+		switch v := address[key].(type) {
+		case string:
+			if place, ok := g.Lookup(country, v); ok {
+				return place, true
+			}
+		case float64:
+			if place, ok := g.Lookup(country, strconv.FormatFloat(v, 'f', 0, 64)); ok {
+				return place, true
+			}
+		}
+	}
+
+	for _, match := range postalCodeInText.FindAllString(text, -1) {
+		if place, ok := g.Lookup(country, match); ok {
+			return place, true
+		}
+	}
+	return PostalPlace{}, false
+}
+
+// ParsePostalPlaces reads a gazetteer file. Two formats are accepted:
+//   - GeoNames postal code dumps (tab-separated, no header: country, postal code,
+//     place, admin1 name, admin1 code, admin2 name, admin2 code, admin3 name,
+//     admin3 code, latitude, longitude, accuracy)
+//   - CSV with a header containing postal_code (or pincode/zip), latitude and
+//     longitude, and optionally country, place_name, region and district
+func ParsePostalPlaces(r io.Reader, defaultCountry string) ([]PostalPlace, error) {
+	br := bufio.NewReader(r)
+	first, err := br.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	line := string(first)
+	if i := strings.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+
+	if strings.Count(line, "\t") >= 10 {
+		return parseGeoNames(br)
+	}
+	return parsePostalCSV(br, defaultCountry)
+}
+
+func parseGeoNames(r io.Reader) ([]PostalPlace, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = '\t'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	places := []PostalPlace{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(record) < 11 {
+			continue
+		}
+		lat, errLat := strconv.ParseFloat(strings.TrimSpace(record[9]), 64)
+		lng, errLng := strconv.ParseFloat(strings.TrimSpace(record[10]), 64)
+		if errLat != nil || errLng != nil {
+			continue
+		}
+		places = append(places, PostalPlace{
+			Country:    record[0],
+			PostalCode: record[1],
+			PlaceName:  record[2],
+			Region:     record[3],
+			District:   record[5],
+			Point:      Point{Lat: lat, Lng: lng},
+		})
+	}
+	return places, nil
+}
+
+var postalCSVColumns = map[string][]string{
+	"country":     {"country", "country_code", "countrycode"},
+	"postal_code": {"postal_code", "postalcode", "pincode", "pin_code", "pin", "zip", "zip_code", "postcode"},
+	"place_name":  {"place_name", "placename", "place", "city", "officename", "office_name", "locality"},
+	"region":      {"region", "state", "statename", "state_name", "province"},
+	"district":    {"district", "districtname", "district_name", "county"},
+	"latitude":    {"latitude", "lat"},
+	"longitude":   {"longitude", "lng", "lon", "long"},
+}
+
+func parsePostalCSV(r io.Reader, defaultCountry string) ([]PostalPlace, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gazetteer header: %w", err)
+	}
+
+	index := map[string]int{}
+	for i, h := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		for field, aliases := range postalCSVColumns {
+			for _, alias := range aliases {
+				if name == alias {
+					if _, seen := index[field]; !seen {
+						index[field] = i
+					}
+				}
+			}
+		}
+	}
+	for _, required := range []string{"postal_code", "latitude", "longitude"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("gazetteer is missing a %s column", required)
+		}
+	}
+
+	get := func(record []string, field string) string {
+		i, ok := index[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	places := []PostalPlace{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		lat, errLat := strconv.ParseFloat(get(record, "latitude"), 64)
+		lng, errLng := strconv.ParseFloat(get(record, "longitude"), 64)
+		if errLat != nil || errLng != nil {
+			continue // Rows without coordinates (e.g. "NA") are skipped
+		}
+		country := get(record, "country")
+		if country == "" {
+			country = defaultCountry
+		}
+		places = append(places, PostalPlace{
+			Country:    country,
+			PostalCode: get(record, "postal_code"),
+			PlaceName:  get(record, "place_name"),
+			Region:     get(record, "region"),
+			District:   get(record, "district"),
+			Point:      Point{Lat: lat, Lng: lng},
+		})
+	}
+	return places, nil
+}
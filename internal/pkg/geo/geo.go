@@ -0,0 +1,163 @@
+// Package geo provides coordinates, great-circle distances, configurable radius
+// bands for proximity scoring and offline geocoding from an imported gazetteer.
+package geo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EarthRadiusKm is the mean Earth radius used for haversine distances
+const EarthRadiusKm = 6371.0088
+
+// Point is a WGS84 coordinate
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Valid checks that the point is within coordinate ranges and not the null island
+func (p Point) Valid() bool {
+	if p.Lat == 0 && p.Lng == 0 {
+		return false
+	}
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// String formats the point as "lat,lng"
+func (p Point) String() string {
+	return fmt.Sprintf("%.6f,%.6f", p.Lat, p.Lng)
+}
+
+// DistanceKm returns the great-circle distance between two points using the haversine formula
+func DistanceKm(a, b Point) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := (b.Lat - a.Lat) * math.Pi / 180
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// RoadFactor approximates road distance from straight-line distance
+const RoadFactor = 1.3
+
+// TravelMinutes estimates driving time for a straight-line distance at the given average speed
+func TravelMinutes(distanceKm, speedKmh float64) int {
+	if speedKmh <= 0 {
+		speedKmh = 40
+	}
+	return int(math.Ceil(distanceKm * RoadFactor / speedKmh * 60))
+}
+
+// CurrentLocationMaxAge is how long a reported position is trusted over the base location
+const CurrentLocationMaxAge = 2 * time.Hour
+
+// Travel origin sources
+const (
+	LocationCurrent = "current"
+	LocationBase    = "base"
+)
+
+// TravelOrigin returns the location to measure travel from: the current
+// location when it was reported within maxAge, otherwise the base location
+func TravelOrigin(base, current *Point, currentAt *time.Time, now time.Time, maxAge time.Duration) (Point, string, bool) {
+	if current != nil && current.Valid() && currentAt != nil && now.Sub(*currentAt) <= maxAge {
+		return *current, LocationCurrent, true
+	}
+	if base != nil && base.Valid() {
+		return *base, LocationBase, true
+	}
+	return Point{}, "", false
+}
+
+// RadiusBand scores distances up to MaxKm
+type RadiusBand struct {
+	Name  string  `json:"name"`
+	MaxKm float64 `json:"max_km"`
+	Score float64 `json:"score"` // 0-100
+}
+
+// Bands is a list of radius bands ordered by MaxKm. Distances beyond the last
+// band score OutsideScore.
+type Bands struct {
+	Bands        []RadiusBand `json:"bands"`
+	OutsideScore float64      `json:"outside_score"`
+}
+
+// DefaultBands returns bands suited to field service in a metro area and its hinterland
+func DefaultBands() Bands {
+	return Bands{
+		Bands: []RadiusBand{
+			{Name: "on_site", MaxKm: 2, Score: 100},
+			{Name: "local", MaxKm: 10, Score: 90},
+			{Name: "metro", MaxKm: 30, Score: 75},
+			{Name: "regional", MaxKm: 100, Score: 50},
+			{Name: "extended", MaxKm: 300, Score: 25},
+		},
+		OutsideScore: 5,
+	}
+}
+
+// Classify returns the band a distance falls into; ok is false beyond the last band
+func (b Bands) Classify(distanceKm float64) (RadiusBand, bool) {
+	for _, band := range b.Bands {
+		if distanceKm <= band.MaxKm {
+			return band, true
+		}
+	}
+	return RadiusBand{Name: "outside", MaxKm: math.Inf(1), Score: b.OutsideScore}, false
+}
+
+// Score returns the proximity score (0-100) of a distance
+func (b Bands) Score(distanceKm float64) float64 {
+	band, _ := b.Classify(distanceKm)
+	return band.Score
+}
+
+// ParseBands parses a band specification of the form
+// "name:max_km:score,...[,outside:score]", e.g. "local:10:90,metro:30:75,outside:5".
+// An empty specification returns the default bands.
+func ParseBands(spec string) (Bands, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return DefaultBands(), nil
+	}
+
+	bands := Bands{}
+	for _, part := range strings.Split(spec, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ":")
+		switch {
+		case len(fields) == 2 && strings.EqualFold(fields[0], "outside"):
+			score, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return Bands{}, fmt.Errorf("invalid outside score %q", fields[1])
+			}
+			bands.OutsideScore = score
+		case len(fields) == 3:
+			maxKm, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil || maxKm <= 0 {
+				return Bands{}, fmt.Errorf("invalid radius %q in band %q", fields[1], part)
+			}
+			score, err := strconv.ParseFloat(fields[2], 64)
+			if err != nil || score < 0 || score > 100 {
+				return Bands{}, fmt.Errorf("invalid score %q in band %q", fields[2], part)
+			}
+			bands.Bands = append(bands.Bands, RadiusBand{Name: strings.TrimSpace(fields[0]), MaxKm: maxKm, Score: score})
+		default:
+			return Bands{}, fmt.Errorf("invalid radius band %q: expected name:max_km:score", part)
+		}
+	}
+
+	if len(bands.Bands) == 0 {
+		return Bands{}, fmt.Errorf("radius band specification has no bands")
+	}
+	sort.Slice(bands.Bands, func(i, j int) bool { return bands.Bands[i].MaxKm < bands.Bands[j].MaxKm })
+	return bands, nil
+}
@@ -0,0 +1,96 @@
+package geo
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDistanceKm(t *testing.T) {
+	delhi := Point{Lat: 28.6139, Lng: 77.2090}
+	mumbai := Point{Lat: 19.0760, Lng: 72.8777}
+
+	d := DistanceKm(delhi, mumbai)
+	if math.Abs(d-1153) > 5 {
+		t.Fatalf("DistanceKm(Delhi, Mumbai) = %.1f, want ~1153", d)
+	}
+	if DistanceKm(delhi, delhi) != 0 {
+		t.Fatalf("distance to self must be 0")
+	}
+}
+
+func TestTravelOrigin(t *testing.T) {
+	base := &Point{Lat: 28.6139, Lng: 77.2090}
+	current := &Point{Lat: 28.5355, Lng: 77.3910}
+	now := time.Now()
+	fresh := now.Add(-30 * time.Minute)
+	stale := now.Add(-3 * time.Hour)
+
+	if p, source, ok := TravelOrigin(base, current, &fresh, now, CurrentLocationMaxAge); !ok || source != LocationCurrent || p != *current {
+		t.Fatalf("fresh current location: got %v %q %v", p, source, ok)
+	}
+	if p, source, ok := TravelOrigin(base, current, &stale, now, CurrentLocationMaxAge); !ok || source != LocationBase || p != *base {
+		t.Fatalf("stale current location: got %v %q %v", p, source, ok)
+	}
+	if _, _, ok := TravelOrigin(nil, current, &stale, now, CurrentLocationMaxAge); ok {
+		t.Fatalf("stale current location without base must not be used")
+	}
+}
+
+func TestParseBands(t *testing.T) {
+	bands, err := ParseBands("metro:30:70, local:10:95, outside:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bands.Bands[0].Name != "local" || bands.OutsideScore != 0 {
+		t.Fatalf("unexpected bands: %#v", bands)
+	}
+
+	cases := map[float64]float64{5: 95, 10: 95, 25: 70, 31: 0}
+	for km, want := range cases {
+		if got := bands.Score(km); got != want {
+			t.Errorf("Score(%v) = %v, want %v", km, got, want)
+		}
+	}
+
+	for _, spec := range []string{"local:10", "local:x:90", "local:10:120", "outside:5"} {
+		if _, err := ParseBands(spec); err == nil {
+			t.Errorf("ParseBands(%q) should fail", spec)
+		}
+	}
+}
+
+func TestGazetteer(t *testing.T) {
+	geoNames := "IN\t110001\tConnaught Place\tDelhi\t07\tNew Delhi\t\t\t\t28.6300\t77.2200\t4\n" +
+		"IN\t110001\tParliament Street\tDelhi\t07\tNew Delhi\t\t\t\t28.6200\t77.2100\t4\n" +
+		"IN\t400001\tFort\tMaharashtra\t16\tMumbai\t\t\t\t18.9300\t72.8300\t4\n"
+
+	places, err := ParsePostalPlaces(strings.NewReader(geoNames), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g := NewGazetteer(places)
+	if g.Len() != 2 {
+		t.Fatalf("expected 2 postal codes, got %d", g.Len())
+	}
+
+	place, ok := g.Lookup("in", "110 001")
+	if !ok || math.Abs(place.Point.Lat-28.625) > 1e-9 {
+		t.Fatalf("expected centroid of 110001, got %#v (ok=%v)", place, ok)
+	}
+
+	place, ok = g.GeocodeAddress(map[string]interface{}{"city": "Mumbai"}, "Fort, Mumbai 400 001")
+	if !ok || place.PostalCode != "400001" {
+		t.Fatalf("expected free text geocoding to find 400001, got %#v", place)
+	}
+
+	csvData := "Pincode,OfficeName,StateName,Latitude,Longitude\n560001,Bangalore GPO,Karnataka,12.97,77.59\n560002,Unknown,Karnataka,NA,NA\n"
+	places, err = ParsePostalPlaces(strings.NewReader(csvData), "IN")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(places) != 1 || places[0].Country != "IN" || places[0].PlaceName != "Bangalore GPO" {
+		t.Fatalf("unexpected CSV places: %#v", places)
+	}
+}
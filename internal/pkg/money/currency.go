@@ -0,0 +1,65 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// DefaultCurrency is used where no currency was ever recorded
+const DefaultCurrency = "USD"
+
+// minorUnits holds the ISO 4217 decimal places of the currencies we accept
+var minorUnits = map[string]int32{
+	"AED": 2, "AUD": 2, "BDT": 2, "BHD": 3, "BRL": 2, "CAD": 2, "CHF": 2, "CLP": 0,
+	"CNY": 2, "CZK": 2, "DKK": 2, "EGP": 2, "EUR": 2, "GBP": 2, "HKD": 2, "HUF": 2,
+	"IDR": 2, "ILS": 2, "INR": 2, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KES": 2,
+	"KRW": 0, "KWD": 3, "LKR": 2, "MXN": 2, "MYR": 2, "NGN": 2, "NOK": 2, "NPR": 2,
+	"NZD": 2, "OMR": 3, "PHP": 2, "PKR": 2, "PLN": 2, "QAR": 2, "RUB": 2, "SAR": 2,
+	"SEK": 2, "SGD": 2, "THB": 2, "TND": 3, "TRY": 2, "TWD": 2, "UGX": 0, "USD": 2,
+	"VND": 0, "ZAR": 2,
+}
+
+// NormalizeCurrency upper-cases a currency code and checks that it is known.
+// An empty code yields DefaultCurrency.
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency, nil
+	}
+	if _, ok := minorUnits[code]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	return code, nil
+}
+
+// MinorUnits returns the number of decimal places amounts in the currency
+// are kept to; unknown currencies get 2
+func MinorUnits(code string) int32 {
+	if places, ok := minorUnits[strings.ToUpper(code)]; ok {
+		return places
+	}
+	return 2
+}
+
+// RoundTo rounds an amount to the minor units of a currency
+func RoundTo(amount Decimal, currency string) Decimal {
+	return amount.Round(MinorUnits(currency))
+}
+
+// Convert converts an amount with an exchange rate (units of the target
+// currency per unit of the source) and rounds it to the target's minor units
+func Convert(amount, rate Decimal, to string) Decimal {
+	return RoundTo(amount.Mul(rate), to)
+}
+
+// Sum adds amounts exactly
+func Sum(amounts ...Decimal) Decimal {
+	total := Zero
+	for _, a := range amounts {
+		total = total.Add(a)
+	}
+	return total
+}
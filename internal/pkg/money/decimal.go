@@ -0,0 +1,302 @@
+// Package money provides exact decimal arithmetic for monetary amounts and
+// exchange rates, ISO 4217 currency codes with their minor units, and
+// conversion between currencies.
+//
+// Quote, contract, purchase order and auction amounts are still stored and
+// serialized as float64. Their arithmetic goes through Decimal and every
+// result is rounded to the currency's minor units before it is stored, so the
+// float64 fields only carry values already exact to the minor unit. Moving
+// those fields and their columns to Decimal is left to a separate change.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidDecimal = errors.New("invalid decimal")
+
+// Bounds on parsed input, so a value like "1e2000000000" cannot make Parse
+// build an enormous coefficient
+const (
+	MaxExponent = 64 // Largest |exponent| accepted in scientific notation
+	MaxScale    = 38 // Most digits accepted after the decimal point
+)
+
+var (
+	bigTen  = big.NewInt(10)
+	bigZero = big.NewInt(0)
+)
+
+// Decimal is an exact base-10 number, coef × 10^-scale. The zero value is 0.
+// Decimals are immutable; every operation returns a new value.
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+// Zero is the decimal 0
+var Zero = Decimal{}
+
+// New returns coef × 10^-scale
+func New(coef int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{coef: new(big.Int).Mul(big.NewInt(coef), pow10(-scale))}
+	}
+	return Decimal{coef: big.NewInt(coef), scale: scale}
+}
+
+// NewFromInt returns the decimal value of an integer
+func NewFromInt(v int64) Decimal {
+	return New(v, 0)
+}
+
+// NewFromFloat returns the shortest decimal that reads back as f, so 0.1
+// becomes exactly 0.1 rather than its binary approximation. It is meant for
+// amounts that are still carried as float64 at API and storage boundaries.
+func NewFromFloat(f float64) Decimal {
+	d, err := Parse(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Zero // NaN and infinities have no decimal value
+	}
+	return d
+}
+
+// Parse reads a decimal such as "-1234.5678" or "1.5e3"
+func Parse(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		mantissa = s[:i]
+		if exp, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil {
+			return Zero, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+		}
+		if exp > MaxExponent || exp < -MaxExponent {
+			return Zero, fmt.Errorf("%w: exponent of %q out of range", ErrInvalidDecimal, s)
+		}
+	}
+
+	digits, scale := mantissa, int64(0)
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		digits = mantissa[:i] + mantissa[i+1:]
+		scale = int64(len(mantissa) - i - 1)
+	}
+	unsigned := strings.TrimLeft(digits, "+-")
+	if unsigned == "" || len(digits)-len(unsigned) > 1 || strings.ContainsAny(unsigned, "+-") {
+		return Zero, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Zero, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	scale -= exp
+	if scale > MaxScale {
+		return Zero, fmt.Errorf("%w: more than %d decimal places in %q", ErrInvalidDecimal, MaxScale, s)
+	}
+	if scale < 0 {
+		return Decimal{coef: coef.Mul(coef, pow10(int32(-scale)))}, nil
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParse is like Parse but panics on invalid input. It is meant for constants.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) value() *big.Int {
+	if d.coef == nil {
+		return bigZero
+	}
+	return d.coef
+}
+
+// rescale returns the coefficient of d at a scale at least as large as d's
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.value()
+	}
+	return new(big.Int).Mul(d.value(), pow10(scale-d.scale))
+}
+
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// Add returns d + o
+func (d Decimal) Add(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{coef: new(big.Int).Add(x, y), scale: scale}
+}
+
+// Sub returns d - o
+func (d Decimal) Sub(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{coef: new(big.Int).Sub(x, y), scale: scale}
+}
+
+// Mul returns d × o exactly
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.value(), o.value()), scale: d.scale + o.scale}
+}
+
+// Div returns d ÷ o rounded half away from zero to places decimal places.
+// It panics if o is zero.
+func (d Decimal) Div(o Decimal, places int32) Decimal {
+	if o.IsZero() {
+		panic("money: division by zero")
+	}
+	// d/o = (dc × 10^(places+os)) / (oc × 10^ds) × 10^-places
+	num := new(big.Int).Mul(d.value(), pow10(places+o.scale))
+	den := new(big.Int).Mul(o.value(), pow10(d.scale))
+	return Decimal{coef: divRound(num, den), scale: places}
+}
+
+// divRound divides rounding half away from zero
+func divRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	twice := new(big.Int).Abs(r)
+	twice.Lsh(twice, 1)
+	if twice.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign()*den.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// Round returns d rounded half away from zero to places decimal places
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	return Decimal{coef: divRound(d.value(), pow10(d.scale-places)), scale: places}
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.value()), scale: d.scale}
+}
+
+// Abs returns |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.value()), scale: d.scale}
+}
+
+// Cmp compares d and o, returning -1, 0 or +1
+func (d Decimal) Cmp(o Decimal) int {
+	x, y, _ := align(d, o)
+	return x.Cmp(y)
+}
+
+// Equal reports whether d and o have the same value
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+// Sign returns -1, 0 or +1
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// IsZero reports whether d is 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Float64 returns the nearest float64, for callers that still carry floats
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String formats d in plain notation with its own number of decimal places
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.value()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		return sign + digits
+	}
+	if pad := int(d.scale) - len(digits) + 1; pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// StringFixed formats d rounded to exactly places decimal places
+func (d Decimal) StringFixed(places int32) string {
+	r := d.Round(places)
+	return Decimal{coef: r.rescale(places), scale: places}.String()
+}
+
+// MarshalJSON encodes d as a JSON number without loss of precision
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number, a quoted decimal or null
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*d = Zero
+		return nil
+	}
+	parsed, err := Parse(strings.Trim(s, `"`))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner for NUMERIC columns
+func (d *Decimal) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*d = Zero
+	case string:
+		*d, err = Parse(v)
+	case []byte:
+		*d, err = Parse(string(v))
+	case int64:
+		*d = NewFromInt(v)
+	case float64:
+		*d = NewFromFloat(v)
+	default:
+		err = fmt.Errorf("%w: cannot scan %T", ErrInvalidDecimal, src)
+	}
+	return err
+}
+
+// Value implements driver.Valuer, storing d as its exact text
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
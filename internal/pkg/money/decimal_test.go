@@ -0,0 +1,88 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecimalArithmetic(t *testing.T) {
+	// 0.1 + 0.2 is exactly 0.3, unlike float64
+	if got := NewFromFloat(0.1).Add(NewFromFloat(0.2)); !got.Equal(MustParse("0.3")) {
+		t.Fatalf("0.1 + 0.2 = %s", got)
+	}
+
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"mul", MustParse("19.99").Mul(NewFromInt(3)), "59.97"},
+		{"sub", MustParse("10").Sub(MustParse("10.005")), "-0.005"},
+		{"div rounds half up", MustParse("2").Div(MustParse("3"), 4), "0.6667"},
+		{"div negative", MustParse("-1").Div(MustParse("8"), 2), "-0.13"},
+		{"round", MustParse("2.345").Round(2), "2.35"},
+		{"exponent", MustParse("1.5e3"), "1500"},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if s := MustParse("7").StringFixed(2); s != "7.00" {
+		t.Errorf("StringFixed(2) = %s, want 7.00", s)
+	}
+	if _, err := Parse("1.2.3"); err == nil {
+		t.Error("expected an error for 1.2.3")
+	}
+}
+
+func TestParseBounds(t *testing.T) {
+	for _, s := range []string{"1e2000000000", "1e-2000000000", "1e65", "1.5e-40", "0." + strings.Repeat("0", MaxScale) + "1"} {
+		if _, err := Parse(s); !errors.Is(err, ErrInvalidDecimal) {
+			t.Errorf("Parse(%q): expected ErrInvalidDecimal, got %v", s, err)
+		}
+	}
+
+	var d Decimal
+	if err := json.Unmarshal([]byte(`"1e2000000000"`), &d); err == nil {
+		t.Error("expected JSON with a huge exponent to be rejected")
+	}
+
+	if got := MustParse("1e64"); got.String() != "1"+strings.Repeat("0", 64) {
+		t.Errorf("1e64 = %s", got)
+	}
+	if got := MustParse("1.5e-36"); got.String() != "0.0000000000000000000000000000000000015" {
+		t.Errorf("1.5e-36 = %s", got)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Amount Decimal `json:"amount"`
+		Rate   Decimal `json:"rate"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount": 1234.56, "rate": "0.01195432"}`), &v); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, _ := json.Marshal(v)
+	if string(out) != `{"amount":1234.56,"rate":0.01195432}` {
+		t.Fatalf("unexpected JSON: %s", out)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	// 1,000,000 INR at 0.011954 USD/INR
+	if got := Convert(NewFromInt(1000000), MustParse("0.011954"), "USD"); got.String() != "11954.00" {
+		t.Fatalf("unexpected conversion: %s", got)
+	}
+	// JPY has no minor units
+	if got := Convert(MustParse("100"), MustParse("151.237"), "JPY"); got.String() != "15124" {
+		t.Fatalf("expected 15124 JPY, got %s", got)
+	}
+	if _, err := NormalizeCurrency("xyz"); err == nil {
+		t.Fatal("expected an unknown currency error")
+	}
+}
@@ -0,0 +1,330 @@
+// Package xlsx provides a minimal streaming reader for the first worksheet of
+// an Office Open XML spreadsheet. It only extracts cell values as strings, which
+// is all the bulk importers need.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Reader reads rows from the first worksheet of an XLSX workbook.
+// Its Read method mirrors encoding/csv.Reader so importers can accept both.
+type Reader struct {
+	zr            *zip.Reader
+	sheet         io.ReadCloser
+	decoder       *xml.Decoder
+	sharedStrings []string
+	nextRow       int
+	pending       *xml.StartElement // row start read ahead while filling a gap
+}
+
+// NewReader opens an XLSX workbook from r
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx file: %w", err)
+	}
+
+	x := &Reader{zr: zr, nextRow: 1}
+
+	if err := x.loadSharedStrings(); err != nil {
+		return nil, err
+	}
+
+	sheetPath, err := x.firstSheetPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := x.open(sheetPath)
+	if err != nil {
+		return nil, fmt.Errorf("xlsx worksheet %s not found: %w", sheetPath, err)
+	}
+	x.sheet = f
+	x.decoder = xml.NewDecoder(f)
+	return x, nil
+}
+
+// Read returns the next row of cell values. Missing cells are returned as empty
+// strings and fully missing rows as empty slices, so row numbers stay aligned
+// with the spreadsheet. It returns io.EOF after the last row.
+func (x *Reader) Read() ([]string, error) {
+	for {
+		var start xml.StartElement
+		if x.pending != nil {
+			start = *x.pending
+			x.pending = nil
+		} else {
+			tok, err := x.decoder.Token()
+			if err != nil {
+				if err == io.EOF {
+					return nil, io.EOF
+				}
+				return nil, fmt.Errorf("xlsx parse error: %w", err)
+			}
+
+			el, ok := tok.(xml.StartElement)
+			if !ok || el.Name.Local != "row" {
+				continue
+			}
+			start = el.Copy()
+		}
+
+		rowNum := x.nextRow
+		if r := attr(start, "r"); r != "" {
+			if n, err := strconv.Atoi(r); err == nil {
+				rowNum = n
+			}
+		}
+
+		// Emit empty rows for gaps so callers see consistent row numbers
+		if rowNum > x.nextRow {
+			x.nextRow++
+			x.pending = &start
+			return []string{}, nil
+		}
+
+		row, err := x.readRow()
+		if err != nil {
+			return nil, err
+		}
+		x.nextRow = rowNum + 1
+		return row, nil
+	}
+}
+
+// Close releases the underlying worksheet stream
+func (x *Reader) Close() error {
+	if x.sheet != nil {
+		return x.sheet.Close()
+	}
+	return nil
+}
+
+// readRow reads the cells of the current <row> element
+func (x *Reader) readRow() ([]string, error) {
+	row := []string{}
+	for {
+		tok, err := x.decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("xlsx parse error: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "c" {
+				continue
+			}
+			col := len(row)
+			if ref := attr(t, "r"); ref != "" {
+				if c, ok := columnIndex(ref); ok {
+					col = c
+				}
+			}
+			value, err := x.readCell(attr(t, "t"))
+			if err != nil {
+				return nil, err
+			}
+			for len(row) < col {
+				row = append(row, "")
+			}
+			row = append(row, value)
+		case xml.EndElement:
+			if t.Name.Local == "row" {
+				return row, nil
+			}
+		}
+	}
+}
+
+// readCell reads the value of the current <c> element
+func (x *Reader) readCell(cellType string) (string, error) {
+	var value, inline strings.Builder
+	var inValue, inInline bool
+	for {
+		tok, err := x.decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("xlsx parse error: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "v":
+				inValue = true
+			case "t":
+				inInline = true
+			}
+		case xml.CharData:
+			if inValue {
+				value.Write(t)
+			} else if inInline {
+				inline.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "v":
+				inValue = false
+			case "t":
+				inInline = false
+			case "c":
+				return x.resolve(cellType, value.String(), inline.String()), nil
+			}
+		}
+	}
+}
+
+// resolve converts a raw cell value according to its type
+func (x *Reader) resolve(cellType, value, inline string) string {
+	switch cellType {
+	case "s":
+		if i, err := strconv.Atoi(value); err == nil && i >= 0 && i < len(x.sharedStrings) {
+			return x.sharedStrings[i]
+		}
+		return ""
+	case "inlineStr":
+		return inline
+	case "b":
+		if value == "1" {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		return value
+	}
+}
+
+// loadSharedStrings reads the shared string table, if present
+func (x *Reader) loadSharedStrings() error {
+	f, err := x.open("xl/sharedStrings.xml")
+	if err != nil {
+		return nil // Workbooks with only inline strings have no table
+	}
+	defer f.Close()
+
+	dec := xml.NewDecoder(f)
+	var current strings.Builder
+	var inItem, inText bool
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("xlsx shared strings parse error: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				inItem = true
+				current.Reset()
+			case "t":
+				inText = inItem
+			case "rPh":
+				// Phonetic hints are not part of the visible text
+				if err := dec.Skip(); err != nil {
+					return fmt.Errorf("xlsx shared strings parse error: %w", err)
+				}
+			}
+		case xml.CharData:
+			if inText {
+				current.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "si":
+				inItem = false
+				x.sharedStrings = append(x.sharedStrings, current.String())
+			}
+		}
+	}
+}
+
+// firstSheetPath resolves the archive path of the first worksheet
+func (x *Reader) firstSheetPath() (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	var workbook struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := x.decodeXML("xl/workbook.xml", &workbook); err != nil || len(workbook.Sheets) == 0 {
+		return fallback, nil
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := x.decodeXML("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return fallback, nil
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].ID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+func (x *Reader) decodeXML(name string, v interface{}) error {
+	f, err := x.open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return xml.NewDecoder(f).Decode(v)
+}
+
+func (x *Reader) open(name string) (io.ReadCloser, error) {
+	for _, f := range x.zr.File {
+		if f.Name == name {
+			return f.Open()
+		}
+	}
+	return nil, fmt.Errorf("%s: not found", name)
+}
+
+func attr(el xml.StartElement, name string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// columnIndex converts a cell reference such as "AB12" to a zero-based column index
+func columnIndex(ref string) (int, bool) {
+	col := 0
+	n := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return col - 1, true
+}
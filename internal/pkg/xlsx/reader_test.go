@@ -0,0 +1,58 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	files := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Equipment" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships>
+			<Relationship Id="rId1" Target="worksheets/data.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>serial_number</t></si><si><t>name</t></si>
+			<si><r><t>CT </t></r><r><t>Scanner</t></r></si></sst>`,
+		"xl/worksheets/data.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+			<row r="3"><c r="A3" t="inlineStr"><is><t>SN-1</t></is></c><c r="C3"><v>42</v></c></row>
+			<row r="4"><c r="B4" t="s"><v>2</v></c></row>
+		</sheetData></worksheet>`,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer r.Close()
+
+	want := [][]string{
+		{"serial_number", "name"},
+		{},
+		{"SN-1", "", "42"},
+		{"", "CT Scanner"},
+	}
+	for i, w := range want {
+		row, err := r.Read()
+		if err != nil {
+			t.Fatalf("row %d: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(row, w) {
+			t.Errorf("row %d: got %q, want %q", i+1, row, w)
+		}
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -46,6 +47,10 @@ func (h *ComparisonHandler) CreateComparison(w http.ResponseWriter, r *http.Requ
 
 	comparison, err := h.service.CreateComparison(r.Context(), tenantID, createdBy, req)
 	if err != nil {
+		if err == domain.ErrBidsSealed {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		h.logger.Error("Failed to create comparison", slog.String("error", err.Error()))
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -306,6 +311,14 @@ func (h *ComparisonHandler) CalculateScores(w http.ResponseWriter, r *http.Reque
 
 	comparison, err := h.service.CalculateScores(r.Context(), tenantID, id, quotes)
 	if err != nil {
+		if err == domain.ErrBidsSealed {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, domain.ErrMissingExchangeRate) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
 		h.logger.Error("Failed to calculate scores", slog.String("error", err.Error()))
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/comparison/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateTemplate handles POST /scoring-templates
+func (h *ComparisonHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		http.Error(w, "X-Tenant-ID header required", http.StatusBadRequest)
+		return
+	}
+
+	var req app.ScoringTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	createdBy := r.Header.Get("X-User-ID")
+	if createdBy == "" {
+		createdBy = "system"
+	}
+
+	template, err := h.service.CreateTemplate(r.Context(), tenantID, createdBy, req)
+	if err != nil {
+		h.templateError(w, "Failed to create scoring template", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(template)
+}
+
+// ListTemplates handles GET /scoring-templates
+func (h *ComparisonHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		http.Error(w, "X-Tenant-ID header required", http.StatusBadRequest)
+		return
+	}
+
+	templates, err := h.service.ListTemplates(r.Context(), tenantID)
+	if err != nil {
+		h.templateError(w, "Failed to list scoring templates", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"templates": templates, "total": len(templates)})
+}
+
+// GetTemplate handles GET /scoring-templates/{id}
+func (h *ComparisonHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	template, err := h.service.GetTemplate(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"))
+	if err != nil {
+		h.templateError(w, "Failed to get scoring template", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+// UpdateTemplate handles PUT /scoring-templates/{id}
+func (h *ComparisonHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req app.ScoringTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.service.UpdateTemplate(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"), req)
+	if err != nil {
+		h.templateError(w, "Failed to update scoring template", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+// DeleteTemplate handles DELETE /scoring-templates/{id}
+func (h *ComparisonHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteTemplate(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id")); err != nil {
+		h.templateError(w, "Failed to delete scoring template", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ApplyTemplate handles POST /comparisons/{id}/template
+func (h *ComparisonHandler) ApplyTemplate(w http.ResponseWriter, r *http.Request) {
+	var req app.ApplyTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.TemplateID == "" {
+		http.Error(w, "template_id is required", http.StatusBadRequest)
+		return
+	}
+
+	comparison, err := h.service.ApplyTemplate(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrComparisonNotFound) {
+			http.Error(w, "Comparison not found", http.StatusNotFound)
+			return
+		}
+		h.templateError(w, "Failed to apply scoring template", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(app.ToResponse(comparison))
+}
+
+// templateError maps scoring template errors to HTTP status codes
+func (h *ComparisonHandler) templateError(w http.ResponseWriter, msg string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTemplateNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidTemplate), errors.Is(err, domain.ErrInvalidWeights):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(msg, slog.String("error", err.Error()))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -1,6 +1,9 @@
 ﻿package app
 
-import "github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+import (
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	"github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+)
 
 // CreateComparisonRequest represents the request to create a comparison
 type CreateComparisonRequest struct {
@@ -8,6 +11,8 @@ type CreateComparisonRequest struct {
 	Title       string   `json:"title"`
 	Description string   `json:"description"`
 	QuoteIDs    []string `json:"quote_ids"`
+	TemplateID  string   `json:"template_id,omitempty"` // Scoring template; defaults to the tenant's default template
+	TCOModel    *domain.TCOModel `json:"tco_model,omitempty"` // TCO horizon and rates; defaults apply when unset
 }
 
 // UpdateComparisonRequest represents the request to update a comparison
@@ -23,6 +28,20 @@ type UpdateScoringCriteriaRequest struct {
 	QualityWeight    float64 `json:"quality_weight"`
 	DeliveryWeight   float64 `json:"delivery_weight"`
 	ComplianceWeight float64 `json:"compliance_weight"`
+	TCOModel         *domain.TCOModel `json:"tco_model,omitempty"` // Keeps the current TCO model when unset
+}
+
+// ScoringTemplateRequest represents the request to create or replace a scoring template
+type ScoringTemplateRequest struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Criteria    []domain.ScoringCriterion `json:"criteria"`
+	IsDefault   bool                      `json:"is_default"`
+}
+
+// ApplyTemplateRequest represents the request to score a comparison with a template
+type ApplyTemplateRequest struct {
+	TemplateID string `json:"template_id"`
 }
 
 // AddQuoteRequest represents the request to add a quote to a comparison
@@ -33,12 +52,14 @@ type AddQuoteRequest struct {
 // ComparisonResponse represents a comparison in API responses
 type ComparisonResponse struct {
 	*domain.Comparison
+	StaleQuoteIDs []string `json:"stale_quote_ids"`
 }
 
 // ToResponse converts a domain comparison to a response DTO
 func ToResponse(comparison *domain.Comparison) *ComparisonResponse {
 	return &ComparisonResponse{
-		Comparison: comparison,
+		Comparison:    comparison,
+		StaleQuoteIDs: comparison.StaleQuoteIDs(),
 	}
 }
 
@@ -59,11 +80,27 @@ type Quote struct {
 	SupplierName      string      `json:"supplier_name"`
 	RFQID             string      `json:"rfq_id"`
 	TotalAmount       float64     `json:"total_amount"`
+	Currency          string      `json:"currency"` // Defaults to the RFQ's base currency
 	ValidUntil        string      `json:"valid_until"`
 	DeliveryTerms     string      `json:"delivery_terms"`
 	PaymentTerms      string      `json:"payment_terms"`
 	WarrantyTerms     string      `json:"warranty_terms"`
 	Items             []QuoteItem `json:"items"`
+
+	// Structured scoring inputs
+	TCO               *float64    `json:"tco,omitempty"`                // Total cost of ownership
+	DeliveryDays      *float64    `json:"delivery_days,omitempty"`      // Defaults to the slowest item
+	WarrantyYears     *float64    `json:"warranty_years,omitempty"`
+	SupplierRating    *float64    `json:"supplier_rating,omitempty"`    // 0-5, looked up from the supplier if omitted
+	PastPerformance   *float64    `json:"past_performance,omitempty"`   // 0-100, derived from past contracts if omitted
+	SupplierScorecard *float64    `json:"supplier_scorecard,omitempty"` // 0-100, computed from the supplier's scorecard if omitted
+	Certifications    []string    `json:"certifications,omitempty"`     // Defaults to the certifications every item holds
+
+	// Quote-wide running costs; item running costs are added to these
+	OwnershipCosts    *domain.OwnershipCosts `json:"ownership_costs,omitempty"`
+
+	// Set once the quote has been converted to the RFQ's base currency
+	Conversion        *domain.CurrencyConversion `json:"-"`
 }
 
 // QuoteItem represents an item in a quote
@@ -81,4 +118,140 @@ type QuoteItem struct {
 	ModelNumber       string  `json:"model_number"`
 	Specifications    string  `json:"specifications"`
 	ComplianceCerts   string  `json:"compliance_certs"`
+	DeliveryDays      *float64 `json:"delivery_days,omitempty"`
+	Certifications    []string `json:"certifications,omitempty"`
+	OwnershipCosts    *domain.OwnershipCosts `json:"ownership_costs,omitempty"` // Running costs of the whole line
+}
+
+// convert returns the quote with every amount converted at rate into the
+// given currency, each rounded to its minor units
+func (q Quote) convert(rate money.Decimal, currency string) Quote {
+	conv := func(v float64) float64 {
+		return money.Convert(money.NewFromFloat(v), rate, currency).Float64()
+	}
+	convCosts := func(c *domain.OwnershipCosts) *domain.OwnershipCosts {
+		if c == nil {
+			return nil
+		}
+		out := *c
+		out.AMCByYear = make([]float64, len(c.AMCByYear))
+		for i, amc := range c.AMCByYear {
+			out.AMCByYear[i] = conv(amc)
+		}
+		out.ConsumablesPerYear = conv(c.ConsumablesPerYear)
+		out.TradeInCredit = conv(c.TradeInCredit)
+		return &out
+	}
+
+	out := q
+	out.Currency = currency
+	out.TotalAmount = conv(q.TotalAmount)
+	if q.TCO != nil {
+		tco := conv(*q.TCO)
+		out.TCO = &tco
+	}
+	out.OwnershipCosts = convCosts(q.OwnershipCosts)
+	out.Items = make([]QuoteItem, len(q.Items))
+	for i, item := range q.Items {
+		item.UnitPrice = conv(item.UnitPrice)
+		item.TotalPrice = conv(item.TotalPrice)
+		item.TaxAmount = conv(item.TaxAmount)
+		item.OwnershipCosts = convCosts(item.OwnershipCosts)
+		out.Items[i] = item
+	}
+	return out
+}
+
+// ownershipCosts returns the running costs declared on the quote and its items
+func (q Quote) ownershipCosts() (domain.OwnershipCosts, bool) {
+	costs := domain.OwnershipCosts{}
+	declared := false
+	if q.OwnershipCosts != nil {
+		costs = costs.Add(*q.OwnershipCosts)
+		declared = true
+	}
+	for _, item := range q.Items {
+		if item.OwnershipCosts != nil {
+			costs = costs.Add(*item.OwnershipCosts)
+			declared = true
+		}
+	}
+	return costs, declared
+}
+
+// TotalCostOfOwnership returns the quote's TCO under the model. A TCO given
+// on the quote is used as is, without a breakdown.
+func (q Quote) TotalCostOfOwnership(model domain.TCOModel) (float64, *domain.TCOBreakdown) {
+	if q.TCO != nil {
+		return *q.TCO, nil
+	}
+	costs, _ := q.ownershipCosts()
+	breakdown := domain.ComputeTCO(q.TotalAmount, costs, model)
+	return breakdown.Total, &breakdown
+}
+
+// TotalCostOfOwnership returns the TCO of a quote line under the model
+func (i QuoteItem) TotalCostOfOwnership(model domain.TCOModel) domain.TCOBreakdown {
+	costs := domain.OwnershipCosts{}
+	if i.OwnershipCosts != nil {
+		costs = *i.OwnershipCosts
+	}
+	return domain.ComputeTCO(i.TotalPrice, costs, model)
+}
+
+// ScoringInput returns the structured inputs the quote is scored on. TCO is
+// only an input when the quote gives it or declares running costs, so that a
+// quote silent on them is not scored as if it had none.
+func (q Quote) ScoringInput(model domain.TCOModel) domain.ScoringInput {
+	in := domain.ScoringInput{
+		QuoteID:         q.ID,
+		SupplierID:      q.SupplierID,
+		TotalAmount:     q.TotalAmount,
+		TCO:             q.TCO,
+		DeliveryDays:    q.DeliveryDays,
+		WarrantyYears:   q.WarrantyYears,
+		SupplierRating:  q.SupplierRating,
+		PastPerformance: q.PastPerformance,
+		Scorecard:       q.SupplierScorecard,
+		Certifications:  q.Certifications,
+	}
+
+	if _, declared := q.ownershipCosts(); in.TCO == nil && declared {
+		tco, _ := q.TotalCostOfOwnership(model)
+		in.TCO = &tco
+	}
+
+	if in.DeliveryDays == nil {
+		for _, item := range q.Items {
+			if item.DeliveryDays != nil && (in.DeliveryDays == nil || *item.DeliveryDays > *in.DeliveryDays) {
+				days := *item.DeliveryDays
+				in.DeliveryDays = &days
+			}
+		}
+	}
+
+	if in.Certifications == nil {
+		held := map[string]int{}
+		listed := 0
+		for _, item := range q.Items {
+			if item.Certifications == nil {
+				continue
+			}
+			listed++
+			seen := map[string]bool{}
+			for _, cert := range item.Certifications {
+				if !seen[cert] {
+					seen[cert] = true
+					held[cert]++
+				}
+			}
+		}
+		for cert, n := range held {
+			if n == listed {
+				in.Certifications = append(in.Certifications, cert)
+			}
+		}
+	}
+
+	return in
 }
@@ -9,26 +9,46 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aby-med/medical-platform/internal/pkg/money"
 	"github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
 	"github.com/segmentio/ksuid"
 )
 
 // ComparisonService handles comparison business logic
 type ComparisonService struct {
-	repo   domain.Repository
-	logger *slog.Logger
+	repo      domain.Repository
+	rfqs      domain.RFQReader
+	templates domain.TemplateRepository
+	suppliers domain.SupplierPerformanceReader
+	rates     domain.ExchangeRateReader
+	logger    *slog.Logger
 }
 
 // NewComparisonService creates a new comparison service
-func NewComparisonService(repo domain.Repository, logger *slog.Logger) *ComparisonService {
+func NewComparisonService(
+	repo domain.Repository,
+	rfqs domain.RFQReader,
+	templates domain.TemplateRepository,
+	suppliers domain.SupplierPerformanceReader,
+	rates domain.ExchangeRateReader,
+	logger *slog.Logger,
+) *ComparisonService {
 	return &ComparisonService{
-		repo:   repo,
-		logger: logger.With(slog.String("service", "comparison")),
+		repo:      repo,
+		rfqs:      rfqs,
+		templates: templates,
+		suppliers: suppliers,
+		rates:     rates,
+		logger:    logger.With(slog.String("service", "comparison")),
 	}
 }
 
 // CreateComparison creates a new comparison
 func (s *ComparisonService) CreateComparison(ctx context.Context, tenantID, createdBy string, req CreateComparisonRequest) (*domain.Comparison, error) {
+	if err := s.ensureBidsOpened(ctx, tenantID, req.RFQID); err != nil {
+		return nil, err
+	}
+
 	comparison, err := domain.NewComparison(tenantID, req.RFQID, req.Title, createdBy, req.QuoteIDs)
 	if err != nil {
 		return nil, err
@@ -36,6 +56,22 @@ func (s *ComparisonService) CreateComparison(ctx context.Context, tenantID, crea
 
 	comparison.ID = ksuid.New().String()
 	comparison.Description = req.Description
+	if req.TCOModel != nil {
+		if err := req.TCOModel.Validate(); err != nil {
+			return nil, err
+		}
+		comparison.ScoringCriteria.TCOModel = req.TCOModel
+	}
+
+	// Score with the requested template, else the tenant's default template if any
+	template, err := s.templateFor(ctx, tenantID, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if template != nil {
+		comparison.ScoringCriteria.TemplateID = template.ID
+		comparison.ScoringCriteria.Criteria = template.Criteria
+	}
 
 	if err := s.repo.Create(ctx, comparison); err != nil {
 		return nil, err
@@ -96,6 +132,10 @@ func (s *ComparisonService) UpdateScoringCriteria(ctx context.Context, tenantID,
 		QualityWeight:    req.QualityWeight,
 		DeliveryWeight:   req.DeliveryWeight,
 		ComplianceWeight: req.ComplianceWeight,
+		TCOModel:         comparison.ScoringCriteria.TCOModel,
+	}
+	if req.TCOModel != nil {
+		criteria.TCOModel = req.TCOModel
 	}
 
 	if err := comparison.UpdateScoringCriteria(criteria); err != nil {
@@ -156,8 +196,21 @@ func (s *ComparisonService) CalculateScores(ctx context.Context, tenantID, id st
 		return nil, fmt.Errorf("no quotes provided for scoring")
 	}
 
+	if err := s.ensureBidsOpened(ctx, tenantID, comparison.RFQID); err != nil {
+		return nil, err
+	}
+
+	// Compare every quote in the RFQ's base currency
+	quotes, err = s.convertQuotes(ctx, tenantID, comparison.RFQID, quotes)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate individual scores
-	scores := s.calculateQuoteScores(quotes, comparison.ScoringCriteria)
+	scores := s.calculateQuoteScores(ctx, tenantID, quotes, comparison.ScoringCriteria)
+
+	// Flag quotes submitted against a superseded RFQ version
+	s.flagStaleQuotes(ctx, tenantID, comparison.RFQID, scores)
 
 	// Rank scores
 	scores = s.rankScores(scores)
@@ -165,16 +218,21 @@ func (s *ComparisonService) CalculateScores(ctx context.Context, tenantID, id st
 	// Generate recommendations
 	for i := range scores {
 		scores[i].Recommendation = s.generateRecommendation(&scores[i], len(quotes))
+		if scores[i].StaleVersion {
+			scores[i].Recommendation = fmt.Sprintf("Quote is based on RFQ version %d; request a re-quote before award. %s",
+				scores[i].RFQVersion, scores[i].Recommendation)
+		}
 	}
 
 	// Calculate price differences
-	priceDiffs := s.calculatePriceDifferences(quotes)
+	tco := comparison.ScoringCriteria.TCO()
+	priceDiffs := s.calculatePriceDifferences(quotes, tco)
 
 	// Build item comparisons
-	itemComps := s.buildItemComparisons(quotes)
+	itemComps := s.buildItemComparisons(quotes, tco)
 
 	// Generate overall recommendation
-	overallRec := s.generateOverallRecommendation(scores, comparison.ScoringCriteria)
+	overallRec := s.generateOverallRecommendation(scores) + tcoNote(priceDiffs)
 
 	// Update comparison
 	comparison.SetScores(scores)
@@ -190,276 +248,225 @@ func (s *ComparisonService) CalculateScores(ctx context.Context, tenantID, id st
 	return comparison, nil
 }
 
-// calculateQuoteScores calculates scores for each quote
-func (s *ComparisonService) calculateQuoteScores(quotes []Quote, criteria domain.ScoringCriteria) []domain.QuoteScore {
+// calculateQuoteScores scores each quote on the comparison's weighted criteria
+func (s *ComparisonService) calculateQuoteScores(ctx context.Context, tenantID string, quotes []Quote, criteria domain.ScoringCriteria) []domain.QuoteScore {
+	inputs := s.scoringInputs(ctx, tenantID, quotes, criteria.TCO())
+	results, overall := domain.ScoreQuotes(criteria.Effective(), inputs)
+
 	scores := make([]domain.QuoteScore, len(quotes))
 	now := time.Now()
 
 	for i, quote := range quotes {
-		priceScore := s.calculatePriceScore(quote, quotes)
-		qualityScore := s.calculateQualityScore(quote)
-		deliveryScore := s.calculateDeliveryScore(quote)
-		complianceScore := s.calculateComplianceScore(quote)
-
-		overallScore := (priceScore * criteria.PriceWeight / 100.0) +
-			(qualityScore * criteria.QualityWeight / 100.0) +
-			(deliveryScore * criteria.DeliveryWeight / 100.0) +
-			(complianceScore * criteria.ComplianceWeight / 100.0)
-
-		strengths, weaknesses := s.analyzeQuoteStrengthsWeaknesses(priceScore, qualityScore, deliveryScore, complianceScore)
+		strengths, weaknesses := s.analyzeQuoteStrengthsWeaknesses(results[i])
 
 		scores[i] = domain.QuoteScore{
-			QuoteID:         quote.ID,
-			QuoteNumber:     quote.QuoteNumber,
-			SupplierID:      quote.SupplierID,
-			SupplierName:    quote.SupplierName,
-			TotalAmount:     quote.TotalAmount,
-			PriceScore:      priceScore,
-			QualityScore:    qualityScore,
-			DeliveryScore:   deliveryScore,
-			ComplianceScore: complianceScore,
-			OverallScore:    overallScore,
-			Strengths:       strengths,
-			Weaknesses:      weaknesses,
-			CalculatedAt:    now,
+			QuoteID:      quote.ID,
+			QuoteNumber:  quote.QuoteNumber,
+			SupplierID:   quote.SupplierID,
+			SupplierName: quote.SupplierName,
+			TotalAmount:  quote.TotalAmount,
+			OverallScore: overall[i],
+			Strengths:    strengths,
+			Weaknesses:   weaknesses,
+			Criteria:     results[i],
+			Currency:     quote.Currency,
+			Conversion:   quote.Conversion,
+			CalculatedAt: now,
 		}
+		summarizeCriteria(&scores[i])
 	}
 
 	return scores
 }
 
-// calculatePriceScore calculates price competitiveness (0-100, higher is better)
-func (s *ComparisonService) calculatePriceScore(quote Quote, allQuotes []Quote) float64 {
-	if len(allQuotes) == 0 {
-		return 50.0 // Default if no comparison
-	}
-
-	// Find min and max prices
-	minPrice := math.MaxFloat64
-	maxPrice := 0.0
-	for _, q := range allQuotes {
-		if q.TotalAmount < minPrice {
-			minPrice = q.TotalAmount
-		}
-		if q.TotalAmount > maxPrice {
-			maxPrice = q.TotalAmount
+// scoringInputs collects the structured inputs of each quote. Supplier rating,
+// past performance and scorecard not given in the request are looked up; lookup
+// failures leave them unset rather than failing the comparison.
+func (s *ComparisonService) scoringInputs(ctx context.Context, tenantID string, quotes []Quote, model domain.TCOModel) []domain.ScoringInput {
+	inputs := make([]domain.ScoringInput, len(quotes))
+	missing := []string{}
+	for i, q := range quotes {
+		inputs[i] = q.ScoringInput(model)
+		if inputs[i].SupplierRating == nil || inputs[i].PastPerformance == nil || inputs[i].Scorecard == nil {
+			missing = append(missing, q.SupplierID)
 		}
 	}
 
-	// Avoid division by zero
-	if maxPrice == minPrice {
-		return 100.0
+	if s.suppliers == nil || len(missing) == 0 {
+		return inputs
 	}
-
-	// Lower price gets higher score (inverted scale)
-	// Best price gets 100, worst gets 0
-	normalized := (maxPrice - quote.TotalAmount) / (maxPrice - minPrice)
-	return normalized * 100.0
-}
-
-// calculateQualityScore calculates quality indicators (0-100)
-func (s *ComparisonService) calculateQualityScore(quote Quote) float64 {
-	score := 0.0
-	factors := 0.0
-
-	// Warranty terms (40 points)
-	if quote.WarrantyTerms != "" {
-		warrantyScore := s.scoreWarranty(quote.WarrantyTerms)
-		score += warrantyScore * 0.4
+	performance, err := s.suppliers.SupplierPerformance(ctx, tenantID, missing)
+	if err != nil {
+		s.logger.Warn("Failed to load supplier performance for scoring", slog.String("error", err.Error()))
+		return inputs
 	}
-	factors += 0.4
-
-	// Manufacturer reputation (30 points) - based on known manufacturers
-	for _, item := range quote.Items {
-		if s.isReputableManufacturer(item.ManufacturerName) {
-			score += 30.0
-			break
+	for i := range inputs {
+		p, ok := performance[inputs[i].SupplierID]
+		if !ok {
+			continue
+		}
+		if inputs[i].SupplierRating == nil {
+			inputs[i].SupplierRating = p.Rating
+		}
+		if inputs[i].PastPerformance == nil {
+			inputs[i].PastPerformance = p.PastPerformance
+		}
+		if inputs[i].Scorecard == nil {
+			inputs[i].Scorecard = p.Scorecard
 		}
 	}
-	factors += 0.3
-
-	// Specifications completeness (30 points)
-	specsCompleteness := s.scoreSpecificationsCompleteness(quote.Items)
-	score += specsCompleteness * 0.3
-	factors += 0.3
-
-	return (score / factors) * 100.0
+	return inputs
 }
 
-// calculateDeliveryScore calculates delivery speed score (0-100)
-func (s *ComparisonService) calculateDeliveryScore(quote Quote) float64 {
-	if len(quote.Items) == 0 {
-		return 50.0 // Default
-	}
-
-	// Average delivery timeframe across items
-	totalDays := 0.0
-	validItems := 0
-
-	for _, item := range quote.Items {
-		days := s.parseDeliveryDays(item.DeliveryTimeframe)
-		if days > 0 {
-			totalDays += days
-			validItems++
+// summarizeCriteria fills the legacy per-dimension scores from the criterion
+// scores: quality covers warranty, supplier rating, past performance and scorecard
+func summarizeCriteria(score *domain.QuoteScore) {
+	sums := map[string]float64{}
+	counts := map[string]int{}
+	for _, c := range score.Criteria {
+		group := ""
+		switch c.Kind {
+		case domain.CriterionPrice:
+			group = "price"
+		case domain.CriterionWarranty, domain.CriterionSupplierRating, domain.CriterionPastPerformance,
+			domain.CriterionScorecard:
+			group = "quality"
+		case domain.CriterionDelivery:
+			group = "delivery"
+		case domain.CriterionCompliance:
+			group = "compliance"
+		default:
+			continue
 		}
+		sums[group] += c.Score
+		counts[group]++
 	}
-
-	if validItems == 0 {
-		return 50.0 // Default if no delivery info
-	}
-
-	avgDays := totalDays / float64(validItems)
-
-	// Score based on delivery speed
-	// 0-30 days: 90-100
-	// 31-60 days: 70-89
-	// 61-90 days: 50-69
-	// 91+ days: 0-49
-	switch {
-	case avgDays <= 30:
-		return 90.0 + (30.0-avgDays)/30.0*10.0
-	case avgDays <= 60:
-		return 70.0 + (60.0-avgDays)/30.0*20.0
-	case avgDays <= 90:
-		return 50.0 + (90.0-avgDays)/30.0*20.0
-	default:
-		return math.Max(0, 50.0-(avgDays-90.0)/90.0*50.0)
+	avg := func(group string) float64 {
+		if counts[group] == 0 {
+			return 0
+		}
+		return sums[group] / float64(counts[group])
 	}
+	score.PriceScore = avg("price")
+	score.QualityScore = avg("quality")
+	score.DeliveryScore = avg("delivery")
+	score.ComplianceScore = avg("compliance")
 }
 
-// calculateComplianceScore calculates compliance and certification score (0-100)
-func (s *ComparisonService) calculateComplianceScore(quote Quote) float64 {
-	if len(quote.Items) == 0 {
-		return 0.0
+// convertQuotes converts quotes priced in another currency to the RFQ's base
+// currency at the rates of its rate date. Quotes without a currency are taken
+// to be in the base currency. Comparing fails rather than mixing currencies
+// when a rate is missing.
+func (s *ComparisonService) convertQuotes(ctx context.Context, tenantID, rfqID string, quotes []Quote) ([]Quote, error) {
+	base := ""
+	var rateDate time.Time
+	if s.rfqs != nil {
+		var err error
+		if base, rateDate, err = s.rfqs.BaseCurrency(ctx, tenantID, rfqID); err != nil {
+			return nil, err
+		}
 	}
-
-	totalScore := 0.0
-	for _, item := range quote.Items {
-		itemScore := s.scoreCompliance(item.ComplianceCerts)
-		totalScore += itemScore
+	if base == "" && len(quotes) > 0 {
+		base = quotes[0].Currency
 	}
-
-	return (totalScore / float64(len(quote.Items))) * 100.0
-}
-
-// Helper scoring functions
-
-func (s *ComparisonService) scoreWarranty(warrantyTerms string) float64 {
-	lower := strings.ToLower(warrantyTerms)
-	if strings.Contains(lower, "5 year") || strings.Contains(lower, "5-year") {
-		return 1.0
-	} else if strings.Contains(lower, "3 year") || strings.Contains(lower, "3-year") {
-		return 0.8
-	} else if strings.Contains(lower, "2 year") || strings.Contains(lower, "2-year") {
-		return 0.6
-	} else if strings.Contains(lower, "1 year") || strings.Contains(lower, "1-year") {
-		return 0.4
+	base, err := money.NormalizeCurrency(base)
+	if err != nil {
+		return nil, err
 	}
-	return 0.2
-}
 
-func (s *ComparisonService) isReputableManufacturer(name string) bool {
-	reputable := []string{
-		"siemens", "ge", "philips", "medtronic", "stryker",
-		"boston scientific", "abbott", "johnson & johnson", "roche",
-		"baxter", "becton dickinson", "cardinal health",
-	}
-	lower := strings.ToLower(name)
-	for _, rep := range reputable {
-		if strings.Contains(lower, rep) {
-			return true
+	converted := make([]Quote, len(quotes))
+	for i, q := range quotes {
+		currency, err := money.NormalizeCurrency(q.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if q.Currency == "" || currency == base {
+			converted[i] = q
+			converted[i].Currency = base
+			continue
+		}
+		if s.rates == nil {
+			return nil, fmt.Errorf("%w: %s to %s", domain.ErrMissingExchangeRate, currency, base)
 		}
-	}
-	return false
-}
-
-func (s *ComparisonService) scoreSpecificationsCompleteness(items []QuoteItem) float64 {
-	if len(items) == 0 {
-		return 0.0
-	}
 
-	completeCount := 0
-	for _, item := range items {
-		if item.Specifications != "" && item.ModelNumber != "" {
-			completeCount++
+		rate, day, err := s.rates.Rate(ctx, tenantID, currency, base, rateDate)
+		if err != nil {
+			return nil, err
+		}
+		converted[i] = q.convert(rate, base)
+		converted[i].Conversion = &domain.CurrencyConversion{
+			QuotedCurrency: currency,
+			QuotedAmount:   money.NewFromFloat(q.TotalAmount),
+			Rate:           rate,
+			RateDate:       day,
 		}
 	}
-
-	return float64(completeCount) / float64(len(items))
+	return converted, nil
 }
 
-func (s *ComparisonService) parseDeliveryDays(timeframe string) float64 {
-	lower := strings.ToLower(timeframe)
-
-	// Try to extract number
-	var days float64
-	if strings.Contains(lower, "day") {
-		fmt.Sscanf(lower, "%f", &days)
-		return days
+// ensureBidsOpened refuses to compare quotes of a sealed RFQ before its bids are opened
+func (s *ComparisonService) ensureBidsOpened(ctx context.Context, tenantID, rfqID string) error {
+	if s.rfqs == nil {
+		return nil
 	}
-	if strings.Contains(lower, "week") {
-		fmt.Sscanf(lower, "%f", &days)
-		return days * 7
+	sealed, err := s.rfqs.BidsSealed(ctx, tenantID, rfqID)
+	if err != nil {
+		return err
 	}
-	if strings.Contains(lower, "month") {
-		fmt.Sscanf(lower, "%f", &days)
-		return days * 30
+	if sealed {
+		return domain.ErrBidsSealed
 	}
-
-	return 0.0
+	return nil
 }
 
-func (s *ComparisonService) scoreCompliance(certs string) float64 {
-	if certs == "" {
-		return 0.0
+// flagStaleQuotes marks scores whose quote responds to an older RFQ version.
+// Version data is best effort: scoring proceeds unflagged if it is unavailable.
+func (s *ComparisonService) flagStaleQuotes(ctx context.Context, tenantID, rfqID string, scores []domain.QuoteScore) {
+	if s.rfqs == nil || len(scores) == 0 {
+		return
 	}
 
-	score := 0.0
-	lower := strings.ToLower(certs)
-
-	if strings.Contains(lower, "fda") {
-		score += 0.4
+	quoteIDs := make([]string, len(scores))
+	for i, score := range scores {
+		quoteIDs[i] = score.QuoteID
 	}
-	if strings.Contains(lower, "ce") || strings.Contains(lower, "ce mark") {
-		score += 0.3
-	}
-	if strings.Contains(lower, "iso") {
-		score += 0.2
-	}
-	if strings.Contains(lower, "ul") || strings.Contains(lower, "csa") {
-		score += 0.1
+
+	current, byQuote, err := s.rfqs.QuoteVersions(ctx, tenantID, rfqID, quoteIDs)
+	if err != nil {
+		s.logger.Warn("Failed to load RFQ versions for comparison",
+			slog.String("rfq_id", rfqID),
+			slog.String("error", err.Error()))
+		return
 	}
 
-	return math.Min(score, 1.0)
+	for i := range scores {
+		version, ok := byQuote[scores[i].QuoteID]
+		if !ok {
+			continue
+		}
+		scores[i].RFQVersion = version
+		if version < current {
+			scores[i].StaleVersion = true
+			scores[i].Weaknesses = append(scores[i].Weaknesses,
+				fmt.Sprintf("Responds to RFQ version %d; current version is %d", version, current))
+		}
+	}
 }
 
-// analyzeQuoteStrengthsWeaknesses identifies strengths and weaknesses
-func (s *ComparisonService) analyzeQuoteStrengthsWeaknesses(price, quality, delivery, compliance float64) ([]string, []string) {
+// analyzeQuoteStrengthsWeaknesses identifies strengths and weaknesses from the weighted criteria
+func (s *ComparisonService) analyzeQuoteStrengthsWeaknesses(criteria []domain.CriterionScore) ([]string, []string) {
 	strengths := []string{}
 	weaknesses := []string{}
 
-	if price >= 80 {
-		strengths = append(strengths, "Highly competitive pricing")
-	} else if price < 50 {
-		weaknesses = append(weaknesses, "Higher price compared to alternatives")
-	}
-
-	if quality >= 80 {
-		strengths = append(strengths, "Excellent quality indicators")
-	} else if quality < 50 {
-		weaknesses = append(weaknesses, "Quality indicators below average")
-	}
-
-	if delivery >= 80 {
-		strengths = append(strengths, "Fast delivery timeframe")
-	} else if delivery < 50 {
-		weaknesses = append(weaknesses, "Longer delivery timeframe")
-	}
-
-	if compliance >= 80 {
-		strengths = append(strengths, "Strong compliance and certifications")
-	} else if compliance < 50 {
-		weaknesses = append(weaknesses, "Limited compliance certifications")
+	for _, c := range criteria {
+		if c.Weight <= 0 {
+			continue
+		}
+		if c.Score >= 80 {
+			strengths = append(strengths, fmt.Sprintf("Strong %s: %s", strings.ToLower(c.Label), c.Explanation))
+		} else if c.Score < 50 {
+			weaknesses = append(weaknesses, fmt.Sprintf("Weak %s: %s", strings.ToLower(c.Label), c.Explanation))
+		}
 	}
 
 	return strengths, weaknesses
@@ -495,18 +502,26 @@ func (s *ComparisonService) generateRecommendation(score *domain.QuoteScore, tot
 	}
 }
 
-// calculatePriceDifferences calculates price differences from the lowest quote
-func (s *ComparisonService) calculatePriceDifferences(quotes []Quote) []domain.PriceDifference {
+// calculatePriceDifferences calculates each quote's difference from the
+// lowest quote, on acquisition price and on total cost of ownership
+func (s *ComparisonService) calculatePriceDifferences(quotes []Quote, model domain.TCOModel) []domain.PriceDifference {
 	if len(quotes) == 0 {
 		return []domain.PriceDifference{}
 	}
 
-	// Find minimum price
+	// Find minimum price and TCO
 	minPrice := math.MaxFloat64
-	for _, q := range quotes {
+	minTCO := math.MaxFloat64
+	tcos := make([]float64, len(quotes))
+	breakdowns := make([]*domain.TCOBreakdown, len(quotes))
+	for i, q := range quotes {
 		if q.TotalAmount < minPrice {
 			minPrice = q.TotalAmount
 		}
+		tcos[i], breakdowns[i] = q.TotalCostOfOwnership(model)
+		if tcos[i] < minTCO {
+			minTCO = tcos[i]
+		}
 	}
 
 	diffs := make([]domain.PriceDifference, len(quotes))
@@ -517,12 +532,23 @@ func (s *ComparisonService) calculatePriceDifferences(quotes []Quote) []domain.P
 			percentage = (diff / minPrice) * 100.0
 		}
 
+		tcoDiff := tcos[i] - minTCO
+		tcoPercentage := 0.0
+		if minTCO > 0 {
+			tcoPercentage = (tcoDiff / minTCO) * 100.0
+		}
+
 		diffs[i] = domain.PriceDifference{
-			QuoteID:              q.ID,
-			QuoteNumber:          q.QuoteNumber,
-			TotalAmount:          q.TotalAmount,
-			DifferenceFromLowest: diff,
-			PercentageFromLowest: percentage,
+			QuoteID:                 q.ID,
+			QuoteNumber:             q.QuoteNumber,
+			TotalAmount:             q.TotalAmount,
+			Currency:                q.Currency,
+			DifferenceFromLowest:    diff,
+			PercentageFromLowest:    percentage,
+			TCO:                     tcos[i],
+			TCODifferenceFromLowest: tcoDiff,
+			TCOPercentageFromLowest: tcoPercentage,
+			TCOBreakdown:            breakdowns[i],
 		}
 	}
 
@@ -530,7 +556,7 @@ func (s *ComparisonService) calculatePriceDifferences(quotes []Quote) []domain.P
 }
 
 // buildItemComparisons builds item-level comparisons
-func (s *ComparisonService) buildItemComparisons(quotes []Quote) []domain.ItemComparison {
+func (s *ComparisonService) buildItemComparisons(quotes []Quote, model domain.TCOModel) []domain.ItemComparison {
 	// Group items by equipment
 	itemMap := make(map[string]*domain.ItemComparison)
 
@@ -549,6 +575,7 @@ func (s *ComparisonService) buildItemComparisons(quotes []Quote) []domain.ItemCo
 				}
 			}
 
+			tco := item.TotalCostOfOwnership(model)
 			itemMap[key].Quotes[quote.ID] = domain.ItemDetails{
 				Quantity:          item.Quantity,
 				UnitPrice:         item.UnitPrice,
@@ -558,6 +585,8 @@ func (s *ComparisonService) buildItemComparisons(quotes []Quote) []domain.ItemCo
 				ModelNumber:       item.ModelNumber,
 				Specifications:    item.Specifications,
 				ComplianceCerts:   item.ComplianceCerts,
+				TCO:               tco.Total,
+				TCOBreakdown:      &tco,
 			}
 		}
 	}
@@ -565,14 +594,57 @@ func (s *ComparisonService) buildItemComparisons(quotes []Quote) []domain.ItemCo
 	// Convert map to slice
 	comparisons := make([]domain.ItemComparison, 0, len(itemMap))
 	for _, comp := range itemMap {
+		comp.LowestPriceQuote, comp.LowestTCOQuote = lowestItemQuotes(comp.Quotes)
 		comparisons = append(comparisons, *comp)
 	}
 
 	return comparisons
 }
 
+// tcoNote points out when the cheapest quote to buy is not the cheapest to own
+func tcoNote(diffs []domain.PriceDifference) string {
+	if len(diffs) == 0 {
+		return ""
+	}
+	cheapest, lowestTCO := diffs[0], diffs[0]
+	for _, d := range diffs {
+		if d.TotalAmount < cheapest.TotalAmount {
+			cheapest = d
+		}
+		if d.TCO < lowestTCO.TCO {
+			lowestTCO = d
+		}
+	}
+	if cheapest.QuoteID == lowestTCO.QuoteID {
+		return ""
+	}
+	return fmt.Sprintf(" Note: Quote %s has the lowest price, but Quote %s has the lowest total cost of ownership (%.2f vs %.2f).",
+		cheapest.QuoteNumber, lowestTCO.QuoteNumber, lowestTCO.TCO, cheapest.TCO)
+}
+
+// lowestItemQuotes returns the quotes offering an item at the lowest line
+// price and at the lowest line TCO; ties go to the lowest quote ID
+func lowestItemQuotes(quotes map[string]domain.ItemDetails) (string, string) {
+	ids := make([]string, 0, len(quotes))
+	for id := range quotes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	lowestPrice, lowestTCO := "", ""
+	for _, id := range ids {
+		if lowestPrice == "" || quotes[id].TotalPrice < quotes[lowestPrice].TotalPrice {
+			lowestPrice = id
+		}
+		if lowestTCO == "" || quotes[id].TCO < quotes[lowestTCO].TCO {
+			lowestTCO = id
+		}
+	}
+	return lowestPrice, lowestTCO
+}
+
 // generateOverallRecommendation generates an overall recommendation
-func (s *ComparisonService) generateOverallRecommendation(scores []domain.QuoteScore, criteria domain.ScoringCriteria) string {
+func (s *ComparisonService) generateOverallRecommendation(scores []domain.QuoteScore) string {
 	if len(scores) == 0 {
 		return "No quotes to compare."
 	}
@@ -581,16 +653,13 @@ func (s *ComparisonService) generateOverallRecommendation(scores []domain.QuoteS
 	recommendation := fmt.Sprintf("Based on the analysis of %d quotes, Quote %s from %s is recommended with an overall score of %.1f/100. ",
 		len(scores), best.QuoteNumber, best.SupplierName, best.OverallScore)
 
-	// Add key factors
+	// Add the most heavily weighted criteria as key factors
 	factors := []string{}
-	if criteria.PriceWeight >= 40 {
-		factors = append(factors, fmt.Sprintf("price competitiveness (%.1f/100)", best.PriceScore))
-	}
-	if criteria.QualityWeight >= 30 {
-		factors = append(factors, fmt.Sprintf("quality indicators (%.1f/100)", best.QualityScore))
-	}
-	if criteria.DeliveryWeight >= 20 {
-		factors = append(factors, fmt.Sprintf("delivery timeframe (%.1f/100)", best.DeliveryScore))
+	for _, c := range domain.SortedByWeight(best.Criteria) {
+		if len(factors) == 3 || c.Weight <= 0 {
+			break
+		}
+		factors = append(factors, fmt.Sprintf("%s (%.1f/100)", strings.ToLower(c.Label), c.Score))
 	}
 
 	if len(factors) > 0 {
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// CreateTemplate creates a scoring template
+func (s *ComparisonService) CreateTemplate(ctx context.Context, tenantID, createdBy string, req ScoringTemplateRequest) (*domain.ScoringTemplate, error) {
+	now := time.Now()
+	template := &domain.ScoringTemplate{
+		ID:          ksuid.New().String(),
+		TenantID:    tenantID,
+		Name:        req.Name,
+		Description: req.Description,
+		Criteria:    req.Criteria,
+		IsDefault:   req.IsDefault,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.templates.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Scoring template created", slog.String("id", template.ID))
+	return template, nil
+}
+
+// GetTemplate retrieves a scoring template
+func (s *ComparisonService) GetTemplate(ctx context.Context, tenantID, id string) (*domain.ScoringTemplate, error) {
+	return s.templates.GetByID(ctx, tenantID, id)
+}
+
+// ListTemplates lists the tenant's scoring templates
+func (s *ComparisonService) ListTemplates(ctx context.Context, tenantID string) ([]*domain.ScoringTemplate, error) {
+	return s.templates.List(ctx, tenantID)
+}
+
+// UpdateTemplate replaces a scoring template. Comparisons keep the criteria
+// they were scored with until the template is applied again.
+func (s *ComparisonService) UpdateTemplate(ctx context.Context, tenantID, id string, req ScoringTemplateRequest) (*domain.ScoringTemplate, error) {
+	template, err := s.templates.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Name = req.Name
+	template.Description = req.Description
+	template.Criteria = req.Criteria
+	template.IsDefault = req.IsDefault
+	template.UpdatedAt = time.Now()
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.templates.Update(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteTemplate deletes a scoring template
+func (s *ComparisonService) DeleteTemplate(ctx context.Context, tenantID, id string) error {
+	return s.templates.Delete(ctx, tenantID, id)
+}
+
+// ApplyTemplate copies a template's criteria onto a comparison; scores are
+// recalculated with them on the next calculation
+func (s *ComparisonService) ApplyTemplate(ctx context.Context, tenantID, id string, req ApplyTemplateRequest) (*domain.Comparison, error) {
+	comparison, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.templates.GetByID(ctx, tenantID, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := comparison.ScoringCriteria
+	criteria.TemplateID = template.ID
+	criteria.Criteria = template.Criteria
+	if err := comparison.UpdateScoringCriteria(criteria); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, comparison); err != nil {
+		return nil, err
+	}
+	return comparison, nil
+}
+
+// templateFor returns the requested template, or the tenant's default
+// template when none is requested; nil means legacy weights apply
+func (s *ComparisonService) templateFor(ctx context.Context, tenantID, templateID string) (*domain.ScoringTemplate, error) {
+	if s.templates == nil {
+		return nil, nil
+	}
+	if templateID != "" {
+		return s.templates.GetByID(ctx, tenantID, templateID)
+	}
+	template, err := s.templates.GetDefault(ctx, tenantID)
+	if err == domain.ErrTemplateNotFound {
+		return nil, nil
+	}
+	return template, err
+}
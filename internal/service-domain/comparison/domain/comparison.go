@@ -3,6 +3,8 @@ package domain
 import (
 	"errors"
 	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
 )
 
 var (
@@ -11,6 +13,8 @@ var (
 	ErrInvalidWeights            = errors.New("scoring weights must sum to 100")
 	ErrInvalidCriteria           = errors.New("invalid scoring criteria")
 	ErrQuoteNotInComparison      = errors.New("quote not included in this comparison")
+	ErrBidsSealed                = errors.New("rfq bids are sealed until bid opening")
+	ErrMissingExchangeRate       = errors.New("no exchange rate to the rfq base currency")
 )
 
 // ComparisonStatus represents the status of a comparison
@@ -23,16 +27,31 @@ const (
 	ComparisonStatusArchived   ComparisonStatus = "archived"
 )
 
-// ScoringCriteria defines the criteria used for scoring quotes
+// ScoringCriteria defines the criteria used for scoring quotes. When Criteria
+// is set (usually copied from a scoring template) it replaces the four
+// legacy weights.
 type ScoringCriteria struct {
 	PriceWeight       float64 `json:"price_weight"`       // 0-100
 	QualityWeight     float64 `json:"quality_weight"`     // 0-100
 	DeliveryWeight    float64 `json:"delivery_weight"`    // 0-100
 	ComplianceWeight  float64 `json:"compliance_weight"`  // 0-100
+
+	TemplateID        string             `json:"template_id,omitempty"` // Template the criteria were copied from
+	Criteria          []ScoringCriterion `json:"criteria,omitempty"`
+
+	TCOModel          *TCOModel          `json:"tco_model,omitempty"` // Horizon and rates for TCO; defaults apply when unset
 }
 
 // ValidateWeights ensures weights sum to 100
 func (sc *ScoringCriteria) ValidateWeights() error {
+	if sc.TCOModel != nil {
+		if err := sc.TCOModel.Validate(); err != nil {
+			return err
+		}
+	}
+	if len(sc.Criteria) > 0 {
+		return ValidateCriteria(sc.Criteria)
+	}
 	total := sc.PriceWeight + sc.QualityWeight + sc.DeliveryWeight + sc.ComplianceWeight
 	if total < 99.9 || total > 100.1 { // Allow small floating point errors
 		return ErrInvalidWeights
@@ -40,6 +59,45 @@ func (sc *ScoringCriteria) ValidateWeights() error {
 	return nil
 }
 
+// TCO returns the model total cost of ownership is computed with
+func (sc *ScoringCriteria) TCO() TCOModel {
+	if sc.TCOModel != nil {
+		return *sc.TCOModel
+	}
+	return DefaultTCOModel()
+}
+
+// Effective returns the weighted criteria quotes are scored on. The legacy
+// quality weight is split evenly between warranty and supplier rating.
+func (sc *ScoringCriteria) Effective() []ScoringCriterion {
+	if len(sc.Criteria) > 0 {
+		return sc.Criteria
+	}
+
+	legacy := []ScoringCriterion{
+		{Key: "price", Kind: CriterionPrice, Label: "Price", Weight: sc.PriceWeight},
+		{Key: "warranty", Kind: CriterionWarranty, Label: "Warranty", Weight: sc.QualityWeight / 2},
+		{Key: "supplier_rating", Kind: CriterionSupplierRating, Label: "Supplier rating", Weight: sc.QualityWeight / 2},
+		{Key: "delivery", Kind: CriterionDelivery, Label: "Delivery", Weight: sc.DeliveryWeight},
+		{Key: "compliance", Kind: CriterionCompliance, Label: "Compliance", Weight: sc.ComplianceWeight},
+	}
+	criteria := []ScoringCriterion{}
+	for _, c := range legacy {
+		if c.Weight > 0 {
+			criteria = append(criteria, c)
+		}
+	}
+	return criteria
+}
+
+// CurrencyConversion records how a quote was converted to the RFQ's base currency
+type CurrencyConversion struct {
+	QuotedCurrency string        `json:"quoted_currency"`
+	QuotedAmount   money.Decimal `json:"quoted_amount"`
+	Rate           money.Decimal `json:"rate"`
+	RateDate       time.Time     `json:"rate_date"`
+}
+
 // QuoteScore represents the calculated score for a quote
 type QuoteScore struct {
 	QuoteID           string    `json:"quote_id"`
@@ -56,16 +114,28 @@ type QuoteScore struct {
 	Strengths         []string  `json:"strengths"`
 	Weaknesses        []string  `json:"weaknesses"`
 	Recommendation    string    `json:"recommendation"`
+	RFQVersion        int       `json:"rfq_version,omitempty"`  // RFQ version the quote responds to
+	StaleVersion      bool      `json:"stale_version"`          // Quote predates the latest RFQ addendum
+	Criteria          []CriterionScore `json:"criteria,omitempty"` // Per-criterion scores and explanations
+	Currency          string              `json:"currency,omitempty"`   // Currency of all amounts, the RFQ's base currency
+	Conversion        *CurrencyConversion `json:"conversion,omitempty"` // Set when the quote was priced in another currency
 	CalculatedAt      time.Time `json:"calculated_at"`
 }
 
-// PriceDifference represents price comparison between quotes
+// PriceDifference represents price comparison between quotes, on
+// acquisition price and on total cost of ownership
 type PriceDifference struct {
 	QuoteID         string  `json:"quote_id"`
 	QuoteNumber     string  `json:"quote_number"`
 	TotalAmount     float64 `json:"total_amount"`
+	Currency        string  `json:"currency,omitempty"`
 	DifferenceFromLowest  float64 `json:"difference_from_lowest"`   // Absolute difference
 	PercentageFromLowest  float64 `json:"percentage_from_lowest"`   // Percentage difference
+
+	TCO                      float64       `json:"tco"`                          // Net present value over the TCO horizon
+	TCODifferenceFromLowest  float64       `json:"tco_difference_from_lowest"`
+	TCOPercentageFromLowest  float64       `json:"tco_percentage_from_lowest"`
+	TCOBreakdown             *TCOBreakdown `json:"tco_breakdown,omitempty"`      // Unset when the quote gave its TCO directly
 }
 
 // ItemComparison represents comparison of specific items across quotes
@@ -73,6 +143,8 @@ type ItemComparison struct {
 	EquipmentID     string                 `json:"equipment_id"`
 	EquipmentName   string                 `json:"equipment_name"`
 	Quotes          map[string]ItemDetails `json:"quotes"` // quote_id -> details
+	LowestPriceQuote string                `json:"lowest_price_quote"`
+	LowestTCOQuote   string                `json:"lowest_tco_quote"`
 }
 
 // ItemDetails contains item-specific details for comparison
@@ -85,6 +157,8 @@ type ItemDetails struct {
 	ModelNumber       string  `json:"model_number"`
 	Specifications    string  `json:"specifications"`
 	ComplianceCerts   string  `json:"compliance_certs"`
+	TCO               float64       `json:"tco"`                     // Line total plus running costs over the TCO horizon
+	TCOBreakdown      *TCOBreakdown `json:"tco_breakdown,omitempty"`
 }
 
 // Comparison is the aggregate root for quote comparisons
@@ -282,6 +356,17 @@ func (c *Comparison) GetQuoteScore(quoteID string) (*QuoteScore, error) {
 	return nil, errors.New("score not found for quote")
 }
 
+// StaleQuoteIDs returns the quotes that respond to a superseded RFQ version
+func (c *Comparison) StaleQuoteIDs() []string {
+	stale := []string{}
+	for _, score := range c.QuoteScores {
+		if score.StaleVersion {
+			stale = append(stale, score.QuoteID)
+		}
+	}
+	return stale
+}
+
 // IsQuoteIncluded checks if a quote is included in the comparison
 func (c *Comparison) IsQuoteIncluded(quoteID string) bool {
 	for _, id := range c.QuoteIDs {
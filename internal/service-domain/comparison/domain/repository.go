@@ -1,6 +1,11 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+)
 
 // Repository defines the interface for comparison persistence
 type Repository interface {
@@ -23,6 +28,26 @@ type Repository interface {
 	Delete(ctx context.Context, tenantID, id string) error
 }
 
+// RFQReader looks up the RFQ state that governs how its quotes may be compared
+type RFQReader interface {
+	// QuoteVersions returns the RFQ's current version and the version each quote is pinned to
+	QuoteVersions(ctx context.Context, tenantID, rfqID string, quoteIDs []string) (int, map[string]int, error)
+
+	// BidsSealed reports whether the RFQ's sealed bids are still awaiting opening
+	BidsSealed(ctx context.Context, tenantID, rfqID string) (bool, error)
+
+	// BaseCurrency returns the currency the RFQ's quotes are compared in and
+	// the date of the exchange rates converting them
+	BaseCurrency(ctx context.Context, tenantID, rfqID string) (string, time.Time, error)
+}
+
+// ExchangeRateReader looks up exchange rates recorded for the tenant
+type ExchangeRateReader interface {
+	// Rate returns the rate converting from one currency to another on a day,
+	// and the date of that rate; ErrMissingExchangeRate if none is recorded
+	Rate(ctx context.Context, tenantID, from, to string, day time.Time) (money.Decimal, time.Time, error)
+}
+
 // ListCriteria defines filtering criteria for listing comparisons
 type ListCriteria struct {
 	TenantID    string
@@ -0,0 +1,343 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("scoring template not found")
+	ErrInvalidTemplate  = errors.New("invalid scoring template")
+)
+
+// CriterionKind identifies the metric a scoring criterion rates
+type CriterionKind string
+
+const (
+	CriterionPrice           CriterionKind = "price"              // Quote total, lower is better
+	CriterionTCO             CriterionKind = "tco"                // Total cost of ownership, lower is better
+	CriterionDelivery        CriterionKind = "delivery"           // Delivery days, lower is better
+	CriterionWarranty        CriterionKind = "warranty"           // Warranty years, higher is better
+	CriterionSupplierRating  CriterionKind = "supplier_rating"    // Supplier rating 0-5
+	CriterionPastPerformance CriterionKind = "past_performance"   // Share of past contracts completed, 0-100
+	CriterionCompliance      CriterionKind = "compliance"         // Certifications held
+	CriterionScorecard       CriterionKind = "supplier_scorecard" // Supplier scorecard overall score, 0-100
+)
+
+// ScoringCriterion is one weighted criterion of a scoring template. An input
+// equal to Best scores 100 and one equal to Worst scores 0; without bounds
+// quotes are scored relative to each other.
+type ScoringCriterion struct {
+	Key           string        `json:"key"` // Unique within the template
+	Kind          CriterionKind `json:"kind"`
+	Label         string        `json:"label,omitempty"`
+	Weight        float64       `json:"weight"` // 0-100, weights of a template sum to 100
+	Best          *float64      `json:"best,omitempty"`
+	Worst         *float64      `json:"worst,omitempty"`
+	RequiredCerts []string      `json:"required_certs,omitempty"` // Compliance: certifications that must be held
+}
+
+// DisplayName returns the criterion label, defaulting to its key
+func (c ScoringCriterion) DisplayName() string {
+	if c.Label != "" {
+		return c.Label
+	}
+	return c.Key
+}
+
+// ScoringInput holds the structured, numeric data of one quote that
+// criteria are scored on. Nil values mean the input was not provided.
+type ScoringInput struct {
+	QuoteID         string   `json:"quote_id"`
+	SupplierID      string   `json:"supplier_id"`
+	TotalAmount     float64  `json:"total_amount"`
+	TCO             *float64 `json:"tco,omitempty"`
+	DeliveryDays    *float64 `json:"delivery_days,omitempty"`
+	WarrantyYears   *float64 `json:"warranty_years,omitempty"`
+	SupplierRating  *float64 `json:"supplier_rating,omitempty"`
+	PastPerformance *float64 `json:"past_performance,omitempty"`
+	Scorecard       *float64 `json:"supplier_scorecard,omitempty"`
+	Certifications  []string `json:"certifications,omitempty"`
+}
+
+// CriterionScore explains how a quote scored on one criterion
+type CriterionScore struct {
+	Key         string        `json:"key"`
+	Kind        CriterionKind `json:"kind"`
+	Label       string        `json:"label"`
+	Weight      float64       `json:"weight"`
+	Input       *float64      `json:"input,omitempty"`
+	Score       float64       `json:"score"`    // 0-100
+	Weighted    float64       `json:"weighted"` // Contribution to the overall score
+	Explanation string        `json:"explanation"`
+}
+
+// Metric describes how a criterion kind reads and rates its input. New
+// criterion kinds are added by registering a metric.
+type Metric struct {
+	Kind           CriterionKind
+	Unit           string
+	HigherIsBetter bool
+	DefaultBest    *float64 // Used when a criterion sets no bounds; nil means relative scoring
+	DefaultWorst   *float64
+	Value          func(c ScoringCriterion, in ScoringInput) (float64, bool)
+}
+
+var metrics = map[CriterionKind]Metric{}
+
+// RegisterMetric adds or replaces the metric of a criterion kind
+func RegisterMetric(m Metric) {
+	metrics[m.Kind] = m
+}
+
+// LookupMetric returns the metric of a criterion kind
+func LookupMetric(kind CriterionKind) (Metric, bool) {
+	m, ok := metrics[kind]
+	return m, ok
+}
+
+func bound(v float64) *float64 { return &v }
+
+func init() {
+	RegisterMetric(Metric{Kind: CriterionPrice, Unit: "total",
+		Value: func(_ ScoringCriterion, in ScoringInput) (float64, bool) { return in.TotalAmount, true }})
+	RegisterMetric(Metric{Kind: CriterionTCO, Unit: "TCO",
+		Value: func(_ ScoringCriterion, in ScoringInput) (float64, bool) { return deref(in.TCO) }})
+	RegisterMetric(Metric{Kind: CriterionDelivery, Unit: "days",
+		Value: func(_ ScoringCriterion, in ScoringInput) (float64, bool) { return deref(in.DeliveryDays) }})
+	RegisterMetric(Metric{Kind: CriterionWarranty, Unit: "years", HigherIsBetter: true,
+		Value: func(_ ScoringCriterion, in ScoringInput) (float64, bool) { return deref(in.WarrantyYears) }})
+	RegisterMetric(Metric{Kind: CriterionSupplierRating, Unit: "/5", HigherIsBetter: true,
+		DefaultBest: bound(5), DefaultWorst: bound(0),
+		Value: func(_ ScoringCriterion, in ScoringInput) (float64, bool) { return deref(in.SupplierRating) }})
+	RegisterMetric(Metric{Kind: CriterionPastPerformance, Unit: "%", HigherIsBetter: true,
+		DefaultBest: bound(100), DefaultWorst: bound(0),
+		Value: func(_ ScoringCriterion, in ScoringInput) (float64, bool) { return deref(in.PastPerformance) }})
+	RegisterMetric(Metric{Kind: CriterionScorecard, Unit: "/100", HigherIsBetter: true,
+		DefaultBest: bound(100), DefaultWorst: bound(0),
+		Value: func(_ ScoringCriterion, in ScoringInput) (float64, bool) { return deref(in.Scorecard) }})
+	RegisterMetric(Metric{Kind: CriterionCompliance, Unit: "certifications", HigherIsBetter: true,
+		Value: complianceValue})
+}
+
+func deref(v *float64) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	return *v, true
+}
+
+// complianceValue is the share of required certifications held, or the
+// number of certifications when none are required
+func complianceValue(c ScoringCriterion, in ScoringInput) (float64, bool) {
+	held := make(map[string]bool, len(in.Certifications))
+	for _, cert := range in.Certifications {
+		held[strings.ToLower(strings.TrimSpace(cert))] = true
+	}
+	if len(c.RequiredCerts) == 0 {
+		return float64(len(held)), true
+	}
+	covered := 0
+	for _, cert := range c.RequiredCerts {
+		if held[strings.ToLower(strings.TrimSpace(cert))] {
+			covered++
+		}
+	}
+	return float64(covered) / float64(len(c.RequiredCerts)) * 100, true
+}
+
+// ValidateCriteria checks that criteria are known, uniquely keyed and weigh 100 in total
+func ValidateCriteria(criteria []ScoringCriterion) error {
+	if len(criteria) == 0 {
+		return fmt.Errorf("%w: at least one criterion is required", ErrInvalidTemplate)
+	}
+	keys := make(map[string]bool, len(criteria))
+	total := 0.0
+	for _, c := range criteria {
+		if c.Key == "" {
+			return fmt.Errorf("%w: criterion key is required", ErrInvalidTemplate)
+		}
+		if keys[c.Key] {
+			return fmt.Errorf("%w: duplicate criterion %q", ErrInvalidTemplate, c.Key)
+		}
+		keys[c.Key] = true
+		if _, ok := LookupMetric(c.Kind); !ok {
+			return fmt.Errorf("%w: unknown criterion kind %q", ErrInvalidTemplate, c.Kind)
+		}
+		if c.Weight < 0 {
+			return fmt.Errorf("%w: criterion %q has a negative weight", ErrInvalidTemplate, c.Key)
+		}
+		if c.Best != nil && c.Worst != nil && *c.Best == *c.Worst {
+			return fmt.Errorf("%w: criterion %q has equal best and worst bounds", ErrInvalidTemplate, c.Key)
+		}
+		total += c.Weight
+	}
+	if total < 99.9 || total > 100.1 {
+		return ErrInvalidWeights
+	}
+	return nil
+}
+
+// ScoreQuotes rates every input on every criterion. It returns the criterion
+// scores per input, in criterion order, and the weighted overall scores.
+func ScoreQuotes(criteria []ScoringCriterion, inputs []ScoringInput) ([][]CriterionScore, []float64) {
+	results := make([][]CriterionScore, len(inputs))
+	overall := make([]float64, len(inputs))
+	for i := range inputs {
+		results[i] = make([]CriterionScore, 0, len(criteria))
+	}
+
+	for _, c := range criteria {
+		metric, ok := LookupMetric(c.Kind)
+		if !ok {
+			continue
+		}
+
+		values := make([]*float64, len(inputs))
+		for i, in := range inputs {
+			if v, ok := metric.Value(c, in); ok {
+				values[i] = bound(v)
+			}
+		}
+		best, worst := scoringBounds(c, metric, values)
+
+		for i := range inputs {
+			cs := CriterionScore{Key: c.Key, Kind: c.Kind, Label: c.DisplayName(), Weight: c.Weight, Input: values[i]}
+			if values[i] == nil {
+				cs.Explanation = fmt.Sprintf("No %s provided", metric.Unit)
+			} else {
+				cs.Score = scaleScore(*values[i], best, worst)
+				cs.Explanation = explainScore(c, metric, *values[i], best, worst)
+			}
+			cs.Weighted = cs.Score * c.Weight / 100.0
+			overall[i] += cs.Weighted
+			results[i] = append(results[i], cs)
+		}
+	}
+
+	return results, overall
+}
+
+// scoringBounds returns the inputs scoring 100 and 0: the criterion's own
+// bounds, the metric's defaults, or the best and worst provided input
+func scoringBounds(c ScoringCriterion, m Metric, values []*float64) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if v != nil {
+			lo, hi = math.Min(lo, *v), math.Max(hi, *v)
+		}
+	}
+	best, worst := lo, hi
+	if m.HigherIsBetter {
+		best, worst = hi, lo
+	}
+	if c.Kind == CriterionCompliance && len(c.RequiredCerts) > 0 {
+		best, worst = 100, 0
+	}
+	if m.DefaultBest != nil {
+		best = *m.DefaultBest
+	}
+	if m.DefaultWorst != nil {
+		worst = *m.DefaultWorst
+	}
+	if c.Best != nil {
+		best = *c.Best
+	}
+	if c.Worst != nil {
+		worst = *c.Worst
+	}
+	return best, worst
+}
+
+// scaleScore maps an input linearly between worst (0) and best (100)
+func scaleScore(v, best, worst float64) float64 {
+	if best == worst || math.IsInf(best, 0) || math.IsInf(worst, 0) {
+		return 100.0
+	}
+	score := (v - worst) / (best - worst) * 100.0
+	return math.Max(0, math.Min(100, score))
+}
+
+func explainScore(c ScoringCriterion, m Metric, v, best, worst float64) string {
+	if c.Kind == CriterionCompliance && len(c.RequiredCerts) > 0 {
+		return fmt.Sprintf("Holds %.0f%% of required certifications (%s)", v, strings.Join(c.RequiredCerts, ", "))
+	}
+	if best == worst {
+		return fmt.Sprintf("%s %s; all quotes are equal", formatInput(v), m.Unit)
+	}
+	return fmt.Sprintf("%s %s on a scale from %s (worst) to %s (best)",
+		formatInput(v), m.Unit, formatInput(worst), formatInput(best))
+}
+
+func formatInput(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+// ScoringTemplate is a named, reusable set of weighted criteria
+type ScoringTemplate struct {
+	ID          string             `json:"id"`
+	TenantID    string             `json:"tenant_id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Criteria    []ScoringCriterion `json:"criteria"`
+	IsDefault   bool               `json:"is_default"` // Applied to new comparisons without a template
+	CreatedBy   string             `json:"created_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// Validate checks the template's name and criteria
+func (t *ScoringTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTemplate)
+	}
+	return ValidateCriteria(t.Criteria)
+}
+
+// SortedByWeight returns the criterion scores, heaviest first
+func SortedByWeight(scores []CriterionScore) []CriterionScore {
+	sorted := append([]CriterionScore(nil), scores...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Weight > sorted[j].Weight })
+	return sorted
+}
+
+// TemplateRepository defines persistence for scoring templates
+type TemplateRepository interface {
+	// Create persists a new template
+	Create(ctx context.Context, template *ScoringTemplate) error
+
+	// GetByID retrieves a template by ID
+	GetByID(ctx context.Context, tenantID, id string) (*ScoringTemplate, error)
+
+	// GetDefault retrieves the tenant's default template, or ErrTemplateNotFound
+	GetDefault(ctx context.Context, tenantID string) (*ScoringTemplate, error)
+
+	// List retrieves all templates of a tenant
+	List(ctx context.Context, tenantID string) ([]*ScoringTemplate, error)
+
+	// Update saves a template; making it the default clears the previous default
+	Update(ctx context.Context, template *ScoringTemplate) error
+
+	// Delete deletes a template
+	Delete(ctx context.Context, tenantID, id string) error
+}
+
+// SupplierPerformance is the track record of a supplier used for scoring
+type SupplierPerformance struct {
+	Rating          *float64 // 0-5
+	PastPerformance *float64 // Share of finished contracts completed, 0-100
+	Scorecard       *float64 // Overall supplier scorecard score, 0-100
+}
+
+// SupplierPerformanceReader looks up supplier ratings, past contract performance and scorecards
+type SupplierPerformanceReader interface {
+	SupplierPerformance(ctx context.Context, tenantID string, supplierIDs []string) (map[string]SupplierPerformance, error)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestScoreQuotes(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	criteria := []ScoringCriterion{
+		{Key: "price", Kind: CriterionPrice, Weight: 50},
+		{Key: "warranty", Kind: CriterionWarranty, Weight: 20, Best: f(5), Worst: f(0)},
+		{Key: "certs", Kind: CriterionCompliance, Weight: 30, RequiredCerts: []string{"FDA", "CE"}},
+	}
+	if err := ValidateCriteria(criteria); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inputs := []ScoringInput{
+		{QuoteID: "a", TotalAmount: 1000, WarrantyYears: f(5), Certifications: []string{"fda", "CE"}},
+		{QuoteID: "b", TotalAmount: 1500, WarrantyYears: f(2), Certifications: []string{"FDA"}},
+		{QuoteID: "c", TotalAmount: 2000},
+	}
+	results, overall := ScoreQuotes(criteria, inputs)
+
+	want := []float64{100, 50*0.5 + 40*0.2 + 50*0.3, 0}
+	for i := range want {
+		if math.Abs(overall[i]-want[i]) > 1e-9 {
+			t.Errorf("quote %s: expected overall %.2f, got %.2f", inputs[i].QuoteID, want[i], overall[i])
+		}
+	}
+	if c := results[2][1]; c.Input != nil || c.Score != 0 || c.Explanation == "" {
+		t.Errorf("expected a missing warranty to score 0 with an explanation, got %+v", c)
+	}
+}
+
+func TestValidateCriteria(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria []ScoringCriterion
+		wantErr  error
+	}{
+		{"unknown kind", []ScoringCriterion{{Key: "x", Kind: "colour", Weight: 100}}, ErrInvalidTemplate},
+		{"duplicate key", []ScoringCriterion{
+			{Key: "p", Kind: CriterionPrice, Weight: 50},
+			{Key: "p", Kind: CriterionTCO, Weight: 50},
+		}, ErrInvalidTemplate},
+		{"weights below 100", []ScoringCriterion{{Key: "p", Kind: CriterionPrice, Weight: 90}}, ErrInvalidWeights},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCriteria(tt.criteria); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrInvalidTCOModel = errors.New("invalid TCO model")
+
+const (
+	DefaultTCOHorizonYears = 5
+	DefaultTCODiscountRate = 0.08
+	maxTCOHorizonYears     = 30
+)
+
+// TCOModel configures how total cost of ownership is computed for a
+// comparison. Running costs are discounted to their net present value over
+// the horizon; the acquisition and trade-in credit are taken at year zero.
+type TCOModel struct {
+	HorizonYears        int     `json:"horizon_years"`          // Years of ownership to cost
+	DiscountRate        float64 `json:"discount_rate"`          // Annual rate as a fraction, e.g. 0.08
+	DowntimeCostPerHour float64 `json:"downtime_cost_per_hour"` // Lost revenue per hour out of service
+	EnergyCostPerKWh    float64 `json:"energy_cost_per_kwh"`
+}
+
+// DefaultTCOModel returns the model used when a comparison does not set one
+func DefaultTCOModel() TCOModel {
+	return TCOModel{HorizonYears: DefaultTCOHorizonYears, DiscountRate: DefaultTCODiscountRate}
+}
+
+// Validate checks the model's horizon and rates
+func (m TCOModel) Validate() error {
+	if m.HorizonYears < 1 || m.HorizonYears > maxTCOHorizonYears {
+		return ErrInvalidTCOModel
+	}
+	if m.DiscountRate < 0 || m.DiscountRate >= 1 || m.DowntimeCostPerHour < 0 || m.EnergyCostPerKWh < 0 {
+		return ErrInvalidTCOModel
+	}
+	return nil
+}
+
+// OwnershipCosts are the costs a quote declares beyond its acquisition price
+type OwnershipCosts struct {
+	AMCByYear             []float64 `json:"amc_by_year,omitempty"` // Annual maintenance contract price per year; the last year repeats
+	ConsumablesPerYear    float64   `json:"consumables_per_year,omitempty"`
+	DowntimeHoursPerYear  float64   `json:"downtime_hours_per_year,omitempty"` // Expected hours out of service
+	PowerKW               float64   `json:"power_kw,omitempty"`                // Average power draw while operating
+	OperatingHoursPerYear float64   `json:"operating_hours_per_year,omitempty"`
+	TradeInCredit         float64   `json:"trade_in_credit,omitempty"` // Credit for the equipment being replaced
+}
+
+// Add returns the sum of two sets of ownership costs. AMC prices are added
+// year by year.
+func (o OwnershipCosts) Add(other OwnershipCosts) OwnershipCosts {
+	sum := OwnershipCosts{
+		ConsumablesPerYear:   o.ConsumablesPerYear + other.ConsumablesPerYear,
+		DowntimeHoursPerYear: o.DowntimeHoursPerYear + other.DowntimeHoursPerYear,
+		TradeInCredit:        o.TradeInCredit + other.TradeInCredit,
+	}
+	// Power is summed as energy so differing operating hours are kept
+	energy := o.PowerKW*o.OperatingHoursPerYear + other.PowerKW*other.OperatingHoursPerYear
+	if energy > 0 {
+		sum.OperatingHoursPerYear = math.Max(o.OperatingHoursPerYear, other.OperatingHoursPerYear)
+		sum.PowerKW = energy / sum.OperatingHoursPerYear
+	}
+	years := len(o.AMCByYear)
+	if len(other.AMCByYear) > years {
+		years = len(other.AMCByYear)
+	}
+	for year := 1; year <= years; year++ {
+		sum.AMCByYear = append(sum.AMCByYear, o.amcFor(year)+other.amcFor(year))
+	}
+	return sum
+}
+
+// amcFor returns the AMC price of a year (1-based); years past the schedule repeat its last price
+func (o OwnershipCosts) amcFor(year int) float64 {
+	if len(o.AMCByYear) == 0 {
+		return 0
+	}
+	if year > len(o.AMCByYear) {
+		return o.AMCByYear[len(o.AMCByYear)-1]
+	}
+	return o.AMCByYear[year-1]
+}
+
+// TCOBreakdown is a total cost of ownership with each cost at its present value
+type TCOBreakdown struct {
+	Acquisition   float64 `json:"acquisition"`
+	TradeInCredit float64 `json:"trade_in_credit"`
+	AMC           float64 `json:"amc"`
+	Consumables   float64 `json:"consumables"`
+	Downtime      float64 `json:"downtime"`
+	Power         float64 `json:"power"`
+	Total         float64 `json:"total"` // Net present value of all of the above
+	HorizonYears  int     `json:"horizon_years"`
+	DiscountRate  float64 `json:"discount_rate"`
+}
+
+// ComputeTCO returns the net present value of owning equipment bought for
+// acquisition with the given running costs, under the model
+func ComputeTCO(acquisition float64, costs OwnershipCosts, model TCOModel) TCOBreakdown {
+	b := TCOBreakdown{
+		Acquisition:   acquisition,
+		TradeInCredit: costs.TradeInCredit,
+		HorizonYears:  model.HorizonYears,
+		DiscountRate:  model.DiscountRate,
+	}
+
+	// Running costs are paid at the end of each year
+	for year := 1; year <= model.HorizonYears; year++ {
+		discount := math.Pow(1+model.DiscountRate, float64(year))
+		b.AMC += costs.amcFor(year) / discount
+		b.Consumables += costs.ConsumablesPerYear / discount
+		b.Downtime += costs.DowntimeHoursPerYear * model.DowntimeCostPerHour / discount
+		b.Power += costs.PowerKW * costs.OperatingHoursPerYear * model.EnergyCostPerKWh / discount
+	}
+
+	b.AMC = roundCents(b.AMC)
+	b.Consumables = roundCents(b.Consumables)
+	b.Downtime = roundCents(b.Downtime)
+	b.Power = roundCents(b.Power)
+	b.Total = roundCents(b.Acquisition - b.TradeInCredit + b.AMC + b.Consumables + b.Downtime + b.Power)
+	return b
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestComputeTCO(t *testing.T) {
+	model := TCOModel{HorizonYears: 2, DiscountRate: 0.1, DowntimeCostPerHour: 50}
+	costs := OwnershipCosts{
+		AMCByYear:            []float64{0, 110}, // First year under warranty
+		DowntimeHoursPerYear: 10,
+		TradeInCredit:        100,
+	}
+
+	b := ComputeTCO(1000, costs, model)
+	if b.AMC != 90.91 || b.Downtime != 867.77 {
+		t.Fatalf("unexpected discounted costs: %+v", b)
+	}
+	if b.Total != 1858.68 {
+		t.Errorf("expected total 1858.68, got %.2f", b.Total)
+	}
+
+	// The last AMC price repeats past the schedule
+	if got := costs.amcFor(5); got != 110 {
+		t.Errorf("expected AMC 110 in year 5, got %.2f", got)
+	}
+}
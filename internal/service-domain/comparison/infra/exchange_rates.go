@@ -0,0 +1,57 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	"github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// ExchangeRateRepository implements domain.ExchangeRateReader over the exchange_rates table
+type ExchangeRateRepository struct {
+	db *PostgresDB
+}
+
+// NewExchangeRateRepository creates a new exchange rate reader
+func NewExchangeRateRepository(db *PostgresDB) *ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+// Rate returns the latest rate dated on or before the day in either
+// direction, inverting a reverse-pair rate. A direct rate wins only when both
+// are dated the same day, so a stale direct rate never hides a newer inverse.
+func (r *ExchangeRateRepository) Rate(ctx context.Context, tenantID, from, to string, day time.Time) (money.Decimal, time.Time, error) {
+	var value string
+	var rateDate time.Time
+	var direct bool
+	err := r.db.pool.QueryRow(ctx, `
+		SELECT rate::text, rate_date, from_currency = $2
+		FROM exchange_rates
+		WHERE tenant_id = $1 AND rate_date <= $4
+			AND ((from_currency = $2 AND to_currency = $3) OR (from_currency = $3 AND to_currency = $2))
+		ORDER BY rate_date DESC, from_currency = $2 DESC
+		LIMIT 1
+	`, tenantID, from, to, day).Scan(&value, &rateDate, &direct)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return money.Zero, time.Time{}, fmt.Errorf("%w: %s to %s on %s", domain.ErrMissingExchangeRate, from, to, day.Format("2006-01-02"))
+		}
+		return money.Zero, time.Time{}, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	rate, err := money.Parse(value)
+	if err != nil {
+		return money.Zero, time.Time{}, err
+	}
+	if !direct {
+		rate = money.NewFromInt(1).Div(rate, rateScale)
+	}
+	return rate, rateDate, nil
+}
+
+// rateScale matches the precision exchange rates are stored with
+const rateScale = 10
@@ -44,6 +44,15 @@ func (db *PostgresDB) Pool() *pgxpool.Pool {
 	return db.pool
 }
 
+// NewPostgresDBFromPool wraps a connection pool owned by another module; the
+// owner keeps responsibility for closing it.
+func NewPostgresDBFromPool(pool *pgxpool.Pool, logger *slog.Logger) *PostgresDB {
+	return &PostgresDB{
+		pool:   pool,
+		logger: logger.With(slog.String("component", "postgres_db")),
+	}
+}
+
 // Close closes the database connection pool
 func (db *PostgresDB) Close() {
 	if db.pool != nil {
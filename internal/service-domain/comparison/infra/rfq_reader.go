@@ -0,0 +1,87 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RFQReaderRepository implements domain.RFQReader over the rfqs and quotes tables
+type RFQReaderRepository struct {
+	db *PostgresDB
+}
+
+// NewRFQReaderRepository creates a new RFQ reader
+func NewRFQReaderRepository(db *PostgresDB) *RFQReaderRepository {
+	return &RFQReaderRepository{db: db}
+}
+
+// QuoteVersions returns the RFQ's current version and the version each quote is pinned to
+func (r *RFQReaderRepository) QuoteVersions(ctx context.Context, tenantID, rfqID string, quoteIDs []string) (int, map[string]int, error) {
+	var current int
+	err := r.db.pool.QueryRow(ctx,
+		`SELECT version FROM rfqs WHERE id = $1 AND tenant_id = $2`,
+		rfqID, tenantID,
+	).Scan(&current)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get rfq version: %w", err)
+	}
+
+	rows, err := r.db.pool.Query(ctx,
+		`SELECT id, rfq_version FROM quotes WHERE rfq_id = $1 AND tenant_id = $2 AND id = ANY($3)`,
+		rfqID, tenantID, quoteIDs,
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get quote versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[string]int, len(quoteIDs))
+	for rows.Next() {
+		var id string
+		var version int
+		if err := rows.Scan(&id, &version); err != nil {
+			return 0, nil, fmt.Errorf("failed to scan quote version: %w", err)
+		}
+		versions[id] = version
+	}
+
+	return current, versions, rows.Err()
+}
+
+// BidsSealed reports whether the RFQ's sealed bids are still awaiting opening
+func (r *RFQReaderRepository) BidsSealed(ctx context.Context, tenantID, rfqID string) (bool, error) {
+	var sealed bool
+	err := r.db.pool.QueryRow(ctx,
+		`SELECT sealed_bid AND bids_opened_at IS NULL FROM rfqs WHERE id = $1 AND tenant_id = $2`,
+		rfqID, tenantID,
+	).Scan(&sealed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get rfq seal state: %w", err)
+	}
+	return sealed, nil
+}
+
+// BaseCurrency returns the RFQ's base currency and its rate date, which
+// defaults to the response deadline
+func (r *RFQReaderRepository) BaseCurrency(ctx context.Context, tenantID, rfqID string) (string, time.Time, error) {
+	var currency string
+	var rateDate time.Time
+	err := r.db.pool.QueryRow(ctx,
+		`SELECT currency, COALESCE(rate_date, response_deadline) FROM rfqs WHERE id = $1 AND tenant_id = $2`,
+		rfqID, tenantID,
+	).Scan(&currency, &rateDate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", time.Time{}, nil
+		}
+		return "", time.Time{}, fmt.Errorf("failed to get rfq currency: %w", err)
+	}
+	return currency, rateDate, nil
+}
@@ -0,0 +1,76 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+	supplierDomain "github.com/aby-med/medical-platform/internal/service-domain/supplier/domain"
+)
+
+// SupplierPerformanceRepository implements domain.SupplierPerformanceReader
+// over the suppliers and contracts tables and the supplier scorecards
+type SupplierPerformanceRepository struct {
+	db         *PostgresDB
+	scorecards supplierDomain.ScorecardRepository
+}
+
+// NewSupplierPerformanceRepository creates a new supplier performance reader.
+// Scorecards are optional.
+func NewSupplierPerformanceRepository(db *PostgresDB, scorecards supplierDomain.ScorecardRepository) *SupplierPerformanceRepository {
+	return &SupplierPerformanceRepository{db: db, scorecards: scorecards}
+}
+
+// SupplierPerformance returns each supplier's rating, the share of its
+// finished contracts (completed, cancelled or suspended) that were completed
+// and its overall scorecard score
+func (r *SupplierPerformanceRepository) SupplierPerformance(ctx context.Context, tenantID string, supplierIDs []string) (map[string]domain.SupplierPerformance, error) {
+	rows, err := r.db.Pool().Query(ctx, `
+		SELECT s.id, s.performance_rating,
+			COUNT(c.id) FILTER (WHERE c.status = 'completed'),
+			COUNT(c.id) FILTER (WHERE c.status IN ('completed', 'cancelled', 'suspended'))
+		FROM suppliers s
+		LEFT JOIN contracts c ON c.supplier_id = s.id AND c.tenant_id = s.tenant_id
+		WHERE s.tenant_id = $1 AND s.id = ANY($2)
+		GROUP BY s.id, s.performance_rating
+	`, tenantID, supplierIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supplier performance: %w", err)
+	}
+	defer rows.Close()
+
+	performance := make(map[string]domain.SupplierPerformance, len(supplierIDs))
+	for rows.Next() {
+		var id string
+		var rating *float64
+		var completed, finished int
+		if err := rows.Scan(&id, &rating, &completed, &finished); err != nil {
+			return nil, fmt.Errorf("failed to scan supplier performance: %w", err)
+		}
+
+		p := domain.SupplierPerformance{Rating: rating}
+		if finished > 0 {
+			share := float64(completed) / float64(finished) * 100
+			p.PastPerformance = &share
+		}
+		performance[id] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if r.scorecards == nil || len(performance) == 0 {
+		return performance, nil
+	}
+	scorecards, err := r.scorecards.Scorecards(ctx, tenantID, supplierIDs, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supplier scorecards: %w", err)
+	}
+	for id, sc := range scorecards {
+		if p, ok := performance[id]; ok {
+			p.Scorecard = sc.OverallScore
+			performance[id] = p
+		}
+	}
+	return performance, nil
+}
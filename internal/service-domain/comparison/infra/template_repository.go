@@ -0,0 +1,174 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// TemplateRepository implements domain.TemplateRepository using PostgreSQL
+type TemplateRepository struct {
+	db     *PostgresDB
+	logger *slog.Logger
+}
+
+// NewTemplateRepository creates a new scoring template repository
+func NewTemplateRepository(db *PostgresDB, logger *slog.Logger) *TemplateRepository {
+	return &TemplateRepository{
+		db:     db,
+		logger: logger.With(slog.String("component", "scoring_template_repository")),
+	}
+}
+
+const templateColumns = `id, tenant_id, name, COALESCE(description, ''), criteria, is_default, created_by, created_at, updated_at`
+
+// Create persists a new template
+func (r *TemplateRepository) Create(ctx context.Context, t *domain.ScoringTemplate) error {
+	criteria, err := json.Marshal(t.Criteria)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scoring criteria: %w", err)
+	}
+
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := clearDefault(ctx, tx, t); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO comparison_scoring_templates (
+			id, tenant_id, name, description, criteria, is_default, created_by, created_at, updated_at
+		) VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9)
+	`, t.ID, t.TenantID, t.Name, t.Description, criteria, t.IsDefault, t.CreatedBy, t.CreatedAt, t.UpdatedAt)
+	if err != nil {
+		r.logger.Error("Failed to create scoring template", slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create scoring template: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetByID retrieves a template by ID
+func (r *TemplateRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.ScoringTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM comparison_scoring_templates WHERE tenant_id = $1 AND id = $2`
+	return r.get(ctx, query, tenantID, id)
+}
+
+// GetDefault retrieves the tenant's default template
+func (r *TemplateRepository) GetDefault(ctx context.Context, tenantID string) (*domain.ScoringTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM comparison_scoring_templates WHERE tenant_id = $1 AND is_default`
+	return r.get(ctx, query, tenantID)
+}
+
+// List retrieves all templates of a tenant
+func (r *TemplateRepository) List(ctx context.Context, tenantID string) ([]*domain.ScoringTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM comparison_scoring_templates WHERE tenant_id = $1 ORDER BY is_default DESC, name ASC`
+	rows, err := r.db.Pool().Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scoring templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*domain.ScoringTemplate{}
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scoring template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// Update saves a template; making it the default clears the previous default
+func (r *TemplateRepository) Update(ctx context.Context, t *domain.ScoringTemplate) error {
+	criteria, err := json.Marshal(t.Criteria)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scoring criteria: %w", err)
+	}
+
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := clearDefault(ctx, tx, t); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE comparison_scoring_templates SET
+			name = $1, description = NULLIF($2, ''), criteria = $3, is_default = $4, updated_at = $5
+		WHERE tenant_id = $6 AND id = $7
+	`, t.Name, t.Description, criteria, t.IsDefault, t.UpdatedAt, t.TenantID, t.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update scoring template: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrTemplateNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Delete deletes a template
+func (r *TemplateRepository) Delete(ctx context.Context, tenantID, id string) error {
+	result, err := r.db.Pool().Exec(ctx,
+		`DELETE FROM comparison_scoring_templates WHERE tenant_id = $1 AND id = $2`, tenantID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete scoring template: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrTemplateNotFound
+	}
+	return nil
+}
+
+func (r *TemplateRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.ScoringTemplate, error) {
+	t, err := scanTemplate(r.db.Pool().QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get scoring template: %w", err)
+	}
+	return t, nil
+}
+
+// clearDefault unsets the tenant's current default when t becomes the default
+func clearDefault(ctx context.Context, tx pgx.Tx, t *domain.ScoringTemplate) error {
+	if !t.IsDefault {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `
+		UPDATE comparison_scoring_templates SET is_default = FALSE
+		WHERE tenant_id = $1 AND id <> $2 AND is_default
+	`, t.TenantID, t.ID)
+	if err != nil {
+		return fmt.Errorf("failed to clear default scoring template: %w", err)
+	}
+	return nil
+}
+
+func scanTemplate(row pgx.Row) (*domain.ScoringTemplate, error) {
+	t := &domain.ScoringTemplate{}
+	var criteria []byte
+	err := row.Scan(&t.ID, &t.TenantID, &t.Name, &t.Description, &criteria, &t.IsDefault, &t.CreatedBy, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(criteria, &t.Criteria); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scoring criteria: %w", err)
+	}
+	return t, nil
+}
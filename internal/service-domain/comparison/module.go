@@ -8,6 +8,7 @@ import (
 	"github.com/aby-med/medical-platform/internal/service-domain/comparison/api"
 	"github.com/aby-med/medical-platform/internal/service-domain/comparison/app"
 	"github.com/aby-med/medical-platform/internal/service-domain/comparison/infra"
+	supplierInfra "github.com/aby-med/medical-platform/internal/service-domain/supplier/infra"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -45,7 +46,12 @@ func (m *Module) Initialize(ctx context.Context) error {
 
 	// Initialize layers
 	repo := infra.NewComparisonRepository(db, m.logger)
-	service := app.NewComparisonService(repo, m.logger)
+	rfqs := infra.NewRFQReaderRepository(db)
+	templates := infra.NewTemplateRepository(db, m.logger)
+	scorecards := supplierInfra.NewScorecardRepository(supplierInfra.NewPostgresDBFromPool(db.Pool(), m.logger), m.logger)
+	suppliers := infra.NewSupplierPerformanceRepository(db, scorecards)
+	rates := infra.NewExchangeRateRepository(db)
+	service := app.NewComparisonService(repo, rfqs, templates, suppliers, rates, m.logger)
 	m.handler = api.NewComparisonHandler(service, m.logger)
 
 	m.logger.Info("Comparison module initialized successfully")
@@ -54,7 +60,7 @@ func (m *Module) Initialize(ctx context.Context) error {
 
 // MountRoutes registers HTTP routes for the comparison module
 func (m *Module) MountRoutes(r chi.Router) {
-	m.logger.Info("Mounting comparison routes at /comparisons")
+	m.logger.Info("Mounting comparison routes at /comparisons and /scoring-templates")
 
 	r.Route("/comparisons", func(r chi.Router) {
 		// Core comparison operations
@@ -68,6 +74,7 @@ func (m *Module) MountRoutes(r chi.Router) {
 		r.Post("/{id}/quotes", m.handler.AddQuote)
 		r.Delete("/{id}/quotes/{quote_id}", m.handler.RemoveQuote)
 		r.Post("/{id}/scoring", m.handler.UpdateScoringCriteria)
+		r.Post("/{id}/template", m.handler.ApplyTemplate)
 		r.Post("/{id}/calculate", m.handler.CalculateScores)
 
 		// Lifecycle operations
@@ -76,6 +83,15 @@ func (m *Module) MountRoutes(r chi.Router) {
 		r.Post("/{id}/archive", m.handler.ArchiveComparison)
 	})
 
+	// Scoring templates
+	r.Route("/scoring-templates", func(r chi.Router) {
+		r.Post("/", m.handler.CreateTemplate)
+		r.Get("/", m.handler.ListTemplates)
+		r.Get("/{id}", m.handler.GetTemplate)
+		r.Put("/{id}", m.handler.UpdateTemplate)
+		r.Delete("/{id}", m.handler.DeleteTemplate)
+	})
+
 	// Additional route for getting comparisons by RFQ
 	r.Get("/rfqs/{rfq_id}/comparisons", m.handler.GetComparisonsByRFQ)
 
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/aby-med/medical-platform/internal/middleware"
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// ProposeAmendment handles POST /contracts/{id}/amendment-requests
+func (h *ContractHandler) ProposeAmendment(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	var req app.ProposeAmendmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	amendment, err := h.service.ProposeAmendment(r.Context(), tenantID, chi.URLParam(r, "id"), r.Header.Get("X-User-ID"), req)
+	if err != nil {
+		h.respondAmendmentError(w, err, "Failed to propose amendment")
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, amendment)
+}
+
+// ListAmendmentRequests handles GET /contracts/{id}/amendment-requests
+func (h *ContractHandler) ListAmendmentRequests(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	amendments, err := h.service.ListAmendmentRequests(r.Context(), tenantID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondAmendmentError(w, err, "Failed to list amendment requests")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"amendments": amendments,
+		"total":      len(amendments),
+	})
+}
+
+// GetAmendmentRequest handles GET /contracts/{id}/amendment-requests/{amendment_id}
+func (h *ContractHandler) GetAmendmentRequest(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	amendment, err := h.service.GetAmendmentRequest(r.Context(), tenantID, chi.URLParam(r, "id"), chi.URLParam(r, "amendment_id"))
+	if err != nil {
+		h.respondAmendmentError(w, err, "Failed to get amendment request")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, amendment)
+}
+
+// ApproveAmendment handles POST /contracts/{id}/amendment-requests/{amendment_id}/approve.
+// The approver's role comes from the authenticated user's token, never from a
+// client header, so a caller cannot claim an approval tier it does not hold.
+func (h *ContractHandler) ApproveAmendment(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	role, ok := middleware.GetUserRole(r.Context())
+	if !ok || role == "" {
+		h.respondError(w, http.StatusForbidden, "authenticated user role required")
+		return
+	}
+	approvedBy := r.Header.Get("X-User-ID")
+	if userID, ok := middleware.GetUserID(r.Context()); ok {
+		approvedBy = userID.String()
+	}
+
+	req, ok := h.decodeAmendmentDecision(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.ApproveAmendment(r.Context(), tenantID, chi.URLParam(r, "id"), chi.URLParam(r, "amendment_id"),
+		role, approvedBy, req)
+	if err != nil {
+		h.respondAmendmentError(w, err, "Failed to approve amendment")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// RejectAmendment handles POST /contracts/{id}/amendment-requests/{amendment_id}/reject
+func (h *ContractHandler) RejectAmendment(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	req, ok := h.decodeAmendmentDecision(w, r)
+	if !ok {
+		return
+	}
+
+	amendment, err := h.service.RejectAmendment(r.Context(), tenantID, chi.URLParam(r, "id"), chi.URLParam(r, "amendment_id"),
+		r.Header.Get("X-User-ID"), req)
+	if err != nil {
+		h.respondAmendmentError(w, err, "Failed to reject amendment")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, amendment)
+}
+
+// WithdrawAmendment handles POST /contracts/{id}/amendment-requests/{amendment_id}/withdraw
+func (h *ContractHandler) WithdrawAmendment(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	amendment, err := h.service.WithdrawAmendment(r.Context(), tenantID, chi.URLParam(r, "id"), chi.URLParam(r, "amendment_id"),
+		r.Header.Get("X-User-ID"))
+	if err != nil {
+		h.respondAmendmentError(w, err, "Failed to withdraw amendment")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, amendment)
+}
+
+// ListContractVersions handles GET /contracts/{id}/versions
+func (h *ContractHandler) ListContractVersions(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	versions, err := h.service.ListContractVersions(r.Context(), tenantID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondAmendmentError(w, err, "Failed to list contract versions")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"versions": versions,
+		"total":    len(versions),
+	})
+}
+
+// GetContractVersion handles GET /contracts/{id}/versions/{version}
+func (h *ContractHandler) GetContractVersion(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil || version < 1 {
+		h.respondError(w, http.StatusBadRequest, "version must be a positive number")
+		return
+	}
+
+	response, err := h.service.GetContractVersion(r.Context(), tenantID, chi.URLParam(r, "id"), version)
+	if err != nil {
+		h.respondAmendmentError(w, err, "Failed to get contract version")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// decodeAmendmentDecision reads the optional decision notes, writing a 400 when the body is invalid
+func (h *ContractHandler) decodeAmendmentDecision(w http.ResponseWriter, r *http.Request) (app.DecideAmendmentRequest, bool) {
+	var req app.DecideAmendmentRequest
+	if r.ContentLength == 0 {
+		return req, true
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return req, false
+	}
+	return req, true
+}
+
+// respondAmendmentError maps amendment workflow errors to status codes
+func (h *ContractHandler) respondAmendmentError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrContractNotFound):
+		h.respondError(w, http.StatusNotFound, "Contract not found")
+	case errors.Is(err, domain.ErrAmendmentNotFound):
+		h.respondError(w, http.StatusNotFound, "Amendment request not found")
+	case errors.Is(err, domain.ErrVersionNotFound):
+		h.respondError(w, http.StatusNotFound, "Contract version not found")
+	case errors.Is(err, domain.ErrAmendmentPending),
+		errors.Is(err, domain.ErrAmendmentDecided),
+		errors.Is(err, domain.ErrAmendmentStale):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrApprovalNotAllowed):
+		h.respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrInvalidAmendment),
+		errors.Is(err, domain.ErrCannotAmendContract),
+		errors.Is(err, domain.ErrInvalidPaymentSchedule):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error(message, slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, message)
+	}
+}
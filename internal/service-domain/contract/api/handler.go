@@ -2,11 +2,14 @@
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
 
+	"github.com/aby-med/medical-platform/internal/pkg/money"
 	"github.com/aby-med/medical-platform/internal/service-domain/contract/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -45,6 +48,10 @@ func (h *ContractHandler) CreateContract(w http.ResponseWriter, r *http.Request)
 
 	response, err := h.service.CreateContract(r.Context(), tenantID, createdBy, req)
 	if err != nil {
+		if errors.Is(err, money.ErrUnknownCurrency) || errors.Is(err, domain.ErrInvalidPaymentSchedule) {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		h.logger.Error("Failed to create contract", slog.String("error", err.Error()))
 		h.respondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -172,6 +179,10 @@ func (h *ContractHandler) SignContract(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.SignContract(r.Context(), tenantID, id, req)
 	if err != nil {
+		if errors.Is(err, domain.ErrSigningInProgress) {
+			h.respondError(w, http.StatusConflict, err.Error())
+			return
+		}
 		h.logger.Error("Failed to sign contract", slog.String("error", err.Error()))
 		h.respondError(w, http.StatusBadRequest, err.Error())
 		return
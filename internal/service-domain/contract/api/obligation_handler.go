@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// RecordPayment handles POST /contracts/{id}/payments
+func (h *ContractHandler) RecordPayment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	var req app.RecordPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.RecordPayment(r.Context(), tenantID, id, r.Header.Get("X-User-ID"), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrContractNotFound) {
+			h.respondError(w, http.StatusNotFound, "Contract not found")
+			return
+		}
+		h.logger.Error("Failed to record payment", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// GetObligations handles GET /contracts/{id}/obligations?within_days=7
+func (h *ContractHandler) GetObligations(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	window, ok := h.reminderWindow(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.GetObligations(r.Context(), tenantID, chi.URLParam(r, "id"), window)
+	if err != nil {
+		if errors.Is(err, domain.ErrContractNotFound) {
+			h.respondError(w, http.StatusNotFound, "Contract not found")
+			return
+		}
+		h.logger.Error("Failed to get contract obligations", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to get contract obligations")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// ObligationDashboard handles GET /contracts/obligations/dashboard?within_days=7
+func (h *ContractHandler) ObligationDashboard(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+
+	window, ok := h.reminderWindow(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.ObligationDashboard(r.Context(), tenantID, window)
+	if err != nil {
+		h.logger.Error("Failed to build obligation dashboard", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to build obligation dashboard")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// ListObligationReminders handles GET /contracts/obligations/reminders?recipient=&contract_id=&unread=true
+func (h *ContractHandler) ListObligationReminders(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := domain.ReminderFilter{
+		Recipient:  query.Get("recipient"),
+		ContractID: query.Get("contract_id"),
+		UnreadOnly: query.Get("unread") == "true",
+	}
+	if filter.Recipient != "" && filter.Recipient != domain.RecipientBuyer && filter.Recipient != domain.RecipientSupplier {
+		h.respondError(w, http.StatusBadRequest, "recipient must be buyer or supplier")
+		return
+	}
+
+	reminders, err := h.service.ListObligationReminders(r.Context(), tenantID, filter)
+	if err != nil {
+		h.logger.Error("Failed to list obligation reminders", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list obligation reminders")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"reminders": reminders,
+		"total":     len(reminders),
+	})
+}
+
+// MarkObligationReminderRead handles POST /contracts/obligations/reminders/{reminder_id}/read
+func (h *ContractHandler) MarkObligationReminderRead(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+
+	err := h.service.MarkObligationReminderRead(r.Context(), tenantID, chi.URLParam(r, "reminder_id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrReminderNotFound) {
+			h.respondError(w, http.StatusNotFound, "Reminder not found")
+			return
+		}
+		h.logger.Error("Failed to mark obligation reminder read", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to mark reminder read")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// reminderWindow reads the within_days query parameter, writing a 400 when it is invalid
+func (h *ContractHandler) reminderWindow(w http.ResponseWriter, r *http.Request) (time.Duration, bool) {
+	days := app.DefaultObligationReminderDays
+	if value := r.URL.Query().Get("within_days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			h.respondError(w, http.StatusBadRequest, "within_days must be a non-negative number")
+			return 0, false
+		}
+		days = parsed
+	}
+	return time.Duration(days) * 24 * time.Hour, true
+}
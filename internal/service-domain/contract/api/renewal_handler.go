@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// RenewContract handles POST /contracts/{id}/renew
+func (h *ContractHandler) RenewContract(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	var req app.RenewContractRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.RenewContract(r.Context(), tenantID, chi.URLParam(r, "id"), r.Header.Get("X-User-ID"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrContractNotFound):
+			h.respondError(w, http.StatusNotFound, "Contract not found")
+		case errors.Is(err, domain.ErrRenewalExists):
+			h.respondError(w, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error("Failed to renew contract", slog.String("error", err.Error()))
+			h.respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, response)
+}
+
+// GetRenewal handles GET /contracts/{id}/renewal
+func (h *ContractHandler) GetRenewal(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	renewal, err := h.service.GetRenewal(r.Context(), tenantID, chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrRenewalNotFound) {
+			h.respondError(w, http.StatusNotFound, "Contract has not been renewed")
+			return
+		}
+		h.logger.Error("Failed to get contract renewal", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to get contract renewal")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, renewal)
+}
+
+// ExpiryReport handles GET /contracts/renewals/expiring
+func (h *ContractHandler) ExpiryReport(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+
+	report, err := h.service.ExpiryReport(r.Context(), tenantID)
+	if err != nil {
+		h.logger.Error("Failed to build contract expiry report", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to build contract expiry report")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, report)
+}
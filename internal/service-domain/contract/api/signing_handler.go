@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/aby-med/medical-platform/internal/shared/audit"
+	"github.com/go-chi/chi/v5"
+)
+
+// RenderDocument handles POST /contracts/{id}/documents
+func (h *ContractHandler) RenderDocument(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+	renderedBy := r.Header.Get("X-User-ID")
+	if renderedBy == "" {
+		renderedBy = "system"
+	}
+
+	doc, err := h.service.RenderDocument(r.Context(), tenantID, chi.URLParam(r, "id"), renderedBy)
+	if err != nil {
+		h.respondSigningError(w, "Failed to render contract document", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, doc)
+}
+
+// ListDocuments handles GET /contracts/{id}/documents
+func (h *ContractHandler) ListDocuments(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	docs, err := h.service.ListDocuments(r.Context(), tenantID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondSigningError(w, "Failed to list contract documents", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"documents": docs,
+		"total":     len(docs),
+	})
+}
+
+// DownloadDocument handles GET /contracts/{id}/documents/{document_id}/pdf
+func (h *ContractHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	doc, err := h.service.GetDocument(r.Context(), tenantID, chi.URLParam(r, "id"), chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.respondSigningError(w, "Failed to get contract document", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=contract_%s_v%d_%s.pdf", doc.ContractID, doc.Version, doc.Kind))
+	w.Header().Set("Content-Length", strconv.Itoa(len(doc.Content)))
+	w.Header().Set("X-Document-SHA256", doc.ContentHash)
+	w.WriteHeader(http.StatusOK)
+	w.Write(doc.Content)
+}
+
+// StartSigning handles POST /contracts/{id}/signing-requests
+func (h *ContractHandler) StartSigning(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+	createdBy := r.Header.Get("X-User-ID")
+	if createdBy == "" {
+		createdBy = "system"
+	}
+
+	var req app.StartSigningRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.StartSigning(r.Context(), tenantID, chi.URLParam(r, "id"), createdBy, req)
+	if err != nil {
+		h.respondSigningError(w, "Failed to start signing", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, response)
+}
+
+// ListSigningRequests handles GET /contracts/{id}/signing-requests
+func (h *ContractHandler) ListSigningRequests(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	responses, err := h.service.ListSigningRequests(r.Context(), tenantID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondSigningError(w, "Failed to list signing requests", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"signing_requests": responses,
+		"total":            len(responses),
+	})
+}
+
+// GetSigningRequest handles GET /contracts/{id}/signing-requests/{request_id}
+func (h *ContractHandler) GetSigningRequest(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	response, err := h.service.GetSigningRequest(r.Context(), tenantID, chi.URLParam(r, "id"), chi.URLParam(r, "request_id"))
+	if err != nil {
+		h.respondSigningError(w, "Failed to get signing request", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// SignDocument handles POST /contracts/{id}/signing-requests/{request_id}/signatories/{signatory_id}/sign.
+// The signer's identity comes from X-User-ID; the IP address and user agent
+// of the request are kept as signature evidence.
+func (h *ContractHandler) SignDocument(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	signerID := r.Header.Get("X-User-ID")
+	if tenantID == "" || signerID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID and X-User-ID headers required")
+		return
+	}
+
+	var req app.SignDocumentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	evidence := domain.SignatureEvidence{
+		SignerID:     signerID,
+		SignerName:   req.SignerName,
+		IPAddress:    audit.ExtractIPAddress(r),
+		UserAgent:    audit.ExtractUserAgent(r),
+		DocumentHash: req.DocumentHash,
+	}
+	response, err := h.service.SignDocument(r.Context(), tenantID, chi.URLParam(r, "id"),
+		chi.URLParam(r, "request_id"), chi.URLParam(r, "signatory_id"), evidence)
+	if err != nil {
+		h.respondSigningError(w, "Failed to sign contract document", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// DeclineSigning handles POST /contracts/{id}/signing-requests/{request_id}/signatories/{signatory_id}/decline.
+// Only the signatory's user, from X-User-ID, can decline.
+func (h *ContractHandler) DeclineSigning(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	userID := r.Header.Get("X-User-ID")
+	if tenantID == "" || userID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID and X-User-ID headers required")
+		return
+	}
+
+	var req app.DeclineSigningRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.service.DeclineSigning(r.Context(), tenantID, chi.URLParam(r, "id"),
+		chi.URLParam(r, "request_id"), chi.URLParam(r, "signatory_id"), userID, req)
+	if err != nil {
+		h.respondSigningError(w, "Failed to decline signing", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// CancelSigning handles POST /contracts/{id}/signing-requests/{request_id}/cancel
+func (h *ContractHandler) CancelSigning(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+
+	response, err := h.service.CancelSigning(r.Context(), tenantID, chi.URLParam(r, "id"), chi.URLParam(r, "request_id"))
+	if err != nil {
+		h.respondSigningError(w, "Failed to cancel signing", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// respondSigningError maps document and signing errors to HTTP statuses
+func (h *ContractHandler) respondSigningError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrContractNotFound), errors.Is(err, domain.ErrDocumentNotFound),
+		errors.Is(err, domain.ErrSigningRequestNotFound), errors.Is(err, domain.ErrSignatoryNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrNotSignatory):
+		h.respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrInvalidSigningRequest), errors.Is(err, domain.ErrDocumentHashMismatch):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrSigningInProgress), errors.Is(err, domain.ErrSigningClosed),
+		errors.Is(err, domain.ErrSigningExpired), errors.Is(err, domain.ErrNotSignatoryTurn),
+		errors.Is(err, domain.ErrAlreadySigned), errors.Is(err, domain.ErrSigningConflict),
+		errors.Is(err, domain.ErrContractAlreadySigned):
+		h.respondError(w, http.StatusConflict, err.Error())
+	default:
+		h.logger.Error(message, slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, message)
+	}
+}
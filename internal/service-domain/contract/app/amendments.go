@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+)
+
+// ProposeAmendment proposes a structured change set against the contract's
+// current version. Only one amendment can be pending per contract.
+func (s *ContractService) ProposeAmendment(ctx context.Context, tenantID, contractID, requestedBy string, req ProposeAmendmentRequest) (*domain.AmendmentRequest, error) {
+	contract, err := s.repo.GetByID(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	amendment, err := contract.ProposeAmendment(req.Description, req.ChangeSet, requestedBy, req.RequiredRoles)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.amendments.CreateAmendmentRequest(ctx, amendment); err != nil {
+		return nil, err
+	}
+	return amendment, nil
+}
+
+// ListAmendmentRequests lists a contract's amendment requests, newest first
+func (s *ContractService) ListAmendmentRequests(ctx context.Context, tenantID, contractID string) ([]*domain.AmendmentRequest, error) {
+	if _, err := s.repo.GetByID(ctx, tenantID, contractID); err != nil {
+		return nil, err
+	}
+	return s.amendments.ListAmendmentRequests(ctx, tenantID, contractID)
+}
+
+// GetAmendmentRequest retrieves an amendment request
+func (s *ContractService) GetAmendmentRequest(ctx context.Context, tenantID, contractID, id string) (*domain.AmendmentRequest, error) {
+	return s.amendments.GetAmendmentRequest(ctx, tenantID, contractID, id)
+}
+
+// ApproveAmendment records an approval in the approver's role. The last
+// required approval applies the amendment, recalculating the contract's
+// totals and storing its new version.
+func (s *ContractService) ApproveAmendment(ctx context.Context, tenantID, contractID, id, role, approvedBy string, req DecideAmendmentRequest) (*AmendmentDecisionResponse, error) {
+	amendment, err := s.amendments.GetAmendmentRequest(ctx, tenantID, contractID, id)
+	if err != nil {
+		return nil, err
+	}
+	contract, err := s.repo.GetByID(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := contract.Snapshot(amendment.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := amendment.Approve(contract, role, approvedBy, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	if !applied {
+		if err := s.amendments.UpdateAmendmentRequest(ctx, amendment); err != nil {
+			return nil, err
+		}
+		s.logger.Info("Contract amendment approved",
+			slog.String("contract_id", contractID),
+			slog.String("amendment_id", id),
+			slog.String("role", role))
+		return &AmendmentDecisionResponse{Amendment: amendment}, nil
+	}
+
+	after, err := contract.Snapshot(approvedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.amendments.SaveAmendedContract(ctx, contract, before, after, amendment); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Contract amendment applied",
+		slog.String("contract_id", contractID),
+		slog.String("amendment_id", id),
+		slog.Int("version", after.Version),
+		slog.Float64("total_amount", contract.TotalAmount))
+	response := ToContractResponse(contract)
+	return &AmendmentDecisionResponse{Amendment: amendment, Applied: true, Contract: &response}, nil
+}
+
+// RejectAmendment rejects a pending amendment, leaving the contract unchanged
+func (s *ContractService) RejectAmendment(ctx context.Context, tenantID, contractID, id, rejectedBy string, req DecideAmendmentRequest) (*domain.AmendmentRequest, error) {
+	amendment, err := s.amendments.GetAmendmentRequest(ctx, tenantID, contractID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := amendment.Reject(rejectedBy, req.Notes); err != nil {
+		return nil, err
+	}
+	if err := s.amendments.UpdateAmendmentRequest(ctx, amendment); err != nil {
+		return nil, err
+	}
+	return amendment, nil
+}
+
+// WithdrawAmendment withdraws a pending amendment on behalf of its requester
+func (s *ContractService) WithdrawAmendment(ctx context.Context, tenantID, contractID, id, withdrawnBy string) (*domain.AmendmentRequest, error) {
+	amendment, err := s.amendments.GetAmendmentRequest(ctx, tenantID, contractID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := amendment.Withdraw(withdrawnBy); err != nil {
+		return nil, err
+	}
+	if err := s.amendments.UpdateAmendmentRequest(ctx, amendment); err != nil {
+		return nil, err
+	}
+	return amendment, nil
+}
+
+// ListContractVersions lists a contract's versions, oldest first. The
+// current version is included even before the contract is first amended.
+func (s *ContractService) ListContractVersions(ctx context.Context, tenantID, contractID string) ([]*domain.ContractVersion, error) {
+	contract, err := s.repo.GetByID(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.amendments.ListVersions(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(versions); n == 0 || versions[n-1].Version < contract.Version() {
+		current, err := contract.Snapshot(contract.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		current.CreatedAt = contract.UpdatedAt
+		current.Contract = nil
+		versions = append(versions, current)
+	}
+	return versions, nil
+}
+
+// GetContractVersion reconstructs the contract as of a version
+func (s *ContractService) GetContractVersion(ctx context.Context, tenantID, contractID string, version int) (*domain.ContractVersion, error) {
+	contract, err := s.repo.GetByID(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if version == contract.Version() {
+		current, err := contract.Snapshot(contract.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		current.CreatedAt = contract.UpdatedAt
+		return current, nil
+	}
+	return s.amendments.GetVersion(ctx, tenantID, contractID, version)
+}
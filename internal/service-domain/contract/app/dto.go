@@ -22,6 +22,7 @@ type CreateContractRequest struct {
 	PaymentSchedule    []PaymentTermRequest       `json:"payment_schedule,omitempty"`
 	DeliverySchedule   []DeliveryScheduleRequest  `json:"delivery_schedule,omitempty"`
 	TaxAmount          float64                    `json:"tax_amount"`
+	Currency           string                     `json:"currency,omitempty"` // ISO 4217, defaults to USD
 	Notes              string                     `json:"notes,omitempty"`
 }
 
@@ -39,9 +40,11 @@ type ContractItemRequest struct {
 
 // PaymentTermRequest represents a payment term in requests
 type PaymentTermRequest struct {
-	DueDate     time.Time `json:"due_date"`
-	Amount      float64   `json:"amount"`
-	Description string    `json:"description"`
+	DueDate          time.Time  `json:"due_date"`
+	Amount           float64    `json:"amount"`
+	Description      string     `json:"description"`
+	RetentionAmount  float64    `json:"retention_amount,omitempty"` // Part of the amount held back until the retention due date
+	RetentionDueDate *time.Time `json:"retention_due_date,omitempty"`
 }
 
 // DeliveryScheduleRequest represents a delivery milestone in requests
@@ -67,6 +70,41 @@ type SignContractRequest struct {
 	SignedBy string `json:"signed_by"`
 }
 
+// StartSigningRequest represents a request to send a contract document for e-signature
+type StartSigningRequest struct {
+	DocumentID    string             `json:"document_id,omitempty"` // Defaults to the latest rendered version
+	Signatories   []SignatoryRequest `json:"signatories"`
+	ExpiresInDays int                `json:"expires_in_days,omitempty"`
+}
+
+// SignatoryRequest represents a signatory in requests
+type SignatoryRequest struct {
+	UserID string `json:"user_id"` // User who will sign as this signatory
+	Party  string `json:"party"`   // buyer or supplier
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Title  string `json:"title,omitempty"`
+	Order  int    `json:"order"`
+}
+
+// SignDocumentRequest represents a signatory signing a document. The hash
+// must be that of the document the signer reviewed.
+type SignDocumentRequest struct {
+	DocumentHash string `json:"document_hash"`
+	SignerName   string `json:"signer_name,omitempty"`
+}
+
+// DeclineSigningRequest represents a signatory declining to sign
+type DeclineSigningRequest struct {
+	Reason string `json:"reason"`
+}
+
+// SigningResponse represents a signing request in responses
+type SigningResponse struct {
+	*domain.SigningRequest
+	NextSignatories []domain.Signatory `json:"next_signatories"`
+}
+
 // CancelContractRequest represents a request to cancel a contract
 type CancelContractRequest struct {
 	Reason string `json:"reason"`
@@ -84,14 +122,82 @@ type AddAmendmentRequest struct {
 	AmendedBy   string `json:"amended_by"`
 }
 
+// ProposeAmendmentRequest represents a structured amendment proposal. The
+// approval roles follow from the changes; more can be required.
+type ProposeAmendmentRequest struct {
+	Description   string           `json:"description"`
+	ChangeSet     domain.ChangeSet `json:"change_set"`
+	RequiredRoles []string         `json:"required_roles,omitempty"`
+}
+
+// DecideAmendmentRequest represents an approval, rejection or withdrawal of an amendment
+type DecideAmendmentRequest struct {
+	Notes string `json:"notes,omitempty"`
+}
+
+// AmendmentDecisionResponse represents an amendment request after a decision
+type AmendmentDecisionResponse struct {
+	Amendment *domain.AmendmentRequest `json:"amendment"`
+	Applied   bool                     `json:"applied"`
+	Contract  *ContractResponse        `json:"contract,omitempty"` // Set once applied
+}
+
+// RenewContractRequest represents a request to renew a contract through a
+// renewal RFQ or a renewal draft contract with the same supplier
+type RenewContractRequest struct {
+	Kind             domain.RenewalKind `json:"kind"`
+	ResponseDeadline *time.Time         `json:"response_deadline,omitempty"` // Renewal RFQs only
+}
+
+// RenewalResponse represents a contract renewal in responses
+type RenewalResponse struct {
+	Renewal         *domain.ContractRenewal `json:"renewal"`
+	RenewalContract *ContractResponse       `json:"renewal_contract,omitempty"` // Set for renewal drafts
+}
+
+// ExpiryReportResponse represents the contracts expiring in the next 30, 60 and 90 days
+type ExpiryReportResponse struct {
+	AsOf    time.Time             `json:"as_of"`
+	Buckets []domain.ExpiryBucket `json:"buckets"`
+}
+
 // MarkPaymentPaidRequest represents a request to mark a payment as paid
 type MarkPaymentPaidRequest struct {
 	PaymentIndex int `json:"payment_index"`
 }
 
-// MarkDeliveryCompletedRequest represents a request to mark a delivery as completed
+// RecordPaymentRequest represents a full or partial payment against a payment term
+type RecordPaymentRequest struct {
+	PaymentIndex int        `json:"payment_index"`
+	Amount       float64    `json:"amount"`
+	PaidAt       *time.Time `json:"paid_at,omitempty"` // Defaults to now
+	Reference    string     `json:"reference,omitempty"`
+}
+
+// MarkDeliveryCompletedRequest represents a request to mark a delivery as
+// completed. Delivered units are registered in the equipment registry.
 type MarkDeliveryCompletedRequest struct {
-	DeliveryIndex int `json:"delivery_index"`
+	DeliveryIndex int                    `json:"delivery_index"`
+	Units         []domain.DeliveredUnit `json:"units,omitempty"`
+	CustomerName  string                 `json:"customer_name,omitempty"` // Recorded on the equipment records
+}
+
+// ObligationsResponse represents a contract's obligations in responses
+type ObligationsResponse struct {
+	ContractID  string                `json:"contract_id"`
+	Obligations []domain.Obligation   `json:"obligations"`
+	Aging       []domain.AgingSummary `json:"aging"`
+}
+
+// ObligationDashboardResponse represents upcoming and overdue obligations
+// across a tenant's contracts
+type ObligationDashboardResponse struct {
+	AsOf       time.Time             `json:"as_of"`
+	WithinDays int                   `json:"within_days"`
+	Upcoming   []domain.Obligation   `json:"upcoming"` // Due within the window, soonest first
+	Overdue    []domain.Obligation   `json:"overdue"`  // Most overdue first
+	Aging      []domain.AgingSummary `json:"aging"`
+	Contracts  int                   `json:"contracts"`
 }
 
 // ContractResponse represents a contract in responses
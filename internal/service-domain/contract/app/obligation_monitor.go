@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/shared/config"
+)
+
+// ObligationMonitor periodically reminds buyers and suppliers of contract
+// payments, retention releases and deliveries coming due, and escalates
+// overdue ones as they age
+type ObligationMonitor struct {
+	service        *ContractService
+	interval       time.Duration
+	reminderWindow time.Duration
+	now            func() time.Time
+	logger         *slog.Logger
+}
+
+// NewObligationMonitor creates a new obligation monitor. The reminder window
+// can be changed with CONTRACT_OBLIGATION_REMINDER_DAYS.
+func NewObligationMonitor(service *ContractService, logger *slog.Logger) *ObligationMonitor {
+	days := DefaultObligationReminderDays
+	if value, err := strconv.Atoi(os.Getenv("CONTRACT_OBLIGATION_REMINDER_DAYS")); err == nil && value > 0 {
+		days = value
+	}
+	return &ObligationMonitor{
+		service:        service,
+		interval:       time.Hour,
+		reminderWindow: time.Duration(days) * 24 * time.Hour,
+		now:            time.Now,
+		logger:         logger.With(slog.String("component", "contract_obligation_monitor")),
+	}
+}
+
+// Run checks obligations until the context is cancelled.
+// Disabled with ENABLE_CONTRACT_OBLIGATION_MONITOR=false.
+func (m *ObligationMonitor) Run(ctx context.Context) {
+	if !config.Enabled("ENABLE_CONTRACT_OBLIGATION_MONITOR") {
+		m.logger.Info("Contract obligation monitor disabled; skipping run")
+		return
+	}
+
+	m.RunOnce(ctx)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce sends due obligation reminders once
+func (m *ObligationMonitor) RunOnce(ctx context.Context) {
+	sent, err := m.service.MonitorObligations(ctx, m.now(), m.reminderWindow)
+	if err != nil {
+		m.logger.Error("Failed to monitor contract obligations", slog.String("error", err.Error()))
+		return
+	}
+	if sent > 0 {
+		m.logger.Info("Contract obligation reminders sent", slog.Int("reminders", sent))
+	}
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+)
+
+// DefaultObligationReminderDays is how far ahead of due dates obligations are due soon
+const DefaultObligationReminderDays = 7
+
+// RecordPayment records a full or partial payment against a payment term
+func (s *ContractService) RecordPayment(ctx context.Context, tenantID, id, recordedBy string, req RecordPaymentRequest) (*ContractResponse, error) {
+	contract, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	paidAt := time.Now()
+	if req.PaidAt != nil {
+		paidAt = *req.PaidAt
+	}
+	if err := contract.RecordPayment(req.PaymentIndex, req.Amount, paidAt, req.Reference, recordedBy); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, contract); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Contract payment recorded",
+		slog.String("contract_id", id),
+		slog.Int("payment_index", req.PaymentIndex),
+		slog.Float64("amount", req.Amount))
+	response := ToContractResponse(contract)
+	return &response, nil
+}
+
+// GetObligations lists a contract's obligations with their aging
+func (s *ContractService) GetObligations(ctx context.Context, tenantID, id string, window time.Duration) (*ObligationsResponse, error) {
+	contract, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	obligations := contract.Obligations(time.Now(), window)
+	return &ObligationsResponse{
+		ContractID:  contract.ID,
+		Obligations: obligations,
+		Aging:       domain.SummarizeAging(obligations),
+	}, nil
+}
+
+// ObligationDashboard lists obligations due within the window and overdue
+// ones across the tenant's active and suspended contracts
+func (s *ContractService) ObligationDashboard(ctx context.Context, tenantID string, window time.Duration) (*ObligationDashboardResponse, error) {
+	contracts, err := s.obligations.ListOpenContracts(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	response := &ObligationDashboardResponse{
+		AsOf:       now,
+		WithinDays: int(window.Hours() / 24),
+		Upcoming:   []domain.Obligation{},
+		Overdue:    []domain.Obligation{},
+		Contracts:  len(contracts),
+	}
+	open := []domain.Obligation{}
+	for _, contract := range contracts {
+		for _, o := range contract.Obligations(now, window) {
+			switch o.Status {
+			case domain.ObligationDueSoon:
+				response.Upcoming = append(response.Upcoming, o)
+			case domain.ObligationOverdue:
+				response.Overdue = append(response.Overdue, o)
+			}
+			if o.IsOpen() {
+				open = append(open, o)
+			}
+		}
+	}
+
+	sort.SliceStable(response.Upcoming, func(i, j int) bool {
+		return response.Upcoming[i].DueDate.Before(response.Upcoming[j].DueDate)
+	})
+	sort.SliceStable(response.Overdue, func(i, j int) bool {
+		return response.Overdue[i].DueDate.Before(response.Overdue[j].DueDate)
+	})
+	response.Aging = domain.SummarizeAging(open)
+	return response, nil
+}
+
+// MonitorObligations reminds buyers and suppliers of obligations due within
+// the window, and of overdue ones each time they enter a new aging bucket.
+// It returns how many reminders were sent.
+func (s *ContractService) MonitorObligations(ctx context.Context, now time.Time, window time.Duration) (int, error) {
+	contracts, err := s.obligations.ListOpenContracts(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, contract := range contracts {
+		for _, o := range contract.Obligations(now, window) {
+			reminders := contract.Remind(o)
+			if len(reminders) == 0 {
+				continue
+			}
+			stored, err := s.obligations.AddReminders(ctx, reminders)
+			if err != nil {
+				s.logger.Error("Failed to send obligation reminder",
+					slog.String("error", err.Error()),
+					slog.String("contract_id", contract.ID))
+				continue
+			}
+			sent += stored
+		}
+	}
+	return sent, nil
+}
+
+// ListObligationReminders lists obligation reminders, newest first
+func (s *ContractService) ListObligationReminders(ctx context.Context, tenantID string, filter domain.ReminderFilter) ([]domain.ObligationReminder, error) {
+	return s.obligations.ListReminders(ctx, tenantID, filter)
+}
+
+// MarkObligationReminderRead marks an obligation reminder as read
+func (s *ContractService) MarkObligationReminderRead(ctx context.Context, tenantID, id string) error {
+	return s.obligations.MarkReminderRead(ctx, tenantID, id)
+}
+
+// registerDeliveredUnits records a delivery's units in the equipment registry
+func (s *ContractService) registerDeliveredUnits(ctx context.Context, contract *domain.Contract, req MarkDeliveryCompletedRequest) ([]string, error) {
+	if len(req.Units) == 0 {
+		return nil, nil
+	}
+	if err := contract.ValidateDeliveryCompletion(req.DeliveryIndex, req.Units); err != nil {
+		return nil, err
+	}
+	if s.equipment == nil {
+		return nil, fmt.Errorf("%w: equipment registry is not configured", domain.ErrInvalidDelivery)
+	}
+
+	customerName := req.CustomerName
+	if customerName == "" {
+		customerName = contract.TenantID
+	}
+	return s.equipment.RegisterDeliveredUnits(ctx, contract, customerName, req.Units)
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/aby-med/medical-platform/internal/shared/config"
+)
+
+// DefaultAutoRenewalDays is how many days before the end date contracts are
+// renewed automatically when auto renewal is on
+const DefaultAutoRenewalDays = 60
+
+// RenewalMonitor periodically expires contracts past their end date, sends
+// renewal notices and, when configured, generates renewals
+type RenewalMonitor struct {
+	service  *ContractService
+	interval time.Duration
+	policy   RenewalPolicy
+	now      func() time.Time
+	logger   *slog.Logger
+}
+
+// NewRenewalMonitor creates a new renewal monitor. Notice lead times can be
+// changed with CONTRACT_RENEWAL_NOTICE_DAYS (e.g. "90,60,30"); automatic
+// renewal is off unless CONTRACT_AUTO_RENEWAL is "rfq" or "draft", and then
+// happens CONTRACT_AUTO_RENEWAL_DAYS before the end date.
+func NewRenewalMonitor(service *ContractService, logger *slog.Logger) *RenewalMonitor {
+	policy := RenewalPolicy{
+		NoticeDays:      domain.DefaultRenewalNoticeDays,
+		AutoRenewalDays: DefaultAutoRenewalDays,
+	}
+	if value := os.Getenv("CONTRACT_RENEWAL_NOTICE_DAYS"); value != "" {
+		days := []int{}
+		for _, field := range strings.Split(value, ",") {
+			if d, err := strconv.Atoi(strings.TrimSpace(field)); err == nil && d > 0 {
+				days = append(days, d)
+			}
+		}
+		if len(days) > 0 {
+			policy.NoticeDays = days
+		}
+	}
+	switch kind := domain.RenewalKind(strings.ToLower(strings.TrimSpace(os.Getenv("CONTRACT_AUTO_RENEWAL")))); kind {
+	case domain.RenewalKindRFQ, domain.RenewalKindDraft:
+		policy.AutoRenewal = kind
+	}
+	if value, err := strconv.Atoi(os.Getenv("CONTRACT_AUTO_RENEWAL_DAYS")); err == nil && value > 0 {
+		policy.AutoRenewalDays = value
+	}
+
+	return &RenewalMonitor{
+		service:  service,
+		interval: time.Hour,
+		policy:   policy,
+		now:      time.Now,
+		logger:   logger.With(slog.String("component", "contract_renewal_monitor")),
+	}
+}
+
+// Run runs the contract lifecycle until the context is cancelled.
+// Disabled with ENABLE_CONTRACT_RENEWAL_MONITOR=false.
+func (m *RenewalMonitor) Run(ctx context.Context) {
+	if !config.Enabled("ENABLE_CONTRACT_RENEWAL_MONITOR") {
+		m.logger.Info("Contract renewal monitor disabled; skipping run")
+		return
+	}
+
+	m.RunOnce(ctx)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce runs the contract lifecycle once
+func (m *RenewalMonitor) RunOnce(ctx context.Context) {
+	result, err := m.service.RunContractLifecycle(ctx, m.now(), m.policy)
+	if err != nil {
+		m.logger.Error("Failed to run contract lifecycle", slog.String("error", err.Error()))
+		return
+	}
+	if result.Expired > 0 || result.Notices > 0 || result.Renewed > 0 {
+		m.logger.Info("Contract lifecycle run",
+			slog.Int("expired", result.Expired),
+			slog.Int("notices", result.Notices),
+			slog.Int("renewed", result.Renewed))
+	}
+}
@@ -0,0 +1,191 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// DefaultRenewalRFQResponseDays is how long suppliers have to respond to a
+// renewal RFQ that does not set its own deadline
+const DefaultRenewalRFQResponseDays = 14
+
+// RenewalPolicy configures the contract lifecycle run
+type RenewalPolicy struct {
+	NoticeDays      []int              // Lead times of renewal notices, in days before the end date
+	AutoRenewal     domain.RenewalKind // Renewal generated automatically; empty for none
+	AutoRenewalDays int                // How many days before the end date to generate it
+}
+
+// LifecycleResult summarizes a contract lifecycle run
+type LifecycleResult struct {
+	Expired int
+	Notices int
+	Renewed int
+}
+
+// RenewContract renews a contract through a renewal RFQ or a renewal draft
+// contract. A contract is renewed at most once.
+func (s *ContractService) RenewContract(ctx context.Context, tenantID, id, createdBy string, req RenewContractRequest) (*RenewalResponse, error) {
+	contract, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().AddDate(0, 0, DefaultRenewalRFQResponseDays)
+	if req.ResponseDeadline != nil {
+		deadline = *req.ResponseDeadline
+	}
+	return s.renew(ctx, contract, req.Kind, deadline, createdBy)
+}
+
+// GetRenewal retrieves how a contract was renewed
+func (s *ContractService) GetRenewal(ctx context.Context, tenantID, id string) (*domain.ContractRenewal, error) {
+	return s.renewals.GetRenewal(ctx, tenantID, id)
+}
+
+// ExpiryReport lists the tenant's active contracts expiring in the next 30,
+// 60 and 90 days with their renewals
+func (s *ContractService) ExpiryReport(ctx context.Context, tenantID string) (*ExpiryReportResponse, error) {
+	contracts, err := s.obligations.ListOpenContracts(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(contracts))
+	for _, c := range contracts {
+		ids = append(ids, c.ID)
+	}
+	renewals, err := s.renewals.ListRenewals(ctx, tenantID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &ExpiryReportResponse{
+		AsOf:    now,
+		Buckets: domain.BucketExpiringContracts(contracts, renewals, now, domain.ExpiryReportDays),
+	}, nil
+}
+
+// RunContractLifecycle expires contracts past their end date, sends renewal
+// notices as contracts come within the policy's lead times and, when the
+// policy says so, renews contracts coming due. Failures on one contract are
+// logged and do not stop the run.
+func (s *ContractService) RunContractLifecycle(ctx context.Context, now time.Time, policy RenewalPolicy) (LifecycleResult, error) {
+	var result LifecycleResult
+	contracts, err := s.obligations.ListOpenContracts(ctx, "")
+	if err != nil {
+		return result, err
+	}
+
+	for _, contract := range contracts {
+		if now.After(contract.EndDate) {
+			if err := contract.Expire(now); err != nil {
+				continue
+			}
+			if err := s.repo.Update(ctx, contract); err != nil {
+				s.logger.Error("Failed to expire contract",
+					slog.String("error", err.Error()),
+					slog.String("contract_id", contract.ID))
+				continue
+			}
+			result.Expired++
+			continue
+		}
+
+		if notices := contract.RenewalNotices(now, policy.NoticeDays); len(notices) > 0 {
+			stored, err := s.obligations.AddReminders(ctx, notices)
+			if err != nil {
+				s.logger.Error("Failed to send renewal notice",
+					slog.String("error", err.Error()),
+					slog.String("contract_id", contract.ID))
+			}
+			result.Notices += stored
+		}
+
+		if policy.AutoRenewal == "" || contract.Status != domain.ContractStatusActive ||
+			contract.DaysUntilExpiry(now) > policy.AutoRenewalDays {
+			continue
+		}
+		if _, err := s.renewals.GetRenewal(ctx, contract.TenantID, contract.ID); err == nil {
+			continue
+		} else if !errors.Is(err, domain.ErrRenewalNotFound) {
+			s.logger.Error("Failed to check contract renewal",
+				slog.String("error", err.Error()),
+				slog.String("contract_id", contract.ID))
+			continue
+		}
+		deadline := now.AddDate(0, 0, DefaultRenewalRFQResponseDays)
+		if _, err := s.renew(ctx, contract, policy.AutoRenewal, deadline, "system"); err != nil {
+			if !errors.Is(err, domain.ErrRenewalExists) {
+				s.logger.Error("Failed to renew contract",
+					slog.String("error", err.Error()),
+					slog.String("contract_id", contract.ID))
+			}
+			continue
+		}
+		result.Renewed++
+	}
+	return result, nil
+}
+
+func (s *ContractService) renew(ctx context.Context, contract *domain.Contract, kind domain.RenewalKind, deadline time.Time, createdBy string) (*RenewalResponse, error) {
+	if err := contract.CanRenew(); err != nil {
+		return nil, err
+	}
+	if _, err := s.renewals.GetRenewal(ctx, contract.TenantID, contract.ID); err == nil {
+		return nil, domain.ErrRenewalExists
+	} else if !errors.Is(err, domain.ErrRenewalNotFound) {
+		return nil, err
+	}
+
+	renewal := &domain.ContractRenewal{
+		ContractID: contract.ID,
+		TenantID:   contract.TenantID,
+		Kind:       kind,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now(),
+	}
+
+	switch kind {
+	case domain.RenewalKindRFQ:
+		if s.rfqs == nil {
+			return nil, fmt.Errorf("%w: renewal RFQs are not configured", domain.ErrCannotRenew)
+		}
+		rfqID, err := s.rfqs.CreateRenewalRFQ(ctx, contract, deadline, createdBy)
+		if err != nil {
+			return nil, err
+		}
+		renewal.RFQID = rfqID
+		if err := s.renewals.CreateRenewal(ctx, renewal); err != nil {
+			return nil, err
+		}
+		s.logger.Info("Contract renewal RFQ created",
+			slog.String("contract_id", contract.ID),
+			slog.String("rfq_id", rfqID))
+		return &RenewalResponse{Renewal: renewal}, nil
+
+	case domain.RenewalKindDraft:
+		number, err := s.repo.NextContractNumber(ctx, contract.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		draft, err := contract.NewRenewalDraft(ksuid.New().String(), number, createdBy)
+		if err != nil {
+			return nil, err
+		}
+		renewal.RenewalContractID = draft.ID
+		if err := s.renewals.CreateRenewalDraft(ctx, renewal, draft); err != nil {
+			return nil, err
+		}
+		response := ToContractResponse(draft)
+		return &RenewalResponse{Renewal: renewal, RenewalContract: &response}, nil
+	}
+	return nil, fmt.Errorf("%w: renewal kind must be rfq or draft", domain.ErrCannotRenew)
+}
@@ -12,18 +12,40 @@ import (
 
 // ContractService provides application-level contract management operations
 type ContractService struct {
-	repo   domain.Repository
-	logger *slog.Logger
+	repo        domain.Repository
+	documents   domain.DocumentRepository
+	renderer    domain.DocumentRenderer
+	obligations domain.ObligationRepository
+	amendments  domain.AmendmentRepository
+	renewals    domain.RenewalRepository
+	equipment   domain.EquipmentRegistrar
+	rfqs        domain.RenewalRFQCreator
+	logger      *slog.Logger
 }
 
 // NewContractService creates a new contract service
-func NewContractService(repo domain.Repository, logger *slog.Logger) *ContractService {
+func NewContractService(repo domain.Repository, documents domain.DocumentRepository, renderer domain.DocumentRenderer, obligations domain.ObligationRepository, amendments domain.AmendmentRepository, renewals domain.RenewalRepository, logger *slog.Logger) *ContractService {
 	return &ContractService{
-		repo:   repo,
-		logger: logger.With(slog.String("service", "contract")),
+		repo:        repo,
+		documents:   documents,
+		renderer:    renderer,
+		obligations: obligations,
+		amendments:  amendments,
+		renewals:    renewals,
+		logger:      logger.With(slog.String("service", "contract")),
 	}
 }
 
+// SetEquipmentRegistrar sets the registrar that records delivered units in the equipment registry
+func (s *ContractService) SetEquipmentRegistrar(equipment domain.EquipmentRegistrar) {
+	s.equipment = equipment
+}
+
+// SetRenewalRFQCreator sets the creator of renewal RFQs
+func (s *ContractService) SetRenewalRFQCreator(rfqs domain.RenewalRFQCreator) {
+	s.rfqs = rfqs
+}
+
 // CreateContract creates a new contract
 func (s *ContractService) CreateContract(ctx context.Context, tenantID, createdBy string, req CreateContractRequest) (*ContractResponse, error) {
 	s.logger.Info("Creating contract", slog.String("tenant_id", tenantID), slog.String("rfq_id", req.RFQID))
@@ -32,9 +54,11 @@ func (s *ContractService) CreateContract(ctx context.Context, tenantID, createdB
 	contract := domain.NewContract(tenantID, req.RFQID, req.QuoteID, req.SupplierID, req.SupplierName, createdBy)
 	contract.ID = ksuid.New().String()
 	
-	// Generate contract number using database function (we'll call it via direct query in repository)
-	// For now, generate a simple contract number
-	contract.ContractNumber = fmt.Sprintf("CT-%s-%04d", time.Now().Format("20060102"), time.Now().Unix()%10000)
+	number, err := s.repo.NextContractNumber(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	contract.ContractNumber = number
 	
 	// Set dates and terms
 	contract.StartDate = req.StartDate
@@ -45,6 +69,9 @@ func (s *ContractService) CreateContract(ctx context.Context, tenantID, createdB
 	contract.TermsAndConditions = req.TermsAndConditions
 	contract.TaxAmount = req.TaxAmount
 	contract.Notes = req.Notes
+	if err := contract.SetCurrency(req.Currency); err != nil {
+		return nil, err
+	}
 
 	// Add items
 	for _, itemReq := range req.Items {
@@ -54,7 +81,6 @@ func (s *ContractService) CreateContract(ctx context.Context, tenantID, createdB
 			EquipmentName:    itemReq.EquipmentName,
 			Quantity:         itemReq.Quantity,
 			UnitPrice:        itemReq.UnitPrice,
-			TotalPrice:       float64(itemReq.Quantity) * itemReq.UnitPrice,
 			ManufacturerName: itemReq.ManufacturerName,
 			ModelNumber:      itemReq.ModelNumber,
 			Specifications:   itemReq.Specifications,
@@ -68,10 +94,12 @@ func (s *ContractService) CreateContract(ctx context.Context, tenantID, createdB
 	// Add payment schedule
 	for _, paymentReq := range req.PaymentSchedule {
 		payment := domain.PaymentTerm{
-			DueDate:     paymentReq.DueDate,
-			Amount:      paymentReq.Amount,
-			Description: paymentReq.Description,
-			Paid:        false,
+			DueDate:          paymentReq.DueDate,
+			Amount:           paymentReq.Amount,
+			Description:      paymentReq.Description,
+			RetentionAmount:  paymentReq.RetentionAmount,
+			RetentionDueDate: paymentReq.RetentionDueDate,
+			Paid:             false,
 		}
 		if err := contract.AddPaymentTerm(payment); err != nil {
 			return nil, fmt.Errorf("failed to add payment term: %w", err)
@@ -92,6 +120,9 @@ func (s *ContractService) CreateContract(ctx context.Context, tenantID, createdB
 
 	// Calculate totals
 	contract.CalculateTotals()
+	if err := contract.ValidatePaymentSchedule(); err != nil {
+		return nil, err
+	}
 
 	// Save to repository
 	if err := s.repo.Create(ctx, contract); err != nil {
@@ -256,13 +287,20 @@ func (s *ContractService) ActivateContract(ctx context.Context, tenantID, id str
 	return &response, nil
 }
 
-// SignContract signs a contract
+// SignContract signs a contract. Contracts out for e-signature are signed
+// through their signing request instead.
 func (s *ContractService) SignContract(ctx context.Context, tenantID, id string, req SignContractRequest) (*ContractResponse, error) {
 	contract, err := s.repo.GetByID(ctx, tenantID, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if open, err := s.openSigningRequest(ctx, tenantID, id); err != nil {
+		return nil, err
+	} else if open != nil {
+		return nil, domain.ErrSigningInProgress
+	}
+
 	if err := contract.Sign(req.SignedBy); err != nil {
 		return nil, err
 	}
@@ -351,7 +389,8 @@ func (s *ContractService) ResumeContract(ctx context.Context, tenantID, id strin
 	return &response, nil
 }
 
-// AddAmendment adds an amendment to a contract
+// AddAmendment records a free-form amendment on a contract and its new
+// version. Structured changes go through ProposeAmendment instead.
 func (s *ContractService) AddAmendment(ctx context.Context, tenantID, id string, req AddAmendmentRequest) (*ContractResponse, error) {
 	contract, err := s.repo.GetByID(ctx, tenantID, id)
 	if err != nil {
@@ -366,11 +405,19 @@ func (s *ContractService) AddAmendment(ctx context.Context, tenantID, id string,
 		AmendedBy:   req.AmendedBy,
 	}
 
+	before, err := contract.Snapshot(req.AmendedBy)
+	if err != nil {
+		return nil, err
+	}
 	if err := contract.AddAmendment(amendment); err != nil {
 		return nil, err
 	}
+	after, err := contract.Snapshot(req.AmendedBy)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := s.repo.Update(ctx, contract); err != nil {
+	if err := s.amendments.SaveAmendedContract(ctx, contract, before, after, nil); err != nil {
 		return nil, err
 	}
 
@@ -397,14 +444,20 @@ func (s *ContractService) MarkPaymentPaid(ctx context.Context, tenantID, id stri
 	return &response, nil
 }
 
-// MarkDeliveryCompleted marks a delivery milestone as completed
+// MarkDeliveryCompleted marks a delivery milestone as completed, registering
+// the delivered units in the equipment registry
 func (s *ContractService) MarkDeliveryCompleted(ctx context.Context, tenantID, id string, req MarkDeliveryCompletedRequest) (*ContractResponse, error) {
 	contract, err := s.repo.GetByID(ctx, tenantID, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := contract.MarkDeliveryCompleted(req.DeliveryIndex); err != nil {
+	equipmentIDs, err := s.registerDeliveredUnits(ctx, contract, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := contract.MarkDeliveryCompleted(req.DeliveryIndex, equipmentIDs...); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,301 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+)
+
+// DefaultSigningExpiryDays is how long signatories have to sign when a
+// signing request does not set its own expiry
+const DefaultSigningExpiryDays = 14
+
+// RenderDocument renders the contract as its next document version
+func (s *ContractService) RenderDocument(ctx context.Context, tenantID, contractID, renderedBy string) (*domain.ContractDocument, error) {
+	contract, err := s.repo.GetByID(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.documents.LatestVersion(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.renderer.Render(contract, latest+1)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := domain.NewContractDocument(contract, latest+1, domain.DocumentKindRendered, content, renderedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.documents.CreateDocument(ctx, doc); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Contract document rendered",
+		slog.String("contract_id", contractID),
+		slog.Int("version", doc.Version),
+		slog.String("hash", doc.ContentHash))
+	return doc, nil
+}
+
+// ListDocuments lists a contract's rendered and sealed documents
+func (s *ContractService) ListDocuments(ctx context.Context, tenantID, contractID string) ([]*domain.ContractDocument, error) {
+	if _, err := s.repo.GetByID(ctx, tenantID, contractID); err != nil {
+		return nil, err
+	}
+	return s.documents.ListDocuments(ctx, tenantID, contractID)
+}
+
+// GetDocument retrieves a contract document with its PDF content
+func (s *ContractService) GetDocument(ctx context.Context, tenantID, contractID, documentID string) (*domain.ContractDocument, error) {
+	return s.documents.GetDocument(ctx, tenantID, contractID, documentID)
+}
+
+// StartSigning sends a rendered document to the buyer's and supplier's
+// signatories. Only one signing request per contract can be open.
+func (s *ContractService) StartSigning(ctx context.Context, tenantID, contractID, createdBy string, req StartSigningRequest) (*SigningResponse, error) {
+	contract, err := s.repo.GetByID(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	if open, err := s.openSigningRequest(ctx, tenantID, contractID); err != nil {
+		return nil, err
+	} else if open != nil {
+		return nil, domain.ErrSigningInProgress
+	}
+
+	var doc *domain.ContractDocument
+	if req.DocumentID != "" {
+		doc, err = s.documents.GetDocument(ctx, tenantID, contractID, req.DocumentID)
+	} else {
+		doc, err = s.latestRenderedDocument(ctx, tenantID, contractID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	days := req.ExpiresInDays
+	if days <= 0 {
+		days = DefaultSigningExpiryDays
+	}
+	signatories := make([]domain.Signatory, 0, len(req.Signatories))
+	for _, sr := range req.Signatories {
+		signatories = append(signatories, domain.Signatory{
+			UserID: sr.UserID,
+			Party:  sr.Party,
+			Name:   sr.Name,
+			Email:  sr.Email,
+			Title:  sr.Title,
+			Order:  sr.Order,
+		})
+	}
+
+	request, err := domain.NewSigningRequest(contract, doc, signatories, time.Now().AddDate(0, 0, days), createdBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.documents.CreateSigningRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Contract sent for signing",
+		slog.String("contract_id", contractID),
+		slog.String("signing_request_id", request.ID),
+		slog.Int("document_version", doc.Version))
+	return toSigningResponse(request), nil
+}
+
+// GetSigningRequest retrieves a signing request, expiring it if overdue
+func (s *ContractService) GetSigningRequest(ctx context.Context, tenantID, contractID, requestID string) (*SigningResponse, error) {
+	request, err := s.documents.GetSigningRequest(ctx, tenantID, contractID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.expireIfOverdue(ctx, request); err != nil {
+		return nil, err
+	}
+	return toSigningResponse(request), nil
+}
+
+// ListSigningRequests lists a contract's signing requests, newest first
+func (s *ContractService) ListSigningRequests(ctx context.Context, tenantID, contractID string) ([]*SigningResponse, error) {
+	requests, err := s.documents.ListSigningRequests(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*SigningResponse, 0, len(requests))
+	for _, request := range requests {
+		if err := s.expireIfOverdue(ctx, request); err != nil {
+			return nil, err
+		}
+		responses = append(responses, toSigningResponse(request))
+	}
+	return responses, nil
+}
+
+// SignDocument records a signatory's signature. The last signature seals the
+// document with an audit page and marks the contract signed.
+func (s *ContractService) SignDocument(ctx context.Context, tenantID, contractID, requestID, signatoryID string, evidence domain.SignatureEvidence) (*SigningResponse, error) {
+	request, err := s.documents.GetSigningRequest(ctx, tenantID, contractID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := request.Sign(signatoryID, evidence, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrSigningExpired) {
+			if updateErr := s.documents.UpdateSigningRequest(ctx, request); updateErr != nil {
+				return nil, updateErr
+			}
+		}
+		return nil, err
+	}
+
+	if request.Status != domain.SigningStatusCompleted {
+		if err := s.documents.UpdateSigningRequest(ctx, request); err != nil {
+			return nil, err
+		}
+		s.logger.Info("Contract document signed",
+			slog.String("signing_request_id", request.ID),
+			slog.String("signatory_id", signatoryID))
+		return toSigningResponse(request), nil
+	}
+
+	if err := s.completeSigning(ctx, request); err != nil {
+		return nil, err
+	}
+	return toSigningResponse(request), nil
+}
+
+// DeclineSigning records a signatory declining, which closes the request
+func (s *ContractService) DeclineSigning(ctx context.Context, tenantID, contractID, requestID, signatoryID, declinedBy string, req DeclineSigningRequest) (*SigningResponse, error) {
+	request, err := s.documents.GetSigningRequest(ctx, tenantID, contractID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	declineErr := request.Decline(signatoryID, declinedBy, req.Reason, time.Now())
+	if declineErr != nil && !errors.Is(declineErr, domain.ErrSigningExpired) {
+		return nil, declineErr
+	}
+	if err := s.documents.UpdateSigningRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	if declineErr != nil {
+		return nil, declineErr
+	}
+
+	s.logger.Info("Contract signing declined",
+		slog.String("signing_request_id", request.ID),
+		slog.String("signatory_id", signatoryID))
+	return toSigningResponse(request), nil
+}
+
+// CancelSigning withdraws an open signing request
+func (s *ContractService) CancelSigning(ctx context.Context, tenantID, contractID, requestID string) (*SigningResponse, error) {
+	request, err := s.documents.GetSigningRequest(ctx, tenantID, contractID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := request.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := s.documents.UpdateSigningRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return toSigningResponse(request), nil
+}
+
+// completeSigning seals the signed document and records the signature on the contract
+func (s *ContractService) completeSigning(ctx context.Context, request *domain.SigningRequest) error {
+	contract, err := s.repo.GetByID(ctx, request.TenantID, request.ContractID)
+	if err != nil {
+		return err
+	}
+	doc, err := s.documents.GetDocument(ctx, request.TenantID, request.ContractID, request.DocumentID)
+	if err != nil {
+		return err
+	}
+	snapshot, err := doc.SnapshotContract()
+	if err != nil {
+		return err
+	}
+
+	content, err := s.renderer.Seal(snapshot, doc, request)
+	if err != nil {
+		return err
+	}
+	sealed, err := domain.NewContractDocument(snapshot, doc.Version, domain.DocumentKindSealed, content, request.CreatedBy)
+	if err != nil {
+		return err
+	}
+	sealed.SigningRequestID = request.ID
+	request.SealedDocumentID = sealed.ID
+
+	if err := contract.Sign(request.SignerNames()); err != nil {
+		return fmt.Errorf("failed to record contract signature: %w", err)
+	}
+	if err := s.documents.CompleteSigning(ctx, request, sealed, contract); err != nil {
+		return err
+	}
+
+	s.logger.Info("Contract signing completed",
+		slog.String("contract_id", contract.ID),
+		slog.String("sealed_document_id", sealed.ID),
+		slog.String("hash", sealed.ContentHash))
+	return nil
+}
+
+// openSigningRequest returns the contract's open signing request, if any,
+// expiring overdue ones on the way
+func (s *ContractService) openSigningRequest(ctx context.Context, tenantID, contractID string) (*domain.SigningRequest, error) {
+	requests, err := s.documents.ListSigningRequests(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		if err := s.expireIfOverdue(ctx, request); err != nil {
+			return nil, err
+		}
+		if request.IsOpen() {
+			return request, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *ContractService) latestRenderedDocument(ctx context.Context, tenantID, contractID string) (*domain.ContractDocument, error) {
+	docs, err := s.documents.ListDocuments(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		if doc.Kind == domain.DocumentKindRendered {
+			return s.documents.GetDocument(ctx, tenantID, contractID, doc.ID)
+		}
+	}
+	return nil, fmt.Errorf("%w: render the contract before sending it for signing", domain.ErrDocumentNotFound)
+}
+
+func (s *ContractService) expireIfOverdue(ctx context.Context, request *domain.SigningRequest) error {
+	if !request.Expire(time.Now()) {
+		return nil
+	}
+	return s.documents.UpdateSigningRequest(ctx, request)
+}
+
+func toSigningResponse(request *domain.SigningRequest) *SigningResponse {
+	return &SigningResponse{
+		SigningRequest:  request,
+		NextSignatories: request.NextSignatories(),
+	}
+}
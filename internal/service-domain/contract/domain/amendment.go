@@ -0,0 +1,542 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrAmendmentNotFound  = errors.New("amendment request not found")
+	ErrInvalidAmendment   = errors.New("invalid amendment")
+	ErrAmendmentPending   = errors.New("an amendment is already pending for this contract")
+	ErrAmendmentDecided   = errors.New("amendment already decided")
+	ErrAmendmentStale     = errors.New("contract changed since the amendment was proposed")
+	ErrApprovalNotAllowed = errors.New("approval not allowed")
+	ErrVersionNotFound    = errors.New("contract version not found")
+)
+
+// Approval roles
+const (
+	RoleProcurementManager = "procurement_manager"
+	RoleFinance            = "finance"
+	RoleLegal              = "legal"
+)
+
+// ItemChange changes the quantity or unit price of a contract item
+type ItemChange struct {
+	ItemID    string   `json:"item_id"`
+	Quantity  *int     `json:"quantity,omitempty"`
+	UnitPrice *float64 `json:"unit_price,omitempty"`
+}
+
+// ChangeSet is a structured set of changes to a contract
+type ChangeSet struct {
+	AddItems           []ContractItem `json:"add_items,omitempty"`
+	RemoveItems        []string       `json:"remove_items,omitempty"` // Item IDs
+	ItemChanges        []ItemChange   `json:"item_changes,omitempty"`
+	StartDate          *time.Time     `json:"start_date,omitempty"`
+	EndDate            *time.Time     `json:"end_date,omitempty"`
+	TaxAmount          *float64       `json:"tax_amount,omitempty"`
+	PaymentTerms       *string        `json:"payment_terms,omitempty"`
+	DeliveryTerms      *string        `json:"delivery_terms,omitempty"`
+	WarrantyTerms      *string        `json:"warranty_terms,omitempty"`
+	TermsAndConditions *string        `json:"terms_and_conditions,omitempty"`
+}
+
+// IsEmpty reports whether the change set changes nothing
+func (cs ChangeSet) IsEmpty() bool {
+	return len(cs.AddItems) == 0 && len(cs.RemoveItems) == 0 && len(cs.ItemChanges) == 0 &&
+		cs.StartDate == nil && cs.EndDate == nil && cs.TaxAmount == nil && !cs.changesTerms()
+}
+
+func (cs ChangeSet) changesTerms() bool {
+	return cs.PaymentTerms != nil || cs.DeliveryTerms != nil || cs.WarrantyTerms != nil || cs.TermsAndConditions != nil
+}
+
+func (cs ChangeSet) changesValue() bool {
+	return len(cs.AddItems) > 0 || len(cs.RemoveItems) > 0 || len(cs.ItemChanges) > 0 || cs.TaxAmount != nil
+}
+
+// RequiredApprovalRoles returns the roles that must approve the change set:
+// a procurement manager always, finance for changes to the contract value and
+// legal for changes to the terms
+func (cs ChangeSet) RequiredApprovalRoles() []string {
+	roles := []string{RoleProcurementManager}
+	if cs.changesValue() {
+		roles = append(roles, RoleFinance)
+	}
+	if cs.changesTerms() {
+		roles = append(roles, RoleLegal)
+	}
+	return roles
+}
+
+// FieldChange is one field-level difference between two contract states
+type FieldChange struct {
+	Field  string      `json:"field"` // e.g. end_date, items[<id>].unit_price
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// Version returns the contract version: 1 as created, plus one per applied amendment
+func (c *Contract) Version() int {
+	return len(c.Amendments) + 1
+}
+
+// Clone returns a deep copy of the contract
+func (c *Contract) Clone() (*Contract, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy contract: %w", err)
+	}
+	var clone Contract
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to copy contract: %w", err)
+	}
+	return &clone, nil
+}
+
+// applyChangeSet applies the changes, recalculates the totals and checks the
+// payment schedule still fits the new total
+func (c *Contract) applyChangeSet(cs ChangeSet) error {
+	items := map[string]int{}
+	for i, item := range c.Items {
+		items[item.ID] = i
+	}
+
+	removed := map[string]bool{}
+	for _, id := range cs.RemoveItems {
+		if _, ok := items[id]; !ok {
+			return fmt.Errorf("%w: item %s is not on the contract", ErrInvalidAmendment, id)
+		}
+		removed[id] = true
+	}
+	for _, change := range cs.ItemChanges {
+		i, ok := items[change.ItemID]
+		if !ok || removed[change.ItemID] {
+			return fmt.Errorf("%w: item %s is not on the contract", ErrInvalidAmendment, change.ItemID)
+		}
+		if change.Quantity != nil {
+			if *change.Quantity <= 0 {
+				return fmt.Errorf("%w: quantity must be positive", ErrInvalidAmendment)
+			}
+			c.Items[i].Quantity = *change.Quantity
+		}
+		if change.UnitPrice != nil {
+			if *change.UnitPrice < 0 {
+				return fmt.Errorf("%w: unit price cannot be negative", ErrInvalidAmendment)
+			}
+			c.Items[i].UnitPrice = *change.UnitPrice
+		}
+		c.Items[i].TotalPrice = c.lineTotal(c.Items[i])
+	}
+
+	kept := make([]ContractItem, 0, len(c.Items)+len(cs.AddItems))
+	for _, item := range c.Items {
+		if !removed[item.ID] {
+			kept = append(kept, item)
+		}
+	}
+	for _, item := range cs.AddItems {
+		if _, exists := items[item.ID]; exists || item.ID == "" {
+			return fmt.Errorf("%w: added items need a new ID", ErrInvalidAmendment)
+		}
+		if item.Quantity <= 0 || item.UnitPrice < 0 {
+			return fmt.Errorf("%w: added items need a positive quantity and a price", ErrInvalidAmendment)
+		}
+		item.TotalPrice = c.lineTotal(item)
+		kept = append(kept, item)
+	}
+	if len(kept) == 0 {
+		return fmt.Errorf("%w: contract must keep at least one item", ErrInvalidAmendment)
+	}
+	c.Items = kept
+
+	if cs.StartDate != nil {
+		c.StartDate = *cs.StartDate
+	}
+	if cs.EndDate != nil {
+		c.EndDate = *cs.EndDate
+	}
+	if !c.EndDate.After(c.StartDate) {
+		return fmt.Errorf("%w: end date must be after the start date", ErrInvalidAmendment)
+	}
+	if cs.TaxAmount != nil {
+		if *cs.TaxAmount < 0 {
+			return fmt.Errorf("%w: tax cannot be negative", ErrInvalidAmendment)
+		}
+		c.TaxAmount = *cs.TaxAmount
+	}
+	if cs.PaymentTerms != nil {
+		c.PaymentTerms = *cs.PaymentTerms
+	}
+	if cs.DeliveryTerms != nil {
+		c.DeliveryTerms = *cs.DeliveryTerms
+	}
+	if cs.WarrantyTerms != nil {
+		c.WarrantyTerms = *cs.WarrantyTerms
+	}
+	if cs.TermsAndConditions != nil {
+		c.TermsAndConditions = *cs.TermsAndConditions
+	}
+
+	c.CalculateTotals()
+	return c.ValidatePaymentSchedule()
+}
+
+// DiffContracts computes the field-level changes from one contract state to another
+func DiffContracts(before, after *Contract) []FieldChange {
+	diff := []FieldChange{}
+	add := func(field string, b, a interface{}) {
+		diff = append(diff, FieldChange{Field: field, Before: b, After: a})
+	}
+	date := func(t time.Time) string { return t.Format("2006-01-02") }
+
+	if !before.StartDate.Equal(after.StartDate) {
+		add("start_date", date(before.StartDate), date(after.StartDate))
+	}
+	if !before.EndDate.Equal(after.EndDate) {
+		add("end_date", date(before.EndDate), date(after.EndDate))
+	}
+	for _, f := range []struct {
+		field         string
+		before, after string
+	}{
+		{"payment_terms", before.PaymentTerms, after.PaymentTerms},
+		{"delivery_terms", before.DeliveryTerms, after.DeliveryTerms},
+		{"warranty_terms", before.WarrantyTerms, after.WarrantyTerms},
+		{"terms_and_conditions", before.TermsAndConditions, after.TermsAndConditions},
+	} {
+		if f.before != f.after {
+			add(f.field, f.before, f.after)
+		}
+	}
+
+	beforeItems := map[string]ContractItem{}
+	for _, item := range before.Items {
+		beforeItems[item.ID] = item
+	}
+	afterItems := map[string]ContractItem{}
+	for _, item := range after.Items {
+		afterItems[item.ID] = item
+		old, existed := beforeItems[item.ID]
+		field := "items[" + item.ID + "]"
+		if !existed {
+			add(field, nil, item)
+			continue
+		}
+		if old.Quantity != item.Quantity {
+			add(field+".quantity", old.Quantity, item.Quantity)
+		}
+		if old.UnitPrice != item.UnitPrice {
+			add(field+".unit_price", old.UnitPrice, item.UnitPrice)
+		}
+		if old.TotalPrice != item.TotalPrice {
+			add(field+".total_price", old.TotalPrice, item.TotalPrice)
+		}
+	}
+	for _, item := range before.Items {
+		if _, kept := afterItems[item.ID]; !kept {
+			add("items["+item.ID+"]", item, nil)
+		}
+	}
+
+	if before.TaxAmount != after.TaxAmount {
+		add("tax_amount", before.TaxAmount, after.TaxAmount)
+	}
+	if before.TotalAmount != after.TotalAmount {
+		add("total_amount", before.TotalAmount, after.TotalAmount)
+	}
+	return diff
+}
+
+// AmendmentStatus represents the state of an amendment request
+type AmendmentStatus string
+
+const (
+	AmendmentStatusPending   AmendmentStatus = "pending"
+	AmendmentStatusApproved  AmendmentStatus = "approved"
+	AmendmentStatusRejected  AmendmentStatus = "rejected"
+	AmendmentStatusWithdrawn AmendmentStatus = "withdrawn"
+)
+
+// AmendmentApproval records one role's approval of an amendment request
+type AmendmentApproval struct {
+	Role       string    `json:"role"`
+	ApprovedBy string    `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"`
+	Notes      string    `json:"notes,omitempty"`
+}
+
+// AmendmentRequest proposes a change set against a contract version. It is
+// applied once every required role has approved it.
+type AmendmentRequest struct {
+	ID            string              `json:"id"`
+	TenantID      string              `json:"tenant_id"`
+	ContractID    string              `json:"contract_id"`
+	BaseVersion   int                 `json:"base_version"`
+	Description   string              `json:"description"`
+	ChangeSet     ChangeSet           `json:"change_set"`
+	Diff          []FieldChange       `json:"diff"`
+	PreviousTotal float64             `json:"previous_total"`
+	NewTotal      float64             `json:"new_total"`
+	RequiredRoles []string            `json:"required_roles"`
+	Approvals     []AmendmentApproval `json:"approvals"`
+	Status        AmendmentStatus     `json:"status"`
+	RequestedBy   string              `json:"requested_by"`
+	RequestedAt   time.Time           `json:"requested_at"`
+	DecidedBy     *string             `json:"decided_by,omitempty"`
+	DecidedAt     *time.Time          `json:"decided_at,omitempty"`
+	DecisionNotes string              `json:"decision_notes,omitempty"`
+}
+
+// ProposeAmendment validates a change set against the contract and returns a
+// pending amendment request with its diff. New items are given IDs here so
+// the diff stays stable until the amendment is applied.
+func (c *Contract) ProposeAmendment(description string, cs ChangeSet, requestedBy string, extraRoles []string) (*AmendmentRequest, error) {
+	if c.Status != ContractStatusActive {
+		return nil, ErrCannotAmendContract
+	}
+	if strings.TrimSpace(description) == "" {
+		return nil, fmt.Errorf("%w: description is required", ErrInvalidAmendment)
+	}
+	if cs.IsEmpty() {
+		return nil, fmt.Errorf("%w: change set is empty", ErrInvalidAmendment)
+	}
+	for i := range cs.AddItems {
+		cs.AddItems[i].ID = ksuid.New().String()
+	}
+
+	after, err := c.Clone()
+	if err != nil {
+		return nil, err
+	}
+	if err := after.applyChangeSet(cs); err != nil {
+		return nil, err
+	}
+
+	roles := cs.RequiredApprovalRoles()
+	for _, role := range extraRoles {
+		role = strings.TrimSpace(role)
+		if role != "" && !containsString(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+
+	return &AmendmentRequest{
+		ID:            ksuid.New().String(),
+		TenantID:      c.TenantID,
+		ContractID:    c.ID,
+		BaseVersion:   c.Version(),
+		Description:   description,
+		ChangeSet:     cs,
+		Diff:          DiffContracts(c, after),
+		PreviousTotal: c.TotalAmount,
+		NewTotal:      after.TotalAmount,
+		RequiredRoles: roles,
+		Approvals:     []AmendmentApproval{},
+		Status:        AmendmentStatusPending,
+		RequestedBy:   requestedBy,
+		RequestedAt:   time.Now(),
+	}, nil
+}
+
+// PendingRoles returns the required roles that have not approved yet
+func (r *AmendmentRequest) PendingRoles() []string {
+	pending := []string{}
+	for _, role := range r.RequiredRoles {
+		approved := false
+		for _, a := range r.Approvals {
+			if a.Role == role {
+				approved = true
+				break
+			}
+		}
+		if !approved {
+			pending = append(pending, role)
+		}
+	}
+	return pending
+}
+
+// Approve records an approval in one of the required roles. The requester
+// cannot approve their own amendment, and nobody can approve in two roles.
+// When the last role approves, the change set is applied to the contract,
+// which must still be at the version the amendment was proposed against.
+// It reports whether the amendment was applied.
+func (r *AmendmentRequest) Approve(c *Contract, role, approvedBy, notes string) (bool, error) {
+	if r.Status != AmendmentStatusPending {
+		return false, ErrAmendmentDecided
+	}
+	if !containsString(r.PendingRoles(), role) {
+		return false, fmt.Errorf("%w: %q is not a pending approval role for this amendment", ErrApprovalNotAllowed, role)
+	}
+	if approvedBy == "" || approvedBy == r.RequestedBy {
+		return false, fmt.Errorf("%w: the requester cannot approve their own amendment", ErrApprovalNotAllowed)
+	}
+	for _, a := range r.Approvals {
+		if a.ApprovedBy == approvedBy {
+			return false, fmt.Errorf("%w: %s already approved as %s", ErrApprovalNotAllowed, approvedBy, a.Role)
+		}
+	}
+
+	now := time.Now()
+	r.Approvals = append(r.Approvals, AmendmentApproval{Role: role, ApprovedBy: approvedBy, ApprovedAt: now, Notes: notes})
+	if len(r.PendingRoles()) > 0 {
+		return false, nil
+	}
+
+	if err := c.applyAmendment(r, now); err != nil {
+		r.Approvals = r.Approvals[:len(r.Approvals)-1]
+		return false, err
+	}
+	r.decide(AmendmentStatusApproved, approvedBy, notes, now)
+	return true, nil
+}
+
+// Reject declines a pending amendment; the contract is left unchanged
+func (r *AmendmentRequest) Reject(decidedBy, notes string) error {
+	if r.Status != AmendmentStatusPending {
+		return ErrAmendmentDecided
+	}
+	r.decide(AmendmentStatusRejected, decidedBy, notes, time.Now())
+	return nil
+}
+
+// Withdraw lets the requester take back a pending amendment
+func (r *AmendmentRequest) Withdraw(by string) error {
+	if r.Status != AmendmentStatusPending {
+		return ErrAmendmentDecided
+	}
+	if by != r.RequestedBy {
+		return fmt.Errorf("%w: only the requester can withdraw an amendment", ErrApprovalNotAllowed)
+	}
+	r.decide(AmendmentStatusWithdrawn, by, "", time.Now())
+	return nil
+}
+
+func (r *AmendmentRequest) decide(status AmendmentStatus, decidedBy, notes string, at time.Time) {
+	r.Status = status
+	r.DecidedBy = &decidedBy
+	r.DecidedAt = &at
+	r.DecisionNotes = notes
+}
+
+// applyAmendment applies an approved amendment request and records it
+func (c *Contract) applyAmendment(r *AmendmentRequest, now time.Time) error {
+	if c.Status != ContractStatusActive {
+		return ErrCannotAmendContract
+	}
+	if c.Version() != r.BaseVersion {
+		return fmt.Errorf("%w: proposed against version %d, contract is at version %d", ErrAmendmentStale, r.BaseVersion, c.Version())
+	}
+
+	before, err := c.Clone()
+	if err != nil {
+		return err
+	}
+	if err := c.applyChangeSet(r.ChangeSet); err != nil {
+		*c = *before
+		return err
+	}
+
+	diff := DiffContracts(before, c)
+	changes, _ := json.Marshal(diff)
+	approvers := make([]string, 0, len(r.Approvals))
+	for _, a := range r.Approvals {
+		approvers = append(approvers, a.ApprovedBy)
+	}
+	cs := r.ChangeSet
+	c.Amendments = append(c.Amendments, Amendment{
+		ID:          r.ID,
+		Date:        now,
+		Description: r.Description,
+		Changes:     string(changes),
+		AmendedBy:   r.RequestedBy,
+		Version:     before.Version() + 1,
+		ChangeSet:   &cs,
+		Diff:        diff,
+		ApprovedBy:  approvers,
+	})
+	r.Diff = diff
+	r.NewTotal = c.TotalAmount
+	c.UpdatedAt = now
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ContractVersion is a snapshot of a contract as of one version
+type ContractVersion struct {
+	ContractID  string    `json:"contract_id"`
+	TenantID    string    `json:"tenant_id"`
+	Version     int       `json:"version"`
+	AmendmentID string    `json:"amendment_id,omitempty"` // Amendment that produced the version
+	TotalAmount float64   `json:"total_amount"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	Contract    *Contract `json:"contract,omitempty"` // Omitted from listings
+}
+
+// Snapshot captures the contract at its current version
+func (c *Contract) Snapshot(createdBy string) (*ContractVersion, error) {
+	clone, err := c.Clone()
+	if err != nil {
+		return nil, err
+	}
+	v := &ContractVersion{
+		ContractID:  c.ID,
+		TenantID:    c.TenantID,
+		Version:     c.Version(),
+		TotalAmount: c.TotalAmount,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now(),
+		Contract:    clone,
+	}
+	if n := len(c.Amendments); n > 0 {
+		v.AmendmentID = c.Amendments[n-1].ID
+	}
+	return v, nil
+}
+
+// AmendmentRepository persists amendment requests and contract version history
+type AmendmentRepository interface {
+	// CreateAmendmentRequest stores a new amendment request, failing with
+	// ErrAmendmentPending when the contract already has one pending
+	CreateAmendmentRequest(ctx context.Context, r *AmendmentRequest) error
+
+	// GetAmendmentRequest retrieves an amendment request of a contract
+	GetAmendmentRequest(ctx context.Context, tenantID, contractID, id string) (*AmendmentRequest, error)
+
+	// ListAmendmentRequests retrieves a contract's amendment requests, newest first
+	ListAmendmentRequests(ctx context.Context, tenantID, contractID string) ([]*AmendmentRequest, error)
+
+	// UpdateAmendmentRequest saves a pending request's approvals or decision
+	UpdateAmendmentRequest(ctx context.Context, r *AmendmentRequest) error
+
+	// SaveAmendedContract atomically stores the amended contract and its
+	// versions: the version before, if not stored yet, and the new one. The
+	// amendment request is saved too when one is given.
+	SaveAmendedContract(ctx context.Context, contract *Contract, before, after *ContractVersion, r *AmendmentRequest) error
+
+	// ListVersions retrieves a contract's stored versions without snapshots, oldest first
+	ListVersions(ctx context.Context, tenantID, contractID string) ([]*ContractVersion, error)
+
+	// GetVersion retrieves one version of a contract with its snapshot
+	GetVersion(ctx context.Context, tenantID, contractID string, version int) (*ContractVersion, error)
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAmendmentAppliesAfterRequiredApprovals(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	contract := &Contract{ID: "ct1", Currency: "USD", Status: ContractStatusDraft, StartDate: start, EndDate: start.AddDate(1, 0, 0)}
+	contract.AddItem(ContractItem{ID: "i1", EquipmentName: "Monitor", Quantity: 2, UnitPrice: 100})
+	contract.AddItem(ContractItem{ID: "i2", EquipmentName: "Pump", Quantity: 1, UnitPrice: 50})
+	contract.CalculateTotals()
+	contract.Status = ContractStatusActive
+
+	price := 120.0
+	extended := start.AddDate(2, 0, 0)
+	request, err := contract.ProposeAmendment("Price rise and extension", ChangeSet{
+		RemoveItems: []string{"i2"},
+		ItemChanges: []ItemChange{{ItemID: "i1", UnitPrice: &price}},
+		EndDate:     &extended,
+	}, "buyer", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if request.NewTotal != 240 || request.BaseVersion != 1 {
+		t.Fatalf("request = %+v, want new total 240 against version 1", request)
+	}
+	if len(request.RequiredRoles) != 2 || request.RequiredRoles[0] != RoleFinance {
+		t.Fatalf("required roles = %v, want finance and procurement manager", request.RequiredRoles)
+	}
+	if contract.TotalAmount != 250 {
+		t.Fatalf("proposal changed the contract total to %v", contract.TotalAmount)
+	}
+
+	if _, err := request.Approve(contract, RoleFinance, "buyer", ""); !errors.Is(err, ErrApprovalNotAllowed) {
+		t.Fatalf("self approval = %v, want ErrApprovalNotAllowed", err)
+	}
+	if applied, err := request.Approve(contract, RoleFinance, "cfo", ""); err != nil || applied {
+		t.Fatalf("finance approval = %v, %v; want pending", applied, err)
+	}
+	if _, err := request.Approve(contract, RoleProcurementManager, "cfo", ""); !errors.Is(err, ErrApprovalNotAllowed) {
+		t.Fatalf("second role by same approver = %v, want ErrApprovalNotAllowed", err)
+	}
+	applied, err := request.Approve(contract, RoleProcurementManager, "pm", "ok")
+	if err != nil || !applied {
+		t.Fatalf("final approval = %v, %v; want applied", applied, err)
+	}
+
+	if contract.TotalAmount != 240 || len(contract.Items) != 1 || !contract.EndDate.Equal(extended) {
+		t.Fatalf("contract = %+v, want one item totalling 240 ending %v", contract, extended)
+	}
+	if contract.Version() != 2 || contract.Amendments[0].Version != 2 || request.Status != AmendmentStatusApproved {
+		t.Fatalf("version = %d, amendment = %+v", contract.Version(), contract.Amendments[0])
+	}
+
+	fields := map[string]bool{}
+	for _, change := range request.Diff {
+		fields[change.Field] = true
+	}
+	for _, field := range []string{"end_date", "items[i1].unit_price", "items[i2]", "total_amount"} {
+		if !fields[field] {
+			t.Errorf("diff %+v missing %s", request.Diff, field)
+		}
+	}
+}
+
+func TestAmendmentStaleAfterContractChanged(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	contract := &Contract{ID: "ct1", Currency: "USD", Status: ContractStatusDraft, StartDate: start, EndDate: start.AddDate(1, 0, 0)}
+	contract.AddItem(ContractItem{ID: "i1", Quantity: 1, UnitPrice: 100})
+	contract.Status = ContractStatusActive
+
+	terms := "Net 60"
+	request, err := contract.ProposeAmendment("Payment terms", ChangeSet{PaymentTerms: &terms}, "buyer", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := contract.AddAmendment(Amendment{ID: "a1", Description: "Side letter"}); err != nil {
+		t.Fatal(err)
+	}
+
+	request.Approve(contract, RoleLegal, "counsel", "")
+	if _, err := request.Approve(contract, RoleProcurementManager, "pm", ""); !errors.Is(err, ErrAmendmentStale) {
+		t.Fatalf("approve stale amendment = %v, want ErrAmendmentStale", err)
+	}
+	if contract.PaymentTerms == terms || request.Status != AmendmentStatusPending {
+		t.Fatalf("stale amendment was applied")
+	}
+}
@@ -2,7 +2,10 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
 )
 
 var (
@@ -26,21 +29,35 @@ const (
 	ContractStatusSuspended ContractStatus = "suspended"
 )
 
-// PaymentTerm represents payment terms
+// PaymentTerm represents payment terms. A retention part of the amount is
+// held back until its own due date, typically after acceptance.
 type PaymentTerm struct {
-	DueDate     time.Time `json:"due_date"`
-	Amount      float64   `json:"amount"`
-	Description string    `json:"description"`
-	Paid        bool      `json:"paid"`
-	PaidDate    *time.Time `json:"paid_date,omitempty"`
+	DueDate          time.Time       `json:"due_date"`
+	Amount           float64         `json:"amount"`
+	Description      string          `json:"description"`
+	RetentionAmount  float64         `json:"retention_amount,omitempty"`
+	RetentionDueDate *time.Time      `json:"retention_due_date,omitempty"`
+	PaidAmount       float64         `json:"paid_amount"`
+	Payments         []PaymentRecord `json:"payments,omitempty"`
+	Paid             bool            `json:"paid"`
+	PaidDate         *time.Time      `json:"paid_date,omitempty"`
+}
+
+// PaymentRecord represents a full or partial payment against a payment term
+type PaymentRecord struct {
+	Amount     float64   `json:"amount"`
+	PaidAt     time.Time `json:"paid_at"`
+	Reference  string    `json:"reference,omitempty"`
+	RecordedBy string    `json:"recorded_by,omitempty"`
 }
 
 // DeliverySchedule represents delivery milestones
 type DeliverySchedule struct {
-	MilestoneDate time.Time `json:"milestone_date"`
-	Description   string    `json:"description"`
-	Completed     bool      `json:"completed"`
+	MilestoneDate time.Time  `json:"milestone_date"`
+	Description   string     `json:"description"`
+	Completed     bool       `json:"completed"`
 	CompletedDate *time.Time `json:"completed_date,omitempty"`
+	EquipmentIDs  []string   `json:"equipment_ids,omitempty"` // Equipment registry records of the delivered units
 }
 
 // ContractItem represents an item in the contract
@@ -57,13 +74,18 @@ type ContractItem struct {
 	WarrantyPeriod    string  `json:"warranty_period"`
 }
 
-// Amendment represents a contract amendment
+// Amendment represents a contract amendment. Amendments applied from an
+// approved change set carry it with the resulting diff and contract version.
 type Amendment struct {
-	ID          string    `json:"id"`
-	Date        time.Time `json:"date"`
-	Description string    `json:"description"`
-	Changes     string    `json:"changes"` // JSON string of what changed
-	AmendedBy   string    `json:"amended_by"`
+	ID          string        `json:"id"`
+	Date        time.Time     `json:"date"`
+	Description string        `json:"description"`
+	Changes     string        `json:"changes"` // JSON string of what changed
+	AmendedBy   string        `json:"amended_by"`
+	Version     int           `json:"version,omitempty"` // Contract version the amendment produced
+	ChangeSet   *ChangeSet    `json:"change_set,omitempty"`
+	Diff        []FieldChange `json:"diff,omitempty"`
+	ApprovedBy  []string      `json:"approved_by,omitempty"`
 }
 
 // Contract is the aggregate root for contract management
@@ -121,7 +143,7 @@ func NewContract(tenantID, rfqID, quoteID, supplierID, supplierName, createdBy s
 		SupplierID:       supplierID,
 		SupplierName:     supplierName,
 		Status:           ContractStatusDraft,
-		Currency:         "USD",
+		Currency:         money.DefaultCurrency,
 		PaymentSchedule:  []PaymentTerm{},
 		DeliverySchedule: []DeliverySchedule{},
 		Items:            []ContractItem{},
@@ -210,6 +232,7 @@ func (c *Contract) AddAmendment(amendment Amendment) error {
 	if c.Status != ContractStatusActive {
 		return ErrCannotAmendContract
 	}
+	amendment.Version = c.Version() + 1
 	c.Amendments = append(c.Amendments, amendment)
 	c.UpdatedAt = time.Now()
 	return nil
@@ -220,12 +243,23 @@ func (c *Contract) AddPaymentTerm(term PaymentTerm) error {
 	if c.Status == ContractStatusCompleted || c.Status == ContractStatusCancelled {
 		return errors.New("cannot add payment terms to completed or cancelled contracts")
 	}
+	if term.Amount <= 0 {
+		return fmt.Errorf("%w: payment amount must be positive", ErrInvalidPaymentSchedule)
+	}
+	if term.RetentionAmount < 0 || term.RetentionAmount > term.Amount {
+		return fmt.Errorf("%w: retention must be between zero and the payment amount", ErrInvalidPaymentSchedule)
+	}
+	if term.RetentionAmount > 0 && (term.RetentionDueDate == nil || term.RetentionDueDate.Before(term.DueDate)) {
+		return fmt.Errorf("%w: retention needs a due date on or after the payment due date", ErrInvalidPaymentSchedule)
+	}
+	term.Amount = money.RoundTo(money.NewFromFloat(term.Amount), c.Currency).Float64()
+	term.RetentionAmount = money.RoundTo(money.NewFromFloat(term.RetentionAmount), c.Currency).Float64()
 	c.PaymentSchedule = append(c.PaymentSchedule, term)
 	c.UpdatedAt = time.Now()
 	return nil
 }
 
-// MarkPaymentPaid marks a payment as paid
+// MarkPaymentPaid marks a payment as paid, recording the outstanding amount as paid now
 func (c *Contract) MarkPaymentPaid(index int) error {
 	if index < 0 || index >= len(c.PaymentSchedule) {
 		return errors.New("invalid payment index")
@@ -233,11 +267,7 @@ func (c *Contract) MarkPaymentPaid(index int) error {
 	if c.PaymentSchedule[index].Paid {
 		return errors.New("payment already marked as paid")
 	}
-	now := time.Now()
-	c.PaymentSchedule[index].Paid = true
-	c.PaymentSchedule[index].PaidDate = &now
-	c.UpdatedAt = now
-	return nil
+	return c.RecordPayment(index, c.PaymentSchedule[index].Outstanding(), time.Now(), "", "")
 }
 
 // AddDeliveryMilestone adds a delivery milestone
@@ -250,8 +280,9 @@ func (c *Contract) AddDeliveryMilestone(milestone DeliverySchedule) error {
 	return nil
 }
 
-// MarkDeliveryCompleted marks a delivery milestone as completed
-func (c *Contract) MarkDeliveryCompleted(index int) error {
+// MarkDeliveryCompleted marks a delivery milestone as completed, linking the
+// equipment registry records of the units delivered with it
+func (c *Contract) MarkDeliveryCompleted(index int, equipmentIDs ...string) error {
 	if index < 0 || index >= len(c.DeliverySchedule) {
 		return errors.New("invalid delivery milestone index")
 	}
@@ -261,6 +292,7 @@ func (c *Contract) MarkDeliveryCompleted(index int) error {
 	now := time.Now()
 	c.DeliverySchedule[index].Completed = true
 	c.DeliverySchedule[index].CompletedDate = &now
+	c.DeliverySchedule[index].EquipmentIDs = append(c.DeliverySchedule[index].EquipmentIDs, equipmentIDs...)
 	c.UpdatedAt = now
 	return nil
 }
@@ -270,21 +302,59 @@ func (c *Contract) AddItem(item ContractItem) error {
 	if c.Status != ContractStatusDraft {
 		return errors.New("can only add items to draft contracts")
 	}
+	item.TotalPrice = c.lineTotal(item)
 	c.Items = append(c.Items, item)
 	c.UpdatedAt = time.Now()
 	return nil
 }
 
+// SetCurrency sets the contract currency and reprices the items in it
+func (c *Contract) SetCurrency(currency string) error {
+	if c.Status != ContractStatusDraft {
+		return errors.New("can only change the currency of draft contracts")
+	}
+	code, err := money.NormalizeCurrency(currency)
+	if err != nil {
+		return err
+	}
+	c.Currency = code
+	for i := range c.Items {
+		c.Items[i].TotalPrice = c.lineTotal(c.Items[i])
+	}
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// lineTotal prices an item in decimal, rounded to the contract currency
+func (c *Contract) lineTotal(item ContractItem) float64 {
+	total := money.NewFromFloat(item.UnitPrice).Mul(money.NewFromInt(int64(item.Quantity)))
+	return money.RoundTo(total, c.Currency).Float64()
+}
+
 // CalculateTotals recalculates the contract totals
 func (c *Contract) CalculateTotals() {
-	total := 0.0
+	total := money.NewFromFloat(c.TaxAmount)
 	for _, item := range c.Items {
-		total += item.TotalPrice
+		total = total.Add(money.NewFromFloat(item.TotalPrice))
 	}
-	c.TotalAmount = total + c.TaxAmount
+	c.TotalAmount = money.RoundTo(total, c.Currency).Float64()
 	c.UpdatedAt = time.Now()
 }
 
+// ValidatePaymentSchedule checks the scheduled payments do not exceed the contract total
+func (c *Contract) ValidatePaymentSchedule() error {
+	scheduled := money.Decimal{}
+	for _, term := range c.PaymentSchedule {
+		scheduled = scheduled.Add(money.NewFromFloat(term.Amount))
+	}
+	if scheduled.Cmp(money.NewFromFloat(c.TotalAmount)) > 0 {
+		return fmt.Errorf("%w: payments of %s exceed the contract total of %s %s",
+			ErrInvalidPaymentSchedule, scheduled.StringFixed(money.MinorUnits(c.Currency)),
+			money.NewFromFloat(c.TotalAmount).StringFixed(money.MinorUnits(c.Currency)), c.Currency)
+	}
+	return nil
+}
+
 // IsExpired checks if the contract has expired
 func (c *Contract) IsExpired() bool {
 	return time.Now().After(c.EndDate) && c.Status == ContractStatusActive
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// EventType represents the type of contract domain event
+type EventType string
+
+const (
+	EventTypeContractDrafted EventType = "contract.drafted"
+)
+
+// DomainEvent is the base structure for all contract domain events
+type DomainEvent struct {
+	EventID   string    `json:"event_id"`
+	EventType EventType `json:"event_type"`
+	TenantID  string    `json:"tenant_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ContractDraftedEvent is published when a contract is drafted from an awarded quote
+type ContractDraftedEvent struct {
+	DomainEvent
+	ContractID     string  `json:"contract_id"`
+	ContractNumber string  `json:"contract_number"`
+	RFQID          string  `json:"rfq_id"`
+	QuoteID        string  `json:"quote_id"`
+	SupplierID     string  `json:"supplier_id"`
+	TotalAmount    float64 `json:"total_amount"`
+	Currency       string  `json:"currency"`
+	ItemCount      int     `json:"item_count"`
+	CreatedBy      string  `json:"created_by"`
+}
+
+// NewContractDraftedEvent creates a new contract drafted event
+func NewContractDraftedEvent(contract *Contract) *ContractDraftedEvent {
+	return &ContractDraftedEvent{
+		DomainEvent: DomainEvent{
+			EventID:   ksuid.New().String(),
+			EventType: EventTypeContractDrafted,
+			TenantID:  contract.TenantID,
+			Timestamp: time.Now(),
+		},
+		ContractID:     contract.ID,
+		ContractNumber: contract.ContractNumber,
+		RFQID:          contract.RFQID,
+		QuoteID:        contract.QuoteID,
+		SupplierID:     contract.SupplierID,
+		TotalAmount:    contract.TotalAmount,
+		Currency:       contract.Currency,
+		ItemCount:      len(contract.Items),
+		CreatedBy:      contract.CreatedBy,
+	}
+}
+
+// EventPublisher defines the interface for publishing contract domain events
+type EventPublisher interface {
+	Publish(ctx context.Context, event interface{}) error
+}
@@ -0,0 +1,439 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrInvalidPayment   = errors.New("invalid payment")
+	ErrInvalidDelivery  = errors.New("invalid delivery")
+	ErrReminderNotFound = errors.New("obligation reminder not found")
+)
+
+// Outstanding returns the amount of the payment term still to be paid. Terms
+// marked paid before partial payments were tracked have nothing outstanding.
+func (t PaymentTerm) Outstanding() float64 {
+	if t.Paid {
+		return 0
+	}
+	outstanding := money.NewFromFloat(t.Amount).Sub(money.NewFromFloat(t.PaidAmount))
+	if outstanding.Sign() <= 0 {
+		return 0
+	}
+	return outstanding.Float64()
+}
+
+// outstandingParts splits the outstanding amount into the part due on the
+// payment due date and the retention due later. Payments settle the part due
+// first, then the retention.
+func (t PaymentTerm) outstandingParts() (due, retention float64) {
+	if t.Paid {
+		return 0, 0
+	}
+	amount := money.NewFromFloat(t.Amount)
+	held := money.NewFromFloat(t.RetentionAmount)
+	paid := money.NewFromFloat(t.PaidAmount)
+
+	dueNow := amount.Sub(held).Sub(paid)
+	if dueNow.Sign() < 0 {
+		dueNow = money.Decimal{}
+	}
+	left := amount.Sub(paid)
+	if left.Sign() < 0 {
+		left = money.Decimal{}
+	}
+	return dueNow.Float64(), left.Sub(dueNow).Float64()
+}
+
+// RecordPayment records a full or partial payment against a payment term.
+// The term is paid once the payments cover its amount, retention included.
+func (c *Contract) RecordPayment(index int, amount float64, paidAt time.Time, reference, recordedBy string) error {
+	if index < 0 || index >= len(c.PaymentSchedule) {
+		return fmt.Errorf("%w: invalid payment index", ErrInvalidPayment)
+	}
+	if c.Status == ContractStatusDraft || c.Status == ContractStatusCancelled {
+		return fmt.Errorf("%w: payments can only be recorded against active contracts", ErrInvalidPayment)
+	}
+	term := &c.PaymentSchedule[index]
+	if term.Paid {
+		return fmt.Errorf("%w: payment already marked as paid", ErrInvalidPayment)
+	}
+
+	paid := money.RoundTo(money.NewFromFloat(amount), c.Currency)
+	if paid.Sign() <= 0 {
+		return fmt.Errorf("%w: payment amount must be positive", ErrInvalidPayment)
+	}
+	if paid.Cmp(money.NewFromFloat(term.Outstanding())) > 0 {
+		return fmt.Errorf("%w: payment of %s exceeds the outstanding %s %s", ErrInvalidPayment,
+			paid.StringFixed(money.MinorUnits(c.Currency)),
+			money.NewFromFloat(term.Outstanding()).StringFixed(money.MinorUnits(c.Currency)), c.Currency)
+	}
+
+	term.Payments = append(term.Payments, PaymentRecord{
+		Amount:     paid.Float64(),
+		PaidAt:     paidAt,
+		Reference:  reference,
+		RecordedBy: recordedBy,
+	})
+	term.PaidAmount = money.NewFromFloat(term.PaidAmount).Add(paid).Float64()
+	if term.Outstanding() == 0 {
+		term.Paid = true
+		term.PaidDate = &paidAt
+	}
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// ObligationKind identifies what a contract obligation is about
+type ObligationKind string
+
+const (
+	ObligationPayment   ObligationKind = "payment"
+	ObligationRetention ObligationKind = "retention"
+	ObligationDelivery  ObligationKind = "delivery"
+)
+
+// ObligationStatus represents where an obligation stands against its due date
+type ObligationStatus string
+
+const (
+	ObligationUpcoming ObligationStatus = "upcoming"
+	ObligationDueSoon  ObligationStatus = "due_soon"
+	ObligationOverdue  ObligationStatus = "overdue"
+	ObligationSettled  ObligationStatus = "settled"
+)
+
+// Aging buckets of overdue obligations, by days past the due date
+const (
+	AgingCurrent = "current"
+	Aging1To30   = "1-30"
+	Aging31To60  = "31-60"
+	Aging61To90  = "61-90"
+	AgingOver90  = "90+"
+)
+
+// AgingBuckets lists the aging buckets in order
+var AgingBuckets = []string{AgingCurrent, Aging1To30, Aging31To60, Aging61To90, AgingOver90}
+
+// AgingBucket returns the aging bucket for an obligation that many days overdue
+func AgingBucket(daysOverdue int) string {
+	switch {
+	case daysOverdue < 0:
+		return AgingCurrent
+	case daysOverdue <= 30:
+		return Aging1To30
+	case daysOverdue <= 60:
+		return Aging31To60
+	case daysOverdue <= 90:
+		return Aging61To90
+	default:
+		return AgingOver90
+	}
+}
+
+// Obligation is a payment, retention release or delivery a contract
+// schedules by a due date
+type Obligation struct {
+	ContractID     string           `json:"contract_id"`
+	ContractNumber string           `json:"contract_number"`
+	SupplierID     string           `json:"supplier_id"`
+	SupplierName   string           `json:"supplier_name"`
+	Kind           ObligationKind   `json:"kind"`
+	Index          int              `json:"index"` // Position in the payment or delivery schedule
+	Description    string           `json:"description"`
+	DueDate        time.Time        `json:"due_date"`
+	Amount         float64          `json:"amount,omitempty"`
+	Outstanding    float64          `json:"outstanding,omitempty"`
+	Currency       string           `json:"currency,omitempty"`
+	Status         ObligationStatus `json:"status"`
+	DaysOverdue    int              `json:"days_overdue,omitempty"`
+	AgingBucket    string           `json:"aging_bucket"`
+}
+
+// IsOpen reports whether the obligation still has to be met
+func (o Obligation) IsOpen() bool {
+	return o.Status != ObligationSettled
+}
+
+// Obligations lists the contract's payment, retention and delivery
+// obligations as of now. Those due within the window are due soon.
+func (c *Contract) Obligations(now time.Time, window time.Duration) []Obligation {
+	obligations := []Obligation{}
+	for i, term := range c.PaymentSchedule {
+		due, retention := term.outstandingParts()
+		obligations = append(obligations, c.obligation(ObligationPayment, i, term.Description, term.DueDate,
+			money.NewFromFloat(term.Amount).Sub(money.NewFromFloat(term.RetentionAmount)).Float64(), due, due == 0, now, window))
+		if term.RetentionAmount > 0 && term.RetentionDueDate != nil {
+			obligations = append(obligations, c.obligation(ObligationRetention, i, "Retention: "+term.Description, *term.RetentionDueDate,
+				term.RetentionAmount, retention, retention == 0, now, window))
+		}
+	}
+	for i, milestone := range c.DeliverySchedule {
+		obligations = append(obligations, c.obligation(ObligationDelivery, i, milestone.Description, milestone.MilestoneDate,
+			0, 0, milestone.Completed, now, window))
+	}
+	return obligations
+}
+
+func (c *Contract) obligation(kind ObligationKind, index int, description string, dueDate time.Time, amount, outstanding float64, settled bool, now time.Time, window time.Duration) Obligation {
+	o := Obligation{
+		ContractID:     c.ID,
+		ContractNumber: c.ContractNumber,
+		SupplierID:     c.SupplierID,
+		SupplierName:   c.SupplierName,
+		Kind:           kind,
+		Index:          index,
+		Description:    description,
+		DueDate:        dueDate,
+		Amount:         amount,
+		Outstanding:    outstanding,
+		AgingBucket:    AgingCurrent,
+	}
+	if kind != ObligationDelivery {
+		o.Currency = c.Currency
+	}
+
+	switch {
+	case settled:
+		o.Status = ObligationSettled
+	case now.After(dueDate):
+		o.Status = ObligationOverdue
+		o.DaysOverdue = int(now.Sub(dueDate).Hours() / 24)
+		o.AgingBucket = AgingBucket(o.DaysOverdue)
+	case !dueDate.After(now.Add(window)):
+		o.Status = ObligationDueSoon
+	default:
+		o.Status = ObligationUpcoming
+	}
+	return o
+}
+
+// AgingSummary totals open obligations of one aging bucket and currency
+type AgingSummary struct {
+	Bucket      string  `json:"bucket"`
+	Currency    string  `json:"currency,omitempty"`
+	Count       int     `json:"count"`
+	Outstanding float64 `json:"outstanding"`
+}
+
+// SummarizeAging totals open obligations per aging bucket and currency, in
+// bucket order. Deliveries count towards a bucket without an amount.
+func SummarizeAging(obligations []Obligation) []AgingSummary {
+	type key struct{ bucket, currency string }
+	totals := map[key]money.Decimal{}
+	counts := map[key]int{}
+	currencies := []string{}
+	seen := map[string]bool{}
+	for _, o := range obligations {
+		if !o.IsOpen() {
+			continue
+		}
+		k := key{o.AgingBucket, o.Currency}
+		totals[k] = totals[k].Add(money.NewFromFloat(o.Outstanding))
+		counts[k]++
+		if !seen[o.Currency] {
+			seen[o.Currency] = true
+			currencies = append(currencies, o.Currency)
+		}
+	}
+
+	summary := []AgingSummary{}
+	for _, bucket := range AgingBuckets {
+		for _, currency := range currencies {
+			k := key{bucket, currency}
+			if counts[k] == 0 {
+				continue
+			}
+			summary = append(summary, AgingSummary{
+				Bucket:      bucket,
+				Currency:    currency,
+				Count:       counts[k],
+				Outstanding: money.RoundTo(totals[k], currency).Float64(),
+			})
+		}
+	}
+	return summary
+}
+
+// Reminder recipients
+const (
+	RecipientBuyer    = "buyer"
+	RecipientSupplier = "supplier"
+)
+
+// Reminder kinds
+const (
+	ReminderDueSoon = "obligation_due_soon"
+	ReminderOverdue = "obligation_overdue"
+)
+
+// ObligationReminder reminds the buyer or supplier of an obligation coming
+// due, or escalates one that is overdue as it ages
+type ObligationReminder struct {
+	ID              string         `json:"id"`
+	TenantID        string         `json:"tenant_id"`
+	ContractID      string         `json:"contract_id"`
+	ObligationKind  ObligationKind `json:"obligation_kind"`
+	ObligationIndex int            `json:"obligation_index"`
+	DueDate         time.Time      `json:"due_date"`
+	AgingBucket     string         `json:"aging_bucket"`
+	Recipient       string         `json:"recipient"`
+	Kind            string         `json:"kind"`
+	Subject         string         `json:"subject"`
+	Message         string         `json:"message"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ReadAt          *time.Time     `json:"read_at,omitempty"`
+}
+
+// ReminderFilter narrows an obligation reminder listing
+type ReminderFilter struct {
+	Recipient  string
+	ContractID string
+	UnreadOnly bool
+}
+
+// Remind builds reminders about an open obligation of the contract: payments
+// and retention go to the buyer, who pays them, deliveries to both parties.
+// It returns nil for obligations that are upcoming or settled.
+func (c *Contract) Remind(o Obligation) []ObligationReminder {
+	var kind, subject, message string
+	switch o.Status {
+	case ObligationDueSoon:
+		kind = ReminderDueSoon
+		subject = fmt.Sprintf("%s: %s due on %s", c.ContractNumber, o.Kind, o.DueDate.Format("2006-01-02"))
+	case ObligationOverdue:
+		kind = ReminderOverdue
+		subject = fmt.Sprintf("%s: %s overdue by %d days", c.ContractNumber, o.Kind, o.DaysOverdue)
+	default:
+		return nil
+	}
+	if o.Kind == ObligationDelivery {
+		message = fmt.Sprintf("Delivery milestone %q with %s is scheduled for %s.", o.Description, c.SupplierName, o.DueDate.Format("2006-01-02"))
+	} else {
+		message = fmt.Sprintf("%q to %s: %s %s outstanding of %s, due %s.", o.Description, c.SupplierName,
+			money.NewFromFloat(o.Outstanding).StringFixed(money.MinorUnits(o.Currency)), o.Currency,
+			money.NewFromFloat(o.Amount).StringFixed(money.MinorUnits(o.Currency)), o.DueDate.Format("2006-01-02"))
+	}
+
+	recipients := []string{RecipientBuyer}
+	if o.Kind == ObligationDelivery {
+		recipients = append(recipients, RecipientSupplier)
+	}
+	now := time.Now()
+	reminders := make([]ObligationReminder, 0, len(recipients))
+	for _, recipient := range recipients {
+		reminders = append(reminders, ObligationReminder{
+			ID:              ksuid.New().String(),
+			TenantID:        c.TenantID,
+			ContractID:      c.ID,
+			ObligationKind:  o.Kind,
+			ObligationIndex: o.Index,
+			DueDate:         o.DueDate,
+			AgingBucket:     o.AgingBucket,
+			Recipient:       recipient,
+			Kind:            kind,
+			Subject:         subject,
+			Message:         message,
+			CreatedAt:       now,
+		})
+	}
+	return reminders
+}
+
+// DeliveredUnit is a unit delivered against a contract item, to be
+// registered in the equipment registry
+type DeliveredUnit struct {
+	ContractItemID       string `json:"contract_item_id"`
+	SerialNumber         string `json:"serial_number"`
+	InstallationLocation string `json:"installation_location,omitempty"`
+}
+
+// ValidateDeliveryCompletion checks a delivery milestone can be completed
+// with the delivered units: they must name items of the contract and carry
+// distinct serial numbers
+func (c *Contract) ValidateDeliveryCompletion(index int, units []DeliveredUnit) error {
+	if index < 0 || index >= len(c.DeliverySchedule) {
+		return fmt.Errorf("%w: invalid delivery milestone index", ErrInvalidDelivery)
+	}
+	if c.DeliverySchedule[index].Completed {
+		return fmt.Errorf("%w: delivery already marked as completed", ErrInvalidDelivery)
+	}
+	items := map[string]bool{}
+	for _, item := range c.Items {
+		items[item.ID] = true
+	}
+	serials := map[string]bool{}
+	for _, unit := range units {
+		serial := strings.ToUpper(strings.TrimSpace(unit.SerialNumber))
+		if serial == "" {
+			return fmt.Errorf("%w: delivered units need a serial number", ErrInvalidDelivery)
+		}
+		if serials[serial] {
+			return fmt.Errorf("%w: serial number %s is listed twice", ErrInvalidDelivery, unit.SerialNumber)
+		}
+		serials[serial] = true
+		if !items[unit.ContractItemID] {
+			return fmt.Errorf("%w: %s is not an item of this contract", ErrInvalidDelivery, unit.ContractItemID)
+		}
+	}
+	return nil
+}
+
+// Item returns the contract item with the given ID
+func (c *Contract) Item(id string) (ContractItem, bool) {
+	for _, item := range c.Items {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return ContractItem{}, false
+}
+
+// WarrantyMonths reads warranty periods such as "24 months", "2 years" or
+// "12", returning 0 when the period cannot be read
+func (i ContractItem) WarrantyMonths() int {
+	fields := strings.Fields(strings.ToLower(i.WarrantyPeriod))
+	if len(fields) == 0 {
+		return 0
+	}
+	var n int
+	if _, err := fmt.Sscanf(fields[0], "%d", &n); err != nil || n <= 0 {
+		return 0
+	}
+	if len(fields) > 1 && strings.HasPrefix(fields[1], "year") {
+		return n * 12
+	}
+	return n
+}
+
+// EquipmentRegistrar registers delivered units in the equipment registry
+type EquipmentRegistrar interface {
+	// RegisterDeliveredUnits creates equipment records linked to the contract
+	// for the units, or links existing records of the same serial number, and
+	// returns their IDs
+	RegisterDeliveredUnits(ctx context.Context, contract *Contract, customerName string, units []DeliveredUnit) ([]string, error)
+}
+
+// ObligationRepository finds contracts with obligations and stores reminders
+type ObligationRepository interface {
+	// ListOpenContracts retrieves active and suspended contracts of a tenant,
+	// or of all tenants when tenantID is empty
+	ListOpenContracts(ctx context.Context, tenantID string) ([]*Contract, error)
+
+	// AddReminders stores reminders, skipping repeats about the same
+	// obligation, due date, aging bucket and recipient, and returns how many were stored
+	AddReminders(ctx context.Context, reminders []ObligationReminder) (int, error)
+
+	// ListReminders retrieves reminders, newest first
+	ListReminders(ctx context.Context, tenantID string, filter ReminderFilter) ([]ObligationReminder, error)
+
+	// MarkReminderRead marks a reminder as read
+	MarkReminderRead(ctx context.Context, tenantID, id string) error
+}
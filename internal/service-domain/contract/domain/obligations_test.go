@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestObligationsTrackPartialPaymentsRetentionAndAging(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	retentionDue := now.AddDate(0, 0, 5)
+	contract := &Contract{ID: "ct1", ContractNumber: "CT-1", Currency: "USD", Status: ContractStatusActive}
+	if err := contract.AddPaymentTerm(PaymentTerm{
+		DueDate: now.AddDate(0, 0, -45), Amount: 1000, Description: "Installation",
+		RetentionAmount: 100, RetentionDueDate: &retentionDue,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	contract.AddDeliveryMilestone(DeliverySchedule{MilestoneDate: now.AddDate(0, 0, 20), Description: "Training"})
+
+	if err := contract.RecordPayment(0, 1200, now, "", ""); !errors.Is(err, ErrInvalidPayment) {
+		t.Fatalf("overpayment = %v, want ErrInvalidPayment", err)
+	}
+	if err := contract.RecordPayment(0, 400, now.AddDate(0, 0, -1), "TX-1", "finance"); err != nil {
+		t.Fatal(err)
+	}
+
+	obligations := contract.Obligations(now, 7*24*time.Hour)
+	if len(obligations) != 3 {
+		t.Fatalf("obligations = %+v, want payment, retention and delivery", obligations)
+	}
+	payment, retention, delivery := obligations[0], obligations[1], obligations[2]
+	if payment.Status != ObligationOverdue || payment.Outstanding != 500 || payment.AgingBucket != Aging31To60 {
+		t.Fatalf("payment = %+v, want 500 overdue in 31-60", payment)
+	}
+	if retention.Kind != ObligationRetention || retention.Status != ObligationDueSoon || retention.Outstanding != 100 {
+		t.Fatalf("retention = %+v, want 100 due soon", retention)
+	}
+	if delivery.Status != ObligationUpcoming {
+		t.Fatalf("delivery = %+v, want upcoming", delivery)
+	}
+
+	if got := contract.Remind(payment); len(got) != 1 || got[0].Recipient != RecipientBuyer || got[0].Kind != ReminderOverdue {
+		t.Fatalf("Remind(payment) = %+v, want one overdue reminder to the buyer", got)
+	}
+
+	if err := contract.RecordPayment(0, 600, now, "TX-2", "finance"); err != nil {
+		t.Fatal(err)
+	}
+	if term := contract.PaymentSchedule[0]; !term.Paid || len(term.Payments) != 2 {
+		t.Fatalf("term = %+v, want paid after two payments", term)
+	}
+	if summary := SummarizeAging(contract.Obligations(now, 0)); len(summary) != 1 || summary[0].Count != 1 {
+		t.Fatalf("aging = %+v, want only the delivery open", summary)
+	}
+}
@@ -0,0 +1,266 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrRenewalExists   = errors.New("contract already has a renewal")
+	ErrRenewalNotFound = errors.New("contract renewal not found")
+	ErrCannotRenew     = errors.New("cannot renew contract in current state")
+)
+
+// ObligationRenewal marks renewal notices in the obligation reminder feed:
+// the obligation is deciding on a renewal before the contract ends
+const ObligationRenewal ObligationKind = "renewal"
+
+// ReminderRenewal is the kind of renewal notices
+const ReminderRenewal = "renewal_notice"
+
+// DefaultRenewalNoticeDays are the lead times, in days before the end date,
+// at which renewal notices are sent
+var DefaultRenewalNoticeDays = []int{90, 60, 30}
+
+// ExpiryReportDays are the horizons of the expiring contracts report
+var ExpiryReportDays = []int{30, 60, 90}
+
+// DaysUntilExpiry returns the whole days left until the contract ends, negative once it has
+func (c *Contract) DaysUntilExpiry(now time.Time) int {
+	return int(c.EndDate.Sub(now).Hours() / 24)
+}
+
+// Expire moves an active or suspended contract past its end date to expired
+func (c *Contract) Expire(now time.Time) error {
+	if c.Status != ContractStatusActive && c.Status != ContractStatusSuspended {
+		return fmt.Errorf("%w: %s contracts do not expire", ErrInvalidContractStatus, c.Status)
+	}
+	if !now.After(c.EndDate) {
+		return fmt.Errorf("%w: contract runs until %s", ErrInvalidContractStatus, c.EndDate.Format("2006-01-02"))
+	}
+	c.Status = ContractStatusExpired
+	c.UpdatedAt = now
+	return nil
+}
+
+// RenewalNotices returns the renewal notices due for an active contract: one
+// to the buyer and one to the supplier for the shortest lead time the contract
+// is within. A contract first seen 20 days out gets only the 30-day notice.
+func (c *Contract) RenewalNotices(now time.Time, leadDays []int) []ObligationReminder {
+	if c.Status != ContractStatusActive {
+		return nil
+	}
+	days := c.DaysUntilExpiry(now)
+	if days < 0 {
+		return nil
+	}
+
+	lead := -1
+	for _, d := range leadDays {
+		if days <= d && (lead < 0 || d < lead) {
+			lead = d
+		}
+	}
+	if lead < 0 {
+		return nil
+	}
+
+	subject := fmt.Sprintf("%s with %s expires on %s", c.ContractNumber, c.SupplierName, c.EndDate.Format("2006-01-02"))
+	message := fmt.Sprintf("The contract ends in %d days. Its value is %s %s; decide whether to renew, re-tender or let it expire.",
+		days, money.NewFromFloat(c.TotalAmount).StringFixed(money.MinorUnits(c.Currency)), c.Currency)
+	notices := make([]ObligationReminder, 0, 2)
+	for _, recipient := range []string{RecipientBuyer, RecipientSupplier} {
+		notices = append(notices, ObligationReminder{
+			ID:             ksuid.New().String(),
+			TenantID:       c.TenantID,
+			ContractID:     c.ID,
+			ObligationKind: ObligationRenewal,
+			DueDate:        c.EndDate,
+			AgingBucket:    fmt.Sprintf("%dd", lead),
+			Recipient:      recipient,
+			Kind:           ReminderRenewal,
+			Subject:        subject,
+			Message:        message,
+			CreatedAt:      now,
+		})
+	}
+	return notices
+}
+
+// RenewalKind is how a contract is renewed
+type RenewalKind string
+
+const (
+	RenewalKindRFQ   RenewalKind = "rfq"   // Re-tendered through a new RFQ
+	RenewalKindDraft RenewalKind = "draft" // Renewal contract drafted with the same supplier
+)
+
+// ContractRenewal records how a contract was renewed. A contract is renewed at most once.
+type ContractRenewal struct {
+	ContractID        string      `json:"contract_id"`
+	TenantID          string      `json:"tenant_id"`
+	Kind              RenewalKind `json:"kind"`
+	RFQID             string      `json:"rfq_id,omitempty"`
+	RenewalContractID string      `json:"renewal_contract_id,omitempty"`
+	CreatedBy         string      `json:"created_by"`
+	CreatedAt         time.Time   `json:"created_at"`
+}
+
+// CanRenew checks the contract can still be renewed: active or suspended, or
+// expired without a replacement yet
+func (c *Contract) CanRenew() error {
+	switch c.Status {
+	case ContractStatusActive, ContractStatusSuspended, ContractStatusExpired:
+		return nil
+	}
+	return fmt.Errorf("%w: contract is %s", ErrCannotRenew, c.Status)
+}
+
+// NewRenewalDraft drafts a renewal contract with the same supplier, items and
+// terms, starting the day after this one ends and running for as long.
+// Payment terms are carried over shifted by the same offset and unpaid;
+// delivery milestones are left for the buyer to plan.
+func (c *Contract) NewRenewalDraft(id, contractNumber, createdBy string) (*Contract, error) {
+	if err := c.CanRenew(); err != nil {
+		return nil, err
+	}
+
+	draft := NewContract(c.TenantID, c.RFQID, c.QuoteID, c.SupplierID, c.SupplierName, createdBy)
+	draft.ID = id
+	draft.ContractNumber = contractNumber
+	draft.Currency = c.Currency
+	draft.TaxAmount = c.TaxAmount
+	draft.PaymentTerms = c.PaymentTerms
+	draft.DeliveryTerms = c.DeliveryTerms
+	draft.WarrantyTerms = c.WarrantyTerms
+	draft.TermsAndConditions = c.TermsAndConditions
+	draft.Notes = fmt.Sprintf("Renewal of contract %s", c.ContractNumber)
+
+	start := time.Date(c.EndDate.Year(), c.EndDate.Month(), c.EndDate.Day()+1, 0, 0, 0, 0, c.EndDate.Location())
+	offset := start.Sub(c.StartDate)
+	draft.StartDate = start
+	draft.EndDate = c.EndDate.Add(offset)
+
+	for _, item := range c.Items {
+		item.ID = ksuid.New().String()
+		if err := draft.AddItem(item); err != nil {
+			return nil, err
+		}
+	}
+	draft.CalculateTotals()
+
+	for _, term := range c.PaymentSchedule {
+		renewed := PaymentTerm{
+			DueDate:         term.DueDate.Add(offset),
+			Amount:          term.Amount,
+			Description:     term.Description,
+			RetentionAmount: term.RetentionAmount,
+		}
+		if term.RetentionDueDate != nil {
+			due := term.RetentionDueDate.Add(offset)
+			renewed.RetentionDueDate = &due
+		}
+		if err := draft.AddPaymentTerm(renewed); err != nil {
+			return nil, err
+		}
+	}
+	return draft, nil
+}
+
+// ExpiringContract summarizes a contract in the expiry report
+type ExpiringContract struct {
+	ContractID     string           `json:"contract_id"`
+	ContractNumber string           `json:"contract_number"`
+	SupplierID     string           `json:"supplier_id"`
+	SupplierName   string           `json:"supplier_name"`
+	EndDate        time.Time        `json:"end_date"`
+	DaysLeft       int              `json:"days_left"`
+	TotalAmount    float64          `json:"total_amount"`
+	Currency       string           `json:"currency"`
+	Renewal        *ContractRenewal `json:"renewal,omitempty"`
+}
+
+// ExpiryBucket groups contracts expiring after the previous bucket's horizon
+// and within this one's
+type ExpiryBucket struct {
+	WithinDays int                `json:"within_days"`
+	Count      int                `json:"count"`
+	Totals     map[string]float64 `json:"totals"` // Contract value per currency
+	Contracts  []ExpiringContract `json:"contracts"`
+}
+
+// BucketExpiringContracts sorts active contracts into expiry buckets, soonest
+// first within each. Contracts outside the last horizon are left out.
+func BucketExpiringContracts(contracts []*Contract, renewals map[string]*ContractRenewal, now time.Time, horizons []int) []ExpiryBucket {
+	horizons = append([]int(nil), horizons...)
+	sort.Ints(horizons)
+	buckets := make([]ExpiryBucket, len(horizons))
+	for i, h := range horizons {
+		buckets[i] = ExpiryBucket{WithinDays: h, Totals: map[string]float64{}, Contracts: []ExpiringContract{}}
+	}
+
+	for _, c := range contracts {
+		if c.Status != ContractStatusActive {
+			continue
+		}
+		days := c.DaysUntilExpiry(now)
+		if days < 0 {
+			continue
+		}
+		for i, h := range horizons {
+			if days > h {
+				continue
+			}
+			b := &buckets[i]
+			b.Contracts = append(b.Contracts, ExpiringContract{
+				ContractID:     c.ID,
+				ContractNumber: c.ContractNumber,
+				SupplierID:     c.SupplierID,
+				SupplierName:   c.SupplierName,
+				EndDate:        c.EndDate,
+				DaysLeft:       days,
+				TotalAmount:    c.TotalAmount,
+				Currency:       c.Currency,
+				Renewal:        renewals[c.ID],
+			})
+			b.Count++
+			b.Totals[c.Currency] = money.NewFromFloat(b.Totals[c.Currency]).Add(money.NewFromFloat(c.TotalAmount)).Float64()
+			break
+		}
+	}
+
+	for i := range buckets {
+		sort.SliceStable(buckets[i].Contracts, func(a, b int) bool {
+			return buckets[i].Contracts[a].EndDate.Before(buckets[i].Contracts[b].EndDate)
+		})
+	}
+	return buckets
+}
+
+// RenewalRFQCreator opens a renewal RFQ for an expiring contract
+type RenewalRFQCreator interface {
+	// CreateRenewalRFQ drafts an RFQ for the contract's items and terms and returns its ID
+	CreateRenewalRFQ(ctx context.Context, contract *Contract, responseDeadline time.Time, createdBy string) (string, error)
+}
+
+// RenewalRepository persists contract renewals
+type RenewalRepository interface {
+	// CreateRenewal records a renewal, failing with ErrRenewalExists when the
+	// contract was renewed already
+	CreateRenewal(ctx context.Context, renewal *ContractRenewal) error
+
+	// CreateRenewalDraft stores a renewal draft contract and its renewal record atomically
+	CreateRenewalDraft(ctx context.Context, renewal *ContractRenewal, draft *Contract) error
+
+	// GetRenewal retrieves a contract's renewal
+	GetRenewal(ctx context.Context, tenantID, contractID string) (*ContractRenewal, error)
+
+	// ListRenewals retrieves the renewals of the given contracts, keyed by contract ID
+	ListRenewals(ctx context.Context, tenantID string, contractIDs []string) (map[string]*ContractRenewal, error)
+}
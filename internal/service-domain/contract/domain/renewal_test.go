@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRenewalNoticesAndExpiry(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	contract := &Contract{ID: "ct1", ContractNumber: "CT-1", Currency: "USD", Status: ContractStatusActive,
+		StartDate: now.AddDate(-1, 0, 0), EndDate: now.AddDate(0, 0, 20)}
+
+	notices := contract.RenewalNotices(now, DefaultRenewalNoticeDays)
+	if len(notices) != 2 || notices[0].AgingBucket != "30d" || notices[0].ObligationKind != ObligationRenewal {
+		t.Fatalf("notices = %+v, want the 30-day notice to buyer and supplier", notices)
+	}
+	if got := contract.RenewalNotices(now.AddDate(0, 0, -80), DefaultRenewalNoticeDays); len(got) != 0 {
+		t.Fatalf("notices 100 days out = %+v, want none", got)
+	}
+
+	if err := contract.Expire(now); !errors.Is(err, ErrInvalidContractStatus) {
+		t.Fatalf("expire before end date = %v, want ErrInvalidContractStatus", err)
+	}
+	if err := contract.Expire(now.AddDate(0, 0, 21)); err != nil || contract.Status != ContractStatusExpired {
+		t.Fatalf("expire after end date = %v, status %s", err, contract.Status)
+	}
+}
+
+func TestNewRenewalDraftShiftsTermsByContractLength(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	contract := &Contract{ID: "ct1", ContractNumber: "CT-1", Currency: "USD", Status: ContractStatusDraft,
+		StartDate: start, EndDate: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)}
+	contract.AddItem(ContractItem{ID: "i1", EquipmentName: "Monitor", Quantity: 2, UnitPrice: 100})
+	contract.CalculateTotals()
+	contract.AddPaymentTerm(PaymentTerm{DueDate: start.AddDate(0, 1, 0), Amount: 200})
+	contract.Status = ContractStatusExpired
+
+	draft, err := contract.NewRenewalDraft("ct2", "CT-2", "buyer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.Status != ContractStatusDraft || !draft.StartDate.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("draft = %+v, want a draft starting 2026-01-01", draft)
+	}
+	if draft.TotalAmount != 200 || draft.Items[0].ID == "i1" {
+		t.Fatalf("draft items = %+v, want copies with new IDs totalling 200", draft.Items)
+	}
+	if due := draft.PaymentSchedule[0].DueDate; !due.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) || draft.PaymentSchedule[0].Paid {
+		t.Fatalf("renewed payment term = %+v, want unpaid and due 2026-02-01", draft.PaymentSchedule[0])
+	}
+
+	contract.Status = ContractStatusCancelled
+	if _, err := contract.NewRenewalDraft("ct3", "CT-3", "buyer"); !errors.Is(err, ErrCannotRenew) {
+		t.Fatalf("renew cancelled contract = %v, want ErrCannotRenew", err)
+	}
+}
@@ -4,6 +4,9 @@ import "context"
 
 // Repository defines the interface for contract persistence
 type Repository interface {
+	// NextContractNumber reserves a contract number no other contract has
+	NextContractNumber(ctx context.Context, tenantID string) (string, error)
+
 	// Create creates a new contract
 	Create(ctx context.Context, contract *Contract) error
 
@@ -0,0 +1,415 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrDocumentNotFound       = errors.New("contract document not found")
+	ErrSigningRequestNotFound = errors.New("signing request not found")
+	ErrSignatoryNotFound      = errors.New("signatory not found")
+	ErrNotSignatory           = errors.New("user is not this signatory")
+	ErrInvalidSigningRequest  = errors.New("invalid signing request")
+	ErrSigningInProgress      = errors.New("contract has a signing request in progress")
+	ErrSigningClosed          = errors.New("signing request is no longer open")
+	ErrSigningExpired         = errors.New("signing request has expired")
+	ErrNotSignatoryTurn       = errors.New("earlier signatories have not signed yet")
+	ErrAlreadySigned          = errors.New("signatory has already signed")
+	ErrDocumentHashMismatch   = errors.New("document hash does not match the document being signed")
+	ErrSigningConflict        = errors.New("signing request was changed concurrently")
+)
+
+// DocumentKind distinguishes rendered contract documents from sealed ones
+type DocumentKind string
+
+const (
+	DocumentKindRendered DocumentKind = "rendered" // Contract document to be signed
+	DocumentKindSealed   DocumentKind = "sealed"   // Fully signed document with the audit page
+)
+
+// ContractDocument is a rendered PDF of a contract. Each rendering is a new
+// version, tied to the latest amendment at the time it was rendered.
+type ContractDocument struct {
+	ID               string       `json:"id"`
+	TenantID         string       `json:"tenant_id"`
+	ContractID       string       `json:"contract_id"`
+	Version          int          `json:"version"`
+	AmendmentID      string       `json:"amendment_id,omitempty"`
+	Kind             DocumentKind `json:"kind"`
+	SigningRequestID string       `json:"signing_request_id,omitempty"` // Sealed documents: the completed request
+	ContentHash      string       `json:"content_hash"`                 // SHA-256 of the PDF, hex encoded
+	SizeBytes        int          `json:"size_bytes"`
+	Content          []byte       `json:"-"`
+	Snapshot         []byte       `json:"-"` // Contract as rendered, JSON encoded
+	CreatedBy        string       `json:"created_by"`
+	CreatedAt        time.Time    `json:"created_at"`
+}
+
+// NewContractDocument wraps rendered PDF content as a document version,
+// keeping a snapshot of the contract it was rendered from
+func NewContractDocument(contract *Contract, version int, kind DocumentKind, content []byte, createdBy string) (*ContractDocument, error) {
+	snapshot, err := json.Marshal(contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot contract: %w", err)
+	}
+	doc := &ContractDocument{
+		ID:          ksuid.New().String(),
+		TenantID:    contract.TenantID,
+		ContractID:  contract.ID,
+		Version:     version,
+		Kind:        kind,
+		ContentHash: HashDocument(content),
+		SizeBytes:   len(content),
+		Content:     content,
+		Snapshot:    snapshot,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now(),
+	}
+	if n := len(contract.Amendments); n > 0 {
+		doc.AmendmentID = contract.Amendments[n-1].ID
+	}
+	return doc, nil
+}
+
+// SnapshotContract returns the contract as it was when the document was rendered
+func (d *ContractDocument) SnapshotContract() (*Contract, error) {
+	var contract Contract
+	if err := json.Unmarshal(d.Snapshot, &contract); err != nil {
+		return nil, fmt.Errorf("failed to read contract snapshot: %w", err)
+	}
+	return &contract, nil
+}
+
+// HashDocument returns the hex encoded SHA-256 of document content
+func HashDocument(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// SigningStatus represents the status of a signing request
+type SigningStatus string
+
+const (
+	SigningStatusPending   SigningStatus = "pending"
+	SigningStatusCompleted SigningStatus = "completed"
+	SigningStatusDeclined  SigningStatus = "declined"
+	SigningStatusExpired   SigningStatus = "expired"
+	SigningStatusCancelled SigningStatus = "cancelled"
+)
+
+// Signing parties
+const (
+	PartyBuyer    = "buyer"
+	PartySupplier = "supplier"
+)
+
+// SignatoryStatus represents where a signatory is in the workflow
+type SignatoryStatus string
+
+const (
+	SignatoryStatusPending  SignatoryStatus = "pending"
+	SignatoryStatusSigned   SignatoryStatus = "signed"
+	SignatoryStatusDeclined SignatoryStatus = "declined"
+)
+
+// SignatureEvidence records who signed what, from where and when
+type SignatureEvidence struct {
+	SignerID     string    `json:"signer_id"` // Authenticated user who signed
+	SignerName   string    `json:"signer_name"`
+	IPAddress    string    `json:"ip_address"`
+	UserAgent    string    `json:"user_agent,omitempty"`
+	DocumentHash string    `json:"document_hash"` // Hash of the document the signer was shown
+	SignedAt     time.Time `json:"signed_at"`
+}
+
+// Signatory is a person who signs for the buyer or the supplier. Signatories
+// sign in ascending order; those sharing an order may sign in any order.
+// Only the user the signatory is bound to can sign or decline.
+type Signatory struct {
+	ID            string             `json:"id"`
+	UserID        string             `json:"user_id"` // Platform user who signs as this signatory
+	Party         string             `json:"party"`
+	Name          string             `json:"name"`
+	Email         string             `json:"email"`
+	Title         string             `json:"title,omitempty"`
+	Order         int                `json:"order"`
+	Status        SignatoryStatus    `json:"status"`
+	Evidence      *SignatureEvidence `json:"evidence,omitempty"`
+	DeclineReason string             `json:"decline_reason,omitempty"`
+	DeclinedAt    *time.Time         `json:"declined_at,omitempty"`
+}
+
+// SigningRequest asks the buyer's and supplier's signatories to sign one
+// document version before it expires
+type SigningRequest struct {
+	ID               string        `json:"id"`
+	TenantID         string        `json:"tenant_id"`
+	ContractID       string        `json:"contract_id"`
+	DocumentID       string        `json:"document_id"`
+	DocumentVersion  int           `json:"document_version"`
+	DocumentHash     string        `json:"document_hash"`
+	Status           SigningStatus `json:"status"`
+	Signatories      []Signatory   `json:"signatories"`
+	ExpiresAt        time.Time     `json:"expires_at"`
+	SealedDocumentID string        `json:"sealed_document_id,omitempty"`
+	CreatedBy        string        `json:"created_by"`
+	CreatedAt        time.Time     `json:"created_at"`
+	CompletedAt      *time.Time    `json:"completed_at,omitempty"`
+	Revision         int           `json:"-"` // Optimistic concurrency token
+}
+
+// NewSigningRequest creates a signing request for a rendered document of an
+// active, unsigned contract. Both parties must have a signatory.
+func NewSigningRequest(contract *Contract, doc *ContractDocument, signatories []Signatory, expiresAt time.Time, createdBy string) (*SigningRequest, error) {
+	if contract.Status != ContractStatusActive {
+		return nil, fmt.Errorf("%w: only active contracts can be signed", ErrInvalidSigningRequest)
+	}
+	if contract.SignedDate != nil {
+		return nil, ErrContractAlreadySigned
+	}
+	if doc.ContractID != contract.ID || doc.Kind != DocumentKindRendered {
+		return nil, fmt.Errorf("%w: document is not a rendering of this contract", ErrInvalidSigningRequest)
+	}
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expiry must be in the future", ErrInvalidSigningRequest)
+	}
+
+	parties := map[string]bool{}
+	for i := range signatories {
+		s := &signatories[i]
+		s.Party = strings.ToLower(strings.TrimSpace(s.Party))
+		if s.Party != PartyBuyer && s.Party != PartySupplier {
+			return nil, fmt.Errorf("%w: signatory party must be buyer or supplier", ErrInvalidSigningRequest)
+		}
+		if strings.TrimSpace(s.Name) == "" || strings.TrimSpace(s.Email) == "" {
+			return nil, fmt.Errorf("%w: signatories need a name and email", ErrInvalidSigningRequest)
+		}
+		s.UserID = strings.TrimSpace(s.UserID)
+		if s.UserID == "" {
+			return nil, fmt.Errorf("%w: signatories need the user ID that will sign", ErrInvalidSigningRequest)
+		}
+		if s.Order < 0 {
+			return nil, fmt.Errorf("%w: signing order cannot be negative", ErrInvalidSigningRequest)
+		}
+		s.ID = ksuid.New().String()
+		s.Status = SignatoryStatusPending
+		s.Evidence, s.DeclinedAt, s.DeclineReason = nil, nil, ""
+		parties[s.Party] = true
+	}
+	if !parties[PartyBuyer] || !parties[PartySupplier] {
+		return nil, fmt.Errorf("%w: both buyer and supplier signatories are required", ErrInvalidSigningRequest)
+	}
+	sort.SliceStable(signatories, func(i, j int) bool { return signatories[i].Order < signatories[j].Order })
+
+	return &SigningRequest{
+		ID:              ksuid.New().String(),
+		TenantID:        contract.TenantID,
+		ContractID:      contract.ID,
+		DocumentID:      doc.ID,
+		DocumentVersion: doc.Version,
+		DocumentHash:    doc.ContentHash,
+		Status:          SigningStatusPending,
+		Signatories:     signatories,
+		ExpiresAt:       expiresAt,
+		CreatedBy:       createdBy,
+		CreatedAt:       now,
+	}, nil
+}
+
+// IsOpen reports whether the request still awaits signatures
+func (r *SigningRequest) IsOpen() bool {
+	return r.Status == SigningStatusPending
+}
+
+// Expire marks an open request past its expiry as expired and reports whether it did
+func (r *SigningRequest) Expire(now time.Time) bool {
+	if !r.IsOpen() || now.Before(r.ExpiresAt) {
+		return false
+	}
+	r.Status = SigningStatusExpired
+	return true
+}
+
+// NextSignatories returns the pending signatories whose turn it is
+func (r *SigningRequest) NextSignatories() []Signatory {
+	next := []Signatory{}
+	if !r.IsOpen() {
+		return next
+	}
+	order := -1
+	for _, s := range r.Signatories {
+		if s.Status != SignatoryStatusPending {
+			continue
+		}
+		if order == -1 {
+			order = s.Order
+		}
+		if s.Order == order {
+			next = append(next, s)
+		}
+	}
+	return next
+}
+
+// Sign records a signatory's signature with its evidence. The evidence must
+// come from the signatory's user and carry the hash of the document being
+// signed. The request completes once every signatory has signed.
+func (r *SigningRequest) Sign(signatoryID string, evidence SignatureEvidence, now time.Time) error {
+	if r.Expire(now) {
+		return ErrSigningExpired
+	}
+	if !r.IsOpen() {
+		return ErrSigningClosed
+	}
+	s, err := r.signatory(signatoryID)
+	if err != nil {
+		return err
+	}
+	if s.Status == SignatoryStatusSigned {
+		return ErrAlreadySigned
+	}
+	if evidence.SignerID != s.UserID {
+		return ErrNotSignatory
+	}
+	if !r.isTurnOf(s) {
+		return ErrNotSignatoryTurn
+	}
+	if !strings.EqualFold(evidence.DocumentHash, r.DocumentHash) {
+		return ErrDocumentHashMismatch
+	}
+
+	evidence.DocumentHash = r.DocumentHash
+	evidence.SignedAt = now
+	if evidence.SignerName == "" {
+		evidence.SignerName = s.Name
+	}
+	s.Status = SignatoryStatusSigned
+	s.Evidence = &evidence
+
+	for _, other := range r.Signatories {
+		if other.Status != SignatoryStatusSigned {
+			return nil
+		}
+	}
+	r.Status = SigningStatusCompleted
+	r.CompletedAt = &now
+	return nil
+}
+
+// Decline records a signatory declining to sign, which closes the request.
+// Only the signatory's user can decline.
+func (r *SigningRequest) Decline(signatoryID, declinedBy, reason string, now time.Time) error {
+	if r.Expire(now) {
+		return ErrSigningExpired
+	}
+	if !r.IsOpen() {
+		return ErrSigningClosed
+	}
+	s, err := r.signatory(signatoryID)
+	if err != nil {
+		return err
+	}
+	if s.Status == SignatoryStatusSigned {
+		return ErrAlreadySigned
+	}
+	if declinedBy != s.UserID {
+		return ErrNotSignatory
+	}
+	s.Status = SignatoryStatusDeclined
+	s.DeclineReason = reason
+	s.DeclinedAt = &now
+	r.Status = SigningStatusDeclined
+	return nil
+}
+
+// Cancel withdraws an open request
+func (r *SigningRequest) Cancel() error {
+	if !r.IsOpen() {
+		return ErrSigningClosed
+	}
+	r.Status = SigningStatusCancelled
+	return nil
+}
+
+// SignerNames lists the names of the signatories who signed, in signing order
+func (r *SigningRequest) SignerNames() string {
+	names := []string{}
+	for _, s := range r.Signatories {
+		if s.Status == SignatoryStatusSigned {
+			names = append(names, s.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func (r *SigningRequest) signatory(id string) (*Signatory, error) {
+	for i := range r.Signatories {
+		if r.Signatories[i].ID == id {
+			return &r.Signatories[i], nil
+		}
+	}
+	return nil, ErrSignatoryNotFound
+}
+
+// isTurnOf reports whether every signatory ordered before s has signed
+func (r *SigningRequest) isTurnOf(s *Signatory) bool {
+	for _, other := range r.Signatories {
+		if other.Order < s.Order && other.Status != SignatoryStatusSigned {
+			return false
+		}
+	}
+	return true
+}
+
+// DocumentRenderer renders contracts to PDF
+type DocumentRenderer interface {
+	// Render renders the contract as the given document version
+	Render(contract *Contract, version int) ([]byte, error)
+
+	// Seal renders the signed document version from its contract snapshot,
+	// followed by an audit page with the signature evidence
+	Seal(snapshot *Contract, doc *ContractDocument, request *SigningRequest) ([]byte, error)
+}
+
+// DocumentRepository stores contract documents and signing requests
+type DocumentRepository interface {
+	// CreateDocument stores a document; rendered versions are numbered per contract
+	CreateDocument(ctx context.Context, doc *ContractDocument) error
+
+	// GetDocument retrieves a document with its content
+	GetDocument(ctx context.Context, tenantID, contractID, id string) (*ContractDocument, error)
+
+	// ListDocuments retrieves a contract's documents without content, newest first
+	ListDocuments(ctx context.Context, tenantID, contractID string) ([]*ContractDocument, error)
+
+	// LatestVersion returns the highest rendered version of a contract, 0 if none
+	LatestVersion(ctx context.Context, tenantID, contractID string) (int, error)
+
+	// CreateSigningRequest stores a new signing request
+	CreateSigningRequest(ctx context.Context, request *SigningRequest) error
+
+	// GetSigningRequest retrieves a signing request of a contract
+	GetSigningRequest(ctx context.Context, tenantID, contractID, id string) (*SigningRequest, error)
+
+	// ListSigningRequests retrieves a contract's signing requests, newest first
+	ListSigningRequests(ctx context.Context, tenantID, contractID string) ([]*SigningRequest, error)
+
+	// UpdateSigningRequest saves a signing request, failing with
+	// ErrSigningConflict when it changed since it was read
+	UpdateSigningRequest(ctx context.Context, request *SigningRequest) error
+
+	// CompleteSigning atomically saves the completed request, stores the
+	// sealed document and records the signature on the contract
+	CompleteSigning(ctx context.Context, request *SigningRequest, sealed *ContractDocument, contract *Contract) error
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSigningRequestEnforcesOrderAndDocumentHash(t *testing.T) {
+	now := time.Now()
+	contract := &Contract{ID: "ct1", TenantID: "t1", Status: ContractStatusActive}
+	doc, err := NewContractDocument(contract, 1, DocumentKindRendered, []byte("%PDF-1.3 contract"), "buyer-admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := NewSigningRequest(contract, doc, []Signatory{
+		{UserID: "u2", Party: "supplier", Name: "Sam Supplier", Email: "sam@supplier.test", Order: 2},
+		{UserID: "u1", Party: "buyer", Name: "Bea Buyer", Email: "bea@hospital.test", Order: 1},
+	}, now.Add(24*time.Hour), "buyer-admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buyer, supplier := request.Signatories[0], request.Signatories[1]
+	if buyer.Party != PartyBuyer {
+		t.Fatalf("signatories not sorted by order: %+v", request.Signatories)
+	}
+
+	evidence := SignatureEvidence{SignerID: "u2", IPAddress: "10.0.0.2", DocumentHash: doc.ContentHash}
+	if err := request.Sign(supplier.ID, evidence, now); !errors.Is(err, ErrNotSignatoryTurn) {
+		t.Fatalf("Sign() out of turn = %v, want ErrNotSignatoryTurn", err)
+	}
+
+	tampered := SignatureEvidence{SignerID: "u1", IPAddress: "10.0.0.1", DocumentHash: HashDocument([]byte("other"))}
+	if err := request.Sign(buyer.ID, tampered, now); !errors.Is(err, ErrDocumentHashMismatch) {
+		t.Fatalf("Sign() with wrong hash = %v, want ErrDocumentHashMismatch", err)
+	}
+
+	evidence.SignerID, evidence.IPAddress = "u1", "10.0.0.1"
+	if err := request.Sign(buyer.ID, evidence, now); err != nil {
+		t.Fatal(err)
+	}
+	evidence.SignerID, evidence.IPAddress = "u2", "10.0.0.2"
+	if err := request.Sign(supplier.ID, evidence, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	if request.Status != SigningStatusCompleted || request.CompletedAt == nil {
+		t.Fatalf("status = %s, want completed", request.Status)
+	}
+	if got := request.SignerNames(); got != "Bea Buyer, Sam Supplier" {
+		t.Fatalf("SignerNames() = %q", got)
+	}
+	if e := request.Signatories[1].Evidence; e == nil || e.IPAddress != "10.0.0.2" || e.DocumentHash != doc.ContentHash {
+		t.Fatalf("supplier evidence = %+v", e)
+	}
+}
+
+func TestSigningRequestExpires(t *testing.T) {
+	now := time.Now()
+	contract := &Contract{ID: "ct1", TenantID: "t1", Status: ContractStatusActive}
+	doc, _ := NewContractDocument(contract, 1, DocumentKindRendered, []byte("%PDF"), "buyer-admin")
+
+	if _, err := NewSigningRequest(contract, doc, []Signatory{
+		{UserID: "u1", Party: "buyer", Name: "Bea Buyer", Email: "bea@hospital.test"},
+	}, now.Add(time.Hour), "buyer-admin"); !errors.Is(err, ErrInvalidSigningRequest) {
+		t.Fatalf("NewSigningRequest() without supplier = %v, want ErrInvalidSigningRequest", err)
+	}
+
+	request, err := NewSigningRequest(contract, doc, []Signatory{
+		{UserID: "u1", Party: "buyer", Name: "Bea Buyer", Email: "bea@hospital.test"},
+		{UserID: "u2", Party: "supplier", Name: "Sam Supplier", Email: "sam@supplier.test"},
+	}, now.Add(time.Hour), "buyer-admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evidence := SignatureEvidence{SignerID: "u1", DocumentHash: doc.ContentHash}
+	if err := request.Sign(request.Signatories[0].ID, evidence, now.Add(2*time.Hour)); !errors.Is(err, ErrSigningExpired) {
+		t.Fatalf("Sign() after expiry = %v, want ErrSigningExpired", err)
+	}
+	if request.Status != SigningStatusExpired {
+		t.Fatalf("status = %s, want expired", request.Status)
+	}
+}
+
+func TestSigningRequestBindsSignatoryToUser(t *testing.T) {
+	now := time.Now()
+	contract := &Contract{ID: "ct1", TenantID: "t1", Status: ContractStatusActive}
+	doc, _ := NewContractDocument(contract, 1, DocumentKindRendered, []byte("%PDF"), "buyer-admin")
+
+	if _, err := NewSigningRequest(contract, doc, []Signatory{
+		{Party: "buyer", Name: "Bea Buyer", Email: "bea@hospital.test"},
+		{UserID: "u2", Party: "supplier", Name: "Sam Supplier", Email: "sam@supplier.test"},
+	}, now.Add(time.Hour), "buyer-admin"); !errors.Is(err, ErrInvalidSigningRequest) {
+		t.Fatalf("NewSigningRequest() without user ID = %v, want ErrInvalidSigningRequest", err)
+	}
+
+	request, err := NewSigningRequest(contract, doc, []Signatory{
+		{UserID: "u1", Party: "buyer", Name: "Bea Buyer", Email: "bea@hospital.test"},
+		{UserID: "u2", Party: "supplier", Name: "Sam Supplier", Email: "sam@supplier.test"},
+	}, now.Add(time.Hour), "buyer-admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buyer := request.Signatories[0]
+
+	impostor := SignatureEvidence{SignerID: "u2", DocumentHash: doc.ContentHash}
+	if err := request.Sign(buyer.ID, impostor, now); !errors.Is(err, ErrNotSignatory) {
+		t.Fatalf("Sign() by another user = %v, want ErrNotSignatory", err)
+	}
+	if err := request.Decline(buyer.ID, "u2", "not mine", now); !errors.Is(err, ErrNotSignatory) {
+		t.Fatalf("Decline() by another user = %v, want ErrNotSignatory", err)
+	}
+	if request.Status != SigningStatusPending || request.Signatories[0].Status != SignatoryStatusPending {
+		t.Fatalf("request changed by a rejected signer: %s", request.Status)
+	}
+
+	if err := request.Decline(buyer.ID, "u1", "terms changed", now); err != nil {
+		t.Fatal(err)
+	}
+	if request.Status != SigningStatusDeclined {
+		t.Fatalf("status = %s, want declined", request.Status)
+	}
+}
@@ -0,0 +1,273 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateAmendmentRequest stores a new amendment request; a contract can have
+// only one pending at a time
+func (r *ContractRepository) CreateAmendmentRequest(ctx context.Context, req *domain.AmendmentRequest) error {
+	changeSetJSON, diffJSON, approvalsJSON, err := marshalAmendmentRequest(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Pool().Exec(ctx, `
+		INSERT INTO contract_amendment_requests (
+			id, tenant_id, contract_id, base_version, description, change_set, diff,
+			previous_total, new_total, required_roles, approvals, status,
+			requested_by, requested_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`, req.ID, req.TenantID, req.ContractID, req.BaseVersion, req.Description, changeSetJSON, diffJSON,
+		req.PreviousTotal, req.NewTotal, req.RequiredRoles, approvalsJSON, req.Status,
+		req.RequestedBy, req.RequestedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrAmendmentPending
+		}
+		return fmt.Errorf("failed to create amendment request: %w", err)
+	}
+
+	r.logger.Info("Contract amendment proposed",
+		slog.String("contract_id", req.ContractID),
+		slog.String("amendment_id", req.ID))
+	return nil
+}
+
+// GetAmendmentRequest retrieves an amendment request of a contract
+func (r *ContractRepository) GetAmendmentRequest(ctx context.Context, tenantID, contractID, id string) (*domain.AmendmentRequest, error) {
+	rows, err := r.db.Pool().Query(ctx, amendmentRequestSelect+`
+		WHERE id = $1 AND contract_id = $2 AND tenant_id = $3
+	`, id, contractID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get amendment request: %w", err)
+	}
+	requests, err := scanAmendmentRequests(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, domain.ErrAmendmentNotFound
+	}
+	return requests[0], nil
+}
+
+// ListAmendmentRequests retrieves a contract's amendment requests, newest first
+func (r *ContractRepository) ListAmendmentRequests(ctx context.Context, tenantID, contractID string) ([]*domain.AmendmentRequest, error) {
+	rows, err := r.db.Pool().Query(ctx, amendmentRequestSelect+`
+		WHERE contract_id = $1 AND tenant_id = $2
+		ORDER BY requested_at DESC
+	`, contractID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list amendment requests: %w", err)
+	}
+	return scanAmendmentRequests(rows)
+}
+
+// UpdateAmendmentRequest saves a pending request's approvals or decision
+func (r *ContractRepository) UpdateAmendmentRequest(ctx context.Context, req *domain.AmendmentRequest) error {
+	return r.updateAmendmentRequest(ctx, r.db.Pool(), req)
+}
+
+// SaveAmendedContract stores the amended contract, its versions and the
+// amendment request in one transaction. The contract row is locked and must
+// still be at the version the amendment was applied to.
+func (r *ContractRepository) SaveAmendedContract(ctx context.Context, contract *domain.Contract, before, after *domain.ContractVersion, req *domain.AmendmentRequest) error {
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var amendments int
+	err = tx.QueryRow(ctx, `
+		SELECT CASE WHEN jsonb_typeof(amendments) = 'array' THEN jsonb_array_length(amendments) ELSE 0 END
+		FROM contracts
+		WHERE id = $1 AND tenant_id = $2
+		FOR UPDATE
+	`, contract.ID, contract.TenantID).Scan(&amendments)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrContractNotFound
+		}
+		return fmt.Errorf("failed to lock contract: %w", err)
+	}
+	if amendments+1 != before.Version {
+		return domain.ErrAmendmentStale
+	}
+
+	if err := r.update(ctx, tx, contract); err != nil {
+		return err
+	}
+	// The version before is stored already unless the contract predates
+	// version history
+	if err := insertVersion(ctx, tx, before); err != nil {
+		return err
+	}
+	if err := insertVersion(ctx, tx, after); err != nil {
+		return err
+	}
+	if req != nil {
+		if err := r.updateAmendmentRequest(ctx, tx, req); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit amendment: %w", err)
+	}
+
+	r.logger.Info("Contract amended",
+		slog.String("contract_id", contract.ID),
+		slog.Int("version", after.Version))
+	return nil
+}
+
+// ListVersions retrieves a contract's stored versions without snapshots, oldest first
+func (r *ContractRepository) ListVersions(ctx context.Context, tenantID, contractID string) ([]*domain.ContractVersion, error) {
+	rows, err := r.db.Pool().Query(ctx, `
+		SELECT contract_id, tenant_id, version, amendment_id, total_amount, created_by, created_at
+		FROM contract_versions
+		WHERE contract_id = $1 AND tenant_id = $2
+		ORDER BY version
+	`, contractID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contract versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*domain.ContractVersion{}
+	for rows.Next() {
+		var v domain.ContractVersion
+		var amendmentID *string
+		if err := rows.Scan(&v.ContractID, &v.TenantID, &v.Version, &amendmentID, &v.TotalAmount, &v.CreatedBy, &v.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan contract version: %w", err)
+		}
+		v.AmendmentID = deref(amendmentID)
+		versions = append(versions, &v)
+	}
+	return versions, rows.Err()
+}
+
+// GetVersion retrieves one version of a contract with its snapshot
+func (r *ContractRepository) GetVersion(ctx context.Context, tenantID, contractID string, version int) (*domain.ContractVersion, error) {
+	var v domain.ContractVersion
+	var amendmentID *string
+	var snapshot []byte
+	err := r.db.Pool().QueryRow(ctx, `
+		SELECT contract_id, tenant_id, version, amendment_id, total_amount, created_by, created_at, snapshot
+		FROM contract_versions
+		WHERE contract_id = $1 AND tenant_id = $2 AND version = $3
+	`, contractID, tenantID, version).Scan(
+		&v.ContractID, &v.TenantID, &v.Version, &amendmentID, &v.TotalAmount, &v.CreatedBy, &v.CreatedAt, &snapshot)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get contract version: %w", err)
+	}
+	v.AmendmentID = deref(amendmentID)
+	if err := json.Unmarshal(snapshot, &v.Contract); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal contract snapshot: %w", err)
+	}
+	return &v, nil
+}
+
+func insertVersion(ctx context.Context, db execer, v *domain.ContractVersion) error {
+	snapshot, err := json.Marshal(v.Contract)
+	if err != nil {
+		return fmt.Errorf("failed to marshal contract snapshot: %w", err)
+	}
+	var amendmentID *string
+	if v.AmendmentID != "" {
+		amendmentID = &v.AmendmentID
+	}
+
+	_, err = db.Exec(ctx, `
+		INSERT INTO contract_versions (
+			contract_id, tenant_id, version, amendment_id, total_amount, snapshot, created_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (contract_id, version) DO NOTHING
+	`, v.ContractID, v.TenantID, v.Version, amendmentID, v.TotalAmount, snapshot, v.CreatedBy, v.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store contract version %d: %w", v.Version, err)
+	}
+	return nil
+}
+
+func (r *ContractRepository) updateAmendmentRequest(ctx context.Context, db execer, req *domain.AmendmentRequest) error {
+	_, diffJSON, approvalsJSON, err := marshalAmendmentRequest(req)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Exec(ctx, `
+		UPDATE contract_amendment_requests SET
+			diff = $1, new_total = $2, approvals = $3, status = $4,
+			decided_by = $5, decided_at = $6, decision_notes = $7
+		WHERE id = $8 AND tenant_id = $9 AND status = 'pending'
+	`, diffJSON, req.NewTotal, approvalsJSON, req.Status,
+		req.DecidedBy, req.DecidedAt, req.DecisionNotes,
+		req.ID, req.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to update amendment request: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrAmendmentDecided
+	}
+	return nil
+}
+
+func marshalAmendmentRequest(req *domain.AmendmentRequest) (changeSet, diff, approvals []byte, err error) {
+	if changeSet, err = json.Marshal(req.ChangeSet); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal change set: %w", err)
+	}
+	if diff, err = json.Marshal(req.Diff); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal diff: %w", err)
+	}
+	if approvals, err = json.Marshal(req.Approvals); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal approvals: %w", err)
+	}
+	return changeSet, diff, approvals, nil
+}
+
+const amendmentRequestSelect = `
+		SELECT id, tenant_id, contract_id, base_version, description, change_set, diff,
+			previous_total, new_total, required_roles, approvals, status,
+			requested_by, requested_at, decided_by, decided_at, COALESCE(decision_notes, '')
+		FROM contract_amendment_requests`
+
+func scanAmendmentRequests(rows pgx.Rows) ([]*domain.AmendmentRequest, error) {
+	defer rows.Close()
+
+	requests := []*domain.AmendmentRequest{}
+	for rows.Next() {
+		var req domain.AmendmentRequest
+		var changeSetJSON, diffJSON, approvalsJSON []byte
+		if err := rows.Scan(
+			&req.ID, &req.TenantID, &req.ContractID, &req.BaseVersion, &req.Description, &changeSetJSON, &diffJSON,
+			&req.PreviousTotal, &req.NewTotal, &req.RequiredRoles, &approvalsJSON, &req.Status,
+			&req.RequestedBy, &req.RequestedAt, &req.DecidedBy, &req.DecidedAt, &req.DecisionNotes,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan amendment request: %w", err)
+		}
+		if err := json.Unmarshal(changeSetJSON, &req.ChangeSet); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal change set: %w", err)
+		}
+		if err := json.Unmarshal(diffJSON, &req.Diff); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal diff: %w", err)
+		}
+		if err := json.Unmarshal(approvalsJSON, &req.Approvals); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal approvals: %w", err)
+		}
+		requests = append(requests, &req)
+	}
+	return requests, rows.Err()
+}
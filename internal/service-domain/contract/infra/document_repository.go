@@ -0,0 +1,302 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DocumentRepository implements domain.DocumentRepository
+type DocumentRepository struct {
+	db     *PostgresDB
+	logger *slog.Logger
+}
+
+// NewDocumentRepository creates a new contract document repository
+func NewDocumentRepository(db *PostgresDB, logger *slog.Logger) *DocumentRepository {
+	return &DocumentRepository{
+		db:     db,
+		logger: logger.With(slog.String("component", "contract_document_repository")),
+	}
+}
+
+// execer is satisfied by both the pool and a transaction
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// CreateDocument stores a document
+func (r *DocumentRepository) CreateDocument(ctx context.Context, doc *domain.ContractDocument) error {
+	if err := r.insertDocument(ctx, r.db.Pool(), doc); err != nil {
+		return err
+	}
+	r.logger.Info("Contract document stored",
+		slog.String("contract_id", doc.ContractID),
+		slog.Int("version", doc.Version),
+		slog.String("kind", string(doc.Kind)))
+	return nil
+}
+
+// GetDocument retrieves a document with its content
+func (r *DocumentRepository) GetDocument(ctx context.Context, tenantID, contractID, id string) (*domain.ContractDocument, error) {
+	var doc domain.ContractDocument
+	var amendmentID, signingRequestID *string
+	err := r.db.Pool().QueryRow(ctx, `
+		SELECT id, tenant_id, contract_id, version, amendment_id, kind,
+			signing_request_id, content_hash, size_bytes, content, snapshot, created_by, created_at
+		FROM contract_documents
+		WHERE id = $1 AND contract_id = $2 AND tenant_id = $3
+	`, id, contractID, tenantID).Scan(
+		&doc.ID, &doc.TenantID, &doc.ContractID, &doc.Version, &amendmentID, &doc.Kind,
+		&signingRequestID, &doc.ContentHash, &doc.SizeBytes, &doc.Content, &doc.Snapshot, &doc.CreatedBy, &doc.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("failed to get contract document: %w", err)
+	}
+	doc.AmendmentID = deref(amendmentID)
+	doc.SigningRequestID = deref(signingRequestID)
+	return &doc, nil
+}
+
+// ListDocuments retrieves a contract's documents without content, newest first
+func (r *DocumentRepository) ListDocuments(ctx context.Context, tenantID, contractID string) ([]*domain.ContractDocument, error) {
+	rows, err := r.db.Pool().Query(ctx, `
+		SELECT id, tenant_id, contract_id, version, amendment_id, kind,
+			signing_request_id, content_hash, size_bytes, created_by, created_at
+		FROM contract_documents
+		WHERE contract_id = $1 AND tenant_id = $2
+		ORDER BY version DESC, created_at DESC
+	`, contractID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contract documents: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []*domain.ContractDocument{}
+	for rows.Next() {
+		var doc domain.ContractDocument
+		var amendmentID, signingRequestID *string
+		if err := rows.Scan(
+			&doc.ID, &doc.TenantID, &doc.ContractID, &doc.Version, &amendmentID, &doc.Kind,
+			&signingRequestID, &doc.ContentHash, &doc.SizeBytes, &doc.CreatedBy, &doc.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan contract document: %w", err)
+		}
+		doc.AmendmentID = deref(amendmentID)
+		doc.SigningRequestID = deref(signingRequestID)
+		docs = append(docs, &doc)
+	}
+	return docs, rows.Err()
+}
+
+// LatestVersion returns the highest rendered version of a contract, 0 if none
+func (r *DocumentRepository) LatestVersion(ctx context.Context, tenantID, contractID string) (int, error) {
+	var version int
+	err := r.db.Pool().QueryRow(ctx, `
+		SELECT COALESCE(MAX(version), 0)
+		FROM contract_documents
+		WHERE contract_id = $1 AND tenant_id = $2 AND kind = $3
+	`, contractID, tenantID, domain.DocumentKindRendered).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest document version: %w", err)
+	}
+	return version, nil
+}
+
+// CreateSigningRequest stores a new signing request. The partial unique
+// index on open requests keeps a contract to one request in progress.
+func (r *DocumentRepository) CreateSigningRequest(ctx context.Context, request *domain.SigningRequest) error {
+	signatoriesJSON, err := json.Marshal(request.Signatories)
+	if err != nil {
+		return fmt.Errorf("failed to marshal signatories: %w", err)
+	}
+
+	_, err = r.db.Pool().Exec(ctx, `
+		INSERT INTO contract_signing_requests (
+			id, tenant_id, contract_id, document_id, document_version, document_hash,
+			status, signatories, expires_at, sealed_document_id,
+			created_by, created_at, completed_at, revision
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULL, $10, $11, NULL, 0)
+	`,
+		request.ID, request.TenantID, request.ContractID, request.DocumentID, request.DocumentVersion, request.DocumentHash,
+		request.Status, signatoriesJSON, request.ExpiresAt,
+		request.CreatedBy, request.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrSigningInProgress
+		}
+		return fmt.Errorf("failed to create signing request: %w", err)
+	}
+	request.Revision = 0
+	return nil
+}
+
+// GetSigningRequest retrieves a signing request of a contract
+func (r *DocumentRepository) GetSigningRequest(ctx context.Context, tenantID, contractID, id string) (*domain.SigningRequest, error) {
+	rows, err := r.db.Pool().Query(ctx, signingRequestSelect+`
+		WHERE id = $1 AND contract_id = $2 AND tenant_id = $3
+	`, id, contractID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing request: %w", err)
+	}
+	requests, err := r.scanSigningRequests(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, domain.ErrSigningRequestNotFound
+	}
+	return requests[0], nil
+}
+
+// ListSigningRequests retrieves a contract's signing requests, newest first
+func (r *DocumentRepository) ListSigningRequests(ctx context.Context, tenantID, contractID string) ([]*domain.SigningRequest, error) {
+	rows, err := r.db.Pool().Query(ctx, signingRequestSelect+`
+		WHERE contract_id = $1 AND tenant_id = $2
+		ORDER BY created_at DESC
+	`, contractID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing requests: %w", err)
+	}
+	return r.scanSigningRequests(rows)
+}
+
+// UpdateSigningRequest saves a signing request if it is unchanged since it was read
+func (r *DocumentRepository) UpdateSigningRequest(ctx context.Context, request *domain.SigningRequest) error {
+	return r.updateSigningRequest(ctx, r.db.Pool(), request)
+}
+
+// CompleteSigning saves the completed request, stores the sealed document
+// and records the signature on the contract in one transaction
+func (r *DocumentRepository) CompleteSigning(ctx context.Context, request *domain.SigningRequest, sealed *domain.ContractDocument, contract *domain.Contract) error {
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.insertDocument(ctx, tx, sealed); err != nil {
+		return err
+	}
+	if err := r.updateSigningRequest(ctx, tx, request); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE contracts SET signed_date = $1, signed_by = $2, updated_at = $3
+		WHERE id = $4 AND tenant_id = $5 AND signed_date IS NULL
+	`, contract.SignedDate, contract.SignedBy, contract.UpdatedAt, contract.ID, contract.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to record contract signature: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrContractAlreadySigned
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit signing: %w", err)
+	}
+
+	r.logger.Info("Contract signing completed",
+		slog.String("contract_id", contract.ID),
+		slog.String("signing_request_id", request.ID),
+		slog.String("sealed_document_id", sealed.ID))
+	return nil
+}
+
+const signingRequestSelect = `
+		SELECT id, tenant_id, contract_id, document_id, document_version, document_hash,
+			status, signatories, expires_at, sealed_document_id,
+			created_by, created_at, completed_at, revision
+		FROM contract_signing_requests`
+
+func (r *DocumentRepository) scanSigningRequests(rows pgx.Rows) ([]*domain.SigningRequest, error) {
+	defer rows.Close()
+
+	requests := []*domain.SigningRequest{}
+	for rows.Next() {
+		var req domain.SigningRequest
+		var signatoriesJSON []byte
+		var sealedDocumentID *string
+		if err := rows.Scan(
+			&req.ID, &req.TenantID, &req.ContractID, &req.DocumentID, &req.DocumentVersion, &req.DocumentHash,
+			&req.Status, &signatoriesJSON, &req.ExpiresAt, &sealedDocumentID,
+			&req.CreatedBy, &req.CreatedAt, &req.CompletedAt, &req.Revision,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan signing request: %w", err)
+		}
+		if err := json.Unmarshal(signatoriesJSON, &req.Signatories); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal signatories: %w", err)
+		}
+		req.SealedDocumentID = deref(sealedDocumentID)
+		requests = append(requests, &req)
+	}
+	return requests, rows.Err()
+}
+
+func (r *DocumentRepository) insertDocument(ctx context.Context, db execer, doc *domain.ContractDocument) error {
+	_, err := db.Exec(ctx, `
+		INSERT INTO contract_documents (
+			id, tenant_id, contract_id, version, amendment_id, kind,
+			signing_request_id, content_hash, size_bytes, content, snapshot, created_by, created_at
+		) VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, NULLIF($7, ''), $8, $9, $10, $11, $12, $13)
+	`,
+		doc.ID, doc.TenantID, doc.ContractID, doc.Version, doc.AmendmentID, doc.Kind,
+		doc.SigningRequestID, doc.ContentHash, doc.SizeBytes, doc.Content, doc.Snapshot, doc.CreatedBy, doc.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: document version %d already exists", domain.ErrSigningConflict, doc.Version)
+		}
+		return fmt.Errorf("failed to store contract document: %w", err)
+	}
+	return nil
+}
+
+func (r *DocumentRepository) updateSigningRequest(ctx context.Context, db execer, request *domain.SigningRequest) error {
+	signatoriesJSON, err := json.Marshal(request.Signatories)
+	if err != nil {
+		return fmt.Errorf("failed to marshal signatories: %w", err)
+	}
+
+	result, err := db.Exec(ctx, `
+		UPDATE contract_signing_requests SET
+			status = $1, signatories = $2, sealed_document_id = NULLIF($3, ''),
+			completed_at = $4, revision = revision + 1
+		WHERE id = $5 AND tenant_id = $6 AND revision = $7
+	`,
+		request.Status, signatoriesJSON, request.SealedDocumentID,
+		request.CompletedAt, request.ID, request.TenantID, request.Revision,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update signing request: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrSigningConflict
+	}
+	request.Revision++
+	return nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
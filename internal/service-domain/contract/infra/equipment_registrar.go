@@ -0,0 +1,74 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	equipmentApp "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/app"
+)
+
+// EquipmentRegistrar implements domain.EquipmentRegistrar over the equipment registry
+type EquipmentRegistrar struct {
+	registrar *equipmentApp.InstalledBaseRegistrar
+	logger    *slog.Logger
+}
+
+// NewEquipmentRegistrar creates a registrar for delivered contract units
+func NewEquipmentRegistrar(registrar *equipmentApp.InstalledBaseRegistrar, logger *slog.Logger) *EquipmentRegistrar {
+	return &EquipmentRegistrar{
+		registrar: registrar,
+		logger:    logger.With(slog.String("component", "contract_equipment_registrar")),
+	}
+}
+
+// RegisterDeliveredUnits registers each delivered unit with the contract ID
+// set, taking name, model, price and warranty from the contract item. Units
+// already registered are linked to the contract.
+func (r *EquipmentRegistrar) RegisterDeliveredUnits(ctx context.Context, contract *domain.Contract, customerName string, units []domain.DeliveredUnit) ([]string, error) {
+	ids := make([]string, 0, len(units))
+	for _, unit := range units {
+		item, ok := contract.Item(unit.ContractItemID)
+		if !ok {
+			return ids, fmt.Errorf("%w: %s is not an item of this contract", domain.ErrInvalidDelivery, unit.ContractItemID)
+		}
+
+		now := time.Now()
+		installed := equipmentApp.InstalledUnit{
+			SerialNumber:         unit.SerialNumber,
+			EquipmentID:          item.EquipmentID,
+			EquipmentName:        item.EquipmentName,
+			ManufacturerName:     item.ManufacturerName,
+			ModelNumber:          item.ModelNumber,
+			CustomerID:           contract.TenantID,
+			CustomerName:         customerName,
+			InstallationLocation: unit.InstallationLocation,
+			ContractID:           contract.ID,
+			PurchaseDate:         now,
+			PurchasePrice:        item.UnitPrice,
+			Notes:                fmt.Sprintf("Delivered under contract %s", contract.ContractNumber),
+			CreatedBy:            contract.CreatedBy,
+		}
+		if months := item.WarrantyMonths(); months > 0 {
+			expiry := now.AddDate(0, months, 0)
+			installed.WarrantyExpiry = &expiry
+		}
+
+		id, err := r.registrar.Register(ctx, installed)
+		if errors.Is(err, equipmentApp.ErrContractConflict) {
+			return ids, fmt.Errorf("%w: %v", domain.ErrInvalidDelivery, err)
+		}
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+
+	r.logger.Info("Delivered units registered",
+		slog.String("contract_id", contract.ID),
+		slog.Int("units", len(ids)))
+	return ids, nil
+}
@@ -0,0 +1,132 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+)
+
+// ListOpenContracts retrieves active and suspended contracts of a tenant, or
+// of all tenants when tenantID is empty
+func (r *ContractRepository) ListOpenContracts(ctx context.Context, tenantID string) ([]*domain.Contract, error) {
+	rows, err := r.db.Pool().Query(ctx, `
+		SELECT id, tenant_id FROM contracts
+		WHERE status IN ('active', 'suspended')
+		  AND ($1 = '' OR tenant_id = $1)
+		ORDER BY tenant_id, end_date
+	`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open contracts: %w", err)
+	}
+
+	type key struct{ id, tenantID string }
+	keys := []key{}
+	for rows.Next() {
+		var k key
+		if err := rows.Scan(&k.id, &k.tenantID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan contract: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list open contracts: %w", err)
+	}
+
+	contracts := make([]*domain.Contract, 0, len(keys))
+	for _, k := range keys {
+		contract, err := r.GetByID(ctx, k.tenantID, k.id)
+		if err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, contract)
+	}
+	return contracts, nil
+}
+
+// AddReminders stores reminders, skipping repeats about the same obligation,
+// due date, aging bucket and recipient, and returns how many were stored
+func (r *ContractRepository) AddReminders(ctx context.Context, reminders []domain.ObligationReminder) (int, error) {
+	query := `
+		INSERT INTO contract_obligation_reminders (
+			id, tenant_id, contract_id, obligation_kind, obligation_index, due_date,
+			aging_bucket, recipient, kind, subject, message, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT DO NOTHING
+	`
+	stored := 0
+	for _, n := range reminders {
+		result, err := r.db.Pool().Exec(ctx, query,
+			n.ID, n.TenantID, n.ContractID, n.ObligationKind, n.ObligationIndex, n.DueDate,
+			n.AgingBucket, n.Recipient, n.Kind, n.Subject, n.Message, n.CreatedAt)
+		if err != nil {
+			r.logger.Error("Failed to add obligation reminder",
+				slog.String("error", err.Error()),
+				slog.String("contract_id", n.ContractID))
+			return stored, fmt.Errorf("failed to add obligation reminder: %w", err)
+		}
+		stored += int(result.RowsAffected())
+	}
+	return stored, nil
+}
+
+// ListReminders retrieves obligation reminders, newest first
+func (r *ContractRepository) ListReminders(ctx context.Context, tenantID string, filter domain.ReminderFilter) ([]domain.ObligationReminder, error) {
+	query := `
+		SELECT id, tenant_id, contract_id, obligation_kind, obligation_index, due_date,
+			aging_bucket, recipient, kind, subject, COALESCE(message, ''), created_at, read_at
+		FROM contract_obligation_reminders
+		WHERE tenant_id = $1
+	`
+	args := []interface{}{tenantID}
+	if filter.Recipient != "" {
+		args = append(args, filter.Recipient)
+		query += fmt.Sprintf(" AND recipient = $%d", len(args))
+	}
+	if filter.ContractID != "" {
+		args = append(args, filter.ContractID)
+		query += fmt.Sprintf(" AND contract_id = $%d", len(args))
+	}
+	if filter.UnreadOnly {
+		query += " AND read_at IS NULL"
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := r.db.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list obligation reminders: %w", err)
+	}
+	defer rows.Close()
+
+	reminders := []domain.ObligationReminder{}
+	for rows.Next() {
+		var n domain.ObligationReminder
+		err := rows.Scan(
+			&n.ID, &n.TenantID, &n.ContractID, &n.ObligationKind, &n.ObligationIndex, &n.DueDate,
+			&n.AgingBucket, &n.Recipient, &n.Kind, &n.Subject, &n.Message, &n.CreatedAt, &n.ReadAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan obligation reminder: %w", err)
+		}
+		reminders = append(reminders, n)
+	}
+	return reminders, rows.Err()
+}
+
+// MarkReminderRead marks an obligation reminder as read
+func (r *ContractRepository) MarkReminderRead(ctx context.Context, tenantID, id string) error {
+	result, err := r.db.Pool().Exec(ctx, `
+		UPDATE contract_obligation_reminders SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+		WHERE id = $1 AND tenant_id = $2
+	`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to mark obligation reminder read: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrReminderNotFound
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package infra
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// PDFRenderer implements domain.DocumentRenderer with gofpdf
+type PDFRenderer struct{}
+
+// NewPDFRenderer creates a new contract PDF renderer
+func NewPDFRenderer() *PDFRenderer {
+	return &PDFRenderer{}
+}
+
+// Render renders the contract terms, items and schedules
+func (p *PDFRenderer) Render(contract *domain.Contract, version int) ([]byte, error) {
+	pdf := p.newDocument(contract, version)
+	return p.output(pdf)
+}
+
+// Seal re-renders the signed document version from the contract snapshot
+// taken when it was rendered, and appends an audit page recording every
+// signature and the hash of the document that was signed
+func (p *PDFRenderer) Seal(contract *domain.Contract, doc *domain.ContractDocument, request *domain.SigningRequest) ([]byte, error) {
+	pdf := p.newDocument(contract, doc.Version)
+
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(0, 10, "Signature Audit Trail")
+	pdf.Ln(12)
+
+	p.field(pdf, "Contract:", contract.ContractNumber)
+	p.field(pdf, "Document:", fmt.Sprintf("%s (version %d)", doc.ID, doc.Version))
+	p.field(pdf, "Signing request:", request.ID)
+	if request.CompletedAt != nil {
+		p.field(pdf, "Completed:", request.CompletedAt.UTC().Format(time.RFC3339))
+	}
+	pdf.Ln(2)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.Cell(0, 6, "SHA-256 of the signed document:")
+	pdf.Ln(6)
+	pdf.SetFont("Courier", "", 9)
+	pdf.Cell(0, 6, request.DocumentHash)
+	pdf.Ln(10)
+
+	for _, s := range request.Signatories {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.Cell(0, 8, fmt.Sprintf("%d. %s (%s)", s.Order, s.Name, s.Party))
+		pdf.Ln(8)
+		p.field(pdf, "Email:", s.Email)
+		if s.Title != "" {
+			p.field(pdf, "Title:", s.Title)
+		}
+		p.field(pdf, "Status:", string(s.Status))
+		if e := s.Evidence; e != nil {
+			p.field(pdf, "Signed at:", e.SignedAt.UTC().Format(time.RFC3339))
+			p.field(pdf, "Signer ID:", e.SignerID)
+			p.field(pdf, "IP address:", e.IPAddress)
+			if e.UserAgent != "" {
+				p.field(pdf, "User agent:", e.UserAgent)
+			}
+			p.field(pdf, "Document hash:", e.DocumentHash)
+		}
+		pdf.Ln(4)
+	}
+
+	pdf.SetFont("Arial", "I", 8)
+	pdf.MultiCell(0, 4, "The pages before this one reproduce the document version named above. "+
+		"Its SHA-256 hash can be checked against the rendered document stored with the contract.", "", "", false)
+
+	return p.output(pdf)
+}
+
+// newDocument lays out the contract pages shared by rendered and sealed documents
+func (p *PDFRenderer) newDocument(contract *domain.Contract, version int) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle(tr(fmt.Sprintf("Contract %s", contract.ContractNumber)), false)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.CellFormat(0, 8, fmt.Sprintf("%s - version %d - page %d", contract.ContractNumber, version, pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(0, 10, tr(fmt.Sprintf("Contract %s", contract.ContractNumber)))
+	pdf.Ln(12)
+
+	p.field(pdf, "Supplier:", tr(contract.SupplierName))
+	p.field(pdf, "RFQ:", contract.RFQID)
+	p.field(pdf, "Quote:", contract.QuoteID)
+	p.field(pdf, "Term:", fmt.Sprintf("%s to %s", contract.StartDate.Format("2006-01-02"), contract.EndDate.Format("2006-01-02")))
+	p.field(pdf, "Total:", p.amount(contract, contract.TotalAmount))
+	if contract.TaxAmount > 0 {
+		p.field(pdf, "Tax:", p.amount(contract, contract.TaxAmount))
+	}
+	if n := len(contract.Amendments); n > 0 {
+		last := contract.Amendments[n-1]
+		p.field(pdf, "Amendment:", tr(fmt.Sprintf("%d (%s, %s)", n, last.Date.Format("2006-01-02"), last.Description)))
+	}
+	pdf.Ln(4)
+
+	p.heading(pdf, "Items")
+	pdf.SetFont("Arial", "B", 9)
+	pdf.CellFormat(80, 7, "Equipment", "B", 0, "", false, 0, "")
+	pdf.CellFormat(20, 7, "Qty", "B", 0, "R", false, 0, "")
+	pdf.CellFormat(40, 7, "Unit price", "B", 0, "R", false, 0, "")
+	pdf.CellFormat(40, 7, "Total", "B", 1, "R", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	for _, item := range contract.Items {
+		name := item.EquipmentName
+		if item.ModelNumber != "" {
+			name += " (" + item.ModelNumber + ")"
+		}
+		pdf.CellFormat(80, 6, tr(name), "", 0, "", false, 0, "")
+		pdf.CellFormat(20, 6, fmt.Sprintf("%d", item.Quantity), "", 0, "R", false, 0, "")
+		pdf.CellFormat(40, 6, p.amount(contract, item.UnitPrice), "", 0, "R", false, 0, "")
+		pdf.CellFormat(40, 6, p.amount(contract, item.TotalPrice), "", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	if len(contract.PaymentSchedule) > 0 {
+		p.heading(pdf, "Payment Schedule")
+		pdf.SetFont("Arial", "", 9)
+		for _, term := range contract.PaymentSchedule {
+			pdf.CellFormat(30, 6, term.DueDate.Format("2006-01-02"), "", 0, "", false, 0, "")
+			pdf.CellFormat(40, 6, p.amount(contract, term.Amount), "", 0, "R", false, 0, "")
+			pdf.CellFormat(0, 6, "  "+tr(term.Description), "", 1, "", false, 0, "")
+		}
+		pdf.Ln(4)
+	}
+
+	if len(contract.DeliverySchedule) > 0 {
+		p.heading(pdf, "Delivery Schedule")
+		pdf.SetFont("Arial", "", 9)
+		for _, milestone := range contract.DeliverySchedule {
+			pdf.CellFormat(30, 6, milestone.MilestoneDate.Format("2006-01-02"), "", 0, "", false, 0, "")
+			pdf.CellFormat(0, 6, tr(milestone.Description), "", 1, "", false, 0, "")
+		}
+		pdf.Ln(4)
+	}
+
+	p.section(pdf, "Payment Terms", tr(contract.PaymentTerms))
+	p.section(pdf, "Delivery Terms", tr(contract.DeliveryTerms))
+	p.section(pdf, "Warranty Terms", tr(contract.WarrantyTerms))
+	p.section(pdf, "Terms and Conditions", tr(contract.TermsAndConditions))
+	return pdf
+}
+
+func (p *PDFRenderer) heading(pdf *gofpdf.Fpdf, title string) {
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(0, 8, title)
+	pdf.Ln(8)
+}
+
+func (p *PDFRenderer) section(pdf *gofpdf.Fpdf, title, body string) {
+	if body == "" {
+		return
+	}
+	p.heading(pdf, title)
+	pdf.SetFont("Arial", "", 10)
+	pdf.MultiCell(0, 5, body, "", "", false)
+	pdf.Ln(4)
+}
+
+func (p *PDFRenderer) field(pdf *gofpdf.Fpdf, label, value string) {
+	pdf.SetFont("Arial", "B", 10)
+	pdf.Cell(40, 6, label)
+	pdf.SetFont("Arial", "", 10)
+	pdf.MultiCell(0, 6, value, "", "", false)
+}
+
+func (p *PDFRenderer) amount(contract *domain.Contract, value float64) string {
+	return contract.Currency + " " + money.NewFromFloat(value).StringFixed(money.MinorUnits(contract.Currency))
+}
+
+func (p *PDFRenderer) output(pdf *gofpdf.Fpdf) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render contract PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,120 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateRenewal records a contract's renewal; a contract is renewed at most once
+func (r *ContractRepository) CreateRenewal(ctx context.Context, renewal *domain.ContractRenewal) error {
+	return r.insertRenewal(ctx, r.db.Pool(), renewal)
+}
+
+// CreateRenewalDraft stores a renewal draft contract and its renewal record in one transaction
+func (r *ContractRepository) CreateRenewalDraft(ctx context.Context, renewal *domain.ContractRenewal, draft *domain.Contract) error {
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.create(ctx, tx, draft); err != nil {
+		return err
+	}
+	if err := r.insertRenewal(ctx, tx, renewal); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit renewal draft: %w", err)
+	}
+
+	r.logger.Info("Renewal contract drafted",
+		slog.String("contract_id", renewal.ContractID),
+		slog.String("renewal_contract_id", draft.ID))
+	return nil
+}
+
+// GetRenewal retrieves a contract's renewal
+func (r *ContractRepository) GetRenewal(ctx context.Context, tenantID, contractID string) (*domain.ContractRenewal, error) {
+	var renewal domain.ContractRenewal
+	var rfqID, renewalContractID *string
+	err := r.db.Pool().QueryRow(ctx, `
+		SELECT contract_id, tenant_id, kind, rfq_id, renewal_contract_id, created_by, created_at
+		FROM contract_renewals
+		WHERE contract_id = $1 AND tenant_id = $2
+	`, contractID, tenantID).Scan(
+		&renewal.ContractID, &renewal.TenantID, &renewal.Kind, &rfqID, &renewalContractID,
+		&renewal.CreatedBy, &renewal.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRenewalNotFound
+		}
+		return nil, fmt.Errorf("failed to get contract renewal: %w", err)
+	}
+	renewal.RFQID = deref(rfqID)
+	renewal.RenewalContractID = deref(renewalContractID)
+	return &renewal, nil
+}
+
+// ListRenewals retrieves the renewals of the given contracts, keyed by contract ID
+func (r *ContractRepository) ListRenewals(ctx context.Context, tenantID string, contractIDs []string) (map[string]*domain.ContractRenewal, error) {
+	renewals := map[string]*domain.ContractRenewal{}
+	if len(contractIDs) == 0 {
+		return renewals, nil
+	}
+
+	rows, err := r.db.Pool().Query(ctx, `
+		SELECT contract_id, tenant_id, kind, rfq_id, renewal_contract_id, created_by, created_at
+		FROM contract_renewals
+		WHERE tenant_id = $1 AND contract_id = ANY($2)
+	`, tenantID, contractIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contract renewals: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var renewal domain.ContractRenewal
+		var rfqID, renewalContractID *string
+		if err := rows.Scan(
+			&renewal.ContractID, &renewal.TenantID, &renewal.Kind, &rfqID, &renewalContractID,
+			&renewal.CreatedBy, &renewal.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan contract renewal: %w", err)
+		}
+		renewal.RFQID = deref(rfqID)
+		renewal.RenewalContractID = deref(renewalContractID)
+		renewals[renewal.ContractID] = &renewal
+	}
+	return renewals, rows.Err()
+}
+
+func (r *ContractRepository) insertRenewal(ctx context.Context, db execer, renewal *domain.ContractRenewal) error {
+	var rfqID, renewalContractID *string
+	if renewal.RFQID != "" {
+		rfqID = &renewal.RFQID
+	}
+	if renewal.RenewalContractID != "" {
+		renewalContractID = &renewal.RenewalContractID
+	}
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO contract_renewals (
+			contract_id, tenant_id, kind, rfq_id, renewal_contract_id, created_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, renewal.ContractID, renewal.TenantID, renewal.Kind, rfqID, renewalContractID,
+		renewal.CreatedBy, renewal.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrRenewalExists
+		}
+		return fmt.Errorf("failed to record contract renewal: %w", err)
+	}
+	return nil
+}
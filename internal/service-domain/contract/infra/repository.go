@@ -26,8 +26,27 @@ func NewContractRepository(db *PostgresDB, logger *slog.Logger) *ContractReposit
 	}
 }
 
+// NextContractNumber reserves a contract number from the contract number sequence
+func (r *ContractRepository) NextContractNumber(ctx context.Context, tenantID string) (string, error) {
+	var number string
+	if err := r.db.Pool().QueryRow(ctx, `SELECT generate_contract_number($1)`, tenantID).Scan(&number); err != nil {
+		return "", fmt.Errorf("failed to generate contract number: %w", err)
+	}
+	return number, nil
+}
+
 // Create creates a new contract
 func (r *ContractRepository) Create(ctx context.Context, contract *domain.Contract) error {
+	if err := r.create(ctx, r.db.Pool(), contract); err != nil {
+		return err
+	}
+
+	r.logger.Info("Contract created", slog.String("id", contract.ID), slog.String("contract_number", contract.ContractNumber))
+	return nil
+}
+
+// create inserts a contract through the pool or a transaction
+func (r *ContractRepository) create(ctx context.Context, db execer, contract *domain.Contract) error {
 	// Marshal JSONB fields
 	paymentScheduleJSON, err := json.Marshal(contract.PaymentSchedule)
 	if err != nil {
@@ -64,7 +83,7 @@ func (r *ContractRepository) Create(ctx context.Context, contract *domain.Contra
 		)
 	`
 
-	_, err = r.db.Pool().Exec(ctx, query,
+	_, err = db.Exec(ctx, query,
 		contract.ID, contract.TenantID, contract.ContractNumber, contract.RFQID, contract.QuoteID,
 		contract.SupplierID, contract.SupplierName, contract.Status, contract.TotalAmount,
 		contract.Currency, contract.TaxAmount, contract.StartDate, contract.EndDate, contract.SignedDate,
@@ -77,7 +96,6 @@ func (r *ContractRepository) Create(ctx context.Context, contract *domain.Contra
 		return fmt.Errorf("failed to create contract: %w", err)
 	}
 
-	r.logger.Info("Contract created", slog.String("id", contract.ID), slog.String("contract_number", contract.ContractNumber))
 	return nil
 }
 
@@ -336,6 +354,16 @@ func (r *ContractRepository) List(ctx context.Context, criteria domain.ListCrite
 
 // Update updates a contract
 func (r *ContractRepository) Update(ctx context.Context, contract *domain.Contract) error {
+	if err := r.update(ctx, r.db.Pool(), contract); err != nil {
+		return err
+	}
+
+	r.logger.Info("Contract updated", slog.String("id", contract.ID))
+	return nil
+}
+
+// update writes a contract through the pool or a transaction
+func (r *ContractRepository) update(ctx context.Context, db execer, contract *domain.Contract) error {
 	// Marshal JSONB fields
 	paymentScheduleJSON, err := json.Marshal(contract.PaymentSchedule)
 	if err != nil {
@@ -370,7 +398,7 @@ func (r *ContractRepository) Update(ctx context.Context, contract *domain.Contra
 		WHERE id = $24 AND tenant_id = $25
 	`
 
-	result, err := r.db.Pool().Exec(ctx, query,
+	result, err := db.Exec(ctx, query,
 		contract.ContractNumber, contract.RFQID, contract.QuoteID,
 		contract.SupplierID, contract.SupplierName, contract.Status,
 		contract.TotalAmount, contract.Currency, contract.TaxAmount,
@@ -390,7 +418,6 @@ func (r *ContractRepository) Update(ctx context.Context, contract *domain.Contra
 		return domain.ErrContractNotFound
 	}
 
-	r.logger.Info("Contract updated", slog.String("id", contract.ID))
 	return nil
 }
 
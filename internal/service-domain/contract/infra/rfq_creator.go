@@ -0,0 +1,90 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// RenewalRFQCreator implements domain.RenewalRFQCreator over the RFQ repository
+type RenewalRFQCreator struct {
+	rfqs   rfqDomain.RFQRepository
+	logger *slog.Logger
+}
+
+// NewRenewalRFQCreator creates a creator of renewal RFQs
+func NewRenewalRFQCreator(rfqs rfqDomain.RFQRepository, logger *slog.Logger) *RenewalRFQCreator {
+	return &RenewalRFQCreator{
+		rfqs:   rfqs,
+		logger: logger.With(slog.String("component", "contract_renewal_rfq_creator")),
+	}
+}
+
+// CreateRenewalRFQ drafts an RFQ for the contract's items, estimated at the
+// contract prices and required by the contract's end date. It stays a draft
+// for the buyer to review, invite suppliers to and publish.
+func (c *RenewalRFQCreator) CreateRenewalRFQ(ctx context.Context, contract *domain.Contract, responseDeadline time.Time, createdBy string) (string, error) {
+	now := time.Now()
+	rfq, err := rfqDomain.NewRFQ(
+		ksuid.New().String(),
+		// Same format as RFQs created through the RFQ service
+		fmt.Sprintf("RFQ-%s-%04d", now.Format("2006"), now.Unix()%10000),
+		contract.TenantID,
+		fmt.Sprintf("Renewal of contract %s", contract.ContractNumber),
+		fmt.Sprintf("Re-tender of contract %s with %s, which ends on %s.",
+			contract.ContractNumber, contract.SupplierName, contract.EndDate.Format("2006-01-02")),
+		rfqDomain.RFQPriorityMedium,
+		responseDeadline,
+		rfqDomain.DeliveryTerms{RequiredBy: contract.EndDate, SpecialNotes: contract.DeliveryTerms},
+		rfqDomain.PaymentTerms{SpecialTerms: contract.PaymentTerms},
+		createdBy,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to draft renewal RFQ: %w", err)
+	}
+	if err := rfq.SetCurrency(contract.Currency, nil); err != nil {
+		return "", err
+	}
+	rfq.InternalNotes = fmt.Sprintf("Renewal of contract %s (%s), incumbent supplier %s",
+		contract.ContractNumber, contract.ID, contract.SupplierID)
+
+	for _, item := range contract.Items {
+		price := item.UnitPrice
+		rfqItem := rfqDomain.RFQItem{
+			ID:             ksuid.New().String(),
+			Name:           item.EquipmentName,
+			Description:    item.Specifications,
+			Quantity:       item.Quantity,
+			Unit:           "unit",
+			EstimatedPrice: &price,
+			Notes:          fmt.Sprintf("%s %s, warranty %s", item.ManufacturerName, item.ModelNumber, item.WarrantyPeriod),
+		}
+		if item.EquipmentID != "" {
+			equipmentID := item.EquipmentID
+			rfqItem.EquipmentID = &equipmentID
+		}
+		if err := rfq.AddItem(rfqItem); err != nil {
+			return "", fmt.Errorf("failed to add renewal RFQ item: %w", err)
+		}
+	}
+
+	if err := c.rfqs.Create(ctx, rfq); err != nil {
+		return "", err
+	}
+	for i := range rfq.Items {
+		if err := c.rfqs.AddItem(ctx, rfq.ID, &rfq.Items[i]); err != nil {
+			return rfq.ID, fmt.Errorf("failed to add renewal RFQ item: %w", err)
+		}
+	}
+
+	c.logger.Info("Renewal RFQ drafted",
+		slog.String("contract_id", contract.ID),
+		slog.String("rfq_id", rfq.ID),
+		slog.Int("items", len(rfq.Items)))
+	return rfq.ID, nil
+}
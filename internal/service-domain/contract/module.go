@@ -8,6 +8,9 @@ import (
 	"github.com/aby-med/medical-platform/internal/service-domain/contract/api"
 	"github.com/aby-med/medical-platform/internal/service-domain/contract/app"
 	"github.com/aby-med/medical-platform/internal/service-domain/contract/infra"
+	equipmentApp "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/app"
+	equipmentInfra "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/infra"
+	rfqInfra "github.com/aby-med/medical-platform/internal/service-domain/rfq/infra"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -22,6 +25,8 @@ type Module struct {
 	logger  *slog.Logger
 	db      *infra.PostgresDB
 	handler *api.ContractHandler
+	monitor *app.ObligationMonitor
+	renewal *app.RenewalMonitor
 }
 
 // NewModule creates a new contract module instance
@@ -45,7 +50,14 @@ func (m *Module) Initialize(ctx context.Context) error {
 
 	// Initialize layers
 	repo := infra.NewContractRepository(db, m.logger)
-	service := app.NewContractService(repo, m.logger)
+	documents := infra.NewDocumentRepository(db, m.logger)
+	service := app.NewContractService(repo, documents, infra.NewPDFRenderer(), repo, repo, repo, m.logger)
+	installedBase := equipmentApp.NewInstalledBaseRegistrar(equipmentInfra.NewEquipmentRepository(db.Pool()), m.logger)
+	service.SetEquipmentRegistrar(infra.NewEquipmentRegistrar(installedBase, m.logger))
+	rfqs := rfqInfra.NewRFQRepository(rfqInfra.NewPostgresDBFromPool(db.Pool(), m.logger), m.logger)
+	service.SetRenewalRFQCreator(infra.NewRenewalRFQCreator(rfqs, m.logger))
+	m.monitor = app.NewObligationMonitor(service, m.logger)
+	m.renewal = app.NewRenewalMonitor(service, m.logger)
 	m.handler = api.NewContractHandler(service, m.logger)
 
 	m.logger.Info("Contract module initialized successfully")
@@ -60,6 +72,10 @@ func (m *Module) MountRoutes(r chi.Router) {
 		// Core operations
 		r.Post("/", m.handler.CreateContract)
 		r.Get("/", m.handler.ListContracts)
+		r.Get("/obligations/dashboard", m.handler.ObligationDashboard)
+		r.Get("/obligations/reminders", m.handler.ListObligationReminders)
+		r.Post("/obligations/reminders/{reminder_id}/read", m.handler.MarkObligationReminderRead)
+		r.Get("/renewals/expiring", m.handler.ExpiryReport)
 		r.Get("/{id}", m.handler.GetContract)
 		r.Patch("/{id}", m.handler.UpdateContract)
 		r.Delete("/{id}", m.handler.DeleteContract)
@@ -74,10 +90,35 @@ func (m *Module) MountRoutes(r chi.Router) {
 
 		// Amendment
 		r.Post("/{id}/amendments", m.handler.AddAmendment)
+		r.Post("/{id}/amendment-requests", m.handler.ProposeAmendment)
+		r.Get("/{id}/amendment-requests", m.handler.ListAmendmentRequests)
+		r.Get("/{id}/amendment-requests/{amendment_id}", m.handler.GetAmendmentRequest)
+		r.Post("/{id}/amendment-requests/{amendment_id}/approve", m.handler.ApproveAmendment)
+		r.Post("/{id}/amendment-requests/{amendment_id}/reject", m.handler.RejectAmendment)
+		r.Post("/{id}/amendment-requests/{amendment_id}/withdraw", m.handler.WithdrawAmendment)
+		r.Get("/{id}/versions", m.handler.ListContractVersions)
+		r.Get("/{id}/versions/{version}", m.handler.GetContractVersion)
+
+		// Documents and e-signature
+		r.Post("/{id}/documents", m.handler.RenderDocument)
+		r.Get("/{id}/documents", m.handler.ListDocuments)
+		r.Get("/{id}/documents/{document_id}/pdf", m.handler.DownloadDocument)
+		r.Post("/{id}/signing-requests", m.handler.StartSigning)
+		r.Get("/{id}/signing-requests", m.handler.ListSigningRequests)
+		r.Get("/{id}/signing-requests/{request_id}", m.handler.GetSigningRequest)
+		r.Post("/{id}/signing-requests/{request_id}/cancel", m.handler.CancelSigning)
+		r.Post("/{id}/signing-requests/{request_id}/signatories/{signatory_id}/sign", m.handler.SignDocument)
+		r.Post("/{id}/signing-requests/{request_id}/signatories/{signatory_id}/decline", m.handler.DeclineSigning)
 
 		// Payment and delivery tracking
+		r.Post("/{id}/payments", m.handler.RecordPayment)
 		r.Post("/{id}/payments/paid", m.handler.MarkPaymentPaid)
 		r.Post("/{id}/deliveries/completed", m.handler.MarkDeliveryCompleted)
+		r.Get("/{id}/obligations", m.handler.GetObligations)
+
+		// Renewal
+		r.Post("/{id}/renew", m.handler.RenewContract)
+		r.Get("/{id}/renewal", m.handler.GetRenewal)
 	})
 
 	// Query routes
@@ -91,7 +132,13 @@ func (m *Module) MountRoutes(r chi.Router) {
 // Start begins any background processes
 func (m *Module) Start(ctx context.Context) error {
 	m.logger.Info("Starting contract module")
-	// No background processes for now
+
+	if m.monitor != nil {
+		go m.monitor.Run(ctx)
+	}
+	if m.renewal != nil {
+		go m.renewal.Run(ctx)
+	}
 	return nil
 }
 
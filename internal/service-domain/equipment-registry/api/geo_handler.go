@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// GeoHandler handles HTTP requests for the gazetteer and installation coordinates
+type GeoHandler struct {
+	service   *app.GeoService
+	equipment *app.EquipmentService
+	logger    *slog.Logger
+}
+
+// NewGeoHandler creates a new geolocation HTTP handler
+func NewGeoHandler(service *app.GeoService, equipment *app.EquipmentService, logger *slog.Logger) *GeoHandler {
+	return &GeoHandler{
+		service:   service,
+		equipment: equipment,
+		logger:    logger.With(slog.String("component", "geo_handler")),
+	}
+}
+
+// ImportGazetteer handles POST /equipment/geo/gazetteer/import (multipart: file, country)
+func (h *GeoHandler) ImportGazetteer(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(64 << 20); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Failed to parse form: "+err.Error())
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "Gazetteer file is required")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportGazetteer(r.Context(), file, r.FormValue("country"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidGazetteer) {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("Failed to import gazetteer", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to import gazetteer")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// Geocode handles POST /equipment/geo/geocode?dry_run=&overwrite=
+func (h *GeoHandler) Geocode(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	result, err := h.service.BackfillCoordinates(r.Context(), query.Get("overwrite") == "true", query.Get("dry_run") == "true")
+	if err != nil {
+		if errors.Is(err, domain.ErrGazetteerEmpty) {
+			h.respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		h.logger.Error("Failed to geocode installations", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to geocode installations")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// SetLocationRequest holds manually entered installation coordinates
+type SetLocationRequest struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+// SetLocation handles PUT /equipment/{id}/location. Sending no coordinates
+// clears the manual override and geocodes from the postal code again.
+func (h *GeoHandler) SetLocation(w http.ResponseWriter, r *http.Request) {
+	var req SetLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		h.respondError(w, http.StatusBadRequest, "latitude and longitude must be set together")
+		return
+	}
+
+	equipment, err := h.equipment.GetEquipmentByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrEquipmentNotFound) {
+			h.respondError(w, http.StatusNotFound, "Equipment not found")
+			return
+		}
+		h.respondError(w, http.StatusInternalServerError, "Failed to get equipment")
+		return
+	}
+
+	if req.Latitude == nil {
+		err = h.service.ClearCoordinates(r.Context(), equipment)
+	} else {
+		err = h.service.SetCoordinates(r.Context(), equipment, geo.Point{Lat: *req.Latitude, Lng: *req.Longitude})
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCoordinates) {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("Failed to set installation location", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to set installation location")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, equipment)
+}
+
+// respondJSON writes JSON response
+func (h *GeoHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError writes error response
+func (h *GeoHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
@@ -2,10 +2,8 @@
 
 import (
 	"encoding/json"
-	"io"
 	"log/slog"
 	"net/http"
-	"os"
 	"strconv"
 	"time"
 
@@ -286,56 +284,54 @@ func (h *EquipmentHandler) DownloadQRLabel(w http.ResponseWriter, r *http.Reques
 }
 
 // ImportCSV handles POST /equipment/import
+// Accepts a CSV or XLSX file ("file" or "csv_file") with optional dry_run,
+// update_mode and column_mapping (JSON object of field -> header) form fields.
 func (h *EquipmentHandler) ImportCSV(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// Parse multipart form
-	err := r.ParseMultipartForm(10 << 20) // 10 MB max
+	err := r.ParseMultipartForm(32 << 20) // 32 MB max; larger files go through import jobs
 	if err != nil {
 		h.respondError(w, http.StatusBadRequest, "Failed to parse form: "+err.Error())
 		return
 	}
 
-	file, header, err := r.FormFile("csv_file")
+	file, header, err := importFormFile(r)
 	if err != nil {
-		h.respondError(w, http.StatusBadRequest, "CSV file is required")
+		h.respondError(w, http.StatusBadRequest, "CSV or XLSX file is required")
 		return
 	}
 	defer file.Close()
 
-	createdBy := r.FormValue("created_by")
-	if createdBy == "" {
-		createdBy = "system"
-	}
-
-	// Save uploaded file temporarily
-	tempFilePath := "/tmp/" + header.Filename
-
-	// Create temp file
-	tempFile, err := os.Create(tempFilePath)
+	format, err := app.DetectImportFormat(header.Filename)
 	if err != nil {
-		h.respondError(w, http.StatusInternalServerError, "Failed to save file")
+		h.respondError(w, http.StatusBadRequest, "Unsupported file format: use .csv or .xlsx")
 		return
 	}
-	defer tempFile.Close()
-	defer os.Remove(tempFilePath)
 
-	// Copy uploaded file to temp location
-	_, err = io.Copy(tempFile, file)
+	opts, err := parseImportOptions(r)
 	if err != nil {
-		h.respondError(w, http.StatusInternalServerError, "Failed to save file")
+		h.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Import from CSV
-	result, err := h.service.BulkImportFromCSV(ctx, tempFilePath, createdBy)
+	report, err := h.service.ImportEquipment(ctx, file, format, opts)
 	if err != nil {
-		h.logger.Error("Failed to import CSV", slog.String("error", err.Error()))
-		h.respondError(w, http.StatusInternalServerError, "Failed to import CSV: "+err.Error())
+		h.logger.Error("Failed to import equipment", slog.String("error", err.Error()))
+		if report == nil {
+			h.respondError(w, http.StatusBadRequest, "Failed to import equipment: "+err.Error())
+			return
+		}
+		// Earlier batches were committed; return what was processed
+		h.respondJSON(w, http.StatusInternalServerError, map[string]interface{}{
+			"error":  "Import stopped: " + err.Error(),
+			"report": report,
+		})
 		return
 	}
 
-	h.respondJSON(w, http.StatusOK, result)
+	// Row failures are reported per row; the request itself succeeded
+	h.respondJSON(w, http.StatusOK, report)
 }
 
 // ImportQRMapping handles POST /equipment/qr/import-mapping
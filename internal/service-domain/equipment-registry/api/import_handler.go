@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/aby-med/medical-platform/internal/middleware"
+	"github.com/aby-med/medical-platform/internal/pkg/orgfilter"
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// ImportJobHandler handles HTTP requests for background installed-base imports
+type ImportJobHandler struct {
+	service *app.ImportJobService
+	logger  *slog.Logger
+}
+
+// NewImportJobHandler creates a new import job HTTP handler
+func NewImportJobHandler(service *app.ImportJobService, logger *slog.Logger) *ImportJobHandler {
+	return &ImportJobHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "import_job_handler")),
+	}
+}
+
+// StartJob handles POST /equipment/import/jobs
+func (h *ImportJobHandler) StartJob(w http.ResponseWriter, r *http.Request) {
+	// Files are streamed to disk by the multipart parser above this limit
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Failed to parse form: "+err.Error())
+		return
+	}
+
+	file, header, err := importFormFile(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "CSV or XLSX file is required")
+		return
+	}
+	defer file.Close()
+
+	opts, err := parseImportOptions(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	job, err := h.service.StartJob(r.Context(), file, header.Filename, opts)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnsupportedImportFormat) {
+			h.respondError(w, http.StatusBadRequest, "Unsupported file format: use .csv or .xlsx")
+			return
+		}
+		h.logger.Error("Failed to start import job", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to start import job")
+		return
+	}
+
+	h.respondJSON(w, http.StatusAccepted, job)
+}
+
+// ListJobs handles GET /equipment/import/jobs for the caller's organization
+func (h *ImportJobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	jobs, err := h.service.ListJobs(r.Context(), limit)
+	if err != nil {
+		h.logger.Error("Failed to list import jobs", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list import jobs")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"jobs":  jobs,
+		"count": len(jobs),
+	})
+}
+
+// GetJob handles GET /equipment/import/jobs/{id}
+func (h *ImportJobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.GetJob(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondJobError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, job)
+}
+
+// ListJobRows handles GET /equipment/import/jobs/{id}/rows?action=error&limit=&offset=
+func (h *ImportJobHandler) ListJobRows(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	rows, err := h.service.ListJobRows(r.Context(), chi.URLParam(r, "id"), domain.ImportRowAction(query.Get("action")), limit, offset)
+	if err != nil {
+		h.respondJobError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"rows":  rows,
+		"count": len(rows),
+	})
+}
+
+// ResumeJob handles POST /equipment/import/jobs/{id}/resume
+func (h *ImportJobHandler) ResumeJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.ResumeJob(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondJobError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusAccepted, job)
+}
+
+// respondJobError maps import job errors to HTTP status codes
+func (h *ImportJobHandler) respondJobError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrImportJobNotFound):
+		h.respondError(w, http.StatusNotFound, "Import job not found")
+	case errors.Is(err, domain.ErrImportJobNotResumable):
+		h.respondError(w, http.StatusConflict, "Import job has completed and cannot be resumed")
+	default:
+		h.logger.Error("Import job request failed", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Import job request failed")
+	}
+}
+
+// respondJSON writes JSON response
+func (h *ImportJobHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError writes error response
+func (h *ImportJobHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
+
+// importFormFile returns the uploaded import file, accepting "file" or the legacy "csv_file" field
+func importFormFile(r *http.Request) (multipart.File, *multipart.FileHeader, error) {
+	file, header, err := r.FormFile("file")
+	if err == nil {
+		return file, header, nil
+	}
+	return r.FormFile("csv_file")
+}
+
+// parseImportOptions reads dry_run, update_mode, column_mapping and created_by form fields
+func parseImportOptions(r *http.Request) (domain.ImportOptions, error) {
+	opts := domain.ImportOptions{
+		DryRun:     r.FormValue("dry_run") == "true",
+		UpdateMode: r.FormValue("update_mode") == "true",
+		CreatedBy:  r.FormValue("created_by"),
+	}
+	if opts.CreatedBy == "" {
+		opts.CreatedBy = "system"
+	}
+
+	// Existing equipment is matched and updated only within the importing organization
+	if orgID, ok := middleware.GetOrganizationID(r.Context()); ok && !orgfilter.IsSystemAdmin(r.Context()) {
+		orgType, _ := middleware.GetOrganizationType(r.Context())
+		opts.Scope = domain.OrgScope{OrganizationID: orgID.String(), OrganizationType: orgType}
+	}
+
+	if raw := r.FormValue("column_mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &opts.ColumnMapping); err != nil {
+			return opts, fmt.Errorf("column_mapping must be a JSON object of field to column header: %v", err)
+		}
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// MeterHandler handles HTTP requests for meter definitions and readings
+type MeterHandler struct {
+	service *app.MeterService
+	logger  *slog.Logger
+}
+
+// NewMeterHandler creates a new meter HTTP handler
+func NewMeterHandler(service *app.MeterService, logger *slog.Logger) *MeterHandler {
+	return &MeterHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "meter_handler")),
+	}
+}
+
+// DefineMeter handles POST /equipment/meter-definitions
+func (h *MeterHandler) DefineMeter(w http.ResponseWriter, r *http.Request) {
+	var req app.DefineMeterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	def, err := h.service.DefineMeter(r.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to define meter", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusBadRequest, "Failed to define meter: "+err.Error())
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, def)
+}
+
+// UpdateMeterDefinition handles PUT /equipment/meter-definitions/{id}
+func (h *MeterHandler) UpdateMeterDefinition(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req app.DefineMeterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	def, err := h.service.UpdateMeterDefinition(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, domain.ErrMeterDefinitionNotFound) {
+			h.respondError(w, http.StatusNotFound, "Meter definition not found")
+			return
+		}
+		h.respondError(w, http.StatusBadRequest, "Failed to update meter definition: "+err.Error())
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, def)
+}
+
+// ListMeterDefinitions handles GET /equipment/meter-definitions?catalog_equipment_id=
+func (h *MeterHandler) ListMeterDefinitions(w http.ResponseWriter, r *http.Request) {
+	catalogID := r.URL.Query().Get("catalog_equipment_id")
+	if catalogID == "" {
+		h.respondError(w, http.StatusBadRequest, "catalog_equipment_id is required")
+		return
+	}
+
+	defs, err := h.service.ListMeterDefinitions(r.Context(), catalogID)
+	if err != nil {
+		h.logger.Error("Failed to list meter definitions", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list meter definitions")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"meter_definitions": defs,
+		"count":             len(defs),
+	})
+}
+
+// RecordReading handles POST /equipment/{id}/meters/readings
+func (h *MeterHandler) RecordReading(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req struct {
+		domain.MeterReadingInput
+		RecordedBy string `json:"recorded_by"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	req.EquipmentID = id
+	if req.RecordedBy == "" {
+		req.RecordedBy = "system"
+	}
+
+	reading, err := h.service.RecordReading(r.Context(), req.MeterReadingInput, domain.ReadingSourceManual, req.RecordedBy)
+	if err != nil {
+		h.respondReadingError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, reading)
+}
+
+// ListReadings handles GET /equipment/{id}/meters/readings
+func (h *MeterHandler) ListReadings(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	readings, err := h.service.ListReadings(r.Context(), id, r.URL.Query().Get("meter_definition_id"), limit)
+	if err != nil {
+		h.logger.Error("Failed to list meter readings", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list meter readings")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"readings": readings,
+		"count":    len(readings),
+	})
+}
+
+// GetUsage handles GET /equipment/{id}/meters
+func (h *MeterHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	snapshot, err := h.service.GetUsageSnapshot(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrEquipmentNotFound) {
+			h.respondError(w, http.StatusNotFound, "Equipment not found")
+			return
+		}
+		h.logger.Error("Failed to get usage snapshot", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to get usage")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, snapshot)
+}
+
+// ImportReadingsCSV handles POST /equipment/meters/import
+func (h *MeterHandler) ImportReadingsCSV(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Failed to parse form: "+err.Error())
+		return
+	}
+
+	file, _, err := r.FormFile("csv_file")
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "CSV file is required")
+		return
+	}
+	defer file.Close()
+
+	recordedBy := r.FormValue("recorded_by")
+	if recordedBy == "" {
+		recordedBy = "system"
+	}
+
+	result, err := h.service.ImportReadingsFromCSV(r.Context(), file, recordedBy)
+	if err != nil {
+		h.logger.Error("Failed to import meter readings", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusBadRequest, "Failed to import meter readings: "+err.Error())
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// IngestGatewayReadings handles POST /equipment/meters/gateway
+func (h *MeterHandler) IngestGatewayReadings(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		GatewayID string                     `json:"gateway_id"`
+		Readings  []domain.MeterReadingInput `json:"readings"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if req.GatewayID == "" {
+		h.respondError(w, http.StatusBadRequest, "gateway_id is required")
+		return
+	}
+	if len(req.Readings) == 0 {
+		h.respondError(w, http.StatusBadRequest, "readings are required")
+		return
+	}
+
+	result := h.service.IngestGatewayReadings(r.Context(), req.Readings, req.GatewayID)
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// respondReadingError maps reading validation errors to HTTP status codes
+func (h *MeterHandler) respondReadingError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEquipmentNotFound):
+		h.respondError(w, http.StatusNotFound, "Equipment not found")
+	case errors.Is(err, domain.ErrMeterDefinitionNotFound):
+		h.respondError(w, http.StatusNotFound, "Meter definition not found")
+	case errors.Is(err, domain.ErrNonMonotonicReading),
+		errors.Is(err, domain.ErrReadingOutOfOrder),
+		errors.Is(err, domain.ErrReadingInFuture),
+		errors.Is(err, domain.ErrImplausibleReading):
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		h.logger.Error("Failed to record meter reading", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusBadRequest, "Failed to record meter reading: "+err.Error())
+	}
+}
+
+// respondJSON writes JSON response
+func (h *MeterHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError writes error response
+func (h *MeterHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/aby-med/medical-platform/internal/middleware"
+	"github.com/aby-med/medical-platform/internal/pkg/orgfilter"
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// QualityHandler handles HTTP requests for installed-base data quality tooling
+type QualityHandler struct {
+	service *app.QualityService
+	logger  *slog.Logger
+}
+
+// NewQualityHandler creates a new data quality HTTP handler
+func NewQualityHandler(service *app.QualityService, logger *slog.Logger) *QualityHandler {
+	return &QualityHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "quality_handler")),
+	}
+}
+
+// ListDuplicates handles GET /equipment/data-quality/duplicates?customer_id=&min_score=&limit=
+func (h *QualityHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := domain.DuplicateOptions{CustomerID: query.Get("customer_id")}
+	opts.MinScore, _ = strconv.ParseFloat(query.Get("min_score"), 64)
+	opts.Limit, _ = strconv.Atoi(query.Get("limit"))
+	if opts.Limit <= 0 {
+		opts.Limit = 100
+	}
+
+	candidates, err := h.service.FindDuplicates(r.Context(), opts)
+	if err != nil {
+		h.logger.Error("Failed to find duplicate equipment", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to find duplicate equipment")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"candidates": candidates,
+		"count":      len(candidates),
+	})
+}
+
+// Merge handles POST /equipment/data-quality/merge
+func (h *QualityHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	var req app.MergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	merge, err := h.service.MergeEquipment(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEquipmentNotFound):
+			h.respondError(w, http.StatusNotFound, "Equipment not found")
+		case errors.Is(err, domain.ErrMergeSameEquipment), errors.Is(err, domain.ErrMergeConflict):
+			h.respondError(w, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error("Failed to merge equipment", slog.String("error", err.Error()))
+			h.respondError(w, http.StatusInternalServerError, "Failed to merge equipment")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, merge)
+}
+
+// ListMerges handles GET /equipment/{id}/merges
+func (h *QualityHandler) ListMerges(w http.ResponseWriter, r *http.Request) {
+	merges, err := h.service.ListMerges(r.Context(), chi.URLParam(r, "id"))
+	if errors.Is(err, domain.ErrEquipmentNotFound) {
+		h.respondError(w, http.StatusNotFound, "Equipment not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to list equipment merges", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list equipment merges")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"merges": merges,
+		"count":  len(merges),
+	})
+}
+
+// Normalize handles POST /equipment/data-quality/normalize?customer_id=&apply=true
+//
+// Changes are only reported unless apply=true. A run must be limited to the
+// caller's organization or, for system admins, to one customer.
+func (h *QualityHandler) Normalize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	customerID := query.Get("customer_id")
+
+	_, hasOrgID := middleware.GetOrganizationID(r.Context())
+	if customerID == "" && (!hasOrgID || orgfilter.IsSystemAdmin(r.Context())) {
+		h.respondError(w, http.StatusBadRequest, "customer_id is required to normalize without an organization scope")
+		return
+	}
+
+	report, err := h.service.Normalize(r.Context(), customerID, query.Get("apply") != "true")
+	if err != nil {
+		h.logger.Error("Failed to normalize equipment", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to normalize equipment")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, report)
+}
+
+// ListManufacturerAliases handles GET /equipment/data-quality/manufacturer-aliases
+func (h *QualityHandler) ListManufacturerAliases(w http.ResponseWriter, r *http.Request) {
+	aliases, err := h.service.ListManufacturerAliases(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list manufacturer aliases", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list manufacturer aliases")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"aliases": aliases,
+		"count":   len(aliases),
+	})
+}
+
+// AddManufacturerAlias handles POST /equipment/data-quality/manufacturer-aliases
+func (h *QualityHandler) AddManufacturerAlias(w http.ResponseWriter, r *http.Request) {
+	var req app.AddAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	alias, err := h.service.AddManufacturerAlias(r.Context(), req)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "Failed to add manufacturer alias: "+err.Error())
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, alias)
+}
+
+// ListQualityScores handles GET /equipment/data-quality/scores for the
+// customers of the caller's organization
+func (h *QualityHandler) ListQualityScores(w http.ResponseWriter, r *http.Request) {
+	scores, err := h.service.ListQualityScores(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to compute quality scores", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to compute quality scores")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"scores": scores,
+		"count":  len(scores),
+	})
+}
+
+// GetQualityScore handles GET /equipment/data-quality/scores/{customer_id}
+func (h *QualityHandler) GetQualityScore(w http.ResponseWriter, r *http.Request) {
+	score, err := h.service.GetQualityScore(r.Context(), chi.URLParam(r, "customer_id"))
+	if err != nil {
+		h.logger.Error("Failed to compute quality score", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to compute quality score")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, score)
+}
+
+// respondJSON writes JSON response
+func (h *QualityHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError writes error response
+func (h *QualityHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
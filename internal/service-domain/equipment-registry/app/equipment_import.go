@@ -0,0 +1,796 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/xlsx"
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// importBatchSize is the number of rows validated against the database and
+// committed together. Each committed batch becomes a resume checkpoint.
+const importBatchSize = 500
+
+// importFields lists the installed-base fields accepted by the importer with
+// the header aliases recognised during auto-detection (normalized form).
+var importFields = map[string][]string{
+	"serial_number":         {"serial", "serial_no", "serialnumber", "sn", "s_n", "equipment_serial", "device_serial"},
+	"equipment_name":        {"name", "equipment", "device_name", "asset_name", "product_name"},
+	"manufacturer_name":     {"manufacturer", "make", "brand", "oem"},
+	"model_number":          {"model", "model_no", "model_name"},
+	"category":              {"equipment_type", "device_type", "modality"},
+	"customer_name":         {"customer", "hospital", "hospital_name", "facility", "facility_name", "client", "site"},
+	"customer_id":           {"hospital_id", "facility_id", "organization_id", "org_id"},
+	"installation_location": {"location", "department", "install_location", "room"},
+	"installation_date":     {"install_date", "installed_on", "date_of_installation", "commissioning_date"},
+	"purchase_date":         {"po_date", "date_of_purchase", "invoice_date"},
+	"purchase_price":        {"price", "cost", "purchase_cost", "invoice_value"},
+	"warranty_months":       {"warranty", "warranty_period", "warranty_months_"},
+	"warranty_expiry":       {"warranty_end", "warranty_end_date", "warranty_expiry_date", "warranty_until"},
+	"equipment_id":          {"catalog_id", "catalog_equipment_id"},
+	"contract_id":           {"procurement_contract_id"},
+	"amc_contract_id":       {"amc", "amc_contract", "amc_id"},
+	"status":                {"equipment_status", "operational_status"},
+	"notes":                 {"remarks", "comments", "note"},
+}
+
+// requiredCreateFields must be present for rows that register new equipment
+var requiredCreateFields = []string{"serial_number", "equipment_name", "manufacturer_name", "customer_name"}
+
+var headerSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// rowReader is implemented by csv.Reader and xlsx.Reader
+type rowReader interface {
+	Read() ([]string, error)
+}
+
+// DetectImportFormat determines the import format from a file name
+func DetectImportFormat(filename string) (domain.ImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv", ".txt", "":
+		return domain.ImportFormatCSV, nil
+	case ".xlsx":
+		return domain.ImportFormatXLSX, nil
+	default:
+		return "", domain.ErrUnsupportedImportFormat
+	}
+}
+
+// ImportEquipment imports installed-base equipment from a CSV or XLSX stream.
+// Rows are matched to existing equipment by serial number; existing equipment
+// is updated in update mode and skipped otherwise. In dry-run mode the full
+// report is produced without writing anything.
+func (s *EquipmentService) ImportEquipment(ctx context.Context, r io.Reader, format domain.ImportFormat, opts domain.ImportOptions) (*domain.ImportReport, error) {
+	reader, err := newRowReader(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	imp, err := s.newEquipmentImporter(reader, format, opts)
+	if err != nil {
+		return nil, err
+	}
+	imp.collectRows = true
+
+	if err := imp.run(ctx); err != nil {
+		return imp.report, err
+	}
+
+	s.logger.Info("Equipment import completed",
+		slog.Bool("dry_run", opts.DryRun),
+		slog.Int("total", imp.report.TotalRows),
+		slog.Int("created", imp.report.CreatedCount),
+		slog.Int("updated", imp.report.UpdatedCount),
+		slog.Int("skipped", imp.report.SkippedCount),
+		slog.Int("failure", imp.report.FailureCount),
+	)
+
+	return imp.report, nil
+}
+
+// BulkImportFromCSV imports equipment from CSV file
+func (s *EquipmentService) BulkImportFromCSV(ctx context.Context, csvFilePath, createdBy string) (*domain.CSVImportResult, error) {
+	file, err := os.Open(csvFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer file.Close()
+
+	report, err := s.ImportEquipment(ctx, file, domain.ImportFormatCSV, domain.ImportOptions{CreatedBy: createdBy})
+	if report == nil {
+		return nil, err
+	}
+
+	result := &domain.CSVImportResult{
+		TotalRows:    report.TotalRows,
+		SuccessCount: report.SuccessCount,
+		FailureCount: report.FailureCount,
+		Errors:       report.Errors,
+		ImportedIDs:  report.ImportedIDs,
+	}
+	return result, err
+}
+
+// equipmentImporter validates rows and writes them in checkpointed batches
+type equipmentImporter struct {
+	svc     *EquipmentService
+	reader  rowReader
+	opts    domain.ImportOptions
+	mapping map[string]int
+	report  *domain.ImportReport
+
+	// seen tracks serial numbers already encountered in the file (serial -> row)
+	seen    map[string]int
+	qrCodes map[string]bool
+	pending []*pendingImportRow
+	batch   []domain.ImportRowResult
+
+	// skipThrough is the last row committed by a previous run of a resumed job
+	skipThrough int
+
+	// collectRows keeps every row result in the report (synchronous imports)
+	collectRows bool
+
+	// onBatch is called after each batch with its last row and row results
+	onBatch func(ctx context.Context, checkpointRow int, rows []domain.ImportRowResult) error
+}
+
+// pendingImportRow is a validated row waiting for its batch to be flushed
+type pendingImportRow struct {
+	result domain.ImportRowResult
+	record *importRecord
+}
+
+func (s *EquipmentService) newEquipmentImporter(reader rowReader, format domain.ImportFormat, opts domain.ImportOptions) (*equipmentImporter, error) {
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("import file is empty")
+		}
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	mapping, err := detectColumnMapping(header, opts.ColumnMapping)
+	if err != nil {
+		return nil, err
+	}
+	if mapping["serial_number"] == -1 {
+		return nil, fmt.Errorf("required column missing: serial_number")
+	}
+
+	report := &domain.ImportReport{
+		DryRun:          opts.DryRun,
+		UpdateMode:      opts.UpdateMode,
+		Format:          format,
+		ColumnMapping:   map[string]string{},
+		UnmappedColumns: []string{},
+		Rows:            []domain.ImportRowResult{},
+		Errors:          []string{},
+		ImportedIDs:     []string{},
+	}
+
+	used := map[int]bool{}
+	for field, idx := range mapping {
+		if idx >= 0 {
+			report.ColumnMapping[field] = strings.TrimSpace(strings.TrimPrefix(header[idx], "\ufeff"))
+			used[idx] = true
+		}
+	}
+	for i, h := range header {
+		if !used[i] && strings.TrimSpace(h) != "" {
+			report.UnmappedColumns = append(report.UnmappedColumns, strings.TrimSpace(h))
+		}
+	}
+
+	return &equipmentImporter{
+		svc:     s,
+		reader:  reader,
+		opts:    opts,
+		mapping: mapping,
+		report:  report,
+		seen:    map[string]int{},
+		qrCodes: map[string]bool{},
+	}, nil
+}
+
+// run reads all rows, validating and flushing them in batches
+func (imp *equipmentImporter) run(ctx context.Context) error {
+	rowNum := 1 // Header is row 1
+	for {
+		record, err := imp.reader.Read()
+		if err == io.EOF {
+			break
+		}
+		rowNum++
+
+		if rowNum <= imp.skipThrough {
+			// Already committed by a previous run; only remember the serial for duplicate checks
+			if err == nil {
+				if serial := cell(record, imp.mapping["serial_number"]); serial != "" {
+					imp.seen[serial] = rowNum
+				}
+			}
+			continue
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			imp.addResult(domain.ImportRowResult{
+				Row:    rowNum,
+				Action: domain.ImportActionError,
+				Errors: []string{fmt.Sprintf("failed to read row: %v", err)},
+			})
+			continue
+		}
+
+		if isBlankRow(record) {
+			continue
+		}
+
+		rec, result := parseImportRow(record, imp.mapping, rowNum)
+		if len(result.Errors) == 0 {
+			if firstRow, dup := imp.seen[rec.serialNumber]; dup {
+				result.Errors = append(result.Errors, fmt.Sprintf("duplicate serial number in file (first seen on row %d)", firstRow))
+			} else {
+				imp.seen[rec.serialNumber] = rowNum
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			result.Action = domain.ImportActionError
+			imp.addResult(result)
+			continue
+		}
+
+		imp.pending = append(imp.pending, &pendingImportRow{result: result, record: rec})
+		if len(imp.pending) >= importBatchSize {
+			if err := imp.flush(ctx, rowNum); err != nil {
+				return err
+			}
+		}
+	}
+
+	return imp.flush(ctx, rowNum)
+}
+
+// addResult records a final row result in the report and the current batch
+func (imp *equipmentImporter) addResult(result domain.ImportRowResult) {
+	imp.report.TotalRows++
+	switch result.Action {
+	case domain.ImportActionCreate:
+		imp.report.ValidRows++
+		imp.report.CreatedCount++
+		imp.report.SuccessCount++
+	case domain.ImportActionUpdate:
+		imp.report.ValidRows++
+		imp.report.UpdatedCount++
+		imp.report.SuccessCount++
+	case domain.ImportActionSkip:
+		imp.report.ValidRows++
+		imp.report.SkippedCount++
+	case domain.ImportActionError:
+		imp.report.FailureCount++
+		for _, msg := range result.Errors {
+			imp.report.Errors = append(imp.report.Errors, fmt.Sprintf("Row %d: %s", result.Row, msg))
+		}
+	}
+	if result.EquipmentID != "" && !imp.opts.DryRun && result.Action != domain.ImportActionSkip {
+		imp.report.ImportedIDs = append(imp.report.ImportedIDs, result.EquipmentID)
+	}
+	if imp.collectRows {
+		imp.report.Rows = append(imp.report.Rows, result)
+	}
+	imp.batch = append(imp.batch, result)
+}
+
+// flush resolves pending rows against existing equipment and writes the batch
+func (imp *equipmentImporter) flush(ctx context.Context, lastRow int) error {
+	if len(imp.pending) > 0 {
+		serials := make([]string, 0, len(imp.pending))
+		for _, p := range imp.pending {
+			serials = append(serials, p.record.serialNumber)
+		}
+
+		existing, err := imp.svc.repo.GetBySerialNumbers(ctx, imp.opts.Scope, serials)
+		if err != nil {
+			return fmt.Errorf("failed to look up existing equipment: %w", err)
+		}
+
+		creates := []*domain.Equipment{}
+		updates := []*domain.Equipment{}
+		results := make([]domain.ImportRowResult, 0, len(imp.pending))
+
+		for _, p := range imp.pending {
+			result := p.result
+			if current, ok := existing[p.record.serialNumber]; ok {
+				if current == nil {
+					result.Action = domain.ImportActionError
+					result.Errors = append(result.Errors, "serial number is registered to another organization")
+					results = append(results, result)
+					continue
+				}
+				result.EquipmentID = current.ID
+				if !imp.opts.UpdateMode {
+					result.Action = domain.ImportActionSkip
+					result.Warnings = append(result.Warnings, "equipment already registered with this serial number (use update_mode=true to update)")
+				} else {
+					result.Action = domain.ImportActionUpdate
+					result.Warnings = append(result.Warnings, p.record.applyTo(current)...)
+					updates = append(updates, current)
+				}
+				results = append(results, result)
+				continue
+			}
+
+			if missing := p.record.missingFields(requiredCreateFields); len(missing) > 0 {
+				result.Action = domain.ImportActionError
+				result.Errors = append(result.Errors, fmt.Sprintf("required for new equipment: %s", strings.Join(missing, ", ")))
+				results = append(results, result)
+				continue
+			}
+
+			equipment := imp.newEquipment(p.record)
+			result.Warnings = append(result.Warnings, p.record.applyTo(equipment)...)
+			result.Action = domain.ImportActionCreate
+			result.EquipmentID = equipment.ID
+			creates = append(creates, equipment)
+			results = append(results, result)
+		}
+
+		if !imp.opts.DryRun && (len(creates) > 0 || len(updates) > 0) {
+			for _, equipment := range append(creates, updates...) {
+				imp.svc.geocode(equipment)
+			}
+			if err := imp.svc.repo.BulkUpsert(ctx, imp.opts.Scope, creates, updates); err != nil {
+				return fmt.Errorf("failed to write rows up to %d: %w", lastRow, err)
+			}
+		}
+
+		for _, result := range results {
+			if imp.opts.DryRun && result.Action == domain.ImportActionCreate {
+				result.EquipmentID = "" // Nothing was registered
+			}
+			imp.addResult(result)
+		}
+		imp.pending = nil
+	}
+
+	batch := imp.batch
+	imp.batch = nil
+	if imp.onBatch != nil {
+		return imp.onBatch(ctx, lastRow, batch)
+	}
+	return nil
+}
+
+// newEquipment builds a new equipment registration for a validated row
+func (imp *equipmentImporter) newEquipment(rec *importRecord) *domain.Equipment {
+	equipment := domain.NewEquipment(
+		rec.serialNumber,
+		rec.values["equipment_name"],
+		rec.values["manufacturer_name"],
+		rec.values["model_number"],
+		rec.values["customer_name"],
+		imp.opts.CreatedBy,
+	)
+
+	equipment.ID = ksuid.New().String()
+	equipment.QRCodeURL = fmt.Sprintf("%s/equipment/%s", imp.svc.baseURL, equipment.ID)
+
+	// generateQRCodeID is time based; guard against collisions within one import
+	qrCode := imp.svc.generateQRCodeID()
+	for imp.qrCodes[qrCode] {
+		qrCode = imp.svc.generateQRCodeID()
+	}
+	imp.qrCodes[qrCode] = true
+	equipment.QRCode = qrCode
+
+	return equipment
+}
+
+// importRecord holds the parsed, non-empty values of one row
+type importRecord struct {
+	serialNumber     string
+	values           map[string]string
+	installationDate *time.Time
+	purchaseDate     *time.Time
+	warrantyExpiry   *time.Time
+	warrantyMonths   int
+	purchasePrice    *float64
+	status           domain.EquipmentStatus
+}
+
+// parseImportRow parses and validates a row independently of existing data
+func parseImportRow(record []string, mapping map[string]int, rowNum int) (*importRecord, domain.ImportRowResult) {
+	rec := &importRecord{values: map[string]string{}}
+	result := domain.ImportRowResult{Row: rowNum}
+
+	for field, idx := range mapping {
+		if v := cell(record, idx); v != "" {
+			rec.values[field] = v
+		}
+	}
+
+	rec.serialNumber = rec.values["serial_number"]
+	result.SerialNumber = rec.serialNumber
+	if rec.serialNumber == "" {
+		result.Errors = append(result.Errors, "serial_number is required")
+	}
+
+	parseDateField := func(field string) *time.Time {
+		v, ok := rec.values[field]
+		if !ok {
+			return nil
+		}
+		t, err := parseImportDate(v)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", field, err))
+			return nil
+		}
+		return &t
+	}
+	rec.installationDate = parseDateField("installation_date")
+	rec.purchaseDate = parseDateField("purchase_date")
+	rec.warrantyExpiry = parseDateField("warranty_expiry")
+
+	if v, ok := rec.values["purchase_price"]; ok {
+		price, err := parseImportPrice(v)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("purchase_price: %v", err))
+		} else {
+			rec.purchasePrice = &price
+		}
+	}
+
+	if v, ok := rec.values["warranty_months"]; ok {
+		months, err := parseWarrantyMonths(v)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("warranty_months: %v", err))
+		} else {
+			rec.warrantyMonths = months
+		}
+	}
+
+	if v, ok := rec.values["status"]; ok {
+		status, err := parseImportStatus(v)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("status: %v", err))
+		} else {
+			rec.status = status
+		}
+	}
+
+	// Consistency checks that do not block the import
+	now := time.Now()
+	if rec.installationDate != nil && rec.installationDate.After(now) {
+		result.Warnings = append(result.Warnings, "installation_date is in the future")
+	}
+	if rec.installationDate != nil && rec.purchaseDate != nil && rec.installationDate.Before(*rec.purchaseDate) {
+		result.Warnings = append(result.Warnings, "installation_date is before purchase_date")
+	}
+	if rec.warrantyExpiry != nil && rec.warrantyMonths > 0 {
+		result.Warnings = append(result.Warnings, "both warranty_expiry and warranty_months given; using warranty_expiry")
+	}
+
+	return rec, result
+}
+
+// missingFields returns the fields without a value
+func (rec *importRecord) missingFields(fields []string) []string {
+	missing := []string{}
+	for _, f := range fields {
+		if rec.values[f] == "" {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// applyTo copies the row's non-empty values onto equipment, so updates never
+// blank out fields the file leaves empty. It returns any warnings.
+func (rec *importRecord) applyTo(e *domain.Equipment) []string {
+	warnings := []string{}
+	set := func(field string, dst *string) {
+		if v, ok := rec.values[field]; ok {
+			*dst = v
+		}
+	}
+
+	set("equipment_id", &e.EquipmentID)
+	set("equipment_name", &e.EquipmentName)
+	set("manufacturer_name", &e.ManufacturerName)
+	set("model_number", &e.ModelNumber)
+	set("category", &e.Category)
+	set("customer_id", &e.CustomerID)
+	set("customer_name", &e.CustomerName)
+	set("installation_location", &e.InstallationLocation)
+	set("contract_id", &e.ContractID)
+	set("amc_contract_id", &e.AMCContractID)
+	set("notes", &e.Notes)
+
+	if rec.installationDate != nil {
+		e.InstallationDate = rec.installationDate
+	}
+	if rec.purchaseDate != nil {
+		e.PurchaseDate = rec.purchaseDate
+	}
+	if rec.purchasePrice != nil {
+		e.PurchasePrice = *rec.purchasePrice
+	}
+	if rec.status != "" {
+		e.Status = rec.status
+	}
+
+	// Calculate warranty expiry
+	switch {
+	case rec.warrantyExpiry != nil:
+		e.WarrantyExpiry = rec.warrantyExpiry
+	case rec.warrantyMonths > 0:
+		var start time.Time
+		switch {
+		case e.PurchaseDate != nil:
+			start = *e.PurchaseDate
+		case e.InstallationDate != nil:
+			start = *e.InstallationDate
+		default:
+			start = time.Now()
+			warnings = append(warnings, "warranty_months counted from today: no purchase or installation date")
+		}
+		expiry := start.AddDate(0, rec.warrantyMonths, 0)
+		e.WarrantyExpiry = &expiry
+	}
+
+	return warnings
+}
+
+// detectColumnMapping maps file columns to importer fields. Explicit overrides
+// (field -> header) take precedence over exact and alias matches.
+func detectColumnMapping(headers []string, overrides map[string]string) (map[string]int, error) {
+	mapping := make(map[string]int, len(importFields))
+	for field := range importFields {
+		mapping[field] = -1
+	}
+
+	normalized := make([]string, len(headers))
+	for i, h := range headers {
+		normalized[i] = normalizeHeader(h)
+	}
+
+	assigned := map[int]bool{}
+	for field, header := range overrides {
+		if _, ok := importFields[field]; !ok {
+			return nil, fmt.Errorf("column_mapping: unknown field %q", field)
+		}
+		want := normalizeHeader(header)
+		found := false
+		for i, n := range normalized {
+			if n == want {
+				mapping[field] = i
+				assigned[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("column_mapping: column %q for field %q not found in file", header, field)
+		}
+	}
+
+	// Exact matches first so an alias never steals a column named after another field
+	for i, n := range normalized {
+		if assigned[i] {
+			continue
+		}
+		if idx, ok := mapping[n]; ok && idx == -1 {
+			mapping[n] = i
+			assigned[i] = true
+		}
+	}
+
+	// Sort fields for deterministic alias resolution
+	fields := make([]string, 0, len(importFields))
+	for field := range importFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for i, n := range normalized {
+		if assigned[i] || n == "" {
+			continue
+		}
+		for _, field := range fields {
+			if mapping[field] != -1 {
+				continue
+			}
+			if containsString(importFields[field], n) {
+				mapping[field] = i
+				assigned[i] = true
+				break
+			}
+		}
+	}
+
+	return mapping, nil
+}
+
+// normalizeHeader lowercases a header and collapses separators to underscores
+func normalizeHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	return strings.Trim(headerSeparators.ReplaceAllString(h, "_"), "_")
+}
+
+// importDateLayouts lists accepted date formats; day-first formats are assumed
+// for slash and dash separated dates
+var importDateLayouts = []string{
+	"2006-01-02",
+	"02-01-2006",
+	"02/01/2006",
+	"2/1/2006",
+	"2006/01/02",
+	"02.01.2006",
+	"02-Jan-2006",
+	"2-Jan-2006",
+	"02 Jan 2006",
+	"Jan 2, 2006",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+}
+
+// parseImportDate parses a date cell, including Excel serial dates
+func parseImportDate(v string) (time.Time, error) {
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+
+	// Excel stores dates as days since 1899-12-30
+	if serial, err := strconv.ParseFloat(v, 64); err == nil && serial > 1 && serial < 2958466 {
+		base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+		return base.AddDate(0, 0, int(math.Floor(serial))), nil
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognised date %q (use YYYY-MM-DD)", v)
+}
+
+// parseImportPrice parses a price cell, tolerating currency symbols and thousands separators
+func parseImportPrice(v string) (float64, error) {
+	cleaned := strings.NewReplacer(",", "", " ", "", "₹", "", "$", "", "€", "", "INR", "", "USD", "", "Rs.", "", "Rs", "").Replace(v)
+	price, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", v)
+	}
+	if price < 0 {
+		return 0, fmt.Errorf("cannot be negative")
+	}
+	return price, nil
+}
+
+// parseWarrantyMonths parses a warranty period in months, accepting "24", "24 months" or "2 years"
+func parseWarrantyMonths(v string) (int, error) {
+	fields := strings.Fields(strings.ToLower(v))
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid warranty period %q", v)
+	}
+	if len(fields) > 1 && strings.HasPrefix(fields[1], "year") {
+		n *= 12
+	}
+	return int(math.Round(n)), nil
+}
+
+// parseImportStatus maps common status spellings to equipment statuses
+func parseImportStatus(v string) (domain.EquipmentStatus, error) {
+	switch normalizeHeader(v) {
+	case "operational", "active", "working", "in_service":
+		return domain.StatusOperational, nil
+	case "down", "not_working", "broken", "breakdown":
+		return domain.StatusDown, nil
+	case "under_maintenance", "maintenance", "in_repair", "under_repair":
+		return domain.StatusUnderMaintenance, nil
+	case "decommissioned", "retired", "disposed":
+		return domain.StatusDecommissioned, nil
+	}
+	return "", fmt.Errorf("unknown status %q", v)
+}
+
+// newRowReader creates a row reader for the given format. CSV delimiters
+// (comma, semicolon or tab) are detected from the header line.
+func newRowReader(r io.Reader, format domain.ImportFormat) (rowReader, error) {
+	switch format {
+	case domain.ImportFormatXLSX:
+		ra, size, err := readerAt(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read xlsx file: %w", err)
+		}
+		return xlsx.NewReader(ra, size)
+	case domain.ImportFormatCSV, "":
+		br := bufio.NewReader(r)
+		peek, _ := br.Peek(4096)
+		if i := bytes.IndexByte(peek, '\n'); i >= 0 {
+			peek = peek[:i]
+		}
+
+		reader := csv.NewReader(br)
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		reader.TrimLeadingSpace = true
+		if bytes.Count(peek, []byte(";")) > bytes.Count(peek, []byte(",")) {
+			reader.Comma = ';'
+		} else if bytes.Count(peek, []byte("\t")) > bytes.Count(peek, []byte(",")) {
+			reader.Comma = '\t'
+		}
+		return reader, nil
+	default:
+		return nil, domain.ErrUnsupportedImportFormat
+	}
+}
+
+// readerAt adapts r for random access, reading it into memory only when needed
+func readerAt(r io.Reader) (io.ReaderAt, int64, error) {
+	if ra, ok := r.(interface {
+		io.ReaderAt
+		io.Seeker
+	}); ok {
+		size, err := ra.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, 0, err
+		}
+		if _, err := ra.Seek(0, io.SeekStart); err != nil {
+			return nil, 0, err
+		}
+		return ra, size, nil
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bytes.NewReader(data), int64(len(data)), nil
+}
+
+func cell(record []string, idx int) string {
+	if idx < 0 || idx >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(record[idx], "\ufeff"))
+}
+
+func isBlankRow(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
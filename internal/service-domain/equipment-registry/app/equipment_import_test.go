@@ -0,0 +1,75 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+)
+
+func TestDetectColumnMapping(t *testing.T) {
+	headers := []string{"\ufeffSerial No.", "Hospital", "Make", "Equipment Name", "Install Date", "Remarks", "Floor"}
+
+	mapping, err := detectColumnMapping(headers, map[string]string{"installation_location": "Floor"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]int{
+		"serial_number":         0,
+		"customer_name":         1,
+		"manufacturer_name":     2,
+		"equipment_name":        3,
+		"installation_date":     4,
+		"notes":                 5,
+		"installation_location": 6,
+		"model_number":          -1,
+	}
+	for field, idx := range want {
+		if mapping[field] != idx {
+			t.Errorf("%s: got column %d, want %d", field, mapping[field], idx)
+		}
+	}
+
+	if _, err := detectColumnMapping(headers, map[string]string{"serial_number": "Missing"}); err == nil {
+		t.Error("expected error for override pointing at a missing column")
+	}
+}
+
+func TestParseImportRow(t *testing.T) {
+	mapping, _ := detectColumnMapping(
+		[]string{"serial_number", "purchase_date", "purchase_price", "warranty_months", "status"}, nil)
+
+	rec, result := parseImportRow([]string{"SN-1", "15/03/2024", "₹ 12,50,000", "2 years", "Active"}, mapping, 2)
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if rec.purchaseDate == nil || !rec.purchaseDate.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected purchase date: %v", rec.purchaseDate)
+	}
+	if rec.purchasePrice == nil || *rec.purchasePrice != 1250000 {
+		t.Errorf("unexpected purchase price: %v", rec.purchasePrice)
+	}
+	if rec.warrantyMonths != 24 || rec.status != domain.StatusOperational {
+		t.Errorf("unexpected warranty/status: %d %s", rec.warrantyMonths, rec.status)
+	}
+
+	equipment := &domain.Equipment{SerialNumber: "SN-1", EquipmentName: "CT Scanner"}
+	rec.applyTo(equipment)
+	if equipment.EquipmentName != "CT Scanner" {
+		t.Error("empty cells must not overwrite existing values")
+	}
+	if equipment.WarrantyExpiry == nil || equipment.WarrantyExpiry.Year() != 2026 {
+		t.Errorf("unexpected warranty expiry: %v", equipment.WarrantyExpiry)
+	}
+
+	_, result = parseImportRow([]string{"SN-2", "2024-13-45", "-10", "", "melted"}, mapping, 3)
+	if len(result.Errors) != 3 {
+		t.Errorf("expected 3 errors, got %v", result.Errors)
+	}
+
+	// Excel serial date
+	if d, err := parseImportDate("45366"); err != nil || d.Format("2006-01-02") != "2024-03-15" {
+		t.Errorf("unexpected excel date: %v %v", d, err)
+	}
+}
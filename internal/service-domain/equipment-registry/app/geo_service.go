@@ -0,0 +1,195 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+)
+
+// Geocoder resolves installation coordinates of equipment before it is saved
+type Geocoder interface {
+	// GeocodeEquipment sets coordinates from the installation address; it reports whether they changed
+	GeocodeEquipment(equipment *domain.Equipment) bool
+}
+
+// GeoService geocodes installation addresses offline against an imported
+// postal code gazetteer. The gazetteer is held in memory and reloaded on import.
+type GeoService struct {
+	geoRepo domain.GeoRepository
+	logger  *slog.Logger
+
+	mu        sync.RWMutex
+	gazetteer *geo.Gazetteer
+}
+
+// NewGeoService creates a new geocoding service
+func NewGeoService(geoRepo domain.GeoRepository, logger *slog.Logger) *GeoService {
+	return &GeoService{
+		geoRepo: geoRepo,
+		logger:  logger.With(slog.String("component", "geo_service")),
+	}
+}
+
+// Load reads the gazetteer from the database into memory
+func (s *GeoService) Load(ctx context.Context) error {
+	places, err := s.geoRepo.ListPostalPlaces(ctx)
+	if err != nil {
+		return err
+	}
+	gazetteer := geo.NewGazetteer(places)
+
+	s.mu.Lock()
+	s.gazetteer = gazetteer
+	s.mu.Unlock()
+
+	s.logger.Info("Gazetteer loaded", slog.Int("postal_codes", gazetteer.Len()))
+	return nil
+}
+
+// Gazetteer returns the in-memory gazetteer
+func (s *GeoService) Gazetteer() *geo.Gazetteer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.gazetteer
+}
+
+// GazetteerImportResult summarizes a gazetteer import
+type GazetteerImportResult struct {
+	Parsed      int `json:"parsed"`
+	Imported    int `json:"imported"`
+	PostalCodes int `json:"postal_codes"` // Distinct postal codes available after the import
+}
+
+// ImportGazetteer imports a GeoNames or CSV postal code file and reloads the gazetteer
+func (s *GeoService) ImportGazetteer(ctx context.Context, r io.Reader, defaultCountry string) (*GazetteerImportResult, error) {
+	places, err := geo.ParsePostalPlaces(r, defaultCountry)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidGazetteer, err)
+	}
+
+	for _, p := range places {
+		if len(p.Country) != 2 {
+			return nil, fmt.Errorf("%w: postal code %s has no country (pass a default country)", domain.ErrInvalidGazetteer, p.PostalCode)
+		}
+	}
+
+	imported, err := s.geoRepo.ImportPostalPlaces(ctx, places)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Load(ctx); err != nil {
+		return nil, err
+	}
+
+	return &GazetteerImportResult{
+		Parsed:      len(places),
+		Imported:    imported,
+		PostalCodes: s.Gazetteer().Len(),
+	}, nil
+}
+
+// GeocodeEquipment sets installation coordinates from the postal code of the
+// installation address. Manually entered coordinates are never overwritten.
+func (s *GeoService) GeocodeEquipment(equipment *domain.Equipment) bool {
+	if equipment == nil || equipment.HasManualCoordinates() {
+		return false
+	}
+	place, ok := s.Gazetteer().GeocodeAddress(equipment.InstallationAddress, equipment.InstallationLocation)
+	if !ok {
+		return false
+	}
+	if current, has := equipment.InstallationPoint(); has && current == place.Point {
+		return false
+	}
+	equipment.SetInstallationPoint(place.Point, domain.GeocodeSourceGazetteer, place.PostalCode)
+	return true
+}
+
+// GeocodeBackfillResult summarizes a geocoding run over the installed base
+type GeocodeBackfillResult struct {
+	DryRun     bool     `json:"dry_run"`
+	Scanned    int      `json:"scanned"`
+	Geocoded   int      `json:"geocoded"`
+	Unresolved int      `json:"unresolved"`
+	Manual     int      `json:"manual"`              // Skipped because coordinates were entered manually
+	Unmatched  []string `json:"unmatched,omitempty"` // Serial numbers without a resolvable postal code
+}
+
+// maxUnmatchedReported bounds the serial numbers listed in a backfill result
+const maxUnmatchedReported = 100
+
+// BackfillCoordinates geocodes installations. Without overwrite only
+// installations that have no coordinates yet are processed.
+func (s *GeoService) BackfillCoordinates(ctx context.Context, overwrite, dryRun bool) (*GeocodeBackfillResult, error) {
+	if s.Gazetteer().Len() == 0 {
+		return nil, domain.ErrGazetteerEmpty
+	}
+
+	installations, err := s.geoRepo.ListInstallations(ctx, !overwrite)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GeocodeBackfillResult{DryRun: dryRun, Scanned: len(installations)}
+	for _, inst := range installations {
+		equipment := &domain.Equipment{
+			ID:                   inst.ID,
+			InstallationLocation: inst.InstallationLocation,
+			InstallationAddress:  inst.InstallationAddress,
+		}
+		if equipment.HasManualCoordinates() {
+			result.Manual++
+			continue
+		}
+		_, hadPoint := equipment.InstallationPoint()
+		if !s.GeocodeEquipment(equipment) {
+			if !hadPoint {
+				result.Unresolved++
+				if len(result.Unmatched) < maxUnmatchedReported {
+					result.Unmatched = append(result.Unmatched, inst.SerialNumber)
+				}
+			}
+			continue
+		}
+
+		result.Geocoded++
+		if dryRun {
+			continue
+		}
+		if err := s.geoRepo.UpdateInstallationAddress(ctx, inst.ID, equipment.InstallationAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("Installation geocoding completed",
+		slog.Bool("dry_run", dryRun),
+		slog.Int("scanned", result.Scanned),
+		slog.Int("geocoded", result.Geocoded),
+		slog.Int("unresolved", result.Unresolved),
+	)
+	return result, nil
+}
+
+// SetCoordinates stores manually entered installation coordinates
+func (s *GeoService) SetCoordinates(ctx context.Context, equipment *domain.Equipment, point geo.Point) error {
+	if !point.Valid() {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidCoordinates, point)
+	}
+	equipment.SetInstallationPoint(point, domain.GeocodeSourceManual, "")
+	return s.geoRepo.UpdateInstallationAddress(ctx, equipment.ID, equipment.InstallationAddress)
+}
+
+// ClearCoordinates removes manually entered coordinates and re-geocodes from the postal code
+func (s *GeoService) ClearCoordinates(ctx context.Context, equipment *domain.Equipment) error {
+	for _, key := range []string{domain.AddressLatitudeKey, domain.AddressLongitudeKey, "lat", "lng", "lon",
+		domain.AddressGeocodeSourceKey, domain.AddressGeocodedPostalKey} {
+		delete(equipment.InstallationAddress, key)
+	}
+	s.GeocodeEquipment(equipment)
+	return s.geoRepo.UpdateInstallationAddress(ctx, equipment.ID, equipment.InstallationAddress)
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// ImportJobService runs large installed-base imports in the background.
+// Uploaded files are kept on disk and progress is checkpointed per batch, so a
+// job interrupted by a restart or a database error resumes where it stopped.
+type ImportJobService struct {
+	jobs       domain.ImportJobRepository
+	equipment  *EquipmentService
+	storageDir string
+	logger     *slog.Logger
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// NewImportJobService creates a new import job service
+func NewImportJobService(jobs domain.ImportJobRepository, equipment *EquipmentService, storageDir string, logger *slog.Logger) *ImportJobService {
+	if storageDir == "" {
+		storageDir = filepath.Join(os.TempDir(), "equipment-imports")
+	}
+	return &ImportJobService{
+		jobs:       jobs,
+		equipment:  equipment,
+		storageDir: storageDir,
+		logger:     logger.With(slog.String("component", "equipment_import_jobs")),
+		running:    map[string]bool{},
+	}
+}
+
+// StartJob stores the uploaded file and starts importing it in the background
+func (s *ImportJobService) StartJob(ctx context.Context, r io.Reader, filename string, opts domain.ImportOptions) (*domain.ImportJob, error) {
+	format, err := DetectImportFormat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(s.storageDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create import directory: %w", err)
+	}
+
+	id := ksuid.New().String()
+	path := filepath.Join(s.storageDir, id+"."+string(format))
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save import file: %w", err)
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to save import file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to save import file: %w", err)
+	}
+
+	now := time.Now()
+	job := &domain.ImportJob{
+		ID:        id,
+		Status:    domain.ImportJobPending,
+		FileName:  filepath.Base(filename),
+		Format:    format,
+		FilePath:  path,
+		Options:   opts,
+		CreatedBy: opts.CreatedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.jobs.CreateJob(ctx, job); err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	s.launch(job)
+
+	s.logger.Info("Equipment import job started",
+		slog.String("job_id", job.ID),
+		slog.String("file", job.FileName),
+		slog.Bool("dry_run", opts.DryRun),
+	)
+
+	return job, nil
+}
+
+// ResumeJob restarts an interrupted or failed job from its last checkpoint
+func (s *ImportJobService) ResumeJob(ctx context.Context, id string) (*domain.ImportJob, error) {
+	job, err := s.jobs.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !job.CanResume() {
+		return nil, domain.ErrImportJobNotResumable
+	}
+
+	s.launch(job)
+	return job, nil
+}
+
+// ResumeInterrupted resumes jobs that were pending or running when the process stopped
+func (s *ImportJobService) ResumeInterrupted(ctx context.Context) error {
+	for _, status := range []domain.ImportJobStatus{domain.ImportJobRunning, domain.ImportJobPending} {
+		jobs, err := s.jobs.ListJobsByStatus(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			s.logger.Info("Resuming equipment import job",
+				slog.String("job_id", job.ID),
+				slog.Int("checkpoint_row", job.CheckpointRow),
+			)
+			s.launch(job)
+		}
+	}
+	return nil
+}
+
+// GetJob retrieves an import job of the caller's organization
+func (s *ImportJobService) GetJob(ctx context.Context, id string) (*domain.ImportJob, error) {
+	return s.jobs.GetJob(ctx, id)
+}
+
+// ListJobs retrieves recent import jobs of the caller's organization
+func (s *ImportJobService) ListJobs(ctx context.Context, limit int) ([]*domain.ImportJob, error) {
+	return s.jobs.ListJobs(ctx, limit)
+}
+
+// ListJobRows retrieves the stored row results (errors, warnings and skips) of a job
+func (s *ImportJobService) ListJobRows(ctx context.Context, id string, action domain.ImportRowAction, limit, offset int) ([]domain.ImportRowResult, error) {
+	if _, err := s.jobs.GetJob(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.jobs.ListRowResults(ctx, id, action, limit, offset)
+}
+
+// launch runs a job in the background unless it is already running in this process
+func (s *ImportJobService) launch(job *domain.ImportJob) {
+	s.mu.Lock()
+	if s.running[job.ID] {
+		s.mu.Unlock()
+		return
+	}
+	s.running[job.ID] = true
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, job.ID)
+			s.mu.Unlock()
+		}()
+		s.process(context.Background(), job)
+	}()
+}
+
+// process imports the job file, skipping rows committed by earlier runs
+func (s *ImportJobService) process(ctx context.Context, job *domain.ImportJob) {
+	now := time.Now()
+	job.Status = domain.ImportJobRunning
+	job.Error = ""
+	if job.StartedAt == nil {
+		job.StartedAt = &now
+	}
+	if err := s.jobs.UpdateJob(ctx, job); err != nil {
+		s.logger.Error("Failed to mark import job running", slog.String("job_id", job.ID), slog.String("error", err.Error()))
+		return
+	}
+
+	err := s.run(ctx, job)
+
+	completedAt := time.Now()
+	job.CompletedAt = &completedAt
+	if err != nil {
+		job.Status = domain.ImportJobFailed
+		job.Error = err.Error()
+		s.logger.Error("Equipment import job failed",
+			slog.String("job_id", job.ID),
+			slog.Int("checkpoint_row", job.CheckpointRow),
+			slog.String("error", err.Error()),
+		)
+	} else {
+		job.Status = domain.ImportJobCompleted
+		s.logger.Info("Equipment import job completed",
+			slog.String("job_id", job.ID),
+			slog.Int("total", job.TotalRows),
+			slog.Int("created", job.CreatedCount),
+			slog.Int("updated", job.UpdatedCount),
+			slog.Int("skipped", job.SkippedCount),
+			slog.Int("failure", job.FailureCount),
+		)
+	}
+
+	if err := s.jobs.UpdateJob(ctx, job); err != nil {
+		s.logger.Error("Failed to update import job", slog.String("job_id", job.ID), slog.String("error", err.Error()))
+	}
+}
+
+func (s *ImportJobService) run(ctx context.Context, job *domain.ImportJob) error {
+	file, err := os.Open(job.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := newRowReader(file, job.Format)
+	if err != nil {
+		return err
+	}
+
+	imp, err := s.equipment.newEquipmentImporter(reader, job.Format, job.Options)
+	if err != nil {
+		return err
+	}
+	imp.skipThrough = job.CheckpointRow
+
+	// Counters restored from earlier runs; the importer only counts rows after the checkpoint
+	base := *job
+	imp.onBatch = func(ctx context.Context, checkpointRow int, rows []domain.ImportRowResult) error {
+		stored := make([]domain.ImportRowResult, 0, len(rows))
+		for _, row := range rows {
+			if row.Action == domain.ImportActionError || row.Action == domain.ImportActionSkip || len(row.Warnings) > 0 {
+				stored = append(stored, row)
+			}
+		}
+		if err := s.jobs.AddRowResults(ctx, job.ID, stored); err != nil {
+			return err
+		}
+
+		job.CheckpointRow = checkpointRow
+		job.TotalRows = base.TotalRows + imp.report.TotalRows
+		job.CreatedCount = base.CreatedCount + imp.report.CreatedCount
+		job.UpdatedCount = base.UpdatedCount + imp.report.UpdatedCount
+		job.SkippedCount = base.SkippedCount + imp.report.SkippedCount
+		job.FailureCount = base.FailureCount + imp.report.FailureCount
+		return s.jobs.UpdateJob(ctx, job)
+	}
+
+	return imp.run(ctx)
+}
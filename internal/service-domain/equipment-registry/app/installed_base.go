@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// ErrContractConflict is returned when a serial number is already registered
+// under a different contract
+var ErrContractConflict = errors.New("serial number is registered under another contract")
+
+// InstalledUnit is a unit delivered to a customer under a contract, as
+// reported by contract deliveries and purchase order receipts
+type InstalledUnit struct {
+	SerialNumber         string
+	EquipmentID          string // Catalog equipment ID
+	EquipmentName        string
+	ManufacturerName     string
+	ModelNumber          string
+	CustomerID           string
+	CustomerName         string
+	InstallationLocation string
+	ContractID           string
+	PurchaseDate         time.Time
+	PurchasePrice        float64
+	WarrantyExpiry       *time.Time
+	Notes                string
+	CreatedBy            string
+}
+
+// InstalledBaseRegistrar adds delivered units to the equipment registry
+type InstalledBaseRegistrar struct {
+	repo   domain.Repository
+	logger *slog.Logger
+}
+
+// NewInstalledBaseRegistrar creates a registrar for delivered units
+func NewInstalledBaseRegistrar(repo domain.Repository, logger *slog.Logger) *InstalledBaseRegistrar {
+	return &InstalledBaseRegistrar{
+		repo:   repo,
+		logger: logger.With(slog.String("component", "installed_base_registrar")),
+	}
+}
+
+// Register creates an equipment record for the unit with its contract set
+// and a QR code issued the same way as at registration. A unit already
+// registered under its serial number is linked to the contract instead,
+// which also makes retries safe. It returns the equipment ID.
+func (r *InstalledBaseRegistrar) Register(ctx context.Context, unit InstalledUnit) (string, error) {
+	serial := strings.TrimSpace(unit.SerialNumber)
+
+	existing, err := r.repo.GetBySerialNumber(ctx, serial)
+	switch {
+	case err == nil:
+		if existing.ContractID != "" && existing.ContractID != unit.ContractID {
+			return "", fmt.Errorf("%w: serial number %s is registered under contract %s",
+				ErrContractConflict, serial, existing.ContractID)
+		}
+		existing.ContractID = unit.ContractID
+		existing.UpdatedAt = time.Now()
+		if err := r.repo.Update(ctx, existing); err != nil {
+			return "", fmt.Errorf("failed to link equipment %s: %w", existing.ID, err)
+		}
+		return existing.ID, nil
+	case !errors.Is(err, domain.ErrEquipmentNotFound):
+		return "", fmt.Errorf("failed to look up equipment %s: %w", serial, err)
+	}
+
+	purchaseDate := unit.PurchaseDate
+	equipment := domain.NewEquipment(serial, unit.EquipmentName, unit.ManufacturerName, unit.ModelNumber, unit.CustomerName, unit.CreatedBy)
+	equipment.ID = ksuid.New().String()
+	equipment.QRCode = newQRCodeID()
+	equipment.EquipmentID = unit.EquipmentID
+	equipment.CustomerID = unit.CustomerID
+	equipment.InstallationLocation = unit.InstallationLocation
+	equipment.ContractID = unit.ContractID
+	equipment.PurchaseDate = &purchaseDate
+	equipment.PurchasePrice = unit.PurchasePrice
+	equipment.WarrantyExpiry = unit.WarrantyExpiry
+	equipment.Notes = unit.Notes
+
+	if err := r.repo.Create(ctx, equipment); err != nil {
+		return "", fmt.Errorf("failed to register equipment %s: %w", serial, err)
+	}
+	return equipment.ID, nil
+}
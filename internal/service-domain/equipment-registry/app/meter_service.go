@@ -0,0 +1,462 @@
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// MeterService handles meter definitions, reading ingestion and usage snapshots
+type MeterService struct {
+	meterRepo     domain.MeterRepository
+	equipmentRepo domain.Repository
+	logger        *slog.Logger
+}
+
+// NewMeterService creates a new meter service
+func NewMeterService(meterRepo domain.MeterRepository, equipmentRepo domain.Repository, logger *slog.Logger) *MeterService {
+	return &MeterService{
+		meterRepo:     meterRepo,
+		equipmentRepo: equipmentRepo,
+		logger:        logger.With(slog.String("component", "meter_service")),
+	}
+}
+
+// DefineMeterRequest represents a request to define a meter for a catalog model
+type DefineMeterRequest struct {
+	CatalogEquipmentID string           `json:"catalog_equipment_id"`
+	Code               string           `json:"code"`
+	Name               string           `json:"name"`
+	MeterType          domain.MeterType `json:"meter_type"`
+	Unit               string           `json:"unit"`
+	RolloverAt         *float64         `json:"rollover_at,omitempty"`
+	MaxDailyIncrease   float64          `json:"max_daily_increase,omitempty"`
+	ServiceInterval    float64          `json:"service_interval,omitempty"`
+}
+
+// DefineMeter creates a meter definition for a catalog model
+func (s *MeterService) DefineMeter(ctx context.Context, req DefineMeterRequest) (*domain.MeterDefinition, error) {
+	now := time.Now()
+	def := &domain.MeterDefinition{
+		ID:                 ksuid.New().String(),
+		CatalogEquipmentID: req.CatalogEquipmentID,
+		Code:               strings.ToLower(strings.TrimSpace(req.Code)),
+		Name:               req.Name,
+		MeterType:          req.MeterType,
+		Unit:               req.Unit,
+		RolloverAt:         req.RolloverAt,
+		MaxDailyIncrease:   req.MaxDailyIncrease,
+		ServiceInterval:    req.ServiceInterval,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	if def.Name == "" {
+		def.Name = def.Code
+	}
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.meterRepo.CreateDefinition(ctx, def); err != nil {
+		return nil, fmt.Errorf("failed to define meter: %w", err)
+	}
+
+	s.logger.Info("Meter defined",
+		slog.String("catalog_equipment_id", def.CatalogEquipmentID),
+		slog.String("code", def.Code),
+		slog.String("meter_type", string(def.MeterType)))
+
+	return def, nil
+}
+
+// UpdateMeterDefinition updates the mutable fields of a meter definition
+func (s *MeterService) UpdateMeterDefinition(ctx context.Context, id string, req DefineMeterRequest) (*domain.MeterDefinition, error) {
+	def, err := s.meterRepo.GetDefinition(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		def.Name = req.Name
+	}
+	if req.MeterType != "" {
+		def.MeterType = req.MeterType
+	}
+	if req.Unit != "" {
+		def.Unit = req.Unit
+	}
+	def.RolloverAt = req.RolloverAt
+	def.MaxDailyIncrease = req.MaxDailyIncrease
+	def.ServiceInterval = req.ServiceInterval
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.meterRepo.UpdateDefinition(ctx, def); err != nil {
+		return nil, fmt.Errorf("failed to update meter definition: %w", err)
+	}
+	return def, nil
+}
+
+// ListMeterDefinitions lists meter definitions for a catalog model
+func (s *MeterService) ListMeterDefinitions(ctx context.Context, catalogEquipmentID string) ([]*domain.MeterDefinition, error) {
+	return s.meterRepo.ListDefinitions(ctx, catalogEquipmentID)
+}
+
+// ListReadings lists readings for an installed unit, optionally for a single meter
+func (s *MeterService) ListReadings(ctx context.Context, equipmentID, meterDefinitionID string, limit int) ([]*domain.MeterReading, error) {
+	return s.meterRepo.ListReadings(ctx, equipmentID, meterDefinitionID, limit)
+}
+
+// RecordReading validates and stores a single meter reading
+func (s *MeterService) RecordReading(ctx context.Context, input domain.MeterReadingInput, source domain.ReadingSource, recordedBy string) (*domain.MeterReading, error) {
+	equipment, err := s.resolveEquipment(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := s.resolveDefinition(ctx, equipment, input)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	readAt := input.ReadAt
+	if readAt.IsZero() {
+		readAt = now
+	}
+
+	reading := &domain.MeterReading{
+		ID:                ksuid.New().String(),
+		EquipmentID:       equipment.ID,
+		MeterDefinitionID: def.ID,
+		MeterCode:         def.Code,
+		Value:             input.Value,
+		Source:            source,
+		ReadAt:            readAt,
+		RecordedBy:        recordedBy,
+		CreatedAt:         now,
+	}
+
+	previous, err := s.meterRepo.GetLatestReading(ctx, equipment.ID, def.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := def.ApplyToPrevious(reading, previous, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.meterRepo.CreateReading(ctx, reading); err != nil {
+		return nil, fmt.Errorf("failed to record meter reading: %w", err)
+	}
+
+	if reading.Rollover {
+		s.logger.Info("Meter rollover detected",
+			slog.String("equipment_id", equipment.ID),
+			slog.String("meter_code", def.Code),
+			slog.Float64("value", reading.Value))
+	}
+
+	// Keep the preventive maintenance schedule in step with usage
+	if def.ServiceInterval > 0 {
+		if err := s.SyncServiceSchedule(ctx, equipment); err != nil {
+			s.logger.Warn("Failed to sync service schedule from meters",
+				slog.String("equipment_id", equipment.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return reading, nil
+}
+
+// IngestGatewayReadings stores a batch of readings pushed by a device gateway.
+// Readings are applied in chronological order so out-of-order batches still
+// pass monotonicity validation.
+func (s *MeterService) IngestGatewayReadings(ctx context.Context, inputs []domain.MeterReadingInput, gatewayID string) *domain.MeterImportResult {
+	sorted := make([]domain.MeterReadingInput, len(inputs))
+	copy(sorted, inputs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ReadAt.Before(sorted[j].ReadAt)
+	})
+
+	result := &domain.MeterImportResult{Errors: []string{}}
+	for i, input := range sorted {
+		result.TotalRows++
+		if _, err := s.RecordReading(ctx, input, domain.ReadingSourceGateway, gatewayID); err != nil {
+			result.FailureCount++
+			result.Errors = append(result.Errors, fmt.Sprintf("Reading %d (%s/%s): %v", i+1, readingEquipmentRef(input), readingMeterRef(input), err))
+			continue
+		}
+		result.SuccessCount++
+	}
+
+	s.logger.Info("Gateway meter readings ingested",
+		slog.String("gateway_id", gatewayID),
+		slog.Int("total", result.TotalRows),
+		slog.Int("success", result.SuccessCount),
+		slog.Int("failure", result.FailureCount))
+
+	return result
+}
+
+// ImportReadingsFromCSV imports meter readings from a CSV stream.
+// Expected header columns (any order):
+// - equipment_id or serial_number (required)
+// - meter_code or meter_definition_id (required)
+// - value (required)
+// - read_at (optional, RFC3339 or YYYY-MM-DD[ HH:MM]; defaults to now)
+func (s *MeterService) ImportReadingsFromCSV(ctx context.Context, r io.Reader, recordedBy string) (*domain.MeterImportResult, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	idx := map[string]int{}
+	for i, h := range header {
+		norm := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		idx[norm] = i
+	}
+
+	_, hasID := idx["equipment_id"]
+	_, hasSerial := idx["serial_number"]
+	_, hasCode := idx["meter_code"]
+	_, hasDef := idx["meter_definition_id"]
+	_, hasValue := idx["value"]
+	if !(hasID || hasSerial) || !(hasCode || hasDef) || !hasValue {
+		return nil, fmt.Errorf("csv requires equipment_id or serial_number, meter_code or meter_definition_id, and value columns")
+	}
+
+	result := &domain.MeterImportResult{Errors: []string{}}
+	rowNum := 1
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		rowNum++
+		result.TotalRows++
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: read error: %v", rowNum, err))
+			result.FailureCount++
+			continue
+		}
+
+		get := func(name string) string {
+			if i, ok := idx[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		value, err := strconv.ParseFloat(get("value"), 64)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: invalid value %q", rowNum, get("value")))
+			result.FailureCount++
+			continue
+		}
+
+		readAt, err := parseReadingTime(get("read_at"))
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: %v", rowNum, err))
+			result.FailureCount++
+			continue
+		}
+
+		input := domain.MeterReadingInput{
+			EquipmentID:       get("equipment_id"),
+			SerialNumber:      get("serial_number"),
+			MeterDefinitionID: get("meter_definition_id"),
+			MeterCode:         get("meter_code"),
+			Value:             value,
+			ReadAt:            readAt,
+		}
+
+		if _, err := s.RecordReading(ctx, input, domain.ReadingSourceCSV, recordedBy); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: %v", rowNum, err))
+			result.FailureCount++
+			continue
+		}
+		result.SuccessCount++
+	}
+
+	s.logger.Info("Meter reading CSV import completed",
+		slog.Int("total", result.TotalRows),
+		slog.Int("success", result.SuccessCount),
+		slog.Int("failure", result.FailureCount))
+
+	return result, nil
+}
+
+// GetUsageSnapshot returns the latest value of every meter on an installed unit,
+// together with the usage accumulated since the unit was last serviced.
+func (s *MeterService) GetUsageSnapshot(ctx context.Context, equipmentID string) (*domain.UsageSnapshot, error) {
+	equipment, err := s.equipmentRepo.GetByID(ctx, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildSnapshot(ctx, equipment)
+}
+
+// SyncServiceSchedule pulls the next service date forward to today when any
+// metered service interval has been exceeded since the last service.
+func (s *MeterService) SyncServiceSchedule(ctx context.Context, equipment *domain.Equipment) error {
+	snapshot, err := s.buildSnapshot(ctx, equipment)
+	if err != nil {
+		return err
+	}
+	if !snapshot.ServiceDue() {
+		return nil
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	if equipment.NextServiceDate != nil && !equipment.NextServiceDate.After(today) {
+		return nil
+	}
+
+	equipment.ScheduleNextService(today)
+	if err := s.equipmentRepo.Update(ctx, equipment); err != nil {
+		return fmt.Errorf("failed to schedule service: %w", err)
+	}
+
+	s.logger.Info("Usage-based service scheduled",
+		slog.String("equipment_id", equipment.ID),
+		slog.Time("next_service_date", today))
+	return nil
+}
+
+// buildSnapshot assembles the usage snapshot for an installed unit
+func (s *MeterService) buildSnapshot(ctx context.Context, equipment *domain.Equipment) (*domain.UsageSnapshot, error) {
+	snapshot := &domain.UsageSnapshot{
+		EquipmentID: equipment.ID,
+		Meters:      []domain.MeterUsage{},
+		ByType:      map[domain.MeterType]domain.MeterUsage{},
+	}
+	if equipment.EquipmentID == "" {
+		return snapshot, nil
+	}
+
+	defs, err := s.meterRepo.ListDefinitions(ctx, equipment.EquipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, def := range defs {
+		latest, err := s.meterRepo.GetLatestReading(ctx, equipment.ID, def.ID)
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil {
+			continue
+		}
+
+		usage := domain.MeterUsage{
+			MeterDefinitionID: def.ID,
+			MeterCode:         def.Code,
+			Name:              def.Name,
+			MeterType:         def.MeterType,
+			Unit:              def.Unit,
+			LatestValue:       latest.Value,
+			CumulativeValue:   latest.CumulativeValue,
+			ReadAt:            &latest.ReadAt,
+			UsageSinceService: latest.CumulativeValue,
+			ServiceInterval:   def.ServiceInterval,
+		}
+
+		if equipment.LastServiceDate != nil {
+			atService, err := s.meterRepo.GetReadingAsOf(ctx, equipment.ID, def.ID, *equipment.LastServiceDate)
+			if err != nil {
+				return nil, err
+			}
+			if atService != nil {
+				usage.UsageSinceService = latest.CumulativeValue - atService.CumulativeValue
+			}
+		}
+		usage.ServiceDue = def.ServiceInterval > 0 && usage.UsageSinceService >= def.ServiceInterval
+
+		snapshot.Meters = append(snapshot.Meters, usage)
+		if _, exists := snapshot.ByType[def.MeterType]; !exists {
+			snapshot.ByType[def.MeterType] = usage
+		}
+	}
+
+	return snapshot, nil
+}
+
+// resolveEquipment finds the installed unit referenced by a reading
+func (s *MeterService) resolveEquipment(ctx context.Context, input domain.MeterReadingInput) (*domain.Equipment, error) {
+	switch {
+	case input.EquipmentID != "":
+		return s.equipmentRepo.GetByID(ctx, input.EquipmentID)
+	case input.SerialNumber != "":
+		return s.equipmentRepo.GetBySerialNumber(ctx, input.SerialNumber)
+	default:
+		return nil, fmt.Errorf("equipment_id or serial_number is required")
+	}
+}
+
+// resolveDefinition finds the meter definition referenced by a reading and
+// checks that it belongs to the unit's catalog model
+func (s *MeterService) resolveDefinition(ctx context.Context, equipment *domain.Equipment, input domain.MeterReadingInput) (*domain.MeterDefinition, error) {
+	if equipment.EquipmentID == "" {
+		return nil, fmt.Errorf("equipment %s is not linked to a catalog model", equipment.ID)
+	}
+
+	if input.MeterDefinitionID != "" {
+		def, err := s.meterRepo.GetDefinition(ctx, input.MeterDefinitionID)
+		if err != nil {
+			return nil, err
+		}
+		if def.CatalogEquipmentID != equipment.EquipmentID {
+			return nil, fmt.Errorf("meter %s does not apply to equipment %s", def.Code, equipment.ID)
+		}
+		return def, nil
+	}
+
+	if input.MeterCode != "" {
+		return s.meterRepo.GetDefinitionByCode(ctx, equipment.EquipmentID, strings.ToLower(input.MeterCode))
+	}
+
+	return nil, fmt.Errorf("meter_definition_id or meter_code is required")
+}
+
+// parseReadingTime parses reading timestamps accepted in CSV imports
+func parseReadingTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid read_at %q", value)
+}
+
+func readingEquipmentRef(input domain.MeterReadingInput) string {
+	if input.EquipmentID != "" {
+		return input.EquipmentID
+	}
+	return input.SerialNumber
+}
+
+func readingMeterRef(input domain.MeterReadingInput) string {
+	if input.MeterCode != "" {
+		return input.MeterCode
+	}
+	return input.MeterDefinitionID
+}
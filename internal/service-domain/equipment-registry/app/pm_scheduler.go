@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/shared/config"
+)
+
+// MeterPMScheduler periodically re-evaluates usage-based preventive maintenance
+// for every metered unit and pulls NextServiceDate forward when an interval is hit.
+type MeterPMScheduler struct {
+	meters   *MeterService
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+// NewMeterPMScheduler creates a new usage-based PM scheduler
+func NewMeterPMScheduler(meters *MeterService, logger *slog.Logger) *MeterPMScheduler {
+	return &MeterPMScheduler{
+		meters:   meters,
+		interval: time.Hour,
+		logger:   logger.With(slog.String("component", "meter_pm_scheduler")),
+	}
+}
+
+// Run evaluates PM schedules until the context is cancelled.
+// Disabled with ENABLE_METER_PM_SCHEDULER=false.
+func (p *MeterPMScheduler) Run(ctx context.Context) {
+	if !config.Enabled("ENABLE_METER_PM_SCHEDULER") {
+		p.logger.Info("Meter PM scheduler disabled; skipping run")
+		return
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce evaluates all metered units once
+func (p *MeterPMScheduler) RunOnce(ctx context.Context) {
+	ids, err := p.meters.meterRepo.ListMeteredEquipmentIDs(ctx)
+	if err != nil {
+		p.logger.Error("Failed to list metered equipment", slog.String("error", err.Error()))
+		return
+	}
+
+	for _, id := range ids {
+		equipment, err := p.meters.equipmentRepo.GetByID(ctx, id)
+		if err != nil {
+			continue
+		}
+		if err := p.meters.SyncServiceSchedule(ctx, equipment); err != nil {
+			p.logger.Warn("Failed to sync service schedule",
+				slog.String("equipment_id", id),
+				slog.String("error", err.Error()))
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// QualityService provides data quality tooling over the installed base:
+// normalization of serials and manufacturer names, duplicate detection,
+// merging duplicates and quality scores per organization
+type QualityService struct {
+	qualityRepo   domain.QualityRepository
+	equipmentRepo domain.Repository
+	logger        *slog.Logger
+}
+
+// NewQualityService creates a new data quality service
+func NewQualityService(qualityRepo domain.QualityRepository, equipmentRepo domain.Repository, logger *slog.Logger) *QualityService {
+	return &QualityService{
+		qualityRepo:   qualityRepo,
+		equipmentRepo: equipmentRepo,
+		logger:        logger.With(slog.String("component", "quality_service")),
+	}
+}
+
+// normalizer loads configured aliases on top of the built-in manufacturer rules
+func (s *QualityService) normalizer(ctx context.Context) (*domain.ManufacturerNormalizer, error) {
+	aliases, err := s.qualityRepo.ListManufacturerAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewManufacturerNormalizer(aliases), nil
+}
+
+// FindDuplicates returns likely duplicate pairs, best matches first
+func (s *QualityService) FindDuplicates(ctx context.Context, opts domain.DuplicateOptions) ([]*domain.DuplicateCandidate, error) {
+	normalizer, err := s.normalizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.qualityRepo.ListForQuality(ctx, opts.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.FindDuplicateCandidates(records, normalizer, opts), nil
+}
+
+// MergeRequest represents a request to merge a duplicate into a surviving record
+type MergeRequest struct {
+	SurvivorID  string `json:"survivor_id"`
+	DuplicateID string `json:"duplicate_id"`
+	Reason      string `json:"reason"`
+	MergedBy    string `json:"merged_by"`
+
+	// Force merges records of different manufacturers or customers
+	Force bool `json:"force"`
+}
+
+// MergeEquipment merges a duplicate into the surviving record. Tickets,
+// documents, history and QR labels of the duplicate move to the survivor, and
+// the duplicate's QR code and serial keep resolving to it.
+func (s *QualityService) MergeEquipment(ctx context.Context, req MergeRequest) (*domain.EquipmentMerge, error) {
+	if req.SurvivorID == "" || req.DuplicateID == "" {
+		return nil, fmt.Errorf("%w: survivor_id and duplicate_id are required", domain.ErrMergeConflict)
+	}
+	if req.SurvivorID == req.DuplicateID {
+		return nil, domain.ErrMergeSameEquipment
+	}
+
+	survivor, err := s.equipmentRepo.GetByID(ctx, req.SurvivorID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.equipmentRepo.GetByID(ctx, req.DuplicateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Force {
+		if err := s.checkMergeable(ctx, survivor, duplicate); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot := *duplicate
+	snapshot.QRCodeImage = nil
+
+	merge := &domain.EquipmentMerge{
+		ID:           ksuid.New().String(),
+		SurvivorID:   survivor.ID,
+		MergedID:     duplicate.ID,
+		MergedSerial: duplicate.SerialNumber,
+		MergedQRCode: duplicate.QRCode,
+		Snapshot:     &snapshot,
+		Reason:       req.Reason,
+		MergedBy:     req.MergedBy,
+		MergedAt:     time.Now(),
+	}
+	if merge.MergedBy == "" {
+		merge.MergedBy = "system"
+	}
+
+	domain.MergeInto(survivor, duplicate)
+
+	// A survivor without a label takes over the duplicate's QR code
+	if survivor.QRCode == "" && duplicate.QRCode != "" {
+		survivor.QRCode = duplicate.QRCode
+		survivor.QRCodeURL = duplicate.QRCodeURL
+	}
+
+	if err := s.qualityRepo.Merge(ctx, survivor, duplicate, merge); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Equipment merged",
+		slog.String("survivor_id", survivor.ID),
+		slog.String("merged_id", duplicate.ID),
+		slog.String("merged_serial", duplicate.SerialNumber),
+		slog.Any("moved_references", merge.MovedReferences))
+	for _, conflict := range merge.Conflicts {
+		s.logger.Warn("Merge reference conflict",
+			slog.String("survivor_id", survivor.ID),
+			slog.String("merged_id", duplicate.ID),
+			slog.String("table", conflict.Table),
+			slog.String("detail", conflict.Detail))
+	}
+
+	return merge, nil
+}
+
+// checkMergeable rejects merges of records that clearly describe different units
+func (s *QualityService) checkMergeable(ctx context.Context, survivor, duplicate *domain.Equipment) error {
+	normalizer, err := s.normalizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	a, b := normalizer.Key(survivor.ManufacturerName), normalizer.Key(duplicate.ManufacturerName)
+	if a != "" && b != "" && a != b {
+		return fmt.Errorf("%w: manufacturers differ (%s, %s); use force to merge anyway",
+			domain.ErrMergeConflict, survivor.ManufacturerName, duplicate.ManufacturerName)
+	}
+	if survivor.CustomerID != "" && duplicate.CustomerID != "" && survivor.CustomerID != duplicate.CustomerID {
+		return fmt.Errorf("%w: equipment belongs to different customers; use force to merge anyway", domain.ErrMergeConflict)
+	}
+	return nil
+}
+
+// ListMerges returns records merged into the given equipment
+func (s *QualityService) ListMerges(ctx context.Context, equipmentID string) ([]*domain.EquipmentMerge, error) {
+	// The lookup applies the caller's organization filter
+	if _, err := s.equipmentRepo.GetByID(ctx, equipmentID); err != nil {
+		return nil, err
+	}
+	return s.qualityRepo.ListMerges(ctx, equipmentID)
+}
+
+// NormalizationChange is a single field change proposed or applied by normalization
+type NormalizationChange struct {
+	EquipmentID string `json:"equipment_id"`
+	Field       string `json:"field"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Skipped     string `json:"skipped,omitempty"` // Why the change was not applied
+}
+
+// NormalizationReport summarizes a normalization run
+type NormalizationReport struct {
+	DryRun       bool                  `json:"dry_run"`
+	Scanned      int                   `json:"scanned"`
+	AppliedCount int                   `json:"applied_count"`
+	SkippedCount int                   `json:"skipped_count"`
+	Changes      []NormalizationChange `json:"changes"`
+}
+
+// Normalize cleans serial numbers and maps manufacturer spellings to canonical
+// names. In dry-run mode the changes are only reported.
+func (s *QualityService) Normalize(ctx context.Context, customerID string, dryRun bool) (*NormalizationReport, error) {
+	normalizer, err := s.normalizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.qualityRepo.ListForQuality(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &NormalizationReport{DryRun: dryRun, Scanned: len(records), Changes: []NormalizationChange{}}
+
+	// Serial numbers are unique, so a cleaned serial that already exists is a duplicate to merge instead
+	serials := make(map[string]bool, len(records))
+	for _, e := range records {
+		serials[e.SerialNumber] = true
+	}
+
+	renames := map[string][]string{}
+	for _, e := range records {
+		if cleaned := domain.CleanSerial(e.SerialNumber); cleaned != "" && cleaned != e.SerialNumber {
+			change := NormalizationChange{EquipmentID: e.ID, Field: "serial_number", From: e.SerialNumber, To: cleaned}
+			if s.serialTaken(ctx, serials, cleaned) {
+				change.Skipped = "serial number already registered; merge the duplicate instead"
+			} else if !dryRun {
+				if err := s.qualityRepo.UpdateSerialNumber(ctx, e.ID, cleaned); err != nil {
+					change.Skipped = err.Error()
+				}
+			}
+			if change.Skipped == "" {
+				serials[cleaned] = true
+				delete(serials, e.SerialNumber)
+				report.AppliedCount++
+			} else {
+				report.SkippedCount++
+			}
+			report.Changes = append(report.Changes, change)
+		}
+
+		if canonical, known := normalizer.Canonical(e.ManufacturerName); known && canonical != e.ManufacturerName {
+			renames[canonical] = append(renames[canonical], e.ID)
+			report.Changes = append(report.Changes, NormalizationChange{
+				EquipmentID: e.ID, Field: "manufacturer_name", From: e.ManufacturerName, To: canonical,
+			})
+			report.AppliedCount++
+		}
+	}
+
+	if !dryRun {
+		names := make([]string, 0, len(renames))
+		for name := range renames {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if _, err := s.qualityRepo.RenameManufacturer(ctx, renames[name], name); err != nil {
+				return nil, err
+			}
+		}
+
+		s.logger.Info("Installed base normalized",
+			slog.String("customer_id", customerID),
+			slog.Int("applied", report.AppliedCount),
+			slog.Int("skipped", report.SkippedCount))
+	}
+
+	return report, nil
+}
+
+// serialTaken checks a serial against the scanned records, then the whole
+// registry since serial numbers are unique across organizations
+func (s *QualityService) serialTaken(ctx context.Context, scanned map[string]bool, serial string) bool {
+	if scanned[serial] {
+		return true
+	}
+	_, err := s.equipmentRepo.GetBySerialNumber(ctx, serial)
+	return err == nil
+}
+
+// AddAliasRequest represents a request to add a manufacturer alias
+type AddAliasRequest struct {
+	Alias         string `json:"alias"`
+	CanonicalName string `json:"canonical_name"`
+	CreatedBy     string `json:"created_by"`
+}
+
+// AddManufacturerAlias configures a spelling that maps to a canonical manufacturer name
+func (s *QualityService) AddManufacturerAlias(ctx context.Context, req AddAliasRequest) (*domain.ManufacturerAlias, error) {
+	alias := &domain.ManufacturerAlias{
+		Alias:         strings.TrimSpace(req.Alias),
+		AliasKey:      domain.ManufacturerKey(req.Alias),
+		CanonicalName: strings.Join(strings.Fields(req.CanonicalName), " "),
+		CreatedBy:     req.CreatedBy,
+		CreatedAt:     time.Now(),
+	}
+	if alias.AliasKey == "" || alias.CanonicalName == "" {
+		return nil, fmt.Errorf("alias and canonical_name are required")
+	}
+
+	if err := s.qualityRepo.UpsertManufacturerAlias(ctx, alias); err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
+
+// ListManufacturerAliases returns configured manufacturer aliases
+func (s *QualityService) ListManufacturerAliases(ctx context.Context) ([]*domain.ManufacturerAlias, error) {
+	return s.qualityRepo.ListManufacturerAliases(ctx)
+}
+
+// GetQualityScore computes the quality score of one organization's installed base
+func (s *QualityService) GetQualityScore(ctx context.Context, customerID string) (*domain.QualityScore, error) {
+	normalizer, err := s.normalizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.qualityRepo.ListForQuality(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := domain.FindDuplicateCandidates(records, normalizer, domain.DuplicateOptions{})
+	return domain.ScoreQuality(customerID, records, normalizer, duplicates), nil
+}
+
+// ListQualityScores computes quality scores for every customer in the
+// caller's organization, worst first
+func (s *QualityService) ListQualityScores(ctx context.Context) ([]*domain.QualityScore, error) {
+	normalizer, err := s.normalizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.qualityRepo.ListForQuality(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	byCustomer := map[string][]*domain.Equipment{}
+	for _, e := range records {
+		byCustomer[e.CustomerID] = append(byCustomer[e.CustomerID], e)
+	}
+
+	scores := make([]*domain.QualityScore, 0, len(byCustomer))
+	for customerID, group := range byCustomer {
+		duplicates := domain.FindDuplicateCandidates(group, normalizer, domain.DuplicateOptions{})
+		scores = append(scores, domain.ScoreQuality(customerID, group, normalizer, duplicates))
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score < scores[j].Score
+		}
+		return scores[i].CustomerID < scores[j].CustomerID
+	})
+
+	return scores, nil
+}
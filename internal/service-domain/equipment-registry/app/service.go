@@ -7,7 +7,6 @@ import (
 	"io"
 	"log/slog"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +21,7 @@ type EquipmentService struct {
 	qrGenerator *qrcode.Generator
 	logger      *slog.Logger
 	baseURL     string
+	geocoder    Geocoder
 }
 
 // NewEquipmentService creates a new equipment service
@@ -57,6 +57,18 @@ type RegisterEquipmentRequest struct {
 	CreatedBy            string                 `json:"created_by"`
 }
 
+// SetGeocoder sets the geocoder used to resolve installation coordinates on save
+func (s *EquipmentService) SetGeocoder(geocoder Geocoder) {
+	s.geocoder = geocoder
+}
+
+// geocode resolves installation coordinates when a geocoder is configured
+func (s *EquipmentService) geocode(equipment *domain.Equipment) {
+	if s.geocoder != nil {
+		s.geocoder.GeocodeEquipment(equipment)
+	}
+}
+
 // RegisterEquipment registers a new equipment
 func (s *EquipmentService) RegisterEquipment(ctx context.Context, req RegisterEquipmentRequest) (*domain.Equipment, error) {
 	// Generate IDs
@@ -105,6 +117,9 @@ func (s *EquipmentService) RegisterEquipment(ctx context.Context, req RegisterEq
 	// Generate QR code URL
 	equipment.QRCodeURL = fmt.Sprintf("%s/equipment/%s", s.baseURL, equipmentID)
 
+	// Resolve installation coordinates from the postal code
+	s.geocode(equipment)
+
 	// Save to database
 	if err := s.repo.Create(ctx, equipment); err != nil {
 		s.logger.Error("Failed to register equipment", slog.String("error", err.Error()))
@@ -213,6 +228,7 @@ func (s *EquipmentService) ListEquipment(ctx context.Context, criteria domain.Li
 
 // UpdateEquipment updates equipment details
 func (s *EquipmentService) UpdateEquipment(ctx context.Context, equipment *domain.Equipment) error {
+	s.geocode(equipment)
 	return s.repo.Update(ctx, equipment)
 }
 
@@ -238,90 +254,6 @@ func (s *EquipmentService) RecordService(ctx context.Context, equipmentID string
 	return nil
 }
 
-// BulkImportFromCSV imports equipment from CSV file
-func (s *EquipmentService) BulkImportFromCSV(ctx context.Context, csvFilePath, createdBy string) (*domain.CSVImportResult, error) {
-	file, err := os.Open(csvFilePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open CSV file: %w", err)
-	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
-
-	// Read header
-	header, err := reader.Read()
-	if err != nil {
-		return nil, fmt.Errorf("failed to read CSV header: %w", err)
-	}
-
-	s.logger.Info("CSV header", slog.Any("columns", header))
-
-	result := &domain.CSVImportResult{
-		Errors:      []string{},
-		ImportedIDs: []string{},
-	}
-
-	equipmentList := []*domain.Equipment{}
-	rowNum := 1
-
-	// Read rows
-	for {
-		row, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: Failed to read: %v", rowNum, err))
-			result.FailureCount++
-			rowNum++
-			continue
-		}
-
-		// Parse row (expected columns match CSVImportRow structure)
-		if len(row) < 13 {
-			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: Insufficient columns", rowNum))
-			result.FailureCount++
-			rowNum++
-			continue
-		}
-
-		// Create equipment from CSV row
-		equipment, err := s.parseCSVRow(row, createdBy)
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: %v", rowNum, err))
-			result.FailureCount++
-			rowNum++
-			continue
-		}
-
-		equipmentList = append(equipmentList, equipment)
-		result.SuccessCount++
-		rowNum++
-	}
-
-	result.TotalRows = rowNum - 1
-
-	// Bulk insert
-	if len(equipmentList) > 0 {
-		if err := s.repo.BulkCreate(ctx, equipmentList); err != nil {
-			return nil, fmt.Errorf("failed to bulk insert: %w", err)
-		}
-
-		// Collect IDs
-		for _, eq := range equipmentList {
-			result.ImportedIDs = append(result.ImportedIDs, eq.ID)
-		}
-	}
-
-	s.logger.Info("CSV import completed",
-		slog.Int("total", result.TotalRows),
-		slog.Int("success", result.SuccessCount),
-		slog.Int("failure", result.FailureCount),
-	)
-
-	return result, nil
-}
-
 // BulkImportQRMapping imports pregenerated QR mappings from CSV
 // Expected header columns (any order):
 // - qr_code (required)
@@ -421,116 +353,13 @@ func (s *EquipmentService) BulkImportQRMappingFromReader(ctx context.Context, r
     return result, nil
 }
 
-// parseCSVRow parses a CSV row into equipment
-func (s *EquipmentService) parseCSVRow(row []string, createdBy string) (*domain.Equipment, error) {
-	// CSV columns: serial_number, equipment_name, manufacturer_name, model_number, category,
-	// customer_name, customer_id, installation_location, installation_date, purchase_date,
-	// purchase_price, warranty_months, notes
-
-	serialNumber := row[0]
-	if serialNumber == "" {
-		return nil, fmt.Errorf("serial_number is required")
-	}
-
-	equipmentName := row[1]
-	if equipmentName == "" {
-		return nil, fmt.Errorf("equipment_name is required")
-	}
-
-	manufacturerName := row[2]
-	if manufacturerName == "" {
-		return nil, fmt.Errorf("manufacturer_name is required")
-	}
-
-	modelNumber := row[3]
-	category := row[4]
-	customerName := row[5]
-	if customerName == "" {
-		return nil, fmt.Errorf("customer_name is required")
-	}
-
-	customerID := row[6]
-	installationLocation := row[7]
-
-	// Parse dates
-	var installationDate, purchaseDate *time.Time
-	if row[8] != "" {
-		date, err := time.Parse("2006-01-02", row[8])
-		if err == nil {
-			installationDate = &date
-		}
-	}
-
-	if row[9] != "" {
-		date, err := time.Parse("2006-01-02", row[9])
-		if err == nil {
-			purchaseDate = &date
-		}
-	}
-
-	// Parse price
-	purchasePrice := 0.0
-	if row[10] != "" {
-		price, err := strconv.ParseFloat(row[10], 64)
-		if err == nil {
-			purchasePrice = price
-		}
-	}
-
-	// Parse warranty months
-	warrantyMonths := 0
-	if row[11] != "" {
-		months, err := strconv.Atoi(row[11])
-		if err == nil {
-			warrantyMonths = months
-		}
-	}
-
-	notes := row[12]
-
-	// Generate IDs
-	equipmentID := ksuid.New().String()
-	qrCodeID := s.generateQRCodeID()
-
-	// Create equipment
-	equipment := domain.NewEquipment(
-		serialNumber,
-		equipmentName,
-		manufacturerName,
-		modelNumber,
-		customerName,
-		createdBy,
-	)
-
-	equipment.ID = equipmentID
-	equipment.QRCode = qrCodeID
-	equipment.Category = category
-	equipment.CustomerID = customerID
-	equipment.InstallationLocation = installationLocation
-	equipment.InstallationDate = installationDate
-	equipment.PurchaseDate = purchaseDate
-	equipment.PurchasePrice = purchasePrice
-	equipment.Notes = notes
-
-	// Calculate warranty expiry
-	if warrantyMonths > 0 {
-		if purchaseDate != nil {
-			warrantyExpiry := purchaseDate.AddDate(0, warrantyMonths, 0)
-			equipment.WarrantyExpiry = &warrantyExpiry
-		} else {
-			warrantyExpiry := time.Now().AddDate(0, warrantyMonths, 0)
-			equipment.WarrantyExpiry = &warrantyExpiry
-		}
-	}
-
-	// Generate QR code URL
-	equipment.QRCodeURL = fmt.Sprintf("%s/equipment/%s", s.baseURL, equipmentID)
-
-	return equipment, nil
-}
-
 // generateQRCodeID generates a unique QR code identifier
 func (s *EquipmentService) generateQRCodeID() string {
+	return newQRCodeID()
+}
+
+// newQRCodeID returns a QR code identifier in the registry's format
+func newQRCodeID() string {
 	now := time.Now()
 	// Format: QR-YYYYMMDD-XXXXXX (random 6 digits)
 	return fmt.Sprintf("QR-%s-%06d", now.Format("20060102"), now.UnixNano()%1000000)
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
+)
+
+var (
+	ErrInvalidGazetteer   = errors.New("invalid gazetteer file")
+	ErrGazetteerEmpty     = errors.New("no gazetteer has been imported")
+	ErrInvalidCoordinates = errors.New("invalid coordinates")
+)
+
+// Installation address keys holding coordinates
+const (
+	AddressLatitudeKey       = "latitude"
+	AddressLongitudeKey      = "longitude"
+	AddressGeocodeSourceKey  = "geocode_source" // "gazetteer" or "manual"
+	AddressGeocodedPostalKey = "geocoded_postal_code"
+)
+
+// Geocode sources
+const (
+	GeocodeSourceGazetteer = "gazetteer"
+	GeocodeSourceManual    = "manual"
+)
+
+// InstallationPoint returns the coordinates of the installation address, if known
+func (e *Equipment) InstallationPoint() (geo.Point, bool) {
+	lat, okLat := addressFloat(e.InstallationAddress, AddressLatitudeKey, "lat")
+	lng, okLng := addressFloat(e.InstallationAddress, AddressLongitudeKey, "lng", "lon")
+	if !okLat || !okLng {
+		return geo.Point{}, false
+	}
+	p := geo.Point{Lat: lat, Lng: lng}
+	return p, p.Valid()
+}
+
+// SetInstallationPoint stores coordinates on the installation address
+func (e *Equipment) SetInstallationPoint(p geo.Point, source, postalCode string) {
+	if e.InstallationAddress == nil {
+		e.InstallationAddress = map[string]interface{}{}
+	}
+	e.InstallationAddress[AddressLatitudeKey] = p.Lat
+	e.InstallationAddress[AddressLongitudeKey] = p.Lng
+	e.InstallationAddress[AddressGeocodeSourceKey] = source
+	if postalCode != "" {
+		e.InstallationAddress[AddressGeocodedPostalKey] = postalCode
+	} else {
+		delete(e.InstallationAddress, AddressGeocodedPostalKey)
+	}
+}
+
+// HasManualCoordinates reports whether coordinates were entered by hand and must not be re-geocoded
+func (e *Equipment) HasManualCoordinates() bool {
+	if _, ok := e.InstallationPoint(); !ok {
+		return false
+	}
+	source, _ := e.InstallationAddress[AddressGeocodeSourceKey].(string)
+	return source != GeocodeSourceGazetteer
+}
+
+func addressFloat(address map[string]interface{}, keys ...string) (float64, bool) {
+	for _, key := range keys {
+		switch v := address[key].(type) {
+		case float64:
+			return v, true
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// InstallationGeo is the location data of one installation
+type InstallationGeo struct {
+	ID                   string                 `json:"id"`
+	SerialNumber         string                 `json:"serial_number"`
+	InstallationLocation string                 `json:"installation_location"`
+	InstallationAddress  map[string]interface{} `json:"installation_address"`
+}
+
+// GeoRepository defines persistence for the postal code gazetteer and installation coordinates
+type GeoRepository interface {
+	// ImportPostalPlaces creates or replaces gazetteer entries
+	ImportPostalPlaces(ctx context.Context, places []geo.PostalPlace) (int, error)
+
+	// ListPostalPlaces retrieves the full gazetteer
+	ListPostalPlaces(ctx context.Context) ([]geo.PostalPlace, error)
+
+	// LookupPostalCode resolves one postal code to the centroid of its places.
+	// Without a country the code must be unambiguous.
+	LookupPostalCode(ctx context.Context, country, postalCode string) (geo.PostalPlace, bool, error)
+
+	// ListInstallations retrieves installation addresses, optionally only those without coordinates
+	ListInstallations(ctx context.Context, missingOnly bool) ([]*InstallationGeo, error)
+
+	// UpdateInstallationAddress replaces the installation address of an equipment record
+	UpdateInstallationAddress(ctx context.Context, id string, address map[string]interface{}) error
+}
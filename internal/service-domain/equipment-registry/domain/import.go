@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrImportJobNotFound       = errors.New("import job not found")
+	ErrImportJobNotResumable   = errors.New("import job cannot be resumed")
+	ErrUnsupportedImportFormat = errors.New("unsupported import file format")
+)
+
+// ImportFormat identifies the file format of an installed-base import
+type ImportFormat string
+
+const (
+	ImportFormatCSV  ImportFormat = "csv"
+	ImportFormatXLSX ImportFormat = "xlsx"
+)
+
+// ImportRowAction describes what an import did (or would do, in dry-run) with a row
+type ImportRowAction string
+
+const (
+	ImportActionCreate ImportRowAction = "create"
+	ImportActionUpdate ImportRowAction = "update"
+	ImportActionSkip   ImportRowAction = "skip"
+	ImportActionError  ImportRowAction = "error"
+)
+
+// ImportOptions controls an installed-base import
+type ImportOptions struct {
+	// DryRun validates and previews the import without writing
+	DryRun bool `json:"dry_run"`
+
+	// UpdateMode updates existing equipment matched by serial number instead of skipping it
+	UpdateMode bool `json:"update_mode"`
+
+	// ColumnMapping overrides header auto-detection: field name -> header as it appears in the file
+	ColumnMapping map[string]string `json:"column_mapping,omitempty"`
+
+	CreatedBy string `json:"created_by"`
+
+	// Scope is the importing organization; only its equipment is matched and updated
+	Scope OrgScope `json:"scope"`
+}
+
+// ImportRowResult is the validation outcome of a single row
+type ImportRowResult struct {
+	Row          int             `json:"row"` // 1-based row number in the file, header is row 1
+	SerialNumber string          `json:"serial_number,omitempty"`
+	Action       ImportRowAction `json:"action"`
+	EquipmentID  string          `json:"equipment_id,omitempty"`
+	Errors       []string        `json:"errors,omitempty"`
+	Warnings     []string        `json:"warnings,omitempty"`
+}
+
+// ImportReport summarizes an installed-base import or dry-run preview
+type ImportReport struct {
+	DryRun          bool              `json:"dry_run"`
+	UpdateMode      bool              `json:"update_mode"`
+	Format          ImportFormat      `json:"format"`
+	TotalRows       int               `json:"total_rows"`
+	ValidRows       int               `json:"valid_rows"`
+	CreatedCount    int               `json:"created_count"`
+	UpdatedCount    int               `json:"updated_count"`
+	SkippedCount    int               `json:"skipped_count"`
+	FailureCount    int               `json:"failure_count"`
+	ColumnMapping   map[string]string `json:"column_mapping"`   // field -> detected header
+	UnmappedColumns []string          `json:"unmapped_columns"` // headers that were ignored
+	Rows            []ImportRowResult `json:"rows"`
+
+	// Fields kept for clients of the original CSV import response
+	SuccessCount int      `json:"success_count"`
+	Errors       []string `json:"errors"`
+	ImportedIDs  []string `json:"imported_ids"`
+}
+
+// ImportJobStatus represents the lifecycle of an asynchronous import job
+type ImportJobStatus string
+
+const (
+	ImportJobPending   ImportJobStatus = "pending"
+	ImportJobRunning   ImportJobStatus = "running"
+	ImportJobCompleted ImportJobStatus = "completed"
+	ImportJobFailed    ImportJobStatus = "failed"
+)
+
+// ImportJob tracks a large installed-base import processed in the background.
+// CheckpointRow is the last file row whose batch has been committed, so an
+// interrupted job can resume without re-importing earlier rows.
+type ImportJob struct {
+	ID            string          `json:"id"`
+	Status        ImportJobStatus `json:"status"`
+	FileName      string          `json:"file_name"`
+	Format        ImportFormat    `json:"format"`
+	FilePath      string          `json:"-"`
+	Options       ImportOptions   `json:"options"`
+	TotalRows     int             `json:"total_rows"` // Rows processed so far
+	CreatedCount  int             `json:"created_count"`
+	UpdatedCount  int             `json:"updated_count"`
+	SkippedCount  int             `json:"skipped_count"`
+	FailureCount  int             `json:"failure_count"`
+	CheckpointRow int             `json:"checkpoint_row"`
+	Error         string          `json:"error,omitempty"`
+	CreatedBy     string          `json:"created_by"`
+	CreatedAt     time.Time       `json:"created_at"`
+	StartedAt     *time.Time      `json:"started_at,omitempty"`
+	CompletedAt   *time.Time      `json:"completed_at,omitempty"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// CanResume checks if the job was interrupted or failed and can be picked up again
+func (j *ImportJob) CanResume() bool {
+	return j.Status == ImportJobRunning || j.Status == ImportJobFailed || j.Status == ImportJobPending
+}
+
+// ImportJobRepository defines the interface for import job persistence
+type ImportJobRepository interface {
+	// CreateJob creates an import job
+	CreateJob(ctx context.Context, job *ImportJob) error
+
+	// UpdateJob updates status, counters and checkpoint of an import job
+	UpdateJob(ctx context.Context, job *ImportJob) error
+
+	// GetJob retrieves an import job of the request's organization by ID
+	GetJob(ctx context.Context, id string) (*ImportJob, error)
+
+	// ListJobs retrieves recent import jobs of the request's organization, newest first
+	ListJobs(ctx context.Context, limit int) ([]*ImportJob, error)
+
+	// ListJobsByStatus retrieves import jobs in the given status
+	ListJobsByStatus(ctx context.Context, status ImportJobStatus) ([]*ImportJob, error)
+
+	// AddRowResults stores row results (errors and warnings) of a job
+	AddRowResults(ctx context.Context, jobID string, rows []ImportRowResult) error
+
+	// ListRowResults retrieves stored row results of a job, optionally filtered by action
+	ListRowResults(ctx context.Context, jobID string, action ImportRowAction, limit, offset int) ([]ImportRowResult, error)
+}
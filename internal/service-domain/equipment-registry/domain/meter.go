@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrMeterDefinitionNotFound = errors.New("meter definition not found")
+	ErrInvalidMeterType        = errors.New("invalid meter type")
+	ErrNonMonotonicReading     = errors.New("meter reading is lower than the previous reading")
+	ErrReadingInFuture         = errors.New("meter reading timestamp is in the future")
+	ErrReadingOutOfOrder       = errors.New("meter reading is older than the latest reading")
+	ErrImplausibleReading      = errors.New("meter reading exceeds the plausible increase for the elapsed time")
+)
+
+// MeterType represents the kind of usage a meter counts
+type MeterType string
+
+const (
+	MeterOperatingHours MeterType = "operating_hours"
+	MeterExposureCount  MeterType = "exposure_count"
+	MeterScanSeconds    MeterType = "scan_seconds"
+	MeterCycleCount     MeterType = "cycle_count"
+	MeterGeneric        MeterType = "generic"
+)
+
+// IsValid checks if the meter type is a known type
+func (t MeterType) IsValid() bool {
+	switch t {
+	case MeterOperatingHours, MeterExposureCount, MeterScanSeconds, MeterCycleCount, MeterGeneric:
+		return true
+	}
+	return false
+}
+
+// ReadingSource identifies how a meter reading entered the system
+type ReadingSource string
+
+const (
+	ReadingSourceManual  ReadingSource = "manual"
+	ReadingSourceCSV     ReadingSource = "csv"
+	ReadingSourceGateway ReadingSource = "gateway"
+)
+
+// MeterDefinition describes a usage counter for a catalog model.
+// Every installed unit linked to the catalog model inherits its meters.
+type MeterDefinition struct {
+	ID                 string    `json:"id"`
+	CatalogEquipmentID string    `json:"catalog_equipment_id"` // Link to catalog model
+	Code               string    `json:"code"`                 // Stable code used by gateways and CSV (e.g. "tube_scan_seconds")
+	Name               string    `json:"name"`
+	MeterType          MeterType `json:"meter_type"`
+	Unit               string    `json:"unit"` // hours, seconds, exposures, cycles
+
+	// RolloverAt is the value at which the physical counter wraps back to zero.
+	// Nil means the counter never rolls over.
+	RolloverAt *float64 `json:"rollover_at,omitempty"`
+
+	// MaxDailyIncrease bounds the plausible increase per 24h (0 disables the check)
+	MaxDailyIncrease float64 `json:"max_daily_increase"`
+
+	// ServiceInterval triggers preventive maintenance every N units (0 disables)
+	ServiceInterval float64 `json:"service_interval"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate checks the meter definition for required fields
+func (d *MeterDefinition) Validate() error {
+	if d.CatalogEquipmentID == "" {
+		return fmt.Errorf("catalog_equipment_id is required")
+	}
+	if d.Code == "" {
+		return fmt.Errorf("code is required")
+	}
+	if !d.MeterType.IsValid() {
+		return ErrInvalidMeterType
+	}
+	if d.RolloverAt != nil && *d.RolloverAt <= 0 {
+		return fmt.Errorf("rollover_at must be greater than zero")
+	}
+	if d.MaxDailyIncrease < 0 || d.ServiceInterval < 0 {
+		return fmt.Errorf("max_daily_increase and service_interval cannot be negative")
+	}
+	return nil
+}
+
+// MeterReading is a single observation of a meter on an installed unit
+type MeterReading struct {
+	ID                string  `json:"id"`
+	EquipmentID       string  `json:"equipment_id"` // Equipment registry ID
+	MeterDefinitionID string  `json:"meter_definition_id"`
+	MeterCode         string  `json:"meter_code"`
+	Value             float64 `json:"value"`
+
+	// Delta is the usage accumulated since the previous reading (rollover-aware)
+	Delta float64 `json:"delta"`
+
+	// CumulativeValue is the total usage since installation, unaffected by rollovers
+	CumulativeValue float64 `json:"cumulative_value"`
+
+	Rollover   bool          `json:"rollover"`
+	Source     ReadingSource `json:"source"`
+	ReadAt     time.Time     `json:"read_at"`
+	RecordedBy string        `json:"recorded_by"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// ApplyToPrevious validates a new reading against the previous reading of the same
+// meter and fills in Delta, CumulativeValue and Rollover. A nil previous reading
+// means this is the first reading for the meter.
+func (d *MeterDefinition) ApplyToPrevious(reading *MeterReading, previous *MeterReading, now time.Time) error {
+	if reading.ReadAt.After(now.Add(5 * time.Minute)) {
+		return ErrReadingInFuture
+	}
+	if reading.Value < 0 {
+		return fmt.Errorf("meter value cannot be negative")
+	}
+
+	if previous == nil {
+		reading.Delta = 0
+		reading.CumulativeValue = reading.Value
+		reading.Rollover = false
+		return nil
+	}
+
+	if reading.ReadAt.Before(previous.ReadAt) {
+		return ErrReadingOutOfOrder
+	}
+
+	delta := reading.Value - previous.Value
+	rollover := false
+	if delta < 0 {
+		// A lower value is only acceptable when the counter wraps around
+		if d.RolloverAt == nil || previous.Value < *d.RolloverAt*0.9 {
+			return ErrNonMonotonicReading
+		}
+		delta = *d.RolloverAt - previous.Value + reading.Value
+		rollover = true
+	}
+
+	if d.MaxDailyIncrease > 0 {
+		elapsedDays := reading.ReadAt.Sub(previous.ReadAt).Hours() / 24
+		if elapsedDays < 1.0/24 {
+			elapsedDays = 1.0 / 24
+		}
+		if delta > d.MaxDailyIncrease*elapsedDays {
+			return ErrImplausibleReading
+		}
+	}
+
+	reading.Delta = delta
+	reading.CumulativeValue = previous.CumulativeValue + delta
+	reading.Rollover = rollover
+	return nil
+}
+
+// UsageSnapshot summarizes the latest meter values for an installed unit
+type UsageSnapshot struct {
+	EquipmentID string                   `json:"equipment_id"`
+	Meters      []MeterUsage             `json:"meters"`
+	ByType      map[MeterType]MeterUsage `json:"-"`
+}
+
+// MeterUsage is the latest reading of one meter plus preventive maintenance status
+type MeterUsage struct {
+	MeterDefinitionID string     `json:"meter_definition_id"`
+	MeterCode         string     `json:"meter_code"`
+	Name              string     `json:"name"`
+	MeterType         MeterType  `json:"meter_type"`
+	Unit              string     `json:"unit"`
+	LatestValue       float64    `json:"latest_value"`
+	CumulativeValue   float64    `json:"cumulative_value"`
+	ReadAt            *time.Time `json:"read_at,omitempty"`
+
+	// Usage accumulated since the last recorded service
+	UsageSinceService float64 `json:"usage_since_service"`
+	ServiceInterval   float64 `json:"service_interval,omitempty"`
+	ServiceDue        bool    `json:"service_due"`
+}
+
+// ServiceDue reports whether any metered interval has been exceeded
+func (s *UsageSnapshot) ServiceDue() bool {
+	for _, m := range s.Meters {
+		if m.ServiceDue {
+			return true
+		}
+	}
+	return false
+}
+
+// MeterReadingInput is a single reading submitted manually, via CSV or by a gateway.
+// Equipment may be identified by registry ID or serial number, and the meter by
+// definition ID or code.
+type MeterReadingInput struct {
+	EquipmentID       string    `json:"equipment_id,omitempty"`
+	SerialNumber      string    `json:"serial_number,omitempty"`
+	MeterDefinitionID string    `json:"meter_definition_id,omitempty"`
+	MeterCode         string    `json:"meter_code,omitempty"`
+	Value             float64   `json:"value"`
+	ReadAt            time.Time `json:"read_at"`
+}
+
+// MeterImportResult contains results of a bulk reading ingestion
+type MeterImportResult struct {
+	TotalRows    int      `json:"total_rows"`
+	SuccessCount int      `json:"success_count"`
+	FailureCount int      `json:"failure_count"`
+	Errors       []string `json:"errors"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyToPreviousMonotonic(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	def := &MeterDefinition{Code: "hours", MeterType: MeterOperatingHours}
+	prev := &MeterReading{Value: 1000, CumulativeValue: 1000, ReadAt: now.Add(-48 * time.Hour)}
+
+	next := &MeterReading{Value: 1030, ReadAt: now}
+	if err := def.ApplyToPrevious(next, prev, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.Delta != 30 || next.CumulativeValue != 1030 || next.Rollover {
+		t.Fatalf("unexpected result: %#v", next)
+	}
+
+	lower := &MeterReading{Value: 990, ReadAt: now}
+	if err := def.ApplyToPrevious(lower, prev, now); err != ErrNonMonotonicReading {
+		t.Fatalf("expected ErrNonMonotonicReading, got %v", err)
+	}
+
+	older := &MeterReading{Value: 1010, ReadAt: now.Add(-72 * time.Hour)}
+	if err := def.ApplyToPrevious(older, prev, now); err != ErrReadingOutOfOrder {
+		t.Fatalf("expected ErrReadingOutOfOrder, got %v", err)
+	}
+}
+
+func TestApplyToPreviousRollover(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rollover := 100000.0
+	def := &MeterDefinition{Code: "exposures", MeterType: MeterExposureCount, RolloverAt: &rollover, MaxDailyIncrease: 500}
+	prev := &MeterReading{Value: 99900, CumulativeValue: 250000, ReadAt: now.Add(-24 * time.Hour)}
+
+	next := &MeterReading{Value: 150, ReadAt: now}
+	if err := def.ApplyToPrevious(next, prev, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !next.Rollover || next.Delta != 250 || next.CumulativeValue != 250250 {
+		t.Fatalf("unexpected rollover result: %#v", next)
+	}
+
+	// Drop far below the rollover point is a reset, not a wrap
+	early := &MeterReading{Value: 10, ReadAt: now}
+	midPrev := &MeterReading{Value: 40000, CumulativeValue: 40000, ReadAt: now.Add(-24 * time.Hour)}
+	if err := def.ApplyToPrevious(early, midPrev, now); err != ErrNonMonotonicReading {
+		t.Fatalf("expected ErrNonMonotonicReading, got %v", err)
+	}
+
+	// Increase beyond the plausible daily rate is rejected
+	jump := &MeterReading{Value: 99900 + 5000, ReadAt: now}
+	if err := def.ApplyToPrevious(jump, &MeterReading{Value: 99900 - 5000, ReadAt: now.Add(-24 * time.Hour)}, now); err != ErrImplausibleReading {
+		t.Fatalf("expected ErrImplausibleReading, got %v", err)
+	}
+}
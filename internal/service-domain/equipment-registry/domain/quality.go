@@ -0,0 +1,610 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMergeSameEquipment = errors.New("cannot merge equipment into itself")
+	ErrMergeConflict      = errors.New("equipment records cannot be merged")
+)
+
+var (
+	serialSeparators  = regexp.MustCompile(`[\s\-_./\\#:]+`)
+	leadingZeroDigits = regexp.MustCompile(`(^|[A-Z])0+([0-9])`)
+	nonAlphanumeric   = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
+// NormalizeSerial returns the matching key for a serial number. Case,
+// separators and zero padding of number groups are ignored, so "SN-00123",
+// "sn 00123" and "SN123" share the key "SN123".
+func NormalizeSerial(serial string) string {
+	groups := serialSeparators.Split(strings.ToUpper(strings.TrimSpace(serial)), -1)
+	for i, group := range groups {
+		groups[i] = leadingZeroDigits.ReplaceAllString(group, "$1$2")
+	}
+	return strings.Join(groups, "")
+}
+
+// CleanSerial removes whitespace and stray punctuation around a serial number
+// without changing its meaningful characters
+func CleanSerial(serial string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(strings.Trim(serial, " \t\"'.,;")), ""))
+}
+
+// legalSuffixes are dropped when comparing manufacturer names
+var legalSuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "ltd": true, "limited": true, "pvt": true, "private": true,
+	"llc": true, "corp": true, "corporation": true, "co": true, "company": true, "gmbh": true,
+	"ag": true, "sa": true, "bv": true, "nv": true, "plc": true, "kk": true,
+}
+
+// defaultManufacturerAliases maps manufacturer keys to canonical names
+var defaultManufacturerAliases = map[string]string{
+	"ge":                         "GE Healthcare",
+	"ge healthcare":              "GE Healthcare",
+	"ge medical systems":         "GE Healthcare",
+	"general electric":           "GE Healthcare",
+	"wipro ge":                   "GE Healthcare",
+	"wipro ge healthcare":        "GE Healthcare",
+	"siemens":                    "Siemens Healthineers",
+	"siemens healthineers":       "Siemens Healthineers",
+	"siemens healthcare":         "Siemens Healthineers",
+	"siemens medical":            "Siemens Healthineers",
+	"philips":                    "Philips Healthcare",
+	"philips healthcare":         "Philips Healthcare",
+	"philips medical systems":    "Philips Healthcare",
+	"canon":                      "Canon Medical Systems",
+	"canon medical":              "Canon Medical Systems",
+	"canon medical systems":      "Canon Medical Systems",
+	"toshiba":                    "Canon Medical Systems",
+	"toshiba medical":            "Canon Medical Systems",
+	"toshiba medical systems":    "Canon Medical Systems",
+	"fujifilm":                   "Fujifilm Healthcare",
+	"fuji":                       "Fujifilm Healthcare",
+	"fujifilm healthcare":        "Fujifilm Healthcare",
+	"hitachi":                    "Fujifilm Healthcare",
+	"hitachi medical":            "Fujifilm Healthcare",
+	"mindray":                    "Mindray",
+	"shenzhen mindray":           "Mindray",
+	"drager":                     "Dräger",
+	"draeger":                    "Dräger",
+	"dräger":                     "Dräger",
+	"samsung medison":            "Samsung Medison",
+	"samsung":                    "Samsung Medison",
+	"medtronic":                  "Medtronic",
+	"bpl":                        "BPL Medical Technologies",
+	"bpl medical technologies":   "BPL Medical Technologies",
+	"carestream":                 "Carestream Health",
+	"carestream health":          "Carestream Health",
+	"agfa":                       "Agfa HealthCare",
+	"agfa healthcare":            "Agfa HealthCare",
+	"ge healthcare india":        "GE Healthcare",
+	"philips india":              "Philips Healthcare",
+	"siemens healthcare private": "Siemens Healthineers",
+}
+
+// ManufacturerKey returns the comparison key of a manufacturer name: lowercase,
+// punctuation collapsed and legal suffixes removed
+func ManufacturerKey(name string) string {
+	words := strings.Fields(nonAlphanumeric.ReplaceAllString(strings.ToLower(name), " "))
+	for len(words) > 1 && legalSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// ManufacturerAlias is a configured rule mapping a spelling to a canonical manufacturer name
+type ManufacturerAlias struct {
+	Alias         string    `json:"alias"`
+	AliasKey      string    `json:"alias_key"`
+	CanonicalName string    `json:"canonical_name"`
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ManufacturerNormalizer resolves manufacturer spellings to canonical names
+// using the built-in rules plus configured aliases
+type ManufacturerNormalizer struct {
+	aliases map[string]string
+}
+
+// NewManufacturerNormalizer creates a normalizer; configured aliases override built-in rules
+func NewManufacturerNormalizer(configured []*ManufacturerAlias) *ManufacturerNormalizer {
+	aliases := make(map[string]string, len(defaultManufacturerAliases)+len(configured))
+	for k, v := range defaultManufacturerAliases {
+		aliases[ManufacturerKey(k)] = v
+	}
+	for _, a := range configured {
+		aliases[ManufacturerKey(a.Alias)] = a.CanonicalName
+	}
+	return &ManufacturerNormalizer{aliases: aliases}
+}
+
+// Canonical returns the canonical manufacturer name and whether a rule matched.
+// Unknown names are returned trimmed with single spaces.
+func (n *ManufacturerNormalizer) Canonical(name string) (string, bool) {
+	if canonical, ok := n.aliases[ManufacturerKey(name)]; ok {
+		return canonical, true
+	}
+	return strings.Join(strings.Fields(name), " "), false
+}
+
+// Key returns the comparison key of the canonical manufacturer name
+func (n *ManufacturerNormalizer) Key(name string) string {
+	canonical, _ := n.Canonical(name)
+	return ManufacturerKey(canonical)
+}
+
+// DuplicateCandidate is a pair of registry records that likely describe the same unit
+type DuplicateCandidate struct {
+	Equipment  *Equipment `json:"equipment"`
+	Duplicate  *Equipment `json:"duplicate"`
+	Score      float64    `json:"score"` // 0-100
+	Reasons    []string   `json:"reasons"`
+	SerialKey  string     `json:"serial_key"`
+	Suggestion string     `json:"suggestion"` // ID of the suggested surviving record
+}
+
+// DuplicateOptions controls duplicate candidate detection
+type DuplicateOptions struct {
+	CustomerID string
+	MinScore   float64
+	Limit      int
+}
+
+// FindDuplicateCandidates detects likely duplicates among equipment records.
+// Serial keys are compared exactly and within one edit (insert, delete or
+// substitute a character) using deletion neighbourhoods, so the search stays
+// linear in the number of records.
+func FindDuplicateCandidates(records []*Equipment, normalizer *ManufacturerNormalizer, opts DuplicateOptions) []*DuplicateCandidate {
+	if opts.MinScore <= 0 {
+		opts.MinScore = 60
+	}
+
+	keys := make([]string, len(records))
+	index := map[string][]int{}
+	for i, e := range records {
+		keys[i] = NormalizeSerial(e.SerialNumber)
+		if len(keys[i]) < 4 {
+			continue // Too short to compare meaningfully
+		}
+		for _, variant := range deletionVariants(keys[i]) {
+			index[variant] = append(index[variant], i)
+		}
+	}
+
+	seen := map[[2]int]bool{}
+	candidates := []*DuplicateCandidate{}
+	for _, bucket := range index {
+		if len(bucket) < 2 {
+			continue
+		}
+		for x := 0; x < len(bucket); x++ {
+			for y := x + 1; y < len(bucket); y++ {
+				i, j := bucket[x], bucket[y]
+				if i > j {
+					i, j = j, i
+				}
+				pair := [2]int{i, j}
+				if i == j || seen[pair] {
+					continue
+				}
+				seen[pair] = true
+
+				if c := scoreDuplicate(records[i], records[j], keys[i], keys[j], normalizer); c != nil && c.Score >= opts.MinScore {
+					candidates = append(candidates, c)
+				}
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].Score != candidates[b].Score {
+			return candidates[a].Score > candidates[b].Score
+		}
+		return candidates[a].SerialKey < candidates[b].SerialKey
+	})
+
+	if opts.Limit > 0 && len(candidates) > opts.Limit {
+		candidates = candidates[:opts.Limit]
+	}
+	return candidates
+}
+
+// scoreDuplicate scores a pair of records whose serial keys share a deletion variant
+func scoreDuplicate(a, b *Equipment, keyA, keyB string, normalizer *ManufacturerNormalizer) *DuplicateCandidate {
+	reasons := []string{}
+	score := 0.0
+
+	switch {
+	case a.SerialNumber == b.SerialNumber:
+		score += 60
+		reasons = append(reasons, "identical serial number")
+	case keyA == keyB:
+		score += 55
+		reasons = append(reasons, "serial numbers differ only in case, separators or zero padding")
+	default:
+		points, reason, ok := nearSerialMatch(keyA, keyB)
+		if !ok {
+			return nil
+		}
+		score += points
+		reasons = append(reasons, reason)
+	}
+
+	mfrA, mfrB := normalizer.Key(a.ManufacturerName), normalizer.Key(b.ManufacturerName)
+	switch {
+	case mfrA != "" && mfrA == mfrB:
+		score += 20
+		if ManufacturerKey(a.ManufacturerName) != ManufacturerKey(b.ManufacturerName) {
+			reasons = append(reasons, "manufacturer spellings resolve to the same manufacturer")
+		} else {
+			reasons = append(reasons, "same manufacturer")
+		}
+	case mfrA != "" && mfrB != "":
+		// Different manufacturers with near-identical serials are common and not duplicates
+		return nil
+	}
+
+	if modelA, modelB := NormalizeSerial(a.ModelNumber), NormalizeSerial(b.ModelNumber); modelA != "" && modelA == modelB {
+		score += 10
+		reasons = append(reasons, "same model")
+	}
+
+	switch {
+	case a.CustomerID != "" && a.CustomerID == b.CustomerID:
+		score += 10
+		reasons = append(reasons, "same customer")
+	case a.CustomerID == "" && b.CustomerID == "" && ManufacturerKey(a.CustomerName) != "" &&
+		ManufacturerKey(a.CustomerName) == ManufacturerKey(b.CustomerName):
+		score += 10
+		reasons = append(reasons, "same customer name")
+	}
+
+	survivor, duplicate := a, b
+	if PreferredSurvivor(b, a) {
+		survivor, duplicate = b, a
+	}
+
+	return &DuplicateCandidate{
+		Equipment:  survivor,
+		Duplicate:  duplicate,
+		Score:      score,
+		Reasons:    reasons,
+		SerialKey:  keyA,
+		Suggestion: survivor.ID,
+	}
+}
+
+// confusableChars are characters commonly mistyped or misread for each other on labels
+var confusableChars = map[[2]byte]bool{
+	{'O', '0'}: true, {'0', 'O'}: true, {'D', '0'}: true, {'0', 'D'}: true,
+	{'I', '1'}: true, {'1', 'I'}: true, {'L', '1'}: true, {'1', 'L'}: true,
+	{'S', '5'}: true, {'5', 'S'}: true, {'B', '8'}: true, {'8', 'B'}: true,
+	{'Z', '2'}: true, {'2', 'Z'}: true, {'G', '6'}: true, {'6', 'G'}: true,
+}
+
+// nearSerialMatch classifies serial keys that share a deletion variant. A single
+// substituted digit is treated as a different unit, since consecutive serials of
+// one shipment differ exactly that way.
+func nearSerialMatch(keyA, keyB string) (float64, string, bool) {
+	if len(keyA) != len(keyB) {
+		return 35, "serial numbers differ by one extra or missing character", true
+	}
+
+	diff := []int{}
+	for i := 0; i < len(keyA); i++ {
+		if keyA[i] != keyB[i] {
+			diff = append(diff, i)
+		}
+	}
+
+	switch len(diff) {
+	case 1:
+		x, y := keyA[diff[0]], keyB[diff[0]]
+		if confusableChars[[2]byte{x, y}] {
+			return 45, "serial numbers differ by a look-alike character", true
+		}
+		if isDigit(x) && isDigit(y) {
+			return 0, "", false
+		}
+		return 30, "serial numbers differ by one character", true
+	case 2:
+		if diff[1] == diff[0]+1 && keyA[diff[0]] == keyB[diff[1]] && keyA[diff[1]] == keyB[diff[0]] {
+			return 40, "serial numbers differ by two swapped characters", true
+		}
+	}
+	return 0, "", false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// PreferredSurvivor reports whether a should survive a merge with b: the more
+// complete record wins, then the one with more service history, then the older one
+func PreferredSurvivor(a, b *Equipment) bool {
+	if ca, cb := Completeness(a), Completeness(b); ca != cb {
+		return ca > cb
+	}
+	if a.ServiceCount != b.ServiceCount {
+		return a.ServiceCount > b.ServiceCount
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// deletionVariants returns the key and every variant with one character removed
+func deletionVariants(key string) []string {
+	variants := make([]string, 0, len(key)+1)
+	variants = append(variants, key)
+	for i := range key {
+		variants = append(variants, key[:i]+key[i+1:])
+	}
+	return variants
+}
+
+// qualityFields are the attributes counted towards record completeness
+var qualityFields = []func(e *Equipment) bool{
+	func(e *Equipment) bool { return strings.TrimSpace(e.SerialNumber) != "" },
+	func(e *Equipment) bool { return strings.TrimSpace(e.EquipmentName) != "" },
+	func(e *Equipment) bool { return strings.TrimSpace(e.ManufacturerName) != "" },
+	func(e *Equipment) bool { return strings.TrimSpace(e.ModelNumber) != "" },
+	func(e *Equipment) bool { return strings.TrimSpace(e.Category) != "" },
+	func(e *Equipment) bool { return e.CustomerID != "" },
+	func(e *Equipment) bool { return strings.TrimSpace(e.InstallationLocation) != "" },
+	func(e *Equipment) bool { return e.InstallationDate != nil },
+	func(e *Equipment) bool { return e.EquipmentID != "" },
+	func(e *Equipment) bool { return e.WarrantyExpiry != nil || e.AMCContractID != "" },
+}
+
+// Completeness returns the share of quality fields filled in (0-1)
+func Completeness(e *Equipment) float64 {
+	filled := 0
+	for _, f := range qualityFields {
+		if f(e) {
+			filled++
+		}
+	}
+	return float64(filled) / float64(len(qualityFields))
+}
+
+// QualityIssue counts records affected by one data quality problem
+type QualityIssue struct {
+	Code  string `json:"code"`
+	Count int    `json:"count"`
+}
+
+// QualityScore summarizes installed-base data quality for one organization
+type QualityScore struct {
+	CustomerID     string         `json:"customer_id"`
+	CustomerName   string         `json:"customer_name"`
+	TotalEquipment int            `json:"total_equipment"`
+	Score          float64        `json:"score"`        // 0-100 weighted overall score
+	Completeness   float64        `json:"completeness"` // 0-100
+	Validity       float64        `json:"validity"`     // 0-100
+	Uniqueness     float64        `json:"uniqueness"`   // 0-100
+	DuplicatePairs int            `json:"duplicate_pairs"`
+	Issues         []QualityIssue `json:"issues"`
+}
+
+// ScoreQuality computes the quality score of one organization's records.
+// Completeness weighs 50%, validity 25% and uniqueness 25%.
+func ScoreQuality(customerID string, records []*Equipment, normalizer *ManufacturerNormalizer, duplicates []*DuplicateCandidate) *QualityScore {
+	score := &QualityScore{CustomerID: customerID, TotalEquipment: len(records), Issues: []QualityIssue{}}
+	if len(records) == 0 {
+		score.Score, score.Completeness, score.Validity, score.Uniqueness = 100, 100, 100, 100
+		return score
+	}
+
+	issues := map[string]int{}
+	completeness := 0.0
+	valid := 0
+	now := time.Now()
+
+	for _, e := range records {
+		if score.CustomerName == "" {
+			score.CustomerName = e.CustomerName
+		}
+		completeness += Completeness(e)
+
+		ok := true
+		if e.SerialNumber != CleanSerial(e.SerialNumber) {
+			issues["serial_not_normalized"]++
+			ok = false
+		}
+		if canonical, known := normalizer.Canonical(e.ManufacturerName); known && canonical != e.ManufacturerName {
+			issues["manufacturer_not_canonical"]++
+			ok = false
+		}
+		if e.InstallationDate != nil && e.InstallationDate.After(now) {
+			issues["installation_date_in_future"]++
+			ok = false
+		}
+		if e.InstallationDate != nil && e.PurchaseDate != nil && e.InstallationDate.Before(*e.PurchaseDate) {
+			issues["installed_before_purchase"]++
+			ok = false
+		}
+		if e.WarrantyExpiry != nil && e.PurchaseDate != nil && e.WarrantyExpiry.Before(*e.PurchaseDate) {
+			issues["warranty_before_purchase"]++
+			ok = false
+		}
+		if e.CustomerID == "" {
+			issues["missing_customer_id"]++
+		}
+		if e.EquipmentID == "" {
+			issues["missing_catalog_link"]++
+		}
+		if e.InstallationDate == nil {
+			issues["missing_installation_date"]++
+		}
+		if ok {
+			valid++
+		}
+	}
+
+	duplicated := map[string]bool{}
+	for _, d := range duplicates {
+		duplicated[d.Duplicate.ID] = true
+	}
+	if len(duplicated) > 0 {
+		issues["possible_duplicate"] = len(duplicated)
+	}
+
+	n := float64(len(records))
+	score.Completeness = round1(completeness / n * 100)
+	score.Validity = round1(float64(valid) / n * 100)
+	score.Uniqueness = round1((n - float64(len(duplicated))) / n * 100)
+	score.DuplicatePairs = len(duplicates)
+	score.Score = round1(score.Completeness*0.5 + score.Validity*0.25 + score.Uniqueness*0.25)
+
+	for code, count := range issues {
+		score.Issues = append(score.Issues, QualityIssue{Code: code, Count: count})
+	}
+	sort.Slice(score.Issues, func(i, j int) bool {
+		if score.Issues[i].Count != score.Issues[j].Count {
+			return score.Issues[i].Count > score.Issues[j].Count
+		}
+		return score.Issues[i].Code < score.Issues[j].Code
+	})
+
+	return score
+}
+
+func round1(v float64) float64 {
+	return float64(int(v*10+0.5)) / 10
+}
+
+// MergeInto fills empty fields of the survivor from the duplicate and combines
+// photos, documents and service history
+func MergeInto(survivor, duplicate *Equipment) {
+	fill := func(dst *string, src string) {
+		if strings.TrimSpace(*dst) == "" {
+			*dst = src
+		}
+	}
+	fill(&survivor.EquipmentID, duplicate.EquipmentID)
+	fill(&survivor.EquipmentName, duplicate.EquipmentName)
+	fill(&survivor.ManufacturerName, duplicate.ManufacturerName)
+	fill(&survivor.ModelNumber, duplicate.ModelNumber)
+	fill(&survivor.Category, duplicate.Category)
+	fill(&survivor.CustomerID, duplicate.CustomerID)
+	fill(&survivor.CustomerName, duplicate.CustomerName)
+	fill(&survivor.InstallationLocation, duplicate.InstallationLocation)
+	fill(&survivor.ContractID, duplicate.ContractID)
+	fill(&survivor.AMCContractID, duplicate.AMCContractID)
+
+	if survivor.InstallationDate == nil {
+		survivor.InstallationDate = duplicate.InstallationDate
+	}
+	if survivor.PurchaseDate == nil {
+		survivor.PurchaseDate = duplicate.PurchaseDate
+	}
+	if survivor.PurchasePrice == 0 {
+		survivor.PurchasePrice = duplicate.PurchasePrice
+	}
+	if survivor.WarrantyExpiry == nil || (duplicate.WarrantyExpiry != nil && duplicate.WarrantyExpiry.After(*survivor.WarrantyExpiry)) {
+		survivor.WarrantyExpiry = duplicate.WarrantyExpiry
+	}
+	if len(survivor.InstallationAddress) == 0 {
+		survivor.InstallationAddress = duplicate.InstallationAddress
+	}
+	if survivor.LastServiceDate == nil || (duplicate.LastServiceDate != nil && duplicate.LastServiceDate.After(*survivor.LastServiceDate)) {
+		survivor.LastServiceDate = duplicate.LastServiceDate
+	}
+	if survivor.NextServiceDate == nil {
+		survivor.NextServiceDate = duplicate.NextServiceDate
+	}
+	survivor.ServiceCount += duplicate.ServiceCount
+
+	if survivor.Specifications == nil {
+		survivor.Specifications = map[string]interface{}{}
+	}
+	for k, v := range duplicate.Specifications {
+		if _, ok := survivor.Specifications[k]; !ok {
+			survivor.Specifications[k] = v
+		}
+	}
+	survivor.Photos = appendUnique(survivor.Photos, duplicate.Photos)
+	survivor.Documents = appendUnique(survivor.Documents, duplicate.Documents)
+
+	if duplicate.Notes != "" && !strings.Contains(survivor.Notes, duplicate.Notes) {
+		if survivor.Notes != "" {
+			survivor.Notes += "\n"
+		}
+		survivor.Notes += duplicate.Notes
+	}
+	survivor.UpdatedAt = time.Now()
+}
+
+func appendUnique(dst, src []string) []string {
+	seen := make(map[string]bool, len(dst))
+	for _, v := range dst {
+		seen[v] = true
+	}
+	for _, v := range src {
+		if !seen[v] {
+			dst = append(dst, v)
+			seen[v] = true
+		}
+	}
+	return dst
+}
+
+// EquipmentMerge records a merge of a duplicate into a surviving record.
+// The duplicate's QR code and serial keep resolving to the survivor.
+type EquipmentMerge struct {
+	ID              string           `json:"id"`
+	SurvivorID      string           `json:"survivor_id"`
+	MergedID        string           `json:"merged_id"`
+	MergedSerial    string           `json:"merged_serial"`
+	MergedQRCode    string           `json:"merged_qr_code"`
+	Snapshot        *Equipment       `json:"snapshot"` // Duplicate as it was before the merge
+	MovedReferences map[string]int64 `json:"moved_references"`
+	Conflicts       []MergeConflict  `json:"conflicts,omitempty"`
+	Reason          string           `json:"reason"`
+	MergedBy        string           `json:"merged_by"`
+	MergedAt        time.Time        `json:"merged_at"`
+}
+
+// MergeConflict is a reference of the duplicate that could not move to the
+// survivor because the survivor already has one. The duplicate's row is kept
+// in the merge record.
+type MergeConflict struct {
+	Table  string          `json:"table"`
+	Detail string          `json:"detail"`
+	Row    json.RawMessage `json:"row"`
+}
+
+// QualityRepository defines persistence for data quality tooling
+type QualityRepository interface {
+	// ListForQuality retrieves the caller organization's registry records
+	// without images, optionally for one customer
+	ListForQuality(ctx context.Context, customerID string) ([]*Equipment, error)
+
+	// ListManufacturerAliases retrieves configured manufacturer aliases
+	ListManufacturerAliases(ctx context.Context) ([]*ManufacturerAlias, error)
+
+	// UpsertManufacturerAlias creates or replaces a manufacturer alias
+	UpsertManufacturerAlias(ctx context.Context, alias *ManufacturerAlias) error
+
+	// RenameManufacturer sets manufacturer_name on the given records of the
+	// caller's organization
+	RenameManufacturer(ctx context.Context, ids []string, name string) (int64, error)
+
+	// UpdateSerialNumber sets a cleaned serial number on a record of the caller's organization
+	UpdateSerialNumber(ctx context.Context, id, serial string) error
+
+	// Merge saves the merged survivor, re-points references from the duplicate,
+	// records the merge and deletes the duplicate in a single transaction
+	Merge(ctx context.Context, survivor, duplicate *Equipment, merge *EquipmentMerge) error
+
+	// ListMerges retrieves merges into a surviving record
+	ListMerges(ctx context.Context, survivorID string) ([]*EquipmentMerge, error)
+}
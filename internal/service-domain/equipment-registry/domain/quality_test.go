@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestNormalizeSerial(t *testing.T) {
+	cases := map[string]string{
+		"SN-00123":    "SN123",
+		"sn 00123":    "SN123",
+		"SN00123":     "SN123",
+		"ab.12/0045":  "AB1245",
+		" 000789 ":    "789",
+		"CT-2020_001": "CT20201",
+	}
+	for in, want := range cases {
+		if got := NormalizeSerial(in); got != want {
+			t.Errorf("NormalizeSerial(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestManufacturerNormalizer(t *testing.T) {
+	n := NewManufacturerNormalizer([]*ManufacturerAlias{{Alias: "Trivitron Healthcare Pvt. Ltd.", CanonicalName: "Trivitron"}})
+
+	cases := map[string]string{
+		"GE":                      "GE Healthcare",
+		"Wipro GE Healthcare":     "GE Healthcare",
+		"General Electric Co.":    "GE Healthcare",
+		"Toshiba":                 "Canon Medical Systems",
+		"Draeger":                 "Dräger",
+		"trivitron healthcare":    "Trivitron",
+		"  Acme   Medical Inc.  ": "Acme Medical Inc.",
+	}
+	for in, want := range cases {
+		if got, _ := n.Canonical(in); got != want {
+			t.Errorf("Canonical(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFindDuplicateCandidates(t *testing.T) {
+	n := NewManufacturerNormalizer(nil)
+	records := []*Equipment{
+		{ID: "a", SerialNumber: "SN-00123", ManufacturerName: "GE Healthcare", ModelNumber: "Optima", CustomerID: "h1"},
+		{ID: "b", SerialNumber: "SN00123", ManufacturerName: "GE", ModelNumber: "OPTIMA", CustomerID: "h1"},
+		{ID: "c", SerialNumber: "SN00124", ManufacturerName: "GE", ModelNumber: "Optima", CustomerID: "h1"},
+		{ID: "d", SerialNumber: "SN12O45", ManufacturerName: "Siemens", CustomerID: "h1"},
+		{ID: "e", SerialNumber: "SN12045", ManufacturerName: "Siemens Healthineers", CustomerID: "h1"},
+		{ID: "f", SerialNumber: "SN-00123", ManufacturerName: "Philips", CustomerID: "h2"},
+	}
+
+	candidates := FindDuplicateCandidates(records, n, DuplicateOptions{})
+
+	pairs := map[string]bool{}
+	for _, c := range candidates {
+		pairs[c.Equipment.ID+c.Duplicate.ID] = true
+		pairs[c.Duplicate.ID+c.Equipment.ID] = true
+	}
+
+	if !pairs["ab"] {
+		t.Errorf("expected a/b to be duplicates (separator and manufacturer spelling)")
+	}
+	if !pairs["de"] {
+		t.Errorf("expected d/e to be duplicates (look-alike character)")
+	}
+	if pairs["bc"] || pairs["ac"] {
+		t.Errorf("sequential serials must not be reported as duplicates")
+	}
+	if pairs["af"] || pairs["bf"] {
+		t.Errorf("different manufacturers must not be reported as duplicates")
+	}
+	if len(candidates) > 0 && candidates[0].Score < candidates[len(candidates)-1].Score {
+		t.Errorf("candidates must be sorted by score")
+	}
+}
+
+func TestMergeInto(t *testing.T) {
+	survivor := &Equipment{ID: "a", SerialNumber: "SN123", Photos: []string{"p1"}, ServiceCount: 2}
+	duplicate := &Equipment{ID: "b", SerialNumber: "SN-123", ModelNumber: "X1", Photos: []string{"p1", "p2"}, ServiceCount: 3, Notes: "dealer import"}
+
+	MergeInto(survivor, duplicate)
+
+	if survivor.ModelNumber != "X1" || survivor.ServiceCount != 5 || len(survivor.Photos) != 2 || survivor.Notes != "dealer import" {
+		t.Fatalf("unexpected merge result: %#v", survivor)
+	}
+	if survivor.SerialNumber != "SN123" {
+		t.Fatalf("survivor serial must be kept, got %q", survivor.SerialNumber)
+	}
+}
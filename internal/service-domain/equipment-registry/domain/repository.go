@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the interface for equipment persistence
 type Repository interface {
@@ -36,6 +39,55 @@ type Repository interface {
 
     // SetQRCodeBySerial maps a QR code (and URL) to an equipment by serial number
     SetQRCodeBySerial(ctx context.Context, serial, qrCode, qrURL string) error
+
+	// GetBySerialNumbers retrieves equipment in scope for a batch of serial
+	// numbers, keyed by serial number; serials merged away resolve to the
+	// surviving equipment. Serials registered outside the scope map to nil.
+	GetBySerialNumbers(ctx context.Context, scope OrgScope, serialNumbers []string) (map[string]*Equipment, error)
+
+	// BulkUpsert creates equipment and updates equipment in scope in a single transaction
+	BulkUpsert(ctx context.Context, scope OrgScope, creates, updates []*Equipment) error
+}
+
+// OrgScope is the organization an operation acts for, captured from the
+// request that started it so that background work applies the same filter.
+// An empty scope, as for system admins, is not filtered.
+type OrgScope struct {
+	OrganizationID   string `json:"organization_id,omitempty"`
+	OrganizationType string `json:"organization_type,omitempty"`
+}
+
+// MeterRepository defines the interface for meter definition and reading persistence
+type MeterRepository interface {
+	// CreateDefinition creates a meter definition for a catalog model
+	CreateDefinition(ctx context.Context, def *MeterDefinition) error
+
+	// UpdateDefinition updates a meter definition
+	UpdateDefinition(ctx context.Context, def *MeterDefinition) error
+
+	// GetDefinition retrieves a meter definition by ID
+	GetDefinition(ctx context.Context, id string) (*MeterDefinition, error)
+
+	// GetDefinitionByCode retrieves a meter definition by catalog model and code
+	GetDefinitionByCode(ctx context.Context, catalogEquipmentID, code string) (*MeterDefinition, error)
+
+	// ListDefinitions retrieves all meter definitions for a catalog model
+	ListDefinitions(ctx context.Context, catalogEquipmentID string) ([]*MeterDefinition, error)
+
+	// CreateReading stores a validated meter reading
+	CreateReading(ctx context.Context, reading *MeterReading) error
+
+	// GetLatestReading retrieves the most recent reading of a meter on an installed unit
+	GetLatestReading(ctx context.Context, equipmentID, meterDefinitionID string) (*MeterReading, error)
+
+	// GetReadingAsOf retrieves the most recent reading taken at or before the given time
+	GetReadingAsOf(ctx context.Context, equipmentID, meterDefinitionID string, asOf time.Time) (*MeterReading, error)
+
+	// ListReadings retrieves readings of an installed unit, newest first
+	ListReadings(ctx context.Context, equipmentID, meterDefinitionID string, limit int) ([]*MeterReading, error)
+
+	// ListMeteredEquipmentIDs returns installed units that have at least one reading
+	ListMeteredEquipmentIDs(ctx context.Context) ([]string, error)
 }
 
 // ListCriteria defines filtering criteria for listing equipment
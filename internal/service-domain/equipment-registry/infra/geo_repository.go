@@ -0,0 +1,167 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// gazetteerBatchSize bounds the number of statements sent per batch on import
+const gazetteerBatchSize = 1000
+
+// GeoRepository implements the domain.GeoRepository interface
+type GeoRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewGeoRepository creates a new gazetteer and installation coordinate repository
+func NewGeoRepository(pool *pgxpool.Pool) *GeoRepository {
+	return &GeoRepository{pool: pool}
+}
+
+// ImportPostalPlaces creates or replaces gazetteer entries
+func (r *GeoRepository) ImportPostalPlaces(ctx context.Context, places []geo.PostalPlace) (int, error) {
+	imported := 0
+	for start := 0; start < len(places); start += gazetteerBatchSize {
+		end := start + gazetteerBatchSize
+		if end > len(places) {
+			end = len(places)
+		}
+
+		batch := &pgx.Batch{}
+		for _, p := range places[start:end] {
+			batch.Queue(`
+				INSERT INTO geo_postal_codes (
+					country, postal_code, place_name, region, district, latitude, longitude, updated_at
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+				ON CONFLICT (country, postal_code, place_name) DO UPDATE SET
+					region = EXCLUDED.region, district = EXCLUDED.district,
+					latitude = EXCLUDED.latitude, longitude = EXCLUDED.longitude,
+					updated_at = NOW()`,
+				p.Country, geo.NormalizePostalCode(p.PostalCode), p.PlaceName, p.Region, p.District,
+				p.Point.Lat, p.Point.Lng,
+			)
+		}
+
+		if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+			return imported, fmt.Errorf("failed to import gazetteer entries: %w", err)
+		}
+		imported += end - start
+	}
+	return imported, nil
+}
+
+// ListPostalPlaces retrieves the full gazetteer
+func (r *GeoRepository) ListPostalPlaces(ctx context.Context) ([]geo.PostalPlace, error) {
+	query := `
+		SELECT country, postal_code, COALESCE(place_name,''), COALESCE(region,''),
+			COALESCE(district,''), latitude, longitude
+		FROM geo_postal_codes
+	`
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gazetteer entries: %w", err)
+	}
+	defer rows.Close()
+
+	places := []geo.PostalPlace{}
+	for rows.Next() {
+		var p geo.PostalPlace
+		if err := rows.Scan(&p.Country, &p.PostalCode, &p.PlaceName, &p.Region, &p.District, &p.Point.Lat, &p.Point.Lng); err != nil {
+			return nil, fmt.Errorf("failed to scan gazetteer entry: %w", err)
+		}
+		places = append(places, p)
+	}
+	return places, rows.Err()
+}
+
+// LookupPostalCode resolves one postal code to the centroid of its places
+func (r *GeoRepository) LookupPostalCode(ctx context.Context, country, postalCode string) (geo.PostalPlace, bool, error) {
+	query := `
+		SELECT country, postal_code, MIN(COALESCE(region,'')), MIN(COALESCE(district,'')),
+			AVG(latitude), AVG(longitude)
+		FROM geo_postal_codes
+		WHERE postal_code = $1 AND ($2 = '' OR country = $2)
+		GROUP BY country, postal_code
+		LIMIT 2
+	`
+	rows, err := r.pool.Query(ctx, query, geo.NormalizePostalCode(postalCode), strings.ToUpper(strings.TrimSpace(country)))
+	if err != nil {
+		return geo.PostalPlace{}, false, fmt.Errorf("failed to look up postal code: %w", err)
+	}
+	defer rows.Close()
+
+	places := []geo.PostalPlace{}
+	for rows.Next() {
+		var p geo.PostalPlace
+		if err := rows.Scan(&p.Country, &p.PostalCode, &p.Region, &p.District, &p.Point.Lat, &p.Point.Lng); err != nil {
+			return geo.PostalPlace{}, false, fmt.Errorf("failed to scan postal code: %w", err)
+		}
+		places = append(places, p)
+	}
+	if err := rows.Err(); err != nil {
+		return geo.PostalPlace{}, false, err
+	}
+	if len(places) != 1 {
+		return geo.PostalPlace{}, false, nil
+	}
+	return places[0], true, nil
+}
+
+// ListInstallations retrieves installation addresses, optionally only those without coordinates
+func (r *GeoRepository) ListInstallations(ctx context.Context, missingOnly bool) ([]*domain.InstallationGeo, error) {
+	query := `
+		SELECT id, COALESCE(serial_number,''), COALESCE(installation_location,''),
+			COALESCE(installation_address,'{}'::jsonb)
+		FROM equipment_registry
+	`
+	if missingOnly {
+		query += ` WHERE NOT (COALESCE(installation_address,'{}'::jsonb) ? 'latitude')`
+	}
+	query += ` ORDER BY created_at`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installations: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*domain.InstallationGeo{}
+	for rows.Next() {
+		var inst domain.InstallationGeo
+		var address []byte
+		if err := rows.Scan(&inst.ID, &inst.SerialNumber, &inst.InstallationLocation, &address); err != nil {
+			return nil, fmt.Errorf("failed to scan installation: %w", err)
+		}
+		if len(address) > 0 {
+			json.Unmarshal(address, &inst.InstallationAddress)
+		}
+		result = append(result, &inst)
+	}
+	return result, rows.Err()
+}
+
+// UpdateInstallationAddress replaces the installation address of an equipment record
+func (r *GeoRepository) UpdateInstallationAddress(ctx context.Context, id string, address map[string]interface{}) error {
+	data, err := json.Marshal(address)
+	if err != nil {
+		return fmt.Errorf("failed to encode installation address: %w", err)
+	}
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE equipment_registry SET installation_address = $2, updated_at = NOW() WHERE id = $1`,
+		id, data,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update installation address: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrEquipmentNotFound
+	}
+	return nil
+}
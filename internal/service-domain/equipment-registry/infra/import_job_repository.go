@@ -0,0 +1,251 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const importJobColumns = `
+    id, status, file_name, format, file_path, options, total_rows, created_count,
+    updated_count, skipped_count, failure_count, checkpoint_row, COALESCE(error,''),
+    COALESCE(created_by,''), created_at, started_at, completed_at, updated_at`
+
+// ImportJobRepository implements the domain.ImportJobRepository interface
+type ImportJobRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewImportJobRepository creates a new import job repository
+func NewImportJobRepository(pool *pgxpool.Pool) *ImportJobRepository {
+	return &ImportJobRepository{pool: pool}
+}
+
+// CreateJob creates an import job
+func (r *ImportJobRepository) CreateJob(ctx context.Context, job *domain.ImportJob) error {
+	options, err := json.Marshal(job.Options)
+	if err != nil {
+		return fmt.Errorf("failed to marshal import options: %w", err)
+	}
+
+	query := `
+		INSERT INTO equipment_import_jobs (
+			id, status, file_name, format, file_path, options, total_rows, created_count,
+			updated_count, skipped_count, failure_count, checkpoint_row, error,
+			created_by, created_at, started_at, completed_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`
+	_, err = r.pool.Exec(ctx, query,
+		job.ID, job.Status, job.FileName, job.Format, job.FilePath, options,
+		job.TotalRows, job.CreatedCount, job.UpdatedCount, job.SkippedCount, job.FailureCount,
+		job.CheckpointRow, job.Error, job.CreatedBy, job.CreatedAt, job.StartedAt,
+		job.CompletedAt, job.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create import job: %w", err)
+	}
+	return nil
+}
+
+// UpdateJob updates status, counters and checkpoint of an import job
+func (r *ImportJobRepository) UpdateJob(ctx context.Context, job *domain.ImportJob) error {
+	query := `
+		UPDATE equipment_import_jobs SET
+			status = $2, total_rows = $3, created_count = $4, updated_count = $5,
+			skipped_count = $6, failure_count = $7, checkpoint_row = $8, error = $9,
+			started_at = $10, completed_at = $11, updated_at = $12
+		WHERE id = $1
+	`
+	job.UpdatedAt = time.Now()
+	tag, err := r.pool.Exec(ctx, query,
+		job.ID, job.Status, job.TotalRows, job.CreatedCount, job.UpdatedCount,
+		job.SkippedCount, job.FailureCount, job.CheckpointRow, job.Error,
+		job.StartedAt, job.CompletedAt, job.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update import job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrImportJobNotFound
+	}
+	return nil
+}
+
+// jobScope returns a condition on parameter $n limiting jobs to the ones
+// started by the request's organization. System admins see every job.
+func jobScope(ctx context.Context, n int) (string, string, bool) {
+	scope := requestScope(ctx)
+	if scope.OrganizationID == "" {
+		return "", "", false
+	}
+	return fmt.Sprintf("options->'scope'->>'organization_id' = $%d", n), scope.OrganizationID, true
+}
+
+// GetJob retrieves an import job of the request's organization by ID
+func (r *ImportJobRepository) GetJob(ctx context.Context, id string) (*domain.ImportJob, error) {
+	query := `SELECT ` + importJobColumns + ` FROM equipment_import_jobs WHERE id = $1`
+	args := []interface{}{id}
+	if condition, orgID, ok := jobScope(ctx, 2); ok {
+		query += ` AND ` + condition
+		args = append(args, orgID)
+	}
+	job, err := scanImportJob(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+	return job, nil
+}
+
+// ListJobs retrieves recent import jobs of the request's organization, newest first
+func (r *ImportJobRepository) ListJobs(ctx context.Context, limit int) ([]*domain.ImportJob, error) {
+	if limit < 1 {
+		limit = 50
+	}
+	where := ""
+	args := []interface{}{limit}
+	if condition, orgID, ok := jobScope(ctx, 2); ok {
+		where = `WHERE ` + condition
+		args = append(args, orgID)
+	}
+	query := `SELECT ` + importJobColumns + `
+		FROM equipment_import_jobs
+		` + where + `
+		ORDER BY created_at DESC
+		LIMIT $1`
+	return r.queryJobs(ctx, query, args...)
+}
+
+// ListJobsByStatus retrieves import jobs in the given status
+func (r *ImportJobRepository) ListJobsByStatus(ctx context.Context, status domain.ImportJobStatus) ([]*domain.ImportJob, error) {
+	query := `SELECT ` + importJobColumns + `
+		FROM equipment_import_jobs
+		WHERE status = $1
+		ORDER BY created_at`
+	return r.queryJobs(ctx, query, status)
+}
+
+// AddRowResults stores row results (errors and warnings) of a job
+func (r *ImportJobRepository) AddRowResults(ctx context.Context, jobID string, rows []domain.ImportRowResult) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, row := range rows {
+		errs, _ := json.Marshal(row.Errors)
+		warnings, _ := json.Marshal(row.Warnings)
+		batch.Queue(`
+			INSERT INTO equipment_import_job_rows (
+				job_id, row_number, serial_number, action, equipment_id, errors, warnings
+			) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (job_id, row_number) DO UPDATE SET
+				serial_number = EXCLUDED.serial_number, action = EXCLUDED.action,
+				equipment_id = EXCLUDED.equipment_id, errors = EXCLUDED.errors,
+				warnings = EXCLUDED.warnings`,
+			jobID, row.Row, row.SerialNumber, row.Action, row.EquipmentID, errs, warnings,
+		)
+	}
+
+	if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to store import row results: %w", err)
+	}
+	return nil
+}
+
+// ListRowResults retrieves stored row results of a job, optionally filtered by action
+func (r *ImportJobRepository) ListRowResults(ctx context.Context, jobID string, action domain.ImportRowAction, limit, offset int) ([]domain.ImportRowResult, error) {
+	if limit < 1 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `
+		SELECT row_number, COALESCE(serial_number,''), action, COALESCE(equipment_id,''), errors, warnings
+		FROM equipment_import_job_rows
+		WHERE job_id = $1 AND ($2 = '' OR action = $2)
+		ORDER BY row_number
+		LIMIT $3 OFFSET $4`
+	rows, err := r.pool.Query(ctx, query, jobID, string(action), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import row results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []domain.ImportRowResult{}
+	for rows.Next() {
+		var res domain.ImportRowResult
+		var errs, warnings []byte
+		if err := rows.Scan(&res.Row, &res.SerialNumber, &res.Action, &res.EquipmentID, &errs, &warnings); err != nil {
+			return nil, fmt.Errorf("failed to scan import row result: %w", err)
+		}
+		if len(errs) > 0 {
+			json.Unmarshal(errs, &res.Errors)
+		}
+		if len(warnings) > 0 {
+			json.Unmarshal(warnings, &res.Warnings)
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+func (r *ImportJobRepository) queryJobs(ctx context.Context, query string, args ...interface{}) ([]*domain.ImportJob, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*domain.ImportJob{}
+	for rows.Next() {
+		job, err := scanImportJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// scanImportJob scans an import job from a row
+func scanImportJob(row pgx.Row) (*domain.ImportJob, error) {
+	var job domain.ImportJob
+	var options []byte
+	err := row.Scan(
+		&job.ID,
+		&job.Status,
+		&job.FileName,
+		&job.Format,
+		&job.FilePath,
+		&options,
+		&job.TotalRows,
+		&job.CreatedCount,
+		&job.UpdatedCount,
+		&job.SkippedCount,
+		&job.FailureCount,
+		&job.CheckpointRow,
+		&job.Error,
+		&job.CreatedBy,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.CompletedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(options) > 0 {
+		json.Unmarshal(options, &job.Options)
+	}
+	return &job, nil
+}
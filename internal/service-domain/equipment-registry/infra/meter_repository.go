@@ -0,0 +1,268 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const meterDefinitionColumns = `
+    id, catalog_equipment_id, code, name, meter_type, COALESCE(unit,'') AS unit,
+    rollover_at, COALESCE(max_daily_increase,0), COALESCE(service_interval,0),
+    created_at, updated_at`
+
+const meterReadingColumns = `
+    id, equipment_id, meter_definition_id, meter_code, value, delta, cumulative_value,
+    rollover, source, read_at, COALESCE(recorded_by,''), created_at`
+
+// MeterRepository implements the domain.MeterRepository interface
+type MeterRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewMeterRepository creates a new meter repository
+func NewMeterRepository(pool *pgxpool.Pool) *MeterRepository {
+	return &MeterRepository{pool: pool}
+}
+
+// CreateDefinition creates a meter definition for a catalog model
+func (r *MeterRepository) CreateDefinition(ctx context.Context, def *domain.MeterDefinition) error {
+	query := `
+		INSERT INTO equipment_meter_definitions (
+			id, catalog_equipment_id, code, name, meter_type, unit,
+			rollover_at, max_daily_increase, service_interval, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		def.ID, def.CatalogEquipmentID, def.Code, def.Name, def.MeterType, def.Unit,
+		def.RolloverAt, def.MaxDailyIncrease, def.ServiceInterval, def.CreatedAt, def.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create meter definition: %w", err)
+	}
+	return nil
+}
+
+// UpdateDefinition updates a meter definition
+func (r *MeterRepository) UpdateDefinition(ctx context.Context, def *domain.MeterDefinition) error {
+	query := `
+		UPDATE equipment_meter_definitions SET
+			name = $2, meter_type = $3, unit = $4, rollover_at = $5,
+			max_daily_increase = $6, service_interval = $7, updated_at = $8
+		WHERE id = $1
+	`
+	def.UpdatedAt = time.Now()
+	tag, err := r.pool.Exec(ctx, query,
+		def.ID, def.Name, def.MeterType, def.Unit, def.RolloverAt,
+		def.MaxDailyIncrease, def.ServiceInterval, def.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update meter definition: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrMeterDefinitionNotFound
+	}
+	return nil
+}
+
+// GetDefinition retrieves a meter definition by ID
+func (r *MeterRepository) GetDefinition(ctx context.Context, id string) (*domain.MeterDefinition, error) {
+	query := `SELECT ` + meterDefinitionColumns + ` FROM equipment_meter_definitions WHERE id = $1`
+	def, err := scanMeterDefinition(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrMeterDefinitionNotFound
+		}
+		return nil, fmt.Errorf("failed to get meter definition: %w", err)
+	}
+	return def, nil
+}
+
+// GetDefinitionByCode retrieves a meter definition by catalog model and code
+func (r *MeterRepository) GetDefinitionByCode(ctx context.Context, catalogEquipmentID, code string) (*domain.MeterDefinition, error) {
+	query := `SELECT ` + meterDefinitionColumns + `
+		FROM equipment_meter_definitions
+		WHERE catalog_equipment_id = $1 AND code = $2`
+	def, err := scanMeterDefinition(r.pool.QueryRow(ctx, query, catalogEquipmentID, code))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrMeterDefinitionNotFound
+		}
+		return nil, fmt.Errorf("failed to get meter definition by code: %w", err)
+	}
+	return def, nil
+}
+
+// ListDefinitions retrieves all meter definitions for a catalog model
+func (r *MeterRepository) ListDefinitions(ctx context.Context, catalogEquipmentID string) ([]*domain.MeterDefinition, error) {
+	query := `SELECT ` + meterDefinitionColumns + `
+		FROM equipment_meter_definitions
+		WHERE catalog_equipment_id = $1
+		ORDER BY code`
+	rows, err := r.pool.Query(ctx, query, catalogEquipmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list meter definitions: %w", err)
+	}
+	defer rows.Close()
+
+	defs := []*domain.MeterDefinition{}
+	for rows.Next() {
+		def, err := scanMeterDefinition(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan meter definition: %w", err)
+		}
+		defs = append(defs, def)
+	}
+	return defs, rows.Err()
+}
+
+// CreateReading stores a validated meter reading
+func (r *MeterRepository) CreateReading(ctx context.Context, reading *domain.MeterReading) error {
+	query := `
+		INSERT INTO equipment_meter_readings (
+			id, equipment_id, meter_definition_id, meter_code, value, delta, cumulative_value,
+			rollover, source, read_at, recorded_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		reading.ID, reading.EquipmentID, reading.MeterDefinitionID, reading.MeterCode,
+		reading.Value, reading.Delta, reading.CumulativeValue, reading.Rollover,
+		reading.Source, reading.ReadAt, reading.RecordedBy, reading.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create meter reading: %w", err)
+	}
+	return nil
+}
+
+// GetLatestReading retrieves the most recent reading of a meter on an installed unit.
+// It returns nil when the meter has no readings yet.
+func (r *MeterRepository) GetLatestReading(ctx context.Context, equipmentID, meterDefinitionID string) (*domain.MeterReading, error) {
+	query := `SELECT ` + meterReadingColumns + `
+		FROM equipment_meter_readings
+		WHERE equipment_id = $1 AND meter_definition_id = $2
+		ORDER BY read_at DESC, created_at DESC
+		LIMIT 1`
+	reading, err := scanMeterReading(r.pool.QueryRow(ctx, query, equipmentID, meterDefinitionID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest meter reading: %w", err)
+	}
+	return reading, nil
+}
+
+// GetReadingAsOf retrieves the most recent reading taken at or before the given time.
+// It returns nil when no such reading exists.
+func (r *MeterRepository) GetReadingAsOf(ctx context.Context, equipmentID, meterDefinitionID string, asOf time.Time) (*domain.MeterReading, error) {
+	query := `SELECT ` + meterReadingColumns + `
+		FROM equipment_meter_readings
+		WHERE equipment_id = $1 AND meter_definition_id = $2 AND read_at <= $3
+		ORDER BY read_at DESC, created_at DESC
+		LIMIT 1`
+	reading, err := scanMeterReading(r.pool.QueryRow(ctx, query, equipmentID, meterDefinitionID, asOf))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get meter reading as of %s: %w", asOf.Format(time.RFC3339), err)
+	}
+	return reading, nil
+}
+
+// ListReadings retrieves readings of an installed unit, newest first
+func (r *MeterRepository) ListReadings(ctx context.Context, equipmentID, meterDefinitionID string, limit int) ([]*domain.MeterReading, error) {
+	if limit < 1 {
+		limit = 100
+	}
+
+	query := `SELECT ` + meterReadingColumns + `
+		FROM equipment_meter_readings
+		WHERE equipment_id = $1 AND ($2 = '' OR meter_definition_id = $2)
+		ORDER BY read_at DESC, created_at DESC
+		LIMIT $3`
+	rows, err := r.pool.Query(ctx, query, equipmentID, meterDefinitionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list meter readings: %w", err)
+	}
+	defer rows.Close()
+
+	readings := []*domain.MeterReading{}
+	for rows.Next() {
+		reading, err := scanMeterReading(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan meter reading: %w", err)
+		}
+		readings = append(readings, reading)
+	}
+	return readings, rows.Err()
+}
+
+// ListMeteredEquipmentIDs returns installed units that have at least one reading
+func (r *MeterRepository) ListMeteredEquipmentIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT DISTINCT equipment_id FROM equipment_meter_readings`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metered equipment: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan equipment id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// scanMeterDefinition scans a meter definition from a row
+func scanMeterDefinition(row pgx.Row) (*domain.MeterDefinition, error) {
+	var def domain.MeterDefinition
+	err := row.Scan(
+		&def.ID,
+		&def.CatalogEquipmentID,
+		&def.Code,
+		&def.Name,
+		&def.MeterType,
+		&def.Unit,
+		&def.RolloverAt,
+		&def.MaxDailyIncrease,
+		&def.ServiceInterval,
+		&def.CreatedAt,
+		&def.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// scanMeterReading scans a meter reading from a row
+func scanMeterReading(row pgx.Row) (*domain.MeterReading, error) {
+	var reading domain.MeterReading
+	err := row.Scan(
+		&reading.ID,
+		&reading.EquipmentID,
+		&reading.MeterDefinitionID,
+		&reading.MeterCode,
+		&reading.Value,
+		&reading.Delta,
+		&reading.CumulativeValue,
+		&reading.Rollover,
+		&reading.Source,
+		&reading.ReadAt,
+		&reading.RecordedBy,
+		&reading.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &reading, nil
+}
@@ -0,0 +1,388 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// equipmentQualityColumns skips QR images, which quality scans never need
+var equipmentQualityColumns = strings.Replace(equipmentSelectColumns, "qr_code_image,", "NULL::bytea AS qr_code_image,", 1)
+
+const equipmentMergeColumns = `
+    id, survivor_id, merged_id, COALESCE(merged_serial,''), COALESCE(merged_qr_code,''),
+    snapshot, moved_references, conflicts, COALESCE(reason,''), COALESCE(merged_by,''), merged_at`
+
+// mergeReference re-points rows referencing a merged record to the survivor.
+// $1 is the survivor ID and $2 the merged ID.
+type mergeReference struct {
+	table string
+	query string
+}
+
+var mergeReferences = []mergeReference{
+	{"equipment_documents", `UPDATE equipment_documents SET equipment_id = $1 WHERE equipment_id = $2`},
+	{"equipment_downtime", `UPDATE equipment_downtime SET equipment_id = $1 WHERE equipment_id = $2`},
+	{"equipment_maintenance_history", `UPDATE equipment_maintenance_history SET equipment_id = $1 WHERE equipment_id = $2`},
+	{"equipment_usage_logs", `UPDATE equipment_usage_logs SET equipment_id = $1 WHERE equipment_id = $2`},
+	{"equipment_meter_readings", `UPDATE equipment_meter_readings SET equipment_id = $1 WHERE equipment_id = $2`},
+	// One service config per unit: the duplicate's config moves only if the
+	// survivor has none; otherwise Merge records it as a conflict
+	{"equipment_service_config", `
+		UPDATE equipment_service_config SET equipment_id = $1, updated_at = NOW()
+		WHERE equipment_id = $2
+		  AND NOT EXISTS (SELECT 1 FROM equipment_service_config WHERE equipment_id = $1)`},
+	{"org_relationships", `
+		UPDATE org_relationships o SET equipment_id = $1
+		WHERE o.equipment_id = $2
+		  AND NOT EXISTS (
+			SELECT 1 FROM org_relationships s
+			WHERE s.equipment_id = $1 AND s.parent_org_id = o.parent_org_id
+			  AND s.child_org_id = o.child_org_id AND s.rel_type = o.rel_type)`},
+	// Earlier merges into the duplicate now resolve to the survivor
+	{"equipment_merges", `UPDATE equipment_merges SET survivor_id = $1 WHERE survivor_id = $2`},
+}
+
+// QualityRepository implements the domain.QualityRepository interface
+type QualityRepository struct {
+	pool      *pgxpool.Pool
+	equipment *EquipmentRepository
+}
+
+// NewQualityRepository creates a new data quality repository
+func NewQualityRepository(pool *pgxpool.Pool) *QualityRepository {
+	return &QualityRepository{pool: pool, equipment: NewEquipmentRepository(pool)}
+}
+
+// ListForQuality retrieves the caller organization's registry records without
+// images, optionally for one customer
+func (r *QualityRepository) ListForQuality(ctx context.Context, customerID string) ([]*domain.Equipment, error) {
+	query := `SELECT ` + equipmentQualityColumns + ` FROM equipment_registry WHERE 1=1`
+	args := []interface{}{}
+	if scope, orgID, ok := orgScope(ctx, 1); ok {
+		query += ` AND ` + scope
+		args = append(args, orgID)
+	}
+	if customerID != "" {
+		args = append(args, customerID)
+		query += fmt.Sprintf(` AND customer_id = $%d`, len(args))
+	}
+	query += ` ORDER BY created_at`
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list equipment for quality check: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*domain.Equipment{}
+	for rows.Next() {
+		equipment, err := r.equipment.scanEquipment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan equipment: %w", err)
+		}
+		result = append(result, equipment)
+	}
+	return result, rows.Err()
+}
+
+// ListManufacturerAliases retrieves configured manufacturer aliases
+func (r *QualityRepository) ListManufacturerAliases(ctx context.Context) ([]*domain.ManufacturerAlias, error) {
+	query := `
+		SELECT alias, alias_key, canonical_name, COALESCE(created_by,''), created_at
+		FROM equipment_manufacturer_aliases
+		ORDER BY canonical_name, alias
+	`
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list manufacturer aliases: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*domain.ManufacturerAlias{}
+	for rows.Next() {
+		var a domain.ManufacturerAlias
+		if err := rows.Scan(&a.Alias, &a.AliasKey, &a.CanonicalName, &a.CreatedBy, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan manufacturer alias: %w", err)
+		}
+		result = append(result, &a)
+	}
+	return result, rows.Err()
+}
+
+// UpsertManufacturerAlias creates or replaces a manufacturer alias
+func (r *QualityRepository) UpsertManufacturerAlias(ctx context.Context, alias *domain.ManufacturerAlias) error {
+	query := `
+		INSERT INTO equipment_manufacturer_aliases (alias_key, alias, canonical_name, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (alias_key) DO UPDATE SET
+			alias = EXCLUDED.alias, canonical_name = EXCLUDED.canonical_name,
+			created_by = EXCLUDED.created_by, created_at = EXCLUDED.created_at
+	`
+	_, err := r.pool.Exec(ctx, query, alias.AliasKey, alias.Alias, alias.CanonicalName, alias.CreatedBy, alias.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save manufacturer alias: %w", err)
+	}
+	return nil
+}
+
+// RenameManufacturer sets manufacturer_name on the given records of the
+// caller's organization
+func (r *QualityRepository) RenameManufacturer(ctx context.Context, ids []string, name string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	query := `UPDATE equipment_registry SET manufacturer_name = $2, updated_at = NOW() WHERE id = ANY($1)`
+	args := []interface{}{ids, name}
+	if scope, orgID, ok := orgScope(ctx, 3); ok {
+		query += ` AND ` + scope
+		args = append(args, orgID)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename manufacturer: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// UpdateSerialNumber sets a cleaned serial number on a record of the caller's organization
+func (r *QualityRepository) UpdateSerialNumber(ctx context.Context, id, serial string) error {
+	query := `UPDATE equipment_registry SET serial_number = $2, updated_at = NOW() WHERE id = $1`
+	args := []interface{}{id, serial}
+	if scope, orgID, ok := orgScope(ctx, 3); ok {
+		query += ` AND ` + scope
+		args = append(args, orgID)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update serial number: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrEquipmentNotFound
+	}
+	return nil
+}
+
+// Merge saves the merged survivor, re-points references from the duplicate,
+// records the merge and deletes the duplicate in a single transaction
+func (r *QualityRepository) Merge(ctx context.Context, survivor, duplicate *domain.Equipment, merge *domain.EquipmentMerge) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Both records must belong to the caller's organization before their
+	// tickets and history are moved
+	lock := `SELECT id FROM equipment_registry WHERE id IN ($1, $2)`
+	args := []interface{}{survivor.ID, duplicate.ID}
+	if scope, orgID, ok := orgScope(ctx, 3); ok {
+		lock += ` AND ` + scope
+		args = append(args, orgID)
+	}
+	var locked int
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM (`+lock+` FOR UPDATE) l`, args...).Scan(&locked)
+	if err != nil {
+		return fmt.Errorf("failed to lock equipment: %w", err)
+	}
+	if locked != 2 {
+		return domain.ErrEquipmentNotFound
+	}
+
+	if merge.MovedReferences == nil {
+		merge.MovedReferences = map[string]int64{}
+	}
+
+	// Tickets also carry the serial and QR code they were raised against; their
+	// attachments are keyed by ticket and follow the ticket
+	tag, err := tx.Exec(ctx, `
+		UPDATE service_tickets SET
+			equipment_id = $1,
+			serial_number = $3,
+			qr_code = COALESCE(NULLIF($4, ''), qr_code)
+		WHERE equipment_id = $2
+	`, survivor.ID, duplicate.ID, survivor.SerialNumber, survivor.QRCode)
+	if err != nil {
+		return fmt.Errorf("failed to move service tickets: %w", err)
+	}
+	merge.MovedReferences["service_tickets"] = tag.RowsAffected()
+
+	// The duplicate's service config is deleted with it when the survivor has
+	// its own; keep it in the merge record
+	var serviceConfigExists bool
+	if err := tx.QueryRow(ctx, `SELECT to_regclass('equipment_service_config') IS NOT NULL`).Scan(&serviceConfigExists); err != nil {
+		return fmt.Errorf("failed to check table equipment_service_config: %w", err)
+	}
+	if serviceConfigExists {
+		var row []byte
+		err := tx.QueryRow(ctx, `
+			SELECT row_to_json(c) FROM equipment_service_config c
+			WHERE c.equipment_id = $2
+			  AND EXISTS (SELECT 1 FROM equipment_service_config WHERE equipment_id = $1)
+		`, survivor.ID, duplicate.ID).Scan(&row)
+		if err != nil && err != pgx.ErrNoRows {
+			return fmt.Errorf("failed to check service config conflict: %w", err)
+		}
+		if err == nil {
+			merge.Conflicts = append(merge.Conflicts, domain.MergeConflict{
+				Table:  "equipment_service_config",
+				Detail: "survivor already has a service config; the duplicate's config was not applied",
+				Row:    row,
+			})
+		}
+	}
+
+	for _, ref := range mergeReferences {
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, ref.table).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check table %s: %w", ref.table, err)
+		}
+		if !exists {
+			continue
+		}
+		tag, err := tx.Exec(ctx, ref.query, survivor.ID, duplicate.ID)
+		if err != nil {
+			return fmt.Errorf("failed to move %s: %w", ref.table, err)
+		}
+		if tag.RowsAffected() > 0 {
+			merge.MovedReferences[ref.table] = tag.RowsAffected()
+		}
+	}
+
+	// Printed QR labels of the duplicate stay registered against the surviving serial
+	if duplicate.QRCode != "" {
+		tag, err := tx.Exec(ctx,
+			`UPDATE qr_codes SET serial_number = $2, updated_at = NOW() WHERE qr_code = $1`,
+			duplicate.QRCode, survivor.SerialNumber,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to move QR code: %w", err)
+		}
+		if tag.RowsAffected() > 0 {
+			merge.MovedReferences["qr_codes"] = tag.RowsAffected()
+		}
+	}
+
+	snapshot, _ := json.Marshal(merge.Snapshot)
+	moved, _ := json.Marshal(merge.MovedReferences)
+	conflicts, _ := json.Marshal(merge.Conflicts)
+	if merge.Conflicts == nil {
+		conflicts = []byte("[]")
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO equipment_merges (
+			id, survivor_id, merged_id, merged_serial, merged_qr_code,
+			snapshot, moved_references, conflicts, reason, merged_by, merged_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, merge.ID, merge.SurvivorID, merge.MergedID, merge.MergedSerial, merge.MergedQRCode,
+		snapshot, moved, conflicts, merge.Reason, merge.MergedBy, merge.MergedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record merge: %w", err)
+	}
+
+	// Delete before updating the survivor so it can take over the duplicate's QR code
+	if _, err := tx.Exec(ctx, `DELETE FROM equipment_registry WHERE id = $1`, duplicate.ID); err != nil {
+		return fmt.Errorf("failed to delete merged equipment: %w", err)
+	}
+
+	specs, _ := json.Marshal(survivor.Specifications)
+	photos, _ := json.Marshal(survivor.Photos)
+	docs, _ := json.Marshal(survivor.Documents)
+	address, _ := json.Marshal(survivor.InstallationAddress)
+	survivor.UpdatedAt = time.Now()
+
+	_, err = tx.Exec(ctx, `
+		UPDATE equipment_registry SET
+			qr_code = NULLIF($2, ''), qr_code_url = $3, equipment_id = $4, equipment_name = $5,
+			manufacturer_name = $6, model_number = $7, category = $8, customer_id = $9,
+			customer_name = $10, installation_location = $11, installation_address = $12,
+			installation_date = $13, contract_id = $14, purchase_date = $15, purchase_price = $16,
+			warranty_expiry = $17, amc_contract_id = $18, last_service_date = $19,
+			next_service_date = $20, service_count = $21, specifications = $22, photos = $23,
+			documents = $24, notes = $25, updated_at = $26
+		WHERE id = $1
+	`,
+		survivor.ID,
+		survivor.QRCode,
+		survivor.QRCodeURL,
+		survivor.EquipmentID,
+		survivor.EquipmentName,
+		survivor.ManufacturerName,
+		survivor.ModelNumber,
+		survivor.Category,
+		survivor.CustomerID,
+		survivor.CustomerName,
+		survivor.InstallationLocation,
+		address,
+		survivor.InstallationDate,
+		survivor.ContractID,
+		survivor.PurchaseDate,
+		survivor.PurchasePrice,
+		survivor.WarrantyExpiry,
+		survivor.AMCContractID,
+		survivor.LastServiceDate,
+		survivor.NextServiceDate,
+		survivor.ServiceCount,
+		specs,
+		photos,
+		docs,
+		survivor.Notes,
+		survivor.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update surviving equipment: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit merge: %w", err)
+	}
+	return nil
+}
+
+// ListMerges retrieves merges into a surviving record, newest first
+func (r *QualityRepository) ListMerges(ctx context.Context, survivorID string) ([]*domain.EquipmentMerge, error) {
+	query := `SELECT ` + equipmentMergeColumns + ` FROM equipment_merges WHERE survivor_id = $1 ORDER BY merged_at DESC`
+	rows, err := r.pool.Query(ctx, query, survivorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list equipment merges: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*domain.EquipmentMerge{}
+	for rows.Next() {
+		merge, err := scanEquipmentMerge(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan equipment merge: %w", err)
+		}
+		result = append(result, merge)
+	}
+	return result, rows.Err()
+}
+
+func scanEquipmentMerge(row pgx.Row) (*domain.EquipmentMerge, error) {
+	var m domain.EquipmentMerge
+	var snapshot, moved, conflicts []byte
+	err := row.Scan(
+		&m.ID, &m.SurvivorID, &m.MergedID, &m.MergedSerial, &m.MergedQRCode,
+		&snapshot, &moved, &conflicts, &m.Reason, &m.MergedBy, &m.MergedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshot) > 0 {
+		json.Unmarshal(snapshot, &m.Snapshot)
+	}
+	if len(moved) > 0 {
+		json.Unmarshal(moved, &m.MovedReferences)
+	}
+	if len(conflicts) > 0 {
+		json.Unmarshal(conflicts, &m.Conflicts)
+	}
+	return &m, nil
+}
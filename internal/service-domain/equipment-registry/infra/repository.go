@@ -139,6 +139,43 @@ func (r *EquipmentRepository) Create(ctx context.Context, equipment *domain.Equi
 	return nil
 }
 
+// requestScope returns the organization of the request. System admins and
+// requests without an organization get an empty scope.
+func requestScope(ctx context.Context) domain.OrgScope {
+	orgID, hasOrgID := middleware.GetOrganizationID(ctx)
+	if !hasOrgID || orgfilter.IsSystemAdmin(ctx) {
+		return domain.OrgScope{}
+	}
+	orgType, _ := middleware.GetOrganizationType(ctx)
+	return domain.OrgScope{OrganizationID: orgID.String(), OrganizationType: orgType}
+}
+
+// scopeCondition returns the organization filter applied by GetByID and List
+// as a condition on parameter $n. An empty scope has no condition.
+func scopeCondition(scope domain.OrgScope, n int) (string, bool) {
+	if scope.OrganizationID == "" {
+		return "", false
+	}
+	switch scope.OrganizationType {
+	case "manufacturer":
+		return fmt.Sprintf("manufacturer_id = $%d", n), true
+	case "hospital", "imaging_center":
+		return fmt.Sprintf("(customer_id = $%d OR organization_id = $%d)", n, n), true
+	case "Channel Partner", "Sub-sub_SUB_DEALER":
+		return fmt.Sprintf("(channel_partner_org_id = $%d OR service_provider_org_id = $%d)", n, n), true
+	default:
+		return fmt.Sprintf("customer_id = $%d", n), true
+	}
+}
+
+// orgScope returns the request's organization filter as a condition on
+// parameter $n with its argument
+func orgScope(ctx context.Context, n int) (string, string, bool) {
+	scope := requestScope(ctx)
+	condition, ok := scopeCondition(scope, n)
+	return condition, scope.OrganizationID, ok
+}
+
 // GetByID retrieves equipment by ID
 func (r *EquipmentRepository) GetByID(ctx context.Context, id string) (*domain.Equipment, error) {
 	// Get organization context
@@ -192,7 +229,8 @@ func (r *EquipmentRepository) GetByQRCode(ctx context.Context, qrCode string) (*
 	equipment, err := r.scanEquipment(r.pool.QueryRow(ctx, query, qrCode))
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, domain.ErrEquipmentNotFound
+			// Labels of records merged into another unit keep working
+			return r.getMergedSurvivor(ctx, "merged_qr_code", qrCode)
 		}
 		return nil, fmt.Errorf("failed to get equipment by QR code: %w", err)
 	}
@@ -211,7 +249,7 @@ func (r *EquipmentRepository) GetBySerialNumber(ctx context.Context, serialNumbe
 	equipment, err := r.scanEquipment(r.pool.QueryRow(ctx, query, serialNumber))
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, domain.ErrEquipmentNotFound
+			return r.getMergedSurvivor(ctx, "merged_serial", serialNumber)
 		}
 		return nil, fmt.Errorf("failed to get equipment by serial number: %w", err)
 	}
@@ -769,3 +807,290 @@ func (r *EquipmentRepository) SetQRCodeBySerial(ctx context.Context, serial, qrC
     }
     return nil
 }
+
+// GetBySerialNumbers retrieves equipment in scope for a batch of serial
+// numbers, keyed by serial number. Serials merged away resolve to the
+// surviving equipment. Serials registered outside the scope map to nil, as
+// serial numbers are unique across organizations.
+func (r *EquipmentRepository) GetBySerialNumbers(ctx context.Context, scope domain.OrgScope, serialNumbers []string) (map[string]*domain.Equipment, error) {
+	result := make(map[string]*domain.Equipment, len(serialNumbers))
+	if len(serialNumbers) == 0 {
+		return result, nil
+	}
+
+	query := `
+        SELECT ` + equipmentSelectColumns + `
+		FROM equipment_registry
+		WHERE serial_number = ANY($1)
+	`
+	args := []interface{}{serialNumbers}
+	if condition, ok := scopeCondition(scope, 2); ok {
+		query += ` AND ` + condition
+		args = append(args, scope.OrganizationID)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equipment by serial numbers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		equipment, err := r.scanEquipment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan equipment: %w", err)
+		}
+		result[equipment.SerialNumber] = equipment
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	missing := missingSerials(serialNumbers, result)
+	if len(missing) == 0 {
+		return result, nil
+	}
+	if scope.OrganizationID != "" {
+		rows, err := r.pool.Query(ctx, `SELECT serial_number FROM equipment_registry WHERE serial_number = ANY($1)`, missing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check serial numbers: %w", err)
+		}
+		taken, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return nil, fmt.Errorf("failed to check serial numbers: %w", err)
+		}
+		for _, serial := range taken {
+			result[serial] = nil
+		}
+		missing = missingSerials(missing, result)
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	if err := r.resolveMergedSerials(ctx, scope, missing, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// missingSerials returns the serials without an entry in result
+func missingSerials(serials []string, result map[string]*domain.Equipment) []string {
+	missing := []string{}
+	for _, serial := range serials {
+		if _, ok := result[serial]; !ok {
+			missing = append(missing, serial)
+		}
+	}
+	return missing
+}
+
+// resolveMergedSerials adds the surviving equipment of serials merged away by
+// data quality tooling to result, keyed by the merged serial. Survivors
+// outside the scope map to nil.
+func (r *EquipmentRepository) resolveMergedSerials(ctx context.Context, scope domain.OrgScope, serials []string, result map[string]*domain.Equipment) error {
+	rows, err := r.pool.Query(ctx, `
+		SELECT DISTINCT ON (merged_serial) merged_serial, survivor_id
+		FROM equipment_merges
+		WHERE merged_serial = ANY($1)
+		ORDER BY merged_serial, merged_at DESC
+	`, serials)
+	if err != nil {
+		return fmt.Errorf("failed to resolve merged serial numbers: %w", err)
+	}
+	survivors := map[string][]string{}
+	ids := []string{}
+	for rows.Next() {
+		var serial, survivorID string
+		if err := rows.Scan(&serial, &survivorID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan equipment merge: %w", err)
+		}
+		if _, ok := survivors[survivorID]; !ok {
+			ids = append(ids, survivorID)
+		}
+		survivors[survivorID] = append(survivors[survivorID], serial)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to resolve merged serial numbers: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `SELECT ` + equipmentSelectColumns + ` FROM equipment_registry WHERE id = ANY($1)`
+	args := []interface{}{ids}
+	if condition, ok := scopeCondition(scope, 2); ok {
+		query += ` AND ` + condition
+		args = append(args, scope.OrganizationID)
+		for _, survivorSerials := range survivors {
+			for _, serial := range survivorSerials {
+				result[serial] = nil
+			}
+		}
+	}
+	rows, err = r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to get surviving equipment: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		equipment, err := r.scanEquipment(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan equipment: %w", err)
+		}
+		for _, serial := range survivors[equipment.ID] {
+			result[serial] = equipment
+		}
+	}
+	return rows.Err()
+}
+
+// BulkUpsert creates equipment and updates equipment in scope in a single
+// transaction. An update of equipment outside the scope fails the batch.
+func (r *EquipmentRepository) BulkUpsert(ctx context.Context, scope domain.OrgScope, creates, updates []*domain.Equipment) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertQuery := `
+		INSERT INTO equipment_registry (
+			id, qr_code, serial_number, equipment_id, equipment_name, manufacturer_name,
+			model_number, category, customer_id, customer_name, installation_location,
+			installation_address, installation_date, contract_id, purchase_date, purchase_price,
+			warranty_expiry, amc_contract_id, status, last_service_date, next_service_date,
+			service_count, specifications, photos, documents, qr_code_url, notes,
+			created_at, updated_at, created_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
+			$17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30
+		)
+	`
+
+	updateQuery := `
+		UPDATE equipment_registry SET
+			equipment_id = $2, equipment_name = $3, manufacturer_name = $4, model_number = $5,
+			category = $6, customer_id = $7, customer_name = $8, installation_location = $9,
+			installation_address = $10, installation_date = $11, contract_id = $12,
+			purchase_date = $13, purchase_price = $14, warranty_expiry = $15,
+			amc_contract_id = $16, status = $17, notes = $18, updated_at = $19
+		WHERE id = $1
+	`
+	if condition, ok := scopeCondition(scope, 20); ok {
+		updateQuery += ` AND ` + condition
+	}
+
+	for _, equipment := range creates {
+		specs, _ := json.Marshal(equipment.Specifications)
+		photos, _ := json.Marshal(equipment.Photos)
+		docs, _ := json.Marshal(equipment.Documents)
+		address, _ := json.Marshal(equipment.InstallationAddress)
+
+		_, err = tx.Exec(ctx, insertQuery,
+			equipment.ID,
+			equipment.QRCode,
+			equipment.SerialNumber,
+			equipment.EquipmentID,
+			equipment.EquipmentName,
+			equipment.ManufacturerName,
+			equipment.ModelNumber,
+			equipment.Category,
+			equipment.CustomerID,
+			equipment.CustomerName,
+			equipment.InstallationLocation,
+			address,
+			equipment.InstallationDate,
+			equipment.ContractID,
+			equipment.PurchaseDate,
+			equipment.PurchasePrice,
+			equipment.WarrantyExpiry,
+			equipment.AMCContractID,
+			equipment.Status,
+			equipment.LastServiceDate,
+			equipment.NextServiceDate,
+			equipment.ServiceCount,
+			specs,
+			photos,
+			docs,
+			equipment.QRCodeURL,
+			equipment.Notes,
+			equipment.CreatedAt,
+			equipment.UpdatedAt,
+			equipment.CreatedBy,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create equipment %s: %w", equipment.SerialNumber, err)
+		}
+	}
+
+	for _, equipment := range updates {
+		address, _ := json.Marshal(equipment.InstallationAddress)
+		equipment.UpdatedAt = time.Now()
+
+		args := []interface{}{
+			equipment.ID,
+			equipment.EquipmentID,
+			equipment.EquipmentName,
+			equipment.ManufacturerName,
+			equipment.ModelNumber,
+			equipment.Category,
+			equipment.CustomerID,
+			equipment.CustomerName,
+			equipment.InstallationLocation,
+			address,
+			equipment.InstallationDate,
+			equipment.ContractID,
+			equipment.PurchaseDate,
+			equipment.PurchasePrice,
+			equipment.WarrantyExpiry,
+			equipment.AMCContractID,
+			equipment.Status,
+			equipment.Notes,
+			equipment.UpdatedAt,
+		}
+		if scope.OrganizationID != "" {
+			args = append(args, scope.OrganizationID)
+		}
+		tag, err := tx.Exec(ctx, updateQuery, args...)
+		if err != nil {
+			return fmt.Errorf("failed to update equipment %s: %w", equipment.SerialNumber, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("failed to update equipment %s: %w", equipment.SerialNumber, domain.ErrEquipmentNotFound)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// getMergedSurvivor resolves the QR code or serial of a record merged away by
+// data quality tooling to the surviving equipment
+func (r *EquipmentRepository) getMergedSurvivor(ctx context.Context, column, value string) (*domain.Equipment, error) {
+	query := `
+        SELECT ` + equipmentSelectColumns + `
+		FROM equipment_registry
+		WHERE id = (
+			SELECT survivor_id FROM equipment_merges
+			WHERE ` + column + ` = $1
+			ORDER BY merged_at DESC
+			LIMIT 1
+		)
+	`
+
+	equipment, err := r.scanEquipment(r.pool.QueryRow(ctx, query, value))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrEquipmentNotFound
+		}
+		return nil, fmt.Errorf("failed to resolve merged equipment: %w", err)
+	}
+
+	return equipment, nil
+}
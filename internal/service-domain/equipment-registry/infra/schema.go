@@ -62,3 +62,150 @@ func EnsureEquipmentSchema(ctx context.Context, pool PgxIface) error {
 type PgxIface interface {
     Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
 }
+
+// EnsureMeterSchema creates the meter definition and reading tables if they don't exist.
+func EnsureMeterSchema(ctx context.Context, pool PgxIface) error {
+    stmts := []string{
+        `CREATE TABLE IF NOT EXISTS equipment_meter_definitions (
+            id VARCHAR(255) PRIMARY KEY,
+            catalog_equipment_id VARCHAR(255) NOT NULL,
+            code VARCHAR(100) NOT NULL,
+            name VARCHAR(255) NOT NULL,
+            meter_type VARCHAR(50) NOT NULL,
+            unit VARCHAR(50),
+            rollover_at DOUBLE PRECISION,
+            max_daily_increase DOUBLE PRECISION DEFAULT 0,
+            service_interval DOUBLE PRECISION DEFAULT 0,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            UNIQUE (catalog_equipment_id, code)
+        )`,
+        `CREATE TABLE IF NOT EXISTS equipment_meter_readings (
+            id VARCHAR(255) PRIMARY KEY,
+            equipment_id VARCHAR(255) NOT NULL,
+            meter_definition_id VARCHAR(255) NOT NULL REFERENCES equipment_meter_definitions(id),
+            meter_code VARCHAR(100) NOT NULL,
+            value DOUBLE PRECISION NOT NULL,
+            delta DOUBLE PRECISION NOT NULL DEFAULT 0,
+            cumulative_value DOUBLE PRECISION NOT NULL DEFAULT 0,
+            rollover BOOLEAN NOT NULL DEFAULT false,
+            source VARCHAR(20) NOT NULL DEFAULT 'manual',
+            read_at TIMESTAMP WITH TIME ZONE NOT NULL,
+            recorded_by VARCHAR(255),
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )`,
+        "CREATE INDEX IF NOT EXISTS idx_meter_readings_equipment_meter ON equipment_meter_readings(equipment_id, meter_definition_id, read_at DESC)",
+    }
+
+    for _, stmt := range stmts {
+        if _, err := pool.Exec(ctx, stmt); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// EnsureImportJobSchema creates the installed-base import job tables if they don't exist.
+func EnsureImportJobSchema(ctx context.Context, pool PgxIface) error {
+    stmts := []string{
+        `CREATE TABLE IF NOT EXISTS equipment_import_jobs (
+            id VARCHAR(255) PRIMARY KEY,
+            status VARCHAR(20) NOT NULL,
+            file_name VARCHAR(500) NOT NULL,
+            format VARCHAR(10) NOT NULL,
+            file_path TEXT NOT NULL,
+            options JSONB NOT NULL DEFAULT '{}'::jsonb,
+            total_rows INTEGER NOT NULL DEFAULT 0,
+            created_count INTEGER NOT NULL DEFAULT 0,
+            updated_count INTEGER NOT NULL DEFAULT 0,
+            skipped_count INTEGER NOT NULL DEFAULT 0,
+            failure_count INTEGER NOT NULL DEFAULT 0,
+            checkpoint_row INTEGER NOT NULL DEFAULT 0,
+            error TEXT,
+            created_by VARCHAR(255),
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            started_at TIMESTAMP WITH TIME ZONE,
+            completed_at TIMESTAMP WITH TIME ZONE,
+            updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )`,
+        `CREATE TABLE IF NOT EXISTS equipment_import_job_rows (
+            job_id VARCHAR(255) NOT NULL REFERENCES equipment_import_jobs(id) ON DELETE CASCADE,
+            row_number INTEGER NOT NULL,
+            serial_number VARCHAR(255),
+            action VARCHAR(20) NOT NULL,
+            equipment_id VARCHAR(255),
+            errors JSONB NOT NULL DEFAULT '[]'::jsonb,
+            warnings JSONB NOT NULL DEFAULT '[]'::jsonb,
+            PRIMARY KEY (job_id, row_number)
+        )`,
+        "CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON equipment_import_jobs(status)",
+    }
+
+    for _, stmt := range stmts {
+        if _, err := pool.Exec(ctx, stmt); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// EnsureQualitySchema creates the manufacturer alias and equipment merge tables if they don't exist.
+func EnsureQualitySchema(ctx context.Context, pool PgxIface) error {
+    stmts := []string{
+        `CREATE TABLE IF NOT EXISTS equipment_manufacturer_aliases (
+            alias_key VARCHAR(255) PRIMARY KEY,
+            alias VARCHAR(255) NOT NULL,
+            canonical_name VARCHAR(255) NOT NULL,
+            created_by VARCHAR(255),
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )`,
+        `CREATE TABLE IF NOT EXISTS equipment_merges (
+            id VARCHAR(255) PRIMARY KEY,
+            survivor_id VARCHAR(255) NOT NULL,
+            merged_id VARCHAR(255) NOT NULL,
+            merged_serial VARCHAR(255),
+            merged_qr_code VARCHAR(255),
+            snapshot JSONB NOT NULL DEFAULT '{}'::jsonb,
+            moved_references JSONB NOT NULL DEFAULT '{}'::jsonb,
+            reason TEXT,
+            merged_by VARCHAR(255),
+            merged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )`,
+        "ALTER TABLE equipment_merges ADD COLUMN IF NOT EXISTS conflicts JSONB NOT NULL DEFAULT '[]'::jsonb",
+        "CREATE INDEX IF NOT EXISTS idx_equipment_merges_survivor ON equipment_merges(survivor_id)",
+        "CREATE INDEX IF NOT EXISTS idx_equipment_merges_qr_code ON equipment_merges(merged_qr_code)",
+        "CREATE INDEX IF NOT EXISTS idx_equipment_merges_serial ON equipment_merges(merged_serial)",
+    }
+
+    for _, stmt := range stmts {
+        if _, err := pool.Exec(ctx, stmt); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// EnsureGeoSchema creates the postal code gazetteer table if it doesn't exist.
+func EnsureGeoSchema(ctx context.Context, pool PgxIface) error {
+    stmts := []string{
+        `CREATE TABLE IF NOT EXISTS geo_postal_codes (
+            country VARCHAR(2) NOT NULL,
+            postal_code VARCHAR(20) NOT NULL,
+            place_name VARCHAR(255) NOT NULL DEFAULT '',
+            region VARCHAR(255),
+            district VARCHAR(255),
+            latitude DOUBLE PRECISION NOT NULL,
+            longitude DOUBLE PRECISION NOT NULL,
+            updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            PRIMARY KEY (country, postal_code, place_name)
+        )`,
+        "CREATE INDEX IF NOT EXISTS idx_geo_postal_codes_postal ON geo_postal_codes(postal_code)",
+    }
+
+    for _, stmt := range stmts {
+        if _, err := pool.Exec(ctx, stmt); err != nil {
+            return err
+        }
+    }
+    return nil
+}
@@ -13,9 +13,15 @@ import (
 
 // Module represents the equipment registry module
 type Module struct {
-	config  ModuleConfig
-	handler *api.EquipmentHandler
-	logger  *slog.Logger
+	config         ModuleConfig
+	handler        *api.EquipmentHandler
+	meterHandler   *api.MeterHandler
+	importJobs     *app.ImportJobService
+	jobHandler     *api.ImportJobHandler
+	qualityHandler *api.QualityHandler
+	geoHandler     *api.GeoHandler
+	pmScheduler    *app.MeterPMScheduler
+	logger         *slog.Logger
 }
 
 // ModuleConfig holds module configuration
@@ -27,6 +33,7 @@ type ModuleConfig struct {
 	DBName     string
 	BaseURL    string
 	QROutputDir string
+	ImportStorageDir string // Directory for files of background import jobs
 }
 
 // NewModule creates a new equipment registry module
@@ -65,13 +72,46 @@ func (m *Module) Initialize(ctx context.Context) error {
 	// Create application service
 	service := app.NewEquipmentService(repo, qrGenerator, m.logger, m.config.BaseURL)
 
-	// Create HTTP handler
+	// Create meter service for usage counters and usage-based PM
+	meterService := app.NewMeterService(infra.NewMeterRepository(pool), repo, m.logger)
+	m.pmScheduler = app.NewMeterPMScheduler(meterService, m.logger)
+
+	// Create import job service for large, resumable installed-base imports
+	m.importJobs = app.NewImportJobService(infra.NewImportJobRepository(pool), service, m.config.ImportStorageDir, m.logger)
+
+	// Create HTTP handlers
 	m.handler = api.NewEquipmentHandler(service, m.logger)
+	m.meterHandler = api.NewMeterHandler(meterService, m.logger)
+	m.jobHandler = api.NewImportJobHandler(m.importJobs, m.logger)
+
+	// Create data quality tooling for normalization, deduplication and scoring
+	qualityService := app.NewQualityService(infra.NewQualityRepository(pool), repo, m.logger)
+	m.qualityHandler = api.NewQualityHandler(qualityService, m.logger)
+
+	// Create offline geocoding of installation addresses from the postal code gazetteer
+	geoService := app.NewGeoService(infra.NewGeoRepository(pool), m.logger)
+	service.SetGeocoder(geoService)
+	m.geoHandler = api.NewGeoHandler(geoService, service, m.logger)
 
     // Ensure schema is compatible with application expectations
     if err := infra.EnsureEquipmentSchema(ctx, pool); err != nil {
         return err
     }
+    if err := infra.EnsureMeterSchema(ctx, pool); err != nil {
+        return err
+    }
+    if err := infra.EnsureImportJobSchema(ctx, pool); err != nil {
+        return err
+    }
+    if err := infra.EnsureQualitySchema(ctx, pool); err != nil {
+        return err
+    }
+    if err := infra.EnsureGeoSchema(ctx, pool); err != nil {
+        return err
+    }
+    if err := geoService.Load(ctx); err != nil {
+        m.logger.Warn("Failed to load gazetteer", slog.String("error", err.Error()))
+    }
 
 	m.logger.Info("Equipment Registry module initialized successfully")
 	return nil
@@ -86,17 +126,47 @@ func (m *Module) MountRoutes(r chi.Router) {
 		// Routes without {id} parameter first
 		r.Post("/", m.handler.RegisterEquipment)          // Register equipment
 		r.Get("/", m.handler.ListEquipment)               // List equipment
-		r.Post("/import", m.handler.ImportCSV)            // CSV import
+		r.Post("/import", m.handler.ImportCSV)            // CSV/XLSX import (dry_run, update_mode, column_mapping)
+		r.Get("/import/jobs", m.jobHandler.ListJobs)                  // List background import jobs
+		r.Post("/import/jobs", m.jobHandler.StartJob)                 // Start background import of a large file
+		r.Get("/import/jobs/{id}", m.jobHandler.GetJob)               // Import job status and progress
+		r.Get("/import/jobs/{id}/rows", m.jobHandler.ListJobRows)     // Row errors, warnings and skips
+		r.Post("/import/jobs/{id}/resume", m.jobHandler.ResumeJob)    // Resume from last checkpoint
 		r.Post("/qr/bulk-generate", m.handler.BulkGenerateQRCodes) // Bulk generate QR codes
 		r.Post("/qr/import-mapping", m.handler.ImportQRMapping)    // Import pregenerated QR mappings via CSV
 		r.Get("/qr/image/{id}", m.handler.GetQRCodeImage) // Get QR code image (different pattern to avoid conflict)
 		r.Get("/qr/{qr_code}", m.handler.GetEquipmentByQR) // Get by QR code
 		r.Get("/serial/{serial}", m.handler.GetEquipmentBySerial) // Get by serial
+
+		// Meter definitions and bulk reading ingestion
+		r.Get("/meter-definitions", m.meterHandler.ListMeterDefinitions)        // List meters for a catalog model
+		r.Post("/meter-definitions", m.meterHandler.DefineMeter)                // Define meter for a catalog model
+		r.Put("/meter-definitions/{id}", m.meterHandler.UpdateMeterDefinition)  // Update meter definition
+		r.Post("/meters/import", m.meterHandler.ImportReadingsCSV)              // CSV reading import
+		r.Post("/meters/gateway", m.meterHandler.IngestGatewayReadings)         // Bulk JSON from device gateways
+
+		// Data quality: normalization, duplicate detection, merge and scores
+		r.Get("/data-quality/duplicates", m.qualityHandler.ListDuplicates)                        // Fuzzy duplicate candidates
+		r.Post("/data-quality/merge", m.qualityHandler.Merge)                                     // Merge duplicate into survivor
+		r.Post("/data-quality/normalize", m.qualityHandler.Normalize)                             // Normalize serials and manufacturers (apply=true writes)
+		r.Get("/data-quality/manufacturer-aliases", m.qualityHandler.ListManufacturerAliases)     // Configured manufacturer aliases
+		r.Post("/data-quality/manufacturer-aliases", m.qualityHandler.AddManufacturerAlias)       // Add manufacturer alias
+		r.Get("/data-quality/scores", m.qualityHandler.ListQualityScores)                         // Quality score per organization
+		r.Get("/data-quality/scores/{customer_id}", m.qualityHandler.GetQualityScore)             // Quality score of one organization
+
+		// Geolocation: postal code gazetteer and installation coordinates
+		r.Post("/geo/gazetteer/import", m.geoHandler.ImportGazetteer)   // Import GeoNames/CSV postal codes
+		r.Post("/geo/geocode", m.geoHandler.Geocode)                    // Geocode installations (dry_run, overwrite)
 		
 		// {id} sub-routes
 		r.Get("/{id}/qr/pdf", m.handler.DownloadQRLabel)   // Download PDF label
 		r.Post("/{id}/qr", m.handler.GenerateQRCode)       // Generate QR code
 		r.Post("/{id}/service", m.handler.RecordService)   // Record service
+		r.Get("/{id}/meters", m.meterHandler.GetUsage)                  // Latest readings and PM status
+		r.Get("/{id}/meters/readings", m.meterHandler.ListReadings)     // Reading history
+		r.Post("/{id}/meters/readings", m.meterHandler.RecordReading)   // Manual reading
+		r.Get("/{id}/merges", m.qualityHandler.ListMerges)              // Records merged into this unit
+		r.Put("/{id}/location", m.geoHandler.SetLocation)               // Manual coordinates override
 		
 		// Base /{id} routes LAST
 		r.Get("/{id}", m.handler.GetEquipment)            // Get by ID
@@ -108,6 +178,17 @@ func (m *Module) MountRoutes(r chi.Router) {
 
 // Start starts background tasks (if any)
 func (m *Module) Start(ctx context.Context) error {
+	// Start usage-based PM scheduler if enabled
+	if m.pmScheduler != nil {
+		go m.pmScheduler.Run(ctx)
+	}
+
+	// Resume import jobs interrupted by a restart
+	if m.importJobs != nil {
+		if err := m.importJobs.ResumeInterrupted(ctx); err != nil {
+			m.logger.Error("Failed to resume import jobs", slog.String("error", err.Error()))
+		}
+	}
 	m.logger.Info("Equipment Registry module started")
 	return nil
 }
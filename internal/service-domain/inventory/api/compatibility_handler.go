@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// CompatibilityHandler handles HTTP requests for part fitments, supersession
+// and alternate links
+type CompatibilityHandler struct {
+	service *app.CompatibilityService
+	logger  *slog.Logger
+}
+
+// NewCompatibilityHandler creates a new parts compatibility HTTP handler
+func NewCompatibilityHandler(service *app.CompatibilityService, logger *slog.Logger) *CompatibilityHandler {
+	return &CompatibilityHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "compatibility_handler")),
+	}
+}
+
+// GetPartCompatibility handles GET /inventory/parts/{id}/compatibility.
+// The part may be given by ID or part number.
+func (h *CompatibilityHandler) GetPartCompatibility(w http.ResponseWriter, r *http.Request) {
+	view, err := h.service.GetPartCompatibility(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondCompatibilityError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, view)
+}
+
+// SetFitments handles PUT /inventory/parts/{id}/fitments
+func (h *CompatibilityHandler) SetFitments(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Fitments []app.FitmentInput `json:"fitments"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	fitments, err := h.service.SetFitments(r.Context(), chi.URLParam(r, "id"), req.Fitments)
+	if err != nil {
+		h.respondCompatibilityError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"fitments": fitments, "total": len(fitments)})
+}
+
+// AddLink handles POST /inventory/parts/{id}/links
+func (h *CompatibilityHandler) AddLink(w http.ResponseWriter, r *http.Request) {
+	var req app.AddLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	link, err := h.service.AddLink(r.Context(), chi.URLParam(r, "id"), req, userFrom(r))
+	if err != nil {
+		h.respondCompatibilityError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, link)
+}
+
+// RemoveLink handles DELETE /inventory/part-links/{id}
+func (h *CompatibilityHandler) RemoveLink(w http.ResponseWriter, r *http.Request) {
+	link, err := h.service.RemoveLink(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondCompatibilityError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, link)
+}
+
+// ResolvePart handles GET /inventory/parts/resolve?part_number=&equipment_id=
+func (h *CompatibilityHandler) ResolvePart(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	ref := query.Get("part_number")
+	if ref == "" {
+		ref = query.Get("spare_part_id")
+	}
+	if ref == "" {
+		h.respondError(w, http.StatusBadRequest, "part_number or spare_part_id is required")
+		return
+	}
+
+	selection, err := h.service.SelectPart(r.Context(), ref, query.Get("equipment_id"))
+	if err != nil {
+		h.respondCompatibilityError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, selection)
+}
+
+// ListCompatibleParts handles
+// GET /inventory/compatible-parts?equipment_id=|equipment_catalog_id=&serial_number=&q=
+func (h *CompatibilityHandler) ListCompatibleParts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	parts, err := h.service.CompatibleParts(r.Context(), app.CompatiblePartsQuery{
+		EquipmentID:        query.Get("equipment_id"),
+		EquipmentCatalogID: query.Get("equipment_catalog_id"),
+		SerialNumber:       query.Get("serial_number"),
+		Query:              query.Get("q"),
+	})
+	if err != nil {
+		h.respondCompatibilityError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"parts": parts, "total": len(parts)})
+}
+
+// respondCompatibilityError maps compatibility errors to HTTP status codes
+func (h *CompatibilityHandler) respondCompatibilityError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPartNotFound), errors.Is(err, domain.ErrEquipmentNotFound),
+		errors.Is(err, domain.ErrLinkNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidCompatibility):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrIncompatiblePart):
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		h.logger.Error("Compatibility request failed", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// respondJSON writes JSON response
+func (h *CompatibilityHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError writes error response
+func (h *CompatibilityHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
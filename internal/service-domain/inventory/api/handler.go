@@ -0,0 +1,216 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// StockHandler handles HTTP requests for stock locations, levels and movements
+type StockHandler struct {
+	service *app.StockService
+	logger  *slog.Logger
+}
+
+// NewStockHandler creates a new stock HTTP handler
+func NewStockHandler(service *app.StockService, logger *slog.Logger) *StockHandler {
+	return &StockHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "stock_handler")),
+	}
+}
+
+// CreateLocation handles POST /inventory/locations
+func (h *StockHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var req app.CreateLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	location, err := h.service.CreateLocation(r.Context(), req)
+	if err != nil {
+		h.respondStockError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, location)
+}
+
+// ListLocations handles GET /inventory/locations?type=&engineer_id=&include_inactive=
+func (h *StockHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	includeInactive, _ := strconv.ParseBool(query.Get("include_inactive"))
+	locations, err := h.service.ListLocations(r.Context(), domain.LocationFilter{
+		Type:            domain.LocationType(query.Get("type")),
+		EngineerID:      query.Get("engineer_id"),
+		IncludeInactive: includeInactive,
+	})
+	if err != nil {
+		h.respondStockError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"locations": locations, "total": len(locations)})
+}
+
+// GetLocation handles GET /inventory/locations/{id}
+func (h *StockHandler) GetLocation(w http.ResponseWriter, r *http.Request) {
+	location, err := h.service.GetLocation(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondStockError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, location)
+}
+
+// ListStock handles GET /inventory/stock?location_id=&spare_part_id=
+func (h *StockHandler) ListStock(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	levels, err := h.service.ListStock(r.Context(), domain.StockFilter{
+		LocationID:  query.Get("location_id"),
+		SparePartID: query.Get("spare_part_id"),
+	})
+	if err != nil {
+		h.respondStockError(w, err)
+		return
+	}
+
+	type stockLine struct {
+		domain.StockLevel
+		Available int `json:"available"`
+	}
+	lines := make([]stockLine, 0, len(levels))
+	for _, level := range levels {
+		lines = append(lines, stockLine{StockLevel: level, Available: level.Available()})
+	}
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"stock": lines, "total": len(lines)})
+}
+
+// ReceiveStock handles POST /inventory/receipts
+func (h *StockHandler) ReceiveStock(w http.ResponseWriter, r *http.Request) {
+	var req app.ReceiveStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	result, err := h.service.ReceiveStock(r.Context(), req, userFrom(r))
+	if err != nil {
+		h.respondStockError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, result)
+}
+
+// TransferStock handles POST /inventory/transfers
+func (h *StockHandler) TransferStock(w http.ResponseWriter, r *http.Request) {
+	var req app.TransferStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	result, err := h.service.TransferStock(r.Context(), req, userFrom(r))
+	if err != nil {
+		h.respondStockError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, result)
+}
+
+// CycleCount handles POST /inventory/counts
+func (h *StockHandler) CycleCount(w http.ResponseWriter, r *http.Request) {
+	var req app.CycleCountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	result, err := h.service.CycleCount(r.Context(), req, userFrom(r))
+	if err != nil {
+		h.respondStockError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, result)
+}
+
+// ListLedger handles GET /inventory/ledger?location_id=&spare_part_id=&reference_id=&limit=
+func (h *StockHandler) ListLedger(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	entries, err := h.service.Ledger(r.Context(), domain.LedgerFilter{
+		LocationID:  query.Get("location_id"),
+		SparePartID: query.Get("spare_part_id"),
+		ReferenceID: query.Get("reference_id"),
+		Limit:       limit,
+	})
+	if err != nil {
+		h.respondStockError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"entries": entries, "total": len(entries)})
+}
+
+// ListReservations handles GET /inventory/reservations?ticket_id=&status=
+func (h *StockHandler) ListReservations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	reservations, err := h.service.ListReservations(r.Context(), domain.ReservationFilter{
+		TicketID: query.Get("ticket_id"),
+		Status:   domain.ReservationStatus(query.Get("status")),
+	})
+	if err != nil {
+		h.respondStockError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"reservations": reservations, "total": len(reservations)})
+}
+
+// userFrom returns the calling user, or "system"
+func userFrom(r *http.Request) string {
+	if user := r.Header.Get("X-User-ID"); user != "" {
+		return user
+	}
+	return "system"
+}
+
+// respondStockError maps stock errors to HTTP status codes
+func (h *StockHandler) respondStockError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrLocationNotFound), errors.Is(err, domain.ErrReservationNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidLocation), errors.Is(err, domain.ErrInvalidMovement):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrLocationExists), errors.Is(err, domain.ErrReservationClosed):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInsufficientStock):
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		h.logger.Error("Stock request failed", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// respondJSON writes JSON response
+func (h *StockHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError writes error response
+func (h *StockHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// PlanningHandler handles HTTP requests for stock policies, replenishment
+// suggestions and stock-out alerts
+type PlanningHandler struct {
+	service *app.PlanningService
+	logger  *slog.Logger
+}
+
+// NewPlanningHandler creates a new replenishment planning HTTP handler
+func NewPlanningHandler(service *app.PlanningService, logger *slog.Logger) *PlanningHandler {
+	return &PlanningHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "planning_handler")),
+	}
+}
+
+// RunPlanning handles POST /inventory/planning/run
+func (h *PlanningHandler) RunPlanning(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.RunPlanning(r.Context(), time.Now())
+	if err != nil {
+		h.respondPlanningError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// ListPolicies handles GET /inventory/policies?location_id=
+func (h *PlanningHandler) ListPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.service.ListPolicies(r.Context(), r.URL.Query().Get("location_id"))
+	if err != nil {
+		h.respondPlanningError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"policies": policies, "total": len(policies)})
+}
+
+// SetPolicy handles PUT /inventory/policies
+func (h *PlanningHandler) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	var req app.SetPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	policy, err := h.service.SetPolicy(r.Context(), req, userFrom(r))
+	if err != nil {
+		h.respondPlanningError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, policy)
+}
+
+// ResetPolicy handles DELETE /inventory/policies/{locationID}/{sparePartID}
+func (h *PlanningHandler) ResetPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.service.ResetPolicy(r.Context(), chi.URLParam(r, "locationID"), chi.URLParam(r, "sparePartID"), userFrom(r))
+	if err != nil {
+		h.respondPlanningError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, policy)
+}
+
+// ListSuggestions handles GET /inventory/suggestions?location_id=&status=pending
+func (h *PlanningHandler) ListSuggestions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	status := domain.SuggestionStatus(query.Get("status"))
+	if status == "" {
+		status = domain.SuggestionPending
+	}
+	suggestions, err := h.service.ListSuggestions(r.Context(), domain.SuggestionFilter{
+		LocationID: query.Get("location_id"),
+		Status:     status,
+	})
+	if err != nil {
+		h.respondPlanningError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"suggestions": suggestions, "total": len(suggestions)})
+}
+
+// ApproveSuggestion handles POST /inventory/suggestions/{id}/approve
+func (h *PlanningHandler) ApproveSuggestion(w http.ResponseWriter, r *http.Request) {
+	var req app.ApproveSuggestionRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	suggestion, err := h.service.ApproveSuggestion(r.Context(), chi.URLParam(r, "id"), req, userFrom(r))
+	if err != nil {
+		h.respondPlanningError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, suggestion)
+}
+
+// RejectSuggestion handles POST /inventory/suggestions/{id}/reject
+func (h *PlanningHandler) RejectSuggestion(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Notes string `json:"notes"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	suggestion, err := h.service.RejectSuggestion(r.Context(), chi.URLParam(r, "id"), userFrom(r), req.Notes)
+	if err != nil {
+		h.respondPlanningError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, suggestion)
+}
+
+// ListAlerts handles GET /inventory/alerts?location_id=&status=open,acknowledged.
+// Unresolved alerts are listed by default.
+func (h *PlanningHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.AlertFilter{LocationID: query.Get("location_id")}
+	for _, s := range strings.Split(query.Get("status"), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			filter.Status = append(filter.Status, domain.AlertStatus(s))
+		}
+	}
+	if len(filter.Status) == 0 {
+		filter.Status = []domain.AlertStatus{domain.AlertOpen, domain.AlertAcknowledged}
+	}
+
+	alerts, err := h.service.ListAlerts(r.Context(), filter)
+	if err != nil {
+		h.respondPlanningError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"alerts": alerts, "total": len(alerts)})
+}
+
+// AcknowledgeAlert handles POST /inventory/alerts/{id}/acknowledge
+func (h *PlanningHandler) AcknowledgeAlert(w http.ResponseWriter, r *http.Request) {
+	alert, err := h.service.AcknowledgeAlert(r.Context(), chi.URLParam(r, "id"), userFrom(r))
+	if err != nil {
+		h.respondPlanningError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, alert)
+}
+
+// respondPlanningError maps planning errors to HTTP status codes
+func (h *PlanningHandler) respondPlanningError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPolicyNotFound), errors.Is(err, domain.ErrSuggestionNotFound),
+		errors.Is(err, domain.ErrAlertNotFound), errors.Is(err, domain.ErrLocationNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidPolicy), errors.Is(err, domain.ErrInvalidLocation),
+		errors.Is(err, domain.ErrInvalidMovement):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrSuggestionReviewed):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInsufficientStock):
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		h.logger.Error("Planning request failed", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// respondJSON writes JSON response
+func (h *PlanningHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError writes error response
+func (h *PlanningHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,383 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+)
+
+// FitmentInput is a fitment of a part given by catalog model reference
+type FitmentInput struct {
+	Model      string `json:"model"` // Catalog model ID, model number or product code
+	SerialFrom string `json:"serial_from,omitempty"`
+	SerialTo   string `json:"serial_to,omitempty"`
+	Notes      string `json:"notes,omitempty"`
+}
+
+// AddLinkRequest links a part to another. With type "supersedes" the part
+// replaces the related part.
+type AddLinkRequest struct {
+	RelatedPartID     string          `json:"related_part_id,omitempty"`
+	RelatedPartNumber string          `json:"related_part_number,omitempty"`
+	Type              domain.LinkType `json:"type"`
+	Notes             string          `json:"notes,omitempty"`
+}
+
+// CompatiblePartsQuery selects the unit to list compatible parts for
+type CompatiblePartsQuery struct {
+	EquipmentID        string // Registered unit; its model and serial number are used
+	EquipmentCatalogID string // Catalog model ID, model number or product code
+	SerialNumber       string
+	Query              string // Part name, number or description
+}
+
+// PartCompatibility is the compatibility view of a catalog part
+type PartCompatibility struct {
+	Part       *domain.CatalogPart `json:"part"`
+	Current    *domain.CatalogPart `json:"current"`              // Part replacing it today; itself when not superseded
+	Superseded []string            `json:"superseded,omitempty"` // Part numbers between the part and its current one
+	Alternates []string            `json:"alternates"`
+	Fitments   []domain.Fitment    `json:"fitments"`
+	Links      []domain.PartLink   `json:"links"`
+}
+
+// CompatibilityService manages the parts compatibility graph and picks
+// fitting, non-obsolete parts for installed units
+type CompatibilityService struct {
+	repo   domain.CompatibilityRepository
+	logger *slog.Logger
+}
+
+// NewCompatibilityService creates a new parts compatibility service
+func NewCompatibilityService(repo domain.CompatibilityRepository, logger *slog.Logger) *CompatibilityService {
+	return &CompatibilityService{
+		repo:   repo,
+		logger: logger.With(slog.String("component", "compatibility_service")),
+	}
+}
+
+// GetPartCompatibility returns the fitments, links and current replacement of a part
+func (s *CompatibilityService) GetPartCompatibility(ctx context.Context, ref string) (*PartCompatibility, error) {
+	part, err := s.findPart(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := s.repo.LoadGraph(ctx, []string{part.ID})
+	if err != nil {
+		return nil, err
+	}
+	current, chain, err := graph.Current(part.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &PartCompatibility{
+		Part:       part,
+		Current:    graph.Part(current),
+		Alternates: []string{},
+		Fitments:   graph.Fitments(part.ID),
+		Links:      graph.Links(part.ID),
+	}
+	if len(chain) > 1 {
+		for _, id := range chain[1:] {
+			if between := graph.Part(id); between != nil {
+				view.Superseded = append(view.Superseded, between.PartNumber)
+			}
+		}
+	}
+	for _, id := range graph.Alternates(part.ID) {
+		if alternate := graph.Part(id); alternate != nil {
+			view.Alternates = append(view.Alternates, alternate.PartNumber)
+		}
+	}
+	if view.Current == nil {
+		view.Current = part
+	}
+	if view.Fitments == nil {
+		view.Fitments = []domain.Fitment{}
+	}
+	if view.Links == nil {
+		view.Links = []domain.PartLink{}
+	}
+	return view, nil
+}
+
+// SetFitments replaces the models and serial ranges a part fits. Models
+// listed for the part in the equipment catalog keep fitting.
+func (s *CompatibilityService) SetFitments(ctx context.Context, ref string, inputs []FitmentInput) ([]domain.Fitment, error) {
+	part, err := s.findPart(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	fitments := make([]*domain.Fitment, 0, len(inputs))
+	for _, input := range inputs {
+		if strings.TrimSpace(input.Model) == "" {
+			return nil, fmt.Errorf("%w: a catalog model is required", domain.ErrInvalidCompatibility)
+		}
+		catalogID, err := s.repo.FindModel(ctx, strings.TrimSpace(input.Model))
+		if err != nil {
+			return nil, err
+		}
+		fitment, err := domain.NewFitment(part.ID, catalogID, input.SerialFrom, input.SerialTo, input.Notes)
+		if err != nil {
+			return nil, err
+		}
+		fitments = append(fitments, fitment)
+	}
+
+	if err := s.repo.ReplaceFitments(ctx, part.ID, fitments); err != nil {
+		return nil, err
+	}
+	graph, err := s.repo.LoadGraph(ctx, []string{part.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Part fitments updated",
+		slog.String("part_number", part.PartNumber),
+		slog.Int("fitments", len(fitments)))
+	return graph.Fitments(part.ID), nil
+}
+
+// AddLink records that a part supersedes, or is interchangeable with,
+// another part. Supersession cycles are rejected.
+func (s *CompatibilityService) AddLink(ctx context.Context, ref string, req AddLinkRequest, createdBy string) (*domain.PartLink, error) {
+	part, err := s.findPart(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	relatedRef := req.RelatedPartID
+	if relatedRef == "" {
+		relatedRef = req.RelatedPartNumber
+	}
+	if relatedRef == "" {
+		return nil, fmt.Errorf("%w: a related part is required", domain.ErrInvalidCompatibility)
+	}
+	related, err := s.findPart(ctx, relatedRef)
+	if err != nil {
+		return nil, err
+	}
+	return s.addLink(ctx, part, related, req.Type, req.Notes, createdBy)
+}
+
+func (s *CompatibilityService) addLink(ctx context.Context, part, related *domain.CatalogPart, linkType domain.LinkType, notes, createdBy string) (*domain.PartLink, error) {
+	link, err := domain.NewPartLink(part.ID, related.ID, linkType, notes, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := s.repo.LoadGraph(ctx, []string{part.ID, related.ID})
+	if err != nil {
+		return nil, err
+	}
+	if err := graph.AddLink(*link); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateLink(ctx, link); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Part link created",
+		slog.String("part_number", part.PartNumber),
+		slog.String("related_part_number", related.PartNumber),
+		slog.String("type", string(linkType)))
+	return link, nil
+}
+
+// RemoveLink removes a link. A part no longer superseded is reinstated.
+func (s *CompatibilityService) RemoveLink(ctx context.Context, id string) (*domain.PartLink, error) {
+	link, err := s.repo.DeleteLink(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Part link removed", slog.String("link_id", id), slog.String("type", string(link.Type)))
+	return link, nil
+}
+
+// ResolvePart resolves a part ID or number to the non-obsolete part to use
+// today, following supersessions and alternates
+func (s *CompatibilityService) ResolvePart(ctx context.Context, ref string) (*domain.PartSelection, error) {
+	return s.SelectPart(ctx, ref, "")
+}
+
+// SelectPart picks the part to use for a requested part on an installed
+// unit. Without a known unit only supersession and obsolescence are checked.
+func (s *CompatibilityService) SelectPart(ctx context.Context, ref, equipmentID string) (*domain.PartSelection, error) {
+	part, err := s.findPart(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	unit, err := s.equipmentModel(ctx, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := s.repo.LoadGraph(ctx, []string{part.ID})
+	if err != nil {
+		return nil, err
+	}
+	return graph.Select(part.ID, unit.EquipmentCatalogID, unit.SerialNumber)
+}
+
+// SelectModelPart picks the part to use for a requested part on a catalog
+// model given by ID, model number or product code. An unknown model is
+// treated as an unknown unit.
+func (s *CompatibilityService) SelectModelPart(ctx context.Context, ref, modelRef string) (*domain.PartSelection, error) {
+	part, err := s.findPart(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var catalogID string
+	if modelRef = strings.TrimSpace(modelRef); modelRef != "" {
+		catalogID, err = s.repo.FindModel(ctx, modelRef)
+		if err != nil && !errors.Is(err, domain.ErrInvalidCompatibility) {
+			return nil, err
+		}
+	}
+	graph, err := s.repo.LoadGraph(ctx, []string{part.ID})
+	if err != nil {
+		return nil, err
+	}
+	return graph.Select(part.ID, catalogID, "")
+}
+
+// CompatibleParts lists the current, non-obsolete parts fitting a unit or
+// catalog model. Superseded parts are listed as their replacements.
+func (s *CompatibilityService) CompatibleParts(ctx context.Context, q CompatiblePartsQuery) ([]*domain.PartSelection, error) {
+	unit := &domain.EquipmentModel{SerialNumber: strings.TrimSpace(q.SerialNumber)}
+	switch {
+	case q.EquipmentID != "":
+		found, err := s.repo.EquipmentModel(ctx, q.EquipmentID)
+		if err != nil {
+			return nil, err
+		}
+		unit = found
+	case q.EquipmentCatalogID != "":
+		catalogID, err := s.repo.FindModel(ctx, q.EquipmentCatalogID)
+		if err != nil {
+			return nil, err
+		}
+		unit.EquipmentCatalogID = catalogID
+	default:
+		return nil, fmt.Errorf("%w: an equipment or catalog model is required", domain.ErrInvalidCompatibility)
+	}
+	if unit.EquipmentCatalogID == "" {
+		return []*domain.PartSelection{}, nil
+	}
+
+	ids, err := s.repo.ModelParts(ctx, unit.EquipmentCatalogID, strings.TrimSpace(q.Query))
+	if err != nil {
+		return nil, err
+	}
+	selections := []*domain.PartSelection{}
+	if len(ids) == 0 {
+		return selections, nil
+	}
+	graph, err := s.repo.LoadGraph(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, id := range ids {
+		selection, err := graph.Select(id, unit.EquipmentCatalogID, unit.SerialNumber)
+		if err != nil {
+			if !errors.Is(err, domain.ErrIncompatiblePart) {
+				s.logger.Warn("Failed to select compatible part",
+					slog.String("spare_part_id", id),
+					slog.String("error", err.Error()))
+			}
+			continue
+		}
+		// Alternates are offered through the part they stand in for only
+		if selection.Alternate || seen[selection.SparePartID] {
+			continue
+		}
+		seen[selection.SparePartID] = true
+		selections = append(selections, selection)
+	}
+	return selections, nil
+}
+
+// ImportCompatibility records the models and the superseded part numbers of
+// an imported part. Models are "MODEL" or "MODEL:FROM..TO" with a serial
+// range, either end of which may be empty. Links that already exist are kept.
+func (s *CompatibilityService) ImportCompatibility(ctx context.Context, partID string, models, supersedes []string, createdBy string) error {
+	if len(models) > 0 {
+		inputs := make([]FitmentInput, 0, len(models))
+		for _, model := range models {
+			input := FitmentInput{Model: model}
+			if i := strings.Index(model, ":"); i >= 0 {
+				input.Model = model[:i]
+				from, to, ok := strings.Cut(model[i+1:], "..")
+				if !ok {
+					return fmt.Errorf("%w: serial range of %s must be FROM..TO", domain.ErrInvalidCompatibility, model)
+				}
+				input.SerialFrom, input.SerialTo = from, to
+			}
+			inputs = append(inputs, input)
+		}
+		if _, err := s.SetFitments(ctx, partID, inputs); err != nil {
+			return err
+		}
+	}
+
+	if len(supersedes) == 0 {
+		return nil
+	}
+	part, err := s.repo.GetPart(ctx, partID)
+	if err != nil {
+		return err
+	}
+	graph, err := s.repo.LoadGraph(ctx, []string{part.ID})
+	if err != nil {
+		return err
+	}
+	for _, partNumber := range supersedes {
+		old, err := s.repo.FindPart(ctx, partNumber)
+		if err != nil {
+			return fmt.Errorf("superseded part %s: %w", partNumber, err)
+		}
+		linked := false
+		for _, l := range graph.Links(part.ID) {
+			linked = linked || (l.Type == domain.LinkSupersedes && l.RelatedPartID == old.ID)
+		}
+		if linked {
+			continue
+		}
+		if _, err := s.addLink(ctx, part, old, domain.LinkSupersedes, "Imported", createdBy); err != nil {
+			return fmt.Errorf("superseded part %s: %w", partNumber, err)
+		}
+	}
+	return nil
+}
+
+// findPart looks a part up by ID, then by part number
+func (s *CompatibilityService) findPart(ctx context.Context, ref string) (*domain.CatalogPart, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, domain.ErrPartNotFound
+	}
+	part, err := s.repo.GetPart(ctx, ref)
+	if errors.Is(err, domain.ErrPartNotFound) {
+		return s.repo.FindPart(ctx, ref)
+	}
+	return part, err
+}
+
+// equipmentModel returns the model of a registered unit. An unknown or
+// unregistered unit leaves the fit unchecked.
+func (s *CompatibilityService) equipmentModel(ctx context.Context, equipmentID string) (*domain.EquipmentModel, error) {
+	if equipmentID == "" {
+		return &domain.EquipmentModel{}, nil
+	}
+	unit, err := s.repo.EquipmentModel(ctx, equipmentID)
+	if errors.Is(err, domain.ErrEquipmentNotFound) {
+		return &domain.EquipmentModel{EquipmentID: equipmentID}, nil
+	}
+	return unit, err
+}
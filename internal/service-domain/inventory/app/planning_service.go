@@ -0,0 +1,362 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+)
+
+// SetPolicyRequest overrides the computed policy of a part at a location
+type SetPolicyRequest struct {
+	LocationID   string `json:"location_id"`
+	SparePartID  string `json:"spare_part_id"`
+	MinQty       int    `json:"min_qty"`
+	ReorderPoint int    `json:"reorder_point"`
+	MaxQty       int    `json:"max_qty"`
+	LeadTimeDays int    `json:"lead_time_days,omitempty"`
+}
+
+// ApproveSuggestionRequest approves a replenishment suggestion
+type ApproveSuggestionRequest struct {
+	Quantity         int    `json:"quantity,omitempty"`           // Defaults to the suggested quantity
+	SourceLocationID string `json:"source_location_id,omitempty"` // Overrides the suggested warehouse of a transfer
+	Notes            string `json:"notes,omitempty"`
+}
+
+// PlanningResult summarizes a replenishment planning run
+type PlanningResult struct {
+	Policies    int `json:"policies"`
+	Suggestions int `json:"suggestions"`
+	Alerts      int `json:"alerts"`
+}
+
+// PlanningService computes stock policies from consumption history, suggests
+// replenishment and raises stock-out alerts for parts of critical equipment
+type PlanningService struct {
+	repo   domain.PlanningRepository
+	stock  *StockService
+	params domain.PlanningParams
+	logger *slog.Logger
+}
+
+// NewPlanningService creates a new replenishment planning service
+func NewPlanningService(repo domain.PlanningRepository, stock *StockService, logger *slog.Logger) *PlanningService {
+	return &PlanningService{
+		repo:   repo,
+		stock:  stock,
+		params: domain.DefaultPlanningParams(),
+		logger: logger.With(slog.String("component", "planning_service")),
+	}
+}
+
+// RunPlanning recomputes the policies of all parts with demand at active
+// locations, suggests replenishment for those at or below their reorder
+// point and raises or resolves stock-out alerts. Manual policies keep their
+// levels. Failures on one part are logged and do not stop the run.
+func (s *PlanningService) RunPlanning(ctx context.Context, now time.Time) (PlanningResult, error) {
+	var result PlanningResult
+	locations, err := s.stock.ListLocations(ctx, domain.LocationFilter{})
+	if err != nil {
+		return result, err
+	}
+	byID := make(map[string]*domain.Location, len(locations))
+	for _, location := range locations {
+		byID[location.ID] = location
+	}
+
+	history, err := s.repo.ConsumptionHistory(ctx, now.AddDate(0, 0, -s.params.LookbackDays))
+	if err != nil {
+		return result, err
+	}
+	info, err := s.repo.PartPlanningInfo(ctx)
+	if err != nil {
+		return result, err
+	}
+	existing, err := s.repo.ListPolicies(ctx, "")
+	if err != nil {
+		return result, err
+	}
+
+	demand := map[stockKey][]domain.DemandPoint{}
+	for _, point := range history {
+		key := stockKey{point.LocationID, point.SparePartID}
+		demand[key] = append(demand[key], point)
+	}
+	current := map[stockKey]*domain.StockPolicy{}
+	for _, policy := range existing {
+		key := stockKey{policy.LocationID, policy.SparePartID}
+		current[key] = policy
+		if _, ok := demand[key]; !ok {
+			demand[key] = nil // Recomputed so that fading demand lowers the levels
+		}
+	}
+
+	policies := make([]*domain.StockPolicy, 0, len(demand))
+	for key, points := range demand {
+		location, ok := byID[key.locationID]
+		if !ok {
+			continue // Inactive
+		}
+		leadTime := info[key.sparePartID].LeadTimeDays
+		if location.Type == domain.LocationVan {
+			leadTime = s.params.VanLeadTimeDays
+		}
+		policy := domain.ComputePolicy(key.locationID, key.sparePartID, points, leadTime, s.params, now)
+		if manual := current[key]; manual != nil && manual.Manual {
+			manual.AvgDailyDemand = policy.AvgDailyDemand
+			manual.DemandStdDev = policy.DemandStdDev
+			policy = *manual
+		}
+		policies = append(policies, &policy)
+	}
+	if err := s.repo.SavePolicies(ctx, policies); err != nil {
+		return result, err
+	}
+	result.Policies = len(policies)
+
+	levels, err := s.stock.ListStock(ctx, domain.StockFilter{})
+	if err != nil {
+		return result, err
+	}
+	stock := make(map[stockKey]domain.StockLevel, len(levels))
+	for _, level := range levels {
+		stock[stockKey{level.LocationID, level.SparePartID}] = level
+	}
+	approved, err := s.repo.ListSuggestions(ctx, domain.SuggestionFilter{
+		Status:        domain.SuggestionApproved,
+		ReviewedSince: now.AddDate(0, 0, -s.params.LookbackDays),
+	})
+	if err != nil {
+		return result, err
+	}
+	ordered := map[stockKey]time.Time{}
+	for _, suggestion := range approved {
+		key := stockKey{suggestion.LocationID, suggestion.SparePartID}
+		if suggestion.Kind == domain.ReplenishPurchase && suggestion.ReviewedAt.After(ordered[key]) {
+			ordered[key] = *suggestion.ReviewedAt
+		}
+	}
+
+	for _, policy := range policies {
+		location := byID[policy.LocationID]
+		part := info[policy.SparePartID]
+		available := stock[stockKey{policy.LocationID, policy.SparePartID}].Available()
+
+		// A purchase approved within the lead time is still on its way
+		onOrder := now.Before(ordered[stockKey{policy.LocationID, policy.SparePartID}].AddDate(0, 0, policy.LeadTimeDays))
+		if suggestion := s.suggest(policy, location, part, available, levels, byID, now); suggestion != nil && !onOrder {
+			if err := s.repo.SaveSuggestion(ctx, suggestion); err != nil {
+				s.logger.Error("Failed to save replenishment suggestion",
+					slog.String("error", err.Error()),
+					slog.String("location_id", policy.LocationID),
+					slog.String("spare_part_id", policy.SparePartID))
+			} else {
+				result.Suggestions++
+			}
+		}
+
+		var alert *domain.StockAlert
+		if part.CriticalUnits > 0 {
+			alert = domain.AssessStockoutRisk(*policy, available, part.CriticalUnits, now)
+		}
+		if alert == nil {
+			err = s.repo.ResolveAlerts(ctx, policy.LocationID, policy.SparePartID)
+		} else if err = s.repo.SaveAlert(ctx, alert); err == nil {
+			result.Alerts++
+		}
+		if err != nil {
+			s.logger.Error("Failed to update stock alert",
+				slog.String("error", err.Error()),
+				slog.String("location_id", policy.LocationID),
+				slog.String("spare_part_id", policy.SparePartID))
+		}
+	}
+	return result, nil
+}
+
+// suggest builds the replenishment of a part at a location, or nil if none
+// is needed. Warehouses purchase from suppliers; vans are restocked from the
+// warehouse with the most available.
+func (s *PlanningService) suggest(policy *domain.StockPolicy, location *domain.Location, part domain.PartPlanningInfo, available int, levels []domain.StockLevel, locations map[string]*domain.Location, now time.Time) *domain.ReplenishmentSuggestion {
+	kind := domain.ReplenishPurchase
+	minOrderQty := part.MinOrderQty
+	if location.Type == domain.LocationVan {
+		kind = domain.ReplenishTransfer
+		minOrderQty = 0
+	}
+	quantity := domain.ReplenishmentQuantity(*policy, available, minOrderQty)
+	if quantity <= 0 {
+		return nil
+	}
+
+	suggestion := &domain.ReplenishmentSuggestion{
+		LocationID:   policy.LocationID,
+		SparePartID:  policy.SparePartID,
+		Kind:         kind,
+		Quantity:     quantity,
+		Available:    available,
+		ReorderPoint: policy.ReorderPoint,
+		MaxQty:       policy.MaxQty,
+		Critical:     part.CriticalUnits > 0,
+		Status:       domain.SuggestionPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if kind == domain.ReplenishTransfer {
+		best := 0
+		for _, level := range levels {
+			source := locations[level.LocationID]
+			if level.SparePartID != policy.SparePartID || source == nil || source.Type != domain.LocationWarehouse {
+				continue
+			}
+			if level.Available() > best {
+				best = level.Available()
+				suggestion.SourceLocationID = level.LocationID
+			}
+		}
+	}
+	return suggestion
+}
+
+// GetPolicy retrieves the policy of a part at a location
+func (s *PlanningService) GetPolicy(ctx context.Context, locationID, sparePartID string) (*domain.StockPolicy, error) {
+	return s.repo.GetPolicy(ctx, locationID, sparePartID)
+}
+
+// ListPolicies lists stock policies, optionally of one location
+func (s *PlanningService) ListPolicies(ctx context.Context, locationID string) ([]*domain.StockPolicy, error) {
+	return s.repo.ListPolicies(ctx, locationID)
+}
+
+// SetPolicy overrides the computed policy of a part at a location. Planning
+// runs keep the levels until the override is reset.
+func (s *PlanningService) SetPolicy(ctx context.Context, req SetPolicyRequest, setBy string) (*domain.StockPolicy, error) {
+	if err := s.stock.ensureActive(ctx, req.LocationID); err != nil {
+		return nil, err
+	}
+	policy, err := s.repo.GetPolicy(ctx, req.LocationID, req.SparePartID)
+	if errors.Is(err, domain.ErrPolicyNotFound) {
+		policy = &domain.StockPolicy{LocationID: req.LocationID, SparePartID: req.SparePartID}
+	} else if err != nil {
+		return nil, err
+	}
+
+	policy.MinQty = req.MinQty
+	policy.ReorderPoint = req.ReorderPoint
+	policy.MaxQty = req.MaxQty
+	if req.LeadTimeDays > 0 {
+		policy.LeadTimeDays = req.LeadTimeDays
+	}
+	policy.Manual = true
+	policy.UpdatedBy = setBy
+	policy.UpdatedAt = time.Now()
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SavePolicies(ctx, []*domain.StockPolicy{policy}); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Stock policy overridden",
+		slog.String("location_id", policy.LocationID),
+		slog.String("spare_part_id", policy.SparePartID),
+		slog.Int("reorder_point", policy.ReorderPoint),
+		slog.Int("max_qty", policy.MaxQty))
+	return policy, nil
+}
+
+// ResetPolicy drops the override of a policy; the next planning run
+// recomputes its levels
+func (s *PlanningService) ResetPolicy(ctx context.Context, locationID, sparePartID, resetBy string) (*domain.StockPolicy, error) {
+	policy, err := s.repo.GetPolicy(ctx, locationID, sparePartID)
+	if err != nil {
+		return nil, err
+	}
+	policy.Manual = false
+	policy.UpdatedBy = resetBy
+	policy.UpdatedAt = time.Now()
+	if err := s.repo.SavePolicies(ctx, []*domain.StockPolicy{policy}); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ListSuggestions lists replenishment suggestions
+func (s *PlanningService) ListSuggestions(ctx context.Context, filter domain.SuggestionFilter) ([]*domain.ReplenishmentSuggestion, error) {
+	return s.repo.ListSuggestions(ctx, filter)
+}
+
+// ApproveSuggestion approves a replenishment suggestion. Approved transfers
+// are executed right away; approved purchases are left for purchasing.
+func (s *PlanningService) ApproveSuggestion(ctx context.Context, id string, req ApproveSuggestionRequest, approvedBy string) (*domain.ReplenishmentSuggestion, error) {
+	suggestion, err := s.repo.GetSuggestion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := suggestion.Approve(approvedBy, req.Quantity, req.Notes, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if suggestion.Kind == domain.ReplenishTransfer {
+		if req.SourceLocationID != "" {
+			suggestion.SourceLocationID = req.SourceLocationID
+		}
+		if suggestion.SourceLocationID == "" {
+			return nil, fmt.Errorf("%w: no warehouse has stock to transfer; choose a source location", domain.ErrInvalidMovement)
+		}
+		transfer, err := s.stock.TransferStock(ctx, TransferStockRequest{
+			FromLocationID: suggestion.SourceLocationID,
+			ToLocationID:   suggestion.LocationID,
+			Lines:          []domain.StockLine{{SparePartID: suggestion.SparePartID, Quantity: suggestion.ApprovedQuantity}},
+			Notes:          "Replenishment " + suggestion.ID,
+		}, approvedBy)
+		if err != nil {
+			return nil, err
+		}
+		suggestion.ReferenceID = transfer.ReferenceID
+	}
+
+	if err := s.repo.UpdateSuggestionReview(ctx, suggestion); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Replenishment suggestion approved",
+		slog.String("suggestion_id", suggestion.ID),
+		slog.String("kind", string(suggestion.Kind)),
+		slog.Int("quantity", suggestion.ApprovedQuantity))
+	return suggestion, nil
+}
+
+// RejectSuggestion rejects a replenishment suggestion
+func (s *PlanningService) RejectSuggestion(ctx context.Context, id, rejectedBy, notes string) (*domain.ReplenishmentSuggestion, error) {
+	suggestion, err := s.repo.GetSuggestion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := suggestion.Reject(rejectedBy, notes, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateSuggestionReview(ctx, suggestion); err != nil {
+		return nil, err
+	}
+	return suggestion, nil
+}
+
+// ListAlerts lists stock-out alerts
+func (s *PlanningService) ListAlerts(ctx context.Context, filter domain.AlertFilter) ([]*domain.StockAlert, error) {
+	return s.repo.ListAlerts(ctx, filter)
+}
+
+// AcknowledgeAlert marks an alert as seen
+func (s *PlanningService) AcknowledgeAlert(ctx context.Context, id, acknowledgedBy string) (*domain.StockAlert, error) {
+	return s.repo.AcknowledgeAlert(ctx, id, acknowledgedBy)
+}
+
+// stockKey identifies a part at a location
+type stockKey struct {
+	locationID  string
+	sparePartID string
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/shared/config"
+)
+
+// ReplenishmentPlanner runs replenishment planning once a day
+type ReplenishmentPlanner struct {
+	service  *PlanningService
+	interval time.Duration
+	now      func() time.Time
+	logger   *slog.Logger
+}
+
+// NewReplenishmentPlanner creates a new daily replenishment planner
+func NewReplenishmentPlanner(service *PlanningService, logger *slog.Logger) *ReplenishmentPlanner {
+	return &ReplenishmentPlanner{
+		service:  service,
+		interval: 24 * time.Hour,
+		now:      time.Now,
+		logger:   logger.With(slog.String("component", "replenishment_planner")),
+	}
+}
+
+// Run plans replenishment until the context is cancelled.
+// Disabled with ENABLE_REPLENISHMENT_PLANNER=false.
+func (p *ReplenishmentPlanner) Run(ctx context.Context) {
+	if !config.Enabled("ENABLE_REPLENISHMENT_PLANNER") {
+		p.logger.Info("Replenishment planner disabled; skipping run")
+		return
+	}
+
+	p.RunOnce(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce plans replenishment once
+func (p *ReplenishmentPlanner) RunOnce(ctx context.Context) {
+	result, err := p.service.RunPlanning(ctx, p.now())
+	if err != nil {
+		p.logger.Error("Failed to plan replenishment", slog.String("error", err.Error()))
+		return
+	}
+	p.logger.Info("Replenishment planned",
+		slog.Int("policies", result.Policies),
+		slog.Int("suggestions", result.Suggestions),
+		slog.Int("alerts", result.Alerts))
+}
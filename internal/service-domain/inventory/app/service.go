@@ -0,0 +1,276 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// CreateLocationRequest creates a warehouse or an engineer's van
+type CreateLocationRequest struct {
+	Code       string              `json:"code"`
+	Name       string              `json:"name"`
+	Type       domain.LocationType `json:"type"`
+	EngineerID string              `json:"engineer_id,omitempty"` // Required for vans
+	Address    string              `json:"address,omitempty"`
+}
+
+// ReceiveStockRequest receives parts into a location
+type ReceiveStockRequest struct {
+	LocationID string             `json:"location_id"`
+	Lines      []domain.StockLine `json:"lines"`
+	Reference  string             `json:"reference,omitempty"` // Supplier delivery note or PO number
+	Notes      string             `json:"notes,omitempty"`
+}
+
+// TransferStockRequest moves parts between locations
+type TransferStockRequest struct {
+	FromLocationID string             `json:"from_location_id"`
+	ToLocationID   string             `json:"to_location_id"`
+	Lines          []domain.StockLine `json:"lines"`
+	Notes          string             `json:"notes,omitempty"`
+}
+
+// CycleCountRequest records the quantities counted at a location
+type CycleCountRequest struct {
+	LocationID string             `json:"location_id"`
+	Counts     []domain.StockLine `json:"counts"`
+	Notes      string             `json:"notes,omitempty"`
+}
+
+// MovementResult is the outcome of a receipt, transfer or count
+type MovementResult struct {
+	ReferenceID string                `json:"reference_id"`
+	Entries     []*domain.LedgerEntry `json:"entries"`
+}
+
+// TicketReservationRequest reserves stock for a part attached to a ticket
+type TicketReservationRequest struct {
+	TicketID     string
+	TicketPartID string
+	SparePartID  string
+	Quantity     int
+	LocationID   string // Defaults to the engineer's van, then the best stocked warehouse
+	EngineerID   string
+}
+
+// StockService manages spare parts stock across warehouses and vans
+type StockService struct {
+	repo   domain.Repository
+	logger *slog.Logger
+}
+
+// NewStockService creates a new stock service
+func NewStockService(repo domain.Repository, logger *slog.Logger) *StockService {
+	return &StockService{
+		repo:   repo,
+		logger: logger.With(slog.String("component", "stock_service")),
+	}
+}
+
+// CreateLocation creates a stock location
+func (s *StockService) CreateLocation(ctx context.Context, req CreateLocationRequest) (*domain.Location, error) {
+	location, err := domain.NewLocation(req.Code, req.Name, req.Type, req.EngineerID, req.Address)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateLocation(ctx, location); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Stock location created",
+		slog.String("location_id", location.ID),
+		slog.String("code", location.Code),
+		slog.String("type", string(location.Type)))
+	return location, nil
+}
+
+// GetLocation retrieves a stock location
+func (s *StockService) GetLocation(ctx context.Context, id string) (*domain.Location, error) {
+	return s.repo.GetLocation(ctx, id)
+}
+
+// ListLocations lists stock locations
+func (s *StockService) ListLocations(ctx context.Context, filter domain.LocationFilter) ([]*domain.Location, error) {
+	return s.repo.ListLocations(ctx, filter)
+}
+
+// ListStock lists on hand, reserved and available quantities
+func (s *StockService) ListStock(ctx context.Context, filter domain.StockFilter) ([]domain.StockLevel, error) {
+	return s.repo.ListStock(ctx, filter)
+}
+
+// ReceiveStock receives parts into a location
+func (s *StockService) ReceiveStock(ctx context.Context, req ReceiveStockRequest, receivedBy string) (*MovementResult, error) {
+	if err := s.ensureActive(ctx, req.LocationID); err != nil {
+		return nil, err
+	}
+	if len(req.Lines) == 0 {
+		return nil, fmt.Errorf("%w: nothing to receive", domain.ErrInvalidMovement)
+	}
+
+	referenceID := req.Reference
+	if referenceID == "" {
+		referenceID = ksuid.New().String()
+	}
+	movements := make([]domain.Movement, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		movements = append(movements, domain.Movement{
+			LocationID:    req.LocationID,
+			SparePartID:   line.SparePartID,
+			Type:          domain.MovementReceipt,
+			Quantity:      line.Quantity,
+			ReferenceType: domain.ReferenceReceipt,
+			ReferenceID:   referenceID,
+			Reason:        req.Notes,
+		})
+	}
+	return s.apply(ctx, referenceID, movements, receivedBy)
+}
+
+// TransferStock moves available parts from one location to another
+func (s *StockService) TransferStock(ctx context.Context, req TransferStockRequest, transferredBy string) (*MovementResult, error) {
+	for _, id := range []string{req.FromLocationID, req.ToLocationID} {
+		if err := s.ensureActive(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	transferID := ksuid.New().String()
+	movements, err := domain.TransferMovements(transferID, req.FromLocationID, req.ToLocationID, req.Lines, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+	return s.apply(ctx, transferID, movements, transferredBy)
+}
+
+// CycleCount sets the stock of a location to the counted quantities
+func (s *StockService) CycleCount(ctx context.Context, req CycleCountRequest, countedBy string) (*MovementResult, error) {
+	if _, err := s.repo.GetLocation(ctx, req.LocationID); err != nil {
+		return nil, err
+	}
+	countID := ksuid.New().String()
+	movements, err := domain.CountMovements(countID, req.LocationID, req.Counts, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+	return s.apply(ctx, countID, movements, countedBy)
+}
+
+// Ledger lists stock movements
+func (s *StockService) Ledger(ctx context.Context, filter domain.LedgerFilter) ([]*domain.LedgerEntry, error) {
+	return s.repo.ListLedger(ctx, filter)
+}
+
+// ListReservations lists stock reservations
+func (s *StockService) ListReservations(ctx context.Context, filter domain.ReservationFilter) ([]*domain.Reservation, error) {
+	return s.repo.ListReservations(ctx, filter)
+}
+
+// ReserveForTicket reserves stock for a part attached to a ticket, at the
+// requested location or else where domain.ChooseReservationLocation finds it
+func (s *StockService) ReserveForTicket(ctx context.Context, req TicketReservationRequest, reservedBy string) (*domain.Reservation, error) {
+	locationID := req.LocationID
+	if locationID == "" {
+		levels, err := s.repo.ListStock(ctx, domain.StockFilter{SparePartID: req.SparePartID})
+		if err != nil {
+			return nil, err
+		}
+		locations, err := s.repo.ListLocations(ctx, domain.LocationFilter{})
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]*domain.Location, len(locations))
+		for _, location := range locations {
+			byID[location.ID] = location
+		}
+		if locationID, err = domain.ChooseReservationLocation(levels, byID, req.EngineerID, req.Quantity); err != nil {
+			return nil, err
+		}
+	} else if err := s.ensureActive(ctx, locationID); err != nil {
+		return nil, err
+	}
+
+	reservation, err := domain.NewReservation(req.TicketID, req.TicketPartID, req.SparePartID, locationID, req.Quantity, reservedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateReservation(ctx, reservation); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Stock reserved for ticket",
+		slog.String("ticket_id", reservation.TicketID),
+		slog.String("spare_part_id", reservation.SparePartID),
+		slog.String("location_id", reservation.LocationID),
+		slog.Int("quantity", reservation.Quantity))
+	return reservation, nil
+}
+
+// ReleaseTicketPart releases the active reservations of a part removed from a ticket
+func (s *StockService) ReleaseTicketPart(ctx context.Context, ticketPartID, releasedBy string) ([]*domain.Reservation, error) {
+	return s.closeReservations(ctx, domain.ReservationFilter{TicketPartID: ticketPartID}, domain.ReservationReleased, releasedBy)
+}
+
+// ReleaseForTicket releases the active reservations of a cancelled ticket
+func (s *StockService) ReleaseForTicket(ctx context.Context, ticketID, releasedBy string) ([]*domain.Reservation, error) {
+	return s.closeReservations(ctx, domain.ReservationFilter{TicketID: ticketID}, domain.ReservationReleased, releasedBy)
+}
+
+// ConsumeForTicket consumes the active reservations of a resolved ticket,
+// taking the parts out of stock
+func (s *StockService) ConsumeForTicket(ctx context.Context, ticketID, consumedBy string) ([]*domain.Reservation, error) {
+	return s.closeReservations(ctx, domain.ReservationFilter{TicketID: ticketID}, domain.ReservationConsumed, consumedBy)
+}
+
+func (s *StockService) closeReservations(ctx context.Context, filter domain.ReservationFilter, status domain.ReservationStatus, closedBy string) ([]*domain.Reservation, error) {
+	filter.Status = domain.ReservationActive
+	active, err := s.repo.ListReservations(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make([]*domain.Reservation, 0, len(active))
+	for _, reservation := range active {
+		updated, err := s.repo.CloseReservation(ctx, reservation.ID, status, closedBy)
+		if errors.Is(err, domain.ErrReservationClosed) {
+			continue // Closed concurrently
+		}
+		if err != nil {
+			return closed, err
+		}
+		closed = append(closed, updated)
+	}
+	if len(closed) > 0 {
+		s.logger.Info("Stock reservations closed",
+			slog.String("status", string(status)),
+			slog.String("ticket_id", closed[0].TicketID),
+			slog.Int("count", len(closed)))
+	}
+	return closed, nil
+}
+
+func (s *StockService) apply(ctx context.Context, referenceID string, movements []domain.Movement, createdBy string) (*MovementResult, error) {
+	entries, err := s.repo.ApplyMovements(ctx, movements, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Stock moved",
+		slog.String("type", string(movements[0].ReferenceType)),
+		slog.String("reference_id", referenceID),
+		slog.Int("entries", len(entries)))
+	return &MovementResult{ReferenceID: referenceID, Entries: entries}, nil
+}
+
+// ensureActive checks that stock can be moved into or out of the location
+func (s *StockService) ensureActive(ctx context.Context, locationID string) error {
+	location, err := s.repo.GetLocation(ctx, locationID)
+	if err != nil {
+		return err
+	}
+	if !location.Active {
+		return fmt.Errorf("%w: %s is inactive", domain.ErrInvalidLocation, location.Code)
+	}
+	return nil
+}
@@ -0,0 +1,406 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrPartNotFound         = errors.New("spare part not found")
+	ErrEquipmentNotFound    = errors.New("equipment not found")
+	ErrInvalidCompatibility = errors.New("invalid part compatibility")
+	ErrLinkNotFound         = errors.New("part link not found")
+	ErrIncompatiblePart     = errors.New("part does not fit the equipment")
+)
+
+// CatalogPart is a spare part of the catalog as seen by the compatibility graph
+type CatalogPart struct {
+	ID         string `json:"id"`
+	PartNumber string `json:"part_number"`
+	PartName   string `json:"part_name"`
+	Obsolete   bool   `json:"obsolete"`
+}
+
+// Fitment says a part fits a catalog model, optionally only the units in a
+// serial number range
+type Fitment struct {
+	ID                 string    `json:"id,omitempty"`
+	SparePartID        string    `json:"spare_part_id"`
+	EquipmentCatalogID string    `json:"equipment_catalog_id"`
+	ModelNumber        string    `json:"model_number,omitempty"`
+	SerialFrom         string    `json:"serial_from,omitempty"` // Inclusive; empty for open
+	SerialTo           string    `json:"serial_to,omitempty"`   // Inclusive; empty for open
+	Notes              string    `json:"notes,omitempty"`
+	FromCatalog        bool      `json:"from_catalog,omitempty"` // Listed as an equipment spare part of the model
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// NewFitment creates a fitment of a part to a catalog model
+func NewFitment(sparePartID, equipmentCatalogID, serialFrom, serialTo, notes string) (*Fitment, error) {
+	f := &Fitment{
+		ID:                 ksuid.New().String(),
+		SparePartID:        sparePartID,
+		EquipmentCatalogID: strings.TrimSpace(equipmentCatalogID),
+		SerialFrom:         strings.TrimSpace(serialFrom),
+		SerialTo:           strings.TrimSpace(serialTo),
+		Notes:              notes,
+		CreatedAt:          time.Now(),
+	}
+	if f.EquipmentCatalogID == "" {
+		return nil, fmt.Errorf("%w: a catalog model is required", ErrInvalidCompatibility)
+	}
+	if f.SerialFrom != "" && f.SerialTo != "" && compareSerials(f.SerialFrom, f.SerialTo) > 0 {
+		return nil, fmt.Errorf("%w: serial range %s-%s is reversed", ErrInvalidCompatibility, f.SerialFrom, f.SerialTo)
+	}
+	return f, nil
+}
+
+// Covers reports whether the fitment includes the unit with the serial
+// number. Without a serial number only unrestricted fitments cover it.
+func (f Fitment) Covers(serial string) bool {
+	if f.SerialFrom == "" && f.SerialTo == "" {
+		return true
+	}
+	if strings.TrimSpace(serial) == "" {
+		return false
+	}
+	return (f.SerialFrom == "" || compareSerials(serial, f.SerialFrom) >= 0) &&
+		(f.SerialTo == "" || compareSerials(serial, f.SerialTo) <= 0)
+}
+
+// compareSerials orders serial numbers by length, then case-insensitively,
+// so that "SN900" comes before "SN1000"
+func compareSerials(a, b string) int {
+	a = strings.ToUpper(strings.TrimSpace(a))
+	b = strings.ToUpper(strings.TrimSpace(b))
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// LinkType is the relation between two parts
+type LinkType string
+
+const (
+	LinkSupersedes LinkType = "supersedes" // The part replaces the related part, which becomes obsolete
+	LinkAlternate  LinkType = "alternate"  // The parts are interchangeable
+)
+
+// PartLink relates two parts of the catalog
+type PartLink struct {
+	ID            string    `json:"id"`
+	PartID        string    `json:"part_id"`
+	RelatedPartID string    `json:"related_part_id"` // The superseded part, for supersessions
+	Type          LinkType  `json:"type"`
+	Notes         string    `json:"notes,omitempty"`
+	CreatedBy     string    `json:"created_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// NewPartLink creates a supersession or alternate link between two parts
+func NewPartLink(partID, relatedPartID string, linkType LinkType, notes, createdBy string) (*PartLink, error) {
+	if partID == "" || relatedPartID == "" {
+		return nil, fmt.Errorf("%w: both parts are required", ErrInvalidCompatibility)
+	}
+	if partID == relatedPartID {
+		return nil, fmt.Errorf("%w: a part cannot be linked to itself", ErrInvalidCompatibility)
+	}
+	if linkType != LinkSupersedes && linkType != LinkAlternate {
+		return nil, fmt.Errorf("%w: type must be supersedes or alternate", ErrInvalidCompatibility)
+	}
+	return &PartLink{
+		ID:            ksuid.New().String(),
+		PartID:        partID,
+		RelatedPartID: relatedPartID,
+		Type:          linkType,
+		Notes:         notes,
+		CreatedBy:     createdBy,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// FitStatus is what the graph knows about a part fitting a unit
+type FitStatus string
+
+const (
+	FitConfirmed FitStatus = "confirmed" // A fitment covers the unit
+	FitUnknown   FitStatus = "unknown"   // No fitments recorded, or no serial number to check the range
+	FitNone      FitStatus = "none"      // The part only fits other models or serial ranges
+)
+
+// PartSelection is the part to use for a requested part: the requested one,
+// its current successor or an interchangeable alternate
+type PartSelection struct {
+	SparePartID     string    `json:"spare_part_id"`
+	PartNumber      string    `json:"part_number"`
+	PartName        string    `json:"part_name"`
+	RequestedPartID string    `json:"requested_part_id"`
+	Superseded      []string  `json:"superseded,omitempty"` // Part numbers replaced along the chain, oldest first
+	Alternate       bool      `json:"alternate,omitempty"`  // Substituted because the requested line does not fit
+	Fit             FitStatus `json:"fit"`
+}
+
+// PartGraph is the compatibility graph of a set of parts: fitments to
+// catalog models and supersession and alternate links
+type PartGraph struct {
+	parts    map[string]*CatalogPart
+	fitments map[string][]Fitment
+	links    []PartLink
+}
+
+// NewPartGraph builds a graph from loaded parts, links and fitments
+func NewPartGraph(parts []*CatalogPart, links []PartLink, fitments []Fitment) *PartGraph {
+	g := &PartGraph{
+		parts:    make(map[string]*CatalogPart, len(parts)),
+		fitments: map[string][]Fitment{},
+	}
+	for _, part := range parts {
+		g.parts[part.ID] = part
+	}
+	for _, f := range fitments {
+		g.fitments[f.SparePartID] = append(g.fitments[f.SparePartID], f)
+	}
+	g.links = append(g.links, links...)
+	// Newest first, so the latest supersession of a part wins
+	sort.SliceStable(g.links, func(i, j int) bool { return g.links[i].CreatedAt.After(g.links[j].CreatedAt) })
+	return g
+}
+
+// Part returns a part of the graph, or nil
+func (g *PartGraph) Part(id string) *CatalogPart {
+	return g.parts[id]
+}
+
+// Fitments returns the fitments recorded for the part itself
+func (g *PartGraph) Fitments(id string) []Fitment {
+	return g.fitments[id]
+}
+
+// Links returns the links of a part
+func (g *PartGraph) Links(id string) []PartLink {
+	links := []PartLink{}
+	for _, l := range g.links {
+		if l.PartID == id || l.RelatedPartID == id {
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// AddLink adds a link, rejecting supersessions that would close a cycle
+func (g *PartGraph) AddLink(link PartLink) error {
+	for _, l := range g.links {
+		if l.PartID == link.PartID && l.RelatedPartID == link.RelatedPartID && l.Type == link.Type {
+			return fmt.Errorf("%w: the parts are already linked", ErrInvalidCompatibility)
+		}
+	}
+	g.links = append([]PartLink{link}, g.links...)
+	if link.Type == LinkSupersedes {
+		if _, _, err := g.Current(link.RelatedPartID); err != nil {
+			g.links = g.links[1:]
+			return err
+		}
+	}
+	return nil
+}
+
+// Current follows the supersession chain of a part to the part that
+// replaces it today. It returns the superseded parts along the way.
+func (g *PartGraph) Current(id string) (string, []string, error) {
+	chain := []string{}
+	seen := map[string]bool{id: true}
+	for {
+		next := g.successor(id)
+		if next == "" {
+			return id, chain, nil
+		}
+		if seen[next] {
+			return "", nil, fmt.Errorf("%w: supersession cycle through %s", ErrInvalidCompatibility, g.number(next))
+		}
+		seen[next] = true
+		chain = append(chain, id)
+		id = next
+	}
+}
+
+func (g *PartGraph) successor(id string) string {
+	for _, l := range g.links {
+		if l.Type == LinkSupersedes && l.RelatedPartID == id {
+			return l.PartID
+		}
+	}
+	return ""
+}
+
+// predecessors returns the parts the part supersedes, directly or not
+func (g *PartGraph) predecessors(id string) []string {
+	found := []string{}
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, l := range g.links {
+			if l.Type == LinkSupersedes && l.PartID == current && !seen[l.RelatedPartID] {
+				seen[l.RelatedPartID] = true
+				found = append(found, l.RelatedPartID)
+				queue = append(queue, l.RelatedPartID)
+			}
+		}
+	}
+	return found
+}
+
+// Alternates returns the parts interchangeable with the part
+func (g *PartGraph) Alternates(id string) []string {
+	alternates := []string{}
+	for _, l := range g.links {
+		switch {
+		case l.Type != LinkAlternate:
+		case l.PartID == id:
+			alternates = append(alternates, l.RelatedPartID)
+		case l.RelatedPartID == id:
+			alternates = append(alternates, l.PartID)
+		}
+	}
+	return alternates
+}
+
+// Fit checks a part against a unit of a catalog model. A part inherits the
+// fitments of the parts it supersedes.
+func (g *PartGraph) Fit(id, equipmentCatalogID, serial string) FitStatus {
+	if equipmentCatalogID == "" {
+		return FitUnknown
+	}
+	mapped, ranged := false, false
+	for _, partID := range append([]string{id}, g.predecessors(id)...) {
+		for _, f := range g.fitments[partID] {
+			mapped = true
+			if f.EquipmentCatalogID != equipmentCatalogID {
+				continue
+			}
+			if f.Covers(serial) {
+				return FitConfirmed
+			}
+			if strings.TrimSpace(serial) == "" {
+				ranged = true
+			}
+		}
+	}
+	if mapped && !ranged {
+		return FitNone
+	}
+	return FitUnknown
+}
+
+// Select picks the part to use for a requested part on a unit: the current
+// part of its supersession chain if it fits, else a fitting alternate. Parts
+// without recorded fitments are accepted; parts known to fit other models
+// only are not.
+func (g *PartGraph) Select(id, equipmentCatalogID, serial string) (*PartSelection, error) {
+	requested := g.parts[id]
+	if requested == nil {
+		return nil, ErrPartNotFound
+	}
+	current, chain, err := g.Current(id)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := append([]string{current}, g.Alternates(current)...)
+	if current != id {
+		candidates = append(candidates, g.Alternates(id)...)
+	}
+	seen := map[string]bool{}
+	for i, candidate := range candidates {
+		if i > 0 {
+			if candidate, _, err = g.Current(candidate); err != nil {
+				continue
+			}
+		}
+		part := g.parts[candidate]
+		if seen[candidate] || part == nil || part.Obsolete {
+			continue
+		}
+		seen[candidate] = true
+		fit := g.Fit(candidate, equipmentCatalogID, serial)
+		if fit == FitNone {
+			continue
+		}
+
+		selection := &PartSelection{
+			SparePartID:     part.ID,
+			PartNumber:      part.PartNumber,
+			PartName:        part.PartName,
+			RequestedPartID: id,
+			Alternate:       i > 0,
+			Fit:             fit,
+		}
+		for _, superseded := range chain {
+			selection.Superseded = append(selection.Superseded, g.number(superseded))
+		}
+		return selection, nil
+	}
+
+	if part := g.parts[current]; part != nil && part.Obsolete && g.Fit(current, equipmentCatalogID, serial) != FitNone {
+		return nil, fmt.Errorf("%w: %s is obsolete without a replacement", ErrIncompatiblePart, part.PartNumber)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrIncompatiblePart, requested.PartNumber)
+}
+
+func (g *PartGraph) number(id string) string {
+	if part := g.parts[id]; part != nil {
+		return part.PartNumber
+	}
+	return id
+}
+
+// EquipmentModel is the catalog model and serial number of an installed unit
+type EquipmentModel struct {
+	EquipmentID        string
+	EquipmentCatalogID string
+	SerialNumber       string
+}
+
+// CompatibilityRepository persists the parts compatibility graph
+type CompatibilityRepository interface {
+	// GetPart retrieves a catalog part
+	GetPart(ctx context.Context, id string) (*CatalogPart, error)
+
+	// FindPart retrieves a catalog part by part number
+	FindPart(ctx context.Context, partNumber string) (*CatalogPart, error)
+
+	// FindModel finds a catalog model by ID, model number or product code
+	FindModel(ctx context.Context, ref string) (string, error)
+
+	// EquipmentModel returns the catalog model and serial number of an installed unit
+	EquipmentModel(ctx context.Context, equipmentID string) (*EquipmentModel, error)
+
+	// LoadGraph loads the given parts and every part linked to them, directly
+	// or not, with their links and fitments
+	LoadGraph(ctx context.Context, partIDs []string) (*PartGraph, error)
+
+	// ModelParts returns the parts with a fitment to the catalog model,
+	// optionally matching the text query
+	ModelParts(ctx context.Context, equipmentCatalogID, query string) ([]string, error)
+
+	// ReplaceFitments replaces the fitments of a part atomically
+	ReplaceFitments(ctx context.Context, sparePartID string, fitments []*Fitment) error
+
+	// CreateLink stores a link; a supersession marks the superseded part obsolete
+	CreateLink(ctx context.Context, link *PartLink) error
+
+	// DeleteLink removes a link; removing a supersession reinstates the
+	// superseded part unless another part still supersedes it
+	DeleteLink(ctx context.Context, id string) (*PartLink, error)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFitmentCovers(t *testing.T) {
+	f, err := NewFitment("part-1", "model-1", "sn900", "SN1200", "")
+	if err != nil {
+		t.Fatalf("new fitment: %v", err)
+	}
+	for serial, want := range map[string]bool{"SN900": true, "SN1000": true, "sn1200": true, "SN899": false, "SN1201": false, "": false} {
+		if got := f.Covers(serial); got != want {
+			t.Errorf("Covers(%q) = %v, want %v", serial, got, want)
+		}
+	}
+	if _, err := NewFitment("part-1", "model-1", "SN2000", "SN1000", ""); !errors.Is(err, ErrInvalidCompatibility) {
+		t.Fatalf("expected a reversed range to fail, got %v", err)
+	}
+}
+
+func TestPartGraphSelect(t *testing.T) {
+	at := func(minutes int) time.Time { return time.Date(2026, 10, 1, 0, minutes, 0, 0, time.UTC) }
+	parts := []*CatalogPart{
+		{ID: "a", PartNumber: "TUBE-A", Obsolete: true},
+		{ID: "b", PartNumber: "TUBE-B", Obsolete: true},
+		{ID: "c", PartNumber: "TUBE-C"},
+		{ID: "alt", PartNumber: "TUBE-ALT"},
+		{ID: "loose", PartNumber: "CABLE"},
+	}
+	links := []PartLink{
+		{PartID: "b", RelatedPartID: "a", Type: LinkSupersedes, CreatedAt: at(1)},
+		{PartID: "c", RelatedPartID: "b", Type: LinkSupersedes, CreatedAt: at(2)},
+		{PartID: "alt", RelatedPartID: "c", Type: LinkAlternate, CreatedAt: at(3)},
+	}
+	fitments := []Fitment{
+		{SparePartID: "a", EquipmentCatalogID: "ct-64"},                                            // Inherited by B and C
+		{SparePartID: "alt", EquipmentCatalogID: "ct-128", SerialFrom: "SN100", SerialTo: "SN500"}, // Only early CT-128 units
+	}
+	g := NewPartGraph(parts, links, fitments)
+
+	selection, err := g.Select("a", "ct-64", "SN1")
+	if err != nil {
+		t.Fatalf("select on ct-64: %v", err)
+	}
+	if selection.SparePartID != "c" || selection.Fit != FitConfirmed || len(selection.Superseded) != 2 || selection.Superseded[0] != "TUBE-A" {
+		t.Fatalf("expected TUBE-C superseding A and B, got %+v", selection)
+	}
+
+	selection, err = g.Select("a", "ct-128", "SN200")
+	if err != nil || selection.SparePartID != "alt" || !selection.Alternate {
+		t.Fatalf("expected the alternate on early ct-128 units, got %+v, %v", selection, err)
+	}
+	if _, err := g.Select("a", "ct-128", "SN900"); !errors.Is(err, ErrIncompatiblePart) {
+		t.Fatalf("expected late ct-128 units not to fit, got %v", err)
+	}
+	if selection, err := g.Select("a", "ct-128", ""); err != nil || selection.Fit != FitUnknown {
+		t.Fatalf("expected an unverified fit without a serial number, got %+v, %v", selection, err)
+	}
+	if selection, err := g.Select("loose", "ct-64", "SN1"); err != nil || selection.Fit != FitUnknown {
+		t.Fatalf("expected parts without fitments to pass unverified, got %+v, %v", selection, err)
+	}
+
+	if err := g.AddLink(PartLink{PartID: "a", RelatedPartID: "c", Type: LinkSupersedes, CreatedAt: at(4)}); !errors.Is(err, ErrInvalidCompatibility) {
+		t.Fatalf("expected a supersession cycle to be rejected, got %v", err)
+	}
+	if current, _, err := g.Current("a"); err != nil || current != "c" {
+		t.Fatalf("expected the rejected link to be dropped, got %s, %v", current, err)
+	}
+}
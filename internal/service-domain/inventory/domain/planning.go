@@ -0,0 +1,368 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	ErrPolicyNotFound     = errors.New("stock policy not found")
+	ErrInvalidPolicy      = errors.New("invalid stock policy")
+	ErrSuggestionNotFound = errors.New("replenishment suggestion not found")
+	ErrSuggestionReviewed = errors.New("replenishment suggestion already reviewed")
+	ErrAlertNotFound      = errors.New("stock alert not found")
+)
+
+// PlanningParams tune how stock policies are computed from consumption
+type PlanningParams struct {
+	LookbackDays        int     // Days of consumption history considered
+	ServiceLevelZ       float64 // Safety factor; 1.65 covers demand on ~95% of replenishment cycles
+	ReviewDays          int     // Days of demand the max level holds beyond the reorder point
+	DefaultLeadTimeDays int     // Supplier lead time when the catalog has none
+	VanLeadTimeDays     int     // Vans are replenished from a warehouse
+}
+
+// DefaultPlanningParams returns the planning defaults
+func DefaultPlanningParams() PlanningParams {
+	return PlanningParams{
+		LookbackDays:        90,
+		ServiceLevelZ:       1.65,
+		ReviewDays:          14,
+		DefaultLeadTimeDays: 7,
+		VanLeadTimeDays:     2,
+	}
+}
+
+// DemandPoint is the quantity of a part consumed at a location on one day
+type DemandPoint struct {
+	LocationID  string
+	SparePartID string
+	Day         time.Time
+	Quantity    int
+}
+
+// StockPolicy holds the min/max and reorder point of a part at a location
+type StockPolicy struct {
+	LocationID     string    `json:"location_id"`
+	SparePartID    string    `json:"spare_part_id"`
+	PartNumber     string    `json:"part_number,omitempty"`
+	PartName       string    `json:"part_name,omitempty"`
+	MinQty         int       `json:"min_qty"`       // Safety stock
+	ReorderPoint   int       `json:"reorder_point"` // Replenish when available stock falls to this
+	MaxQty         int       `json:"max_qty"`       // Replenish up to this
+	AvgDailyDemand float64   `json:"avg_daily_demand"`
+	DemandStdDev   float64   `json:"demand_std_dev"`
+	LeadTimeDays   int       `json:"lead_time_days"`
+	Manual         bool      `json:"manual"` // Set by a planner and kept by the daily computation
+	UpdatedBy      string    `json:"updated_by,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ComputePolicy derives a policy from the consumption history. Safety stock
+// covers demand variability over the lead time, the reorder point adds the
+// expected lead time demand and the max level another review period of demand.
+func ComputePolicy(locationID, sparePartID string, history []DemandPoint, leadTimeDays int, params PlanningParams, now time.Time) StockPolicy {
+	if params.LookbackDays <= 0 {
+		params.LookbackDays = DefaultPlanningParams().LookbackDays
+	}
+	if leadTimeDays <= 0 {
+		leadTimeDays = params.DefaultLeadTimeDays
+	}
+	since := now.AddDate(0, 0, -params.LookbackDays)
+
+	daily := make(map[string]int)
+	total := 0
+	for _, point := range history {
+		if point.Day.Before(since) || point.Day.After(now) || point.Quantity <= 0 {
+			continue
+		}
+		daily[point.Day.Format("2006-01-02")] += point.Quantity
+		total += point.Quantity
+	}
+
+	days := float64(params.LookbackDays)
+	mean := float64(total) / days
+	variance := 0.0
+	for _, qty := range daily {
+		variance += math.Pow(float64(qty)-mean, 2)
+	}
+	// Days without consumption count as zero demand
+	variance += float64(params.LookbackDays-len(daily)) * mean * mean
+	stdDev := math.Sqrt(variance / days)
+
+	policy := StockPolicy{
+		LocationID:     locationID,
+		SparePartID:    sparePartID,
+		AvgDailyDemand: math.Round(mean*1000) / 1000,
+		DemandStdDev:   math.Round(stdDev*1000) / 1000,
+		LeadTimeDays:   leadTimeDays,
+		UpdatedBy:      "planner",
+		UpdatedAt:      now,
+	}
+	if total == 0 {
+		return policy
+	}
+	policy.MinQty = int(math.Ceil(params.ServiceLevelZ * stdDev * math.Sqrt(float64(leadTimeDays))))
+	policy.ReorderPoint = policy.MinQty + int(math.Ceil(mean*float64(leadTimeDays)))
+	policy.MaxQty = policy.ReorderPoint + int(math.Ceil(mean*float64(params.ReviewDays)))
+	if policy.MaxQty <= policy.ReorderPoint {
+		policy.MaxQty = policy.ReorderPoint + 1
+	}
+	return policy
+}
+
+// Validate checks a planner-set policy
+func (p *StockPolicy) Validate() error {
+	if p.LocationID == "" || p.SparePartID == "" {
+		return fmt.Errorf("%w: location and spare part are required", ErrInvalidPolicy)
+	}
+	if p.MinQty < 0 || p.ReorderPoint < p.MinQty || p.MaxQty <= p.ReorderPoint {
+		return fmt.Errorf("%w: need 0 <= min_qty <= reorder_point < max_qty", ErrInvalidPolicy)
+	}
+	return nil
+}
+
+// DaysOfCover returns how long the available stock lasts at the average
+// demand, or -1 without demand
+func (p *StockPolicy) DaysOfCover(available int) float64 {
+	if p.AvgDailyDemand <= 0 {
+		return -1
+	}
+	return math.Round(float64(available)/p.AvgDailyDemand*10) / 10
+}
+
+// ReplenishmentKind says where replenishment comes from
+type ReplenishmentKind string
+
+const (
+	ReplenishPurchase ReplenishmentKind = "purchase" // Ordered from a supplier
+	ReplenishTransfer ReplenishmentKind = "transfer" // Moved from a warehouse, e.g. to a van
+)
+
+// SuggestionStatus represents the review of a replenishment suggestion
+type SuggestionStatus string
+
+const (
+	SuggestionPending  SuggestionStatus = "pending"
+	SuggestionApproved SuggestionStatus = "approved"
+	SuggestionRejected SuggestionStatus = "rejected"
+)
+
+// ReplenishmentSuggestion is a suggested order or transfer for a part whose
+// available stock fell to its reorder point
+type ReplenishmentSuggestion struct {
+	ID               string            `json:"id"`
+	LocationID       string            `json:"location_id"`
+	SparePartID      string            `json:"spare_part_id"`
+	PartNumber       string            `json:"part_number,omitempty"`
+	PartName         string            `json:"part_name,omitempty"`
+	Kind             ReplenishmentKind `json:"kind"`
+	SourceLocationID string            `json:"source_location_id,omitempty"` // Warehouse to transfer from
+	Quantity         int               `json:"quantity"`
+	Available        int               `json:"available"`
+	ReorderPoint     int               `json:"reorder_point"`
+	MaxQty           int               `json:"max_qty"`
+	Critical         bool              `json:"critical"` // Needed by critical equipment in the field
+	Status           SuggestionStatus  `json:"status"`
+	ApprovedQuantity int               `json:"approved_quantity,omitempty"`
+	ReferenceID      string            `json:"reference_id,omitempty"` // Transfer executed on approval
+	ReviewedBy       string            `json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time        `json:"reviewed_at,omitempty"`
+	ReviewNotes      string            `json:"review_notes,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+}
+
+// ReplenishmentQuantity returns how much to order to bring the available
+// stock back to the max level, at least the minimum order quantity. It
+// returns 0 while the stock is above the reorder point.
+func ReplenishmentQuantity(policy StockPolicy, available, minOrderQty int) int {
+	if policy.MaxQty <= 0 || available > policy.ReorderPoint {
+		return 0
+	}
+	quantity := policy.MaxQty - available
+	if quantity < minOrderQty {
+		quantity = minOrderQty
+	}
+	return quantity
+}
+
+// Approve approves the suggestion, optionally with a changed quantity
+func (s *ReplenishmentSuggestion) Approve(by string, quantity int, notes string, now time.Time) error {
+	if s.Status != SuggestionPending {
+		return fmt.Errorf("%w: %s", ErrSuggestionReviewed, s.Status)
+	}
+	if quantity == 0 {
+		quantity = s.Quantity
+	}
+	if quantity < 0 {
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidMovement)
+	}
+	s.Status = SuggestionApproved
+	s.ApprovedQuantity = quantity
+	s.ReviewedBy = by
+	s.ReviewedAt = &now
+	s.ReviewNotes = notes
+	s.UpdatedAt = now
+	return nil
+}
+
+// Reject rejects the suggestion
+func (s *ReplenishmentSuggestion) Reject(by, notes string, now time.Time) error {
+	if s.Status != SuggestionPending {
+		return fmt.Errorf("%w: %s", ErrSuggestionReviewed, s.Status)
+	}
+	s.Status = SuggestionRejected
+	s.ReviewedBy = by
+	s.ReviewedAt = &now
+	s.ReviewNotes = notes
+	s.UpdatedAt = now
+	return nil
+}
+
+// AlertSeverity grades a stock-out risk
+type AlertSeverity string
+
+const (
+	AlertStockout AlertSeverity = "stockout" // Nothing available
+	AlertHigh     AlertSeverity = "high"     // Runs out before replenishment can arrive
+)
+
+// AlertStatus represents the handling of a stock alert
+type AlertStatus string
+
+const (
+	AlertOpen         AlertStatus = "open"
+	AlertAcknowledged AlertStatus = "acknowledged"
+	AlertResolved     AlertStatus = "resolved" // The risk went away
+)
+
+// StockAlert warns that a part needed by critical equipment is about to run
+// out at a location
+type StockAlert struct {
+	ID             string        `json:"id"`
+	LocationID     string        `json:"location_id"`
+	SparePartID    string        `json:"spare_part_id"`
+	PartNumber     string        `json:"part_number,omitempty"`
+	PartName       string        `json:"part_name,omitempty"`
+	Severity       AlertSeverity `json:"severity"`
+	Available      int           `json:"available"`
+	DaysOfCover    float64       `json:"days_of_cover"`
+	LeadTimeDays   int           `json:"lead_time_days"`
+	CriticalUnits  int           `json:"critical_units"` // Installed units whose operation depends on the part
+	Message        string        `json:"message"`
+	Status         AlertStatus   `json:"status"`
+	AcknowledgedBy string        `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time    `json:"acknowledged_at,omitempty"`
+	ResolvedAt     *time.Time    `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// AssessStockoutRisk checks a part with demand at a location. It returns
+// nil when the available stock lasts longer than the lead time.
+func AssessStockoutRisk(policy StockPolicy, available, criticalUnits int, now time.Time) *StockAlert {
+	cover := policy.DaysOfCover(available)
+	if cover < 0 {
+		return nil
+	}
+
+	alert := &StockAlert{
+		LocationID:    policy.LocationID,
+		SparePartID:   policy.SparePartID,
+		PartNumber:    policy.PartNumber,
+		PartName:      policy.PartName,
+		Available:     available,
+		DaysOfCover:   cover,
+		LeadTimeDays:  policy.LeadTimeDays,
+		CriticalUnits: criticalUnits,
+		Status:        AlertOpen,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	switch {
+	case available <= 0:
+		alert.Severity = AlertStockout
+		alert.Message = fmt.Sprintf("Out of stock; needed by %d critical installed units", criticalUnits)
+	case cover < float64(policy.LeadTimeDays):
+		alert.Severity = AlertHigh
+		alert.Message = fmt.Sprintf("%.1f days of cover against a %d day lead time; needed by %d critical installed units",
+			cover, policy.LeadTimeDays, criticalUnits)
+	default:
+		return nil
+	}
+	return alert
+}
+
+// PartPlanningInfo is catalog and installed base data used for planning
+type PartPlanningInfo struct {
+	LeadTimeDays  int
+	MinOrderQty   int
+	CriticalUnits int // Installed units of models for which the part is critical
+}
+
+// SuggestionFilter narrows a suggestion listing
+type SuggestionFilter struct {
+	LocationID    string
+	Status        SuggestionStatus
+	ReviewedSince time.Time
+}
+
+// AlertFilter narrows a stock alert listing
+type AlertFilter struct {
+	LocationID string
+	Status     []AlertStatus
+}
+
+// PlanningRepository persists stock policies, replenishment suggestions and
+// stock alerts, and reads the demand history
+type PlanningRepository interface {
+	// ConsumptionHistory returns daily demand per location and part since
+	// the given time: stock consumed for tickets or transferred out, plus
+	// parts used on resolved tickets that consumed no stock, attributed to
+	// the engineer's van
+	ConsumptionHistory(ctx context.Context, since time.Time) ([]DemandPoint, error)
+
+	// PartPlanningInfo returns lead times, minimum order quantities and
+	// critical installed units per spare part
+	PartPlanningInfo(ctx context.Context) (map[string]PartPlanningInfo, error)
+
+	// GetPolicy retrieves the policy of a part at a location
+	GetPolicy(ctx context.Context, locationID, sparePartID string) (*StockPolicy, error)
+
+	// ListPolicies lists policies with part numbers and names
+	ListPolicies(ctx context.Context, locationID string) ([]*StockPolicy, error)
+
+	// SavePolicies upserts policies per location and part
+	SavePolicies(ctx context.Context, policies []*StockPolicy) error
+
+	// SaveSuggestion creates a pending suggestion, or refreshes the pending
+	// suggestion of the same location and part
+	SaveSuggestion(ctx context.Context, suggestion *ReplenishmentSuggestion) error
+
+	// GetSuggestion retrieves a suggestion
+	GetSuggestion(ctx context.Context, id string) (*ReplenishmentSuggestion, error)
+
+	// ListSuggestions lists suggestions, critical ones first
+	ListSuggestions(ctx context.Context, filter SuggestionFilter) ([]*ReplenishmentSuggestion, error)
+
+	// UpdateSuggestionReview stores the review of a pending suggestion,
+	// failing with ErrSuggestionReviewed if it was reviewed meanwhile
+	UpdateSuggestionReview(ctx context.Context, suggestion *ReplenishmentSuggestion) error
+
+	// SaveAlert creates an open alert, or refreshes the unresolved alert of
+	// the same location and part
+	SaveAlert(ctx context.Context, alert *StockAlert) error
+
+	// ListAlerts lists alerts, stock-outs first
+	ListAlerts(ctx context.Context, filter AlertFilter) ([]*StockAlert, error)
+
+	// AcknowledgeAlert marks an open alert as seen
+	AcknowledgeAlert(ctx context.Context, id, by string) (*StockAlert, error)
+
+	// ResolveAlerts resolves the unresolved alerts of a part at a location
+	ResolveAlerts(ctx context.Context, locationID, sparePartID string) error
+}
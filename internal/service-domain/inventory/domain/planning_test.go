@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputePolicy(t *testing.T) {
+	now := time.Date(2026, 10, 11, 12, 0, 0, 0, time.UTC)
+	params := PlanningParams{LookbackDays: 10, ServiceLevelZ: 1.65, ReviewDays: 7, DefaultLeadTimeDays: 4}
+
+	// Two units every other day: mean 1/day, standard deviation 1
+	var history []DemandPoint
+	for i := 0; i < 10; i += 2 {
+		history = append(history, DemandPoint{Day: now.AddDate(0, 0, -i), Quantity: 2})
+	}
+	history = append(history, DemandPoint{Day: now.AddDate(0, 0, -30), Quantity: 50}) // Outside the lookback
+
+	policy := ComputePolicy("van-1", "part-1", history, 0, params, now)
+	if policy.AvgDailyDemand != 1 || policy.DemandStdDev != 1 || policy.LeadTimeDays != 4 {
+		t.Fatalf("unexpected demand: %+v", policy)
+	}
+	// Safety stock ceil(1.65*1*sqrt(4)) = 4, plus 4 days of lead time demand, plus 7 days of review
+	if policy.MinQty != 4 || policy.ReorderPoint != 8 || policy.MaxQty != 15 {
+		t.Fatalf("expected 4/8/15, got %d/%d/%d", policy.MinQty, policy.ReorderPoint, policy.MaxQty)
+	}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("computed policy invalid: %v", err)
+	}
+
+	if got := ReplenishmentQuantity(policy, 9, 0); got != 0 {
+		t.Fatalf("expected no replenishment above the reorder point, got %d", got)
+	}
+	if got := ReplenishmentQuantity(policy, 8, 0); got != 7 {
+		t.Fatalf("expected to order up to max, got %d", got)
+	}
+	if got := ReplenishmentQuantity(policy, 8, 10); got != 10 {
+		t.Fatalf("expected the minimum order quantity, got %d", got)
+	}
+
+	idle := ComputePolicy("van-1", "part-2", nil, 0, params, now)
+	if idle.ReorderPoint != 0 || idle.MaxQty != 0 || ReplenishmentQuantity(idle, 0, 5) != 0 {
+		t.Fatalf("expected no stocking without demand, got %+v", idle)
+	}
+}
+
+func TestAssessStockoutRisk(t *testing.T) {
+	now := time.Now()
+	policy := StockPolicy{LocationID: "wh-1", SparePartID: "part-1", AvgDailyDemand: 1, LeadTimeDays: 4}
+
+	tests := []struct {
+		name      string
+		policy    StockPolicy
+		available int
+		want      AlertSeverity
+	}{
+		{name: "out of stock", policy: policy, available: 0, want: AlertStockout},
+		{name: "runs out before replenishment", policy: policy, available: 3, want: AlertHigh},
+		{name: "covers the lead time", policy: policy, available: 5},
+		{name: "no demand", policy: StockPolicy{LeadTimeDays: 4}, available: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := AssessStockoutRisk(tt.policy, tt.available, 3, now)
+			if tt.want == "" {
+				if alert != nil {
+					t.Fatalf("expected no alert, got %+v", alert)
+				}
+				return
+			}
+			if alert == nil || alert.Severity != tt.want || alert.CriticalUnits != 3 {
+				t.Fatalf("expected a %s alert, got %+v", tt.want, alert)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import "context"
+
+// LocationFilter narrows a location listing
+type LocationFilter struct {
+	Type            LocationType
+	EngineerID      string
+	IncludeInactive bool
+}
+
+// StockFilter narrows a stock listing
+type StockFilter struct {
+	LocationID  string
+	SparePartID string
+}
+
+// ReservationFilter narrows a reservation listing
+type ReservationFilter struct {
+	TicketID     string
+	TicketPartID string
+	Status       ReservationStatus
+}
+
+// LedgerFilter narrows a stock ledger listing
+type LedgerFilter struct {
+	LocationID  string
+	SparePartID string
+	ReferenceID string
+	Limit       int
+}
+
+// Repository persists stock locations, levels, reservations and the stock ledger
+type Repository interface {
+	// CreateLocation stores a location, failing with ErrLocationExists for a used code
+	CreateLocation(ctx context.Context, location *Location) error
+
+	// GetLocation retrieves a location
+	GetLocation(ctx context.Context, id string) (*Location, error)
+
+	// ListLocations lists locations by code
+	ListLocations(ctx context.Context, filter LocationFilter) ([]*Location, error)
+
+	// ListStock lists stock levels with part numbers and names
+	ListStock(ctx context.Context, filter StockFilter) ([]StockLevel, error)
+
+	// ApplyMovements applies the movements atomically, writing one ledger
+	// entry each; none are applied if any fails
+	ApplyMovements(ctx context.Context, movements []Movement, createdBy string) ([]*LedgerEntry, error)
+
+	// CreateReservation reserves the stock and stores the reservation atomically
+	CreateReservation(ctx context.Context, reservation *Reservation) error
+
+	// CloseReservation consumes or releases an active reservation atomically
+	CloseReservation(ctx context.Context, id string, status ReservationStatus, closedBy string) (*Reservation, error)
+
+	// ListReservations lists reservations, oldest first
+	ListReservations(ctx context.Context, filter ReservationFilter) ([]*Reservation, error)
+
+	// ListLedger lists ledger entries, newest first
+	ListLedger(ctx context.Context, filter LedgerFilter) ([]*LedgerEntry, error)
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// ReservationStatus is the lifecycle status of a stock reservation
+type ReservationStatus string
+
+const (
+	ReservationActive   ReservationStatus = "active"
+	ReservationConsumed ReservationStatus = "consumed" // Used when the ticket was resolved
+	ReservationReleased ReservationStatus = "released" // Part removed or ticket cancelled
+)
+
+// Reservation holds stock at one location for a part attached to a ticket
+type Reservation struct {
+	ID           string            `json:"id"`
+	TicketID     string            `json:"ticket_id"`
+	TicketPartID string            `json:"ticket_part_id"`
+	SparePartID  string            `json:"spare_part_id"`
+	LocationID   string            `json:"location_id"`
+	Quantity     int               `json:"quantity"`
+	Status       ReservationStatus `json:"status"`
+	CreatedBy    string            `json:"created_by"`
+	CreatedAt    time.Time         `json:"created_at"`
+	ClosedBy     string            `json:"closed_by,omitempty"`
+	ClosedAt     *time.Time        `json:"closed_at,omitempty"`
+}
+
+// NewReservation creates an active reservation
+func NewReservation(ticketID, ticketPartID, sparePartID, locationID string, quantity int, createdBy string) (*Reservation, error) {
+	if ticketID == "" || sparePartID == "" || locationID == "" || quantity <= 0 {
+		return nil, fmt.Errorf("%w: a reservation needs a ticket, a spare part, a location and a positive quantity", ErrInvalidMovement)
+	}
+	return &Reservation{
+		ID:           ksuid.New().String(),
+		TicketID:     ticketID,
+		TicketPartID: ticketPartID,
+		SparePartID:  sparePartID,
+		LocationID:   locationID,
+		Quantity:     quantity,
+		Status:       ReservationActive,
+		CreatedBy:    createdBy,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+// Movement is the stock movement that places the reservation
+func (r *Reservation) Movement() Movement {
+	return Movement{
+		LocationID:    r.LocationID,
+		SparePartID:   r.SparePartID,
+		Type:          MovementReserve,
+		Quantity:      r.Quantity,
+		ReferenceType: ReferenceTicket,
+		ReferenceID:   r.TicketID,
+	}
+}
+
+// Close consumes or releases an active reservation and returns the stock
+// movement that does so
+func (r *Reservation) Close(status ReservationStatus, closedBy string, now time.Time) (Movement, error) {
+	if r.Status != ReservationActive {
+		return Movement{}, ErrReservationClosed
+	}
+	m := Movement{
+		LocationID:    r.LocationID,
+		SparePartID:   r.SparePartID,
+		Quantity:      r.Quantity,
+		ReferenceType: ReferenceTicket,
+		ReferenceID:   r.TicketID,
+	}
+	switch status {
+	case ReservationConsumed:
+		m.Type = MovementConsume
+	case ReservationReleased:
+		m.Type = MovementRelease
+	default:
+		return Movement{}, fmt.Errorf("%w: reservations are closed as consumed or released", ErrInvalidMovement)
+	}
+	r.Status = status
+	r.ClosedBy = closedBy
+	r.ClosedAt = &now
+	return m, nil
+}
+
+// ChooseReservationLocation picks where to reserve stock for a ticket: the
+// engineer's own van when it has enough, otherwise the warehouse with the
+// most available. Other engineers' vans are never drawn from.
+func ChooseReservationLocation(levels []StockLevel, locations map[string]*Location, engineerID string, quantity int) (string, error) {
+	best, bestAvailable := "", 0
+	for _, level := range levels {
+		location, ok := locations[level.LocationID]
+		if !ok || !location.Active || level.Available() < quantity {
+			continue
+		}
+		if location.Type == LocationVan {
+			if engineerID != "" && location.EngineerID == engineerID {
+				return location.ID, nil
+			}
+			continue
+		}
+		if level.Available() > bestAvailable {
+			best, bestAvailable = location.ID, level.Available()
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("%w: no location has %d available", ErrInsufficientStock, quantity)
+	}
+	return best, nil
+}
@@ -0,0 +1,273 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrLocationNotFound    = errors.New("stock location not found")
+	ErrInvalidLocation     = errors.New("invalid stock location")
+	ErrLocationExists      = errors.New("stock location code already in use")
+	ErrInvalidMovement     = errors.New("invalid stock movement")
+	ErrInsufficientStock   = errors.New("insufficient stock")
+	ErrReservationNotFound = errors.New("stock reservation not found")
+	ErrReservationClosed   = errors.New("stock reservation already consumed or released")
+)
+
+// LocationType distinguishes warehouses from engineers' van stock
+type LocationType string
+
+const (
+	LocationWarehouse LocationType = "warehouse"
+	LocationVan       LocationType = "van" // Carried by one field engineer
+)
+
+// Location is a place spare parts are stocked
+type Location struct {
+	ID         string       `json:"id"`
+	Code       string       `json:"code"`
+	Name       string       `json:"name"`
+	Type       LocationType `json:"type"`
+	EngineerID string       `json:"engineer_id,omitempty"` // Owner of a van
+	Address    string       `json:"address,omitempty"`
+	Active     bool         `json:"active"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// NewLocation creates a stock location. Vans belong to one engineer.
+func NewLocation(code, name string, locationType LocationType, engineerID, address string) (*Location, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" || strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: code and name are required", ErrInvalidLocation)
+	}
+	switch locationType {
+	case LocationWarehouse:
+		engineerID = ""
+	case LocationVan:
+		if strings.TrimSpace(engineerID) == "" {
+			return nil, fmt.Errorf("%w: a van needs an engineer", ErrInvalidLocation)
+		}
+	default:
+		return nil, fmt.Errorf("%w: type must be warehouse or van", ErrInvalidLocation)
+	}
+
+	now := time.Now()
+	return &Location{
+		ID:         ksuid.New().String(),
+		Code:       code,
+		Name:       strings.TrimSpace(name),
+		Type:       locationType,
+		EngineerID: strings.TrimSpace(engineerID),
+		Address:    address,
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// StockLevel is the stock of one spare part at one location
+type StockLevel struct {
+	LocationID  string    `json:"location_id"`
+	SparePartID string    `json:"spare_part_id"`
+	PartNumber  string    `json:"part_number,omitempty"`
+	PartName    string    `json:"part_name,omitempty"`
+	OnHand      int       `json:"on_hand"`
+	Reserved    int       `json:"reserved"` // Held for tickets, still on hand
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Available is the stock on hand that is not reserved
+func (s StockLevel) Available() int {
+	return s.OnHand - s.Reserved
+}
+
+// MovementType is the kind of change to a stock level
+type MovementType string
+
+const (
+	MovementReceipt     MovementType = "receipt"      // Received into the location
+	MovementReserve     MovementType = "reserve"      // Held for a ticket
+	MovementRelease     MovementType = "release"      // Hold given back
+	MovementConsume     MovementType = "consume"      // Reserved stock used on a ticket
+	MovementTransferOut MovementType = "transfer_out" // Sent to another location
+	MovementTransferIn  MovementType = "transfer_in"  // Received from another location
+	MovementAdjustment  MovementType = "adjustment"   // Corrected by a cycle count
+)
+
+// ReferenceType is what caused a movement
+type ReferenceType string
+
+const (
+	ReferenceReceipt  ReferenceType = "receipt"
+	ReferenceTicket   ReferenceType = "ticket"
+	ReferenceTransfer ReferenceType = "transfer"
+	ReferenceCount    ReferenceType = "cycle_count"
+)
+
+// Movement changes the stock of a spare part at a location
+type Movement struct {
+	LocationID    string        `json:"location_id"`
+	SparePartID   string        `json:"spare_part_id"`
+	Type          MovementType  `json:"type"`
+	Quantity      int           `json:"quantity"` // Counted quantity for adjustments
+	ReferenceType ReferenceType `json:"reference_type"`
+	ReferenceID   string        `json:"reference_id"`
+	Reason        string        `json:"reason,omitempty"`
+}
+
+// LedgerEntry records one movement and the stock level it left behind
+type LedgerEntry struct {
+	ID             string        `json:"id"`
+	LocationID     string        `json:"location_id"`
+	SparePartID    string        `json:"spare_part_id"`
+	Type           MovementType  `json:"type"`
+	OnHandChange   int           `json:"on_hand_change"`
+	ReservedChange int           `json:"reserved_change"`
+	OnHandAfter    int           `json:"on_hand_after"`
+	ReservedAfter  int           `json:"reserved_after"`
+	ReferenceType  ReferenceType `json:"reference_type"`
+	ReferenceID    string        `json:"reference_id"`
+	Reason         string        `json:"reason,omitempty"`
+	CreatedBy      string        `json:"created_by"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+// Apply applies a movement to the stock level and returns its ledger entry.
+// Reserving, transferring out and counting never touch reserved stock, so
+// on hand stays at or above reserved.
+func (s *StockLevel) Apply(m Movement, createdBy string, now time.Time) (*LedgerEntry, error) {
+	if m.LocationID != s.LocationID || m.SparePartID != s.SparePartID {
+		return nil, fmt.Errorf("%w: movement is for another stock level", ErrInvalidMovement)
+	}
+	if m.Type == MovementAdjustment {
+		if m.Quantity < 0 {
+			return nil, fmt.Errorf("%w: counted quantity cannot be negative", ErrInvalidMovement)
+		}
+	} else if m.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidMovement)
+	}
+
+	onHand, reserved := 0, 0
+	switch m.Type {
+	case MovementReceipt, MovementTransferIn:
+		onHand = m.Quantity
+	case MovementReserve:
+		if m.Quantity > s.Available() {
+			return nil, fmt.Errorf("%w: %d available, %d requested", ErrInsufficientStock, s.Available(), m.Quantity)
+		}
+		reserved = m.Quantity
+	case MovementRelease:
+		if m.Quantity > s.Reserved {
+			return nil, fmt.Errorf("%w: only %d reserved", ErrInvalidMovement, s.Reserved)
+		}
+		reserved = -m.Quantity
+	case MovementConsume:
+		if m.Quantity > s.Reserved {
+			return nil, fmt.Errorf("%w: only %d reserved", ErrInvalidMovement, s.Reserved)
+		}
+		onHand, reserved = -m.Quantity, -m.Quantity
+	case MovementTransferOut:
+		if m.Quantity > s.Available() {
+			return nil, fmt.Errorf("%w: %d available, %d requested", ErrInsufficientStock, s.Available(), m.Quantity)
+		}
+		onHand = -m.Quantity
+	case MovementAdjustment:
+		if m.Quantity < s.Reserved {
+			return nil, fmt.Errorf("%w: counted %d but %d are reserved; release the reservations first",
+				ErrInsufficientStock, m.Quantity, s.Reserved)
+		}
+		onHand = m.Quantity - s.OnHand
+	default:
+		return nil, fmt.Errorf("%w: unknown movement type %q", ErrInvalidMovement, m.Type)
+	}
+
+	s.OnHand += onHand
+	s.Reserved += reserved
+	s.UpdatedAt = now
+	return &LedgerEntry{
+		ID:             ksuid.New().String(),
+		LocationID:     s.LocationID,
+		SparePartID:    s.SparePartID,
+		Type:           m.Type,
+		OnHandChange:   onHand,
+		ReservedChange: reserved,
+		OnHandAfter:    s.OnHand,
+		ReservedAfter:  s.Reserved,
+		ReferenceType:  m.ReferenceType,
+		ReferenceID:    m.ReferenceID,
+		Reason:         m.Reason,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+	}, nil
+}
+
+// StockLine is a quantity of one spare part
+type StockLine struct {
+	SparePartID string `json:"spare_part_id"`
+	Quantity    int    `json:"quantity"`
+}
+
+// TransferMovements moves stock between two locations, out of one and into the other
+func TransferMovements(transferID, fromLocationID, toLocationID string, lines []StockLine, reason string) ([]Movement, error) {
+	if fromLocationID == "" || toLocationID == "" || fromLocationID == toLocationID {
+		return nil, fmt.Errorf("%w: a transfer needs two different locations", ErrInvalidMovement)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: nothing to transfer", ErrInvalidMovement)
+	}
+
+	movements := make([]Movement, 0, 2*len(lines))
+	for _, line := range lines {
+		if line.SparePartID == "" || line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: every line needs a spare part and a positive quantity", ErrInvalidMovement)
+		}
+		for _, m := range []Movement{
+			{LocationID: fromLocationID, Type: MovementTransferOut},
+			{LocationID: toLocationID, Type: MovementTransferIn},
+		} {
+			m.SparePartID = line.SparePartID
+			m.Quantity = line.Quantity
+			m.ReferenceType = ReferenceTransfer
+			m.ReferenceID = transferID
+			m.Reason = reason
+			movements = append(movements, m)
+		}
+	}
+	return movements, nil
+}
+
+// CountMovements sets the stock of a location to the quantities counted on a
+// cycle count; every counted part gets a ledger entry, changed or not
+func CountMovements(countID, locationID string, counts []StockLine, reason string) ([]Movement, error) {
+	if locationID == "" || len(counts) == 0 {
+		return nil, fmt.Errorf("%w: a cycle count needs a location and counted parts", ErrInvalidMovement)
+	}
+
+	seen := map[string]bool{}
+	movements := make([]Movement, 0, len(counts))
+	for _, count := range counts {
+		if count.SparePartID == "" || count.Quantity < 0 {
+			return nil, fmt.Errorf("%w: every count needs a spare part and a quantity", ErrInvalidMovement)
+		}
+		if seen[count.SparePartID] {
+			return nil, fmt.Errorf("%w: spare part %s counted twice", ErrInvalidMovement, count.SparePartID)
+		}
+		seen[count.SparePartID] = true
+		movements = append(movements, Movement{
+			LocationID:    locationID,
+			SparePartID:   count.SparePartID,
+			Type:          MovementAdjustment,
+			Quantity:      count.Quantity,
+			ReferenceType: ReferenceCount,
+			ReferenceID:   countID,
+			Reason:        reason,
+		})
+	}
+	return movements, nil
+}
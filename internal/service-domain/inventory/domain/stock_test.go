@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStockLevelApply(t *testing.T) {
+	now := time.Now()
+	level := &StockLevel{LocationID: "wh-1", SparePartID: "part-1"}
+	move := func(typ MovementType, quantity int) error {
+		_, err := level.Apply(Movement{LocationID: "wh-1", SparePartID: "part-1", Type: typ, Quantity: quantity}, "tester", now)
+		return err
+	}
+
+	if err := move(MovementReceipt, 10); err != nil {
+		t.Fatalf("receipt: %v", err)
+	}
+	if err := move(MovementReserve, 4); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if err := move(MovementTransferOut, 7); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("expected reserved stock to stay put, got %v", err)
+	}
+	if err := move(MovementConsume, 3); err != nil {
+		t.Fatalf("consume: %v", err)
+	}
+	if level.OnHand != 7 || level.Reserved != 1 || level.Available() != 6 {
+		t.Fatalf("expected 7 on hand, 1 reserved, got %+v", level)
+	}
+	if err := move(MovementAdjustment, 0); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("expected a count below reserved to fail, got %v", err)
+	}
+
+	entry, err := level.Apply(Movement{LocationID: "wh-1", SparePartID: "part-1", Type: MovementAdjustment, Quantity: 5}, "tester", now)
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if entry.OnHandChange != -2 || entry.OnHandAfter != 5 || entry.ReservedAfter != 1 {
+		t.Fatalf("unexpected count entry: %+v", entry)
+	}
+	if err := move(MovementRelease, 2); !errors.Is(err, ErrInvalidMovement) {
+		t.Fatalf("expected releasing more than reserved to fail, got %v", err)
+	}
+}
+
+func TestTransferAndCountMovements(t *testing.T) {
+	movements, err := TransferMovements("tr-1", "wh-1", "van-1", []StockLine{{SparePartID: "part-1", Quantity: 2}}, "")
+	if err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+	if len(movements) != 2 || movements[0].Type != MovementTransferOut || movements[1].LocationID != "van-1" {
+		t.Fatalf("unexpected transfer movements: %+v", movements)
+	}
+	if _, err := TransferMovements("tr-2", "wh-1", "wh-1", []StockLine{{SparePartID: "part-1", Quantity: 2}}, ""); !errors.Is(err, ErrInvalidMovement) {
+		t.Fatalf("expected a transfer to the same location to fail, got %v", err)
+	}
+	if _, err := CountMovements("cc-1", "wh-1", []StockLine{{SparePartID: "part-1"}, {SparePartID: "part-1", Quantity: 3}}, ""); !errors.Is(err, ErrInvalidMovement) {
+		t.Fatalf("expected a part counted twice to fail, got %v", err)
+	}
+}
+
+func TestReservationLifecycle(t *testing.T) {
+	locations := map[string]*Location{
+		"wh-1":  {ID: "wh-1", Type: LocationWarehouse, Active: true},
+		"wh-2":  {ID: "wh-2", Type: LocationWarehouse, Active: true},
+		"van-1": {ID: "van-1", Type: LocationVan, EngineerID: "eng-1", Active: true},
+		"van-2": {ID: "van-2", Type: LocationVan, EngineerID: "eng-2", Active: true},
+	}
+	levels := []StockLevel{
+		{LocationID: "wh-1", OnHand: 3},
+		{LocationID: "wh-2", OnHand: 8, Reserved: 2},
+		{LocationID: "van-1", OnHand: 1},
+		{LocationID: "van-2", OnHand: 20},
+	}
+
+	tests := []struct {
+		name       string
+		engineerID string
+		quantity   int
+		want       string
+		wantErr    error
+	}{
+		{"own van first", "eng-1", 1, "van-1", nil},
+		{"best warehouse when the van is short", "eng-1", 2, "wh-2", nil},
+		{"never another engineer's van", "eng-3", 7, "", ErrInsufficientStock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ChooseReservationLocation(levels, locations, tt.engineerID, tt.quantity)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("expected %s, got %s (%v)", tt.want, got, err)
+			}
+		})
+	}
+
+	reservation, err := NewReservation("ticket-1", "tp-1", "part-1", "wh-1", 2, "tester")
+	if err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	movement, err := reservation.Close(ReservationConsumed, "tester", time.Now())
+	if err != nil || movement.Type != MovementConsume || reservation.Status != ReservationConsumed {
+		t.Fatalf("unexpected consume: %+v (%v)", movement, err)
+	}
+	if _, err := reservation.Close(ReservationReleased, "tester", time.Now()); !errors.Is(err, ErrReservationClosed) {
+		t.Fatalf("expected ErrReservationClosed, got %v", err)
+	}
+}
@@ -0,0 +1,284 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const catalogPartColumns = `id::text, part_number, part_name, COALESCE(is_obsolete, false)`
+
+// CompatibilityRepository implements the domain.CompatibilityRepository interface
+type CompatibilityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCompatibilityRepository creates a new parts compatibility repository
+func NewCompatibilityRepository(pool *pgxpool.Pool) *CompatibilityRepository {
+	return &CompatibilityRepository{pool: pool}
+}
+
+// GetPart retrieves a part of the spare parts catalog
+func (r *CompatibilityRepository) GetPart(ctx context.Context, id string) (*domain.CatalogPart, error) {
+	return r.getPart(ctx, `SELECT `+catalogPartColumns+` FROM spare_parts_catalog WHERE id::text = $1`, id)
+}
+
+// FindPart retrieves a part by part number, ignoring case
+func (r *CompatibilityRepository) FindPart(ctx context.Context, partNumber string) (*domain.CatalogPart, error) {
+	return r.getPart(ctx, `SELECT `+catalogPartColumns+` FROM spare_parts_catalog
+		WHERE UPPER(part_number) = UPPER($1) ORDER BY part_number = $1 DESC LIMIT 1`, partNumber)
+}
+
+func (r *CompatibilityRepository) getPart(ctx context.Context, query string, arg string) (*domain.CatalogPart, error) {
+	var p domain.CatalogPart
+	err := r.pool.QueryRow(ctx, query, arg).Scan(&p.ID, &p.PartNumber, &p.PartName, &p.Obsolete)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrPartNotFound
+		}
+		return nil, fmt.Errorf("failed to get spare part: %w", err)
+	}
+	return &p, nil
+}
+
+// FindModel finds an equipment catalog model by ID, model number or product code
+func (r *CompatibilityRepository) FindModel(ctx context.Context, ref string) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		SELECT id::text FROM equipment_catalog
+		WHERE id::text = $1 OR UPPER(model_number) = UPPER($1) OR UPPER(product_code) = UPPER($1)
+		ORDER BY id::text = $1 DESC, COALESCE(is_active, true) DESC
+		LIMIT 1
+	`, ref).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("%w: unknown catalog model %s", domain.ErrInvalidCompatibility, ref)
+		}
+		return "", fmt.Errorf("failed to find catalog model: %w", err)
+	}
+	return id, nil
+}
+
+// EquipmentModel returns the catalog model and serial number of a registered unit
+func (r *CompatibilityRepository) EquipmentModel(ctx context.Context, equipmentID string) (*domain.EquipmentModel, error) {
+	m := domain.EquipmentModel{EquipmentID: equipmentID}
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(equipment_catalog_id::text, ''), COALESCE(serial_number, '')
+		FROM equipment_registry WHERE id = $1
+	`, equipmentID).Scan(&m.EquipmentCatalogID, &m.SerialNumber)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrEquipmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get equipment model: %w", err)
+	}
+	return &m, nil
+}
+
+// LoadGraph loads the parts reachable through links from the given parts,
+// up to ten links away, with their links and fitments. Spare parts listed
+// for a catalog model count as unrestricted fitments.
+func (r *CompatibilityRepository) LoadGraph(ctx context.Context, partIDs []string) (*domain.PartGraph, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH RECURSIVE related(id, depth) AS (
+			SELECT unnest($1::text[]), 0
+			UNION
+			SELECT CASE WHEN l.part_id = related.id THEN l.related_part_id ELSE l.part_id END, related.depth + 1
+			FROM part_links l
+			JOIN related ON related.id IN (l.part_id, l.related_part_id)
+			WHERE related.depth < 10
+		)
+		SELECT DISTINCT id FROM related
+	`, partIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load related parts: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan related parts: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx, `SELECT `+catalogPartColumns+` FROM spare_parts_catalog WHERE id::text = ANY($1)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load spare parts: %w", err)
+	}
+	parts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.CatalogPart, error) {
+		var p domain.CatalogPart
+		err := row.Scan(&p.ID, &p.PartNumber, &p.PartName, &p.Obsolete)
+		return &p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan spare parts: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx, `
+		SELECT id, part_id, related_part_id, link_type, COALESCE(notes,''), COALESCE(created_by,''), created_at
+		FROM part_links
+		WHERE part_id = ANY($1) OR related_part_id = ANY($1)
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load part links: %w", err)
+	}
+	links, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.PartLink, error) {
+		var l domain.PartLink
+		err := row.Scan(&l.ID, &l.PartID, &l.RelatedPartID, &l.Type, &l.Notes, &l.CreatedBy, &l.CreatedAt)
+		return l, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan part links: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx, `
+		SELECT f.id, f.spare_part_id, f.equipment_catalog_id, COALESCE(ec.model_number,''),
+			COALESCE(f.serial_from,''), COALESCE(f.serial_to,''), COALESCE(f.notes,''), false, f.created_at
+		FROM part_fitments f
+		LEFT JOIN equipment_catalog ec ON ec.id::text = f.equipment_catalog_id
+		WHERE f.spare_part_id = ANY($1)
+		UNION ALL
+		SELECT esp.id::text, esp.spare_part_id::text, esp.equipment_catalog_id::text, COALESCE(ec.model_number,''),
+			'', '', COALESCE(esp.installation_notes,''), true, COALESCE(esp.created_at, NOW())
+		FROM equipment_spare_parts esp
+		LEFT JOIN equipment_catalog ec ON ec.id = esp.equipment_catalog_id
+		WHERE esp.spare_part_id::text = ANY($1)
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load part fitments: %w", err)
+	}
+	fitments, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Fitment, error) {
+		var f domain.Fitment
+		err := row.Scan(&f.ID, &f.SparePartID, &f.EquipmentCatalogID, &f.ModelNumber,
+			&f.SerialFrom, &f.SerialTo, &f.Notes, &f.FromCatalog, &f.CreatedAt)
+		return f, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan part fitments: %w", err)
+	}
+
+	return domain.NewPartGraph(parts, links, fitments), nil
+}
+
+// ModelParts returns up to 200 parts with a fitment to the catalog model
+func (r *CompatibilityRepository) ModelParts(ctx context.Context, equipmentCatalogID, query string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT sp.id::text
+		FROM spare_parts_catalog sp
+		WHERE (
+				EXISTS (SELECT 1 FROM part_fitments f WHERE f.spare_part_id = sp.id::text AND f.equipment_catalog_id = $1)
+				OR EXISTS (SELECT 1 FROM equipment_spare_parts esp WHERE esp.spare_part_id = sp.id AND esp.equipment_catalog_id::text = $1)
+			)
+			AND ($2 = '' OR sp.part_name ILIKE '%' || $2 || '%' OR sp.part_number ILIKE '%' || $2 || '%'
+				OR sp.description ILIKE '%' || $2 || '%')
+		ORDER BY sp.part_name
+		LIMIT 200
+	`, equipmentCatalogID, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list model parts: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan model parts: %w", err)
+	}
+	return ids, nil
+}
+
+// ReplaceFitments replaces the fitments of a part in one transaction
+func (r *CompatibilityRepository) ReplaceFitments(ctx context.Context, sparePartID string, fitments []*domain.Fitment) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM part_fitments WHERE spare_part_id = $1`, sparePartID); err != nil {
+		return fmt.Errorf("failed to clear part fitments: %w", err)
+	}
+	for _, f := range fitments {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO part_fitments (id, spare_part_id, equipment_catalog_id, serial_from, serial_to, notes, created_at)
+			VALUES ($1, $2, $3, NULLIF($4,''), NULLIF($5,''), NULLIF($6,''), $7)
+		`, f.ID, sparePartID, f.EquipmentCatalogID, f.SerialFrom, f.SerialTo, f.Notes, f.CreatedAt); err != nil {
+			return fmt.Errorf("failed to store part fitment: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit part fitments: %w", err)
+	}
+	return nil
+}
+
+// CreateLink stores a link. A supersession marks the superseded part
+// obsolete and points the catalog at its replacement.
+func (r *CompatibilityRepository) CreateLink(ctx context.Context, link *domain.PartLink) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO part_links (id, part_id, related_part_id, link_type, notes, created_by, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5,''), NULLIF($6,''), $7)
+	`, link.ID, link.PartID, link.RelatedPartID, link.Type, link.Notes, link.CreatedBy, link.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%w: the parts are already linked", domain.ErrInvalidCompatibility)
+		}
+		return fmt.Errorf("failed to create part link: %w", err)
+	}
+	if link.Type == domain.LinkSupersedes {
+		if _, err := tx.Exec(ctx, `
+			UPDATE spare_parts_catalog SET is_obsolete = true, replacement_part_id = $2::uuid, updated_at = NOW()
+			WHERE id::text = $1
+		`, link.RelatedPartID, link.PartID); err != nil {
+			return fmt.Errorf("failed to mark superseded part obsolete: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit part link: %w", err)
+	}
+	return nil
+}
+
+// DeleteLink removes a link, reinstating a superseded part that no other
+// part supersedes
+func (r *CompatibilityRepository) DeleteLink(ctx context.Context, id string) (*domain.PartLink, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var l domain.PartLink
+	err = tx.QueryRow(ctx, `
+		DELETE FROM part_links WHERE id = $1
+		RETURNING id, part_id, related_part_id, link_type, COALESCE(notes,''), COALESCE(created_by,''), created_at
+	`, id).Scan(&l.ID, &l.PartID, &l.RelatedPartID, &l.Type, &l.Notes, &l.CreatedBy, &l.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to delete part link: %w", err)
+	}
+	if l.Type == domain.LinkSupersedes {
+		if _, err := tx.Exec(ctx, `
+			UPDATE spare_parts_catalog SET is_obsolete = false, replacement_part_id = NULL, updated_at = NOW()
+			WHERE id::text = $1
+				AND NOT EXISTS (SELECT 1 FROM part_links WHERE related_part_id = $1 AND link_type = 'supersedes')
+		`, l.RelatedPartID); err != nil {
+			return nil, fmt.Errorf("failed to reinstate superseded part: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit part link removal: %w", err)
+	}
+	return &l, nil
+}
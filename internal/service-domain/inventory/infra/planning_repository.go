@@ -0,0 +1,408 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/segmentio/ksuid"
+)
+
+const policyColumns = `
+    p.location_id, p.spare_part_id::text, COALESCE(sp.part_number,''), COALESCE(sp.part_name,''),
+    p.min_qty, p.reorder_point, p.max_qty, p.avg_daily_demand::float8, p.demand_std_dev::float8,
+    p.lead_time_days, p.manual, COALESCE(p.updated_by,''), p.updated_at`
+
+const suggestionColumns = `
+    s.id, s.location_id, s.spare_part_id::text, COALESCE(sp.part_number,''), COALESCE(sp.part_name,''),
+    s.kind, COALESCE(s.source_location_id,''), s.quantity, s.available, s.reorder_point, s.max_qty,
+    s.critical, s.status, COALESCE(s.approved_quantity,0), COALESCE(s.reference_id,''),
+    COALESCE(s.reviewed_by,''), s.reviewed_at, COALESCE(s.review_notes,''), s.created_at, s.updated_at`
+
+const alertColumns = `
+    a.id, a.location_id, a.spare_part_id::text, COALESCE(sp.part_number,''), COALESCE(sp.part_name,''),
+    a.severity, a.available, a.days_of_cover::float8, a.lead_time_days, a.critical_units, a.message,
+    a.status, COALESCE(a.acknowledged_by,''), a.acknowledged_at, a.resolved_at, a.created_at, a.updated_at`
+
+// PlanningRepository implements the domain.PlanningRepository interface
+type PlanningRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPlanningRepository creates a new replenishment planning repository
+func NewPlanningRepository(pool *pgxpool.Pool) *PlanningRepository {
+	return &PlanningRepository{pool: pool}
+}
+
+// ConsumptionHistory returns daily demand per location and part. Ticket
+// parts_used lines are matched to the catalog by part number.
+func (r *PlanningRepository) ConsumptionHistory(ctx context.Context, since time.Time) ([]domain.DemandPoint, error) {
+	query := `
+		SELECT l.location_id, l.spare_part_id::text, date_trunc('day', l.created_at) AS day, SUM(-l.on_hand_change)::int
+		FROM stock_ledger l
+		WHERE l.movement_type IN ('consume', 'transfer_out') AND l.created_at >= $1
+		GROUP BY 1, 2, 3
+		UNION ALL
+		SELECT v.id, sp.id::text, date_trunc('day', t.resolved_at) AS day,
+			SUM(COALESCE(NULLIF(pu->>'quantity','')::numeric, 1))::int
+		FROM service_tickets t
+		CROSS JOIN LATERAL jsonb_array_elements(
+			CASE WHEN jsonb_typeof(t.parts_used) = 'array' THEN t.parts_used ELSE '[]'::jsonb END) pu
+		JOIN spare_parts_catalog sp ON sp.part_number = pu->>'part_number'
+		JOIN stock_locations v ON v.location_type = 'van' AND v.active AND v.engineer_id = t.assigned_engineer_id
+		WHERE t.resolved_at >= $1
+			AND NOT EXISTS (
+				SELECT 1 FROM stock_ledger c
+				WHERE c.reference_id = t.id AND c.movement_type = 'consume'
+			)
+		GROUP BY 1, 2, 3`
+	rows, err := r.pool.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load consumption history: %w", err)
+	}
+	defer rows.Close()
+
+	points := []domain.DemandPoint{}
+	for rows.Next() {
+		var p domain.DemandPoint
+		if err := rows.Scan(&p.LocationID, &p.SparePartID, &p.Day, &p.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan consumption: %w", err)
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// PartPlanningInfo returns catalog lead times and minimum order quantities,
+// and counts the installed units that are not decommissioned for which each
+// part is critical
+func (r *PlanningRepository) PartPlanningInfo(ctx context.Context) (map[string]domain.PartPlanningInfo, error) {
+	query := `
+		SELECT sp.id::text, COALESCE(sp.lead_time_days, 0), COALESCE(sp.minimum_order_quantity, 0),
+			COALESCE(crit.units, 0)
+		FROM spare_parts_catalog sp
+		LEFT JOIN (
+			SELECT esp.spare_part_id, COUNT(DISTINCT er.id)::int AS units
+			FROM equipment_spare_parts esp
+			JOIN equipment_registry er ON er.equipment_catalog_id = esp.equipment_catalog_id
+			WHERE esp.is_critical AND er.status <> 'decommissioned'
+			GROUP BY esp.spare_part_id
+		) crit ON crit.spare_part_id = sp.id`
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load part planning info: %w", err)
+	}
+	defer rows.Close()
+
+	info := map[string]domain.PartPlanningInfo{}
+	for rows.Next() {
+		var id string
+		var i domain.PartPlanningInfo
+		if err := rows.Scan(&id, &i.LeadTimeDays, &i.MinOrderQty, &i.CriticalUnits); err != nil {
+			return nil, fmt.Errorf("failed to scan part planning info: %w", err)
+		}
+		info[id] = i
+	}
+	return info, rows.Err()
+}
+
+// GetPolicy retrieves the policy of a part at a location
+func (r *PlanningRepository) GetPolicy(ctx context.Context, locationID, sparePartID string) (*domain.StockPolicy, error) {
+	query := `SELECT ` + policyColumns + `
+		FROM stock_policies p
+		LEFT JOIN spare_parts_catalog sp ON sp.id = p.spare_part_id
+		WHERE p.location_id = $1 AND p.spare_part_id = $2`
+	policy, err := scanPolicy(r.pool.QueryRow(ctx, query, locationID, sparePartID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrPolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to get stock policy: %w", err)
+	}
+	return policy, nil
+}
+
+// ListPolicies lists policies by location and part number
+func (r *PlanningRepository) ListPolicies(ctx context.Context, locationID string) ([]*domain.StockPolicy, error) {
+	query := `SELECT ` + policyColumns + `
+		FROM stock_policies p
+		LEFT JOIN spare_parts_catalog sp ON sp.id = p.spare_part_id
+		WHERE ($1 = '' OR p.location_id = $1)
+		ORDER BY p.location_id, sp.part_number`
+	rows, err := r.pool.Query(ctx, query, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []*domain.StockPolicy{}
+	for rows.Next() {
+		policy, err := scanPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, rows.Err()
+}
+
+// SavePolicies upserts the policies in one batch
+func (r *PlanningRepository) SavePolicies(ctx context.Context, policies []*domain.StockPolicy) error {
+	batch := &pgx.Batch{}
+	for _, p := range policies {
+		batch.Queue(`
+			INSERT INTO stock_policies (
+				location_id, spare_part_id, min_qty, reorder_point, max_qty, avg_daily_demand,
+				demand_std_dev, lead_time_days, manual, updated_by, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10,''), $11)
+			ON CONFLICT (location_id, spare_part_id) DO UPDATE SET
+				min_qty = EXCLUDED.min_qty, reorder_point = EXCLUDED.reorder_point, max_qty = EXCLUDED.max_qty,
+				avg_daily_demand = EXCLUDED.avg_daily_demand, demand_std_dev = EXCLUDED.demand_std_dev,
+				lead_time_days = EXCLUDED.lead_time_days, manual = EXCLUDED.manual,
+				updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+		`, p.LocationID, p.SparePartID, p.MinQty, p.ReorderPoint, p.MaxQty, p.AvgDailyDemand,
+			p.DemandStdDev, p.LeadTimeDays, p.Manual, p.UpdatedBy, p.UpdatedAt)
+	}
+	if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to save stock policies: %w", err)
+	}
+	return nil
+}
+
+// SaveSuggestion inserts a pending suggestion or refreshes the pending one
+// of the same location and part, keeping its ID
+func (r *PlanningRepository) SaveSuggestion(ctx context.Context, s *domain.ReplenishmentSuggestion) error {
+	if s.ID == "" {
+		s.ID = ksuid.New().String()
+	}
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO replenishment_suggestions (
+			id, location_id, spare_part_id, kind, source_location_id, quantity, available,
+			reorder_point, max_qty, critical, status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, NULLIF($5,''), $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (location_id, spare_part_id) WHERE status = 'pending' DO UPDATE SET
+			kind = EXCLUDED.kind, source_location_id = EXCLUDED.source_location_id,
+			quantity = EXCLUDED.quantity, available = EXCLUDED.available,
+			reorder_point = EXCLUDED.reorder_point, max_qty = EXCLUDED.max_qty,
+			critical = EXCLUDED.critical, updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`, s.ID, s.LocationID, s.SparePartID, s.Kind, s.SourceLocationID, s.Quantity, s.Available,
+		s.ReorderPoint, s.MaxQty, s.Critical, s.Status, s.CreatedAt, s.UpdatedAt).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save replenishment suggestion: %w", err)
+	}
+	return nil
+}
+
+// GetSuggestion retrieves a suggestion
+func (r *PlanningRepository) GetSuggestion(ctx context.Context, id string) (*domain.ReplenishmentSuggestion, error) {
+	query := `SELECT ` + suggestionColumns + `
+		FROM replenishment_suggestions s
+		LEFT JOIN spare_parts_catalog sp ON sp.id = s.spare_part_id
+		WHERE s.id = $1`
+	suggestion, err := scanSuggestion(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrSuggestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get replenishment suggestion: %w", err)
+	}
+	return suggestion, nil
+}
+
+// ListSuggestions lists suggestions, critical ones first, then newest first
+func (r *PlanningRepository) ListSuggestions(ctx context.Context, filter domain.SuggestionFilter) ([]*domain.ReplenishmentSuggestion, error) {
+	where := []string{"1=1"}
+	args := []any{}
+	if filter.LocationID != "" {
+		args = append(args, filter.LocationID)
+		where = append(where, fmt.Sprintf("s.location_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where = append(where, fmt.Sprintf("s.status = $%d", len(args)))
+	}
+	if !filter.ReviewedSince.IsZero() {
+		args = append(args, filter.ReviewedSince)
+		where = append(where, fmt.Sprintf("s.reviewed_at >= $%d", len(args)))
+	}
+
+	query := `SELECT ` + suggestionColumns + `
+		FROM replenishment_suggestions s
+		LEFT JOIN spare_parts_catalog sp ON sp.id = s.spare_part_id
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY s.critical DESC, s.created_at DESC
+		LIMIT 500`
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replenishment suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []*domain.ReplenishmentSuggestion{}
+	for rows.Next() {
+		suggestion, err := scanSuggestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan replenishment suggestion: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, rows.Err()
+}
+
+// UpdateSuggestionReview stores the review if the suggestion is still pending
+func (r *PlanningRepository) UpdateSuggestionReview(ctx context.Context, s *domain.ReplenishmentSuggestion) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE replenishment_suggestions SET
+			status = $2, approved_quantity = NULLIF($3,0), reference_id = NULLIF($4,''),
+			reviewed_by = $5, reviewed_at = $6, review_notes = NULLIF($7,''), updated_at = $8
+		WHERE id = $1 AND status = 'pending'
+	`, s.ID, s.Status, s.ApprovedQuantity, s.ReferenceID, s.ReviewedBy, s.ReviewedAt, s.ReviewNotes, s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update replenishment suggestion: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		if _, err := r.GetSuggestion(ctx, s.ID); err != nil {
+			return err
+		}
+		return domain.ErrSuggestionReviewed
+	}
+	return nil
+}
+
+// SaveAlert inserts an open alert or refreshes the unresolved one of the
+// same location and part. An acknowledged alert reopens when the part runs
+// out of stock.
+func (r *PlanningRepository) SaveAlert(ctx context.Context, a *domain.StockAlert) error {
+	if a.ID == "" {
+		a.ID = ksuid.New().String()
+	}
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO stock_alerts (
+			id, location_id, spare_part_id, severity, available, days_of_cover, lead_time_days,
+			critical_units, message, status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (location_id, spare_part_id) WHERE status <> 'resolved' DO UPDATE SET
+			status = CASE WHEN EXCLUDED.severity = 'stockout' AND stock_alerts.severity <> 'stockout'
+				THEN 'open' ELSE stock_alerts.status END,
+			severity = EXCLUDED.severity, available = EXCLUDED.available,
+			days_of_cover = EXCLUDED.days_of_cover, lead_time_days = EXCLUDED.lead_time_days,
+			critical_units = EXCLUDED.critical_units, message = EXCLUDED.message,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, status, created_at
+	`, a.ID, a.LocationID, a.SparePartID, a.Severity, a.Available, a.DaysOfCover, a.LeadTimeDays,
+		a.CriticalUnits, a.Message, a.Status, a.CreatedAt, a.UpdatedAt).Scan(&a.ID, &a.Status, &a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save stock alert: %w", err)
+	}
+	return nil
+}
+
+// ListAlerts lists alerts, stock-outs first, then by days of cover
+func (r *PlanningRepository) ListAlerts(ctx context.Context, filter domain.AlertFilter) ([]*domain.StockAlert, error) {
+	where := []string{"1=1"}
+	args := []any{}
+	if filter.LocationID != "" {
+		args = append(args, filter.LocationID)
+		where = append(where, fmt.Sprintf("a.location_id = $%d", len(args)))
+	}
+	if len(filter.Status) > 0 {
+		status := make([]string, len(filter.Status))
+		for i, s := range filter.Status {
+			status[i] = string(s)
+		}
+		args = append(args, status)
+		where = append(where, fmt.Sprintf("a.status = ANY($%d)", len(args)))
+	}
+
+	query := `SELECT ` + alertColumns + `
+		FROM stock_alerts a
+		LEFT JOIN spare_parts_catalog sp ON sp.id = a.spare_part_id
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY (a.severity = 'stockout') DESC, a.days_of_cover, a.critical_units DESC
+		LIMIT 500`
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []*domain.StockAlert{}
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+// AcknowledgeAlert marks an open alert as seen
+func (r *PlanningRepository) AcknowledgeAlert(ctx context.Context, id, by string) (*domain.StockAlert, error) {
+	if _, err := r.pool.Exec(ctx, `
+		UPDATE stock_alerts SET status = 'acknowledged', acknowledged_by = $2, acknowledged_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'open'
+	`, id, by); err != nil {
+		return nil, fmt.Errorf("failed to acknowledge stock alert: %w", err)
+	}
+
+	query := `SELECT ` + alertColumns + `
+		FROM stock_alerts a
+		LEFT JOIN spare_parts_catalog sp ON sp.id = a.spare_part_id
+		WHERE a.id = $1`
+	alert, err := scanAlert(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrAlertNotFound
+		}
+		return nil, fmt.Errorf("failed to get stock alert: %w", err)
+	}
+	return alert, nil
+}
+
+// ResolveAlerts resolves the unresolved alerts of a part at a location
+func (r *PlanningRepository) ResolveAlerts(ctx context.Context, locationID, sparePartID string) error {
+	if _, err := r.pool.Exec(ctx, `
+		UPDATE stock_alerts SET status = 'resolved', resolved_at = NOW(), updated_at = NOW()
+		WHERE location_id = $1 AND spare_part_id = $2 AND status <> 'resolved'
+	`, locationID, sparePartID); err != nil {
+		return fmt.Errorf("failed to resolve stock alerts: %w", err)
+	}
+	return nil
+}
+
+func scanPolicy(row pgx.Row) (*domain.StockPolicy, error) {
+	var p domain.StockPolicy
+	if err := row.Scan(&p.LocationID, &p.SparePartID, &p.PartNumber, &p.PartName,
+		&p.MinQty, &p.ReorderPoint, &p.MaxQty, &p.AvgDailyDemand, &p.DemandStdDev,
+		&p.LeadTimeDays, &p.Manual, &p.UpdatedBy, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func scanSuggestion(row pgx.Row) (*domain.ReplenishmentSuggestion, error) {
+	var s domain.ReplenishmentSuggestion
+	if err := row.Scan(&s.ID, &s.LocationID, &s.SparePartID, &s.PartNumber, &s.PartName,
+		&s.Kind, &s.SourceLocationID, &s.Quantity, &s.Available, &s.ReorderPoint, &s.MaxQty,
+		&s.Critical, &s.Status, &s.ApprovedQuantity, &s.ReferenceID,
+		&s.ReviewedBy, &s.ReviewedAt, &s.ReviewNotes, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func scanAlert(row pgx.Row) (*domain.StockAlert, error) {
+	var a domain.StockAlert
+	if err := row.Scan(&a.ID, &a.LocationID, &a.SparePartID, &a.PartNumber, &a.PartName,
+		&a.Severity, &a.Available, &a.DaysOfCover, &a.LeadTimeDays, &a.CriticalUnits, &a.Message,
+		&a.Status, &a.AcknowledgedBy, &a.AcknowledgedAt, &a.ResolvedAt, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,396 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const locationColumns = `
+    id, code, name, location_type, COALESCE(engineer_id,''), COALESCE(address,''),
+    active, created_at, updated_at`
+
+const reservationColumns = `
+    id, ticket_id, COALESCE(ticket_part_id,''), spare_part_id::text, location_id, quantity,
+    status, COALESCE(created_by,''), created_at, COALESCE(closed_by,''), closed_at`
+
+// StockRepository implements the domain.Repository interface
+type StockRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewStockRepository creates a new stock repository
+func NewStockRepository(pool *pgxpool.Pool) *StockRepository {
+	return &StockRepository{pool: pool}
+}
+
+// CreateLocation stores a location
+func (r *StockRepository) CreateLocation(ctx context.Context, location *domain.Location) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO stock_locations (
+			id, code, name, location_type, engineer_id, address, active, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, NULLIF($5,''), NULLIF($6,''), $7, $8, $9)
+	`, location.ID, location.Code, location.Name, location.Type, location.EngineerID, location.Address,
+		location.Active, location.CreatedAt, location.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrLocationExists
+		}
+		return fmt.Errorf("failed to create stock location: %w", err)
+	}
+	return nil
+}
+
+// GetLocation retrieves a location by ID
+func (r *StockRepository) GetLocation(ctx context.Context, id string) (*domain.Location, error) {
+	query := `SELECT ` + locationColumns + ` FROM stock_locations WHERE id = $1`
+	location, err := scanLocation(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrLocationNotFound
+		}
+		return nil, fmt.Errorf("failed to get stock location: %w", err)
+	}
+	return location, nil
+}
+
+// ListLocations lists locations by code
+func (r *StockRepository) ListLocations(ctx context.Context, filter domain.LocationFilter) ([]*domain.Location, error) {
+	where := []string{"1=1"}
+	args := []any{}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		where = append(where, fmt.Sprintf("location_type = $%d", len(args)))
+	}
+	if filter.EngineerID != "" {
+		args = append(args, filter.EngineerID)
+		where = append(where, fmt.Sprintf("engineer_id = $%d", len(args)))
+	}
+	if !filter.IncludeInactive {
+		where = append(where, "active = true")
+	}
+
+	query := `SELECT ` + locationColumns + ` FROM stock_locations WHERE ` + strings.Join(where, " AND ") + ` ORDER BY code`
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := []*domain.Location{}
+	for rows.Next() {
+		location, err := scanLocation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock location: %w", err)
+		}
+		locations = append(locations, location)
+	}
+	return locations, rows.Err()
+}
+
+// ListStock lists stock levels with part numbers and names from the spare parts catalog
+func (r *StockRepository) ListStock(ctx context.Context, filter domain.StockFilter) ([]domain.StockLevel, error) {
+	where := []string{"1=1"}
+	args := []any{}
+	if filter.LocationID != "" {
+		args = append(args, filter.LocationID)
+		where = append(where, fmt.Sprintf("ps.location_id = $%d", len(args)))
+	}
+	if filter.SparePartID != "" {
+		args = append(args, filter.SparePartID)
+		where = append(where, fmt.Sprintf("ps.spare_part_id = $%d", len(args)))
+	}
+
+	query := `
+		SELECT ps.location_id, ps.spare_part_id::text, COALESCE(sp.part_number,''), COALESCE(sp.part_name,''),
+			ps.on_hand, ps.reserved, ps.updated_at
+		FROM part_stock ps
+		LEFT JOIN spare_parts_catalog sp ON sp.id = ps.spare_part_id
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY ps.location_id, sp.part_number`
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock: %w", err)
+	}
+	defer rows.Close()
+
+	levels := []domain.StockLevel{}
+	for rows.Next() {
+		var level domain.StockLevel
+		if err := rows.Scan(&level.LocationID, &level.SparePartID, &level.PartNumber, &level.PartName,
+			&level.OnHand, &level.Reserved, &level.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan stock level: %w", err)
+		}
+		levels = append(levels, level)
+	}
+	return levels, rows.Err()
+}
+
+// ApplyMovements applies the movements in one transaction
+func (r *StockRepository) ApplyMovements(ctx context.Context, movements []domain.Movement, createdBy string) ([]*domain.LedgerEntry, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	entries, err := applyMovements(ctx, tx, movements, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit stock movements: %w", err)
+	}
+	return entries, nil
+}
+
+// CreateReservation reserves the stock and stores the reservation in one transaction
+func (r *StockRepository) CreateReservation(ctx context.Context, reservation *domain.Reservation) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := applyMovements(ctx, tx, []domain.Movement{reservation.Movement()}, reservation.CreatedBy); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO stock_reservations (
+			id, ticket_id, ticket_part_id, spare_part_id, location_id, quantity, status, created_by, created_at
+		) VALUES ($1, $2, NULLIF($3,''), $4, $5, $6, $7, $8, $9)
+	`, reservation.ID, reservation.TicketID, reservation.TicketPartID, reservation.SparePartID, reservation.LocationID,
+		reservation.Quantity, reservation.Status, reservation.CreatedBy, reservation.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create stock reservation: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit stock reservation: %w", err)
+	}
+	return nil
+}
+
+// CloseReservation consumes or releases an active reservation in one transaction
+func (r *StockRepository) CloseReservation(ctx context.Context, id string, status domain.ReservationStatus, closedBy string) (*domain.Reservation, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `SELECT ` + reservationColumns + ` FROM stock_reservations WHERE id = $1 FOR UPDATE`
+	reservation, err := scanReservation(tx.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("failed to get stock reservation: %w", err)
+	}
+
+	movement, err := reservation.Close(status, closedBy, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := applyMovements(ctx, tx, []domain.Movement{movement}, closedBy); err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE stock_reservations SET status = $2, closed_by = $3, closed_at = $4 WHERE id = $1
+	`, reservation.ID, reservation.Status, reservation.ClosedBy, reservation.ClosedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close stock reservation: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit stock reservation: %w", err)
+	}
+	return reservation, nil
+}
+
+// ListReservations lists reservations, oldest first
+func (r *StockRepository) ListReservations(ctx context.Context, filter domain.ReservationFilter) ([]*domain.Reservation, error) {
+	where := []string{"1=1"}
+	args := []any{}
+	if filter.TicketID != "" {
+		args = append(args, filter.TicketID)
+		where = append(where, fmt.Sprintf("ticket_id = $%d", len(args)))
+	}
+	if filter.TicketPartID != "" {
+		args = append(args, filter.TicketPartID)
+		where = append(where, fmt.Sprintf("ticket_part_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `SELECT ` + reservationColumns + ` FROM stock_reservations WHERE ` + strings.Join(where, " AND ") + ` ORDER BY created_at`
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock reservations: %w", err)
+	}
+	defer rows.Close()
+
+	reservations := []*domain.Reservation{}
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock reservation: %w", err)
+		}
+		reservations = append(reservations, reservation)
+	}
+	return reservations, rows.Err()
+}
+
+// ListLedger lists ledger entries, newest first
+func (r *StockRepository) ListLedger(ctx context.Context, filter domain.LedgerFilter) ([]*domain.LedgerEntry, error) {
+	where := []string{"1=1"}
+	args := []any{}
+	if filter.LocationID != "" {
+		args = append(args, filter.LocationID)
+		where = append(where, fmt.Sprintf("location_id = $%d", len(args)))
+	}
+	if filter.SparePartID != "" {
+		args = append(args, filter.SparePartID)
+		where = append(where, fmt.Sprintf("spare_part_id = $%d", len(args)))
+	}
+	if filter.ReferenceID != "" {
+		args = append(args, filter.ReferenceID)
+		where = append(where, fmt.Sprintf("reference_id = $%d", len(args)))
+	}
+	limit := filter.Limit
+	if limit <= 0 || limit > 1000 {
+		limit = 200
+	}
+	args = append(args, limit)
+
+	query := `
+		SELECT id, location_id, spare_part_id::text, movement_type, on_hand_change, reserved_change,
+			on_hand_after, reserved_after, reference_type, reference_id, COALESCE(reason,''),
+			COALESCE(created_by,''), created_at
+		FROM stock_ledger
+		WHERE ` + strings.Join(where, " AND ") + fmt.Sprintf(`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d`, len(args))
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock ledger: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.LedgerEntry{}
+	for rows.Next() {
+		var e domain.LedgerEntry
+		if err := rows.Scan(&e.ID, &e.LocationID, &e.SparePartID, &e.Type, &e.OnHandChange, &e.ReservedChange,
+			&e.OnHandAfter, &e.ReservedAfter, &e.ReferenceType, &e.ReferenceID, &e.Reason,
+			&e.CreatedBy, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan stock ledger entry: %w", err)
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// stockKey identifies the stock of a part at a location
+type stockKey struct {
+	locationID  string
+	sparePartID string
+}
+
+// applyMovements locks the affected stock levels in key order, so concurrent
+// movements cannot deadlock, applies the movements and writes the ledger
+func applyMovements(ctx context.Context, tx pgx.Tx, movements []domain.Movement, createdBy string) ([]*domain.LedgerEntry, error) {
+	keys := []stockKey{}
+	levels := map[stockKey]*domain.StockLevel{}
+	for _, m := range movements {
+		key := stockKey{m.LocationID, m.SparePartID}
+		if _, ok := levels[key]; !ok {
+			levels[key] = nil
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].locationID != keys[j].locationID {
+			return keys[i].locationID < keys[j].locationID
+		}
+		return keys[i].sparePartID < keys[j].sparePartID
+	})
+
+	for _, key := range keys {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO part_stock (location_id, spare_part_id) VALUES ($1, $2)
+			ON CONFLICT (location_id, spare_part_id) DO NOTHING
+		`, key.locationID, key.sparePartID); err != nil {
+			return nil, fmt.Errorf("failed to create stock level: %w", err)
+		}
+		level := &domain.StockLevel{LocationID: key.locationID, SparePartID: key.sparePartID}
+		if err := tx.QueryRow(ctx, `
+			SELECT on_hand, reserved, updated_at FROM part_stock
+			WHERE location_id = $1 AND spare_part_id = $2
+			FOR UPDATE
+		`, key.locationID, key.sparePartID).Scan(&level.OnHand, &level.Reserved, &level.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to lock stock level: %w", err)
+		}
+		levels[key] = level
+	}
+
+	now := time.Now()
+	entries := make([]*domain.LedgerEntry, 0, len(movements))
+	for _, m := range movements {
+		entry, err := levels[stockKey{m.LocationID, m.SparePartID}].Apply(m, createdBy, now)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, key := range keys {
+		level := levels[key]
+		if _, err := tx.Exec(ctx, `
+			UPDATE part_stock SET on_hand = $3, reserved = $4, updated_at = $5
+			WHERE location_id = $1 AND spare_part_id = $2
+		`, level.LocationID, level.SparePartID, level.OnHand, level.Reserved, level.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to update stock level: %w", err)
+		}
+	}
+	for _, e := range entries {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO stock_ledger (
+				id, location_id, spare_part_id, movement_type, on_hand_change, reserved_change,
+				on_hand_after, reserved_after, reference_type, reference_id, reason, created_by, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11,''), $12, $13)
+		`, e.ID, e.LocationID, e.SparePartID, e.Type, e.OnHandChange, e.ReservedChange,
+			e.OnHandAfter, e.ReservedAfter, e.ReferenceType, e.ReferenceID, e.Reason, e.CreatedBy, e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to write stock ledger: %w", err)
+		}
+	}
+	return entries, nil
+}
+
+func scanLocation(row pgx.Row) (*domain.Location, error) {
+	var l domain.Location
+	if err := row.Scan(&l.ID, &l.Code, &l.Name, &l.Type, &l.EngineerID, &l.Address,
+		&l.Active, &l.CreatedAt, &l.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func scanReservation(row pgx.Row) (*domain.Reservation, error) {
+	var r domain.Reservation
+	if err := row.Scan(&r.ID, &r.TicketID, &r.TicketPartID, &r.SparePartID, &r.LocationID, &r.Quantity,
+		&r.Status, &r.CreatedBy, &r.CreatedAt, &r.ClosedBy, &r.ClosedAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,164 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PgxIface is a minimal interface implemented by *pgxpool.Pool
+type PgxIface interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// EnsureInventorySchema creates the stock location, stock level, reservation,
+// ledger, replenishment planning and part compatibility tables if they don't exist. It is idempotent and safe to run on startup.
+func EnsureInventorySchema(ctx context.Context, pool PgxIface) error {
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS stock_locations (
+            id VARCHAR(255) PRIMARY KEY,
+            code VARCHAR(50) NOT NULL UNIQUE,
+            name VARCHAR(255) NOT NULL,
+            location_type VARCHAR(20) NOT NULL CHECK (location_type IN ('warehouse', 'van')),
+            engineer_id VARCHAR(255),
+            address TEXT,
+            active BOOLEAN NOT NULL DEFAULT true,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )`,
+		"CREATE INDEX IF NOT EXISTS idx_stock_locations_engineer ON stock_locations(engineer_id) WHERE engineer_id IS NOT NULL",
+		`CREATE TABLE IF NOT EXISTS part_stock (
+            location_id VARCHAR(255) NOT NULL REFERENCES stock_locations(id),
+            spare_part_id UUID NOT NULL,
+            on_hand INTEGER NOT NULL DEFAULT 0,
+            reserved INTEGER NOT NULL DEFAULT 0,
+            updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            PRIMARY KEY (location_id, spare_part_id),
+            CHECK (reserved >= 0 AND on_hand >= reserved)
+        )`,
+		"CREATE INDEX IF NOT EXISTS idx_part_stock_part ON part_stock(spare_part_id)",
+		`CREATE TABLE IF NOT EXISTS stock_reservations (
+            id VARCHAR(255) PRIMARY KEY,
+            ticket_id VARCHAR(255) NOT NULL,
+            ticket_part_id VARCHAR(255),
+            spare_part_id UUID NOT NULL,
+            location_id VARCHAR(255) NOT NULL REFERENCES stock_locations(id),
+            quantity INTEGER NOT NULL CHECK (quantity > 0),
+            status VARCHAR(20) NOT NULL CHECK (status IN ('active', 'consumed', 'released')),
+            created_by VARCHAR(255),
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            closed_by VARCHAR(255),
+            closed_at TIMESTAMP WITH TIME ZONE
+        )`,
+		"CREATE INDEX IF NOT EXISTS idx_stock_reservations_ticket ON stock_reservations(ticket_id, status)",
+		"CREATE INDEX IF NOT EXISTS idx_stock_reservations_ticket_part ON stock_reservations(ticket_part_id) WHERE ticket_part_id IS NOT NULL",
+		`CREATE TABLE IF NOT EXISTS stock_ledger (
+            id VARCHAR(255) PRIMARY KEY,
+            location_id VARCHAR(255) NOT NULL REFERENCES stock_locations(id),
+            spare_part_id UUID NOT NULL,
+            movement_type VARCHAR(20) NOT NULL,
+            on_hand_change INTEGER NOT NULL,
+            reserved_change INTEGER NOT NULL,
+            on_hand_after INTEGER NOT NULL,
+            reserved_after INTEGER NOT NULL,
+            reference_type VARCHAR(20) NOT NULL,
+            reference_id VARCHAR(255) NOT NULL,
+            reason TEXT,
+            created_by VARCHAR(255),
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )`,
+		"CREATE INDEX IF NOT EXISTS idx_stock_ledger_location_part ON stock_ledger(location_id, spare_part_id, created_at DESC)",
+		"CREATE INDEX IF NOT EXISTS idx_stock_ledger_reference ON stock_ledger(reference_id)",
+		`CREATE TABLE IF NOT EXISTS stock_policies (
+            location_id VARCHAR(255) NOT NULL REFERENCES stock_locations(id),
+            spare_part_id UUID NOT NULL,
+            min_qty INTEGER NOT NULL DEFAULT 0,
+            reorder_point INTEGER NOT NULL DEFAULT 0,
+            max_qty INTEGER NOT NULL DEFAULT 0,
+            avg_daily_demand NUMERIC(12,3) NOT NULL DEFAULT 0,
+            demand_std_dev NUMERIC(12,3) NOT NULL DEFAULT 0,
+            lead_time_days INTEGER NOT NULL DEFAULT 0,
+            manual BOOLEAN NOT NULL DEFAULT false,
+            updated_by VARCHAR(255),
+            updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            PRIMARY KEY (location_id, spare_part_id)
+        )`,
+		`CREATE TABLE IF NOT EXISTS replenishment_suggestions (
+            id VARCHAR(255) PRIMARY KEY,
+            location_id VARCHAR(255) NOT NULL REFERENCES stock_locations(id),
+            spare_part_id UUID NOT NULL,
+            kind VARCHAR(20) NOT NULL CHECK (kind IN ('purchase', 'transfer')),
+            source_location_id VARCHAR(255),
+            quantity INTEGER NOT NULL CHECK (quantity > 0),
+            available INTEGER NOT NULL,
+            reorder_point INTEGER NOT NULL,
+            max_qty INTEGER NOT NULL,
+            critical BOOLEAN NOT NULL DEFAULT false,
+            status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+            approved_quantity INTEGER,
+            reference_id VARCHAR(255),
+            reviewed_by VARCHAR(255),
+            reviewed_at TIMESTAMP WITH TIME ZONE,
+            review_notes TEXT,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )`,
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_replenishment_suggestions_pending ON replenishment_suggestions(location_id, spare_part_id) WHERE status = 'pending'",
+		"CREATE INDEX IF NOT EXISTS idx_replenishment_suggestions_status ON replenishment_suggestions(status, created_at DESC)",
+		`CREATE TABLE IF NOT EXISTS stock_alerts (
+            id VARCHAR(255) PRIMARY KEY,
+            location_id VARCHAR(255) NOT NULL REFERENCES stock_locations(id),
+            spare_part_id UUID NOT NULL,
+            severity VARCHAR(20) NOT NULL CHECK (severity IN ('stockout', 'high')),
+            available INTEGER NOT NULL,
+            days_of_cover NUMERIC(10,1) NOT NULL,
+            lead_time_days INTEGER NOT NULL,
+            critical_units INTEGER NOT NULL DEFAULT 0,
+            message TEXT NOT NULL,
+            status VARCHAR(20) NOT NULL CHECK (status IN ('open', 'acknowledged', 'resolved')),
+            acknowledged_by VARCHAR(255),
+            acknowledged_at TIMESTAMP WITH TIME ZONE,
+            resolved_at TIMESTAMP WITH TIME ZONE,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )`,
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_alerts_unresolved ON stock_alerts(location_id, spare_part_id) WHERE status <> 'resolved'",
+		`CREATE TABLE IF NOT EXISTS part_fitments (
+            id VARCHAR(255) PRIMARY KEY,
+            spare_part_id VARCHAR(255) NOT NULL,
+            equipment_catalog_id VARCHAR(255) NOT NULL,
+            serial_from VARCHAR(255),
+            serial_to VARCHAR(255),
+            notes TEXT,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+        )`,
+		"CREATE INDEX IF NOT EXISTS idx_part_fitments_part ON part_fitments(spare_part_id)",
+		"CREATE INDEX IF NOT EXISTS idx_part_fitments_model ON part_fitments(equipment_catalog_id)",
+		`CREATE TABLE IF NOT EXISTS part_links (
+            id VARCHAR(255) PRIMARY KEY,
+            part_id VARCHAR(255) NOT NULL,
+            related_part_id VARCHAR(255) NOT NULL,
+            link_type VARCHAR(20) NOT NULL CHECK (link_type IN ('supersedes', 'alternate')),
+            notes TEXT,
+            created_by VARCHAR(255),
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            UNIQUE (part_id, related_part_id, link_type),
+            CHECK (part_id <> related_part_id)
+        )`,
+		"CREATE INDEX IF NOT EXISTS idx_part_links_related ON part_links(related_part_id)",
+		// Stock tables created before spare_part_id matched the catalog's id type
+		"ALTER TABLE part_stock ALTER COLUMN spare_part_id TYPE UUID USING spare_part_id::uuid",
+		"ALTER TABLE stock_reservations ALTER COLUMN spare_part_id TYPE UUID USING spare_part_id::uuid",
+		"ALTER TABLE stock_ledger ALTER COLUMN spare_part_id TYPE UUID USING spare_part_id::uuid",
+		"ALTER TABLE stock_policies ALTER COLUMN spare_part_id TYPE UUID USING spare_part_id::uuid",
+		"ALTER TABLE replenishment_suggestions ALTER COLUMN spare_part_id TYPE UUID USING spare_part_id::uuid",
+		"ALTER TABLE stock_alerts ALTER COLUMN spare_part_id TYPE UUID USING spare_part_id::uuid",
+	}
+
+	for _, stmt := range stmts {
+		if _, err := pool.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
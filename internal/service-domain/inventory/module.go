@@ -0,0 +1,136 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/api"
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/inventory/infra"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Module represents the spare parts inventory module
+type Module struct {
+	config  ModuleConfig
+	pool    *pgxpool.Pool
+	handler *api.StockHandler
+	planner *app.ReplenishmentPlanner
+	plans   *api.PlanningHandler
+	fits    *api.CompatibilityHandler
+	logger  *slog.Logger
+}
+
+// ModuleConfig holds module configuration
+type ModuleConfig struct {
+	DBHost     string
+	DBPort     int
+	DBUser     string
+	DBPassword string
+	DBName     string
+}
+
+// NewModule creates a new inventory module
+func NewModule(cfg ModuleConfig, logger *slog.Logger) (*Module, error) {
+	return &Module{
+		config: cfg,
+		logger: logger.With(slog.String("module", "inventory")),
+	}, nil
+}
+
+// Initialize initializes the module (database connections, etc.)
+func (m *Module) Initialize(ctx context.Context) error {
+	m.logger.Info("Initializing Inventory module")
+
+	connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
+		m.config.DBUser, m.config.DBPassword, m.config.DBHost, m.config.DBPort, m.config.DBName)
+
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		return fmt.Errorf("failed to create database pool: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	m.pool = pool
+
+	if err := infra.EnsureInventorySchema(ctx, pool); err != nil {
+		return fmt.Errorf("failed to ensure inventory schema: %w", err)
+	}
+
+	service := app.NewStockService(infra.NewStockRepository(pool), m.logger)
+	m.handler = api.NewStockHandler(service, m.logger)
+
+	planning := app.NewPlanningService(infra.NewPlanningRepository(pool), service, m.logger)
+	m.planner = app.NewReplenishmentPlanner(planning, m.logger)
+	m.plans = api.NewPlanningHandler(planning, m.logger)
+
+	compatibility := app.NewCompatibilityService(infra.NewCompatibilityRepository(pool), m.logger)
+	m.fits = api.NewCompatibilityHandler(compatibility, m.logger)
+
+	m.logger.Info("Inventory module initialized successfully")
+	return nil
+}
+
+// MountRoutes mounts module routes to the router
+func (m *Module) MountRoutes(r chi.Router) {
+	m.logger.Info("Mounting Inventory routes")
+
+	r.Route("/inventory", func(r chi.Router) {
+		r.Get("/locations", m.handler.ListLocations)       // Warehouses and vans
+		r.Post("/locations", m.handler.CreateLocation)     // Create warehouse or engineer van
+		r.Get("/locations/{id}", m.handler.GetLocation)    // Get location
+		r.Get("/stock", m.handler.ListStock)               // On hand, reserved and available per part and location
+		r.Post("/receipts", m.handler.ReceiveStock)        // Receive parts into a location
+		r.Post("/transfers", m.handler.TransferStock)      // Move parts between locations
+		r.Post("/counts", m.handler.CycleCount)            // Cycle-count adjustments
+		r.Get("/ledger", m.handler.ListLedger)             // Stock movements
+		r.Get("/reservations", m.handler.ListReservations) // Stock held for tickets
+
+		// Replenishment planning
+		r.Post("/planning/run", m.plans.RunPlanning)                          // Recompute policies, suggestions and alerts now
+		r.Get("/policies", m.plans.ListPolicies)                              // Min/max and reorder points per part and location
+		r.Put("/policies", m.plans.SetPolicy)                                 // Override a computed policy
+		r.Delete("/policies/{locationID}/{sparePartID}", m.plans.ResetPolicy) // Back to the computed policy
+		r.Get("/suggestions", m.plans.ListSuggestions)                        // Suggested orders and transfers
+		r.Post("/suggestions/{id}/approve", m.plans.ApproveSuggestion)        // Approve; transfers are executed
+		r.Post("/suggestions/{id}/reject", m.plans.RejectSuggestion)          // Reject
+		r.Get("/alerts", m.plans.ListAlerts)                                  // Stock-out risks of critical parts
+		r.Post("/alerts/{id}/acknowledge", m.plans.AcknowledgeAlert)          // Mark alert as seen
+
+		// Parts compatibility
+		r.Get("/parts/resolve", m.fits.ResolvePart)                     // Current, fitting part for a part number
+		r.Get("/parts/{id}/compatibility", m.fits.GetPartCompatibility) // Fitments, links and current replacement
+		r.Put("/parts/{id}/fitments", m.fits.SetFitments)               // Models and serial ranges the part fits
+		r.Post("/parts/{id}/links", m.fits.AddLink)                     // Supersede or add an alternate
+		r.Delete("/part-links/{id}", m.fits.RemoveLink)                 // Remove a link
+		r.Get("/compatible-parts", m.fits.ListCompatibleParts)          // Parts fitting a unit or model
+	})
+
+	m.logger.Info("Inventory routes mounted successfully")
+}
+
+// Start starts background tasks (if any)
+func (m *Module) Start(ctx context.Context) error {
+	if m.planner != nil {
+		go m.planner.Run(ctx)
+	}
+	m.logger.Info("Inventory module started")
+	return nil
+}
+
+// Stop gracefully stops the module
+func (m *Module) Stop(ctx context.Context) error {
+	if m.pool != nil {
+		m.pool.Close()
+	}
+	m.logger.Info("Inventory module stopped")
+	return nil
+}
+
+// Name returns the module name
+func (m *Module) Name() string {
+	return "inventory"
+}
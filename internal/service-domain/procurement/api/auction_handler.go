@@ -0,0 +1,286 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// streamHeartbeat keeps idle live bid streams open through proxies
+const streamHeartbeat = 15 * time.Second
+
+// AuctionHandler handles HTTP requests for reverse auctions: the buyer's
+// endpoints under /procurement and the bidders' under /supplier-portal
+type AuctionHandler struct {
+	service *app.AuctionService
+	logger  *slog.Logger
+}
+
+// NewAuctionHandler creates a new auction handler
+func NewAuctionHandler(service *app.AuctionService, logger *slog.Logger) *AuctionHandler {
+	return &AuctionHandler{
+		service: service,
+		logger:  logger.With(slog.String("handler", "auction")),
+	}
+}
+
+// CreateAuction handles POST /procurement/auctions
+func (h *AuctionHandler) CreateAuction(w http.ResponseWriter, r *http.Request) {
+	var req app.CreateAuctionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.RFQID == "" {
+		h.respondError(w, http.StatusBadRequest, "rfq_id is required")
+		return
+	}
+
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+
+	createdBy := r.Header.Get("X-User-ID")
+	if createdBy == "" {
+		createdBy = "system"
+	}
+
+	auction, err := h.service.CreateAuction(r.Context(), tenantID, createdBy, req)
+	if err != nil {
+		h.respondAuctionError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, auction)
+}
+
+// GetAuction handles GET /procurement/auctions/{id}
+func (h *AuctionHandler) GetAuction(w http.ResponseWriter, r *http.Request) {
+	auction, err := h.service.GetAuction(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondAuctionError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, auction)
+}
+
+// ListAuctionsByRFQ handles GET /procurement/rfqs/{rfq_id}/auctions
+func (h *AuctionHandler) ListAuctionsByRFQ(w http.ResponseWriter, r *http.Request) {
+	auctions, err := h.service.ListAuctionsByRFQ(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "rfq_id"))
+	if err != nil {
+		h.respondAuctionError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"auctions": auctions, "total": len(auctions)})
+}
+
+// CancelAuction handles POST /procurement/auctions/{id}/cancel
+func (h *AuctionHandler) CancelAuction(w http.ResponseWriter, r *http.Request) {
+	auction, err := h.service.CancelAuction(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondAuctionError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, auction)
+}
+
+// CloseAuction handles POST /procurement/auctions/{id}/close
+func (h *AuctionHandler) CloseAuction(w http.ResponseWriter, r *http.Request) {
+	closedBy := r.Header.Get("X-User-ID")
+	if closedBy == "" {
+		closedBy = "system"
+	}
+
+	auction, err := h.service.CloseAuction(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"), closedBy)
+	if err != nil {
+		h.respondAuctionError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, auction)
+}
+
+// StreamAuction handles GET /procurement/auctions/{id}/stream, a server-sent
+// event stream of the buyer's view after every bid
+func (h *AuctionHandler) StreamAuction(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	id := chi.URLParam(r, "id")
+	h.stream(w, r, id, func(ctx context.Context) (interface{}, domain.AuctionStatus, error) {
+		view, err := h.service.GetAuction(ctx, tenantID, id)
+		if err != nil {
+			return nil, "", err
+		}
+		return view, view.CurrentStatus, nil
+	})
+}
+
+// ListSupplierAuctions handles GET /supplier-portal/auctions
+func (h *AuctionHandler) ListSupplierAuctions(w http.ResponseWriter, r *http.Request) {
+	auctions, err := h.service.ListSupplierAuctions(r.Context(), identityFrom(r))
+	if err != nil {
+		h.respondAuctionError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"auctions": auctions, "total": len(auctions)})
+}
+
+// GetSupplierAuction handles GET /supplier-portal/auctions/{id}
+func (h *AuctionHandler) GetSupplierAuction(w http.ResponseWriter, r *http.Request) {
+	view, err := h.service.GetBidderView(r.Context(), identityFrom(r), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondAuctionError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, view)
+}
+
+// PlaceBid handles POST /supplier-portal/auctions/{id}/bids
+func (h *AuctionHandler) PlaceBid(w http.ResponseWriter, r *http.Request) {
+	var req app.PlaceBidRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	view, err := h.service.PlaceBid(r.Context(), identityFrom(r), chi.URLParam(r, "id"), req)
+	if err != nil {
+		h.respondAuctionError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, view)
+}
+
+// StreamSupplierAuction handles GET /supplier-portal/auctions/{id}/stream.
+// Bidders only ever receive their own rank-only view.
+func (h *AuctionHandler) StreamSupplierAuction(w http.ResponseWriter, r *http.Request) {
+	sup := identityFrom(r)
+	id := chi.URLParam(r, "id")
+	h.stream(w, r, id, func(ctx context.Context) (interface{}, domain.AuctionStatus, error) {
+		view, err := h.service.GetBidderView(ctx, sup, id)
+		if err != nil {
+			return nil, "", err
+		}
+		return view, view.Status, nil
+	})
+}
+
+// stream writes the view returned by load as server-sent events: once on
+// connect and again on every auction update, until the auction is settled
+// or the client goes away
+func (h *AuctionHandler) stream(w http.ResponseWriter, r *http.Request, auctionID string,
+	load func(ctx context.Context) (interface{}, domain.AuctionStatus, error)) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.respondError(w, http.StatusInternalServerError, "Streaming not supported")
+		return
+	}
+
+	ctx := r.Context()
+	updates, unsubscribe := h.service.Subscribe(auctionID)
+	defer unsubscribe()
+
+	view, status, err := load(ctx)
+	if err != nil {
+		h.respondAuctionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 3000\n\n")
+
+	send := func(event string, data interface{}) bool {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	if !send("snapshot", view) || settled(status) {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case update := <-updates:
+			view, status, err := load(ctx)
+			if err != nil {
+				h.logger.Warn("Failed to reload auction for stream",
+					slog.String("auction_id", auctionID),
+					slog.String("error", err.Error()))
+				return
+			}
+			if !send(update.Kind, view) || settled(status) {
+				return
+			}
+		}
+	}
+}
+
+func settled(status domain.AuctionStatus) bool {
+	return status == domain.AuctionStatusClosed || status == domain.AuctionStatusCancelled
+}
+
+// respondAuctionError maps auction errors to HTTP status codes
+func (h *AuctionHandler) respondAuctionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAuctionNotFound), errors.Is(err, rfqDomain.ErrRFQNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrNotAuctionParticipant):
+		h.respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrInvalidAuction), errors.Is(err, domain.ErrBidNotLowEnough):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrAuctionExists), errors.Is(err, domain.ErrNotEnoughBidders),
+		errors.Is(err, domain.ErrAuctionNotOpen), errors.Is(err, domain.ErrAuctionNotEnded),
+		errors.Is(err, domain.ErrAuctionFinished), errors.Is(err, domain.ErrAuctionConflict):
+		h.respondError(w, http.StatusConflict, err.Error())
+	default:
+		h.logger.Error("Auction request failed", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// respondJSON sends a JSON response
+func (h *AuctionHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError sends an error response
+func (h *AuctionHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// ExchangeRateHandler handles HTTP requests for exchange rates and currency conversion
+type ExchangeRateHandler struct {
+	service *app.ExchangeRateService
+	logger  *slog.Logger
+}
+
+// NewExchangeRateHandler creates a new exchange rate handler
+func NewExchangeRateHandler(service *app.ExchangeRateService, logger *slog.Logger) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		service: service,
+		logger:  logger.With(slog.String("handler", "exchange_rate")),
+	}
+}
+
+// ListRates handles GET /procurement/exchange-rates
+func (h *ExchangeRateHandler) ListRates(w http.ResponseWriter, r *http.Request) {
+	filter := domain.ExchangeRateFilter{Currency: r.URL.Query().Get("currency")}
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := r.URL.Query().Get(param); value != "" {
+			day, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				h.respondError(w, http.StatusBadRequest, param+" must be YYYY-MM-DD")
+				return
+			}
+			*target = &day
+		}
+	}
+
+	rates, err := h.service.ListRates(r.Context(), r.Header.Get("X-Tenant-ID"), filter)
+	if err != nil {
+		h.respondRateError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"rates": rates, "total": len(rates)})
+}
+
+// SetRates handles POST /procurement/exchange-rates
+func (h *ExchangeRateHandler) SetRates(w http.ResponseWriter, r *http.Request) {
+	var req app.SetExchangeRatesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+
+	rates, err := h.service.SetRates(r.Context(), tenantID, userFrom(r), req)
+	if err != nil {
+		h.respondRateError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"rates": rates, "total": len(rates)})
+}
+
+// ImportRatesCSV handles POST /procurement/exchange-rates/import
+func (h *ExchangeRateHandler) ImportRatesCSV(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Failed to parse form: "+err.Error())
+		return
+	}
+
+	file, _, err := r.FormFile("csv_file")
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "CSV file is required")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportRatesFromCSV(r.Context(), tenantID, file, userFrom(r))
+	if err != nil {
+		h.logger.Error("Failed to import exchange rates", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusBadRequest, "Failed to import exchange rates: "+err.Error())
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// DeleteRate handles DELETE /procurement/exchange-rates/{id}
+func (h *ExchangeRateHandler) DeleteRate(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteRate(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id")); err != nil {
+		h.respondRateError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Convert handles GET /procurement/exchange-rates/convert?amount=&from=&to=&date=
+func (h *ExchangeRateHandler) Convert(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	amount, err := money.Parse(query.Get("amount"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "amount must be a decimal number")
+		return
+	}
+
+	day := time.Now()
+	if value := query.Get("date"); value != "" {
+		if day, err = time.Parse("2006-01-02", value); err != nil {
+			h.respondError(w, http.StatusBadRequest, "date must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	conversion, err := h.service.Convert(r.Context(), r.Header.Get("X-Tenant-ID"), amount, query.Get("from"), query.Get("to"), day)
+	if err != nil {
+		h.respondRateError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, conversion)
+}
+
+// RFQCurrencyReport handles GET /procurement/rfqs/{rfq_id}/currency-report
+func (h *ExchangeRateHandler) RFQCurrencyReport(w http.ResponseWriter, r *http.Request) {
+	report, err := h.service.RFQCurrencyReport(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "rfq_id"))
+	if err != nil {
+		h.respondRateError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, report)
+}
+
+// userFrom returns the calling user, or "system"
+func userFrom(r *http.Request) string {
+	if user := r.Header.Get("X-User-ID"); user != "" {
+		return user
+	}
+	return "system"
+}
+
+// respondRateError maps exchange rate errors to HTTP status codes
+func (h *ExchangeRateHandler) respondRateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrExchangeRateNotFound), errors.Is(err, rfqDomain.ErrRFQNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidExchangeRate), errors.Is(err, money.ErrUnknownCurrency):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("Exchange rate request failed", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// respondJSON sends a JSON response
+func (h *ExchangeRateHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError sends an error response
+func (h *ExchangeRateHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
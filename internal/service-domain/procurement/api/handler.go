@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	comparisonDomain "github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// AwardHandler handles HTTP requests for procurement awards
+type AwardHandler struct {
+	service *app.AwardService
+	logger  *slog.Logger
+}
+
+// NewAwardHandler creates a new award handler
+func NewAwardHandler(service *app.AwardService, logger *slog.Logger) *AwardHandler {
+	return &AwardHandler{
+		service: service,
+		logger:  logger.With(slog.String("handler", "award")),
+	}
+}
+
+// CreateAward handles POST /procurement/awards
+func (h *AwardHandler) CreateAward(w http.ResponseWriter, r *http.Request) {
+	var req app.AwardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.ComparisonID == "" {
+		h.respondError(w, http.StatusBadRequest, "comparison_id is required")
+		return
+	}
+
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+
+	awardedBy := r.Header.Get("X-User-ID")
+	if awardedBy == "" {
+		awardedBy = "system"
+	}
+
+	result, err := h.service.Award(r.Context(), tenantID, awardedBy, req)
+	if err != nil {
+		h.respondAwardError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, result)
+}
+
+// GetAward handles GET /procurement/awards/{id}
+func (h *AwardHandler) GetAward(w http.ResponseWriter, r *http.Request) {
+	award, err := h.service.GetAward(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondAwardError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, award)
+}
+
+// ListAwardsByRFQ handles GET /procurement/rfqs/{rfq_id}/awards
+func (h *AwardHandler) ListAwardsByRFQ(w http.ResponseWriter, r *http.Request) {
+	awards, err := h.service.ListAwardsByRFQ(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "rfq_id"))
+	if err != nil {
+		h.respondAwardError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"awards": awards, "total": len(awards)})
+}
+
+// respondAwardError maps saga errors to HTTP status codes
+func (h *AwardHandler) respondAwardError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAwardNotFound), errors.Is(err, comparisonDomain.ErrComparisonNotFound),
+		errors.Is(err, rfqDomain.ErrRFQNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrComparisonNotCompleted), errors.Is(err, domain.ErrNoWinningQuote),
+		errors.Is(err, domain.ErrQuoteNotAwardable), errors.Is(err, domain.ErrRFQNotAwardable),
+		errors.Is(err, domain.ErrRFQAlreadyAwarded), errors.Is(err, domain.ErrAwardInProgress):
+		h.respondError(w, http.StatusConflict, err.Error())
+	default:
+		h.logger.Error("Procurement award failed", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// respondJSON sends a JSON response
+func (h *AwardHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError sends an error response
+func (h *AwardHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
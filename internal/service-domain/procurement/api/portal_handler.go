@@ -0,0 +1,296 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/aby-med/medical-platform/internal/middleware"
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	quoteDomain "github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// supplierOrgType is the organization type of supplier portal users
+const supplierOrgType = "supplier"
+
+type portalContextKey struct{}
+
+// PortalHandler handles supplier portal HTTP requests
+type PortalHandler struct {
+	service *app.PortalService
+	logger  *slog.Logger
+}
+
+// NewPortalHandler creates a new supplier portal handler
+func NewPortalHandler(service *app.PortalService, logger *slog.Logger) *PortalHandler {
+	return &PortalHandler{
+		service: service,
+		logger:  logger.With(slog.String("handler", "supplier_portal")),
+	}
+}
+
+// SupplierContext authenticates the caller as a user of a supplier
+// organization and resolves the supplier it acts for in the tenant
+func (h *PortalHandler) SupplierContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		orgID, ok := middleware.GetOrganizationID(ctx)
+		if !ok {
+			h.respondError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+		if orgType, _ := middleware.GetOrganizationType(ctx); orgType != supplierOrgType {
+			h.respondError(w, http.StatusForbidden, domain.ErrNotSupplierUser.Error())
+			return
+		}
+
+		tenantID := r.Header.Get("X-Tenant-ID")
+		if tenantID == "" {
+			h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+			return
+		}
+
+		supplier, err := h.service.ResolveSupplier(ctx, tenantID, orgID.String())
+		if err != nil {
+			h.respondPortalError(w, err)
+			return
+		}
+
+		identity := app.SupplierIdentity{TenantID: tenantID, SupplierID: supplier.ID, UserID: orgID.String()}
+		if userID, ok := middleware.GetUserID(ctx); ok {
+			identity.UserID = userID.String()
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, portalContextKey{}, identity)))
+	})
+}
+
+// ListInvitations handles GET /supplier-portal/invitations?status=
+func (h *PortalHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	invitations, err := h.service.ListInvitations(r.Context(), identityFrom(r), r.URL.Query().Get("status"))
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"invitations": invitations, "total": len(invitations)})
+}
+
+// GetInvitation handles GET /supplier-portal/invitations/{rfq_id}
+func (h *PortalHandler) GetInvitation(w http.ResponseWriter, r *http.Request) {
+	invitation, err := h.service.GetInvitation(r.Context(), identityFrom(r), chi.URLParam(r, "rfq_id"))
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, invitation)
+}
+
+// MarkViewed handles POST /supplier-portal/invitations/{rfq_id}/view
+func (h *PortalHandler) MarkViewed(w http.ResponseWriter, r *http.Request) {
+	invitation, err := h.service.MarkViewed(r.Context(), identityFrom(r), chi.URLParam(r, "rfq_id"))
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, invitation)
+}
+
+// DeclineInvitation handles POST /supplier-portal/invitations/{rfq_id}/decline
+func (h *PortalHandler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Reason == "" {
+		h.respondError(w, http.StatusBadRequest, "reason is required")
+		return
+	}
+
+	invitation, err := h.service.DeclineInvitation(r.Context(), identityFrom(r), chi.URLParam(r, "rfq_id"), req.Reason)
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, invitation)
+}
+
+// ListAddenda handles GET /supplier-portal/invitations/{rfq_id}/addenda
+func (h *PortalHandler) ListAddenda(w http.ResponseWriter, r *http.Request) {
+	addenda, err := h.service.ListAddenda(r.Context(), identityFrom(r), chi.URLParam(r, "rfq_id"))
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"addenda": addenda, "total": len(addenda)})
+}
+
+// ListQuestions handles GET /supplier-portal/invitations/{rfq_id}/questions
+func (h *PortalHandler) ListQuestions(w http.ResponseWriter, r *http.Request) {
+	questions, err := h.service.ListQuestions(r.Context(), identityFrom(r), chi.URLParam(r, "rfq_id"))
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"questions": questions, "total": len(questions)})
+}
+
+// AskQuestion handles POST /supplier-portal/invitations/{rfq_id}/questions
+func (h *PortalHandler) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Question string `json:"question"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	question, err := h.service.AskQuestion(r.Context(), identityFrom(r), chi.URLParam(r, "rfq_id"), req.Question)
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, question)
+}
+
+// CreateQuote handles POST /supplier-portal/invitations/{rfq_id}/quotes
+func (h *PortalHandler) CreateQuote(w http.ResponseWriter, r *http.Request) {
+	var req app.PortalQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	quote, err := h.service.CreateQuote(r.Context(), identityFrom(r), chi.URLParam(r, "rfq_id"), req)
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, quote)
+}
+
+// ListQuotes handles GET /supplier-portal/quotes
+func (h *PortalHandler) ListQuotes(w http.ResponseWriter, r *http.Request) {
+	quotes, err := h.service.ListQuotes(r.Context(), identityFrom(r))
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"quotes": quotes, "total": len(quotes)})
+}
+
+// GetQuote handles GET /supplier-portal/quotes/{id}
+func (h *PortalHandler) GetQuote(w http.ResponseWriter, r *http.Request) {
+	quote, err := h.service.GetQuote(r.Context(), identityFrom(r), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, quote)
+}
+
+// UpdateQuote handles PUT /supplier-portal/quotes/{id}
+func (h *PortalHandler) UpdateQuote(w http.ResponseWriter, r *http.Request) {
+	var req app.PortalQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	quote, err := h.service.UpdateQuote(r.Context(), identityFrom(r), chi.URLParam(r, "id"), req)
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, quote)
+}
+
+// SubmitQuote handles POST /supplier-portal/quotes/{id}/submit
+func (h *PortalHandler) SubmitQuote(w http.ResponseWriter, r *http.Request) {
+	quote, err := h.service.SubmitQuote(r.Context(), identityFrom(r), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, quote)
+}
+
+// ListNotifications handles GET /supplier-portal/notifications?unread=true
+func (h *PortalHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+	notifications, err := h.service.ListNotifications(r.Context(), identityFrom(r), unreadOnly)
+	if err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"notifications": notifications, "total": len(notifications)})
+}
+
+// MarkNotificationRead handles POST /supplier-portal/notifications/{id}/read
+func (h *PortalHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.MarkNotificationRead(r.Context(), identityFrom(r), chi.URLParam(r, "id")); err != nil {
+		h.respondPortalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// identityFrom returns the supplier resolved by SupplierContext
+func identityFrom(r *http.Request) app.SupplierIdentity {
+	identity, _ := r.Context().Value(portalContextKey{}).(app.SupplierIdentity)
+	return identity
+}
+
+// respondPortalError maps portal errors to HTTP status codes
+func (h *PortalHandler) respondPortalError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSupplierNotLinked), errors.Is(err, domain.ErrQuoteNotOwned):
+		h.respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, rfqDomain.ErrRFQNotFound), errors.Is(err, quoteDomain.ErrQuoteNotFound),
+		errors.Is(err, rfqDomain.ErrNotificationNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidQuoteItems), errors.Is(err, domain.ErrQuoteValidityTooShort),
+		errors.Is(err, quoteDomain.ErrNoItems), errors.Is(err, rfqDomain.ErrQuestionEmpty),
+		errors.Is(err, money.ErrUnknownCurrency):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrRFQNotOpen), errors.Is(err, domain.ErrQuoteExists),
+		errors.Is(err, rfqDomain.ErrInvitationDeclined), errors.Is(err, rfqDomain.ErrInvitationResponded),
+		errors.Is(err, rfqDomain.ErrQuestionsClosed), errors.Is(err, quoteDomain.ErrQuoteExpired),
+		errors.Is(err, domain.ErrQuoteStaleVersion), errors.Is(err, domain.ErrQuoteNotEditable):
+		h.respondError(w, http.StatusConflict, err.Error())
+	default:
+		h.logger.Error("Supplier portal request failed", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// respondJSON sends a JSON response
+func (h *PortalHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError sends an error response
+func (h *PortalHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
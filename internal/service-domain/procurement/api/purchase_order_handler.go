@@ -0,0 +1,147 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	contractDomain "github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// PurchaseOrderHandler handles HTTP requests for purchase orders, goods
+// receipts and supplier invoices
+type PurchaseOrderHandler struct {
+	service *app.PurchaseOrderService
+	logger  *slog.Logger
+}
+
+// NewPurchaseOrderHandler creates a new purchase order handler
+func NewPurchaseOrderHandler(service *app.PurchaseOrderService, logger *slog.Logger) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		service: service,
+		logger:  logger.With(slog.String("handler", "purchase_order")),
+	}
+}
+
+// CreatePurchaseOrder handles POST /procurement/contracts/{contract_id}/purchase-orders
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	var req app.CreatePurchaseOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header required")
+		return
+	}
+
+	po, err := h.service.CreatePurchaseOrder(r.Context(), tenantID, chi.URLParam(r, "contract_id"), userFrom(r), req)
+	if err != nil {
+		h.respondPurchaseOrderError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, po)
+}
+
+// ListPurchaseOrders handles GET /procurement/contracts/{contract_id}/purchase-orders
+func (h *PurchaseOrderHandler) ListPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	orders, err := h.service.ListPurchaseOrders(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "contract_id"))
+	if err != nil {
+		h.respondPurchaseOrderError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"purchase_orders": orders, "total": len(orders)})
+}
+
+// GetPurchaseOrder handles GET /procurement/purchase-orders/{id}
+func (h *PurchaseOrderHandler) GetPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	po, err := h.service.GetPurchaseOrder(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondPurchaseOrderError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, po)
+}
+
+// CancelPurchaseOrder handles POST /procurement/purchase-orders/{id}/cancel
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	po, err := h.service.CancelPurchaseOrder(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondPurchaseOrderError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, po)
+}
+
+// ReceiveGoods handles POST /procurement/purchase-orders/{id}/receipts
+func (h *PurchaseOrderHandler) ReceiveGoods(w http.ResponseWriter, r *http.Request) {
+	var req app.ReceiveGoodsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	result, err := h.service.ReceiveGoods(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"), userFrom(r), req)
+	if err != nil {
+		h.respondPurchaseOrderError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, result)
+}
+
+// SubmitInvoice handles POST /procurement/purchase-orders/{id}/invoices
+func (h *PurchaseOrderHandler) SubmitInvoice(w http.ResponseWriter, r *http.Request) {
+	var req app.SubmitInvoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	result, err := h.service.SubmitInvoice(r.Context(), r.Header.Get("X-Tenant-ID"), chi.URLParam(r, "id"), userFrom(r), req)
+	if err != nil {
+		h.respondPurchaseOrderError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, result)
+}
+
+// respondPurchaseOrderError maps purchase order errors to HTTP status codes
+func (h *PurchaseOrderHandler) respondPurchaseOrderError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPurchaseOrderNotFound), errors.Is(err, contractDomain.ErrContractNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidPurchaseOrder), errors.Is(err, domain.ErrInvalidReceipt),
+		errors.Is(err, domain.ErrInvalidInvoice):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrCallOffExceedsContract), errors.Is(err, domain.ErrPurchaseOrderClosed),
+		errors.Is(err, domain.ErrDuplicateInvoice), errors.Is(err, domain.ErrPurchaseOrderConflict):
+		h.respondError(w, http.StatusConflict, err.Error())
+	default:
+		h.logger.Error("Purchase order request failed", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// respondJSON sends a JSON response
+func (h *PurchaseOrderHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError sends an error response
+func (h *PurchaseOrderHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/shared/config"
+)
+
+// AuctionCloser periodically settles reverse auctions whose bidding has ended
+type AuctionCloser struct {
+	service  *AuctionService
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+// NewAuctionCloser creates a new auction closer
+func NewAuctionCloser(service *AuctionService, logger *slog.Logger) *AuctionCloser {
+	return &AuctionCloser{
+		service:  service,
+		interval: 30 * time.Second,
+		logger:   logger.With(slog.String("component", "auction_closer")),
+	}
+}
+
+// Run settles ended auctions until the context is cancelled.
+// Disabled with ENABLE_AUCTION_CLOSER=false.
+func (c *AuctionCloser) Run(ctx context.Context) {
+	if !config.Enabled("ENABLE_AUCTION_CLOSER") {
+		c.logger.Info("Auction closer disabled; skipping run")
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce settles all ended auctions once
+func (c *AuctionCloser) RunOnce(ctx context.Context) {
+	closed, err := c.service.CloseEndedAuctions(ctx)
+	if err != nil {
+		c.logger.Error("Failed to close ended auctions", slog.String("error", err.Error()))
+		return
+	}
+	if closed > 0 {
+		c.logger.Info("Closed ended reverse auctions", slog.Int("count", closed))
+	}
+}
@@ -0,0 +1,489 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	comparisonDomain "github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	quoteDomain "github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+)
+
+// bidRetries is how often a bid is retried after losing a race to another bid
+const bidRetries = 3
+
+// Auction update kinds pushed to live bid streams
+const (
+	AuctionUpdateBid       = "bid"
+	AuctionUpdateClosed    = "closed"
+	AuctionUpdateCancelled = "cancelled"
+)
+
+// AuctionUpdate signals that an auction changed. It carries no prices;
+// subscribers reload the view they are allowed to see.
+type AuctionUpdate struct {
+	AuctionID string    `json:"auction_id"`
+	Kind      string    `json:"kind"`
+	EndsAt    time.Time `json:"ends_at"`
+	At        time.Time `json:"at"`
+}
+
+// AuctionBroker fans auction updates out to the live bid streams of this
+// instance
+type AuctionBroker struct {
+	mu   sync.Mutex
+	subs map[string]map[chan AuctionUpdate]struct{}
+}
+
+// NewAuctionBroker creates a new auction update broker
+func NewAuctionBroker() *AuctionBroker {
+	return &AuctionBroker{subs: make(map[string]map[chan AuctionUpdate]struct{})}
+}
+
+// Subscribe registers for updates of an auction; call the returned func to unsubscribe
+func (b *AuctionBroker) Subscribe(auctionID string) (<-chan AuctionUpdate, func()) {
+	ch := make(chan AuctionUpdate, 8)
+
+	b.mu.Lock()
+	if b.subs[auctionID] == nil {
+		b.subs[auctionID] = make(map[chan AuctionUpdate]struct{})
+	}
+	b.subs[auctionID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subs[auctionID], ch)
+		if len(b.subs[auctionID]) == 0 {
+			delete(b.subs, auctionID)
+		}
+		b.mu.Unlock()
+	}
+}
+
+// Publish delivers an update to all subscribers without blocking; a
+// subscriber that is behind only misses intermediate updates
+func (b *AuctionBroker) Publish(update AuctionUpdate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs[update.AuctionID] {
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
+
+// CreateAuctionRequest schedules a reverse auction on an RFQ
+type CreateAuctionRequest struct {
+	RFQID            string    `json:"rfq_id"`
+	StartsAt         time.Time `json:"starts_at"`
+	EndsAt           time.Time `json:"ends_at"`
+	MinDecrement     float64   `json:"min_decrement"`
+	ExtensionSeconds int       `json:"extension_seconds"`
+	MaxExtensions    int       `json:"max_extensions"`
+}
+
+// PlaceBidRequest is a supplier's auction bid
+type PlaceBidRequest struct {
+	Amount float64 `json:"amount"`
+}
+
+// AuctionView is the buyer's view of an auction including the live ranking
+type AuctionView struct {
+	*domain.Auction
+	CurrentStatus domain.AuctionStatus `json:"current_status"`
+	Rankings      []domain.AuctionRank `json:"rankings"`
+}
+
+// AuctionService runs reverse auctions on the qualifying quotes of an RFQ.
+// Closing an auction reprices the quotes to their final bids and creates a
+// completed comparison, which is then awarded through the award saga.
+type AuctionService struct {
+	auctionRepo    domain.AuctionRepository
+	rfqRepo        rfqDomain.RFQRepository
+	quoteRepo      quoteDomain.QuoteRepository
+	comparisonRepo comparisonDomain.Repository
+	broker         *AuctionBroker
+	clock          domain.Clock
+	logger         *slog.Logger
+}
+
+// NewAuctionService creates a new reverse auction service
+func NewAuctionService(
+	auctionRepo domain.AuctionRepository,
+	rfqRepo rfqDomain.RFQRepository,
+	quoteRepo quoteDomain.QuoteRepository,
+	comparisonRepo comparisonDomain.Repository,
+	broker *AuctionBroker,
+	clock domain.Clock,
+	logger *slog.Logger,
+) *AuctionService {
+	return &AuctionService{
+		auctionRepo:    auctionRepo,
+		rfqRepo:        rfqRepo,
+		quoteRepo:      quoteRepo,
+		comparisonRepo: comparisonRepo,
+		broker:         broker,
+		clock:          clock,
+		logger:         logger.With(slog.String("component", "auction_service")),
+	}
+}
+
+// CreateAuction schedules a reverse auction. Every supplier with a submitted,
+// unexpired quote for the RFQ takes part with that quote as its opening price.
+func (s *AuctionService) CreateAuction(ctx context.Context, tenantID, createdBy string, req CreateAuctionRequest) (*domain.Auction, error) {
+	now := s.clock.Now()
+
+	rfq, err := s.rfqRepo.GetByID(ctx, req.RFQID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if rfq.Status != rfqDomain.RFQStatusPublished && rfq.Status != rfqDomain.RFQStatusClosed {
+		return nil, fmt.Errorf("%w: rfq is %s", domain.ErrInvalidAuction, rfq.Status)
+	}
+	if rfq.SealedBid {
+		return nil, fmt.Errorf("%w: sealed-bid rfqs cannot run a reverse auction", domain.ErrInvalidAuction)
+	}
+	if req.StartsAt.Before(rfq.ResponseDeadline) {
+		return nil, fmt.Errorf("%w: auction cannot start before the rfq response deadline", domain.ErrInvalidAuction)
+	}
+
+	existing, err := s.auctionRepo.ListByRFQ(ctx, tenantID, rfq.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range existing {
+		if a.Status != domain.AuctionStatusCancelled {
+			return nil, fmt.Errorf("%w: %s", domain.ErrAuctionExists, a.ID)
+		}
+	}
+
+	quotes, err := s.quoteRepo.GetByRFQID(ctx, rfq.ID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	participants := []domain.AuctionParticipant{}
+	currency := ""
+	for _, q := range quotes {
+		if (q.Status != quoteDomain.QuoteStatusSubmitted && q.Status != quoteDomain.QuoteStatusUnderReview) ||
+			q.ValidUntil.Before(req.EndsAt) {
+			continue
+		}
+		if currency == "" {
+			currency = q.Currency
+		} else if q.Currency != currency {
+			return nil, fmt.Errorf("%w: qualifying quotes use different currencies", domain.ErrInvalidAuction)
+		}
+		participants = append(participants, domain.AuctionParticipant{
+			SupplierID:   q.SupplierID,
+			QuoteID:      q.ID,
+			OpeningPrice: q.TotalAmount,
+		})
+	}
+
+	auction, err := domain.NewAuction(tenantID, rfq.ID, currency, req.StartsAt, req.EndsAt, req.MinDecrement,
+		req.ExtensionSeconds, req.MaxExtensions, participants, createdBy, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auctionRepo.Create(ctx, auction); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Reverse auction scheduled",
+		slog.String("auction_id", auction.ID),
+		slog.String("rfq_id", rfq.ID),
+		slog.Int("participants", len(participants)))
+	return auction, nil
+}
+
+// GetAuction returns the buyer's view of an auction
+func (s *AuctionService) GetAuction(ctx context.Context, tenantID, id string) (*AuctionView, error) {
+	auction, err := s.auctionRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.buyerView(auction), nil
+}
+
+// ListAuctionsByRFQ returns the auctions of an RFQ
+func (s *AuctionService) ListAuctionsByRFQ(ctx context.Context, tenantID, rfqID string) ([]*AuctionView, error) {
+	auctions, err := s.auctionRepo.ListByRFQ(ctx, tenantID, rfqID)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]*AuctionView, 0, len(auctions))
+	for _, a := range auctions {
+		views = append(views, s.buyerView(a))
+	}
+	return views, nil
+}
+
+// CancelAuction cancels an auction that has not been closed
+func (s *AuctionService) CancelAuction(ctx context.Context, tenantID, id string) (*AuctionView, error) {
+	auction, err := s.auctionRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	if err := auction.Cancel(now); err != nil {
+		return nil, err
+	}
+	if err := s.auctionRepo.Update(ctx, auction); err != nil {
+		return nil, err
+	}
+
+	s.broker.Publish(AuctionUpdate{AuctionID: auction.ID, Kind: AuctionUpdateCancelled, EndsAt: auction.EndsAt, At: now})
+	return s.buyerView(auction), nil
+}
+
+// CloseAuction settles an auction whose bidding has ended: each quote is
+// repriced to its supplier's final bid and a completed, price-ranked
+// comparison is created so the winner can be awarded. Quotes already carrying
+// their final price are skipped, so a failed close can simply be retried.
+func (s *AuctionService) CloseAuction(ctx context.Context, tenantID, id, closedBy string) (*AuctionView, error) {
+	auction, err := s.auctionRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.settle(ctx, auction, closedBy); err != nil {
+		return nil, err
+	}
+	return s.buyerView(auction), nil
+}
+
+// CloseEndedAuctions settles all auctions whose bidding has ended
+func (s *AuctionService) CloseEndedAuctions(ctx context.Context) (int, error) {
+	auctions, err := s.auctionRepo.ListEnded(ctx, s.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, auction := range auctions {
+		if err := s.settle(ctx, auction, "system"); err != nil {
+			s.logger.Error("Failed to close auction",
+				slog.String("auction_id", auction.ID),
+				slog.String("error", err.Error()))
+			continue
+		}
+		closed++
+	}
+	return closed, nil
+}
+
+// ListSupplierAuctions returns the rank-only view of every auction the supplier takes part in
+func (s *AuctionService) ListSupplierAuctions(ctx context.Context, sup SupplierIdentity) ([]*domain.BidderView, error) {
+	auctions, err := s.auctionRepo.ListBySupplier(ctx, sup.TenantID, sup.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	views := make([]*domain.BidderView, 0, len(auctions))
+	for _, a := range auctions {
+		view, err := a.BidderView(sup.SupplierID, now)
+		if err != nil {
+			continue
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// GetBidderView returns a participant's rank-only view of an auction
+func (s *AuctionService) GetBidderView(ctx context.Context, sup SupplierIdentity, id string) (*domain.BidderView, error) {
+	auction, err := s.auctionRepo.GetByID(ctx, sup.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return auction.BidderView(sup.SupplierID, s.clock.Now())
+}
+
+// PlaceBid places a supplier's bid and returns its new standing
+func (s *AuctionService) PlaceBid(ctx context.Context, sup SupplierIdentity, id string, req PlaceBidRequest) (*domain.BidderView, error) {
+	for attempt := 0; ; attempt++ {
+		auction, err := s.auctionRepo.GetByID(ctx, sup.TenantID, id)
+		if err != nil {
+			return nil, err
+		}
+
+		now := s.clock.Now()
+		bid, err := auction.PlaceBid(sup.SupplierID, req.Amount, sup.UserID, now)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.auctionRepo.AddBid(ctx, auction)
+		if errors.Is(err, domain.ErrAuctionConflict) && attempt < bidRetries {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		s.logger.Info("Auction bid placed",
+			slog.String("auction_id", auction.ID),
+			slog.String("supplier_id", sup.SupplierID),
+			slog.Bool("extended", bid.Extended))
+		s.broker.Publish(AuctionUpdate{AuctionID: auction.ID, Kind: AuctionUpdateBid, EndsAt: auction.EndsAt, At: now})
+		return auction.BidderView(sup.SupplierID, now)
+	}
+}
+
+// Subscribe registers a live bid stream for an auction
+func (s *AuctionService) Subscribe(auctionID string) (<-chan AuctionUpdate, func()) {
+	return s.broker.Subscribe(auctionID)
+}
+
+// settle applies the final auction prices and builds the award comparison
+func (s *AuctionService) settle(ctx context.Context, auction *domain.Auction, closedBy string) error {
+	now := s.clock.Now()
+	ranks, err := auction.Close(now)
+	if err != nil {
+		return err
+	}
+
+	quotes, err := s.quoteRepo.GetByRFQID(ctx, auction.RFQID, auction.TenantID)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]*quoteDomain.Quote, len(quotes))
+	for _, q := range quotes {
+		byID[q.ID] = q
+	}
+
+	scored := []domain.AuctionRank{}
+	for _, r := range ranks {
+		q, ok := byID[r.QuoteID]
+		if !ok || !domain.IsActiveQuote(q) {
+			continue
+		}
+		if domain.ApplyAuctionPrice(q, r.Price, auction.ID, closedBy, now) {
+			if err := s.quoteRepo.Update(ctx, q); err != nil {
+				return fmt.Errorf("failed to apply final auction price to quote %s: %w", q.ID, err)
+			}
+		}
+		r.Price = q.TotalAmount
+		scored = append(scored, r)
+	}
+
+	if len(scored) >= 2 {
+		comparison, err := s.auctionComparison(ctx, auction, scored, byID, closedBy)
+		if err != nil {
+			return err
+		}
+		auction.ComparisonID = comparison.ID
+	} else {
+		s.logger.Warn("Auction closed with fewer than two active quotes; no comparison created",
+			slog.String("auction_id", auction.ID))
+	}
+
+	if err := s.auctionRepo.Update(ctx, auction); err != nil {
+		return err
+	}
+
+	s.logger.Info("Reverse auction closed",
+		slog.String("auction_id", auction.ID),
+		slog.String("comparison_id", auction.ComparisonID))
+	s.broker.Publish(AuctionUpdate{AuctionID: auction.ID, Kind: AuctionUpdateClosed, EndsAt: auction.EndsAt, At: now})
+	return nil
+}
+
+// auctionComparison returns the award comparison of an auction, creating it
+// on the first close. The comparison takes the auction's ID, so a close that
+// is retried after the auction update failed reuses it instead of adding a
+// second one.
+func (s *AuctionService) auctionComparison(ctx context.Context, auction *domain.Auction, ranks []domain.AuctionRank, quotes map[string]*quoteDomain.Quote, closedBy string) (*comparisonDomain.Comparison, error) {
+	existing, err := s.comparisonRepo.GetByID(ctx, auction.TenantID, auction.ID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, comparisonDomain.ErrComparisonNotFound) {
+		return nil, fmt.Errorf("failed to look up auction comparison: %w", err)
+	}
+
+	comparison, err := buildAuctionComparison(auction, ranks, quotes, closedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.comparisonRepo.Create(ctx, comparison); err != nil {
+		return nil, fmt.Errorf("failed to create auction comparison: %w", err)
+	}
+	return comparison, nil
+}
+
+func (s *AuctionService) buyerView(auction *domain.Auction) *AuctionView {
+	return &AuctionView{
+		Auction:       auction,
+		CurrentStatus: auction.StatusAt(s.clock.Now()),
+		Rankings:      auction.Rankings(),
+	}
+}
+
+// buildAuctionComparison turns the final ranking into a completed, price-only
+// comparison whose best overall quote is the auction winner. It shares the
+// auction's ID.
+func buildAuctionComparison(auction *domain.Auction, ranks []domain.AuctionRank, quotes map[string]*quoteDomain.Quote, createdBy string) (*comparisonDomain.Comparison, error) {
+	quoteIDs := make([]string, 0, len(ranks))
+	for _, r := range ranks {
+		quoteIDs = append(quoteIDs, r.QuoteID)
+	}
+
+	comparison, err := comparisonDomain.NewComparison(auction.TenantID, auction.RFQID,
+		"Reverse auction "+auction.ID, createdBy, quoteIDs)
+	if err != nil {
+		return nil, err
+	}
+	comparison.ID = auction.ID
+	comparison.Description = "Ranked by final reverse auction price"
+	comparison.ScoringCriteria = comparisonDomain.ScoringCriteria{PriceWeight: 100}
+
+	lowest := ranks[0].Price
+	scores := make([]comparisonDomain.QuoteScore, 0, len(ranks))
+	differences := make([]comparisonDomain.PriceDifference, 0, len(ranks))
+	for i, r := range ranks {
+		q := quotes[r.QuoteID]
+		priceScore := 100.0
+		if r.Price > lowest {
+			priceScore = lowest / r.Price * 100
+		}
+		scores = append(scores, comparisonDomain.QuoteScore{
+			QuoteID:        q.ID,
+			QuoteNumber:    q.QuoteNumber,
+			SupplierID:     q.SupplierID,
+			TotalAmount:    r.Price,
+			PriceScore:     priceScore,
+			OverallScore:   priceScore,
+			Rank:           i + 1,
+			Strengths:      []string{},
+			Weaknesses:     []string{},
+			Recommendation: fmt.Sprintf("Auction rank %d after %d bids", i+1, r.BidCount),
+			RFQVersion:     q.RFQVersion,
+			CalculatedAt:   auction.UpdatedAt,
+		})
+
+		diff := comparisonDomain.PriceDifference{QuoteID: q.ID, QuoteNumber: q.QuoteNumber, TotalAmount: r.Price}
+		diff.DifferenceFromLowest = r.Price - lowest
+		if lowest > 0 {
+			diff.PercentageFromLowest = diff.DifferenceFromLowest / lowest * 100
+		}
+		differences = append(differences, diff)
+	}
+
+	comparison.SetScores(scores)
+	comparison.SetPriceDifferences(differences)
+	comparison.SetRecommendation(fmt.Sprintf("Award to the auction winner at %.2f %s", lowest, auction.Currency))
+	if err := comparison.Activate(); err != nil {
+		return nil, err
+	}
+	if err := comparison.Complete(); err != nil {
+		return nil, err
+	}
+	return comparison, nil
+}
@@ -0,0 +1,404 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	comparisonDomain "github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+	contractDomain "github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	quoteDomain "github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+	supplierDomain "github.com/aby-med/medical-platform/internal/service-domain/supplier/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// defaultContractTerm is the contract duration used when no end date is given
+const defaultContractTerm = 1 // years
+
+// AwardService runs the procurement saga from a completed comparison to a
+// draft contract. Each step that changes state registers a compensation so a
+// failure part way leaves the RFQ, its quotes and contracts as they were.
+type AwardService struct {
+	rfqRepo        rfqDomain.RFQRepository
+	quoteRepo      quoteDomain.QuoteRepository
+	comparisonRepo comparisonDomain.Repository
+	contractRepo   contractDomain.Repository
+	supplierRepo   supplierDomain.SupplierRepository
+	awardRepo      domain.AwardRepository
+	rfqEvents      rfqDomain.EventPublisher
+	contractEvents contractDomain.EventPublisher
+	logger         *slog.Logger
+}
+
+// NewAwardService creates a new procurement award service
+func NewAwardService(
+	rfqRepo rfqDomain.RFQRepository,
+	quoteRepo quoteDomain.QuoteRepository,
+	comparisonRepo comparisonDomain.Repository,
+	contractRepo contractDomain.Repository,
+	supplierRepo supplierDomain.SupplierRepository,
+	awardRepo domain.AwardRepository,
+	rfqEvents rfqDomain.EventPublisher,
+	contractEvents contractDomain.EventPublisher,
+	logger *slog.Logger,
+) *AwardService {
+	return &AwardService{
+		rfqRepo:        rfqRepo,
+		quoteRepo:      quoteRepo,
+		comparisonRepo: comparisonRepo,
+		contractRepo:   contractRepo,
+		supplierRepo:   supplierRepo,
+		awardRepo:      awardRepo,
+		rfqEvents:      rfqEvents,
+		contractEvents: contractEvents,
+		logger:         logger.With(slog.String("component", "award_service")),
+	}
+}
+
+// AwardRequest awards the RFQ of a completed comparison
+type AwardRequest struct {
+	ComparisonID       string     `json:"comparison_id"`
+	QuoteID            string     `json:"quote_id,omitempty"` // Defaults to the comparison's best overall quote
+	Reason             string     `json:"reason,omitempty"`
+	ContractStartDate  *time.Time `json:"contract_start_date,omitempty"`
+	ContractEndDate    *time.Time `json:"contract_end_date,omitempty"`
+	TermsAndConditions string     `json:"terms_and_conditions,omitempty"`
+	Notes              string     `json:"notes,omitempty"`
+}
+
+// AwardResult is the outcome of a successful award
+type AwardResult struct {
+	Award    *domain.Award            `json:"award"`
+	Contract *contractDomain.Contract `json:"contract"`
+	Rejected []string                 `json:"rejected_quote_ids"`
+}
+
+// compensation undoes one completed saga step
+type compensation struct {
+	step     string
+	entityID string
+	undo     func(ctx context.Context) error
+}
+
+// saga tracks completed steps of one award run
+type saga struct {
+	award         *domain.Award
+	compensations []compensation
+}
+
+// Award runs the procurement saga: close the RFQ if still published, accept
+// the winning quote, reject the other open quotes, mark the RFQ awarded and
+// draft a contract from the winning quote's items. Events are published only
+// after every step succeeded.
+func (s *AwardService) Award(ctx context.Context, tenantID, awardedBy string, req AwardRequest) (*AwardResult, error) {
+	comparison, err := s.comparisonRepo.GetByID(ctx, tenantID, req.ComparisonID)
+	if err != nil {
+		return nil, err
+	}
+	if comparison.Status != comparisonDomain.ComparisonStatusCompleted {
+		return nil, domain.ErrComparisonNotCompleted
+	}
+
+	quoteID := req.QuoteID
+	if quoteID == "" {
+		quoteID = comparison.BestOverallQuote
+	}
+	if quoteID == "" {
+		return nil, domain.ErrNoWinningQuote
+	}
+	if !comparison.IsQuoteIncluded(quoteID) {
+		return nil, fmt.Errorf("%w: %v", domain.ErrQuoteNotAwardable, comparisonDomain.ErrQuoteNotInComparison)
+	}
+
+	rfq, err := s.rfqRepo.GetByID(ctx, comparison.RFQID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	switch rfq.Status {
+	case rfqDomain.RFQStatusAwarded:
+		return nil, domain.ErrRFQAlreadyAwarded
+	case rfqDomain.RFQStatusPublished, rfqDomain.RFQStatusClosed:
+	default:
+		return nil, fmt.Errorf("%w: %s", domain.ErrRFQNotAwardable, rfq.Status)
+	}
+
+	quotes, err := s.quoteRepo.GetByRFQID(ctx, rfq.ID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	var winner *quoteDomain.Quote
+	for _, q := range quotes {
+		if q.ID == quoteID {
+			winner = q
+		}
+	}
+	if winner == nil {
+		return nil, fmt.Errorf("%w: quote %s does not belong to rfq %s", domain.ErrQuoteNotAwardable, quoteID, rfq.ID)
+	}
+	if winner.Status != quoteDomain.QuoteStatusSubmitted && winner.Status != quoteDomain.QuoteStatusUnderReview {
+		return nil, fmt.Errorf("%w: quote is %s", domain.ErrQuoteNotAwardable, winner.Status)
+	}
+	if winner.IsExpired() {
+		return nil, fmt.Errorf("%w: %v", domain.ErrQuoteNotAwardable, quoteDomain.ErrQuoteExpired)
+	}
+
+	// The contract number is reserved before any state changes, so failing
+	// to get one leaves nothing to undo
+	contractNumber, err := s.contractRepo.NextContractNumber(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Recording the award claims the RFQ: the repository allows one
+	// in-progress or completed award per RFQ, so a concurrent or repeated
+	// request stops here before changing any state
+	award := domain.NewAward(tenantID, rfq.ID, comparison.ID, winner.ID, winner.SupplierID, awardedBy, req.Reason)
+	if err := s.awardRepo.Create(ctx, award); err != nil {
+		if errors.Is(err, domain.ErrAwardInProgress) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to record award: %w", err)
+	}
+	sg := &saga{award: award}
+
+	s.logger.Info("Starting procurement award",
+		slog.String("award_id", award.ID),
+		slog.String("rfq_id", rfq.ID),
+		slog.String("quote_id", winner.ID))
+
+	// Step: close the RFQ if it is still open for quotes
+	if rfq.Status == rfqDomain.RFQStatusPublished {
+		prev := *rfq
+		if err := s.run(ctx, sg, domain.StepCloseRFQ, rfq.ID, "rfq closed for quotes", func() error {
+			if err := rfq.Close(); err != nil {
+				return err
+			}
+			return s.rfqRepo.Update(ctx, rfq)
+		}, func(ctx context.Context) error {
+			rfq.Status, rfq.ClosedAt, rfq.UpdatedAt = prev.Status, prev.ClosedAt, time.Now()
+			return s.rfqRepo.Update(ctx, rfq)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Step: accept the winning quote
+	notes := req.Reason
+	if notes == "" {
+		notes = "Awarded from comparison " + comparison.ID
+	}
+	if err := s.run(ctx, sg, domain.StepAcceptQuote, winner.ID, "quote accepted", func() error {
+		return s.transitionQuote(ctx, winner, func(q *quoteDomain.Quote) error { return q.Accept(awardedBy, notes) })
+	}, s.restoreQuote(winner)); err != nil {
+		return nil, err
+	}
+
+	// Step: reject the other open quotes
+	rejected := []string{}
+	for _, q := range quotes {
+		if q.ID == winner.ID || (q.Status != quoteDomain.QuoteStatusSubmitted && q.Status != quoteDomain.QuoteStatusUnderReview) {
+			continue
+		}
+		q := q
+		if err := s.run(ctx, sg, domain.StepRejectQuote, q.ID, "quote rejected", func() error {
+			return s.transitionQuote(ctx, q, func(q *quoteDomain.Quote) error {
+				return q.Reject(awardedBy, "RFQ awarded to another supplier")
+			})
+		}, s.restoreQuote(q)); err != nil {
+			return nil, err
+		}
+		rejected = append(rejected, q.ID)
+	}
+
+	// Step: mark the RFQ awarded
+	closed := *rfq
+	if err := s.run(ctx, sg, domain.StepAwardRFQ, rfq.ID, "rfq awarded to supplier "+winner.SupplierID, func() error {
+		if err := rfq.Award(); err != nil {
+			return err
+		}
+		return s.rfqRepo.Update(ctx, rfq)
+	}, func(ctx context.Context) error {
+		rfq.Status, rfq.UpdatedAt = closed.Status, time.Now()
+		return s.rfqRepo.Update(ctx, rfq)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Step: draft the contract from the winning quote
+	contract := s.buildContract(ctx, tenantID, awardedBy, contractNumber, winner, req)
+	if err := s.run(ctx, sg, domain.StepDraftContract, contract.ID, "contract "+contract.ContractNumber+" drafted", func() error {
+		return s.contractRepo.Create(ctx, contract)
+	}, func(ctx context.Context) error {
+		return s.contractRepo.Delete(ctx, tenantID, contract.ID)
+	}); err != nil {
+		return nil, err
+	}
+
+	award.Complete(contract.ID)
+	s.publishEvents(ctx, award, rfq, contract)
+	if err := s.awardRepo.Update(ctx, award); err != nil {
+		s.logger.Error("Failed to save award trail", slog.String("award_id", award.ID), slog.String("error", err.Error()))
+	}
+
+	s.logger.Info("Procurement award completed",
+		slog.String("award_id", award.ID),
+		slog.String("rfq_id", rfq.ID),
+		slog.String("contract_id", contract.ID),
+		slog.Int("rejected_quotes", len(rejected)))
+
+	return &AwardResult{Award: award, Contract: contract, Rejected: rejected}, nil
+}
+
+// run executes one saga step. On failure it compensates every completed step
+// in reverse order and returns the step error.
+func (s *AwardService) run(ctx context.Context, sg *saga, step, entityID, detail string, do func() error, undo func(ctx context.Context) error) error {
+	if err := do(); err != nil {
+		sg.award.RecordStep(step, domain.StepStatusFailed, entityID, "", err)
+		s.compensate(ctx, sg, fmt.Errorf("%s failed: %w", step, err))
+		return fmt.Errorf("award failed at %s: %w", step, err)
+	}
+	sg.award.RecordStep(step, domain.StepStatusCompleted, entityID, detail, nil)
+	sg.compensations = append(sg.compensations, compensation{step: step, entityID: entityID, undo: undo})
+	if err := s.awardRepo.Update(ctx, sg.award); err != nil {
+		s.logger.Warn("Failed to save award trail", slog.String("award_id", sg.award.ID), slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+// compensate undoes completed steps in reverse order. Compensation uses a
+// context detached from cancellation so a dropped request does not leave the
+// saga half applied.
+func (s *AwardService) compensate(ctx context.Context, sg *saga, cause error) {
+	ctx = context.WithoutCancel(ctx)
+	compensated := true
+	for i := len(sg.compensations) - 1; i >= 0; i-- {
+		c := sg.compensations[i]
+		if err := c.undo(ctx); err != nil {
+			compensated = false
+			sg.award.RecordStep(c.step, domain.StepStatusCompensationFailed, c.entityID, "", err)
+			s.logger.Error("Award compensation failed",
+				slog.String("award_id", sg.award.ID),
+				slog.String("step", c.step),
+				slog.String("entity_id", c.entityID),
+				slog.String("error", err.Error()))
+			continue
+		}
+		sg.award.RecordStep(c.step, domain.StepStatusCompensated, c.entityID, "", nil)
+	}
+	sg.award.Fail(cause, compensated)
+	if err := s.awardRepo.Update(ctx, sg.award); err != nil {
+		s.logger.Error("Failed to save award trail", slog.String("award_id", sg.award.ID), slog.String("error", err.Error()))
+	}
+}
+
+// transitionQuote applies a status change to a quote and persists it
+func (s *AwardService) transitionQuote(ctx context.Context, q *quoteDomain.Quote, change func(*quoteDomain.Quote) error) error {
+	if err := change(q); err != nil {
+		return err
+	}
+	return s.quoteRepo.Update(ctx, q)
+}
+
+// restoreQuote captures the review state of a quote and returns a compensation restoring it
+func (s *AwardService) restoreQuote(q *quoteDomain.Quote) func(ctx context.Context) error {
+	prev := *q
+	return func(ctx context.Context) error {
+		q.Status = prev.Status
+		q.ReviewedAt = prev.ReviewedAt
+		q.ReviewedBy = prev.ReviewedBy
+		q.ReviewNotes = prev.ReviewNotes
+		q.RejectionReason = prev.RejectionReason
+		q.UpdatedAt = time.Now()
+		return s.quoteRepo.Update(ctx, q)
+	}
+}
+
+// buildContract drafts a contract from the winning quote, copying its items
+func (s *AwardService) buildContract(ctx context.Context, tenantID, createdBy, contractNumber string, quote *quoteDomain.Quote, req AwardRequest) *contractDomain.Contract {
+	contract := contractDomain.NewContract(tenantID, quote.RFQID, quote.ID, quote.SupplierID, s.supplierName(ctx, tenantID, quote.SupplierID), createdBy)
+	contract.ID = ksuid.New().String()
+	contract.ContractNumber = contractNumber
+	if quote.Currency != "" {
+		contract.Currency = quote.Currency
+	}
+
+	contract.StartDate = time.Now()
+	if req.ContractStartDate != nil {
+		contract.StartDate = *req.ContractStartDate
+	}
+	contract.EndDate = contract.StartDate.AddDate(defaultContractTerm, 0, 0)
+	if req.ContractEndDate != nil {
+		contract.EndDate = *req.ContractEndDate
+	}
+
+	contract.PaymentTerms = quote.PaymentTerms
+	contract.DeliveryTerms = quote.DeliveryTerms
+	contract.WarrantyTerms = quote.WarrantyTerms
+	contract.TermsAndConditions = req.TermsAndConditions
+	contract.Notes = req.Notes
+
+	for _, item := range quote.Items {
+		contract.Items = append(contract.Items, contractDomain.ContractItem{
+			ID:               ksuid.New().String(),
+			EquipmentID:      item.EquipmentID,
+			EquipmentName:    item.EquipmentName,
+			Quantity:         item.Quantity,
+			UnitPrice:        item.UnitPrice,
+			TotalPrice:       item.TotalPrice,
+			ManufacturerName: item.ManufacturerName,
+			ModelNumber:      item.ModelNumber,
+			Specifications:   item.Specifications,
+			WarrantyPeriod:   quote.WarrantyTerms,
+		})
+		contract.TaxAmount += item.TaxAmount
+	}
+	contract.CalculateTotals()
+	return contract
+}
+
+// supplierName looks up the supplier's company name, falling back to the supplier ID
+func (s *AwardService) supplierName(ctx context.Context, tenantID, supplierID string) string {
+	if s.supplierRepo != nil {
+		if supplier, err := s.supplierRepo.GetByID(ctx, supplierID, tenantID); err == nil && supplier.CompanyName != "" {
+			return supplier.CompanyName
+		}
+	}
+	return supplierID
+}
+
+// publishEvents publishes rfq.awarded and contract.drafted. Publishing is best
+// effort: the award is already committed, so failures are logged and recorded.
+func (s *AwardService) publishEvents(ctx context.Context, award *domain.Award, rfq *rfqDomain.RFQ, contract *contractDomain.Contract) {
+	var errs []error
+	if s.rfqEvents != nil {
+		if err := s.rfqEvents.Publish(ctx, rfqDomain.NewRFQAwardedEvent(rfq, award.SupplierID, award.AwardedBy)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.contractEvents != nil {
+		if err := s.contractEvents.Publish(ctx, contractDomain.NewContractDraftedEvent(contract)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		s.logger.Error("Failed to publish award events", slog.String("award_id", award.ID), slog.String("error", err.Error()))
+		award.RecordStep(domain.StepPublishEvents, domain.StepStatusFailed, rfq.ID, "", err)
+		return
+	}
+	award.RecordStep(domain.StepPublishEvents, domain.StepStatusCompleted, rfq.ID, "rfq.awarded, contract.drafted", nil)
+}
+
+// GetAward retrieves an award with its audit trail
+func (s *AwardService) GetAward(ctx context.Context, tenantID, id string) (*domain.Award, error) {
+	return s.awardRepo.GetByID(ctx, tenantID, id)
+}
+
+// ListAwardsByRFQ lists award attempts for an RFQ
+func (s *AwardService) ListAwardsByRFQ(ctx context.Context, tenantID, rfqID string) ([]*domain.Award, error) {
+	return s.awardRepo.ListByRFQ(ctx, tenantID, rfqID)
+}
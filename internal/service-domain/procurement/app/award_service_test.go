@@ -0,0 +1,228 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	comparisonDomain "github.com/aby-med/medical-platform/internal/service-domain/comparison/domain"
+	contractDomain "github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	quoteDomain "github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+)
+
+// Fakes embed the repository interfaces and implement only what the saga uses
+
+type fakeRFQRepo struct {
+	rfqDomain.RFQRepository
+	rfqs map[string]rfqDomain.RFQ
+}
+
+func (f *fakeRFQRepo) GetByID(ctx context.Context, id, tenantID string) (*rfqDomain.RFQ, error) {
+	r, ok := f.rfqs[id]
+	if !ok {
+		return nil, rfqDomain.ErrRFQNotFound
+	}
+	return &r, nil
+}
+
+func (f *fakeRFQRepo) Update(ctx context.Context, r *rfqDomain.RFQ) error {
+	f.rfqs[r.ID] = *r
+	return nil
+}
+
+type fakeQuoteRepo struct {
+	quoteDomain.QuoteRepository
+	quotes map[string]quoteDomain.Quote
+}
+
+func (f *fakeQuoteRepo) GetByRFQID(ctx context.Context, rfqID, tenantID string) ([]*quoteDomain.Quote, error) {
+	result := []*quoteDomain.Quote{}
+	for _, q := range f.quotes {
+		if q.RFQID == rfqID {
+			q := q
+			result = append(result, &q)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeQuoteRepo) Update(ctx context.Context, q *quoteDomain.Quote) error {
+	f.quotes[q.ID] = *q
+	return nil
+}
+
+type fakeComparisonRepo struct {
+	comparisonDomain.Repository
+	comparison *comparisonDomain.Comparison
+}
+
+func (f *fakeComparisonRepo) GetByID(ctx context.Context, tenantID, id string) (*comparisonDomain.Comparison, error) {
+	return f.comparison, nil
+}
+
+type fakeContractRepo struct {
+	contractDomain.Repository
+	createErr error
+	contracts map[string]*contractDomain.Contract
+}
+
+func (f *fakeContractRepo) NextContractNumber(ctx context.Context, tenantID string) (string, error) {
+	return fmt.Sprintf("CT-20261018-%04d", len(f.contracts)+1), nil
+}
+
+func (f *fakeContractRepo) Create(ctx context.Context, c *contractDomain.Contract) error {
+	if f.createErr != nil {
+		return f.createErr
+	}
+	f.contracts[c.ID] = c
+	return nil
+}
+
+func (f *fakeContractRepo) Delete(ctx context.Context, tenantID, id string) error {
+	delete(f.contracts, id)
+	return nil
+}
+
+// fakeAwardRepo enforces one in-progress or completed award per RFQ like the unique index
+type fakeAwardRepo struct {
+	awards map[string]*domain.Award
+}
+
+func (f *fakeAwardRepo) Create(ctx context.Context, a *domain.Award) error {
+	for _, existing := range f.awards {
+		if existing.RFQID == a.RFQID &&
+			(existing.Status == domain.AwardStatusInProgress || existing.Status == domain.AwardStatusCompleted) {
+			return domain.ErrAwardInProgress
+		}
+	}
+	f.awards[a.ID] = a
+	return nil
+}
+
+func (f *fakeAwardRepo) Update(ctx context.Context, a *domain.Award) error {
+	f.awards[a.ID] = a
+	return nil
+}
+
+func (f *fakeAwardRepo) GetByID(ctx context.Context, tenantID, id string) (*domain.Award, error) {
+	a, ok := f.awards[id]
+	if !ok {
+		return nil, domain.ErrAwardNotFound
+	}
+	return a, nil
+}
+
+func (f *fakeAwardRepo) ListByRFQ(ctx context.Context, tenantID, rfqID string) ([]*domain.Award, error) {
+	return nil, nil
+}
+
+type awardFixture struct {
+	service   *AwardService
+	rfqs      *fakeRFQRepo
+	quotes    *fakeQuoteRepo
+	contracts *fakeContractRepo
+	awards    *fakeAwardRepo
+}
+
+func newAwardFixture() *awardFixture {
+	validUntil := time.Now().Add(24 * time.Hour)
+	f := &awardFixture{
+		rfqs: &fakeRFQRepo{rfqs: map[string]rfqDomain.RFQ{
+			"rfq-1": {ID: "rfq-1", TenantID: "t1", Status: rfqDomain.RFQStatusPublished},
+		}},
+		quotes: &fakeQuoteRepo{quotes: map[string]quoteDomain.Quote{
+			"q-1": {ID: "q-1", RFQID: "rfq-1", SupplierID: "s-1", Status: quoteDomain.QuoteStatusSubmitted, ValidUntil: validUntil},
+			"q-2": {ID: "q-2", RFQID: "rfq-1", SupplierID: "s-2", Status: quoteDomain.QuoteStatusUnderReview, ValidUntil: validUntil},
+		}},
+		contracts: &fakeContractRepo{contracts: map[string]*contractDomain.Contract{}},
+		awards:    &fakeAwardRepo{awards: map[string]*domain.Award{}},
+	}
+	comparisons := &fakeComparisonRepo{comparison: &comparisonDomain.Comparison{
+		ID:               "cmp-1",
+		RFQID:            "rfq-1",
+		QuoteIDs:         []string{"q-1", "q-2"},
+		Status:           comparisonDomain.ComparisonStatusCompleted,
+		BestOverallQuote: "q-1",
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	f.service = NewAwardService(f.rfqs, f.quotes, comparisons, f.contracts, nil, f.awards, nil, nil, logger)
+	return f
+}
+
+func TestAwardCompensatesWhenContractDraftFails(t *testing.T) {
+	f := newAwardFixture()
+	f.contracts.createErr = errors.New("database unavailable")
+
+	if _, err := f.service.Award(context.Background(), "t1", "buyer-1", AwardRequest{ComparisonID: "cmp-1"}); err == nil {
+		t.Fatal("expected the award to fail")
+	}
+
+	if status := f.rfqs.rfqs["rfq-1"].Status; status != rfqDomain.RFQStatusPublished {
+		t.Errorf("rfq status = %s, want restored to published", status)
+	}
+	if f.rfqs.rfqs["rfq-1"].ClosedAt != nil {
+		t.Error("rfq closed_at should be restored")
+	}
+	if status := f.quotes.quotes["q-1"].Status; status != quoteDomain.QuoteStatusSubmitted {
+		t.Errorf("winning quote status = %s, want restored to submitted", status)
+	}
+	if status := f.quotes.quotes["q-2"].Status; status != quoteDomain.QuoteStatusUnderReview {
+		t.Errorf("losing quote status = %s, want restored to under_review", status)
+	}
+	if len(f.contracts.contracts) != 0 {
+		t.Errorf("no contract should remain, got %d", len(f.contracts.contracts))
+	}
+	for _, a := range f.awards.awards {
+		if a.Status != domain.AwardStatusCompensated {
+			t.Errorf("award status = %s, want compensated", a.Status)
+		}
+	}
+
+	// A compensated award does not block a retry
+	f.contracts.createErr = nil
+	result, err := f.service.Award(context.Background(), "t1", "buyer-1", AwardRequest{ComparisonID: "cmp-1"})
+	if err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+	if result.Award.Status != domain.AwardStatusCompleted {
+		t.Errorf("retry award status = %s, want completed", result.Award.Status)
+	}
+}
+
+func TestAwardRejectsConcurrentAward(t *testing.T) {
+	f := newAwardFixture()
+	running := domain.NewAward("t1", "rfq-1", "cmp-1", "q-2", "s-2", "buyer-2", "")
+	f.awards.awards[running.ID] = running
+
+	_, err := f.service.Award(context.Background(), "t1", "buyer-1", AwardRequest{ComparisonID: "cmp-1"})
+	if !errors.Is(err, domain.ErrAwardInProgress) {
+		t.Fatalf("err = %v, want ErrAwardInProgress", err)
+	}
+	if status := f.rfqs.rfqs["rfq-1"].Status; status != rfqDomain.RFQStatusPublished {
+		t.Errorf("rfq status = %s, want untouched", status)
+	}
+	if status := f.quotes.quotes["q-1"].Status; status != quoteDomain.QuoteStatusSubmitted {
+		t.Errorf("quote status = %s, want untouched", status)
+	}
+}
+
+func TestAwardRejectsRepeatAward(t *testing.T) {
+	f := newAwardFixture()
+	if _, err := f.service.Award(context.Background(), "t1", "buyer-1", AwardRequest{ComparisonID: "cmp-1"}); err != nil {
+		t.Fatalf("first award failed: %v", err)
+	}
+
+	_, err := f.service.Award(context.Background(), "t1", "buyer-1", AwardRequest{ComparisonID: "cmp-1"})
+	if !errors.Is(err, domain.ErrRFQAlreadyAwarded) {
+		t.Fatalf("err = %v, want ErrRFQAlreadyAwarded", err)
+	}
+	if len(f.contracts.contracts) != 1 {
+		t.Errorf("contracts = %d, want 1", len(f.contracts.contracts))
+	}
+}
@@ -0,0 +1,293 @@
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	quoteDomain "github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+)
+
+// ExchangeRateRequest is one exchange rate to record
+type ExchangeRateRequest struct {
+	FromCurrency string        `json:"from_currency"`
+	ToCurrency   string        `json:"to_currency"`
+	Rate         money.Decimal `json:"rate"`      // Units of to_currency per unit of from_currency
+	RateDate     string        `json:"rate_date"` // YYYY-MM-DD or RFC3339
+}
+
+// SetExchangeRatesRequest records rates manually
+type SetExchangeRatesRequest struct {
+	Rates []ExchangeRateRequest `json:"rates"`
+}
+
+// ExchangeRateImportResult summarizes a CSV import of exchange rates
+type ExchangeRateImportResult struct {
+	TotalRows    int      `json:"total_rows"`
+	SuccessCount int      `json:"success_count"`
+	FailureCount int      `json:"failure_count"`
+	Errors       []string `json:"errors"`
+}
+
+// QuoteCurrencyLine is one quote of a currency report
+type QuoteCurrencyLine struct {
+	QuoteID     string             `json:"quote_id"`
+	QuoteNumber string             `json:"quote_number"`
+	SupplierID  string             `json:"supplier_id"`
+	Status      string             `json:"status"`
+	Conversion  *domain.Conversion `json:"conversion,omitempty"`
+	Error       string             `json:"error,omitempty"` // Why the quote could not be converted
+}
+
+// RFQCurrencyReport shows an RFQ's quotes in its base currency
+type RFQCurrencyReport struct {
+	RFQID    string              `json:"rfq_id"`
+	Currency string              `json:"currency"`
+	RateDate time.Time           `json:"rate_date"`
+	Quotes   []QuoteCurrencyLine `json:"quotes"`
+}
+
+// ExchangeRateService maintains exchange rates and converts amounts between currencies
+type ExchangeRateService struct {
+	repo   domain.ExchangeRateRepository
+	rfqs   rfqDomain.RFQRepository
+	quotes quoteDomain.QuoteRepository
+	logger *slog.Logger
+}
+
+// NewExchangeRateService creates a new exchange rate service
+func NewExchangeRateService(
+	repo domain.ExchangeRateRepository,
+	rfqs rfqDomain.RFQRepository,
+	quotes quoteDomain.QuoteRepository,
+	logger *slog.Logger,
+) *ExchangeRateService {
+	return &ExchangeRateService{
+		repo:   repo,
+		rfqs:   rfqs,
+		quotes: quotes,
+		logger: logger.With(slog.String("service", "exchange_rate")),
+	}
+}
+
+// SetRates records rates manually, replacing rates of the same pair and day
+func (s *ExchangeRateService) SetRates(ctx context.Context, tenantID, createdBy string, req SetExchangeRatesRequest) ([]*domain.ExchangeRate, error) {
+	if len(req.Rates) == 0 {
+		return nil, fmt.Errorf("%w: at least one rate is required", domain.ErrInvalidExchangeRate)
+	}
+
+	rates := make([]*domain.ExchangeRate, 0, len(req.Rates))
+	for i, r := range req.Rates {
+		rate, err := newRate(tenantID, r, domain.RateSourceManual, createdBy)
+		if err != nil {
+			return nil, fmt.Errorf("rate %d: %w", i+1, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := s.repo.Upsert(ctx, rates); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Exchange rates recorded", slog.String("tenant_id", tenantID), slog.Int("count", len(rates)))
+	return rates, nil
+}
+
+// ListRates lists recorded rates, newest first
+func (s *ExchangeRateService) ListRates(ctx context.Context, tenantID string, filter domain.ExchangeRateFilter) ([]*domain.ExchangeRate, error) {
+	return s.repo.List(ctx, tenantID, filter)
+}
+
+// DeleteRate removes a recorded rate
+func (s *ExchangeRateService) DeleteRate(ctx context.Context, tenantID, id string) error {
+	return s.repo.Delete(ctx, tenantID, id)
+}
+
+// ImportRatesFromCSV imports exchange rates from a CSV stream. Expected
+// header columns (any order): from_currency, to_currency, rate and
+// rate_date (YYYY-MM-DD). Valid rows are stored even when others fail.
+func (s *ExchangeRateService) ImportRatesFromCSV(ctx context.Context, tenantID string, r io.Reader, createdBy string) (*ExchangeRateImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	idx := map[string]int{}
+	for i, h := range header {
+		idx[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	for _, col := range []string{"from_currency", "to_currency", "rate", "rate_date"} {
+		if _, ok := idx[col]; !ok {
+			return nil, fmt.Errorf("csv requires from_currency, to_currency, rate and rate_date columns")
+		}
+	}
+
+	result := &ExchangeRateImportResult{Errors: []string{}}
+	rates := []*domain.ExchangeRate{}
+	rowNum := 1
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		rowNum++
+		result.TotalRows++
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: read error: %v", rowNum, err))
+			result.FailureCount++
+			continue
+		}
+
+		get := func(name string) string {
+			if i := idx[name]; i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		value, err := money.Parse(get("rate"))
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: invalid rate %q", rowNum, get("rate")))
+			result.FailureCount++
+			continue
+		}
+		rate, err := newRate(tenantID, ExchangeRateRequest{
+			FromCurrency: get("from_currency"),
+			ToCurrency:   get("to_currency"),
+			Rate:         value,
+			RateDate:     get("rate_date"),
+		}, domain.RateSourceCSV, createdBy)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: %v", rowNum, err))
+			result.FailureCount++
+			continue
+		}
+		rates = append(rates, rate)
+	}
+
+	if len(rates) > 0 {
+		if err := s.repo.Upsert(ctx, rates); err != nil {
+			return nil, err
+		}
+	}
+	result.SuccessCount = len(rates)
+
+	s.logger.Info("Exchange rates imported",
+		slog.String("tenant_id", tenantID),
+		slog.Int("total", result.TotalRows),
+		slog.Int("success", result.SuccessCount),
+		slog.Int("failure", result.FailureCount))
+	return result, nil
+}
+
+// Rate returns the rate converting from one currency to another on a day:
+// the latest rate of the pair recorded on or before it, else the inverse of
+// the latest rate of the reverse pair
+func (s *ExchangeRateService) Rate(ctx context.Context, tenantID, from, to string, day time.Time) (*domain.ExchangeRate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	day = domain.TruncateToDay(day)
+	if from == to {
+		return &domain.ExchangeRate{TenantID: tenantID, FromCurrency: from, ToCurrency: to, Rate: money.NewFromInt(1), RateDate: day}, nil
+	}
+
+	rate, err := s.repo.RateOn(ctx, tenantID, from, to, day)
+	if err == nil || !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		return rate, err
+	}
+	reverse, err := s.repo.RateOn(ctx, tenantID, to, from, day)
+	if err != nil {
+		if errors.Is(err, domain.ErrExchangeRateNotFound) {
+			return nil, fmt.Errorf("%w: %s to %s on %s", domain.ErrExchangeRateNotFound, from, to, day.Format("2006-01-02"))
+		}
+		return nil, err
+	}
+	return reverse.Inverse(), nil
+}
+
+// Convert converts an amount between currencies at the rate of a day
+func (s *ExchangeRateService) Convert(ctx context.Context, tenantID string, amount money.Decimal, from, to string, day time.Time) (*domain.Conversion, error) {
+	fromCode, err := money.NormalizeCurrency(from)
+	if err != nil {
+		return nil, err
+	}
+	toCode, err := money.NormalizeCurrency(to)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := s.Rate(ctx, tenantID, fromCode, toCode, day)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Conversion{
+		Amount:     amount,
+		Currency:   fromCode,
+		Converted:  money.Convert(amount, rate.Rate, toCode),
+		ToCurrency: toCode,
+		Rate:       rate.Rate,
+		RateDate:   rate.RateDate,
+	}, nil
+}
+
+// RFQCurrencyReport converts every quote of an RFQ to the RFQ's base
+// currency at its rate date. Quotes without a rate are listed with the error.
+func (s *ExchangeRateService) RFQCurrencyReport(ctx context.Context, tenantID, rfqID string) (*RFQCurrencyReport, error) {
+	rfq, err := s.rfqs.GetByID(ctx, rfqID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	quotes, err := s.quotes.GetByRFQID(ctx, rfqID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RFQCurrencyReport{
+		RFQID:    rfq.ID,
+		Currency: rfq.Currency,
+		RateDate: domain.TruncateToDay(rfq.ConversionDate()),
+		Quotes:   []QuoteCurrencyLine{},
+	}
+	for _, q := range quotes {
+		line := QuoteCurrencyLine{QuoteID: q.ID, QuoteNumber: q.QuoteNumber, SupplierID: q.SupplierID, Status: string(q.Status)}
+		conversion, err := s.Convert(ctx, tenantID, money.NewFromFloat(q.TotalAmount), q.Currency, rfq.Currency, report.RateDate)
+		if err != nil {
+			if !errors.Is(err, domain.ErrExchangeRateNotFound) && !errors.Is(err, money.ErrUnknownCurrency) {
+				return nil, err
+			}
+			line.Error = err.Error()
+		}
+		line.Conversion = conversion
+		report.Quotes = append(report.Quotes, line)
+	}
+	return report, nil
+}
+
+// newRate validates a rate request
+func newRate(tenantID string, r ExchangeRateRequest, source, createdBy string) (*domain.ExchangeRate, error) {
+	day, err := parseRateDate(r.RateDate)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewExchangeRate(tenantID, r.FromCurrency, r.ToCurrency, r.Rate, day, source, createdBy)
+}
+
+// parseRateDate accepts YYYY-MM-DD or RFC3339
+func parseRateDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return domain.TruncateToDay(t), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: rate date %q must be YYYY-MM-DD", domain.ErrInvalidExchangeRate, value)
+}
@@ -0,0 +1,565 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	quoteApp "github.com/aby-med/medical-platform/internal/service-domain/quote/app"
+	quoteDomain "github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+	supplierDomain "github.com/aby-med/medical-platform/internal/service-domain/supplier/domain"
+)
+
+// SupplierIdentity is the supplier a portal request acts for
+type SupplierIdentity struct {
+	TenantID   string
+	SupplierID string
+	UserID     string
+}
+
+// PortalRFQ is the supplier-facing view of an RFQ. It leaves out internal
+// notes and the other invited suppliers.
+type PortalRFQ struct {
+	ID               string                  `json:"id"`
+	RFQNumber        string                  `json:"rfq_number"`
+	Title            string                  `json:"title"`
+	Description      string                  `json:"description"`
+	Priority         rfqDomain.RFQPriority   `json:"priority"`
+	Status           rfqDomain.RFQStatus     `json:"status"`
+	Version          int                     `json:"version"`
+	Items            []rfqDomain.RFQItem     `json:"items"`
+	DeliveryTerms    rfqDomain.DeliveryTerms `json:"delivery_terms"`
+	PaymentTerms     rfqDomain.PaymentTerms  `json:"payment_terms"`
+	PublishedAt      *time.Time              `json:"published_at,omitempty"`
+	ResponseDeadline time.Time               `json:"response_deadline"`
+	OpenForQuotes    bool                    `json:"open_for_quotes"`
+}
+
+// PortalInvitation is an RFQ invitation as seen by the invited supplier
+type PortalInvitation struct {
+	Invitation rfqDomain.RFQInvitation `json:"invitation"`
+	RFQ        PortalRFQ               `json:"rfq"`
+}
+
+// PortalQuestion is a Q&A entry as seen by an invited supplier. The asking
+// supplier is only revealed to itself.
+type PortalQuestion struct {
+	ID         string     `json:"id"`
+	Question   string     `json:"question"`
+	AskedAt    time.Time  `json:"asked_at"`
+	Answer     string     `json:"answer,omitempty"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+	Mine       bool       `json:"mine"`
+}
+
+// PortalQuoteRequest is a supplier's quote for an RFQ
+type PortalQuoteRequest struct {
+	ValidUntil    time.Time                   `json:"valid_until"`
+	Currency      string                      `json:"currency,omitempty"`
+	DeliveryTerms string                      `json:"delivery_terms"`
+	PaymentTerms  string                      `json:"payment_terms"`
+	WarrantyTerms string                      `json:"warranty_terms"`
+	Notes         string                      `json:"notes"`
+	Items         []quoteApp.QuoteItemRequest `json:"items"`
+	Submit        bool                        `json:"submit"` // Submit immediately instead of keeping a draft
+}
+
+// PortalService serves the supplier portal: invitations, clarification
+// questions and quote submission, always scoped to the calling supplier
+type PortalService struct {
+	rfqRepo      rfqDomain.RFQRepository
+	quoteRepo    quoteDomain.QuoteRepository
+	supplierRepo supplierDomain.SupplierRepository
+	rfqEvents    rfqDomain.EventPublisher
+	logger       *slog.Logger
+}
+
+// NewPortalService creates a new supplier portal service
+func NewPortalService(
+	rfqRepo rfqDomain.RFQRepository,
+	quoteRepo quoteDomain.QuoteRepository,
+	supplierRepo supplierDomain.SupplierRepository,
+	rfqEvents rfqDomain.EventPublisher,
+	logger *slog.Logger,
+) *PortalService {
+	return &PortalService{
+		rfqRepo:      rfqRepo,
+		quoteRepo:    quoteRepo,
+		supplierRepo: supplierRepo,
+		rfqEvents:    rfqEvents,
+		logger:       logger.With(slog.String("component", "supplier_portal_service")),
+	}
+}
+
+// ResolveSupplier finds the supplier record linked to the caller's organization
+func (s *PortalService) ResolveSupplier(ctx context.Context, tenantID, organizationID string) (*supplierDomain.Supplier, error) {
+	supplier, err := s.supplierRepo.GetByOrganizationID(ctx, organizationID, tenantID)
+	if err != nil {
+		if errors.Is(err, supplierDomain.ErrSupplierNotFound) {
+			return nil, domain.ErrSupplierNotLinked
+		}
+		return nil, err
+	}
+	// Restricted suppliers keep working on RFQs they were already invited to
+	if supplier.Status != supplierDomain.SupplierStatusActive && supplier.Status != supplierDomain.SupplierStatusRestricted {
+		return nil, fmt.Errorf("%w: supplier is %s", domain.ErrSupplierNotLinked, supplier.Status)
+	}
+	return supplier, nil
+}
+
+// ListInvitations returns the supplier's invitations, optionally filtered by status
+func (s *PortalService) ListInvitations(ctx context.Context, sup SupplierIdentity, status string) ([]PortalInvitation, error) {
+	invitations, err := s.rfqRepo.ListInvitationsBySupplier(ctx, sup.TenantID, sup.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []PortalInvitation{}
+	for _, inv := range invitations {
+		if status != "" && inv.Status != status {
+			continue
+		}
+		rfq, err := s.rfqRepo.GetByID(ctx, inv.RFQID, sup.TenantID)
+		if err != nil {
+			if errors.Is(err, rfqDomain.ErrRFQNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if rfq.Status == rfqDomain.RFQStatusDraft {
+			continue
+		}
+		result = append(result, PortalInvitation{Invitation: inv, RFQ: toPortalRFQ(rfq)})
+	}
+	return result, nil
+}
+
+// GetInvitation returns a single invitation with its RFQ
+func (s *PortalService) GetInvitation(ctx context.Context, sup SupplierIdentity, rfqID string) (*PortalInvitation, error) {
+	rfq, inv, err := s.loadInvitation(ctx, sup, rfqID)
+	if err != nil {
+		return nil, err
+	}
+	return &PortalInvitation{Invitation: *inv, RFQ: toPortalRFQ(rfq)}, nil
+}
+
+// MarkViewed records that the supplier opened the invitation
+func (s *PortalService) MarkViewed(ctx context.Context, sup SupplierIdentity, rfqID string) (*PortalInvitation, error) {
+	rfq, inv, err := s.loadInvitation(ctx, sup, rfqID)
+	if err != nil {
+		return nil, err
+	}
+
+	if inv.MarkViewed() {
+		if err := s.rfqRepo.UpdateInvitation(ctx, inv); err != nil {
+			return nil, fmt.Errorf("failed to mark invitation viewed: %w", err)
+		}
+	}
+
+	return &PortalInvitation{Invitation: *inv, RFQ: toPortalRFQ(rfq)}, nil
+}
+
+// DeclineInvitation records that the supplier will not quote
+func (s *PortalService) DeclineInvitation(ctx context.Context, sup SupplierIdentity, rfqID, reason string) (*PortalInvitation, error) {
+	rfq, inv, err := s.loadInvitation(ctx, sup, rfqID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := inv.Decline(reason); err != nil {
+		return nil, err
+	}
+	if err := s.rfqRepo.UpdateInvitation(ctx, inv); err != nil {
+		return nil, fmt.Errorf("failed to decline invitation: %w", err)
+	}
+
+	event := rfqDomain.NewInvitationDeclinedEvent(rfq, inv)
+	if err := s.rfqEvents.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish invitation declined event",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", rfqID))
+	}
+
+	s.logger.Info("Supplier declined RFQ invitation",
+		slog.String("rfq_id", rfqID),
+		slog.String("supplier_id", sup.SupplierID))
+
+	return &PortalInvitation{Invitation: *inv, RFQ: toPortalRFQ(rfq)}, nil
+}
+
+// ListAddenda returns the addenda issued for an invited RFQ
+func (s *PortalService) ListAddenda(ctx context.Context, sup SupplierIdentity, rfqID string) ([]rfqDomain.RFQAddendum, error) {
+	if _, _, err := s.loadInvitation(ctx, sup, rfqID); err != nil {
+		return nil, err
+	}
+	return s.rfqRepo.GetAddenda(ctx, rfqID)
+}
+
+// ListNotifications returns the supplier's addendum and answer notifications
+func (s *PortalService) ListNotifications(ctx context.Context, sup SupplierIdentity, unreadOnly bool) ([]rfqDomain.BidderNotification, error) {
+	return s.rfqRepo.ListNotificationsBySupplier(ctx, sup.TenantID, sup.SupplierID, unreadOnly)
+}
+
+// MarkNotificationRead marks one of the supplier's notifications as read
+func (s *PortalService) MarkNotificationRead(ctx context.Context, sup SupplierIdentity, notificationID string) error {
+	return s.rfqRepo.MarkNotificationRead(ctx, sup.SupplierID, notificationID)
+}
+
+// ListQuestions returns the RFQ's public Q&A thread plus the supplier's own questions
+func (s *PortalService) ListQuestions(ctx context.Context, sup SupplierIdentity, rfqID string) ([]PortalQuestion, error) {
+	if _, _, err := s.loadInvitation(ctx, sup, rfqID); err != nil {
+		return nil, err
+	}
+
+	questions, err := s.rfqRepo.GetQuestions(ctx, rfqID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]PortalQuestion, 0, len(questions))
+	for i := range questions {
+		if questions[i].VisibleTo(sup.SupplierID) {
+			result = append(result, toPortalQuestion(&questions[i], sup.SupplierID))
+		}
+	}
+	return result, nil
+}
+
+// AskQuestion posts a clarification question to the RFQ's Q&A thread
+func (s *PortalService) AskQuestion(ctx context.Context, sup SupplierIdentity, rfqID, text string) (*PortalQuestion, error) {
+	rfq, inv, err := s.loadInvitation(ctx, sup, rfqID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status == rfqDomain.InvitationStatusDeclined {
+		return nil, rfqDomain.ErrInvitationDeclined
+	}
+
+	question, err := rfq.AskQuestion(sup.SupplierID, text, sup.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rfqRepo.AddQuestion(ctx, question); err != nil {
+		return nil, fmt.Errorf("failed to post question: %w", err)
+	}
+
+	pq := toPortalQuestion(question, sup.SupplierID)
+	return &pq, nil
+}
+
+// ListQuotes returns the supplier's own quotes
+func (s *PortalService) ListQuotes(ctx context.Context, sup SupplierIdentity) ([]quoteApp.QuoteResponse, error) {
+	quotes, err := s.quoteRepo.GetBySupplierID(ctx, sup.SupplierID, sup.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return quoteApp.ToQuoteResponses(quotes), nil
+}
+
+// GetQuote returns one of the supplier's quotes
+func (s *PortalService) GetQuote(ctx context.Context, sup SupplierIdentity, quoteID string) (*quoteApp.QuoteResponse, error) {
+	quote, err := s.loadQuote(ctx, sup, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	response := quoteApp.ToQuoteResponse(quote)
+	return &response, nil
+}
+
+// CreateQuote creates the supplier's quote for an invited RFQ, validated
+// against the RFQ items and response deadline
+func (s *PortalService) CreateQuote(ctx context.Context, sup SupplierIdentity, rfqID string, req PortalQuoteRequest) (*quoteApp.QuoteResponse, error) {
+	rfq, inv, err := s.loadInvitation(ctx, sup, rfqID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status == rfqDomain.InvitationStatusDeclined {
+		return nil, rfqDomain.ErrInvitationDeclined
+	}
+	if err := domain.EnsureOpenForQuotes(rfq, time.Now()); err != nil {
+		return nil, err
+	}
+	existing, err := s.quoteRepo.GetBySupplierID(ctx, sup.SupplierID, sup.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range existing {
+		if q.RFQID == rfq.ID && domain.IsActiveQuote(q) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrQuoteExists, q.ID)
+		}
+	}
+
+	quote, err := quoteDomain.NewQuote(sup.TenantID, rfq.ID, sup.SupplierID, sup.UserID, req.ValidUntil)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyQuoteRequest(rfq, quote, req); err != nil {
+		return nil, err
+	}
+
+	if req.Submit {
+		if err := quote.Submit(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.quoteRepo.Create(ctx, quote); err != nil {
+		s.logger.Error("Failed to persist portal quote", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to create quote: %w", err)
+	}
+
+	if quote.Status == quoteDomain.QuoteStatusSubmitted {
+		s.markQuoted(ctx, inv)
+	}
+
+	s.logger.Info("Supplier created quote via portal",
+		slog.String("quote_id", quote.ID),
+		slog.String("rfq_id", rfq.ID),
+		slog.String("supplier_id", sup.SupplierID),
+		slog.String("status", string(quote.Status)))
+
+	response := quoteApp.ToQuoteResponse(quote)
+	return &response, nil
+}
+
+// UpdateQuote replaces the terms and lines of the supplier's quote and pins
+// it to the current RFQ version. Submitted quotes are revised so that the
+// previous offer stays in the revision history.
+func (s *PortalService) UpdateQuote(ctx context.Context, sup SupplierIdentity, quoteID string, req PortalQuoteRequest) (*quoteApp.QuoteResponse, error) {
+	quote, err := s.loadQuote(ctx, sup, quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	rfq, inv, err := s.loadInvitation(ctx, sup, quote.RFQID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status == rfqDomain.InvitationStatusDeclined {
+		return nil, rfqDomain.ErrInvitationDeclined
+	}
+	if err := domain.EnsureOpenForQuotes(rfq, time.Now()); err != nil {
+		return nil, err
+	}
+
+	switch quote.Status {
+	case quoteDomain.QuoteStatusDraft, quoteDomain.QuoteStatusRevised:
+	case quoteDomain.QuoteStatusSubmitted, quoteDomain.QuoteStatusUnderReview:
+		if err := quote.Revise(fmt.Sprintf("Updated for RFQ version %d", rfq.Version), sup.UserID); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: quote is %s", domain.ErrQuoteNotEditable, quote.Status)
+	}
+
+	quote.ValidUntil = req.ValidUntil
+	quote.Items = []quoteDomain.QuoteItem{}
+	if err := applyQuoteRequest(rfq, quote, req); err != nil {
+		return nil, err
+	}
+
+	if req.Submit {
+		if err := quote.Submit(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.quoteRepo.Update(ctx, quote); err != nil {
+		return nil, fmt.Errorf("failed to update quote: %w", err)
+	}
+
+	if quote.Status == quoteDomain.QuoteStatusSubmitted {
+		s.markQuoted(ctx, inv)
+	}
+
+	s.logger.Info("Supplier updated quote via portal",
+		slog.String("quote_id", quote.ID),
+		slog.String("rfq_id", rfq.ID),
+		slog.Int("rfq_version", quote.RFQVersion))
+
+	response := quoteApp.ToQuoteResponse(quote)
+	return &response, nil
+}
+
+// SubmitQuote submits a draft quote; late submissions and quotes for a
+// superseded RFQ version are rejected
+func (s *PortalService) SubmitQuote(ctx context.Context, sup SupplierIdentity, quoteID string) (*quoteApp.QuoteResponse, error) {
+	quote, err := s.loadQuote(ctx, sup, quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	rfq, inv, err := s.loadInvitation(ctx, sup, quote.RFQID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status == rfqDomain.InvitationStatusDeclined {
+		return nil, rfqDomain.ErrInvitationDeclined
+	}
+	if err := domain.EnsureOpenForQuotes(rfq, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := domain.EnsureCurrentVersion(rfq, quote); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateQuoteItems(rfq, quote.Items); err != nil {
+		return nil, err
+	}
+
+	if err := quote.Submit(); err != nil {
+		return nil, err
+	}
+	if err := s.quoteRepo.Update(ctx, quote); err != nil {
+		return nil, fmt.Errorf("failed to submit quote: %w", err)
+	}
+	s.markQuoted(ctx, inv)
+
+	s.logger.Info("Supplier submitted quote via portal",
+		slog.String("quote_id", quote.ID),
+		slog.String("rfq_id", rfq.ID),
+		slog.String("supplier_id", sup.SupplierID))
+
+	response := quoteApp.ToQuoteResponse(quote)
+	return &response, nil
+}
+
+// loadInvitation loads an RFQ and the calling supplier's invitation to it;
+// suppliers that were not invited see the RFQ as not found
+func (s *PortalService) loadInvitation(ctx context.Context, sup SupplierIdentity, rfqID string) (*rfqDomain.RFQ, *rfqDomain.RFQInvitation, error) {
+	rfq, err := s.rfqRepo.GetByID(ctx, rfqID, sup.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rfq.Status == rfqDomain.RFQStatusDraft {
+		return nil, nil, rfqDomain.ErrRFQNotFound
+	}
+	inv, err := rfq.FindInvitation(sup.SupplierID)
+	if err != nil {
+		return nil, nil, rfqDomain.ErrRFQNotFound
+	}
+	return rfq, inv, nil
+}
+
+// loadQuote loads a quote owned by the calling supplier
+func (s *PortalService) loadQuote(ctx context.Context, sup SupplierIdentity, quoteID string) (*quoteDomain.Quote, error) {
+	quote, err := s.quoteRepo.GetByID(ctx, quoteID, sup.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.SupplierID != sup.SupplierID {
+		return nil, domain.ErrQuoteNotOwned
+	}
+	return quote, nil
+}
+
+// markQuoted flags the invitation as answered; the quote itself is already saved
+func (s *PortalService) markQuoted(ctx context.Context, inv *rfqDomain.RFQInvitation) {
+	if err := inv.MarkQuoted(); err != nil {
+		return
+	}
+	if err := s.rfqRepo.UpdateInvitation(ctx, inv); err != nil {
+		s.logger.Warn("Failed to mark invitation quoted",
+			slog.String("invitation_id", inv.ID),
+			slog.String("error", err.Error()))
+	}
+}
+
+// applyQuoteRequest sets the quote's terms and lines from the request and
+// pins the quote to the RFQ version it now responds to
+func applyQuoteRequest(rfq *rfqDomain.RFQ, quote *quoteDomain.Quote, req PortalQuoteRequest) error {
+	if req.ValidUntil.Before(rfq.ResponseDeadline) {
+		return domain.ErrQuoteValidityTooShort
+	}
+
+	items := quoteItemsFor(rfq, req.Items)
+	if err := domain.ValidateQuoteItems(rfq, items); err != nil {
+		return err
+	}
+
+	if req.Currency != "" {
+		if err := quote.SetCurrency(req.Currency); err != nil {
+			return err
+		}
+	}
+	quote.DeliveryTerms = req.DeliveryTerms
+	quote.PaymentTerms = req.PaymentTerms
+	quote.WarrantyTerms = req.WarrantyTerms
+	quote.Notes = req.Notes
+	quote.RFQVersion = rfq.Version
+
+	for _, item := range items {
+		if err := quote.AddItem(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quoteItemsFor builds quote lines, filling equipment details from the RFQ items
+func quoteItemsFor(rfq *rfqDomain.RFQ, reqs []quoteApp.QuoteItemRequest) []quoteDomain.QuoteItem {
+	rfqItems := make(map[string]rfqDomain.RFQItem, len(rfq.Items))
+	for _, item := range rfq.Items {
+		rfqItems[item.ID] = item
+	}
+
+	items := make([]quoteDomain.QuoteItem, 0, len(reqs))
+	for _, r := range reqs {
+		item := quoteDomain.QuoteItem{
+			RFQItemID:         r.RFQItemID,
+			EquipmentID:       r.EquipmentID,
+			EquipmentName:     r.EquipmentName,
+			Quantity:          r.Quantity,
+			UnitPrice:         r.UnitPrice,
+			TaxRate:           r.TaxRate,
+			DeliveryTimeframe: r.DeliveryTimeframe,
+			ManufacturerName:  r.ManufacturerName,
+			ModelNumber:       r.ModelNumber,
+			Specifications:    r.Specifications,
+			ComplianceCerts:   r.ComplianceCerts,
+			Notes:             r.Notes,
+		}
+		if rfqItem, ok := rfqItems[r.RFQItemID]; ok {
+			if item.EquipmentName == "" {
+				item.EquipmentName = rfqItem.Name
+			}
+			if item.EquipmentID == "" && rfqItem.EquipmentID != nil {
+				item.EquipmentID = *rfqItem.EquipmentID
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func toPortalRFQ(rfq *rfqDomain.RFQ) PortalRFQ {
+	return PortalRFQ{
+		ID:               rfq.ID,
+		RFQNumber:        rfq.RFQNumber,
+		Title:            rfq.Title,
+		Description:      rfq.Description,
+		Priority:         rfq.Priority,
+		Status:           rfq.Status,
+		Version:          rfq.Version,
+		Items:            rfq.Items,
+		DeliveryTerms:    rfq.DeliveryTerms,
+		PaymentTerms:     rfq.PaymentTerms,
+		PublishedAt:      rfq.PublishedAt,
+		ResponseDeadline: rfq.ResponseDeadline,
+		OpenForQuotes:    domain.EnsureOpenForQuotes(rfq, time.Now()) == nil,
+	}
+}
+
+func toPortalQuestion(q *rfqDomain.RFQQuestion, supplierID string) PortalQuestion {
+	return PortalQuestion{
+		ID:         q.ID,
+		Question:   q.Question,
+		AskedAt:    q.AskedAt,
+		Answer:     q.Answer,
+		AnsweredAt: q.AnsweredAt,
+		Mine:       q.SupplierID == supplierID,
+	}
+}
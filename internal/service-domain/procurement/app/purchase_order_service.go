@@ -0,0 +1,239 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	contractDomain "github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	"github.com/segmentio/ksuid"
+)
+
+// CreatePurchaseOrderRequest calls off contract items
+type CreatePurchaseOrderRequest struct {
+	CallOffs     []domain.CallOff `json:"call_offs,omitempty"` // Defaults to everything left on the contract
+	DeliveryDate *time.Time       `json:"delivery_date,omitempty"`
+	Notes        string           `json:"notes,omitempty"`
+}
+
+// ReceiveGoodsRequest records a goods receipt against a purchase order
+type ReceiveGoodsRequest struct {
+	ReceivedAt           *time.Time           `json:"received_at,omitempty"` // Defaults to now; warranties run from this date
+	InstallationLocation string               `json:"installation_location,omitempty"`
+	CustomerName         string               `json:"customer_name,omitempty"` // Recorded on the equipment records
+	Lines                []domain.ReceiptLine `json:"lines"`
+	Notes                string               `json:"notes,omitempty"`
+}
+
+// SubmitInvoiceRequest records a supplier invoice against a purchase order
+type SubmitInvoiceRequest struct {
+	InvoiceNumber string               `json:"invoice_number"`
+	InvoiceDate   *time.Time           `json:"invoice_date,omitempty"` // Defaults to today
+	Currency      string               `json:"currency,omitempty"`     // Defaults to the order currency
+	Lines         []domain.InvoiceLine `json:"lines"`
+	TaxAmount     float64              `json:"tax_amount,omitempty"`
+}
+
+// PurchaseOrderDetail is a purchase order with its receipts and invoices
+type PurchaseOrderDetail struct {
+	*domain.PurchaseOrder
+	Receipts []*domain.GoodsReceipt    `json:"receipts"`
+	Invoices []*domain.SupplierInvoice `json:"invoices"`
+}
+
+// ReceiptResult is a recorded goods receipt and the order it updated
+type ReceiptResult struct {
+	Receipt       *domain.GoodsReceipt  `json:"receipt"`
+	PurchaseOrder *domain.PurchaseOrder `json:"purchase_order"`
+}
+
+// InvoiceResult is a matched supplier invoice and the order it updated
+type InvoiceResult struct {
+	Invoice       *domain.SupplierInvoice `json:"invoice"`
+	PurchaseOrder *domain.PurchaseOrder   `json:"purchase_order"`
+}
+
+// PurchaseOrderService calls off contracts with purchase orders, receives
+// goods into the installed base and matches supplier invoices
+type PurchaseOrderService struct {
+	contracts     contractDomain.Repository
+	orders        domain.PurchaseOrderRepository
+	installedBase domain.InstalledBaseRegistrar
+	tolerance     float64
+	logger        *slog.Logger
+}
+
+// NewPurchaseOrderService creates a new purchase order service
+func NewPurchaseOrderService(
+	contracts contractDomain.Repository,
+	orders domain.PurchaseOrderRepository,
+	installedBase domain.InstalledBaseRegistrar,
+	logger *slog.Logger,
+) *PurchaseOrderService {
+	return &PurchaseOrderService{
+		contracts:     contracts,
+		orders:        orders,
+		installedBase: installedBase,
+		tolerance:     domain.DefaultPriceTolerance,
+		logger:        logger.With(slog.String("service", "purchase_order")),
+	}
+}
+
+// CreatePurchaseOrder calls off items of an active contract, up to the
+// quantities not yet on other purchase orders
+func (s *PurchaseOrderService) CreatePurchaseOrder(ctx context.Context, tenantID, contractID, createdBy string, req CreatePurchaseOrderRequest) (*domain.PurchaseOrder, error) {
+	contract, err := s.contracts.GetByID(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	ordered, err := s.orders.OrderedQuantities(ctx, tenantID, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	poNumber := fmt.Sprintf("PO-%s-%04d", now.Format("20060102"), now.Unix()%10000)
+	po, err := domain.NewPurchaseOrder(contract, poNumber, req.CallOffs, ordered, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	po.DeliveryDate = req.DeliveryDate
+	po.Notes = req.Notes
+
+	if err := s.orders.CreatePurchaseOrder(ctx, po); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Purchase order created",
+		slog.String("po_id", po.ID),
+		slog.String("po_number", po.PONumber),
+		slog.String("contract_id", contract.ID),
+		slog.Int("lines", len(po.Lines)))
+	return po, nil
+}
+
+// GetPurchaseOrder retrieves a purchase order with its receipts and invoices
+func (s *PurchaseOrderService) GetPurchaseOrder(ctx context.Context, tenantID, id string) (*PurchaseOrderDetail, error) {
+	po, err := s.orders.GetPurchaseOrder(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.orders.ListReceipts(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	invoices, err := s.orders.ListInvoices(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return &PurchaseOrderDetail{PurchaseOrder: po, Receipts: receipts, Invoices: invoices}, nil
+}
+
+// ListPurchaseOrders retrieves a contract's purchase orders
+func (s *PurchaseOrderService) ListPurchaseOrders(ctx context.Context, tenantID, contractID string) ([]*domain.PurchaseOrder, error) {
+	return s.orders.ListPurchaseOrders(ctx, tenantID, contractID)
+}
+
+// CancelPurchaseOrder cancels an order nothing was received against
+func (s *PurchaseOrderService) CancelPurchaseOrder(ctx context.Context, tenantID, id string) (*domain.PurchaseOrder, error) {
+	po, err := s.orders.GetPurchaseOrder(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := po.Cancel(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.orders.UpdatePurchaseOrder(ctx, po); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Purchase order cancelled", slog.String("po_id", po.ID))
+	return po, nil
+}
+
+// ReceiveGoods records a goods receipt and registers every received unit in
+// the installed base, with the warranty running from the receipt date.
+// Registration is keyed by serial number, so a receipt retried after a
+// conflict links the units registered by the failed attempt.
+func (s *PurchaseOrderService) ReceiveGoods(ctx context.Context, tenantID, poID, receivedBy string, req ReceiveGoodsRequest) (*ReceiptResult, error) {
+	po, err := s.orders.GetPurchaseOrder(ctx, tenantID, poID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	receipt := &domain.GoodsReceipt{
+		ID:                   ksuid.New().String(),
+		ReceiptNumber:        fmt.Sprintf("GRN-%s-%04d", now.Format("20060102"), now.Unix()%10000),
+		ReceivedAt:           now,
+		ReceivedBy:           receivedBy,
+		InstallationLocation: req.InstallationLocation,
+		Lines:                req.Lines,
+		Notes:                req.Notes,
+		CreatedAt:            now,
+	}
+	if req.ReceivedAt != nil {
+		if req.ReceivedAt.After(now) {
+			return nil, fmt.Errorf("%w: received_at is in the future", domain.ErrInvalidReceipt)
+		}
+		receipt.ReceivedAt = *req.ReceivedAt
+	}
+	if err := po.ReceiveGoods(receipt); err != nil {
+		return nil, err
+	}
+
+	customerName := strings.TrimSpace(req.CustomerName)
+	if customerName == "" {
+		customerName = tenantID
+	}
+	if err := s.installedBase.RegisterReceivedUnits(ctx, po, receipt, customerName); err != nil {
+		return nil, err
+	}
+	if err := s.orders.SaveReceipt(ctx, po, receipt); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Goods received",
+		slog.String("po_id", po.ID),
+		slog.String("receipt_number", receipt.ReceiptNumber),
+		slog.String("status", string(po.Status)))
+	return &ReceiptResult{Receipt: receipt, PurchaseOrder: po}, nil
+}
+
+// SubmitInvoice matches a supplier invoice against the order and its
+// receipts. Invoices that do not match are recorded as exceptions and leave
+// the order unchanged.
+func (s *PurchaseOrderService) SubmitInvoice(ctx context.Context, tenantID, poID, recordedBy string, req SubmitInvoiceRequest) (*InvoiceResult, error) {
+	po, err := s.orders.GetPurchaseOrder(ctx, tenantID, poID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invoice := &domain.SupplierInvoice{
+		ID:            ksuid.New().String(),
+		InvoiceNumber: strings.TrimSpace(req.InvoiceNumber),
+		InvoiceDate:   now.Truncate(24 * time.Hour),
+		Currency:      req.Currency,
+		Lines:         req.Lines,
+		TaxAmount:     req.TaxAmount,
+		RecordedBy:    recordedBy,
+		CreatedAt:     now,
+	}
+	if req.InvoiceDate != nil {
+		invoice.InvoiceDate = *req.InvoiceDate
+	}
+	if err := po.MatchInvoice(invoice, s.tolerance); err != nil {
+		return nil, err
+	}
+	if err := s.orders.SaveInvoice(ctx, po, invoice); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Supplier invoice matched",
+		slog.String("po_id", po.ID),
+		slog.String("invoice_number", invoice.InvoiceNumber),
+		slog.String("status", string(invoice.Status)))
+	return &InvoiceResult{Invoice: invoice, PurchaseOrder: po}, nil
+}
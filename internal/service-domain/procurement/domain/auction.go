@@ -0,0 +1,386 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	quoteDomain "github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrAuctionNotFound       = errors.New("auction not found")
+	ErrInvalidAuction        = errors.New("invalid auction settings")
+	ErrAuctionExists         = errors.New("rfq already has an active auction")
+	ErrNotEnoughBidders      = errors.New("reverse auction needs at least two qualifying quotes")
+	ErrAuctionNotOpen        = errors.New("auction is not open for bidding")
+	ErrAuctionNotEnded       = errors.New("auction has not ended yet")
+	ErrAuctionFinished       = errors.New("auction is already closed or cancelled")
+	ErrNotAuctionParticipant = errors.New("supplier is not a participant in this auction")
+	ErrBidNotLowEnough       = errors.New("bid must undercut your current price by at least the minimum decrement")
+	ErrAuctionConflict       = errors.New("auction changed concurrently, retry the bid")
+)
+
+// Clock supplies the current time. Auctions are driven by it so tests can
+// run them with a fake clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the wall clock
+type SystemClock struct{}
+
+// Now returns the current time
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// AuctionStatus represents the lifecycle status of a reverse auction
+type AuctionStatus string
+
+const (
+	AuctionStatusScheduled AuctionStatus = "scheduled" // Created, bidding not started
+	AuctionStatusOpen      AuctionStatus = "open"      // Between start and (extended) end
+	AuctionStatusEnded     AuctionStatus = "ended"     // Bidding over, final prices not yet applied
+	AuctionStatusClosed    AuctionStatus = "closed"    // Final prices applied to quotes and comparison created
+	AuctionStatusCancelled AuctionStatus = "cancelled"
+)
+
+// AuctionParticipant is a supplier admitted to the auction with its
+// qualifying quote, whose total is the supplier's opening price
+type AuctionParticipant struct {
+	SupplierID   string  `json:"supplier_id"`
+	QuoteID      string  `json:"quote_id"`
+	OpeningPrice float64 `json:"opening_price"`
+}
+
+// AuctionBid is one price offered by a participant
+type AuctionBid struct {
+	ID         string    `json:"id"`
+	AuctionID  string    `json:"auction_id"`
+	SupplierID string    `json:"supplier_id"`
+	Amount     float64   `json:"amount"`
+	PlacedBy   string    `json:"placed_by"`
+	PlacedAt   time.Time `json:"placed_at"`
+	Extended   bool      `json:"extended"` // Bid triggered an anti-sniping extension
+}
+
+// AuctionRank is a participant's standing on its best price. Ties go to the
+// supplier that reached the price first.
+type AuctionRank struct {
+	Rank       int       `json:"rank"`
+	SupplierID string    `json:"supplier_id"`
+	QuoteID    string    `json:"quote_id"`
+	Price      float64   `json:"price"`
+	BidCount   int       `json:"bid_count"`
+	PricedAt   time.Time `json:"priced_at"`
+}
+
+// BidderView is the rank-only feedback a participant gets: its own price and
+// position, never the other suppliers' prices
+type BidderView struct {
+	AuctionID  string        `json:"auction_id"`
+	RFQID      string        `json:"rfq_id"`
+	Status     AuctionStatus `json:"status"`
+	Currency   string        `json:"currency"`
+	StartsAt   time.Time     `json:"starts_at"`
+	EndsAt     time.Time     `json:"ends_at"`
+	Rank       int           `json:"rank"`
+	Bidders    int           `json:"bidders"`
+	YourPrice  float64       `json:"your_price"`
+	MaxNextBid float64       `json:"max_next_bid"` // Highest amount the next bid may have
+	YourBids   int           `json:"your_bids"`
+}
+
+// Auction is a timed reverse auction on the qualifying quotes of an RFQ.
+// Each participant undercuts its own current price by at least the minimum
+// decrement; bids close to the end extend it so nobody can snipe.
+type Auction struct {
+	ID               string               `json:"id"`
+	TenantID         string               `json:"tenant_id"`
+	RFQID            string               `json:"rfq_id"`
+	Status           AuctionStatus        `json:"status"`
+	Currency         string               `json:"currency"`
+	StartsAt         time.Time            `json:"starts_at"`
+	EndsAt           time.Time            `json:"ends_at"`
+	ScheduledEndAt   time.Time            `json:"scheduled_end_at"` // End before anti-sniping extensions
+	MinDecrement     float64              `json:"min_decrement"`
+	ExtensionSeconds int                  `json:"extension_seconds"` // Bids within this window before the end push it out by the same amount
+	MaxExtensions    int                  `json:"max_extensions"`    // 0 = unlimited
+	Extensions       int                  `json:"extensions"`
+	Participants     []AuctionParticipant `json:"participants"`
+	Bids             []AuctionBid         `json:"bids"`
+	ComparisonID     string               `json:"comparison_id,omitempty"` // Comparison created from the final prices
+	CreatedBy        string               `json:"created_by"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+	ClosedAt         *time.Time           `json:"closed_at,omitempty"`
+}
+
+// NewAuction schedules a reverse auction for the given participants
+func NewAuction(tenantID, rfqID, currency string, startsAt, endsAt time.Time, minDecrement float64,
+	extensionSeconds, maxExtensions int, participants []AuctionParticipant, createdBy string, now time.Time) (*Auction, error) {
+	switch {
+	case !endsAt.After(startsAt):
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidAuction)
+	case endsAt.Before(now):
+		return nil, fmt.Errorf("%w: ends_at is in the past", ErrInvalidAuction)
+	case minDecrement <= 0:
+		return nil, fmt.Errorf("%w: min_decrement must be positive", ErrInvalidAuction)
+	case extensionSeconds < 0 || maxExtensions < 0:
+		return nil, fmt.Errorf("%w: extension settings cannot be negative", ErrInvalidAuction)
+	}
+	if len(participants) < 2 {
+		return nil, ErrNotEnoughBidders
+	}
+
+	return &Auction{
+		ID:               ksuid.New().String(),
+		TenantID:         tenantID,
+		RFQID:            rfqID,
+		Status:           AuctionStatusScheduled,
+		Currency:         currency,
+		StartsAt:         startsAt,
+		EndsAt:           endsAt,
+		ScheduledEndAt:   endsAt,
+		MinDecrement:     minDecrement,
+		ExtensionSeconds: extensionSeconds,
+		MaxExtensions:    maxExtensions,
+		Participants:     participants,
+		Bids:             []AuctionBid{},
+		CreatedBy:        createdBy,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}, nil
+}
+
+// StatusAt returns the auction's status at the given time. Only closed and
+// cancelled are stored; the bidding phases follow from the clock.
+func (a *Auction) StatusAt(now time.Time) AuctionStatus {
+	if a.Status == AuctionStatusClosed || a.Status == AuctionStatusCancelled {
+		return a.Status
+	}
+	switch {
+	case now.Before(a.StartsAt):
+		return AuctionStatusScheduled
+	case now.Before(a.EndsAt):
+		return AuctionStatusOpen
+	default:
+		return AuctionStatusEnded
+	}
+}
+
+// Participant returns the participant entry of a supplier
+func (a *Auction) Participant(supplierID string) (*AuctionParticipant, bool) {
+	for i := range a.Participants {
+		if a.Participants[i].SupplierID == supplierID {
+			return &a.Participants[i], true
+		}
+	}
+	return nil, false
+}
+
+// CurrentPrice returns the supplier's lowest price so far: its last bid, or
+// the opening price if it has not bid
+func (a *Auction) CurrentPrice(supplierID string) (float64, bool) {
+	p, ok := a.Participant(supplierID)
+	if !ok {
+		return 0, false
+	}
+	price := p.OpeningPrice
+	for _, b := range a.Bids {
+		if b.SupplierID == supplierID && b.Amount < price {
+			price = b.Amount
+		}
+	}
+	return price, true
+}
+
+// PlaceBid records a participant's bid. A bid placed within the extension
+// window before the end moves the end out to now plus the window.
+func (a *Auction) PlaceBid(supplierID string, amount float64, placedBy string, now time.Time) (*AuctionBid, error) {
+	if a.StatusAt(now) != AuctionStatusOpen {
+		return nil, ErrAuctionNotOpen
+	}
+	current, ok := a.CurrentPrice(supplierID)
+	if !ok {
+		return nil, ErrNotAuctionParticipant
+	}
+	if amount <= 0 || a.round(amount).Cmp(a.round(current).Sub(a.round(a.MinDecrement))) > 0 {
+		return nil, ErrBidNotLowEnough
+	}
+
+	bid := AuctionBid{
+		ID:         ksuid.New().String(),
+		AuctionID:  a.ID,
+		SupplierID: supplierID,
+		Amount:     a.round(amount).Float64(),
+		PlacedBy:   placedBy,
+		PlacedAt:   now,
+	}
+
+	window := time.Duration(a.ExtensionSeconds) * time.Second
+	if window > 0 && a.EndsAt.Sub(now) < window && (a.MaxExtensions == 0 || a.Extensions < a.MaxExtensions) {
+		a.EndsAt = now.Add(window)
+		a.Extensions++
+		bid.Extended = true
+	}
+
+	a.Bids = append(a.Bids, bid)
+	a.UpdatedAt = now
+	return &bid, nil
+}
+
+// Rankings ranks all participants by their current price, lowest first
+func (a *Auction) Rankings() []AuctionRank {
+	ranks := make([]AuctionRank, 0, len(a.Participants))
+	for _, p := range a.Participants {
+		r := AuctionRank{SupplierID: p.SupplierID, QuoteID: p.QuoteID, Price: p.OpeningPrice}
+		for _, b := range a.Bids {
+			if b.SupplierID != p.SupplierID {
+				continue
+			}
+			r.BidCount++
+			if b.Amount < r.Price {
+				r.Price, r.PricedAt = b.Amount, b.PlacedAt
+			}
+		}
+		ranks = append(ranks, r)
+	}
+
+	sort.SliceStable(ranks, func(i, j int) bool {
+		if ranks[i].Price != ranks[j].Price {
+			return ranks[i].Price < ranks[j].Price
+		}
+		return ranks[i].PricedAt.Before(ranks[j].PricedAt)
+	})
+	for i := range ranks {
+		ranks[i].Rank = i + 1
+	}
+	return ranks
+}
+
+// BidderView returns the rank-only feedback for a participant
+func (a *Auction) BidderView(supplierID string, now time.Time) (*BidderView, error) {
+	view := &BidderView{
+		AuctionID: a.ID,
+		RFQID:     a.RFQID,
+		Status:    a.StatusAt(now),
+		Currency:  a.Currency,
+		StartsAt:  a.StartsAt,
+		EndsAt:    a.EndsAt,
+		Bidders:   len(a.Participants),
+	}
+	for _, r := range a.Rankings() {
+		if r.SupplierID == supplierID {
+			view.Rank = r.Rank
+			view.YourPrice = r.Price
+			view.YourBids = r.BidCount
+			view.MaxNextBid = a.round(r.Price).Sub(a.round(a.MinDecrement)).Float64()
+			return view, nil
+		}
+	}
+	return nil, ErrNotAuctionParticipant
+}
+
+// Close ends the auction once bidding is over and returns the final ranking
+func (a *Auction) Close(now time.Time) ([]AuctionRank, error) {
+	switch a.StatusAt(now) {
+	case AuctionStatusClosed, AuctionStatusCancelled:
+		return nil, ErrAuctionFinished
+	case AuctionStatusScheduled, AuctionStatusOpen:
+		return nil, ErrAuctionNotEnded
+	}
+	a.Status = AuctionStatusClosed
+	a.ClosedAt = &now
+	a.UpdatedAt = now
+	return a.Rankings(), nil
+}
+
+// Cancel cancels an auction that has not been closed
+func (a *Auction) Cancel(now time.Time) error {
+	if a.Status == AuctionStatusClosed || a.Status == AuctionStatusCancelled {
+		return ErrAuctionFinished
+	}
+	a.Status = AuctionStatusCancelled
+	a.ClosedAt = &now
+	a.UpdatedAt = now
+	return nil
+}
+
+// ApplyAuctionPrice reprices a quote to its final auction price by scaling
+// every line proportionally, and records the change as a quote revision.
+// It reports false if the quote already carries that price.
+func ApplyAuctionPrice(q *quoteDomain.Quote, price float64, auctionID, revisedBy string, now time.Time) bool {
+	target := money.RoundTo(money.NewFromFloat(price), q.Currency)
+	previous := money.NewFromFloat(q.TotalAmount)
+	if previous.Sign() <= 0 || money.RoundTo(previous, q.Currency).Equal(target) {
+		return false
+	}
+
+	factor := target.Div(previous, 12)
+	scale := func(v float64) money.Decimal {
+		return money.RoundTo(money.NewFromFloat(v).Mul(factor), q.Currency)
+	}
+	total := money.Zero
+	for i := range q.Items {
+		item := &q.Items[i]
+		lineTotal, tax := scale(item.TotalPrice), scale(item.TaxAmount)
+		item.UnitPrice = scale(item.UnitPrice).Float64()
+		item.TotalPrice = lineTotal.Float64()
+		item.TaxAmount = tax.Float64()
+		total = total.Add(lineTotal).Add(tax)
+	}
+	// Rounding differences go on the last line so the quote totals exactly the final bid
+	if n := len(q.Items); n > 0 && !total.Equal(target) {
+		last := &q.Items[n-1]
+		last.TotalPrice = money.NewFromFloat(last.TotalPrice).Add(target.Sub(total)).Float64()
+		total = target
+	}
+	q.TotalAmount = total.Float64()
+
+	q.Revisions = append(q.Revisions, quoteDomain.QuoteRevision{
+		RevisionNumber: q.RevisionNumber,
+		RevisedAt:      now,
+		RevisedBy:      revisedBy,
+		Changes:        "Final price of reverse auction " + auctionID,
+		PreviousTotal:  previous.Float64(),
+		NewTotal:       q.TotalAmount,
+		Metadata:       map[string]interface{}{"auction_id": auctionID},
+	})
+	q.RevisionNumber++
+	q.UpdatedAt = now
+	return true
+}
+
+// round rounds an amount to the minor units of the auction's currency
+func (a *Auction) round(v float64) money.Decimal {
+	return money.RoundTo(money.NewFromFloat(v), a.Currency)
+}
+
+// AuctionRepository defines persistence for reverse auctions
+type AuctionRepository interface {
+	// Create persists a new auction
+	Create(ctx context.Context, auction *Auction) error
+
+	// GetByID retrieves an auction with its bids
+	GetByID(ctx context.Context, tenantID, id string) (*Auction, error)
+
+	// ListByRFQ retrieves the auctions of an RFQ, newest first
+	ListByRFQ(ctx context.Context, tenantID, rfqID string) ([]*Auction, error)
+
+	// ListBySupplier retrieves the auctions a supplier participates in, newest first
+	ListBySupplier(ctx context.Context, tenantID, supplierID string) ([]*Auction, error)
+
+	// ListEnded retrieves auctions of all tenants whose bidding ended but which are not closed
+	ListEnded(ctx context.Context, now time.Time) ([]*Auction, error)
+
+	// AddBid stores the last bid of the auction together with its new end.
+	// It fails with ErrAuctionConflict if another bid was stored meanwhile.
+	AddBid(ctx context.Context, auction *Auction) error
+
+	// Update saves the status and comparison of an auction
+	Update(ctx context.Context, auction *Auction) error
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestAuctionBiddingWithAntiSniping(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+	start := clock.Now().Add(time.Hour)
+	end := start.Add(30 * time.Minute)
+
+	auction, err := NewAuction("tenant", "rfq-1", "USD", start, end, 10, 120, 1, []AuctionParticipant{
+		{SupplierID: "sup-a", QuoteID: "q-a", OpeningPrice: 1000},
+		{SupplierID: "sup-b", QuoteID: "q-b", OpeningPrice: 950},
+	}, "buyer", clock.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := auction.PlaceBid("sup-a", 900, "u", clock.Now()); !errors.Is(err, ErrAuctionNotOpen) {
+		t.Fatalf("expected %v before start, got %v", ErrAuctionNotOpen, err)
+	}
+
+	clock.Advance(time.Hour)
+	if _, err := auction.PlaceBid("sup-a", 995, "u", clock.Now()); !errors.Is(err, ErrBidNotLowEnough) {
+		t.Fatalf("expected %v below the decrement, got %v", ErrBidNotLowEnough, err)
+	}
+	if _, err := auction.PlaceBid("sup-c", 500, "u", clock.Now()); !errors.Is(err, ErrNotAuctionParticipant) {
+		t.Fatalf("expected %v, got %v", ErrNotAuctionParticipant, err)
+	}
+	if _, err := auction.PlaceBid("sup-a", 940, "u", clock.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	view, _ := auction.BidderView("sup-b", clock.Now())
+	if view.Rank != 2 || view.YourPrice != 950 || view.MaxNextBid != 940 {
+		t.Fatalf("unexpected bidder view: %+v", view)
+	}
+
+	// A bid in the last minute extends the end once
+	clock.Advance(29 * time.Minute)
+	bid, err := auction.PlaceBid("sup-b", 930, "u", clock.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bid.Extended || !auction.EndsAt.Equal(clock.Now().Add(2*time.Minute)) {
+		t.Fatalf("expected end extended to %v, got %v", clock.Now().Add(2*time.Minute), auction.EndsAt)
+	}
+	clock.Advance(90 * time.Second)
+	if bid, _ := auction.PlaceBid("sup-a", 920, "u", clock.Now()); bid == nil || bid.Extended {
+		t.Fatalf("expected bid without extension once max extensions is reached, got %+v", bid)
+	}
+
+	if _, err := auction.Close(clock.Now()); !errors.Is(err, ErrAuctionNotEnded) {
+		t.Fatalf("expected %v, got %v", ErrAuctionNotEnded, err)
+	}
+	clock.Advance(time.Minute)
+	ranks, err := auction.Close(clock.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ranks[0].SupplierID != "sup-a" || ranks[0].Price != 920 || ranks[1].Price != 930 {
+		t.Fatalf("unexpected final ranking: %+v", ranks)
+	}
+	if auction.StatusAt(clock.Now()) != AuctionStatusClosed {
+		t.Fatalf("expected closed auction, got %s", auction.StatusAt(clock.Now()))
+	}
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrAwardNotFound          = errors.New("award not found")
+	ErrComparisonNotCompleted = errors.New("comparison must be completed before awarding")
+	ErrNoWinningQuote         = errors.New("comparison has no winning quote")
+	ErrQuoteNotAwardable      = errors.New("quote cannot be awarded")
+	ErrRFQNotAwardable        = errors.New("rfq cannot be awarded in its current status")
+	ErrRFQAlreadyAwarded      = errors.New("rfq already awarded")
+	ErrAwardInProgress        = errors.New("rfq has an award in progress or completed")
+)
+
+// AwardStatus represents the outcome of a procurement award saga
+type AwardStatus string
+
+const (
+	AwardStatusInProgress  AwardStatus = "in_progress"
+	AwardStatusCompleted   AwardStatus = "completed"
+	AwardStatusCompensated AwardStatus = "compensated" // A step failed and all completed steps were undone
+	AwardStatusFailed      AwardStatus = "failed"      // A step failed and compensation did not fully succeed
+)
+
+// StepStatus represents the state of a single saga step
+type StepStatus string
+
+const (
+	StepStatusCompleted          StepStatus = "completed"
+	StepStatusFailed             StepStatus = "failed"
+	StepStatusCompensated        StepStatus = "compensated"
+	StepStatusCompensationFailed StepStatus = "compensation_failed"
+)
+
+// Saga step names
+const (
+	StepCloseRFQ      = "close_rfq"
+	StepAcceptQuote   = "accept_quote"
+	StepRejectQuote   = "reject_quote"
+	StepAwardRFQ      = "award_rfq"
+	StepDraftContract = "draft_contract"
+	StepPublishEvents = "publish_events"
+)
+
+// AwardStep is one entry in the audit trail of an award
+type AwardStep struct {
+	Name     string     `json:"name"`
+	Status   StepStatus `json:"status"`
+	EntityID string     `json:"entity_id,omitempty"` // RFQ, quote or contract touched by the step
+	Detail   string     `json:"detail,omitempty"`
+	Error    string     `json:"error,omitempty"`
+	At       time.Time  `json:"at"`
+}
+
+// Award records one run of the procurement saga: awarding an RFQ from a
+// completed comparison, settling its quotes and drafting the contract
+type Award struct {
+	ID           string      `json:"id"`
+	TenantID     string      `json:"tenant_id"`
+	RFQID        string      `json:"rfq_id"`
+	ComparisonID string      `json:"comparison_id"`
+	QuoteID      string      `json:"quote_id"`
+	SupplierID   string      `json:"supplier_id"`
+	ContractID   string      `json:"contract_id,omitempty"`
+	Status       AwardStatus `json:"status"`
+	Reason       string      `json:"reason,omitempty"`
+	Error        string      `json:"error,omitempty"`
+	Steps        []AwardStep `json:"steps"`
+	AwardedBy    string      `json:"awarded_by"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+	CompletedAt  *time.Time  `json:"completed_at,omitempty"`
+}
+
+// NewAward starts an award record
+func NewAward(tenantID, rfqID, comparisonID, quoteID, supplierID, awardedBy, reason string) *Award {
+	now := time.Now()
+	return &Award{
+		ID:           ksuid.New().String(),
+		TenantID:     tenantID,
+		RFQID:        rfqID,
+		ComparisonID: comparisonID,
+		QuoteID:      quoteID,
+		SupplierID:   supplierID,
+		Status:       AwardStatusInProgress,
+		Reason:       reason,
+		Steps:        []AwardStep{},
+		AwardedBy:    awardedBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// RecordStep appends a step outcome to the audit trail
+func (a *Award) RecordStep(name string, status StepStatus, entityID, detail string, err error) {
+	step := AwardStep{
+		Name:     name,
+		Status:   status,
+		EntityID: entityID,
+		Detail:   detail,
+		At:       time.Now(),
+	}
+	if err != nil {
+		step.Error = err.Error()
+	}
+	a.Steps = append(a.Steps, step)
+	a.UpdatedAt = step.At
+}
+
+// Complete marks the saga as completed
+func (a *Award) Complete(contractID string) {
+	now := time.Now()
+	a.ContractID = contractID
+	a.Status = AwardStatusCompleted
+	a.CompletedAt = &now
+	a.UpdatedAt = now
+}
+
+// Fail marks the saga as failed; compensated reports whether all completed steps were undone
+func (a *Award) Fail(err error, compensated bool) {
+	now := time.Now()
+	a.Status = AwardStatusFailed
+	if compensated {
+		a.Status = AwardStatusCompensated
+	}
+	a.Error = err.Error()
+	a.CompletedAt = &now
+	a.UpdatedAt = now
+}
+
+// AwardRepository defines persistence for award records
+type AwardRepository interface {
+	// Create persists a new award record; ErrAwardInProgress if the RFQ
+	// already has an in-progress or completed award
+	Create(ctx context.Context, award *Award) error
+
+	// Update saves the status and audit trail of an award
+	Update(ctx context.Context, award *Award) error
+
+	// GetByID retrieves an award by ID
+	GetByID(ctx context.Context, tenantID, id string) (*Award, error)
+
+	// ListByRFQ retrieves all award attempts for an RFQ, newest first
+	ListByRFQ(ctx context.Context, tenantID, rfqID string) ([]*Award, error)
+}
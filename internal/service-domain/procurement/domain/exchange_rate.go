@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+	ErrInvalidExchangeRate  = errors.New("invalid exchange rate")
+)
+
+// RateScale is the number of decimal places exchange rates are kept to
+const RateScale = 10
+
+// Exchange rate sources
+const (
+	RateSourceManual = "manual"
+	RateSourceCSV    = "csv"
+)
+
+// ExchangeRate is the number of units of ToCurrency one unit of FromCurrency
+// buys on RateDate. A rate applies from its date until a newer one is recorded.
+type ExchangeRate struct {
+	ID           string        `json:"id"`
+	TenantID     string        `json:"tenant_id"`
+	FromCurrency string        `json:"from_currency"`
+	ToCurrency   string        `json:"to_currency"`
+	Rate         money.Decimal `json:"rate"`
+	RateDate     time.Time     `json:"rate_date"`
+	Source       string        `json:"source"`
+	CreatedBy    string        `json:"created_by"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+// NewExchangeRate validates and creates an exchange rate for a calendar day
+func NewExchangeRate(tenantID, from, to string, rate money.Decimal, rateDate time.Time, source, createdBy string) (*ExchangeRate, error) {
+	fromCode, err := money.NormalizeCurrency(from)
+	if err != nil {
+		return nil, err
+	}
+	toCode, err := money.NormalizeCurrency(to)
+	if err != nil {
+		return nil, err
+	}
+	if from == "" || to == "" || fromCode == toCode {
+		return nil, fmt.Errorf("%w: two different currencies are required", ErrInvalidExchangeRate)
+	}
+	if rate.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: rate must be positive", ErrInvalidExchangeRate)
+	}
+	if rateDate.IsZero() {
+		return nil, fmt.Errorf("%w: rate date is required", ErrInvalidExchangeRate)
+	}
+
+	now := time.Now()
+	return &ExchangeRate{
+		ID:           ksuid.New().String(),
+		TenantID:     tenantID,
+		FromCurrency: fromCode,
+		ToCurrency:   toCode,
+		Rate:         rate.Round(RateScale),
+		RateDate:     TruncateToDay(rateDate),
+		Source:       source,
+		CreatedBy:    createdBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Inverse returns the rate converting the other way
+func (r *ExchangeRate) Inverse() *ExchangeRate {
+	inverse := *r
+	inverse.FromCurrency, inverse.ToCurrency = r.ToCurrency, r.FromCurrency
+	inverse.Rate = money.NewFromInt(1).Div(r.Rate, RateScale)
+	return &inverse
+}
+
+// TruncateToDay returns the UTC calendar day of t
+func TruncateToDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Conversion records how an amount was converted between currencies
+type Conversion struct {
+	Amount     money.Decimal `json:"amount"`
+	Currency   string        `json:"currency"`
+	Converted  money.Decimal `json:"converted"`
+	ToCurrency string        `json:"to_currency"`
+	Rate       money.Decimal `json:"rate"`
+	RateDate   time.Time     `json:"rate_date"` // Date of the rate used, on or before the requested date
+}
+
+// ExchangeRateFilter narrows an exchange rate listing
+type ExchangeRateFilter struct {
+	Currency string     // Either side of the pair
+	From     *time.Time // Rate dates on or after
+	To       *time.Time // Rate dates on or before
+}
+
+// ExchangeRateRepository defines persistence for exchange rates
+type ExchangeRateRepository interface {
+	// Upsert stores rates, replacing any rate of the same pair and day
+	Upsert(ctx context.Context, rates []*ExchangeRate) error
+
+	// List retrieves rates, newest first
+	List(ctx context.Context, tenantID string, filter ExchangeRateFilter) ([]*ExchangeRate, error)
+
+	// RateOn retrieves the latest rate of a pair dated on or before the given day
+	RateOn(ctx context.Context, tenantID, from, to string, day time.Time) (*ExchangeRate, error)
+
+	// Delete removes a rate
+	Delete(ctx context.Context, tenantID, id string) error
+}
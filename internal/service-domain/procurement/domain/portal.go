@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	quoteDomain "github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+)
+
+var (
+	ErrNotSupplierUser       = errors.New("supplier portal requires a supplier organization user")
+	ErrSupplierNotLinked     = errors.New("organization is not linked to a supplier for this tenant")
+	ErrRFQNotOpen            = errors.New("rfq is not open for quotes")
+	ErrQuoteExists           = errors.New("supplier already has an active quote for this rfq")
+	ErrQuoteNotOwned         = errors.New("quote does not belong to this supplier")
+	ErrInvalidQuoteItems     = errors.New("quote items do not match the rfq")
+	ErrQuoteValidityTooShort = errors.New("quote must remain valid until the response deadline")
+	ErrQuoteStaleVersion     = errors.New("quote responds to an outdated rfq version")
+	ErrQuoteNotEditable      = errors.New("quote can no longer be changed")
+)
+
+// EnsureOpenForQuotes checks that suppliers can still respond to the RFQ,
+// using the same bid window rules as quote submission
+func EnsureOpenForQuotes(rfq *rfqDomain.RFQ, now time.Time) error {
+	window := quoteDomain.BidWindow{
+		RFQID:            rfq.ID,
+		Status:           string(rfq.Status),
+		ResponseDeadline: rfq.ResponseDeadline,
+	}
+	if err := window.AcceptsQuotes(now); err != nil {
+		return fmt.Errorf("%w: %v", ErrRFQNotOpen, err)
+	}
+	return nil
+}
+
+// EnsureCurrentVersion checks that a quote responds to the RFQ as amended
+// by its latest addendum
+func EnsureCurrentVersion(rfq *rfqDomain.RFQ, q *quoteDomain.Quote) error {
+	if q.RFQVersion != rfq.Version {
+		return fmt.Errorf("%w: quote is for version %d, rfq is at version %d", ErrQuoteStaleVersion, q.RFQVersion, rfq.Version)
+	}
+	return nil
+}
+
+// ValidateQuoteItems checks quote lines against the RFQ: every line must
+// reference a distinct RFQ item and may not offer more than the requested
+// quantity. Partial bids covering a subset of RFQ items are allowed.
+func ValidateQuoteItems(rfq *rfqDomain.RFQ, items []quoteDomain.QuoteItem) error {
+	if len(items) == 0 {
+		return quoteDomain.ErrNoItems
+	}
+
+	rfqItems := make(map[string]rfqDomain.RFQItem, len(rfq.Items))
+	for _, item := range rfq.Items {
+		rfqItems[item.ID] = item
+	}
+
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		rfqItem, ok := rfqItems[item.RFQItemID]
+		if !ok {
+			return fmt.Errorf("%w: unknown rfq item %q", ErrInvalidQuoteItems, item.RFQItemID)
+		}
+		if seen[item.RFQItemID] {
+			return fmt.Errorf("%w: rfq item %q quoted more than once", ErrInvalidQuoteItems, item.RFQItemID)
+		}
+		seen[item.RFQItemID] = true
+
+		if item.Quantity <= 0 || item.Quantity > rfqItem.Quantity {
+			return fmt.Errorf("%w: quantity for %q must be between 1 and %d", ErrInvalidQuoteItems, rfqItem.Name, rfqItem.Quantity)
+		}
+		if item.UnitPrice < 0 {
+			return fmt.Errorf("%w: unit price for %q cannot be negative", ErrInvalidQuoteItems, rfqItem.Name)
+		}
+	}
+	return nil
+}
+
+// IsActiveQuote reports whether a quote still competes for the RFQ
+func IsActiveQuote(q *quoteDomain.Quote) bool {
+	switch q.Status {
+	case quoteDomain.QuoteStatusWithdrawn, quoteDomain.QuoteStatusRejected, quoteDomain.QuoteStatusExpired:
+		return false
+	}
+	return true
+}
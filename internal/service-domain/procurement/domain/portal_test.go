@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	quoteDomain "github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	rfqDomain "github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+)
+
+func TestValidateQuoteItems(t *testing.T) {
+	rfq := &rfqDomain.RFQ{
+		Items: []rfqDomain.RFQItem{
+			{ID: "item-1", Name: "Ultrasound", Quantity: 2},
+			{ID: "item-2", Name: "Probe", Quantity: 4},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		items   []quoteDomain.QuoteItem
+		wantErr error
+	}{
+		{"partial bid", []quoteDomain.QuoteItem{{RFQItemID: "item-1", Quantity: 2, UnitPrice: 100}}, nil},
+		{"no items", nil, quoteDomain.ErrNoItems},
+		{"unknown item", []quoteDomain.QuoteItem{{RFQItemID: "item-9", Quantity: 1}}, ErrInvalidQuoteItems},
+		{"duplicate item", []quoteDomain.QuoteItem{
+			{RFQItemID: "item-2", Quantity: 1},
+			{RFQItemID: "item-2", Quantity: 1},
+		}, ErrInvalidQuoteItems},
+		{"quantity above request", []quoteDomain.QuoteItem{{RFQItemID: "item-1", Quantity: 3}}, ErrInvalidQuoteItems},
+		{"negative price", []quoteDomain.QuoteItem{{RFQItemID: "item-1", Quantity: 1, UnitPrice: -5}}, ErrInvalidQuoteItems},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQuoteItems(rfq, tt.items)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEnsureOpenForQuotes(t *testing.T) {
+	now := time.Now()
+	rfq := &rfqDomain.RFQ{Status: rfqDomain.RFQStatusPublished, ResponseDeadline: now.Add(time.Hour)}
+	if err := EnsureOpenForQuotes(rfq, now); err != nil {
+		t.Fatalf("expected open rfq, got %v", err)
+	}
+
+	if err := EnsureOpenForQuotes(rfq, now.Add(2*time.Hour)); !errors.Is(err, ErrRFQNotOpen) {
+		t.Fatalf("expected late submission to be rejected, got %v", err)
+	}
+
+	rfq.Status = rfqDomain.RFQStatusClosed
+	if err := EnsureOpenForQuotes(rfq, now); !errors.Is(err, ErrRFQNotOpen) {
+		t.Fatalf("expected closed rfq to be rejected, got %v", err)
+	}
+}
+
+func TestEnsureCurrentVersion(t *testing.T) {
+	rfq := &rfqDomain.RFQ{Version: 1}
+	quote := &quoteDomain.Quote{RFQVersion: 1}
+	if err := EnsureCurrentVersion(rfq, quote); err != nil {
+		t.Fatalf("expected current quote, got %v", err)
+	}
+
+	rfq.Version = 2
+	if err := EnsureCurrentVersion(rfq, quote); !errors.Is(err, ErrQuoteStaleVersion) {
+		t.Fatalf("expected quote for superseded version to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,467 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	contractDomain "github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrPurchaseOrderNotFound  = errors.New("purchase order not found")
+	ErrInvalidPurchaseOrder   = errors.New("invalid purchase order")
+	ErrCallOffExceedsContract = errors.New("call-off exceeds the quantity left on the contract")
+	ErrPurchaseOrderClosed    = errors.New("purchase order is closed or cancelled")
+	ErrInvalidReceipt         = errors.New("invalid goods receipt")
+	ErrInvalidInvoice         = errors.New("invalid supplier invoice")
+	ErrDuplicateInvoice       = errors.New("supplier invoice already recorded")
+	ErrPurchaseOrderConflict  = errors.New("purchase order changed concurrently, retry")
+)
+
+// DefaultPriceTolerance is the relative difference between invoiced and
+// ordered unit prices that still matches
+const DefaultPriceTolerance = 0.01
+
+// PurchaseOrderStatus represents the lifecycle status of a purchase order
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusOpen              PurchaseOrderStatus = "open"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderStatusClosed            PurchaseOrderStatus = "closed" // Received in full and invoiced
+	PurchaseOrderStatusCancelled         PurchaseOrderStatus = "cancelled"
+)
+
+// PurchaseOrderLine is a call-off of a contract item
+type PurchaseOrderLine struct {
+	ID               string  `json:"id"`
+	ContractItemID   string  `json:"contract_item_id"`
+	ContractQuantity int     `json:"contract_quantity"` // Quantity on the contract, the cap across call-offs
+	EquipmentID      string  `json:"equipment_id,omitempty"`
+	EquipmentName    string  `json:"equipment_name"`
+	ManufacturerName string  `json:"manufacturer_name,omitempty"`
+	ModelNumber      string  `json:"model_number,omitempty"`
+	WarrantyMonths   int     `json:"warranty_months,omitempty"`
+	Quantity         int     `json:"quantity"`
+	UnitPrice        float64 `json:"unit_price"`
+	LineTotal        float64 `json:"line_total"`
+	ReceivedQuantity int     `json:"received_quantity"`
+	InvoicedQuantity int     `json:"invoiced_quantity"` // On matched invoices
+}
+
+// PurchaseOrder calls off contract items for delivery
+type PurchaseOrder struct {
+	ID             string              `json:"id"`
+	TenantID       string              `json:"tenant_id"`
+	PONumber       string              `json:"po_number"`
+	ContractID     string              `json:"contract_id"`
+	ContractNumber string              `json:"contract_number"`
+	SupplierID     string              `json:"supplier_id"`
+	SupplierName   string              `json:"supplier_name"`
+	Currency       string              `json:"currency"`
+	Status         PurchaseOrderStatus `json:"status"`
+	Lines          []PurchaseOrderLine `json:"lines"`
+	TotalAmount    float64             `json:"total_amount"`
+	DeliveryDate   *time.Time          `json:"delivery_date,omitempty"`
+	Notes          string              `json:"notes,omitempty"`
+	CreatedBy      string              `json:"created_by"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	Revision       int                 `json:"revision"` // Bumped on every update
+}
+
+// CallOff asks for a quantity of a contract item
+type CallOff struct {
+	ContractItemID string `json:"contract_item_id"`
+	Quantity       int    `json:"quantity"`
+}
+
+// NewPurchaseOrder creates a purchase order calling off contract items.
+// ordered holds the quantities of each contract item already on open purchase
+// orders; no call-offs means everything left on the contract.
+func NewPurchaseOrder(contract *contractDomain.Contract, poNumber string, callOffs []CallOff, ordered map[string]int, createdBy string) (*PurchaseOrder, error) {
+	if contract.Status != contractDomain.ContractStatusActive {
+		return nil, fmt.Errorf("%w: contract is %s", ErrInvalidPurchaseOrder, contract.Status)
+	}
+
+	if len(callOffs) == 0 {
+		for _, item := range contract.Items {
+			if left := item.Quantity - ordered[item.ID]; left > 0 {
+				callOffs = append(callOffs, CallOff{ContractItemID: item.ID, Quantity: left})
+			}
+		}
+		if len(callOffs) == 0 {
+			return nil, fmt.Errorf("%w: every contract item is already ordered", ErrCallOffExceedsContract)
+		}
+	}
+
+	now := time.Now()
+	po := &PurchaseOrder{
+		ID:             ksuid.New().String(),
+		TenantID:       contract.TenantID,
+		PONumber:       poNumber,
+		ContractID:     contract.ID,
+		ContractNumber: contract.ContractNumber,
+		SupplierID:     contract.SupplierID,
+		SupplierName:   contract.SupplierName,
+		Currency:       contract.Currency,
+		Status:         PurchaseOrderStatusOpen,
+		Lines:          []PurchaseOrderLine{},
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	requested := map[string]int{}
+	total := money.Decimal{}
+	for _, callOff := range callOffs {
+		item, ok := contract.Item(callOff.ContractItemID)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not an item of contract %s", ErrInvalidPurchaseOrder, callOff.ContractItemID, contract.ContractNumber)
+		}
+		if callOff.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidPurchaseOrder)
+		}
+		requested[item.ID] += callOff.Quantity
+		if left := item.Quantity - ordered[item.ID]; requested[item.ID] > left {
+			return nil, fmt.Errorf("%w: %d of %s left, %d requested", ErrCallOffExceedsContract, left, item.EquipmentName, requested[item.ID])
+		}
+
+		lineTotal := money.RoundTo(money.NewFromFloat(item.UnitPrice).Mul(money.NewFromInt(int64(callOff.Quantity))), po.Currency)
+		po.Lines = append(po.Lines, PurchaseOrderLine{
+			ID:               ksuid.New().String(),
+			ContractItemID:   item.ID,
+			ContractQuantity: item.Quantity,
+			EquipmentID:      item.EquipmentID,
+			EquipmentName:    item.EquipmentName,
+			ManufacturerName: item.ManufacturerName,
+			ModelNumber:      item.ModelNumber,
+			WarrantyMonths:   item.WarrantyMonths(),
+			Quantity:         callOff.Quantity,
+			UnitPrice:        item.UnitPrice,
+			LineTotal:        lineTotal.Float64(),
+		})
+		total = total.Add(lineTotal)
+	}
+	po.TotalAmount = total.Float64()
+	return po, nil
+}
+
+// Line returns the purchase order line with the given ID
+func (po *PurchaseOrder) Line(id string) (*PurchaseOrderLine, bool) {
+	for i := range po.Lines {
+		if po.Lines[i].ID == id {
+			return &po.Lines[i], true
+		}
+	}
+	return nil, false
+}
+
+// IsOpen reports whether goods can still be received and invoiced against the order
+func (po *PurchaseOrder) IsOpen() bool {
+	return po.Status != PurchaseOrderStatusClosed && po.Status != PurchaseOrderStatusCancelled
+}
+
+// Cancel cancels a purchase order nothing has been received against,
+// returning its quantities to the contract
+func (po *PurchaseOrder) Cancel(now time.Time) error {
+	if po.Status != PurchaseOrderStatusOpen {
+		return fmt.Errorf("%w: only open purchase orders with nothing received can be cancelled", ErrPurchaseOrderClosed)
+	}
+	po.Status = PurchaseOrderStatusCancelled
+	po.UpdatedAt = now
+	return nil
+}
+
+// ReceivedUnit is one unit received on a goods receipt
+type ReceivedUnit struct {
+	SerialNumber string `json:"serial_number"`
+	EquipmentID  string `json:"equipment_id,omitempty"` // Installed-base record created for the unit
+}
+
+// ReceiptLine records the units received against a purchase order line
+type ReceiptLine struct {
+	POLineID string         `json:"po_line_id"`
+	Quantity int            `json:"quantity"`
+	Units    []ReceivedUnit `json:"units"`
+}
+
+// GoodsReceipt is a goods receipt note recording what arrived against a purchase order
+type GoodsReceipt struct {
+	ID                   string        `json:"id"`
+	TenantID             string        `json:"tenant_id"`
+	PurchaseOrderID      string        `json:"purchase_order_id"`
+	ReceiptNumber        string        `json:"receipt_number"`
+	ReceivedAt           time.Time     `json:"received_at"`
+	ReceivedBy           string        `json:"received_by"`
+	InstallationLocation string        `json:"installation_location,omitempty"`
+	Lines                []ReceiptLine `json:"lines"`
+	Notes                string        `json:"notes,omitempty"`
+	CreatedAt            time.Time     `json:"created_at"`
+}
+
+// ReceiveGoods checks a goods receipt against the open quantities of the
+// order and records it. Every unit needs a serial number, unique on the
+// receipt, so it can be registered in the installed base.
+func (po *PurchaseOrder) ReceiveGoods(receipt *GoodsReceipt) error {
+	if !po.IsOpen() {
+		return ErrPurchaseOrderClosed
+	}
+	if len(receipt.Lines) == 0 {
+		return fmt.Errorf("%w: nothing received", ErrInvalidReceipt)
+	}
+
+	serials := map[string]bool{}
+	receiving := map[string]int{}
+	for i, rl := range receipt.Lines {
+		line, ok := po.Line(rl.POLineID)
+		if !ok {
+			return fmt.Errorf("%w: %s is not a line of purchase order %s", ErrInvalidReceipt, rl.POLineID, po.PONumber)
+		}
+		if rl.Quantity <= 0 {
+			return fmt.Errorf("%w: quantity must be positive", ErrInvalidReceipt)
+		}
+		if len(rl.Units) != rl.Quantity {
+			return fmt.Errorf("%w: %d serial numbers for %d units of %s", ErrInvalidReceipt, len(rl.Units), rl.Quantity, line.EquipmentName)
+		}
+		for j, unit := range rl.Units {
+			serial := strings.TrimSpace(unit.SerialNumber)
+			if serial == "" {
+				return fmt.Errorf("%w: every unit needs a serial number", ErrInvalidReceipt)
+			}
+			if serials[serial] {
+				return fmt.Errorf("%w: serial number %s received twice", ErrInvalidReceipt, serial)
+			}
+			serials[serial] = true
+			receipt.Lines[i].Units[j].SerialNumber = serial
+		}
+		receiving[line.ID] += rl.Quantity
+		if open := line.Quantity - line.ReceivedQuantity; receiving[line.ID] > open {
+			return fmt.Errorf("%w: %d of %s open, %d received", ErrInvalidReceipt, open, line.EquipmentName, receiving[line.ID])
+		}
+	}
+
+	for id, quantity := range receiving {
+		line, _ := po.Line(id)
+		line.ReceivedQuantity += quantity
+	}
+	receipt.TenantID = po.TenantID
+	receipt.PurchaseOrderID = po.ID
+	po.updateStatus()
+	po.UpdatedAt = receipt.CreatedAt
+	return nil
+}
+
+// WarrantyExpiry returns when the warranty of a unit received on the line
+// runs out, counted from the receipt date
+func (l PurchaseOrderLine) WarrantyExpiry(receivedAt time.Time) *time.Time {
+	if l.WarrantyMonths <= 0 {
+		return nil
+	}
+	expiry := receivedAt.AddDate(0, l.WarrantyMonths, 0)
+	return &expiry
+}
+
+// updateStatus derives the status from the received and invoiced quantities
+func (po *PurchaseOrder) updateStatus() {
+	received, invoiced := true, true
+	anyReceived := false
+	for _, line := range po.Lines {
+		if line.ReceivedQuantity < line.Quantity {
+			received = false
+		}
+		if line.InvoicedQuantity < line.Quantity {
+			invoiced = false
+		}
+		if line.ReceivedQuantity > 0 {
+			anyReceived = true
+		}
+	}
+	switch {
+	case received && invoiced:
+		po.Status = PurchaseOrderStatusClosed
+	case received:
+		po.Status = PurchaseOrderStatusReceived
+	case anyReceived:
+		po.Status = PurchaseOrderStatusPartiallyReceived
+	}
+}
+
+// InvoiceStatus is the outcome of matching a supplier invoice
+type InvoiceStatus string
+
+const (
+	InvoiceStatusMatched   InvoiceStatus = "matched"   // Ready for payment
+	InvoiceStatusException InvoiceStatus = "exception" // Held until the differences are resolved
+)
+
+// InvoiceLine is an invoiced quantity and price of a purchase order line
+type InvoiceLine struct {
+	POLineID  string  `json:"po_line_id"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+}
+
+// LineMatch compares one purchase order line across order, receipts and invoice
+type LineMatch struct {
+	POLineID           string   `json:"po_line_id"`
+	EquipmentName      string   `json:"equipment_name"`
+	Ordered            int      `json:"ordered"`
+	Received           int      `json:"received"`
+	PreviouslyInvoiced int      `json:"previously_invoiced"`
+	Invoiced           int      `json:"invoiced"`
+	OrderedUnitPrice   float64  `json:"ordered_unit_price"`
+	InvoicedUnitPrice  float64  `json:"invoiced_unit_price"`
+	Matched            bool     `json:"matched"`
+	Issues             []string `json:"issues,omitempty"`
+}
+
+// SupplierInvoice is a supplier's invoice against a purchase order, with the
+// outcome of matching it to the order and its goods receipts
+type SupplierInvoice struct {
+	ID              string        `json:"id"`
+	TenantID        string        `json:"tenant_id"`
+	PurchaseOrderID string        `json:"purchase_order_id"`
+	SupplierID      string        `json:"supplier_id"`
+	InvoiceNumber   string        `json:"invoice_number"`
+	InvoiceDate     time.Time     `json:"invoice_date"`
+	Currency        string        `json:"currency"`
+	Lines           []InvoiceLine `json:"lines"`
+	TaxAmount       float64       `json:"tax_amount"`
+	TotalAmount     float64       `json:"total_amount"` // Lines plus tax
+	Status          InvoiceStatus `json:"status"`
+	Match           []LineMatch   `json:"match"`
+	RecordedBy      string        `json:"recorded_by"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
+// MatchInvoice matches a supplier invoice three ways: each invoiced quantity,
+// with what earlier matched invoices billed, must not exceed what was received,
+// and each unit price must be within tolerance of the order price. A matched
+// invoice adds its quantities to the order; an exception leaves it unchanged.
+func (po *PurchaseOrder) MatchInvoice(invoice *SupplierInvoice, tolerance float64) error {
+	if po.Status == PurchaseOrderStatusCancelled {
+		return ErrPurchaseOrderClosed
+	}
+	if strings.TrimSpace(invoice.InvoiceNumber) == "" {
+		return fmt.Errorf("%w: invoice number is required", ErrInvalidInvoice)
+	}
+	if len(invoice.Lines) == 0 {
+		return fmt.Errorf("%w: invoice has no lines", ErrInvalidInvoice)
+	}
+	if invoice.Currency == "" {
+		invoice.Currency = po.Currency
+	}
+	if invoice.Currency != po.Currency {
+		return fmt.Errorf("%w: invoiced in %s, ordered in %s", ErrInvalidInvoice, invoice.Currency, po.Currency)
+	}
+
+	invoicing := map[string]int{}
+	total := money.NewFromFloat(invoice.TaxAmount)
+	invoice.Match = []LineMatch{}
+	matched := true
+	for _, il := range invoice.Lines {
+		line, ok := po.Line(il.POLineID)
+		if !ok {
+			return fmt.Errorf("%w: %s is not a line of purchase order %s", ErrInvalidInvoice, il.POLineID, po.PONumber)
+		}
+		if il.Quantity <= 0 || il.UnitPrice < 0 {
+			return fmt.Errorf("%w: lines need a positive quantity and a price", ErrInvalidInvoice)
+		}
+		invoicing[line.ID] += il.Quantity
+		total = total.Add(money.NewFromFloat(il.UnitPrice).Mul(money.NewFromInt(int64(il.Quantity))))
+
+		m := LineMatch{
+			POLineID:           line.ID,
+			EquipmentName:      line.EquipmentName,
+			Ordered:            line.Quantity,
+			Received:           line.ReceivedQuantity,
+			PreviouslyInvoiced: line.InvoicedQuantity,
+			Invoiced:           il.Quantity,
+			OrderedUnitPrice:   line.UnitPrice,
+			InvoicedUnitPrice:  il.UnitPrice,
+		}
+		if billed := line.InvoicedQuantity + invoicing[line.ID]; billed > line.ReceivedQuantity {
+			m.Issues = append(m.Issues, fmt.Sprintf("%d billed in total but %d received", billed, line.ReceivedQuantity))
+		}
+		if !withinTolerance(il.UnitPrice, line.UnitPrice, tolerance) {
+			m.Issues = append(m.Issues, fmt.Sprintf("unit price %s differs from ordered %s",
+				money.NewFromFloat(il.UnitPrice).StringFixed(money.MinorUnits(po.Currency)),
+				money.NewFromFloat(line.UnitPrice).StringFixed(money.MinorUnits(po.Currency))))
+		}
+		m.Matched = len(m.Issues) == 0
+		matched = matched && m.Matched
+		invoice.Match = append(invoice.Match, m)
+	}
+
+	invoice.TenantID = po.TenantID
+	invoice.PurchaseOrderID = po.ID
+	invoice.SupplierID = po.SupplierID
+	invoice.TotalAmount = money.RoundTo(total, po.Currency).Float64()
+	if !matched {
+		invoice.Status = InvoiceStatusException
+		return nil
+	}
+
+	invoice.Status = InvoiceStatusMatched
+	for id, quantity := range invoicing {
+		line, _ := po.Line(id)
+		line.InvoicedQuantity += quantity
+	}
+	po.updateStatus()
+	po.UpdatedAt = invoice.CreatedAt
+	return nil
+}
+
+// withinTolerance reports whether price is within the relative tolerance of expected
+func withinTolerance(price, expected, tolerance float64) bool {
+	diff := money.NewFromFloat(price).Sub(money.NewFromFloat(expected)).Abs()
+	allowed := money.NewFromFloat(expected).Abs().Mul(money.NewFromFloat(tolerance))
+	return diff.Cmp(allowed) <= 0
+}
+
+// InstalledBaseRegistrar creates installed-base equipment records for received units
+type InstalledBaseRegistrar interface {
+	// RegisterReceivedUnits registers each unit on the receipt, or links an
+	// existing record of the same serial number, and sets the units' equipment IDs
+	RegisterReceivedUnits(ctx context.Context, po *PurchaseOrder, receipt *GoodsReceipt, customerName string) error
+}
+
+// PurchaseOrderRepository persists purchase orders, goods receipts and supplier invoices
+type PurchaseOrderRepository interface {
+	// CreatePurchaseOrder stores a purchase order, failing with
+	// ErrCallOffExceedsContract when concurrent call-offs used up the quantities
+	CreatePurchaseOrder(ctx context.Context, po *PurchaseOrder) error
+
+	// GetPurchaseOrder retrieves a purchase order
+	GetPurchaseOrder(ctx context.Context, tenantID, id string) (*PurchaseOrder, error)
+
+	// ListPurchaseOrders retrieves a contract's purchase orders, newest first
+	ListPurchaseOrders(ctx context.Context, tenantID, contractID string) ([]*PurchaseOrder, error)
+
+	// OrderedQuantities sums the quantities of each contract item on the
+	// contract's purchase orders that are not cancelled
+	OrderedQuantities(ctx context.Context, tenantID, contractID string) (map[string]int, error)
+
+	// UpdatePurchaseOrder saves a purchase order unchanged since it was read
+	UpdatePurchaseOrder(ctx context.Context, po *PurchaseOrder) error
+
+	// SaveReceipt stores a goods receipt with the purchase order it updated
+	SaveReceipt(ctx context.Context, po *PurchaseOrder, receipt *GoodsReceipt) error
+
+	// ListReceipts retrieves a purchase order's goods receipts, oldest first
+	ListReceipts(ctx context.Context, tenantID, poID string) ([]*GoodsReceipt, error)
+
+	// SaveInvoice stores a supplier invoice with the purchase order it
+	// updated, failing with ErrDuplicateInvoice for a repeated invoice number
+	SaveInvoice(ctx context.Context, po *PurchaseOrder, invoice *SupplierInvoice) error
+
+	// ListInvoices retrieves a purchase order's supplier invoices, oldest first
+	ListInvoices(ctx context.Context, tenantID, poID string) ([]*SupplierInvoice, error)
+}
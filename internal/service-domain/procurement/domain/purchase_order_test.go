@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	contractDomain "github.com/aby-med/medical-platform/internal/service-domain/contract/domain"
+)
+
+func activeContract(t *testing.T) *contractDomain.Contract {
+	t.Helper()
+	contract := contractDomain.NewContract("tenant-1", "rfq-1", "quote-1", "supplier-1", "Acme Medical", "buyer")
+	contract.ID = "contract-1"
+	for _, item := range []contractDomain.ContractItem{
+		{ID: "item-1", EquipmentName: "Ultrasound", Quantity: 3, UnitPrice: 1000, WarrantyPeriod: "2 years"},
+		{ID: "item-2", EquipmentName: "Probe", Quantity: 4, UnitPrice: 250},
+	} {
+		if err := contract.AddItem(item); err != nil {
+			t.Fatalf("add item: %v", err)
+		}
+	}
+	contract.Status = contractDomain.ContractStatusActive
+	return contract
+}
+
+func TestNewPurchaseOrderCallOffs(t *testing.T) {
+	contract := activeContract(t)
+
+	po, err := NewPurchaseOrder(contract, "PO-1", []CallOff{{ContractItemID: "item-1", Quantity: 2}}, nil, "buyer")
+	if err != nil {
+		t.Fatalf("partial call-off: %v", err)
+	}
+	if len(po.Lines) != 1 || po.TotalAmount != 2000 || po.Lines[0].WarrantyMonths != 24 {
+		t.Fatalf("unexpected order: %+v", po)
+	}
+
+	ordered := map[string]int{"item-1": 2}
+	if _, err := NewPurchaseOrder(contract, "PO-2", []CallOff{{ContractItemID: "item-1", Quantity: 2}}, ordered, "buyer"); !errors.Is(err, ErrCallOffExceedsContract) {
+		t.Fatalf("expected ErrCallOffExceedsContract, got %v", err)
+	}
+
+	rest, err := NewPurchaseOrder(contract, "PO-3", nil, ordered, "buyer")
+	if err != nil {
+		t.Fatalf("remaining call-off: %v", err)
+	}
+	if len(rest.Lines) != 2 || rest.Lines[0].Quantity != 1 || rest.Lines[1].Quantity != 4 {
+		t.Fatalf("expected the remaining quantities, got %+v", rest.Lines)
+	}
+
+	contract.Status = contractDomain.ContractStatusDraft
+	if _, err := NewPurchaseOrder(contract, "PO-4", nil, nil, "buyer"); !errors.Is(err, ErrInvalidPurchaseOrder) {
+		t.Fatalf("expected ErrInvalidPurchaseOrder for a draft contract, got %v", err)
+	}
+}
+
+func TestReceiveGoodsAndMatchInvoice(t *testing.T) {
+	po, err := NewPurchaseOrder(activeContract(t), "PO-1", []CallOff{{ContractItemID: "item-1", Quantity: 2}}, nil, "buyer")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	lineID := po.Lines[0].ID
+	receivedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	missingSerial := &GoodsReceipt{ReceivedAt: receivedAt, Lines: []ReceiptLine{
+		{POLineID: lineID, Quantity: 2, Units: []ReceivedUnit{{SerialNumber: "SN-1"}}},
+	}}
+	if err := po.ReceiveGoods(missingSerial); !errors.Is(err, ErrInvalidReceipt) {
+		t.Fatalf("expected ErrInvalidReceipt for a missing serial, got %v", err)
+	}
+
+	receipt := &GoodsReceipt{ReceivedAt: receivedAt, Lines: []ReceiptLine{
+		{POLineID: lineID, Quantity: 1, Units: []ReceivedUnit{{SerialNumber: "SN-1"}}},
+	}}
+	if err := po.ReceiveGoods(receipt); err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+	if po.Status != PurchaseOrderStatusPartiallyReceived {
+		t.Fatalf("expected partially_received, got %s", po.Status)
+	}
+	if expiry := po.Lines[0].WarrantyExpiry(receivedAt); expiry == nil || !expiry.Equal(receivedAt.AddDate(2, 0, 0)) {
+		t.Fatalf("expected warranty to run two years from receipt, got %v", expiry)
+	}
+
+	// Billing more than was received is an exception and leaves the order as it was
+	overbilled := &SupplierInvoice{InvoiceNumber: "INV-1", Lines: []InvoiceLine{{POLineID: lineID, Quantity: 2, UnitPrice: 1000}}}
+	if err := po.MatchInvoice(overbilled, DefaultPriceTolerance); err != nil {
+		t.Fatalf("match: %v", err)
+	}
+	if overbilled.Status != InvoiceStatusException || po.Lines[0].InvoicedQuantity != 0 {
+		t.Fatalf("expected an exception, got %s with %d invoiced", overbilled.Status, po.Lines[0].InvoicedQuantity)
+	}
+
+	overpriced := &SupplierInvoice{InvoiceNumber: "INV-2", Lines: []InvoiceLine{{POLineID: lineID, Quantity: 1, UnitPrice: 1020}}}
+	if err := po.MatchInvoice(overpriced, DefaultPriceTolerance); err != nil || overpriced.Status != InvoiceStatusException {
+		t.Fatalf("expected a price exception, got %s (%v)", overpriced.Status, err)
+	}
+
+	withinTolerance := &SupplierInvoice{InvoiceNumber: "INV-3", TaxAmount: 50, Lines: []InvoiceLine{{POLineID: lineID, Quantity: 1, UnitPrice: 1005}}}
+	if err := po.MatchInvoice(withinTolerance, DefaultPriceTolerance); err != nil || withinTolerance.Status != InvoiceStatusMatched {
+		t.Fatalf("expected a match, got %s (%v)", withinTolerance.Status, err)
+	}
+	if withinTolerance.TotalAmount != 1055 || po.Lines[0].InvoicedQuantity != 1 {
+		t.Fatalf("unexpected total %v or invoiced %d", withinTolerance.TotalAmount, po.Lines[0].InvoicedQuantity)
+	}
+
+	second := &GoodsReceipt{ReceivedAt: receivedAt, Lines: []ReceiptLine{
+		{POLineID: lineID, Quantity: 1, Units: []ReceivedUnit{{SerialNumber: "SN-2"}}},
+	}}
+	if err := po.ReceiveGoods(second); err != nil {
+		t.Fatalf("receive rest: %v", err)
+	}
+	last := &SupplierInvoice{InvoiceNumber: "INV-4", Lines: []InvoiceLine{{POLineID: lineID, Quantity: 1, UnitPrice: 1000}}}
+	if err := po.MatchInvoice(last, DefaultPriceTolerance); err != nil || last.Status != InvoiceStatusMatched {
+		t.Fatalf("expected a match, got %s (%v)", last.Status, err)
+	}
+	if po.Status != PurchaseOrderStatusClosed {
+		t.Fatalf("expected closed once received and invoiced in full, got %s", po.Status)
+	}
+	if err := po.ReceiveGoods(second); !errors.Is(err, ErrPurchaseOrderClosed) {
+		t.Fatalf("expected ErrPurchaseOrderClosed, got %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// AuctionRepository implements domain.AuctionRepository using PostgreSQL
+type AuctionRepository struct {
+	db     *PostgresDB
+	logger *slog.Logger
+}
+
+// NewAuctionRepository creates a new auction repository
+func NewAuctionRepository(db *PostgresDB, logger *slog.Logger) *AuctionRepository {
+	return &AuctionRepository{
+		db:     db,
+		logger: logger.With(slog.String("component", "auction_repository")),
+	}
+}
+
+const auctionColumns = `
+	id, tenant_id, rfq_id, status, currency, starts_at, ends_at, scheduled_end_at,
+	min_decrement, extension_seconds, max_extensions, extensions, participants,
+	COALESCE(comparison_id, ''), created_by, created_at, updated_at, closed_at`
+
+// Create persists a new auction
+func (r *AuctionRepository) Create(ctx context.Context, a *domain.Auction) error {
+	participantsJSON, err := json.Marshal(a.Participants)
+	if err != nil {
+		return fmt.Errorf("failed to marshal auction participants: %w", err)
+	}
+
+	query := `
+		INSERT INTO rfq_auctions (
+			id, tenant_id, rfq_id, status, currency, starts_at, ends_at, scheduled_end_at,
+			min_decrement, extension_seconds, max_extensions, extensions, bid_count, participants,
+			created_by, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, 0, $13, $14, $15, $16)
+	`
+	_, err = r.db.Pool().Exec(ctx, query,
+		a.ID, a.TenantID, a.RFQID, a.Status, a.Currency, a.StartsAt, a.EndsAt, a.ScheduledEndAt,
+		a.MinDecrement, a.ExtensionSeconds, a.MaxExtensions, a.Extensions, participantsJSON,
+		a.CreatedBy, a.CreatedAt, a.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create auction: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an auction with its bids
+func (r *AuctionRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM rfq_auctions WHERE id = $1 AND tenant_id = $2`
+	a, err := scanAuction(r.db.Pool().QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAuctionNotFound
+		}
+		return nil, fmt.Errorf("failed to get auction: %w", err)
+	}
+	if err := r.loadBids(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// ListByRFQ retrieves the auctions of an RFQ, newest first
+func (r *AuctionRepository) ListByRFQ(ctx context.Context, tenantID, rfqID string) ([]*domain.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM rfq_auctions WHERE rfq_id = $1 AND tenant_id = $2 ORDER BY created_at DESC`
+	return r.list(ctx, query, rfqID, tenantID)
+}
+
+// ListBySupplier retrieves the auctions a supplier participates in, newest first
+func (r *AuctionRepository) ListBySupplier(ctx context.Context, tenantID, supplierID string) ([]*domain.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM rfq_auctions
+		WHERE tenant_id = $1 AND participants @> jsonb_build_array(jsonb_build_object('supplier_id', $2::text))
+		ORDER BY created_at DESC`
+	return r.list(ctx, query, tenantID, supplierID)
+}
+
+// ListEnded retrieves auctions of all tenants whose bidding ended but which are not closed
+func (r *AuctionRepository) ListEnded(ctx context.Context, now time.Time) ([]*domain.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM rfq_auctions
+		WHERE status = 'scheduled' AND ends_at <= $1
+		ORDER BY ends_at ASC`
+	return r.list(ctx, query, now)
+}
+
+// AddBid stores the last bid of the auction together with its new end
+func (r *AuctionRepository) AddBid(ctx context.Context, a *domain.Auction) error {
+	if len(a.Bids) == 0 {
+		return fmt.Errorf("auction %s has no bid to store", a.ID)
+	}
+	bid := a.Bids[len(a.Bids)-1]
+
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// bid_count guards against a concurrent bid changing the end or ranking
+	result, err := tx.Exec(ctx, `
+		UPDATE rfq_auctions SET
+			ends_at = $1, extensions = $2, bid_count = bid_count + 1, updated_at = $3
+		WHERE id = $4 AND tenant_id = $5 AND status = 'scheduled' AND bid_count = $6
+	`, a.EndsAt, a.Extensions, a.UpdatedAt, a.ID, a.TenantID, len(a.Bids)-1)
+	if err != nil {
+		return fmt.Errorf("failed to update auction: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrAuctionConflict
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO rfq_auction_bids (id, auction_id, supplier_id, amount, placed_by, placed_at, extended)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, bid.ID, a.ID, bid.SupplierID, bid.Amount, bid.PlacedBy, bid.PlacedAt, bid.Extended)
+	if err != nil {
+		r.logger.Error("Failed to store auction bid",
+			slog.String("error", err.Error()),
+			slog.String("auction_id", a.ID))
+		return fmt.Errorf("failed to store auction bid: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Update saves the status and comparison of an auction
+func (r *AuctionRepository) Update(ctx context.Context, a *domain.Auction) error {
+	query := `
+		UPDATE rfq_auctions SET
+			status = $1, comparison_id = NULLIF($2, ''), updated_at = $3, closed_at = $4
+		WHERE id = $5 AND tenant_id = $6
+	`
+	result, err := r.db.Pool().Exec(ctx, query,
+		a.Status, a.ComparisonID, a.UpdatedAt, a.ClosedAt, a.ID, a.TenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update auction: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrAuctionNotFound
+	}
+	return nil
+}
+
+func (r *AuctionRepository) list(ctx context.Context, query string, args ...interface{}) ([]*domain.Auction, error) {
+	rows, err := r.db.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auctions: %w", err)
+	}
+
+	auctions := []*domain.Auction{}
+	for rows.Next() {
+		a, err := scanAuction(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan auction: %w", err)
+		}
+		auctions = append(auctions, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list auctions: %w", err)
+	}
+
+	for _, a := range auctions {
+		if err := r.loadBids(ctx, a); err != nil {
+			return nil, err
+		}
+	}
+	return auctions, nil
+}
+
+func (r *AuctionRepository) loadBids(ctx context.Context, a *domain.Auction) error {
+	rows, err := r.db.Pool().Query(ctx, `
+		SELECT id, auction_id, supplier_id, amount, placed_by, placed_at, extended
+		FROM rfq_auction_bids
+		WHERE auction_id = $1
+		ORDER BY placed_at ASC
+	`, a.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get auction bids: %w", err)
+	}
+	defer rows.Close()
+
+	a.Bids = []domain.AuctionBid{}
+	for rows.Next() {
+		var b domain.AuctionBid
+		if err := rows.Scan(&b.ID, &b.AuctionID, &b.SupplierID, &b.Amount, &b.PlacedBy, &b.PlacedAt, &b.Extended); err != nil {
+			return fmt.Errorf("failed to scan auction bid: %w", err)
+		}
+		a.Bids = append(a.Bids, b)
+	}
+	return rows.Err()
+}
+
+func scanAuction(row pgx.Row) (*domain.Auction, error) {
+	a := &domain.Auction{}
+	var participantsJSON []byte
+	err := row.Scan(
+		&a.ID, &a.TenantID, &a.RFQID, &a.Status, &a.Currency, &a.StartsAt, &a.EndsAt, &a.ScheduledEndAt,
+		&a.MinDecrement, &a.ExtensionSeconds, &a.MaxExtensions, &a.Extensions, &participantsJSON,
+		&a.ComparisonID, &a.CreatedBy, &a.CreatedAt, &a.UpdatedAt, &a.ClosedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(participantsJSON, &a.Participants); err != nil {
+		a.Participants = []domain.AuctionParticipant{}
+	}
+	return a, nil
+}
@@ -0,0 +1,138 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// AwardRepository implements domain.AwardRepository using PostgreSQL
+type AwardRepository struct {
+	db     *PostgresDB
+	logger *slog.Logger
+}
+
+// NewAwardRepository creates a new award repository
+func NewAwardRepository(db *PostgresDB, logger *slog.Logger) *AwardRepository {
+	return &AwardRepository{
+		db:     db,
+		logger: logger.With(slog.String("component", "award_repository")),
+	}
+}
+
+const awardColumns = `
+	id, tenant_id, rfq_id, comparison_id, quote_id, supplier_id,
+	COALESCE(contract_id, ''), status, COALESCE(reason, ''), COALESCE(error, ''),
+	steps, awarded_by, created_at, updated_at, completed_at`
+
+// Create persists a new award record
+func (r *AwardRepository) Create(ctx context.Context, award *domain.Award) error {
+	stepsJSON, err := json.Marshal(award.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal award steps: %w", err)
+	}
+
+	query := `
+		INSERT INTO procurement_awards (
+			id, tenant_id, rfq_id, comparison_id, quote_id, supplier_id,
+			contract_id, status, reason, error, steps, awarded_by,
+			created_at, updated_at, completed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, NULLIF($10, ''), $11, $12, $13, $14, $15)
+	`
+	_, err = r.db.Pool().Exec(ctx, query,
+		award.ID, award.TenantID, award.RFQID, award.ComparisonID, award.QuoteID, award.SupplierID,
+		award.ContractID, award.Status, award.Reason, award.Error, stepsJSON, award.AwardedBy,
+		award.CreatedAt, award.UpdatedAt, award.CompletedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrAwardInProgress
+		}
+		return fmt.Errorf("failed to create award: %w", err)
+	}
+	return nil
+}
+
+// Update saves the status and audit trail of an award
+func (r *AwardRepository) Update(ctx context.Context, award *domain.Award) error {
+	stepsJSON, err := json.Marshal(award.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal award steps: %w", err)
+	}
+
+	query := `
+		UPDATE procurement_awards SET
+			contract_id = NULLIF($1, ''), status = $2, error = NULLIF($3, ''),
+			steps = $4, updated_at = $5, completed_at = $6
+		WHERE id = $7 AND tenant_id = $8
+	`
+	result, err := r.db.Pool().Exec(ctx, query,
+		award.ContractID, award.Status, award.Error,
+		stepsJSON, award.UpdatedAt, award.CompletedAt,
+		award.ID, award.TenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update award: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrAwardNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves an award by ID
+func (r *AwardRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.Award, error) {
+	query := `SELECT ` + awardColumns + ` FROM procurement_awards WHERE id = $1 AND tenant_id = $2`
+	award, err := scanAward(r.db.Pool().QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAwardNotFound
+		}
+		return nil, fmt.Errorf("failed to get award: %w", err)
+	}
+	return award, nil
+}
+
+// ListByRFQ retrieves all award attempts for an RFQ, newest first
+func (r *AwardRepository) ListByRFQ(ctx context.Context, tenantID, rfqID string) ([]*domain.Award, error) {
+	query := `SELECT ` + awardColumns + ` FROM procurement_awards WHERE rfq_id = $1 AND tenant_id = $2 ORDER BY created_at DESC`
+	rows, err := r.db.Pool().Query(ctx, query, rfqID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list awards: %w", err)
+	}
+	defer rows.Close()
+
+	awards := []*domain.Award{}
+	for rows.Next() {
+		award, err := scanAward(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan award: %w", err)
+		}
+		awards = append(awards, award)
+	}
+	return awards, rows.Err()
+}
+
+func scanAward(row pgx.Row) (*domain.Award, error) {
+	award := &domain.Award{}
+	var stepsJSON []byte
+	err := row.Scan(
+		&award.ID, &award.TenantID, &award.RFQID, &award.ComparisonID, &award.QuoteID, &award.SupplierID,
+		&award.ContractID, &award.Status, &award.Reason, &award.Error,
+		&stepsJSON, &award.AwardedBy, &award.CreatedAt, &award.UpdatedAt, &award.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(stepsJSON, &award.Steps); err != nil {
+		award.Steps = []domain.AwardStep{}
+	}
+	return award, nil
+}
@@ -0,0 +1,152 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// ExchangeRateRepository implements domain.ExchangeRateRepository using PostgreSQL
+type ExchangeRateRepository struct {
+	db     *PostgresDB
+	logger *slog.Logger
+}
+
+// NewExchangeRateRepository creates a new exchange rate repository
+func NewExchangeRateRepository(db *PostgresDB, logger *slog.Logger) *ExchangeRateRepository {
+	return &ExchangeRateRepository{
+		db:     db,
+		logger: logger.With(slog.String("component", "exchange_rate_repository")),
+	}
+}
+
+// Rates are read as text so no precision is lost on the way to a decimal
+const exchangeRateColumns = `
+	id, tenant_id, from_currency, to_currency, rate::text, rate_date,
+	source, created_by, created_at, updated_at`
+
+// Upsert stores rates, replacing any rate of the same pair and day
+func (r *ExchangeRateRepository) Upsert(ctx context.Context, rates []*domain.ExchangeRate) error {
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO exchange_rates (
+			id, tenant_id, from_currency, to_currency, rate, rate_date,
+			source, created_by, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5::numeric, $6, $7, $8, $9, $10)
+		ON CONFLICT (tenant_id, from_currency, to_currency, rate_date) DO UPDATE SET
+			rate = EXCLUDED.rate, source = EXCLUDED.source,
+			created_by = EXCLUDED.created_by, updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`
+	for _, rate := range rates {
+		// An existing rate keeps its identity
+		err := tx.QueryRow(ctx, query,
+			rate.ID, rate.TenantID, rate.FromCurrency, rate.ToCurrency, rate.Rate.String(), rate.RateDate,
+			rate.Source, rate.CreatedBy, rate.CreatedAt, rate.UpdatedAt,
+		).Scan(&rate.ID, &rate.CreatedAt)
+		if err != nil {
+			r.logger.Error("Failed to store exchange rate",
+				slog.String("error", err.Error()),
+				slog.String("pair", rate.FromCurrency+"/"+rate.ToCurrency))
+			return fmt.Errorf("failed to store exchange rate: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// List retrieves rates, newest first
+func (r *ExchangeRateRepository) List(ctx context.Context, tenantID string, filter domain.ExchangeRateFilter) ([]*domain.ExchangeRate, error) {
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{tenantID}
+	if filter.Currency != "" {
+		args = append(args, strings.ToUpper(filter.Currency))
+		conditions = append(conditions, fmt.Sprintf("(from_currency = $%d OR to_currency = $%d)", len(args), len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("rate_date >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("rate_date <= $%d", len(args)))
+	}
+
+	query := `SELECT ` + exchangeRateColumns + ` FROM exchange_rates
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY rate_date DESC, from_currency, to_currency`
+	rows, err := r.db.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := []*domain.ExchangeRate{}
+	for rows.Next() {
+		rate, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
+
+// RateOn retrieves the latest rate of a pair dated on or before the given day
+func (r *ExchangeRateRepository) RateOn(ctx context.Context, tenantID, from, to string, day time.Time) (*domain.ExchangeRate, error) {
+	query := `SELECT ` + exchangeRateColumns + ` FROM exchange_rates
+		WHERE tenant_id = $1 AND from_currency = $2 AND to_currency = $3 AND rate_date <= $4
+		ORDER BY rate_date DESC
+		LIMIT 1`
+	rate, err := scanExchangeRate(r.db.Pool().QueryRow(ctx, query, tenantID, from, to, day))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrExchangeRateNotFound
+		}
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return rate, nil
+}
+
+// Delete removes a rate
+func (r *ExchangeRateRepository) Delete(ctx context.Context, tenantID, id string) error {
+	result, err := r.db.Pool().Exec(ctx, `DELETE FROM exchange_rates WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete exchange rate: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrExchangeRateNotFound
+	}
+	return nil
+}
+
+func scanExchangeRate(row pgx.Row) (*domain.ExchangeRate, error) {
+	rate := &domain.ExchangeRate{}
+	var value string
+	err := row.Scan(
+		&rate.ID, &rate.TenantID, &rate.FromCurrency, &rate.ToCurrency, &value, &rate.RateDate,
+		&rate.Source, &rate.CreatedBy, &rate.CreatedAt, &rate.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if rate.Rate, err = money.Parse(value); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
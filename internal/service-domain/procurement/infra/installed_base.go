@@ -0,0 +1,73 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	equipmentApp "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+)
+
+// InstalledBaseRegistrar implements domain.InstalledBaseRegistrar over the equipment registry
+type InstalledBaseRegistrar struct {
+	registrar *equipmentApp.InstalledBaseRegistrar
+	logger    *slog.Logger
+}
+
+// NewInstalledBaseRegistrar creates a registrar for units received on purchase orders
+func NewInstalledBaseRegistrar(registrar *equipmentApp.InstalledBaseRegistrar, logger *slog.Logger) *InstalledBaseRegistrar {
+	return &InstalledBaseRegistrar{
+		registrar: registrar,
+		logger:    logger.With(slog.String("component", "po_installed_base_registrar")),
+	}
+}
+
+// RegisterReceivedUnits registers each received unit with the contract ID
+// set, taking name, model and price from the order line and counting the
+// warranty from the receipt date. Units already registered are linked.
+func (r *InstalledBaseRegistrar) RegisterReceivedUnits(ctx context.Context, po *domain.PurchaseOrder, receipt *domain.GoodsReceipt, customerName string) error {
+	registered := 0
+	for i := range receipt.Lines {
+		rl := &receipt.Lines[i]
+		line, ok := po.Line(rl.POLineID)
+		if !ok {
+			return fmt.Errorf("%w: %s is not a line of purchase order %s", domain.ErrInvalidReceipt, rl.POLineID, po.PONumber)
+		}
+
+		for j := range rl.Units {
+			unit := &rl.Units[j]
+			id, err := r.registrar.Register(ctx, equipmentApp.InstalledUnit{
+				SerialNumber:         unit.SerialNumber,
+				EquipmentID:          line.EquipmentID,
+				EquipmentName:        line.EquipmentName,
+				ManufacturerName:     line.ManufacturerName,
+				ModelNumber:          line.ModelNumber,
+				CustomerID:           po.TenantID,
+				CustomerName:         customerName,
+				InstallationLocation: receipt.InstallationLocation,
+				ContractID:           po.ContractID,
+				PurchaseDate:         receipt.ReceivedAt,
+				PurchasePrice:        line.UnitPrice,
+				WarrantyExpiry:       line.WarrantyExpiry(receipt.ReceivedAt),
+				Notes:                fmt.Sprintf("Received on %s against purchase order %s", receipt.ReceiptNumber, po.PONumber),
+				CreatedBy:            receipt.ReceivedBy,
+			})
+			if errors.Is(err, equipmentApp.ErrContractConflict) {
+				return fmt.Errorf("%w: %v", domain.ErrInvalidReceipt, err)
+			}
+			if err != nil {
+				return err
+			}
+			unit.EquipmentID = id
+			registered++
+		}
+	}
+
+	r.logger.Info("Received units registered",
+		slog.String("po_id", po.ID),
+		slog.String("receipt_id", receipt.ID),
+		slog.Int("units", registered))
+	return nil
+}
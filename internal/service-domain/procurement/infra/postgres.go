@@ -0,0 +1,53 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresDB wraps a PostgreSQL connection pool
+type PostgresDB struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewPostgresDB creates a new PostgreSQL database connection
+func NewPostgresDB(ctx context.Context, connString string, logger *slog.Logger) (*PostgresDB, error) {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse connection string: %w", err)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create connection pool: %w", err)
+	}
+
+	// Test the connection
+	if err := pool.Ping(ctx); err != nil {
+		return nil, fmt.Errorf("unable to ping database: %w", err)
+	}
+
+	logger.Info("PostgreSQL connection established for procurement service")
+
+	return &PostgresDB{
+		pool:   pool,
+		logger: logger,
+	}, nil
+}
+
+// Pool returns the underlying connection pool
+func (db *PostgresDB) Pool() *pgxpool.Pool {
+	return db.pool
+}
+
+// Close closes the database connection pool
+func (db *PostgresDB) Close() {
+	if db.pool != nil {
+		db.pool.Close()
+		db.logger.Info("PostgreSQL connection closed for procurement service")
+	}
+}
@@ -0,0 +1,358 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PurchaseOrderRepository implements domain.PurchaseOrderRepository using PostgreSQL
+type PurchaseOrderRepository struct {
+	db     *PostgresDB
+	logger *slog.Logger
+}
+
+// NewPurchaseOrderRepository creates a new purchase order repository
+func NewPurchaseOrderRepository(db *PostgresDB, logger *slog.Logger) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{
+		db:     db,
+		logger: logger.With(slog.String("component", "purchase_order_repository")),
+	}
+}
+
+const purchaseOrderColumns = `
+	id, tenant_id, po_number, contract_id, contract_number, supplier_id, supplier_name,
+	currency, status, lines, total_amount, delivery_date, COALESCE(notes, ''),
+	created_by, created_at, updated_at, revision`
+
+// orderedQuantitiesQuery sums the call-offs per contract item across a
+// contract's purchase orders that are not cancelled
+const orderedQuantitiesQuery = `
+	SELECT line->>'contract_item_id', SUM((line->>'quantity')::int)
+	FROM purchase_orders, jsonb_array_elements(lines) AS line
+	WHERE contract_id = $1 AND tenant_id = $2 AND status <> 'cancelled'
+	GROUP BY 1`
+
+// CreatePurchaseOrder stores a purchase order. Call-offs of the same contract
+// are serialized and re-checked against the contract quantities so
+// concurrent orders cannot over-order an item.
+func (r *PurchaseOrderRepository) CreatePurchaseOrder(ctx context.Context, po *domain.PurchaseOrder) error {
+	linesJSON, err := json.Marshal(po.Lines)
+	if err != nil {
+		return fmt.Errorf("failed to marshal purchase order lines: %w", err)
+	}
+
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, po.ContractID); err != nil {
+		return fmt.Errorf("failed to lock contract call-offs: %w", err)
+	}
+	ordered, err := orderedQuantities(ctx, tx, po.TenantID, po.ContractID)
+	if err != nil {
+		return err
+	}
+	requested := map[string]int{}
+	for _, line := range po.Lines {
+		requested[line.ContractItemID] += line.Quantity
+		if ordered[line.ContractItemID]+requested[line.ContractItemID] > line.ContractQuantity {
+			return fmt.Errorf("%w: %s was ordered concurrently", domain.ErrCallOffExceedsContract, line.EquipmentName)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO purchase_orders (
+			id, tenant_id, po_number, contract_id, contract_number, supplier_id, supplier_name,
+			currency, status, lines, total_amount, delivery_date, notes,
+			created_by, created_at, updated_at, revision
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''), $14, $15, $16, $17)
+	`, po.ID, po.TenantID, po.PONumber, po.ContractID, po.ContractNumber, po.SupplierID, po.SupplierName,
+		po.Currency, po.Status, linesJSON, po.TotalAmount, po.DeliveryDate, po.Notes,
+		po.CreatedBy, po.CreatedAt, po.UpdatedAt, po.Revision)
+	if err != nil {
+		return fmt.Errorf("failed to create purchase order: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit purchase order: %w", err)
+	}
+
+	r.logger.Info("Purchase order created",
+		slog.String("po_id", po.ID),
+		slog.String("contract_id", po.ContractID),
+		slog.Float64("total_amount", po.TotalAmount))
+	return nil
+}
+
+// GetPurchaseOrder retrieves a purchase order
+func (r *PurchaseOrderRepository) GetPurchaseOrder(ctx context.Context, tenantID, id string) (*domain.PurchaseOrder, error) {
+	rows, err := r.db.Pool().Query(ctx, `SELECT `+purchaseOrderColumns+`
+		FROM purchase_orders
+		WHERE id = $1 AND tenant_id = $2
+	`, id, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+	orders, err := scanPurchaseOrders(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+	return orders[0], nil
+}
+
+// ListPurchaseOrders retrieves a contract's purchase orders, newest first
+func (r *PurchaseOrderRepository) ListPurchaseOrders(ctx context.Context, tenantID, contractID string) ([]*domain.PurchaseOrder, error) {
+	rows, err := r.db.Pool().Query(ctx, `SELECT `+purchaseOrderColumns+`
+		FROM purchase_orders
+		WHERE contract_id = $1 AND tenant_id = $2
+		ORDER BY created_at DESC
+	`, contractID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purchase orders: %w", err)
+	}
+	return scanPurchaseOrders(rows)
+}
+
+// OrderedQuantities sums the quantities of each contract item on the
+// contract's purchase orders that are not cancelled
+func (r *PurchaseOrderRepository) OrderedQuantities(ctx context.Context, tenantID, contractID string) (map[string]int, error) {
+	return orderedQuantities(ctx, r.db.Pool(), tenantID, contractID)
+}
+
+// UpdatePurchaseOrder saves a purchase order unchanged since it was read
+func (r *PurchaseOrderRepository) UpdatePurchaseOrder(ctx context.Context, po *domain.PurchaseOrder) error {
+	return r.updatePurchaseOrder(ctx, r.db.Pool(), po)
+}
+
+// SaveReceipt stores a goods receipt with the purchase order it updated in one transaction
+func (r *PurchaseOrderRepository) SaveReceipt(ctx context.Context, po *domain.PurchaseOrder, receipt *domain.GoodsReceipt) error {
+	linesJSON, err := json.Marshal(receipt.Lines)
+	if err != nil {
+		return fmt.Errorf("failed to marshal receipt lines: %w", err)
+	}
+
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.updatePurchaseOrder(ctx, tx, po); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO goods_receipts (
+			id, tenant_id, purchase_order_id, receipt_number, received_at, received_by,
+			installation_location, lines, notes, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, NULLIF($9, ''), $10)
+	`, receipt.ID, receipt.TenantID, receipt.PurchaseOrderID, receipt.ReceiptNumber, receipt.ReceivedAt, receipt.ReceivedBy,
+		receipt.InstallationLocation, linesJSON, receipt.Notes, receipt.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store goods receipt: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit goods receipt: %w", err)
+	}
+
+	r.logger.Info("Goods received",
+		slog.String("po_id", po.ID),
+		slog.String("receipt_id", receipt.ID),
+		slog.String("status", string(po.Status)))
+	return nil
+}
+
+// ListReceipts retrieves a purchase order's goods receipts, oldest first
+func (r *PurchaseOrderRepository) ListReceipts(ctx context.Context, tenantID, poID string) ([]*domain.GoodsReceipt, error) {
+	rows, err := r.db.Pool().Query(ctx, `
+		SELECT id, tenant_id, purchase_order_id, receipt_number, received_at, received_by,
+			COALESCE(installation_location, ''), lines, COALESCE(notes, ''), created_at
+		FROM goods_receipts
+		WHERE purchase_order_id = $1 AND tenant_id = $2
+		ORDER BY received_at, created_at
+	`, poID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list goods receipts: %w", err)
+	}
+	defer rows.Close()
+
+	receipts := []*domain.GoodsReceipt{}
+	for rows.Next() {
+		var receipt domain.GoodsReceipt
+		var linesJSON []byte
+		if err := rows.Scan(
+			&receipt.ID, &receipt.TenantID, &receipt.PurchaseOrderID, &receipt.ReceiptNumber, &receipt.ReceivedAt, &receipt.ReceivedBy,
+			&receipt.InstallationLocation, &linesJSON, &receipt.Notes, &receipt.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan goods receipt: %w", err)
+		}
+		if err := json.Unmarshal(linesJSON, &receipt.Lines); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal receipt lines: %w", err)
+		}
+		receipts = append(receipts, &receipt)
+	}
+	return receipts, rows.Err()
+}
+
+// SaveInvoice stores a supplier invoice with the purchase order it updated in one transaction
+func (r *PurchaseOrderRepository) SaveInvoice(ctx context.Context, po *domain.PurchaseOrder, invoice *domain.SupplierInvoice) error {
+	linesJSON, err := json.Marshal(invoice.Lines)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invoice lines: %w", err)
+	}
+	matchJSON, err := json.Marshal(invoice.Match)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invoice match: %w", err)
+	}
+
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Exceptions leave the order unchanged, but still must not race a matched invoice
+	if err := r.updatePurchaseOrder(ctx, tx, po); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO supplier_invoices (
+			id, tenant_id, purchase_order_id, supplier_id, invoice_number, invoice_date,
+			currency, lines, tax_amount, total_amount, status, match, recorded_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`, invoice.ID, invoice.TenantID, invoice.PurchaseOrderID, invoice.SupplierID, invoice.InvoiceNumber, invoice.InvoiceDate,
+		invoice.Currency, linesJSON, invoice.TaxAmount, invoice.TotalAmount, invoice.Status, matchJSON, invoice.RecordedBy, invoice.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrDuplicateInvoice
+		}
+		return fmt.Errorf("failed to store supplier invoice: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit supplier invoice: %w", err)
+	}
+
+	r.logger.Info("Supplier invoice recorded",
+		slog.String("po_id", po.ID),
+		slog.String("invoice_id", invoice.ID),
+		slog.String("status", string(invoice.Status)))
+	return nil
+}
+
+// ListInvoices retrieves a purchase order's supplier invoices, oldest first
+func (r *PurchaseOrderRepository) ListInvoices(ctx context.Context, tenantID, poID string) ([]*domain.SupplierInvoice, error) {
+	rows, err := r.db.Pool().Query(ctx, `
+		SELECT id, tenant_id, purchase_order_id, supplier_id, invoice_number, invoice_date,
+			currency, lines, tax_amount, total_amount, status, match, recorded_by, created_at
+		FROM supplier_invoices
+		WHERE purchase_order_id = $1 AND tenant_id = $2
+		ORDER BY created_at
+	`, poID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list supplier invoices: %w", err)
+	}
+	defer rows.Close()
+
+	invoices := []*domain.SupplierInvoice{}
+	for rows.Next() {
+		var invoice domain.SupplierInvoice
+		var linesJSON, matchJSON []byte
+		if err := rows.Scan(
+			&invoice.ID, &invoice.TenantID, &invoice.PurchaseOrderID, &invoice.SupplierID, &invoice.InvoiceNumber, &invoice.InvoiceDate,
+			&invoice.Currency, &linesJSON, &invoice.TaxAmount, &invoice.TotalAmount, &invoice.Status, &matchJSON, &invoice.RecordedBy, &invoice.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan supplier invoice: %w", err)
+		}
+		if err := json.Unmarshal(linesJSON, &invoice.Lines); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal invoice lines: %w", err)
+		}
+		if err := json.Unmarshal(matchJSON, &invoice.Match); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal invoice match: %w", err)
+		}
+		invoices = append(invoices, &invoice)
+	}
+	return invoices, rows.Err()
+}
+
+// querier is satisfied by both the pool and a transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func orderedQuantities(ctx context.Context, db querier, tenantID, contractID string) (map[string]int, error) {
+	rows, err := db.Query(ctx, orderedQuantitiesQuery, contractID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum ordered quantities: %w", err)
+	}
+	defer rows.Close()
+
+	ordered := map[string]int{}
+	for rows.Next() {
+		var itemID string
+		var quantity int
+		if err := rows.Scan(&itemID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan ordered quantity: %w", err)
+		}
+		ordered[itemID] = quantity
+	}
+	return ordered, rows.Err()
+}
+
+// updatePurchaseOrder saves the order if its revision is unchanged, bumping it
+func (r *PurchaseOrderRepository) updatePurchaseOrder(ctx context.Context, db querier, po *domain.PurchaseOrder) error {
+	linesJSON, err := json.Marshal(po.Lines)
+	if err != nil {
+		return fmt.Errorf("failed to marshal purchase order lines: %w", err)
+	}
+
+	result, err := db.Exec(ctx, `
+		UPDATE purchase_orders SET
+			status = $1, lines = $2, updated_at = $3, revision = revision + 1
+		WHERE id = $4 AND tenant_id = $5 AND revision = $6
+	`, po.Status, linesJSON, po.UpdatedAt, po.ID, po.TenantID, po.Revision)
+	if err != nil {
+		return fmt.Errorf("failed to update purchase order: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrPurchaseOrderConflict
+	}
+	po.Revision++
+	return nil
+}
+
+func scanPurchaseOrders(rows pgx.Rows) ([]*domain.PurchaseOrder, error) {
+	defer rows.Close()
+
+	orders := []*domain.PurchaseOrder{}
+	for rows.Next() {
+		var po domain.PurchaseOrder
+		var linesJSON []byte
+		if err := rows.Scan(
+			&po.ID, &po.TenantID, &po.PONumber, &po.ContractID, &po.ContractNumber, &po.SupplierID, &po.SupplierName,
+			&po.Currency, &po.Status, &linesJSON, &po.TotalAmount, &po.DeliveryDate, &po.Notes,
+			&po.CreatedBy, &po.CreatedAt, &po.UpdatedAt, &po.Revision,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan purchase order: %w", err)
+		}
+		if err := json.Unmarshal(linesJSON, &po.Lines); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal purchase order lines: %w", err)
+		}
+		orders = append(orders, &po)
+	}
+	return orders, rows.Err()
+}
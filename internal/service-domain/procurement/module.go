@@ -0,0 +1,202 @@
+package procurement
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	comparisonInfra "github.com/aby-med/medical-platform/internal/service-domain/comparison/infra"
+	contractInfra "github.com/aby-med/medical-platform/internal/service-domain/contract/infra"
+	equipmentApp "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/app"
+	equipmentInfra "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/infra"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/api"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/procurement/infra"
+	quoteInfra "github.com/aby-med/medical-platform/internal/service-domain/quote/infra"
+	rfqInfra "github.com/aby-med/medical-platform/internal/service-domain/rfq/infra"
+	supplierInfra "github.com/aby-med/medical-platform/internal/service-domain/supplier/infra"
+	"github.com/go-chi/chi/v5"
+)
+
+// Config holds configuration for the procurement module
+type Config struct {
+	DatabaseURL  string
+	KafkaBrokers []string
+}
+
+// Module orchestrates the rfq, quote, comparison and contract modules and
+// serves the supplier portal
+type Module struct {
+	config         Config
+	logger         *slog.Logger
+	db             *infra.PostgresDB
+	rfqEvents      *rfqInfra.KafkaEventPublisher
+	contractEvents *contractInfra.KafkaEventPublisher
+	handler        *api.AwardHandler
+	portalHandler  *api.PortalHandler
+	auctionHandler *api.AuctionHandler
+	auctionCloser  *app.AuctionCloser
+	ratesHandler   *api.ExchangeRateHandler
+	ordersHandler  *api.PurchaseOrderHandler
+}
+
+// NewModule creates a new procurement module instance
+func NewModule(config Config, logger *slog.Logger) *Module {
+	return &Module{
+		config: config,
+		logger: logger.With(slog.String("module", "procurement")),
+	}
+}
+
+// Initialize sets up the module dependencies
+func (m *Module) Initialize(ctx context.Context) error {
+	m.logger.Info("Initializing procurement module")
+
+	// Initialize database
+	db, err := infra.NewPostgresDB(ctx, m.config.DatabaseURL, m.logger)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	m.db = db
+
+	// Repositories of the orchestrated modules share this module's pool
+	rfqRepo := rfqInfra.NewRFQRepository(rfqInfra.NewPostgresDBFromPool(db.Pool(), m.logger), m.logger)
+	quoteRepo := quoteInfra.NewQuoteRepository(quoteInfra.NewPostgresDBFromPool(db.Pool(), m.logger), m.logger)
+	comparisonRepo := comparisonInfra.NewComparisonRepository(comparisonInfra.NewPostgresDBFromPool(db.Pool(), m.logger), m.logger)
+	contractRepo := contractInfra.NewContractRepository(contractInfra.NewPostgresDBFromPool(db.Pool(), m.logger), m.logger)
+	supplierRepo := supplierInfra.NewSupplierRepository(supplierInfra.NewPostgresDBFromPool(db.Pool(), m.logger), m.logger)
+	awardRepo := infra.NewAwardRepository(db, m.logger)
+
+	// Initialize event publishers
+	m.rfqEvents = rfqInfra.NewKafkaEventPublisher(m.config.KafkaBrokers, m.logger)
+	m.contractEvents = contractInfra.NewKafkaEventPublisher(m.config.KafkaBrokers, m.logger)
+
+	// Initialize layers
+	service := app.NewAwardService(
+		rfqRepo, quoteRepo, comparisonRepo, contractRepo, supplierRepo, awardRepo,
+		m.rfqEvents, m.contractEvents, m.logger,
+	)
+	m.handler = api.NewAwardHandler(service, m.logger)
+
+	portalService := app.NewPortalService(rfqRepo, quoteRepo, supplierRepo, m.rfqEvents, m.logger)
+	m.portalHandler = api.NewPortalHandler(portalService, m.logger)
+
+	auctionService := app.NewAuctionService(
+		infra.NewAuctionRepository(db, m.logger), rfqRepo, quoteRepo, comparisonRepo,
+		app.NewAuctionBroker(), domain.SystemClock{}, m.logger,
+	)
+	m.auctionHandler = api.NewAuctionHandler(auctionService, m.logger)
+	m.auctionCloser = app.NewAuctionCloser(auctionService, m.logger)
+
+	ratesService := app.NewExchangeRateService(infra.NewExchangeRateRepository(db, m.logger), rfqRepo, quoteRepo, m.logger)
+	m.ratesHandler = api.NewExchangeRateHandler(ratesService, m.logger)
+
+	ordersService := app.NewPurchaseOrderService(
+		contractRepo, infra.NewPurchaseOrderRepository(db, m.logger),
+		infra.NewInstalledBaseRegistrar(
+			equipmentApp.NewInstalledBaseRegistrar(equipmentInfra.NewEquipmentRepository(db.Pool()), m.logger), m.logger,
+		), m.logger,
+	)
+	m.ordersHandler = api.NewPurchaseOrderHandler(ordersService, m.logger)
+
+	m.logger.Info("Procurement module initialized successfully")
+	return nil
+}
+
+// MountRoutes registers HTTP routes for the procurement module
+func (m *Module) MountRoutes(r chi.Router) {
+	m.logger.Info("Mounting procurement routes at /procurement and /supplier-portal")
+
+	r.Route("/procurement", func(r chi.Router) {
+		r.Post("/awards", m.handler.CreateAward)
+		r.Get("/awards/{id}", m.handler.GetAward)
+		r.Get("/rfqs/{rfq_id}/awards", m.handler.ListAwardsByRFQ)
+
+		// Reverse auctions
+		r.Post("/auctions", m.auctionHandler.CreateAuction)
+		r.Get("/auctions/{id}", m.auctionHandler.GetAuction)
+		r.Get("/auctions/{id}/stream", m.auctionHandler.StreamAuction)
+		r.Post("/auctions/{id}/cancel", m.auctionHandler.CancelAuction)
+		r.Post("/auctions/{id}/close", m.auctionHandler.CloseAuction)
+		r.Get("/rfqs/{rfq_id}/auctions", m.auctionHandler.ListAuctionsByRFQ)
+
+		// Exchange rates and conversion to RFQ base currencies
+		r.Get("/exchange-rates", m.ratesHandler.ListRates)
+		r.Post("/exchange-rates", m.ratesHandler.SetRates)
+		r.Post("/exchange-rates/import", m.ratesHandler.ImportRatesCSV)
+		r.Get("/exchange-rates/convert", m.ratesHandler.Convert)
+		r.Delete("/exchange-rates/{id}", m.ratesHandler.DeleteRate)
+		r.Get("/rfqs/{rfq_id}/currency-report", m.ratesHandler.RFQCurrencyReport)
+
+		// Purchase orders against contracts, goods receipts and invoice matching
+		r.Post("/contracts/{contract_id}/purchase-orders", m.ordersHandler.CreatePurchaseOrder)
+		r.Get("/contracts/{contract_id}/purchase-orders", m.ordersHandler.ListPurchaseOrders)
+		r.Get("/purchase-orders/{id}", m.ordersHandler.GetPurchaseOrder)
+		r.Post("/purchase-orders/{id}/cancel", m.ordersHandler.CancelPurchaseOrder)
+		r.Post("/purchase-orders/{id}/receipts", m.ordersHandler.ReceiveGoods)
+		r.Post("/purchase-orders/{id}/invoices", m.ordersHandler.SubmitInvoice)
+	})
+
+	// Supplier portal: scoped to the supplier linked to the caller's organization
+	r.Route("/supplier-portal", func(r chi.Router) {
+		r.Use(m.portalHandler.SupplierContext)
+
+		r.Get("/invitations", m.portalHandler.ListInvitations)
+		r.Get("/invitations/{rfq_id}", m.portalHandler.GetInvitation)
+		r.Post("/invitations/{rfq_id}/view", m.portalHandler.MarkViewed)
+		r.Post("/invitations/{rfq_id}/decline", m.portalHandler.DeclineInvitation)
+		r.Get("/invitations/{rfq_id}/addenda", m.portalHandler.ListAddenda)
+		r.Get("/invitations/{rfq_id}/questions", m.portalHandler.ListQuestions)
+		r.Post("/invitations/{rfq_id}/questions", m.portalHandler.AskQuestion)
+		r.Post("/invitations/{rfq_id}/quotes", m.portalHandler.CreateQuote)
+
+		r.Get("/quotes", m.portalHandler.ListQuotes)
+		r.Get("/quotes/{id}", m.portalHandler.GetQuote)
+		r.Put("/quotes/{id}", m.portalHandler.UpdateQuote)
+		r.Post("/quotes/{id}/submit", m.portalHandler.SubmitQuote)
+
+		r.Get("/notifications", m.portalHandler.ListNotifications)
+		r.Post("/notifications/{id}/read", m.portalHandler.MarkNotificationRead)
+
+		r.Get("/auctions", m.auctionHandler.ListSupplierAuctions)
+		r.Get("/auctions/{id}", m.auctionHandler.GetSupplierAuction)
+		r.Get("/auctions/{id}/stream", m.auctionHandler.StreamSupplierAuction)
+		r.Post("/auctions/{id}/bids", m.auctionHandler.PlaceBid)
+	})
+
+	m.logger.Info("Procurement routes mounted successfully")
+}
+
+// Start begins any background processes
+func (m *Module) Start(ctx context.Context) error {
+	m.logger.Info("Starting procurement module")
+
+	// Settle reverse auctions once their bidding has ended
+	if m.auctionCloser != nil {
+		go m.auctionCloser.Run(ctx)
+	}
+	return nil
+}
+
+// Stop gracefully shuts down the module
+func (m *Module) Stop(ctx context.Context) error {
+	m.logger.Info("Stopping procurement module")
+
+	if m.rfqEvents != nil {
+		m.rfqEvents.Close()
+	}
+	if m.contractEvents != nil {
+		m.contractEvents.Close()
+	}
+	if m.db != nil {
+		m.db.Close()
+	}
+
+	return nil
+}
+
+// Name returns the module name
+func (m *Module) Name() string {
+	return "procurement"
+}
@@ -2,10 +2,12 @@
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
 
+	"github.com/aby-med/medical-platform/internal/pkg/money"
 	"github.com/aby-med/medical-platform/internal/service-domain/quote/app"
 	"github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
 	"github.com/go-chi/chi/v5"
@@ -46,6 +48,10 @@ func (h *QuoteHandler) CreateQuote(w http.ResponseWriter, r *http.Request) {
 
 	quote, err := h.service.CreateQuote(r.Context(), tenantID, createdBy, req)
 	if err != nil {
+		if errors.Is(err, money.ErrUnknownCurrency) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.Error("Failed to create quote", slog.String("error", err.Error()))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -336,7 +342,7 @@ func (h *QuoteHandler) SubmitQuote(w http.ResponseWriter, r *http.Request) {
 	quote, err := h.service.SubmitQuote(r.Context(), tenantID, quoteID)
 	if err != nil {
 		h.logger.Error("Failed to submit quote", slog.String("error", err.Error()))
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), bidWindowStatus(err))
 		return
 	}
 
@@ -398,7 +404,7 @@ func (h *QuoteHandler) AcceptQuote(w http.ResponseWriter, r *http.Request) {
 	quote, err := h.service.AcceptQuote(r.Context(), tenantID, quoteID, req)
 	if err != nil {
 		h.logger.Error("Failed to accept quote", slog.String("error", err.Error()))
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), bidWindowStatus(err))
 		return
 	}
 
@@ -429,7 +435,7 @@ func (h *QuoteHandler) RejectQuote(w http.ResponseWriter, r *http.Request) {
 	quote, err := h.service.RejectQuote(r.Context(), tenantID, quoteID, req)
 	if err != nil {
 		h.logger.Error("Failed to reject quote", slog.String("error", err.Error()))
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), bidWindowStatus(err))
 		return
 	}
 
@@ -479,7 +485,7 @@ func (h *QuoteHandler) MarkUnderReview(w http.ResponseWriter, r *http.Request) {
 	quote, err := h.service.MarkQuoteUnderReview(r.Context(), tenantID, quoteID)
 	if err != nil {
 		h.logger.Error("Failed to mark quote under review", slog.String("error", err.Error()))
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), bidWindowStatus(err))
 		return
 	}
 
@@ -513,3 +519,11 @@ func (h *QuoteHandler) DeleteQuote(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// bidWindowStatus maps sealed-bid and deadline violations to 409, other failures to 400
+func bidWindowStatus(err error) int {
+	if errors.Is(err, domain.ErrBidsSealed) || errors.Is(err, domain.ErrSubmissionClosed) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/aby-med/medical-platform/internal/middleware"
+	"github.com/aby-med/medical-platform/internal/service-domain/quote/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// RequestValidityExtension handles POST /quotes/{id}/validity-extensions
+func (h *QuoteHandler) RequestValidityExtension(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		http.Error(w, "X-Tenant-ID header required", http.StatusBadRequest)
+		return
+	}
+
+	caller, ok := quoteCaller(w, r)
+	if !ok {
+		return
+	}
+
+	var req app.RequestValidityExtensionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	extension, err := h.service.RequestValidityExtension(r.Context(), tenantID, chi.URLParam(r, "id"), caller, req)
+	if err != nil {
+		h.logger.Error("Failed to request validity extension", slog.String("error", err.Error()))
+		http.Error(w, err.Error(), validityStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(extension)
+}
+
+// ListValidityExtensions handles GET /quotes/{id}/validity-extensions
+func (h *QuoteHandler) ListValidityExtensions(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		http.Error(w, "X-Tenant-ID header required", http.StatusBadRequest)
+		return
+	}
+
+	extensions, err := h.service.ListValidityExtensions(r.Context(), tenantID, chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, err.Error(), validityStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"extensions": extensions, "total": len(extensions)})
+}
+
+// ApproveValidityExtension handles POST /quotes/{id}/validity-extensions/{extension_id}/approve
+func (h *QuoteHandler) ApproveValidityExtension(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		http.Error(w, "X-Tenant-ID header required", http.StatusBadRequest)
+		return
+	}
+
+	caller, ok := quoteCaller(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeDecision(w, r)
+	if !ok {
+		return
+	}
+
+	quote, err := h.service.ApproveValidityExtension(r.Context(), tenantID, chi.URLParam(r, "id"), chi.URLParam(r, "extension_id"), caller, req)
+	if err != nil {
+		h.logger.Error("Failed to approve validity extension", slog.String("error", err.Error()))
+		http.Error(w, err.Error(), validityStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(quote)
+}
+
+// RejectValidityExtension handles POST /quotes/{id}/validity-extensions/{extension_id}/reject
+func (h *QuoteHandler) RejectValidityExtension(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		http.Error(w, "X-Tenant-ID header required", http.StatusBadRequest)
+		return
+	}
+
+	caller, ok := quoteCaller(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeDecision(w, r)
+	if !ok {
+		return
+	}
+
+	extension, err := h.service.RejectValidityExtension(r.Context(), tenantID, chi.URLParam(r, "id"), chi.URLParam(r, "extension_id"), caller, req)
+	if err != nil {
+		h.logger.Error("Failed to reject validity extension", slog.String("error", err.Error()))
+		http.Error(w, err.Error(), validityStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(extension)
+}
+
+// ListNotifications handles GET /quotes/notifications?recipient=&supplier_id=&unread=true
+func (h *QuoteHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		http.Error(w, "X-Tenant-ID header required", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := domain.NotificationFilter{
+		Recipient:  query.Get("recipient"),
+		SupplierID: query.Get("supplier_id"),
+		UnreadOnly: query.Get("unread") == "true",
+	}
+	if filter.Recipient != "" && filter.Recipient != domain.RecipientSupplier && filter.Recipient != domain.RecipientBuyer {
+		http.Error(w, "recipient must be supplier or buyer", http.StatusBadRequest)
+		return
+	}
+
+	notifications, err := h.service.ListNotifications(r.Context(), tenantID, filter)
+	if err != nil {
+		h.logger.Error("Failed to list quote notifications", slog.String("error", err.Error()))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"notifications": notifications, "total": len(notifications)})
+}
+
+// MarkNotificationRead handles POST /quotes/notifications/{notification_id}/read
+func (h *QuoteHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		http.Error(w, "X-Tenant-ID header required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.MarkNotificationRead(r.Context(), tenantID, chi.URLParam(r, "notification_id")); err != nil {
+		http.Error(w, err.Error(), validityStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// quoteCaller reads the caller's identity from the auth context, never from
+// client headers or the body, so a caller cannot act as another party
+func quoteCaller(w http.ResponseWriter, r *http.Request) (app.Caller, bool) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return app.Caller{}, false
+	}
+	orgID, ok := middleware.GetOrganizationID(r.Context())
+	if !ok {
+		http.Error(w, "Organization context required", http.StatusForbidden)
+		return app.Caller{}, false
+	}
+	orgType, _ := middleware.GetOrganizationType(r.Context())
+	return app.Caller{UserID: userID.String(), OrganizationID: orgID.String(), OrganizationType: orgType}, true
+}
+
+// decodeDecision reads a validity extension decision
+func decodeDecision(w http.ResponseWriter, r *http.Request) (app.DecideValidityExtensionRequest, bool) {
+	var req app.DecideValidityExtensionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+// validityStatus maps validity extension errors to HTTP status codes
+func validityStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrQuoteNotFound), errors.Is(err, domain.ErrExtensionNotFound),
+		errors.Is(err, domain.ErrNotificationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrExtensionPending), errors.Is(err, domain.ErrExtensionDecided):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidExtension):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrNotQuoteSupplier), errors.Is(err, domain.ErrNotQuoteBuyer):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
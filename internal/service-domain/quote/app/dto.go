@@ -10,7 +10,9 @@ import (
 type CreateQuoteRequest struct {
 	RFQID         string             `json:"rfq_id" validate:"required"`
 	SupplierID    string             `json:"supplier_id" validate:"required"`
+	RFQVersion    int                `json:"rfq_version"`
 	ValidUntil    time.Time          `json:"valid_until" validate:"required"`
+	Currency      string             `json:"currency"` // ISO 4217 code; defaults to USD
 	DeliveryTerms string             `json:"delivery_terms"`
 	PaymentTerms  string             `json:"payment_terms"`
 	WarrantyTerms string             `json:"warranty_terms"`
@@ -61,12 +63,31 @@ type ReviseQuoteRequest struct {
 	RevisedBy string `json:"revised_by" validate:"required"`
 }
 
+// RequestValidityExtensionRequest represents a supplier's request to extend a quote's validity
+type RequestValidityExtensionRequest struct {
+	ValidUntil time.Time `json:"valid_until" validate:"required"`
+	Reason     string    `json:"reason"`
+}
+
+// DecideValidityExtensionRequest represents the buyer's decision on a validity extension
+type DecideValidityExtensionRequest struct {
+	Notes string `json:"notes"`
+}
+
+// Caller is the authenticated user acting on a quote, taken from the auth context
+type Caller struct {
+	UserID           string
+	OrganizationID   string
+	OrganizationType string
+}
+
 // QuoteResponse represents the response DTO for a quote
 type QuoteResponse struct {
 	ID              string                   `json:"id"`
 	TenantID        string                   `json:"tenant_id"`
 	RFQID           string                   `json:"rfq_id"`
 	SupplierID      string                   `json:"supplier_id"`
+	RFQVersion      int                      `json:"rfq_version"`
 	QuoteNumber     string                   `json:"quote_number"`
 	Status          string                   `json:"status"`
 	TotalAmount     float64                  `json:"total_amount"`
@@ -87,6 +108,20 @@ type QuoteResponse struct {
 	CreatedBy       string                   `json:"created_by"`
 	CreatedAt       time.Time                `json:"created_at"`
 	UpdatedAt       time.Time                `json:"updated_at"`
+	Sealed          bool                     `json:"sealed,omitempty"`
+}
+
+// Seal strips the commercial contents of a quote whose RFQ bids are not yet opened
+func (r *QuoteResponse) Seal() {
+	r.TotalAmount = 0
+	r.DeliveryTerms = ""
+	r.PaymentTerms = ""
+	r.WarrantyTerms = ""
+	r.Notes = ""
+	r.Items = []QuoteItemResponse{}
+	r.Revisions = nil
+	r.Metadata = nil
+	r.Sealed = true
 }
 
 // QuoteItemResponse represents a line item in a quote response
@@ -168,6 +203,7 @@ func ToQuoteResponse(quote *domain.Quote) QuoteResponse {
 		TenantID:        quote.TenantID,
 		RFQID:           quote.RFQID,
 		SupplierID:      quote.SupplierID,
+		RFQVersion:      quote.RFQVersion,
 		QuoteNumber:     quote.QuoteNumber,
 		Status:          string(quote.Status),
 		TotalAmount:     quote.TotalAmount,
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/shared/config"
+)
+
+// defaultReminderDays is how far ahead of expiry suppliers and buyers are reminded
+const defaultReminderDays = 3
+
+// ExpirySweeper periodically expires quotes past their validity, so they can
+// no longer be accepted, and reminds suppliers and buyers of upcoming expiries
+type ExpirySweeper struct {
+	service        *QuoteService
+	interval       time.Duration
+	reminderWindow time.Duration
+	now            func() time.Time
+	logger         *slog.Logger
+}
+
+// NewExpirySweeper creates a new quote expiry sweeper. The reminder window
+// can be changed with QUOTE_EXPIRY_REMINDER_DAYS.
+func NewExpirySweeper(service *QuoteService, logger *slog.Logger) *ExpirySweeper {
+	days := defaultReminderDays
+	if value, err := strconv.Atoi(os.Getenv("QUOTE_EXPIRY_REMINDER_DAYS")); err == nil && value > 0 {
+		days = value
+	}
+	return &ExpirySweeper{
+		service:        service,
+		interval:       5 * time.Minute,
+		reminderWindow: time.Duration(days) * 24 * time.Hour,
+		now:            time.Now,
+		logger:         logger.With(slog.String("component", "quote_expiry_sweeper")),
+	}
+}
+
+// Run sweeps quotes until the context is cancelled.
+// Disabled with ENABLE_QUOTE_EXPIRY_SWEEPER=false.
+func (s *ExpirySweeper) Run(ctx context.Context) {
+	if !config.Enabled("ENABLE_QUOTE_EXPIRY_SWEEPER") {
+		s.logger.Info("Quote expiry sweeper disabled; skipping run")
+		return
+	}
+
+	s.RunOnce(ctx)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce expires lapsed quotes and sends due expiry reminders once
+func (s *ExpirySweeper) RunOnce(ctx context.Context) {
+	now := s.now()
+
+	expired, err := s.service.ExpireQuotes(ctx, now)
+	if err != nil {
+		s.logger.Error("Failed to expire quotes", slog.String("error", err.Error()))
+	} else if expired > 0 {
+		s.logger.Info("Expired quotes past their validity", slog.Int("count", expired))
+	}
+
+	reminded, err := s.service.SendExpiryReminders(ctx, now, s.reminderWindow)
+	if err != nil {
+		s.logger.Error("Failed to send quote expiry reminders", slog.String("error", err.Error()))
+	} else if reminded > 0 {
+		s.logger.Info("Sent quote expiry reminders", slog.Int("count", reminded))
+	}
+}
@@ -4,21 +4,28 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
 )
 
 // QuoteService provides application-level quote operations
 type QuoteService struct {
-	repo   domain.QuoteRepository
-	logger *slog.Logger
+	repo      domain.QuoteRepository
+	bids      domain.BidWindowReader
+	validity  domain.ValidityRepository
+	suppliers domain.SupplierResolver
+	logger    *slog.Logger
 }
 
 // NewQuoteService creates a new quote service
-func NewQuoteService(repo domain.QuoteRepository, logger *slog.Logger) *QuoteService {
+func NewQuoteService(repo domain.QuoteRepository, bids domain.BidWindowReader, validity domain.ValidityRepository, suppliers domain.SupplierResolver, logger *slog.Logger) *QuoteService {
 	return &QuoteService{
-		repo:   repo,
-		logger: logger.With(slog.String("component", "quote_service")),
+		repo:      repo,
+		bids:      bids,
+		validity:  validity,
+		suppliers: suppliers,
+		logger:    logger.With(slog.String("component", "quote_service")),
 	}
 }
 
@@ -31,6 +38,14 @@ func (s *QuoteService) CreateQuote(ctx context.Context, tenantID string, created
 		return nil, fmt.Errorf("failed to create quote: %w", err)
 	}
 
+	if req.RFQVersion > 0 {
+		quote.RFQVersion = req.RFQVersion
+	}
+
+	if err := quote.SetCurrency(req.Currency); err != nil {
+		return nil, err
+	}
+
 	// Set terms
 	quote.DeliveryTerms = req.DeliveryTerms
 	quote.PaymentTerms = req.PaymentTerms
@@ -85,11 +100,15 @@ func (s *QuoteService) GetQuote(ctx context.Context, tenantID string, quoteID st
 		return nil, err
 	}
 
-	response := ToQuoteResponse(quote)
-	return &response, nil
+	responses := []QuoteResponse{ToQuoteResponse(quote)}
+	if err := s.sealResponses(ctx, tenantID, responses); err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
 }
 
-// GetQuotesByRFQ retrieves all quotes for a specific RFQ
+// GetQuotesByRFQ retrieves all quotes for a specific RFQ.
+// Quotes of a sealed-bid RFQ are returned without their contents until bids are opened.
 func (s *QuoteService) GetQuotesByRFQ(ctx context.Context, tenantID string, rfqID string) ([]QuoteResponse, error) {
 	quotes, err := s.repo.GetByRFQID(ctx, rfqID, tenantID)
 	if err != nil {
@@ -99,7 +118,11 @@ func (s *QuoteService) GetQuotesByRFQ(ctx context.Context, tenantID string, rfqI
 		return nil, err
 	}
 
-	return ToQuoteResponses(quotes), nil
+	responses := ToQuoteResponses(quotes)
+	if err := s.sealResponses(ctx, tenantID, responses); err != nil {
+		return nil, err
+	}
+	return responses, nil
 }
 
 // GetQuotesBySupplier retrieves all quotes from a specific supplier
@@ -112,7 +135,11 @@ func (s *QuoteService) GetQuotesBySupplier(ctx context.Context, tenantID string,
 		return nil, err
 	}
 
-	return ToQuoteResponses(quotes), nil
+	responses := ToQuoteResponses(quotes)
+	if err := s.sealResponses(ctx, tenantID, responses); err != nil {
+		return nil, err
+	}
+	return responses, nil
 }
 
 // ListQuotes retrieves quotes with filtering and pagination
@@ -132,8 +159,13 @@ func (s *QuoteService) ListQuotes(ctx context.Context, criteria domain.ListCrite
 		pageSize = 20
 	}
 
+	responses := ToQuoteResponses(quotes)
+	if err := s.sealResponses(ctx, criteria.TenantID, responses); err != nil {
+		return nil, err
+	}
+
 	return &ListQuotesResponse{
-		Quotes: ToQuoteResponses(quotes),
+		Quotes: responses,
 		Total:  total,
 		Page:   page,
 		Size:   pageSize,
@@ -156,7 +188,11 @@ func (s *QuoteService) UpdateQuote(ctx context.Context, tenantID string, quoteID
 	quote.PaymentTerms = req.PaymentTerms
 	quote.WarrantyTerms = req.WarrantyTerms
 	quote.Notes = req.Notes
-	if req.ValidUntil != nil {
+	if req.ValidUntil != nil && !req.ValidUntil.Equal(quote.ValidUntil) {
+		// Once submitted, validity changes go through an extension request the buyer approves
+		if quote.Status != domain.QuoteStatusDraft {
+			return nil, domain.ErrValidityChangeRequired
+		}
 		quote.ValidUntil = *req.ValidUntil
 	}
 
@@ -277,6 +313,17 @@ func (s *QuoteService) SubmitQuote(ctx context.Context, tenantID string, quoteID
 		return nil, err
 	}
 
+	// Late submissions are rejected once the RFQ response deadline has passed
+	window, err := s.bidWindow(ctx, tenantID, quote.RFQID)
+	if err != nil {
+		return nil, err
+	}
+	if window != nil {
+		if err := window.AcceptsQuotes(time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := quote.Submit(); err != nil {
 		s.logger.Error("Failed to submit quote", slog.String("error", err.Error()))
 		return nil, err
@@ -327,6 +374,10 @@ func (s *QuoteService) AcceptQuote(ctx context.Context, tenantID string, quoteID
 		return nil, err
 	}
 
+	if err := s.ensureUnsealed(ctx, quote); err != nil {
+		return nil, err
+	}
+
 	if err := quote.Accept(req.ReviewedBy, req.Notes); err != nil {
 		s.logger.Error("Failed to accept quote", slog.String("error", err.Error()))
 		return nil, err
@@ -352,6 +403,10 @@ func (s *QuoteService) RejectQuote(ctx context.Context, tenantID string, quoteID
 		return nil, err
 	}
 
+	if err := s.ensureUnsealed(ctx, quote); err != nil {
+		return nil, err
+	}
+
 	if err := quote.Reject(req.ReviewedBy, req.Reason); err != nil {
 		s.logger.Error("Failed to reject quote", slog.String("error", err.Error()))
 		return nil, err
@@ -400,6 +455,10 @@ func (s *QuoteService) MarkQuoteUnderReview(ctx context.Context, tenantID string
 		return nil, err
 	}
 
+	if err := s.ensureUnsealed(ctx, quote); err != nil {
+		return nil, err
+	}
+
 	if err := quote.MarkUnderReview(); err != nil {
 		s.logger.Error("Failed to mark quote under review", slog.String("error", err.Error()))
 		return nil, err
@@ -426,3 +485,50 @@ func (s *QuoteService) DeleteQuote(ctx context.Context, tenantID string, quoteID
 	s.logger.Info("Quote deleted successfully", slog.String("quote_id", quoteID))
 	return nil
 }
+
+// bidWindow loads the bid window of the quote's RFQ; nil if the RFQ is unknown
+func (s *QuoteService) bidWindow(ctx context.Context, tenantID, rfqID string) (*domain.BidWindow, error) {
+	if s.bids == nil {
+		return nil, nil
+	}
+	window, err := s.bids.GetBidWindow(ctx, tenantID, rfqID)
+	if err != nil {
+		s.logger.Error("Failed to load RFQ bid window",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", rfqID))
+		return nil, err
+	}
+	return window, nil
+}
+
+// ensureUnsealed rejects buyer evaluation of a quote whose bids are still sealed
+func (s *QuoteService) ensureUnsealed(ctx context.Context, quote *domain.Quote) error {
+	window, err := s.bidWindow(ctx, quote.TenantID, quote.RFQID)
+	if err != nil {
+		return err
+	}
+	if window != nil && window.Sealed() {
+		return domain.ErrBidsSealed
+	}
+	return nil
+}
+
+// sealResponses hides the contents of quotes whose RFQ bids are still sealed
+func (s *QuoteService) sealResponses(ctx context.Context, tenantID string, responses []QuoteResponse) error {
+	windows := make(map[string]*domain.BidWindow)
+	for i := range responses {
+		rfqID := responses[i].RFQID
+		window, ok := windows[rfqID]
+		if !ok {
+			var err error
+			if window, err = s.bidWindow(ctx, tenantID, rfqID); err != nil {
+				return err
+			}
+			windows[rfqID] = window
+		}
+		if window != nil && window.Sealed() {
+			responses[i].Seal()
+		}
+	}
+	return nil
+}
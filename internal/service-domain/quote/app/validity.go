@@ -0,0 +1,251 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+)
+
+// supplierOrgType is the organization type of supplier users
+const supplierOrgType = "supplier"
+
+// RequestValidityExtension records a supplier's request to extend a quote's
+// validity and notifies the buyer. Only the quote's supplier may ask, and only
+// one request may be pending per quote.
+func (s *QuoteService) RequestValidityExtension(ctx context.Context, tenantID, quoteID string, caller Caller, req RequestValidityExtensionRequest) (*domain.ValidityExtension, error) {
+	quote, err := s.repo.GetByID(ctx, quoteID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureQuoteSupplier(ctx, quote, caller); err != nil {
+		return nil, err
+	}
+
+	extensions, err := s.validity.ListExtensions(ctx, tenantID, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range extensions {
+		if e.Status == domain.ExtensionStatusPending {
+			return nil, domain.ErrExtensionPending
+		}
+	}
+
+	extension, err := quote.RequestValidityExtension(req.ValidUntil, req.Reason, caller.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validity.CreateExtension(ctx, extension); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, quote.Notify(domain.NotificationKindExtensionRequested,
+		fmt.Sprintf("Quote %s: validity extension requested", quoteLabel(quote)),
+		strings.TrimSpace(fmt.Sprintf("The supplier asks to extend validity from %s to %s. %s",
+			extension.PreviousValidUntil.Format("2006-01-02"), extension.RequestedValidUntil.Format("2006-01-02"), extension.Reason)),
+		domain.RecipientBuyer))
+
+	s.logger.Info("Quote validity extension requested",
+		slog.String("quote_id", quote.ID),
+		slog.String("extension_id", extension.ID))
+	return extension, nil
+}
+
+// ListValidityExtensions lists a quote's validity extension requests, newest first
+func (s *QuoteService) ListValidityExtensions(ctx context.Context, tenantID, quoteID string) ([]*domain.ValidityExtension, error) {
+	if _, err := s.repo.GetByID(ctx, quoteID, tenantID); err != nil {
+		return nil, err
+	}
+	return s.validity.ListExtensions(ctx, tenantID, quoteID)
+}
+
+// ApproveValidityExtension extends the quote's validity, recording the change
+// as a quote revision, and notifies the supplier. Only the buyer may approve.
+func (s *QuoteService) ApproveValidityExtension(ctx context.Context, tenantID, quoteID, extensionID string, caller Caller, req DecideValidityExtensionRequest) (*QuoteResponse, error) {
+	if err := ensureBuyer(caller); err != nil {
+		return nil, err
+	}
+	extension, err := s.validity.GetExtension(ctx, tenantID, quoteID, extensionID)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := s.repo.GetByID(ctx, quoteID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := quote.ApproveValidityExtension(extension, caller.UserID, req.Notes); err != nil {
+		return nil, err
+	}
+	if err := s.validity.DecideExtension(ctx, extension, quote); err != nil {
+		s.logger.Error("Failed to approve validity extension", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.notify(ctx, quote.Notify(domain.NotificationKindExtensionApproved,
+		fmt.Sprintf("Quote %s: validity extended to %s", quoteLabel(quote), quote.ValidUntil.Format("2006-01-02")),
+		req.Notes, domain.RecipientSupplier))
+
+	s.logger.Info("Quote validity extended",
+		slog.String("quote_id", quote.ID),
+		slog.Time("valid_until", quote.ValidUntil))
+
+	response := ToQuoteResponse(quote)
+	return &response, nil
+}
+
+// RejectValidityExtension declines a validity extension and notifies the
+// supplier. Only the buyer may decline.
+func (s *QuoteService) RejectValidityExtension(ctx context.Context, tenantID, quoteID, extensionID string, caller Caller, req DecideValidityExtensionRequest) (*domain.ValidityExtension, error) {
+	if err := ensureBuyer(caller); err != nil {
+		return nil, err
+	}
+	extension, err := s.validity.GetExtension(ctx, tenantID, quoteID, extensionID)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := s.repo.GetByID(ctx, quoteID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := extension.Reject(caller.UserID, req.Notes); err != nil {
+		return nil, err
+	}
+	if err := s.validity.DecideExtension(ctx, extension, quote); err != nil {
+		s.logger.Error("Failed to reject validity extension", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.notify(ctx, quote.Notify(domain.NotificationKindExtensionRejected,
+		fmt.Sprintf("Quote %s: validity extension declined", quoteLabel(quote)),
+		req.Notes, domain.RecipientSupplier))
+	return extension, nil
+}
+
+// ensureQuoteSupplier rejects callers that are not the supplier of the quote
+func (s *QuoteService) ensureQuoteSupplier(ctx context.Context, quote *domain.Quote, caller Caller) error {
+	if caller.OrganizationType != supplierOrgType || caller.OrganizationID == "" {
+		return domain.ErrNotQuoteSupplier
+	}
+	supplierID, err := s.suppliers.SupplierForOrganization(ctx, quote.TenantID, caller.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if supplierID == "" || supplierID != quote.SupplierID {
+		return domain.ErrNotQuoteSupplier
+	}
+	return nil
+}
+
+// ensureBuyer rejects supplier callers; validity decisions are the buyer's
+func ensureBuyer(caller Caller) error {
+	if caller.OrganizationID == "" || caller.OrganizationType == supplierOrgType {
+		return domain.ErrNotQuoteBuyer
+	}
+	return nil
+}
+
+// ListNotifications lists quote validity notifications, newest first
+func (s *QuoteService) ListNotifications(ctx context.Context, tenantID string, filter domain.NotificationFilter) ([]domain.QuoteNotification, error) {
+	return s.validity.ListNotifications(ctx, tenantID, filter)
+}
+
+// MarkNotificationRead marks a quote notification as read
+func (s *QuoteService) MarkNotificationRead(ctx context.Context, tenantID, notificationID string) error {
+	return s.validity.MarkNotificationRead(ctx, tenantID, notificationID)
+}
+
+// ExpireQuotes marks every open quote past its validity as expired, notifies
+// the supplier and buyer, and returns how many were expired
+func (s *QuoteService) ExpireQuotes(ctx context.Context, now time.Time) (int, error) {
+	quotes, err := s.validity.ListOpenExpired(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, quote := range quotes {
+		if err := quote.MarkExpired(); err != nil {
+			continue
+		}
+		if err := s.repo.Update(ctx, quote); err != nil {
+			s.logger.Error("Failed to expire quote",
+				slog.String("error", err.Error()),
+				slog.String("quote_id", quote.ID))
+			continue
+		}
+		expired++
+
+		s.notify(ctx, quote.Notify(domain.NotificationKindExpired,
+			fmt.Sprintf("Quote %s has expired", quoteLabel(quote)),
+			fmt.Sprintf("The quote was valid until %s and can no longer be accepted unless its validity is extended.",
+				quote.ValidUntil.Format("2006-01-02")),
+			domain.RecipientSupplier, domain.RecipientBuyer))
+	}
+
+	return expired, nil
+}
+
+// SendExpiryReminders notifies the supplier and buyer of each open quote that
+// expires within the window. Each validity date is reminded about once.
+func (s *QuoteService) SendExpiryReminders(ctx context.Context, now time.Time, window time.Duration) (int, error) {
+	quotes, err := s.validity.ListExpiringUnreminded(ctx, now, now.Add(window))
+	if err != nil {
+		return 0, err
+	}
+
+	reminded := 0
+	for _, quote := range quotes {
+		if !quote.ExpiresWithin(now, window) {
+			continue
+		}
+		days := int(quote.ValidUntil.Sub(now).Hours()/24 + 0.5)
+		when := fmt.Sprintf("in %d days", days)
+		switch days {
+		case 0:
+			when = "today"
+		case 1:
+			when = "in 1 day"
+		}
+
+		notifications := quote.Notify(domain.NotificationKindExpiring,
+			fmt.Sprintf("Quote %s expires %s", quoteLabel(quote), when),
+			fmt.Sprintf("The quote is valid until %s. Accept it or request a validity extension before then.",
+				quote.ValidUntil.Format("2006-01-02 15:04 MST")),
+			domain.RecipientSupplier, domain.RecipientBuyer)
+		if err := s.validity.AddNotifications(ctx, notifications); err != nil {
+			s.logger.Error("Failed to send quote expiry reminder",
+				slog.String("error", err.Error()),
+				slog.String("quote_id", quote.ID))
+			continue
+		}
+		reminded++
+	}
+
+	return reminded, nil
+}
+
+// notify stores notifications; failures are logged so the change itself is not rolled back
+func (s *QuoteService) notify(ctx context.Context, notifications []domain.QuoteNotification) {
+	if len(notifications) == 0 {
+		return
+	}
+	if err := s.validity.AddNotifications(ctx, notifications); err != nil {
+		s.logger.Error("Failed to store quote notifications",
+			slog.String("error", err.Error()),
+			slog.String("quote_id", notifications[0].QuoteID))
+	}
+}
+
+// quoteLabel names a quote by number, falling back to its ID for drafts
+func quoteLabel(quote *domain.Quote) string {
+	if quote.QuoteNumber != "" {
+		return quote.QuoteNumber
+	}
+	return quote.ID
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrBidsSealed       = errors.New("quotes for this rfq are sealed until bid opening")
+	ErrSubmissionClosed = errors.New("rfq response deadline has passed")
+)
+
+// BidWindow is the RFQ state that governs quote submission and visibility
+type BidWindow struct {
+	RFQID            string     `json:"rfq_id"`
+	Status           string     `json:"status"`
+	ResponseDeadline time.Time  `json:"response_deadline"`
+	SealedBid        bool       `json:"sealed_bid"`
+	BidsOpenedAt     *time.Time `json:"bids_opened_at,omitempty"`
+}
+
+// Sealed reports whether quote contents must still be hidden from the buyer
+func (w *BidWindow) Sealed() bool {
+	return w.SealedBid && w.BidsOpenedAt == nil
+}
+
+// AcceptsQuotes checks that a quote can still be submitted at the given time
+func (w *BidWindow) AcceptsQuotes(now time.Time) error {
+	if w.Status != "published" {
+		return fmt.Errorf("%w: rfq is %s", ErrSubmissionClosed, w.Status)
+	}
+	if now.After(w.ResponseDeadline) {
+		return ErrSubmissionClosed
+	}
+	return nil
+}
+
+// BidWindowReader reads the bid window of an RFQ
+type BidWindowReader interface {
+	GetBidWindow(ctx context.Context, tenantID, rfqID string) (*BidWindow, error)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBidWindowAcceptsQuotes(t *testing.T) {
+	now := time.Now()
+	window := &BidWindow{Status: "published", ResponseDeadline: now.Add(time.Hour)}
+	if err := window.AcceptsQuotes(now); err != nil {
+		t.Fatalf("expected open window, got %v", err)
+	}
+	if err := window.AcceptsQuotes(now.Add(2 * time.Hour)); !errors.Is(err, ErrSubmissionClosed) {
+		t.Fatalf("expected late submission to be rejected, got %v", err)
+	}
+
+	for _, status := range []string{"draft", "closed", "cancelled"} {
+		window.Status = status
+		if err := window.AcceptsQuotes(now); !errors.Is(err, ErrSubmissionClosed) {
+			t.Fatalf("%s: expected ErrSubmissionClosed, got %v", status, err)
+		}
+	}
+}
+
+func TestBidWindowSealed(t *testing.T) {
+	window := &BidWindow{SealedBid: true}
+	if !window.Sealed() {
+		t.Fatal("sealed window should hide quotes before opening")
+	}
+	opened := time.Now()
+	window.BidsOpenedAt = &opened
+	if window.Sealed() {
+		t.Fatal("opened window should not hide quotes")
+	}
+	if (&BidWindow{}).Sealed() {
+		t.Fatal("open bidding should never be sealed")
+	}
+}
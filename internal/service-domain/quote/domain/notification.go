@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var ErrNotificationNotFound = errors.New("quote notification not found")
+
+// Notification recipients: the supplier that owns the quote, or the buyer
+// side of the tenant that requested it
+const (
+	RecipientSupplier = "supplier"
+	RecipientBuyer    = "buyer"
+)
+
+// Quote notification kinds
+const (
+	NotificationKindExpiring           = "expiring"
+	NotificationKindExpired            = "expired"
+	NotificationKindExtensionRequested = "extension_requested"
+	NotificationKindExtensionApproved  = "extension_approved"
+	NotificationKindExtensionRejected  = "extension_rejected"
+)
+
+// QuoteNotification tells a supplier or buyer about a quote's validity
+type QuoteNotification struct {
+	ID         string     `json:"id"`
+	TenantID   string     `json:"tenant_id"`
+	QuoteID    string     `json:"quote_id"`
+	RFQID      string     `json:"rfq_id"`
+	SupplierID string     `json:"supplier_id"`
+	Recipient  string     `json:"recipient"`
+	Kind       string     `json:"kind"`
+	Subject    string     `json:"subject"`
+	Message    string     `json:"message,omitempty"`
+	ValidUntil time.Time  `json:"valid_until"` // Validity the notification refers to
+	CreatedAt  time.Time  `json:"created_at"`
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+}
+
+// NotificationFilter narrows a notification listing
+type NotificationFilter struct {
+	Recipient  string
+	SupplierID string
+	UnreadOnly bool
+}
+
+// Notify builds a notification about the quote for each recipient
+func (q *Quote) Notify(kind, subject, message string, recipients ...string) []QuoteNotification {
+	now := time.Now()
+	notifications := make([]QuoteNotification, 0, len(recipients))
+	for _, recipient := range recipients {
+		notifications = append(notifications, QuoteNotification{
+			ID:         ksuid.New().String(),
+			TenantID:   q.TenantID,
+			QuoteID:    q.ID,
+			RFQID:      q.RFQID,
+			SupplierID: q.SupplierID,
+			Recipient:  recipient,
+			Kind:       kind,
+			Subject:    subject,
+			Message:    message,
+			ValidUntil: q.ValidUntil,
+			CreatedAt:  now,
+		})
+	}
+	return notifications
+}
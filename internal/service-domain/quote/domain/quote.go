@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/aby-med/medical-platform/internal/pkg/money"
 	"github.com/segmentio/ksuid"
 )
 
@@ -29,6 +30,9 @@ type Quote struct {
 	RFQID      string `json:"rfq_id"`
 	SupplierID string `json:"supplier_id"`
 
+	// RFQVersion is the RFQ version (see RFQ addenda) the quote responds to
+	RFQVersion int `json:"rfq_version"`
+
 	// Quote details
 	QuoteNumber    string      `json:"quote_number"`
 	Status         QuoteStatus `json:"status"`
@@ -115,8 +119,9 @@ func NewQuote(tenantID, rfqID, supplierID, createdBy string, validUntil time.Tim
 		TenantID:       tenantID,
 		RFQID:          rfqID,
 		SupplierID:     supplierID,
+		RFQVersion:     1,
 		Status:         QuoteStatusDraft,
-		Currency:       "USD", // Default
+		Currency:       money.DefaultCurrency,
 		ValidUntil:     validUntil,
 		RevisionNumber: 1,
 		Items:          []QuoteItem{},
@@ -142,8 +147,7 @@ func (q *Quote) AddItem(item QuoteItem) error {
 	}
 
 	// Calculate total price
-	item.TotalPrice = float64(item.Quantity) * item.UnitPrice
-	item.TaxAmount = item.TotalPrice * item.TaxRate
+	q.priceItem(&item)
 
 	q.Items = append(q.Items, item)
 	q.recalculateTotal()
@@ -180,8 +184,7 @@ func (q *Quote) UpdateItem(itemID string, updatedItem QuoteItem) error {
 		if item.ID == itemID {
 			// Preserve ID
 			updatedItem.ID = item.ID
-			updatedItem.TotalPrice = float64(updatedItem.Quantity) * updatedItem.UnitPrice
-			updatedItem.TaxAmount = updatedItem.TotalPrice * updatedItem.TaxRate
+			q.priceItem(&updatedItem)
 			
 			q.Items[i] = updatedItem
 			q.recalculateTotal()
@@ -305,6 +308,9 @@ func (q *Quote) MarkUnderReview() error {
 
 // MarkExpired marks the quote as expired
 func (q *Quote) MarkExpired() error {
+	if !q.IsOpen() {
+		return ErrInvalidStatus
+	}
 	if time.Now().Before(q.ValidUntil) {
 		return errors.New("quote is not yet expired")
 	}
@@ -317,12 +323,39 @@ func (q *Quote) MarkExpired() error {
 
 // Private helper methods
 
+// priceItem computes a line's total and tax in exact decimals, each rounded
+// to the minor units of the quote's currency
+func (q *Quote) priceItem(item *QuoteItem) {
+	total := money.RoundTo(money.NewFromInt(int64(item.Quantity)).Mul(money.NewFromFloat(item.UnitPrice)), q.Currency)
+	tax := money.RoundTo(total.Mul(money.NewFromFloat(item.TaxRate)), q.Currency)
+	item.TotalPrice = total.Float64()
+	item.TaxAmount = tax.Float64()
+}
+
 func (q *Quote) recalculateTotal() {
-	total := 0.0
+	total := money.Zero
 	for _, item := range q.Items {
-		total += item.TotalPrice + item.TaxAmount
+		total = total.Add(money.NewFromFloat(item.TotalPrice)).Add(money.NewFromFloat(item.TaxAmount))
 	}
-	q.TotalAmount = total
+	q.TotalAmount = total.Float64()
+}
+
+// SetCurrency sets the currency the quote is priced in and reprices its lines
+func (q *Quote) SetCurrency(currency string) error {
+	if !q.IsEditable() {
+		return ErrQuoteAlreadySubmitted
+	}
+	code, err := money.NormalizeCurrency(currency)
+	if err != nil {
+		return err
+	}
+	q.Currency = code
+	for i := range q.Items {
+		q.priceItem(&q.Items[i])
+	}
+	q.recalculateTotal()
+	q.UpdatedAt = time.Now()
+	return nil
 }
 
 // IsEditable checks if the quote can be edited
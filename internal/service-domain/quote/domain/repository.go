@@ -26,6 +26,13 @@ type QuoteRepository interface {
 	Delete(ctx context.Context, id string, tenantID string) error
 }
 
+// SupplierResolver finds the supplier an organization acts as
+type SupplierResolver interface {
+	// SupplierForOrganization returns the ID of the tenant's supplier linked
+	// to the organization, or "" when there is none
+	SupplierForOrganization(ctx context.Context, tenantID, organizationID string) (string, error)
+}
+
 // ListCriteria defines filtering and pagination options for listing quotes
 type ListCriteria struct {
 	TenantID       string        `json:"tenant_id"`
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrExtensionNotFound      = errors.New("validity extension not found")
+	ErrInvalidExtension       = errors.New("invalid validity extension")
+	ErrExtensionPending       = errors.New("a validity extension is already pending for this quote")
+	ErrExtensionDecided       = errors.New("validity extension already decided")
+	ErrValidityChangeRequired = errors.New("validity of a submitted quote can only change through an approved extension")
+	ErrNotQuoteSupplier       = errors.New("only the quote's supplier may request a validity extension")
+	ErrNotQuoteBuyer          = errors.New("only the buyer may decide a validity extension")
+)
+
+// ExtensionStatus represents the state of a validity extension request
+type ExtensionStatus string
+
+const (
+	ExtensionStatusPending  ExtensionStatus = "pending"
+	ExtensionStatusApproved ExtensionStatus = "approved"
+	ExtensionStatusRejected ExtensionStatus = "rejected"
+)
+
+// ValidityExtension is a supplier's request to keep a quote valid for longer.
+// Once approved by the buyer it is recorded on the quote as a revision.
+type ValidityExtension struct {
+	ID                  string          `json:"id"`
+	TenantID            string          `json:"tenant_id"`
+	QuoteID             string          `json:"quote_id"`
+	PreviousValidUntil  time.Time       `json:"previous_valid_until"`
+	RequestedValidUntil time.Time       `json:"requested_valid_until"`
+	Reason              string          `json:"reason,omitempty"`
+	Status              ExtensionStatus `json:"status"`
+	RequestedBy         string          `json:"requested_by"`
+	RequestedAt         time.Time       `json:"requested_at"`
+	DecidedBy           *string         `json:"decided_by,omitempty"`
+	DecidedAt           *time.Time      `json:"decided_at,omitempty"`
+	DecisionNotes       string          `json:"decision_notes,omitempty"`
+}
+
+// IsOpen reports whether the quote is still in play, i.e. can still be
+// accepted, revised or expire
+func (q *Quote) IsOpen() bool {
+	switch q.Status {
+	case QuoteStatusDraft, QuoteStatusSubmitted, QuoteStatusUnderReview, QuoteStatusRevised:
+		return true
+	}
+	return false
+}
+
+// ExpiresWithin reports whether an open quote's validity ends within the window after now
+func (q *Quote) ExpiresWithin(now time.Time, window time.Duration) bool {
+	return q.IsOpen() && q.ValidUntil.After(now) && !q.ValidUntil.After(now.Add(window))
+}
+
+// RequestValidityExtension asks the buyer to extend a submitted (or lapsed) quote's validity
+func (q *Quote) RequestValidityExtension(validUntil time.Time, reason, requestedBy string) (*ValidityExtension, error) {
+	if q.Status == QuoteStatusDraft || (!q.IsOpen() && q.Status != QuoteStatusExpired) {
+		return nil, fmt.Errorf("%w: cannot extend a quote in status %s", ErrInvalidExtension, q.Status)
+	}
+	now := time.Now()
+	if !validUntil.After(q.ValidUntil) || !validUntil.After(now) {
+		return nil, fmt.Errorf("%w: valid_until must be later than the current validity and in the future", ErrInvalidExtension)
+	}
+
+	return &ValidityExtension{
+		ID:                  ksuid.New().String(),
+		TenantID:            q.TenantID,
+		QuoteID:             q.ID,
+		PreviousValidUntil:  q.ValidUntil,
+		RequestedValidUntil: validUntil,
+		Reason:              reason,
+		Status:              ExtensionStatusPending,
+		RequestedBy:         requestedBy,
+		RequestedAt:         now,
+	}, nil
+}
+
+// ApproveValidityExtension applies a pending extension and records it as a
+// quote revision. A quote that expired while waiting is reinstated.
+func (q *Quote) ApproveValidityExtension(e *ValidityExtension, decidedBy, notes string) error {
+	if e.Status != ExtensionStatusPending {
+		return ErrExtensionDecided
+	}
+	if q.Status != QuoteStatusExpired && !q.IsOpen() {
+		return fmt.Errorf("%w: cannot extend a quote in status %s", ErrInvalidExtension, q.Status)
+	}
+	if !q.ValidUntil.Equal(e.PreviousValidUntil) {
+		return fmt.Errorf("%w: quote validity changed since the extension was requested", ErrInvalidExtension)
+	}
+	now := time.Now()
+	if !e.RequestedValidUntil.After(now) {
+		return fmt.Errorf("%w: requested validity has already passed", ErrInvalidExtension)
+	}
+
+	q.Revisions = append(q.Revisions, QuoteRevision{
+		RevisionNumber: q.RevisionNumber,
+		RevisedAt:      now,
+		RevisedBy:      decidedBy,
+		Changes: fmt.Sprintf("Validity extended from %s to %s",
+			e.PreviousValidUntil.Format("2006-01-02"), e.RequestedValidUntil.Format("2006-01-02")),
+		PreviousTotal: q.TotalAmount,
+		NewTotal:      q.TotalAmount,
+		Metadata: map[string]interface{}{
+			"validity_extension_id": e.ID,
+			"previous_valid_until":  e.PreviousValidUntil,
+			"new_valid_until":       e.RequestedValidUntil,
+			"requested_by":          e.RequestedBy,
+		},
+	})
+	q.RevisionNumber++
+	q.ValidUntil = e.RequestedValidUntil
+	if q.Status == QuoteStatusExpired {
+		q.Status = QuoteStatusSubmitted
+	}
+	q.UpdatedAt = now
+
+	e.decide(ExtensionStatusApproved, decidedBy, notes, now)
+	return nil
+}
+
+// Reject declines a pending extension; the quote keeps its validity
+func (e *ValidityExtension) Reject(decidedBy, notes string) error {
+	if e.Status != ExtensionStatusPending {
+		return ErrExtensionDecided
+	}
+	e.decide(ExtensionStatusRejected, decidedBy, notes, time.Now())
+	return nil
+}
+
+func (e *ValidityExtension) decide(status ExtensionStatus, decidedBy, notes string, at time.Time) {
+	e.Status = status
+	e.DecidedBy = &decidedBy
+	e.DecidedAt = &at
+	e.DecisionNotes = notes
+}
+
+// ValidityRepository persists validity extensions and supports the expiry sweep
+type ValidityRepository interface {
+	// ListOpenExpired retrieves open quotes of all tenants whose validity ended before now
+	ListOpenExpired(ctx context.Context, now time.Time) ([]*Quote, error)
+
+	// ListExpiringUnreminded retrieves open quotes of all tenants expiring
+	// between now and cutoff that have not been reminded about their current validity
+	ListExpiringUnreminded(ctx context.Context, now, cutoff time.Time) ([]*Quote, error)
+
+	// CreateExtension stores a new extension request
+	CreateExtension(ctx context.Context, e *ValidityExtension) error
+
+	// GetExtension retrieves an extension request of a quote
+	GetExtension(ctx context.Context, tenantID, quoteID, id string) (*ValidityExtension, error)
+
+	// ListExtensions retrieves a quote's extension requests, newest first
+	ListExtensions(ctx context.Context, tenantID, quoteID string) ([]*ValidityExtension, error)
+
+	// DecideExtension atomically stores a decided extension and, when approved, the extended quote
+	DecideExtension(ctx context.Context, e *ValidityExtension, quote *Quote) error
+
+	// AddNotifications stores notifications, skipping duplicates of the same reminder
+	AddNotifications(ctx context.Context, notifications []QuoteNotification) error
+
+	// ListNotifications retrieves notifications, newest first
+	ListNotifications(ctx context.Context, tenantID string, filter NotificationFilter) ([]QuoteNotification, error)
+
+	// MarkNotificationRead marks a notification as read
+	MarkNotificationRead(ctx context.Context, tenantID, id string) error
+}
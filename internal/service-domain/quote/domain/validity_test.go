@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestApproveValidityExtensionReinstatesExpiredQuote(t *testing.T) {
+	q, err := NewQuote("tenant", "rfq", "supplier", "user", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	q.Status = QuoteStatusExpired
+	q.ValidUntil = time.Now().Add(-time.Hour)
+
+	newValidity := time.Now().Add(7 * 24 * time.Hour)
+	ext, err := q.RequestValidityExtension(newValidity, "price held", "supplier")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := q.ApproveValidityExtension(ext, "buyer", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if q.Status != QuoteStatusSubmitted || !q.ValidUntil.Equal(newValidity) {
+		t.Fatalf("quote not reinstated: status %s, valid until %s", q.Status, q.ValidUntil)
+	}
+	if len(q.Revisions) != 1 || q.RevisionNumber != 2 {
+		t.Fatalf("expected the extension recorded as revision 1, got %d revisions", len(q.Revisions))
+	}
+	if err := q.ApproveValidityExtension(ext, "buyer", ""); !errors.Is(err, ErrExtensionDecided) {
+		t.Fatalf("expected ErrExtensionDecided, got %v", err)
+	}
+}
+
+func TestRequestValidityExtensionMustExtend(t *testing.T) {
+	q, _ := NewQuote("tenant", "rfq", "supplier", "user", time.Now().Add(48*time.Hour))
+	q.Status = QuoteStatusSubmitted
+
+	if _, err := q.RequestValidityExtension(q.ValidUntil.Add(-time.Hour), "", "supplier"); !errors.Is(err, ErrInvalidExtension) {
+		t.Fatalf("expected ErrInvalidExtension, got %v", err)
+	}
+	if !q.ExpiresWithin(time.Now(), 72*time.Hour) || q.ExpiresWithin(time.Now(), 24*time.Hour) {
+		t.Fatal("unexpected reminder window result")
+	}
+}
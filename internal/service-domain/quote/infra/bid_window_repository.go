@@ -0,0 +1,42 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// BidWindowRepository implements domain.BidWindowReader over the rfqs table
+type BidWindowRepository struct {
+	db *PostgresDB
+}
+
+// NewBidWindowRepository creates a new bid window reader
+func NewBidWindowRepository(db *PostgresDB) *BidWindowRepository {
+	return &BidWindowRepository{db: db}
+}
+
+// GetBidWindow returns the RFQ's bid window, or nil if the RFQ is unknown
+func (r *BidWindowRepository) GetBidWindow(ctx context.Context, tenantID, rfqID string) (*domain.BidWindow, error) {
+	query := `
+		SELECT id, status, response_deadline, sealed_bid, bids_opened_at
+		FROM rfqs
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	var w domain.BidWindow
+	err := r.db.pool.QueryRow(ctx, query, rfqID, tenantID).Scan(
+		&w.RFQID, &w.Status, &w.ResponseDeadline, &w.SealedBid, &w.BidsOpenedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get rfq bid window: %w", err)
+	}
+
+	return &w, nil
+}
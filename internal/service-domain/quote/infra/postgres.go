@@ -40,6 +40,15 @@ func NewPostgresDB(ctx context.Context, connString string, logger *slog.Logger)
 	}, nil
 }
 
+// NewPostgresDBFromPool wraps a connection pool owned by another module; the
+// owner keeps responsibility for closing it.
+func NewPostgresDBFromPool(pool *pgxpool.Pool, logger *slog.Logger) *PostgresDB {
+	return &PostgresDB{
+		pool:   pool,
+		logger: logger.With(slog.String("component", "postgres_db")),
+	}
+}
+
 // Close closes the database connection pool
 func (db *PostgresDB) Close() {
 	db.pool.Close()
@@ -40,9 +40,9 @@ func (r *QuoteRepository) Create(ctx context.Context, quote *domain.Quote) error
 			id, tenant_id, rfq_id, supplier_id, quote_number, status,
 			total_amount, currency, valid_until, delivery_terms, payment_terms,
 			warranty_terms, notes, revision_number, reviewed_at, reviewed_by,
-			review_notes, rejection_reason, metadata, created_by, created_at, updated_at
+			review_notes, rejection_reason, metadata, created_by, created_at, updated_at, rfq_version
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
 		)
 	`
 
@@ -54,6 +54,7 @@ func (r *QuoteRepository) Create(ctx context.Context, quote *domain.Quote) error
 		quote.DeliveryTerms, quote.PaymentTerms, quote.WarrantyTerms, quote.Notes,
 		quote.RevisionNumber, quote.ReviewedAt, quote.ReviewedBy, quote.ReviewNotes,
 		quote.RejectionReason, metadataJSON, quote.CreatedBy, quote.CreatedAt, quote.UpdatedAt,
+		quote.RFQVersion,
 	)
 	if err != nil {
 		r.logger.Error("Failed to create quote", slog.String("error", err.Error()))
@@ -122,7 +123,7 @@ func (r *QuoteRepository) GetByID(ctx context.Context, id string, tenantID strin
 			id, tenant_id, rfq_id, supplier_id, quote_number, status,
 			total_amount, currency, valid_until, delivery_terms, payment_terms,
 			warranty_terms, notes, revision_number, reviewed_at, reviewed_by,
-			review_notes, rejection_reason, metadata, created_by, created_at, updated_at
+			review_notes, rejection_reason, metadata, created_by, created_at, updated_at, rfq_version
 		FROM quotes
 		WHERE id = $1 AND tenant_id = $2
 	`
@@ -137,6 +138,7 @@ func (r *QuoteRepository) GetByID(ctx context.Context, id string, tenantID strin
 		&quote.DeliveryTerms, &quote.PaymentTerms, &quote.WarrantyTerms, &quote.Notes,
 		&quote.RevisionNumber, &quote.ReviewedAt, &quote.ReviewedBy, &quote.ReviewNotes,
 		&quote.RejectionReason, &metadataJSON, &quote.CreatedBy, &quote.CreatedAt, &quote.UpdatedAt,
+		&quote.RFQVersion,
 	)
 
 	if err != nil {
@@ -399,6 +401,20 @@ func (r *QuoteRepository) Update(ctx context.Context, quote *domain.Quote) error
 	}
 	defer tx.Rollback(ctx)
 
+	if err := r.update(ctx, tx, quote); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Info("Quote updated successfully", slog.String("quote_id", quote.ID))
+	return nil
+}
+
+// update writes a quote with its items and new revisions within a transaction
+func (r *QuoteRepository) update(ctx context.Context, tx pgx.Tx, quote *domain.Quote) error {
 	// Update quote
 	quoteQuery := `
 		UPDATE quotes SET
@@ -406,8 +422,8 @@ func (r *QuoteRepository) Update(ctx context.Context, quote *domain.Quote) error
 			valid_until = $5, delivery_terms = $6, payment_terms = $7,
 			warranty_terms = $8, notes = $9, revision_number = $10,
 			reviewed_at = $11, reviewed_by = $12, review_notes = $13,
-			rejection_reason = $14, metadata = $15, updated_at = $16
-		WHERE id = $17 AND tenant_id = $18
+			rejection_reason = $14, metadata = $15, updated_at = $16, rfq_version = $17
+		WHERE id = $18 AND tenant_id = $19
 	`
 
 	metadataJSON, _ := json.Marshal(quote.Metadata)
@@ -417,7 +433,7 @@ func (r *QuoteRepository) Update(ctx context.Context, quote *domain.Quote) error
 		quote.ValidUntil, quote.DeliveryTerms, quote.PaymentTerms, quote.WarrantyTerms,
 		quote.Notes, quote.RevisionNumber, quote.ReviewedAt, quote.ReviewedBy,
 		quote.ReviewNotes, quote.RejectionReason, metadataJSON, quote.UpdatedAt,
-		quote.ID, quote.TenantID,
+		quote.RFQVersion, quote.ID, quote.TenantID,
 	)
 	if err != nil {
 		r.logger.Error("Failed to update quote", slog.String("error", err.Error()))
@@ -488,11 +504,6 @@ func (r *QuoteRepository) Update(ctx context.Context, quote *domain.Quote) error
 		}
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	r.logger.Info("Quote updated successfully", slog.String("quote_id", quote.ID))
 	return nil
 }
 
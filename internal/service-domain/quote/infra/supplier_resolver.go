@@ -0,0 +1,41 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SupplierResolver implements domain.SupplierResolver over the suppliers table
+type SupplierResolver struct {
+	db *PostgresDB
+}
+
+// NewSupplierResolver creates a new supplier resolver
+func NewSupplierResolver(db *PostgresDB) *SupplierResolver {
+	return &SupplierResolver{db: db}
+}
+
+// SupplierForOrganization returns the tenant's supplier linked to the
+// organization, or "" when there is none
+func (r *SupplierResolver) SupplierForOrganization(ctx context.Context, tenantID, organizationID string) (string, error) {
+	query := `
+		SELECT id::text
+		FROM suppliers
+		WHERE organization_id = $1 AND tenant_id = $2
+		LIMIT 1
+	`
+
+	var id string
+	err := r.db.pool.QueryRow(ctx, query, organizationID, tenantID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to resolve supplier: %w", err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,277 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/quote/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// openStatuses are the quote statuses the expiry sweep looks at
+var openStatuses = []string{
+	string(domain.QuoteStatusDraft),
+	string(domain.QuoteStatusSubmitted),
+	string(domain.QuoteStatusUnderReview),
+	string(domain.QuoteStatusRevised),
+}
+
+// ListOpenExpired retrieves open quotes of all tenants whose validity ended before now
+func (r *QuoteRepository) ListOpenExpired(ctx context.Context, now time.Time) ([]*domain.Quote, error) {
+	query := `
+		SELECT id, tenant_id FROM quotes
+		WHERE status = ANY($1) AND valid_until < $2
+		ORDER BY valid_until ASC
+	`
+	return r.listByKeys(ctx, query, openStatuses, now)
+}
+
+// ListExpiringUnreminded retrieves open quotes of all tenants expiring between
+// now and cutoff that have not been reminded about their current validity
+func (r *QuoteRepository) ListExpiringUnreminded(ctx context.Context, now, cutoff time.Time) ([]*domain.Quote, error) {
+	query := `
+		SELECT q.id, q.tenant_id FROM quotes q
+		WHERE q.status = ANY($1) AND q.valid_until >= $2 AND q.valid_until <= $3
+		  AND NOT EXISTS (
+			SELECT 1 FROM quote_notifications n
+			WHERE n.quote_id = q.id AND n.kind = 'expiring' AND n.valid_until = q.valid_until
+		  )
+		ORDER BY q.valid_until ASC
+	`
+	return r.listByKeys(ctx, query, openStatuses, now, cutoff)
+}
+
+// listByKeys loads the quotes whose id and tenant_id the query selects
+func (r *QuoteRepository) listByKeys(ctx context.Context, query string, args ...interface{}) ([]*domain.Quote, error) {
+	rows, err := r.db.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quotes: %w", err)
+	}
+
+	type key struct{ id, tenantID string }
+	keys := []key{}
+	for rows.Next() {
+		var k key
+		if err := rows.Scan(&k.id, &k.tenantID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan quote: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list quotes: %w", err)
+	}
+
+	quotes := make([]*domain.Quote, 0, len(keys))
+	for _, k := range keys {
+		quote, err := r.GetByID(ctx, k.id, k.tenantID)
+		if err != nil {
+			return nil, err
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes, nil
+}
+
+const extensionColumns = `
+	id, tenant_id, quote_id, previous_valid_until, requested_valid_until, COALESCE(reason, ''),
+	status, requested_by, requested_at, decided_by, decided_at, COALESCE(decision_notes, '')`
+
+// CreateExtension stores a new extension request
+func (r *QuoteRepository) CreateExtension(ctx context.Context, e *domain.ValidityExtension) error {
+	query := `
+		INSERT INTO quote_validity_extensions (
+			id, tenant_id, quote_id, previous_valid_until, requested_valid_until, reason,
+			status, requested_by, requested_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.pool.Exec(ctx, query,
+		e.ID, e.TenantID, e.QuoteID, e.PreviousValidUntil, e.RequestedValidUntil, e.Reason,
+		string(e.Status), e.RequestedBy, e.RequestedAt)
+	if err != nil {
+		// The partial unique index allows one pending request per quote
+		if isUniqueViolation(err) {
+			return domain.ErrExtensionPending
+		}
+		r.logger.Error("Failed to create validity extension",
+			slog.String("error", err.Error()),
+			slog.String("quote_id", e.QuoteID))
+		return fmt.Errorf("failed to create validity extension: %w", err)
+	}
+	return nil
+}
+
+// GetExtension retrieves an extension request of a quote
+func (r *QuoteRepository) GetExtension(ctx context.Context, tenantID, quoteID, id string) (*domain.ValidityExtension, error) {
+	query := `SELECT ` + extensionColumns + ` FROM quote_validity_extensions
+		WHERE id = $1 AND quote_id = $2 AND tenant_id = $3`
+	e, err := scanExtension(r.db.pool.QueryRow(ctx, query, id, quoteID, tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrExtensionNotFound
+		}
+		return nil, fmt.Errorf("failed to get validity extension: %w", err)
+	}
+	return e, nil
+}
+
+// ListExtensions retrieves a quote's extension requests, newest first
+func (r *QuoteRepository) ListExtensions(ctx context.Context, tenantID, quoteID string) ([]*domain.ValidityExtension, error) {
+	query := `SELECT ` + extensionColumns + ` FROM quote_validity_extensions
+		WHERE quote_id = $1 AND tenant_id = $2
+		ORDER BY requested_at DESC`
+	rows, err := r.db.pool.Query(ctx, query, quoteID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validity extensions: %w", err)
+	}
+	defer rows.Close()
+
+	extensions := []*domain.ValidityExtension{}
+	for rows.Next() {
+		e, err := scanExtension(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan validity extension: %w", err)
+		}
+		extensions = append(extensions, e)
+	}
+	return extensions, rows.Err()
+}
+
+// DecideExtension atomically stores a decided extension and, when approved, the extended quote
+func (r *QuoteRepository) DecideExtension(ctx context.Context, e *domain.ValidityExtension, quote *domain.Quote) error {
+	tx, err := r.db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Only a still-pending request can be decided, so concurrent decisions cannot both apply
+	result, err := tx.Exec(ctx, `
+		UPDATE quote_validity_extensions
+		SET status = $1, decided_by = $2, decided_at = $3, decision_notes = $4
+		WHERE id = $5 AND tenant_id = $6 AND status = 'pending'
+	`, string(e.Status), e.DecidedBy, e.DecidedAt, e.DecisionNotes, e.ID, e.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to decide validity extension: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrExtensionDecided
+	}
+
+	if e.Status == domain.ExtensionStatusApproved {
+		if err := r.update(ctx, tx, quote); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// AddNotifications stores notifications, skipping duplicates of the same reminder
+func (r *QuoteRepository) AddNotifications(ctx context.Context, notifications []domain.QuoteNotification) error {
+	query := `
+		INSERT INTO quote_notifications (
+			id, tenant_id, quote_id, rfq_id, supplier_id, recipient, kind,
+			subject, message, valid_until, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT DO NOTHING
+	`
+	for _, n := range notifications {
+		_, err := r.db.pool.Exec(ctx, query,
+			n.ID, n.TenantID, n.QuoteID, n.RFQID, n.SupplierID, n.Recipient, n.Kind,
+			n.Subject, n.Message, n.ValidUntil, n.CreatedAt)
+		if err != nil {
+			r.logger.Error("Failed to add quote notification",
+				slog.String("error", err.Error()),
+				slog.String("quote_id", n.QuoteID))
+			return fmt.Errorf("failed to add quote notification: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListNotifications retrieves notifications, newest first
+func (r *QuoteRepository) ListNotifications(ctx context.Context, tenantID string, filter domain.NotificationFilter) ([]domain.QuoteNotification, error) {
+	query := `
+		SELECT id, tenant_id, quote_id, rfq_id, supplier_id, recipient, kind,
+			subject, COALESCE(message, ''), valid_until, created_at, read_at
+		FROM quote_notifications
+		WHERE tenant_id = $1
+	`
+	args := []interface{}{tenantID}
+	if filter.Recipient != "" {
+		args = append(args, filter.Recipient)
+		query += fmt.Sprintf(" AND recipient = $%d", len(args))
+	}
+	if filter.SupplierID != "" {
+		args = append(args, filter.SupplierID)
+		query += fmt.Sprintf(" AND supplier_id = $%d", len(args))
+	}
+	if filter.UnreadOnly {
+		query += " AND read_at IS NULL"
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := r.db.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quote notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []domain.QuoteNotification{}
+	for rows.Next() {
+		var n domain.QuoteNotification
+		err := rows.Scan(
+			&n.ID, &n.TenantID, &n.QuoteID, &n.RFQID, &n.SupplierID, &n.Recipient, &n.Kind,
+			&n.Subject, &n.Message, &n.ValidUntil, &n.CreatedAt, &n.ReadAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan quote notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+// MarkNotificationRead marks a notification as read
+func (r *QuoteRepository) MarkNotificationRead(ctx context.Context, tenantID, id string) error {
+	result, err := r.db.pool.Exec(ctx, `
+		UPDATE quote_notifications SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+		WHERE id = $1 AND tenant_id = $2
+	`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to mark quote notification read: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func scanExtension(row pgx.Row) (*domain.ValidityExtension, error) {
+	e := &domain.ValidityExtension{}
+	var status string
+	err := row.Scan(
+		&e.ID, &e.TenantID, &e.QuoteID, &e.PreviousValidUntil, &e.RequestedValidUntil, &e.Reason,
+		&status, &e.RequestedBy, &e.RequestedAt, &e.DecidedBy, &e.DecidedAt, &e.DecisionNotes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.Status = domain.ExtensionStatus(status)
+	return e, nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -22,6 +22,7 @@ type Module struct {
 	logger  *slog.Logger
 	db      *infra.PostgresDB
 	handler *api.QuoteHandler
+	sweeper *app.ExpirySweeper
 }
 
 // NewModule creates a new quote module instance
@@ -45,8 +46,11 @@ func (m *Module) Initialize(ctx context.Context) error {
 
 	// Initialize layers
 	repo := infra.NewQuoteRepository(db, m.logger)
-	service := app.NewQuoteService(repo, m.logger)
+	bids := infra.NewBidWindowRepository(db)
+	suppliers := infra.NewSupplierResolver(db)
+	service := app.NewQuoteService(repo, bids, repo, suppliers, m.logger)
 	m.handler = api.NewQuoteHandler(service, m.logger)
+	m.sweeper = app.NewExpirySweeper(service, m.logger)
 
 	m.logger.Info("Quote module initialized successfully")
 	return nil
@@ -60,6 +64,8 @@ func (m *Module) MountRoutes(r chi.Router) {
 		// Core quote operations
 		r.Post("/", m.handler.CreateQuote)
 		r.Get("/", m.handler.ListQuotes)
+		r.Get("/notifications", m.handler.ListNotifications)
+		r.Post("/notifications/{notification_id}/read", m.handler.MarkNotificationRead)
 		r.Get("/{id}", m.handler.GetQuote)
 		r.Patch("/{id}", m.handler.UpdateQuote)
 		r.Delete("/{id}", m.handler.DeleteQuote)
@@ -76,6 +82,12 @@ func (m *Module) MountRoutes(r chi.Router) {
 		r.Post("/{id}/reject", m.handler.RejectQuote)
 		r.Post("/{id}/withdraw", m.handler.WithdrawQuote)
 		r.Post("/{id}/under-review", m.handler.MarkUnderReview)
+
+		// Validity extensions
+		r.Get("/{id}/validity-extensions", m.handler.ListValidityExtensions)
+		r.Post("/{id}/validity-extensions", m.handler.RequestValidityExtension)
+		r.Post("/{id}/validity-extensions/{extension_id}/approve", m.handler.ApproveValidityExtension)
+		r.Post("/{id}/validity-extensions/{extension_id}/reject", m.handler.RejectValidityExtension)
 	})
 
 	// Additional routes for querying quotes by RFQ or Supplier
@@ -88,7 +100,11 @@ func (m *Module) MountRoutes(r chi.Router) {
 // Start begins any background processes
 func (m *Module) Start(ctx context.Context) error {
 	m.logger.Info("Starting quote module")
-	// No background processes for now
+
+	// Expire quotes past their validity and remind parties ahead of expiry
+	if m.sweeper != nil {
+		go m.sweeper.Run(ctx)
+	}
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/aby-med/medical-platform/internal/pkg/money"
 	"github.com/aby-med/medical-platform/internal/service-domain/rfq/app"
 	"github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
 	"github.com/go-chi/chi/v5"
@@ -44,6 +45,10 @@ func (h *RFQHandler) CreateRFQ(w http.ResponseWriter, r *http.Request) {
 
 	rfq, err := h.service.CreateRFQ(ctx, req)
 	if err != nil {
+		if errors.Is(err, money.ErrUnknownCurrency) {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		h.logger.Error("Failed to create RFQ", slog.String("error", err.Error()))
 		h.respondError(w, http.StatusInternalServerError, "Failed to create RFQ: "+err.Error())
 		return
@@ -105,6 +110,10 @@ func (h *RFQHandler) UpdateRFQ(w http.ResponseWriter, r *http.Request) {
 			h.respondError(w, http.StatusNotFound, "RFQ not found")
 			return
 		}
+		if errors.Is(err, domain.ErrUseAddendum) {
+			h.respondError(w, http.StatusConflict, err.Error())
+			return
+		}
 		h.logger.Error("Failed to update RFQ", slog.String("error", err.Error()))
 		h.respondError(w, http.StatusBadRequest, err.Error())
 		return
@@ -334,6 +343,178 @@ func (h *RFQHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// InviteSupplier handles POST /api/v1/rfq/{id}/invitations
+func (h *RFQHandler) InviteSupplier(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rfqID := chi.URLParam(r, "id")
+
+	var req app.InviteSupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	invitation, err := h.service.InviteSupplier(ctx, rfqID, req)
+	if err != nil {
+		if errors.Is(err, domain.ErrRFQNotFound) {
+			h.respondError(w, http.StatusNotFound, "RFQ not found")
+			return
+		}
+		if errors.Is(err, domain.ErrSupplierNotEligible) {
+			h.respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		h.logger.Error("Failed to invite supplier", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, app.APIResponse{
+		Success: true,
+		Message: "Supplier invited successfully",
+		Data:    invitation,
+	})
+}
+
+// ListQuestions handles GET /api/v1/rfq/{id}/questions
+func (h *RFQHandler) ListQuestions(w http.ResponseWriter, r *http.Request) {
+	questions, err := h.service.ListQuestions(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrRFQNotFound) {
+			h.respondError(w, http.StatusNotFound, "RFQ not found")
+			return
+		}
+		h.logger.Error("Failed to list questions", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list questions")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, app.APIResponse{
+		Success: true,
+		Data:    questions,
+	})
+}
+
+// AnswerQuestion handles POST /api/v1/rfq/{id}/questions/{question_id}/answer
+func (h *RFQHandler) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	var req app.AnswerQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	question, err := h.service.AnswerQuestion(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "question_id"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRFQNotFound), errors.Is(err, domain.ErrQuestionNotFound):
+			h.respondError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, domain.ErrQuestionAlreadyAnswered):
+			h.respondError(w, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error("Failed to answer question", slog.String("error", err.Error()))
+			h.respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, app.APIResponse{
+		Success: true,
+		Message: "Question answered successfully",
+		Data:    question,
+	})
+}
+
+// IssueAddendum handles POST /api/v1/rfq/{id}/addenda
+func (h *RFQHandler) IssueAddendum(w http.ResponseWriter, r *http.Request) {
+	var req app.IssueAddendumRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	addendum, err := h.service.IssueAddendum(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRFQNotFound):
+			h.respondError(w, http.StatusNotFound, "RFQ not found")
+		case errors.Is(err, domain.ErrAddendumNotAllowed), errors.Is(err, domain.ErrDeadlinePassed):
+			h.respondError(w, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error("Failed to issue addendum", slog.String("error", err.Error()))
+			h.respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, app.APIResponse{
+		Success: true,
+		Message: "Addendum issued successfully",
+		Data:    addendum,
+	})
+}
+
+// ListAddenda handles GET /api/v1/rfq/{id}/addenda
+func (h *RFQHandler) ListAddenda(w http.ResponseWriter, r *http.Request) {
+	addenda, err := h.service.ListAddenda(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrRFQNotFound) {
+			h.respondError(w, http.StatusNotFound, "RFQ not found")
+			return
+		}
+		h.logger.Error("Failed to list addenda", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list addenda")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, app.APIResponse{
+		Success: true,
+		Data:    addenda,
+	})
+}
+
+// OpenBids handles POST /api/v1/rfq/{id}/open-bids
+func (h *RFQHandler) OpenBids(w http.ResponseWriter, r *http.Request) {
+	opening, err := h.service.OpenBids(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRFQNotFound):
+			h.respondError(w, http.StatusNotFound, "RFQ not found")
+		case errors.Is(err, domain.ErrBidsAlreadyOpened), errors.Is(err, domain.ErrDeadlineNotReached),
+			errors.Is(err, domain.ErrInvalidTransition):
+			h.respondError(w, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error("Failed to open bids", slog.String("error", err.Error()))
+			h.respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, app.APIResponse{
+		Success: true,
+		Message: "Bids opened successfully",
+		Data:    opening,
+	})
+}
+
+// GetBidOpening handles GET /api/v1/rfq/{id}/bid-opening
+func (h *RFQHandler) GetBidOpening(w http.ResponseWriter, r *http.Request) {
+	opening, err := h.service.GetBidOpening(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrRFQNotFound) || errors.Is(err, domain.ErrBidOpeningNotFound) {
+			h.respondError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.logger.Error("Failed to get bid opening", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to get bid opening")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, app.APIResponse{
+		Success: true,
+		Data:    opening,
+	})
+}
+
 // Helper methods
 
 func (h *RFQHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
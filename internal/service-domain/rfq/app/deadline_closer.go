@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/shared/config"
+)
+
+// DeadlineCloser periodically closes published RFQs whose response deadline
+// has passed, so no quotes are accepted late and sealed bids can be opened
+type DeadlineCloser struct {
+	service  *RFQService
+	interval time.Duration
+	now      func() time.Time
+	logger   *slog.Logger
+}
+
+// NewDeadlineCloser creates a new RFQ deadline closer
+func NewDeadlineCloser(service *RFQService, logger *slog.Logger) *DeadlineCloser {
+	return &DeadlineCloser{
+		service:  service,
+		interval: time.Minute,
+		now:      time.Now,
+		logger:   logger.With(slog.String("component", "rfq_deadline_closer")),
+	}
+}
+
+// Run closes expired RFQs until the context is cancelled.
+// Disabled with ENABLE_RFQ_DEADLINE_CLOSER=false.
+func (c *DeadlineCloser) Run(ctx context.Context) {
+	if !config.Enabled("ENABLE_RFQ_DEADLINE_CLOSER") {
+		c.logger.Info("RFQ deadline closer disabled; skipping run")
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce closes all RFQs past their deadline once
+func (c *DeadlineCloser) RunOnce(ctx context.Context) {
+	closed, err := c.service.CloseExpiredRFQs(ctx, c.now())
+	if err != nil {
+		c.logger.Error("Failed to close expired RFQs", slog.String("error", err.Error()))
+		return
+	}
+	if closed > 0 {
+		c.logger.Info("Closed RFQs past their response deadline", slog.Int("count", closed))
+	}
+}
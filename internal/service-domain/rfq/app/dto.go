@@ -15,6 +15,9 @@ type CreateRFQRequest struct {
 	DeliveryTerms    domain.DeliveryTerms  `json:"delivery_terms"`
 	PaymentTerms     domain.PaymentTerms   `json:"payment_terms"`
 	InternalNotes    string                `json:"internal_notes"`
+	SealedBid        bool                  `json:"sealed_bid"`
+	Currency         string                `json:"currency"`  // Base currency for comparison; defaults to USD
+	RateDate         *time.Time            `json:"rate_date"` // Exchange rate date; defaults to the response deadline
 	Items            []AddItemRequest      `json:"items"`
 }
 
@@ -27,6 +30,9 @@ type UpdateRFQRequest struct {
 	DeliveryTerms    domain.DeliveryTerms `json:"delivery_terms"`
 	PaymentTerms     domain.PaymentTerms  `json:"payment_terms"`
 	InternalNotes    string               `json:"internal_notes"`
+	SealedBid        bool                 `json:"sealed_bid"`
+	Currency         string               `json:"currency"`
+	RateDate         *time.Time           `json:"rate_date"`
 }
 
 // AddItemRequest represents the request to add an item to an RFQ
@@ -63,6 +69,12 @@ type RFQDTO struct {
 	Description      string                 `json:"description"`
 	Priority         string                 `json:"priority"`
 	Status           string                 `json:"status"`
+	Version          int                    `json:"version"`
+	SealedBid        bool                   `json:"sealed_bid"`
+	BidsOpenedAt     *time.Time             `json:"bids_opened_at,omitempty"`
+	BidsOpenedBy     string                 `json:"bids_opened_by,omitempty"`
+	Currency         string                 `json:"currency"`
+	RateDate         *time.Time             `json:"rate_date,omitempty"`
 	DeliveryTerms    map[string]interface{} `json:"delivery_terms"`
 	PaymentTerms     map[string]interface{} `json:"payment_terms"`
 	PublishedAt      *time.Time             `json:"published_at,omitempty"`
@@ -103,6 +115,56 @@ type RFQInvitationDTO struct {
 	ViewedAt    *time.Time `json:"viewed_at,omitempty"`
 	RespondedAt *time.Time `json:"responded_at,omitempty"`
 	Message     string     `json:"message,omitempty"`
+	DeclineReason string   `json:"decline_reason,omitempty"`
+}
+
+// InviteSupplierRequest represents a request to invite a supplier to quote
+type InviteSupplierRequest struct {
+	SupplierID string `json:"supplier_id"`
+	Message    string `json:"message,omitempty"`
+}
+
+// AnswerQuestionRequest represents the buyer's answer to a clarification question
+type AnswerQuestionRequest struct {
+	Answer string `json:"answer"`
+	// Publish shares the answer with all bidders; defaults to true
+	Publish *bool `json:"publish,omitempty"`
+}
+
+// RFQQuestionDTO represents a clarification question in the API response
+type RFQQuestionDTO struct {
+	ID         string     `json:"id"`
+	RFQID      string     `json:"rfq_id"`
+	SupplierID string     `json:"supplier_id"`
+	Question   string     `json:"question"`
+	AskedBy    string     `json:"asked_by"`
+	AskedAt    time.Time  `json:"asked_at"`
+	Answer     string     `json:"answer,omitempty"`
+	AnsweredBy string     `json:"answered_by,omitempty"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+	Public     bool       `json:"public"`
+}
+
+// IssueAddendumRequest represents a change to a published RFQ
+type IssueAddendumRequest struct {
+	Summary          string                      `json:"summary"`
+	ResponseDeadline *time.Time                  `json:"response_deadline,omitempty"`
+	ItemChanges      []domain.AddendumItemChange `json:"item_changes,omitempty"`
+	NewItems         []AddItemRequest            `json:"new_items,omitempty"`
+}
+
+// RFQAddendumDTO represents an RFQ addendum in the API response
+type RFQAddendumDTO struct {
+	ID               string                      `json:"id"`
+	RFQID            string                      `json:"rfq_id"`
+	Version          int                         `json:"version"`
+	Summary          string                      `json:"summary"`
+	PreviousDeadline time.Time                   `json:"previous_deadline"`
+	NewDeadline      *time.Time                  `json:"new_deadline,omitempty"`
+	ItemChanges      []domain.AddendumItemChange `json:"item_changes"`
+	NewItems         []RFQItemDTO                `json:"new_items"`
+	IssuedBy         string                      `json:"issued_by"`
+	IssuedAt         time.Time                   `json:"issued_at"`
 }
 
 // PaginatedResponse represents a paginated list response
@@ -15,18 +15,21 @@ import (
 type RFQService struct {
 	repository domain.RFQRepository
 	eventBus   domain.EventPublisher
+	suppliers  domain.SupplierEligibility
 	logger     *slog.Logger
 }
 
-// NewRFQService creates a new RFQ service
+// NewRFQService creates a new RFQ service. Supplier eligibility is optional.
 func NewRFQService(
 	repository domain.RFQRepository,
 	eventBus domain.EventPublisher,
+	suppliers domain.SupplierEligibility,
 	logger *slog.Logger,
 ) *RFQService {
 	return &RFQService{
 		repository: repository,
 		eventBus:   eventBus,
+		suppliers:  suppliers,
 		logger:     logger.With(slog.String("component", "rfq_service")),
 	}
 }
@@ -92,6 +95,10 @@ func (s *RFQService) CreateRFQ(ctx context.Context, req CreateRFQRequest) (*RFQD
 
 	// Set internal notes
 	rfq.InternalNotes = req.InternalNotes
+	rfq.SealedBid = req.SealedBid
+	if err := rfq.SetCurrency(req.Currency, req.RateDate); err != nil {
+		return nil, err
+	}
 
 	// Persist to database
 	if err := s.repository.Create(ctx, rfq); err != nil {
@@ -165,7 +172,10 @@ func (s *RFQService) UpdateRFQ(ctx context.Context, id string, req UpdateRFQRequ
 		return nil, err
 	}
 
-	// Check if RFQ can be edited
+	// Published RFQs must be amended through an addendum so bidders are notified
+	if rfq.Status == domain.RFQStatusPublished {
+		return nil, domain.ErrUseAddendum
+	}
 	if !rfq.CanBeEdited() {
 		return nil, errors.New("RFQ cannot be edited in current status")
 	}
@@ -178,6 +188,10 @@ func (s *RFQService) UpdateRFQ(ctx context.Context, id string, req UpdateRFQRequ
 	rfq.DeliveryTerms = req.DeliveryTerms
 	rfq.PaymentTerms = req.PaymentTerms
 	rfq.InternalNotes = req.InternalNotes
+	rfq.SealedBid = req.SealedBid
+	if err := rfq.SetCurrency(req.Currency, req.RateDate); err != nil {
+		return nil, err
+	}
 	rfq.UpdatedAt = time.Now()
 
 	// Persist changes
@@ -485,8 +499,379 @@ func (s *RFQService) RemoveItem(ctx context.Context, rfqID, itemID string) error
 	return nil
 }
 
+// InviteSupplier invites a supplier to quote on a published RFQ
+func (s *RFQService) InviteSupplier(ctx context.Context, rfqID string, req InviteSupplierRequest) (*RFQInvitationDTO, error) {
+	tenantID := domain.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if req.SupplierID == "" {
+		return nil, errors.New("supplier ID is required")
+	}
+
+	rfq, err := s.repository.GetByID(ctx, rfqID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restricted, suspended or unverified suppliers cannot be invited
+	if s.suppliers != nil {
+		if err := s.suppliers.CheckInvitable(ctx, tenantID, req.SupplierID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Add to domain entity (validates status and duplicates)
+	if err := rfq.InviteSupplier(domain.RFQInvitation{
+		ID:         ksuid.New().String(),
+		SupplierID: req.SupplierID,
+		Message:    req.Message,
+	}); err != nil {
+		return nil, err
+	}
+	invitation := &rfq.Invitations[len(rfq.Invitations)-1]
+
+	if err := s.repository.AddInvitation(ctx, invitation); err != nil {
+		s.logger.Error("Failed to add RFQ invitation",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", rfqID))
+		return nil, fmt.Errorf("failed to invite supplier: %w", err)
+	}
+
+	event := domain.NewSupplierInvitedEvent(rfq, invitation)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish supplier invited event",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", rfqID))
+	}
+
+	s.logger.Info("Supplier invited to RFQ",
+		slog.String("rfq_id", rfqID),
+		slog.String("supplier_id", req.SupplierID))
+
+	return mapInvitationToDTO(invitation), nil
+}
+
+// ListQuestions returns the clarification Q&A thread of an RFQ
+func (s *RFQService) ListQuestions(ctx context.Context, rfqID string) ([]RFQQuestionDTO, error) {
+	tenantID := domain.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify the RFQ belongs to the tenant
+	if _, err := s.repository.GetByID(ctx, rfqID, tenantID); err != nil {
+		return nil, err
+	}
+
+	questions, err := s.repository.GetQuestions(ctx, rfqID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]RFQQuestionDTO, 0, len(questions))
+	for i := range questions {
+		dtos = append(dtos, *MapQuestionToDTO(&questions[i]))
+	}
+	return dtos, nil
+}
+
+// AnswerQuestion records the buyer's answer to a clarification question
+func (s *RFQService) AnswerQuestion(ctx context.Context, rfqID, questionID string, req AnswerQuestionRequest) (*RFQQuestionDTO, error) {
+	tenantID := domain.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	userID := domain.GetUserID(ctx)
+	if userID == "" {
+		userID = "system"
+	}
+
+	rfq, err := s.repository.GetByID(ctx, rfqID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := s.repository.GetQuestion(ctx, rfqID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	public := req.Publish == nil || *req.Publish
+	if err := question.AnswerWith(req.Answer, userID, public); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.UpdateQuestion(ctx, question); err != nil {
+		s.logger.Error("Failed to answer RFQ question",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", rfqID),
+			slog.String("question_id", questionID))
+		return nil, fmt.Errorf("failed to answer question: %w", err)
+	}
+
+	// Public answers are broadcast to every supplier still bidding
+	if question.Public {
+		s.notifyBidders(ctx, rfq.BroadcastToBidders(domain.NotificationKindAnswer, question.ID,
+			fmt.Sprintf("%s: clarification answered", rfq.RFQNumber), question.Question))
+
+		event := domain.NewQuestionAnsweredEvent(rfq, question)
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			s.logger.Error("Failed to publish question answered event",
+				slog.String("error", err.Error()),
+				slog.String("rfq_id", rfqID))
+		}
+	}
+
+	return MapQuestionToDTO(question), nil
+}
+
+// IssueAddendum amends a published RFQ, bumps its version and notifies all bidders
+func (s *RFQService) IssueAddendum(ctx context.Context, rfqID string, req IssueAddendumRequest) (*RFQAddendumDTO, error) {
+	tenantID := domain.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	userID := domain.GetUserID(ctx)
+	if userID == "" {
+		userID = "system"
+	}
+
+	rfq, err := s.repository.GetByID(ctx, rfqID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	newItems := make([]domain.RFQItem, 0, len(req.NewItems))
+	for _, item := range req.NewItems {
+		newItems = append(newItems, domain.RFQItem{
+			EquipmentID:    item.EquipmentID,
+			CategoryID:     item.CategoryID,
+			Name:           item.Name,
+			Description:    item.Description,
+			Specifications: item.Specifications,
+			Quantity:       item.Quantity,
+			Unit:           item.Unit,
+			EstimatedPrice: item.EstimatedPrice,
+			Notes:          item.Notes,
+		})
+	}
+
+	addendum, err := rfq.IssueAddendum(req.Summary, req.ResponseDeadline, req.ItemChanges, newItems, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.SaveAddendum(ctx, rfq, addendum); err != nil {
+		s.logger.Error("Failed to issue RFQ addendum",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", rfqID))
+		return nil, fmt.Errorf("failed to issue addendum: %w", err)
+	}
+
+	s.notifyBidders(ctx, rfq.BroadcastToBidders(domain.NotificationKindAddendum, addendum.ID,
+		fmt.Sprintf("%s: addendum %d issued", rfq.RFQNumber, addendum.Version), addendum.Summary))
+
+	event := domain.NewAddendumIssuedEvent(rfq, addendum)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish addendum issued event",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", rfqID))
+	}
+
+	s.logger.Info("RFQ addendum issued",
+		slog.String("rfq_id", rfqID),
+		slog.Int("version", addendum.Version))
+
+	return s.mapAddendumToDTO(addendum), nil
+}
+
+// ListAddenda returns the addenda issued for an RFQ, oldest first
+func (s *RFQService) ListAddenda(ctx context.Context, rfqID string) ([]RFQAddendumDTO, error) {
+	tenantID := domain.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify the RFQ belongs to the tenant
+	if _, err := s.repository.GetByID(ctx, rfqID, tenantID); err != nil {
+		return nil, err
+	}
+
+	addenda, err := s.repository.GetAddenda(ctx, rfqID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]RFQAddendumDTO, 0, len(addenda))
+	for i := range addenda {
+		dtos = append(dtos, *s.mapAddendumToDTO(&addenda[i]))
+	}
+	return dtos, nil
+}
+
+// OpenBids unseals the quotes of a sealed-bid RFQ after its response deadline
+// and records who opened them
+func (s *RFQService) OpenBids(ctx context.Context, id string) (*domain.BidOpening, error) {
+	tenantID := domain.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// The audit record must name a real user
+	userID := domain.GetUserID(ctx)
+	if userID == "" {
+		return nil, errors.New("user ID is required to open bids")
+	}
+
+	rfq, err := s.repository.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	opening, err := rfq.OpenBids(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.RecordBidOpening(ctx, rfq, opening); err != nil {
+		s.logger.Error("Failed to open bids",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", id))
+		return nil, err
+	}
+
+	event := domain.NewBidsOpenedEvent(rfq, opening)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish bids opened event",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", id))
+	}
+
+	s.logger.Info("Sealed bids opened",
+		slog.String("rfq_id", id),
+		slog.String("opened_by", userID),
+		slog.Int("quote_count", opening.QuoteCount))
+
+	return opening, nil
+}
+
+// GetBidOpening returns the bid opening audit record of an RFQ
+func (s *RFQService) GetBidOpening(ctx context.Context, id string) (*domain.BidOpening, error) {
+	tenantID := domain.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	// Verify the RFQ belongs to the tenant
+	if _, err := s.repository.GetByID(ctx, id, tenantID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetBidOpening(ctx, id)
+}
+
+// CloseExpiredRFQs closes every published RFQ whose response deadline has
+// passed and returns how many were closed
+func (s *RFQService) CloseExpiredRFQs(ctx context.Context, now time.Time) (int, error) {
+	rfqs, err := s.repository.ListPastDeadline(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, rfq := range rfqs {
+		if !rfq.CloseAtDeadline(now) {
+			continue
+		}
+		if err := s.repository.Update(ctx, rfq); err != nil {
+			s.logger.Error("Failed to close RFQ at deadline",
+				slog.String("error", err.Error()),
+				slog.String("rfq_id", rfq.ID))
+			continue
+		}
+		closed++
+
+		event := domain.NewRFQClosedEvent(rfq, "system")
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			s.logger.Error("Failed to publish RFQ closed event",
+				slog.String("error", err.Error()),
+				slog.String("rfq_id", rfq.ID))
+		}
+	}
+
+	return closed, nil
+}
+
+// notifyBidders stores bidder notifications; failures are logged so the
+// change itself is not rolled back
+func (s *RFQService) notifyBidders(ctx context.Context, notifications []domain.BidderNotification) {
+	if len(notifications) == 0 {
+		return
+	}
+	if err := s.repository.AddNotifications(ctx, notifications); err != nil {
+		s.logger.Error("Failed to notify bidders",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", notifications[0].RFQID))
+	}
+}
+
 // Helper methods
 
+func mapInvitationToDTO(inv *domain.RFQInvitation) *RFQInvitationDTO {
+	return &RFQInvitationDTO{
+		ID:            inv.ID,
+		RFQID:         inv.RFQID,
+		SupplierID:    inv.SupplierID,
+		Status:        inv.Status,
+		InvitedAt:     inv.InvitedAt,
+		ViewedAt:      inv.ViewedAt,
+		RespondedAt:   inv.RespondedAt,
+		Message:       inv.Message,
+		DeclineReason: inv.DeclineReason,
+	}
+}
+
+// MapQuestionToDTO converts a clarification question to its API representation
+func MapQuestionToDTO(q *domain.RFQQuestion) *RFQQuestionDTO {
+	return &RFQQuestionDTO{
+		ID:         q.ID,
+		RFQID:      q.RFQID,
+		SupplierID: q.SupplierID,
+		Question:   q.Question,
+		AskedBy:    q.AskedBy,
+		AskedAt:    q.AskedAt,
+		Answer:     q.Answer,
+		AnsweredBy: q.AnsweredBy,
+		AnsweredAt: q.AnsweredAt,
+		Public:     q.Public,
+	}
+}
+
+func (s *RFQService) mapAddendumToDTO(a *domain.RFQAddendum) *RFQAddendumDTO {
+	dto := &RFQAddendumDTO{
+		ID:               a.ID,
+		RFQID:            a.RFQID,
+		Version:          a.Version,
+		Summary:          a.Summary,
+		PreviousDeadline: a.PreviousDeadline,
+		NewDeadline:      a.NewDeadline,
+		ItemChanges:      a.ItemChanges,
+		NewItems:         []RFQItemDTO{},
+		IssuedBy:         a.IssuedBy,
+		IssuedAt:         a.IssuedAt,
+	}
+	if dto.ItemChanges == nil {
+		dto.ItemChanges = []domain.AddendumItemChange{}
+	}
+	for i := range a.NewItems {
+		dto.NewItems = append(dto.NewItems, *s.mapItemToDTO(&a.NewItems[i]))
+	}
+	return dto
+}
+
 func (s *RFQService) mapToDTO(rfq *domain.RFQ) *RFQDTO {
 	dto := &RFQDTO{
 		ID:               rfq.ID,
@@ -496,6 +881,12 @@ func (s *RFQService) mapToDTO(rfq *domain.RFQ) *RFQDTO {
 		Description:      rfq.Description,
 		Priority:         string(rfq.Priority),
 		Status:           string(rfq.Status),
+		Version:          rfq.Version,
+		SealedBid:        rfq.SealedBid,
+		BidsOpenedAt:     rfq.BidsOpenedAt,
+		BidsOpenedBy:     rfq.BidsOpenedBy,
+		Currency:         rfq.Currency,
+		RateDate:         rfq.RateDate,
 		DeliveryTerms:    s.deliveryTermsToMap(rfq.DeliveryTerms),
 		PaymentTerms:     s.paymentTermsToMap(rfq.PaymentTerms),
 		PublishedAt:      rfq.PublishedAt,
@@ -515,17 +906,8 @@ func (s *RFQService) mapToDTO(rfq *domain.RFQ) *RFQDTO {
 	}
 
 	// Map invitations
-	for _, inv := range rfq.Invitations {
-		dto.Invitations = append(dto.Invitations, RFQInvitationDTO{
-			ID:          inv.ID,
-			RFQID:       inv.RFQID,
-			SupplierID:  inv.SupplierID,
-			Status:      inv.Status,
-			InvitedAt:   inv.InvitedAt,
-			ViewedAt:    inv.ViewedAt,
-			RespondedAt: inv.RespondedAt,
-			Message:     inv.Message,
-		})
+	for i := range rfq.Invitations {
+		dto.Invitations = append(dto.Invitations, *mapInvitationToDTO(&rfq.Invitations[i]))
 	}
 
 	return dto
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrUseAddendum        = errors.New("published rfq can only be changed through an addendum")
+	ErrAddendumNotAllowed = errors.New("addenda can only be issued while the rfq is published")
+	ErrAddendumEmpty      = errors.New("addendum must extend the deadline or change items")
+	ErrAddendumSummary    = errors.New("addendum summary is required")
+	ErrDeadlineShortened  = errors.New("addendum may only extend the response deadline")
+	ErrRFQItemNotFound    = errors.New("rfq item not found")
+)
+
+// AddendumItemChange amends an existing RFQ item. Nil fields are left unchanged.
+type AddendumItemChange struct {
+	ItemID         string                 `json:"item_id"`
+	Quantity       *int                   `json:"quantity,omitempty"`
+	Description    *string                `json:"description,omitempty"`
+	Specifications map[string]interface{} `json:"specifications,omitempty"`
+	Notes          *string                `json:"notes,omitempty"`
+}
+
+// RFQAddendum is a formal change to a published RFQ. Each addendum bumps
+// the RFQ version so quotes can be matched to the terms they responded to.
+type RFQAddendum struct {
+	ID               string               `json:"id"`
+	RFQID            string               `json:"rfq_id"`
+	Version          int                  `json:"version"`
+	Summary          string               `json:"summary"`
+	PreviousDeadline time.Time            `json:"previous_deadline"`
+	NewDeadline      *time.Time           `json:"new_deadline,omitempty"`
+	ItemChanges      []AddendumItemChange `json:"item_changes,omitempty"`
+	NewItems         []RFQItem            `json:"new_items,omitempty"`
+	IssuedBy         string               `json:"issued_by"`
+	IssuedAt         time.Time            `json:"issued_at"`
+}
+
+// IssueAddendum applies changes to a published RFQ and bumps its version.
+// Deadlines can only move later; an RFQ past its deadline can be reopened
+// only by an addendum that extends it into the future.
+func (r *RFQ) IssueAddendum(summary string, newDeadline *time.Time, changes []AddendumItemChange, newItems []RFQItem, issuedBy string) (*RFQAddendum, error) {
+	if r.Status != RFQStatusPublished {
+		return nil, ErrAddendumNotAllowed
+	}
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return nil, ErrAddendumSummary
+	}
+	if newDeadline == nil && len(changes) == 0 && len(newItems) == 0 {
+		return nil, ErrAddendumEmpty
+	}
+
+	now := time.Now()
+	if newDeadline != nil && !newDeadline.After(r.ResponseDeadline) {
+		return nil, ErrDeadlineShortened
+	}
+	if r.IsExpired() && (newDeadline == nil || !newDeadline.After(now)) {
+		return nil, ErrDeadlinePassed
+	}
+
+	// Validate everything before mutating the aggregate
+	for _, change := range changes {
+		if r.findItem(change.ItemID) == nil {
+			return nil, fmt.Errorf("%w: %s", ErrRFQItemNotFound, change.ItemID)
+		}
+		if change.Quantity != nil && *change.Quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+	}
+	for _, item := range newItems {
+		if item.Quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+	}
+
+	addendum := &RFQAddendum{
+		ID:               ksuid.New().String(),
+		RFQID:            r.ID,
+		Version:          r.Version + 1,
+		Summary:          summary,
+		PreviousDeadline: r.ResponseDeadline,
+		NewDeadline:      newDeadline,
+		ItemChanges:      changes,
+		IssuedBy:         issuedBy,
+		IssuedAt:         now,
+	}
+
+	for _, change := range changes {
+		item := r.findItem(change.ItemID)
+		if change.Quantity != nil {
+			item.Quantity = *change.Quantity
+		}
+		if change.Description != nil {
+			item.Description = *change.Description
+		}
+		if change.Specifications != nil {
+			item.Specifications = change.Specifications
+		}
+		if change.Notes != nil {
+			item.Notes = *change.Notes
+		}
+		item.UpdatedAt = now
+	}
+	for _, item := range newItems {
+		if item.ID == "" {
+			item.ID = ksuid.New().String()
+		}
+		item.RFQID = r.ID
+		item.CreatedAt = now
+		item.UpdatedAt = now
+		r.Items = append(r.Items, item)
+		addendum.NewItems = append(addendum.NewItems, item)
+	}
+
+	if newDeadline != nil {
+		r.ResponseDeadline = *newDeadline
+	}
+	r.Version = addendum.Version
+	r.UpdatedAt = now
+
+	return addendum, nil
+}
+
+// ChangedItems returns the RFQ items touched by the addendum, in their amended state
+func (a *RFQAddendum) ChangedItems(rfq *RFQ) []RFQItem {
+	items := make([]RFQItem, 0, len(a.ItemChanges))
+	for _, change := range a.ItemChanges {
+		if item := rfq.findItem(change.ItemID); item != nil {
+			items = append(items, *item)
+		}
+	}
+	return items
+}
+
+// ActiveInvitees returns the suppliers invited to the RFQ that have not declined
+func (r *RFQ) ActiveInvitees() []string {
+	invitees := make([]string, 0, len(r.Invitations))
+	for _, inv := range r.Invitations {
+		if inv.Status != InvitationStatusDeclined {
+			invitees = append(invitees, inv.SupplierID)
+		}
+	}
+	return invitees
+}
+
+func (r *RFQ) findItem(itemID string) *RFQItem {
+	for i := range r.Items {
+		if r.Items[i].ID == itemID {
+			return &r.Items[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrNotSealedBid       = errors.New("rfq does not use sealed bidding")
+	ErrBidsAlreadyOpened  = errors.New("bids have already been opened")
+	ErrDeadlineNotReached = errors.New("bids cannot be opened before the response deadline")
+	ErrBidOpeningNotFound = errors.New("bid opening not found")
+)
+
+// BidOpening is the audit record of unsealing the quotes of a sealed-bid RFQ
+type BidOpening struct {
+	ID         string    `json:"id"`
+	RFQID      string    `json:"rfq_id"`
+	TenantID   string    `json:"tenant_id"`
+	OpenedBy   string    `json:"opened_by"`
+	OpenedAt   time.Time `json:"opened_at"`
+	QuoteCount int       `json:"quote_count"` // Submitted quotes at the time of opening
+}
+
+// BidsSealed reports whether submitted quotes must still be hidden from the buyer
+func (r *RFQ) BidsSealed() bool {
+	return r.SealedBid && r.BidsOpenedAt == nil
+}
+
+// CloseAtDeadline closes a published RFQ whose response deadline has passed.
+// It reports whether the RFQ was closed.
+func (r *RFQ) CloseAtDeadline(now time.Time) bool {
+	if r.Status != RFQStatusPublished || now.Before(r.ResponseDeadline) {
+		return false
+	}
+	r.Status = RFQStatusClosed
+	r.ClosedAt = &now
+	r.UpdatedAt = now
+	return true
+}
+
+// OpenBids unseals the quotes of a sealed-bid RFQ. Bids can only be opened
+// once the response deadline has passed; an RFQ still published at that
+// point is closed as part of the opening.
+func (r *RFQ) OpenBids(openedBy string, now time.Time) (*BidOpening, error) {
+	if !r.SealedBid {
+		return nil, ErrNotSealedBid
+	}
+	if r.BidsOpenedAt != nil {
+		return nil, ErrBidsAlreadyOpened
+	}
+	if r.Status == RFQStatusDraft || r.Status == RFQStatusCancelled {
+		return nil, ErrInvalidTransition
+	}
+	if now.Before(r.ResponseDeadline) {
+		return nil, ErrDeadlineNotReached
+	}
+
+	r.CloseAtDeadline(now)
+	r.BidsOpenedAt = &now
+	r.BidsOpenedBy = openedBy
+	r.UpdatedAt = now
+
+	return &BidOpening{
+		ID:       ksuid.New().String(),
+		RFQID:    r.ID,
+		TenantID: r.TenantID,
+		OpenedBy: openedBy,
+		OpenedAt: now,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func sealedRFQ(status RFQStatus, deadline time.Time) *RFQ {
+	return &RFQ{ID: "rfq", TenantID: "tenant", Status: status, SealedBid: true, ResponseDeadline: deadline}
+}
+
+func TestOpenBidsBeforeDeadline(t *testing.T) {
+	now := time.Now()
+	rfq := sealedRFQ(RFQStatusPublished, now.Add(time.Hour))
+
+	if _, err := rfq.OpenBids("buyer", now); !errors.Is(err, ErrDeadlineNotReached) {
+		t.Fatalf("expected ErrDeadlineNotReached, got %v", err)
+	}
+	if !rfq.BidsSealed() || rfq.Status != RFQStatusPublished {
+		t.Fatalf("rfq changed by a rejected opening: status %s", rfq.Status)
+	}
+}
+
+func TestOpenBidsAfterDeadlineClosesRFQ(t *testing.T) {
+	now := time.Now()
+	rfq := sealedRFQ(RFQStatusPublished, now.Add(-time.Minute))
+
+	opening, err := rfq.OpenBids("buyer", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opening.OpenedBy != "buyer" || !opening.OpenedAt.Equal(now) {
+		t.Fatalf("unexpected opening record: %+v", opening)
+	}
+	if rfq.BidsSealed() || rfq.Status != RFQStatusClosed || rfq.ClosedAt == nil {
+		t.Fatalf("expected opened and closed rfq, got status %s", rfq.Status)
+	}
+
+	if _, err := rfq.OpenBids("buyer", now); !errors.Is(err, ErrBidsAlreadyOpened) {
+		t.Fatalf("expected ErrBidsAlreadyOpened on second opening, got %v", err)
+	}
+}
+
+func TestOpenBidsRejectsDraftAndCancelled(t *testing.T) {
+	now := time.Now()
+	for _, status := range []RFQStatus{RFQStatusDraft, RFQStatusCancelled} {
+		rfq := sealedRFQ(status, now.Add(-time.Hour))
+		if _, err := rfq.OpenBids("buyer", now); !errors.Is(err, ErrInvalidTransition) {
+			t.Fatalf("%s: expected ErrInvalidTransition, got %v", status, err)
+		}
+	}
+
+	open := &RFQ{Status: RFQStatusClosed, ResponseDeadline: now.Add(-time.Hour)}
+	if _, err := open.OpenBids("buyer", now); !errors.Is(err, ErrNotSealedBid) {
+		t.Fatalf("expected ErrNotSealedBid, got %v", err)
+	}
+}
+
+func TestCloseAtDeadline(t *testing.T) {
+	now := time.Now()
+	rfq := sealedRFQ(RFQStatusPublished, now.Add(time.Hour))
+	if rfq.CloseAtDeadline(now) {
+		t.Fatal("closed before the deadline")
+	}
+	if !rfq.CloseAtDeadline(now.Add(2*time.Hour)) || rfq.Status != RFQStatusClosed {
+		t.Fatalf("expected close after the deadline, got status %s", rfq.Status)
+	}
+	if rfq.CloseAtDeadline(now.Add(3 * time.Hour)) {
+		t.Fatal("closed an rfq that was no longer published")
+	}
+}
@@ -15,6 +15,10 @@ const (
 	EventTypeRFQCancelled  EventType = "rfq.cancelled"
 	EventTypeRFQAwarded    EventType = "rfq.awarded"
 	EventTypeSupplierInvited EventType = "rfq.supplier_invited"
+	EventTypeInvitationDeclined EventType = "rfq.invitation_declined"
+	EventTypeQuestionAnswered EventType = "rfq.question_answered"
+	EventTypeAddendumIssued EventType = "rfq.addendum_issued"
+	EventTypeBidsOpened EventType = "rfq.bids_opened"
 )
 
 // DomainEvent is the base structure for all domain events
@@ -81,6 +85,50 @@ type SupplierInvitedEvent struct {
 	Deadline     time.Time `json:"deadline"`
 }
 
+// InvitationDeclinedEvent is published when an invited supplier declines to quote
+type InvitationDeclinedEvent struct {
+	DomainEvent
+	RFQID        string `json:"rfq_id"`
+	RFQNumber    string `json:"rfq_number"`
+	SupplierID   string `json:"supplier_id"`
+	InvitationID string `json:"invitation_id"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// QuestionAnsweredEvent is published when the buyer answers a clarification question;
+// the answer is visible to every invited supplier
+type QuestionAnsweredEvent struct {
+	DomainEvent
+	RFQID      string   `json:"rfq_id"`
+	RFQNumber  string   `json:"rfq_number"`
+	QuestionID string   `json:"question_id"`
+	Invitees   []string `json:"invitees"`
+}
+
+// AddendumIssuedEvent is published when a published RFQ is amended;
+// every active invitee is notified of the new version
+type AddendumIssuedEvent struct {
+	DomainEvent
+	RFQID            string    `json:"rfq_id"`
+	RFQNumber        string    `json:"rfq_number"`
+	AddendumID       string    `json:"addendum_id"`
+	Version          int       `json:"version"`
+	Summary          string    `json:"summary"`
+	ResponseDeadline time.Time `json:"response_deadline"`
+	Invitees         []string  `json:"invitees"`
+}
+
+// BidsOpenedEvent is published when the sealed quotes of an RFQ are opened
+type BidsOpenedEvent struct {
+	DomainEvent
+	RFQID      string    `json:"rfq_id"`
+	RFQNumber  string    `json:"rfq_number"`
+	OpeningID  string    `json:"opening_id"`
+	OpenedBy   string    `json:"opened_by"`
+	OpenedAt   time.Time `json:"opened_at"`
+	QuoteCount int       `json:"quote_count"`
+}
+
 // NewRFQCreatedEvent creates a new RFQ created event
 func NewRFQCreatedEvent(rfq *RFQ) *RFQCreatedEvent {
 	return &RFQCreatedEvent{
@@ -130,6 +178,109 @@ func NewRFQClosedEvent(rfq *RFQ, closedBy string) *RFQClosedEvent {
 	}
 }
 
+// NewRFQAwardedEvent creates a new RFQ awarded event
+func NewRFQAwardedEvent(rfq *RFQ, supplierID, awardedBy string) *RFQAwardedEvent {
+	return &RFQAwardedEvent{
+		DomainEvent: DomainEvent{
+			EventID:   generateEventID(),
+			EventType: EventTypeRFQAwarded,
+			TenantID:  rfq.TenantID,
+			Timestamp: time.Now(),
+		},
+		RFQID:      rfq.ID,
+		RFQNumber:  rfq.RFQNumber,
+		SupplierID: supplierID,
+		AwardedBy:  awardedBy,
+	}
+}
+
+// NewSupplierInvitedEvent creates a new supplier invited event
+func NewSupplierInvitedEvent(rfq *RFQ, invitation *RFQInvitation) *SupplierInvitedEvent {
+	return &SupplierInvitedEvent{
+		DomainEvent: DomainEvent{
+			EventID:   generateEventID(),
+			EventType: EventTypeSupplierInvited,
+			TenantID:  rfq.TenantID,
+			Timestamp: time.Now(),
+		},
+		RFQID:        rfq.ID,
+		RFQNumber:    rfq.RFQNumber,
+		SupplierID:   invitation.SupplierID,
+		InvitationID: invitation.ID,
+		Deadline:     rfq.ResponseDeadline,
+	}
+}
+
+// NewInvitationDeclinedEvent creates a new invitation declined event
+func NewInvitationDeclinedEvent(rfq *RFQ, invitation *RFQInvitation) *InvitationDeclinedEvent {
+	return &InvitationDeclinedEvent{
+		DomainEvent: DomainEvent{
+			EventID:   generateEventID(),
+			EventType: EventTypeInvitationDeclined,
+			TenantID:  rfq.TenantID,
+			Timestamp: time.Now(),
+		},
+		RFQID:        rfq.ID,
+		RFQNumber:    rfq.RFQNumber,
+		SupplierID:   invitation.SupplierID,
+		InvitationID: invitation.ID,
+		Reason:       invitation.DeclineReason,
+	}
+}
+
+// NewQuestionAnsweredEvent creates a new question answered event addressed to all invitees
+func NewQuestionAnsweredEvent(rfq *RFQ, question *RFQQuestion) *QuestionAnsweredEvent {
+	return &QuestionAnsweredEvent{
+		DomainEvent: DomainEvent{
+			EventID:   generateEventID(),
+			EventType: EventTypeQuestionAnswered,
+			TenantID:  rfq.TenantID,
+			Timestamp: time.Now(),
+		},
+		RFQID:      rfq.ID,
+		RFQNumber:  rfq.RFQNumber,
+		QuestionID: question.ID,
+		Invitees:   rfq.ActiveInvitees(),
+	}
+}
+
+// NewAddendumIssuedEvent creates a new addendum issued event addressed to all invitees
+func NewAddendumIssuedEvent(rfq *RFQ, addendum *RFQAddendum) *AddendumIssuedEvent {
+	return &AddendumIssuedEvent{
+		DomainEvent: DomainEvent{
+			EventID:   generateEventID(),
+			EventType: EventTypeAddendumIssued,
+			TenantID:  rfq.TenantID,
+			Timestamp: time.Now(),
+		},
+		RFQID:            rfq.ID,
+		RFQNumber:        rfq.RFQNumber,
+		AddendumID:       addendum.ID,
+		Version:          addendum.Version,
+		Summary:          addendum.Summary,
+		ResponseDeadline: rfq.ResponseDeadline,
+		Invitees:         rfq.ActiveInvitees(),
+	}
+}
+
+// NewBidsOpenedEvent creates a new bids opened event
+func NewBidsOpenedEvent(rfq *RFQ, opening *BidOpening) *BidsOpenedEvent {
+	return &BidsOpenedEvent{
+		DomainEvent: DomainEvent{
+			EventID:   generateEventID(),
+			EventType: EventTypeBidsOpened,
+			TenantID:  rfq.TenantID,
+			Timestamp: time.Now(),
+		},
+		RFQID:      rfq.ID,
+		RFQNumber:  rfq.RFQNumber,
+		OpeningID:  opening.ID,
+		OpenedBy:   opening.OpenedBy,
+		OpenedAt:   opening.OpenedAt,
+		QuoteCount: opening.QuoteCount,
+	}
+}
+
 // generateEventID generates a unique event ID
 func generateEventID() string {
 	// In production, use a proper ID generation library (e.g., ULID, UUID)
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var ErrNotificationNotFound = errors.New("bidder notification not found")
+
+// Bidder notification kinds
+const (
+	NotificationKindAddendum = "addendum"
+	NotificationKindAnswer   = "answer"
+)
+
+// BidderNotification is a message broadcast to an invited supplier about a
+// change to an RFQ it is bidding on
+type BidderNotification struct {
+	ID          string     `json:"id"`
+	RFQID       string     `json:"rfq_id"`
+	SupplierID  string     `json:"supplier_id"`
+	Kind        string     `json:"kind"`
+	ReferenceID string     `json:"reference_id"`
+	Subject     string     `json:"subject"`
+	Message     string     `json:"message,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+}
+
+// BroadcastToBidders builds one notification per active invitee of the RFQ
+func (r *RFQ) BroadcastToBidders(kind, referenceID, subject, message string) []BidderNotification {
+	now := time.Now()
+	invitees := r.ActiveInvitees()
+	notifications := make([]BidderNotification, 0, len(invitees))
+	for _, supplierID := range invitees {
+		notifications = append(notifications, BidderNotification{
+			ID:          ksuid.New().String(),
+			RFQID:       r.ID,
+			SupplierID:  supplierID,
+			Kind:        kind,
+			ReferenceID: referenceID,
+			Subject:     subject,
+			Message:     message,
+			CreatedAt:   now,
+		})
+	}
+	return notifications
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	ErrQuestionNotFound        = errors.New("rfq question not found")
+	ErrQuestionEmpty           = errors.New("question text is required")
+	ErrAnswerEmpty             = errors.New("answer text is required")
+	ErrQuestionsClosed         = errors.New("questions can only be asked while the rfq is open")
+	ErrQuestionAlreadyAnswered = errors.New("rfq question already answered")
+)
+
+// RFQQuestion is a clarification question raised by an invited supplier.
+// Published answers form a public thread visible to every invited supplier,
+// without revealing which supplier asked.
+type RFQQuestion struct {
+	ID         string     `json:"id"`
+	RFQID      string     `json:"rfq_id"`
+	SupplierID string     `json:"supplier_id"`
+	Question   string     `json:"question"`
+	AskedBy    string     `json:"asked_by"`
+	AskedAt    time.Time  `json:"asked_at"`
+	Answer     string     `json:"answer,omitempty"`
+	AnsweredBy string     `json:"answered_by,omitempty"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+	Public     bool       `json:"public"`
+}
+
+// AskQuestion records a clarification question from an invited supplier
+func (r *RFQ) AskQuestion(supplierID, question, askedBy string) (*RFQQuestion, error) {
+	if r.Status != RFQStatusPublished || r.IsExpired() {
+		return nil, ErrQuestionsClosed
+	}
+	if _, err := r.FindInvitation(supplierID); err != nil {
+		return nil, err
+	}
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return nil, ErrQuestionEmpty
+	}
+
+	return &RFQQuestion{
+		ID:         ksuid.New().String(),
+		RFQID:      r.ID,
+		SupplierID: supplierID,
+		Question:   question,
+		AskedBy:    askedBy,
+		AskedAt:    time.Now(),
+	}, nil
+}
+
+// AnswerWith records the buyer's answer to the question. Public answers are
+// shared with all bidders; private ones only with the supplier that asked.
+func (q *RFQQuestion) AnswerWith(answer, answeredBy string, public bool) error {
+	if q.AnsweredAt != nil {
+		return ErrQuestionAlreadyAnswered
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return ErrAnswerEmpty
+	}
+
+	now := time.Now()
+	q.Answer = answer
+	q.AnsweredBy = answeredBy
+	q.AnsweredAt = &now
+	q.Public = public
+	return nil
+}
+
+// VisibleTo reports whether a supplier may read the question in the Q&A thread
+func (q *RFQQuestion) VisibleTo(supplierID string) bool {
+	return q.SupplierID == supplierID || (q.IsAnswered() && q.Public)
+}
+
+// IsAnswered reports whether the buyer has answered the question
+func (q *RFQQuestion) IsAnswered() bool {
+	return q.AnsweredAt != nil
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 // RFQRepository defines the interface for RFQ persistence
@@ -44,6 +45,45 @@ type RFQRepository interface {
 	
 	// UpdateInvitation updates an invitation status
 	UpdateInvitation(ctx context.Context, invitation *RFQInvitation) error
+	
+	// ListInvitationsBySupplier retrieves a supplier's invitations across the tenant's RFQs
+	ListInvitationsBySupplier(ctx context.Context, tenantID, supplierID string) ([]RFQInvitation, error)
+	
+	// AddQuestion stores a supplier clarification question
+	AddQuestion(ctx context.Context, question *RFQQuestion) error
+	
+	// GetQuestion retrieves a clarification question of an RFQ
+	GetQuestion(ctx context.Context, rfqID, questionID string) (*RFQQuestion, error)
+	
+	// GetQuestions retrieves the Q&A thread of an RFQ, oldest first
+	GetQuestions(ctx context.Context, rfqID string) ([]RFQQuestion, error)
+	
+	// UpdateQuestion saves the answer to a clarification question
+	UpdateQuestion(ctx context.Context, question *RFQQuestion) error
+	
+	// SaveAddendum atomically records an addendum together with the amended RFQ and items
+	SaveAddendum(ctx context.Context, rfq *RFQ, addendum *RFQAddendum) error
+	
+	// GetAddenda retrieves the addenda of an RFQ, oldest first
+	GetAddenda(ctx context.Context, rfqID string) ([]RFQAddendum, error)
+	
+	// AddNotifications stores bidder notifications
+	AddNotifications(ctx context.Context, notifications []BidderNotification) error
+	
+	// ListNotificationsBySupplier retrieves a supplier's notifications across the tenant's RFQs, newest first
+	ListNotificationsBySupplier(ctx context.Context, tenantID, supplierID string, unreadOnly bool) ([]BidderNotification, error)
+	
+	// MarkNotificationRead marks a supplier's notification as read
+	MarkNotificationRead(ctx context.Context, supplierID, notificationID string) error
+	
+	// ListPastDeadline retrieves published RFQs of all tenants whose response deadline has passed
+	ListPastDeadline(ctx context.Context, now time.Time) ([]*RFQ, error)
+	
+	// RecordBidOpening atomically unseals the RFQ's bids and stores the audit record
+	RecordBidOpening(ctx context.Context, rfq *RFQ, opening *BidOpening) error
+	
+	// GetBidOpening retrieves the bid opening audit record of an RFQ
+	GetBidOpening(ctx context.Context, rfqID string) (*BidOpening, error)
 }
 
 // ListCriteria defines filtering criteria for listing RFQs
@@ -61,6 +101,13 @@ type ListCriteria struct {
 	SortDirection   string
 }
 
+// SupplierEligibility checks whether a supplier may be invited to RFQs
+type SupplierEligibility interface {
+	// CheckInvitable returns ErrSupplierNotEligible when the supplier is not
+	// active, e.g. restricted for lapsed mandatory certifications
+	CheckInvitable(ctx context.Context, tenantID, supplierID string) error
+}
+
 // EventPublisher defines the interface for publishing domain events
 type EventPublisher interface {
 	Publish(ctx context.Context, event interface{}) error
@@ -3,6 +3,8 @@ package domain
 import (
 	"errors"
 	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/money"
 )
 
 // RFQ Status represents the lifecycle state of an RFQ
@@ -59,6 +61,19 @@ type RFQ struct {
 	Priority    RFQPriority `json:"priority"`
 	Status      RFQStatus   `json:"status"`
 	
+	// Version is bumped by every addendum issued after publishing
+	Version int `json:"version"`
+	
+	// Sealed bidding: quotes stay hidden from the buyer until bids are opened
+	SealedBid    bool       `json:"sealed_bid"`
+	BidsOpenedAt *time.Time `json:"bids_opened_at,omitempty"`
+	BidsOpenedBy string     `json:"bids_opened_by,omitempty"`
+	
+	// Base currency quotes are converted to for comparison and reporting,
+	// at the exchange rates of RateDate (the response deadline when unset)
+	Currency string     `json:"currency"`
+	RateDate *time.Time `json:"rate_date,omitempty"`
+	
 	// Items
 	Items []RFQItem `json:"items"`
 	
@@ -100,6 +115,14 @@ type RFQItem struct {
 	UpdatedAt      time.Time              `json:"updated_at"`
 }
 
+// Invitation statuses
+const (
+	InvitationStatusInvited  = "invited"
+	InvitationStatusViewed   = "viewed"
+	InvitationStatusQuoted   = "quoted"
+	InvitationStatusDeclined = "declined"
+)
+
 // RFQInvitation represents an invitation sent to a supplier
 type RFQInvitation struct {
 	ID         string    `json:"id"`
@@ -110,6 +133,7 @@ type RFQInvitation struct {
 	ViewedAt   *time.Time `json:"viewed_at,omitempty"`
 	RespondedAt *time.Time `json:"responded_at,omitempty"`
 	Message    string    `json:"message,omitempty"`
+	DeclineReason string `json:"decline_reason,omitempty"`
 }
 
 // Domain errors
@@ -125,6 +149,10 @@ var (
 	ErrInvalidDeliveryTerms   = errors.New("invalid delivery terms")
 	ErrInvalidPaymentTerms    = errors.New("invalid payment terms")
 	ErrInvalidQuantity        = errors.New("quantity must be greater than zero")
+	ErrInvitationNotFound     = errors.New("rfq invitation not found")
+	ErrInvitationDeclined     = errors.New("rfq invitation was declined")
+	ErrInvitationResponded    = errors.New("rfq invitation already responded to")
+	ErrSupplierNotEligible    = errors.New("supplier is not eligible to be invited")
 )
 
 // NewRFQ creates a new RFQ in draft status
@@ -151,6 +179,8 @@ func NewRFQ(
 		Description:      description,
 		Priority:         priority,
 		Status:           RFQStatusDraft,
+		Version:          1,
+		Currency:         money.DefaultCurrency,
 		Items:            []RFQItem{},
 		DeliveryTerms:    deliveryTerms,
 		PaymentTerms:     paymentTerms,
@@ -277,7 +307,7 @@ func (r *RFQ) InviteSupplier(invitation RFQInvitation) error {
 	
 	invitation.RFQID = r.ID
 	invitation.InvitedAt = time.Now()
-	invitation.Status = "invited"
+	invitation.Status = InvitationStatusInvited
 	
 	r.Invitations = append(r.Invitations, invitation)
 	r.UpdatedAt = time.Now()
@@ -285,12 +315,65 @@ func (r *RFQ) InviteSupplier(invitation RFQInvitation) error {
 	return nil
 }
 
+// FindInvitation returns the invitation for a supplier
+func (r *RFQ) FindInvitation(supplierID string) (*RFQInvitation, error) {
+	for i := range r.Invitations {
+		if r.Invitations[i].SupplierID == supplierID {
+			return &r.Invitations[i], nil
+		}
+	}
+	return nil, ErrInvitationNotFound
+}
+
+// MarkViewed records the first time the supplier opened the invitation
+func (i *RFQInvitation) MarkViewed() bool {
+	if i.ViewedAt != nil {
+		return false
+	}
+	now := time.Now()
+	i.ViewedAt = &now
+	if i.Status == InvitationStatusInvited {
+		i.Status = InvitationStatusViewed
+	}
+	return true
+}
+
+// Decline records that the supplier will not quote
+func (i *RFQInvitation) Decline(reason string) error {
+	if i.Status == InvitationStatusDeclined || i.Status == InvitationStatusQuoted {
+		return ErrInvitationResponded
+	}
+	now := time.Now()
+	if i.ViewedAt == nil {
+		i.ViewedAt = &now
+	}
+	i.Status = InvitationStatusDeclined
+	i.RespondedAt = &now
+	i.DeclineReason = reason
+	return nil
+}
+
+// MarkQuoted records that the supplier submitted a quote
+func (i *RFQInvitation) MarkQuoted() error {
+	if i.Status == InvitationStatusDeclined {
+		return ErrInvitationDeclined
+	}
+	now := time.Now()
+	if i.ViewedAt == nil {
+		i.ViewedAt = &now
+	}
+	i.Status = InvitationStatusQuoted
+	i.RespondedAt = &now
+	return nil
+}
+
 // IsExpired checks if the response deadline has passed
 func (r *RFQ) IsExpired() bool {
 	return time.Now().After(r.ResponseDeadline)
 }
 
-// CanBeEdited returns true if the RFQ can be edited
+// CanBeEdited returns true if the RFQ can be edited in place.
+// Published RFQs change only through addenda.
 func (r *RFQ) CanBeEdited() bool {
 	return r.Status == RFQStatusDraft
 }
@@ -299,3 +382,22 @@ func (r *RFQ) CanBeEdited() bool {
 func (r *RFQ) CanBePublished() bool {
 	return r.Status == RFQStatusDraft && len(r.Items) > 0
 }
+
+// SetCurrency sets the base currency and rate date quotes are compared at
+func (r *RFQ) SetCurrency(currency string, rateDate *time.Time) error {
+	code, err := money.NormalizeCurrency(currency)
+	if err != nil {
+		return err
+	}
+	r.Currency = code
+	r.RateDate = rateDate
+	return nil
+}
+
+// ConversionDate returns the date whose exchange rates convert quotes to the base currency
+func (r *RFQ) ConversionDate() time.Time {
+	if r.RateDate != nil {
+		return *r.RateDate
+	}
+	return r.ResponseDeadline
+}
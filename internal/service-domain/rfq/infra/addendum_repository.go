@@ -0,0 +1,242 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+)
+
+// SaveAddendum atomically records an addendum together with the amended RFQ and items
+func (r *RFQRepository) SaveAddendum(ctx context.Context, rfq *domain.RFQ, addendum *domain.RFQAddendum) error {
+	tx, err := r.db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Bump the version only if nobody else amended the RFQ meanwhile
+	result, err := tx.Exec(ctx, `
+		UPDATE rfqs SET response_deadline = $1, version = $2, updated_at = $3
+		WHERE id = $4 AND tenant_id = $5 AND version = $6
+	`, rfq.ResponseDeadline, rfq.Version, rfq.UpdatedAt, rfq.ID, rfq.TenantID, addendum.Version-1)
+	if err != nil {
+		return fmt.Errorf("failed to update RFQ version: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: rfq was amended concurrently", domain.ErrAddendumNotAllowed)
+	}
+
+	for _, item := range addendum.ChangedItems(rfq) {
+		specsJSON, err := json.Marshal(item.Specifications)
+		if err != nil {
+			return fmt.Errorf("failed to marshal specifications: %w", err)
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE rfq_items SET
+				description = $1, specifications = $2, quantity = $3, notes = $4, updated_at = $5
+			WHERE id = $6 AND rfq_id = $7
+		`, item.Description, specsJSON, item.Quantity, item.Notes, item.UpdatedAt, item.ID, rfq.ID)
+		if err != nil {
+			return fmt.Errorf("failed to update RFQ item: %w", err)
+		}
+	}
+
+	for _, item := range addendum.NewItems {
+		specsJSON, err := json.Marshal(item.Specifications)
+		if err != nil {
+			return fmt.Errorf("failed to marshal specifications: %w", err)
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO rfq_items (
+				id, rfq_id, equipment_id, category_id, name, description,
+				specifications, quantity, unit, estimated_price, notes,
+				created_at, updated_at
+			) VALUES (
+				$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+			)
+		`, item.ID, rfq.ID, item.EquipmentID, item.CategoryID, item.Name, item.Description,
+			specsJSON, item.Quantity, item.Unit, item.EstimatedPrice, item.Notes,
+			item.CreatedAt, item.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add RFQ item: %w", err)
+		}
+	}
+
+	changesJSON, err := json.Marshal(addendum.ItemChanges)
+	if err != nil {
+		return fmt.Errorf("failed to marshal item changes: %w", err)
+	}
+	newItemsJSON, err := json.Marshal(addendum.NewItems)
+	if err != nil {
+		return fmt.Errorf("failed to marshal new items: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO rfq_addenda (
+			id, rfq_id, version, summary, previous_deadline, new_deadline,
+			item_changes, new_items, issued_by, issued_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		)
+	`, addendum.ID, addendum.RFQID, addendum.Version, addendum.Summary, addendum.PreviousDeadline,
+		addendum.NewDeadline, changesJSON, newItemsJSON, addendum.IssuedBy, addendum.IssuedAt)
+	if err != nil {
+		r.logger.Error("Failed to add RFQ addendum",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", rfq.ID))
+		return fmt.Errorf("failed to add RFQ addendum: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Info("RFQ addendum issued",
+		slog.String("rfq_id", rfq.ID),
+		slog.Int("version", addendum.Version))
+
+	return nil
+}
+
+// GetAddenda retrieves the addenda of an RFQ, oldest first
+func (r *RFQRepository) GetAddenda(ctx context.Context, rfqID string) ([]domain.RFQAddendum, error) {
+	query := `
+		SELECT
+			id, rfq_id, version, summary, previous_deadline, new_deadline,
+			item_changes, new_items, issued_by, issued_at
+		FROM rfq_addenda
+		WHERE rfq_id = $1
+		ORDER BY version ASC
+	`
+
+	rows, err := r.db.pool.Query(ctx, query, rfqID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RFQ addenda: %w", err)
+	}
+	defer rows.Close()
+
+	addenda := []domain.RFQAddendum{}
+	for rows.Next() {
+		var a domain.RFQAddendum
+		var changesJSON, newItemsJSON []byte
+
+		err := rows.Scan(
+			&a.ID,
+			&a.RFQID,
+			&a.Version,
+			&a.Summary,
+			&a.PreviousDeadline,
+			&a.NewDeadline,
+			&changesJSON,
+			&newItemsJSON,
+			&a.IssuedBy,
+			&a.IssuedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan RFQ addendum: %w", err)
+		}
+
+		if err := json.Unmarshal(changesJSON, &a.ItemChanges); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal item changes: %w", err)
+		}
+		if err := json.Unmarshal(newItemsJSON, &a.NewItems); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal new items: %w", err)
+		}
+
+		addenda = append(addenda, a)
+	}
+
+	return addenda, rows.Err()
+}
+
+// AddNotifications stores bidder notifications
+func (r *RFQRepository) AddNotifications(ctx context.Context, notifications []domain.BidderNotification) error {
+	query := `
+		INSERT INTO rfq_bidder_notifications (
+			id, rfq_id, supplier_id, kind, reference_id, subject, message, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8
+		)
+	`
+
+	for _, n := range notifications {
+		_, err := r.db.pool.Exec(ctx, query,
+			n.ID, n.RFQID, n.SupplierID, n.Kind, n.ReferenceID, n.Subject, n.Message, n.CreatedAt)
+		if err != nil {
+			r.logger.Error("Failed to add bidder notification",
+				slog.String("error", err.Error()),
+				slog.String("rfq_id", n.RFQID))
+			return fmt.Errorf("failed to add bidder notification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListNotificationsBySupplier retrieves a supplier's notifications across the tenant's RFQs, newest first
+func (r *RFQRepository) ListNotificationsBySupplier(ctx context.Context, tenantID, supplierID string, unreadOnly bool) ([]domain.BidderNotification, error) {
+	query := `
+		SELECT
+			n.id, n.rfq_id, n.supplier_id, n.kind, n.reference_id, n.subject,
+			COALESCE(n.message, ''), n.created_at, n.read_at
+		FROM rfq_bidder_notifications n
+		JOIN rfqs r ON r.id = n.rfq_id
+		WHERE n.supplier_id = $1 AND r.tenant_id = $2
+	`
+	if unreadOnly {
+		query += " AND n.read_at IS NULL"
+	}
+	query += " ORDER BY n.created_at DESC"
+
+	rows, err := r.db.pool.Query(ctx, query, supplierID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bidder notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []domain.BidderNotification{}
+	for rows.Next() {
+		var n domain.BidderNotification
+
+		err := rows.Scan(
+			&n.ID,
+			&n.RFQID,
+			&n.SupplierID,
+			&n.Kind,
+			&n.ReferenceID,
+			&n.Subject,
+			&n.Message,
+			&n.CreatedAt,
+			&n.ReadAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bidder notification: %w", err)
+		}
+
+		notifications = append(notifications, n)
+	}
+
+	return notifications, rows.Err()
+}
+
+// MarkNotificationRead marks a supplier's notification as read
+func (r *RFQRepository) MarkNotificationRead(ctx context.Context, supplierID, notificationID string) error {
+	query := `
+		UPDATE rfq_bidder_notifications SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+		WHERE id = $1 AND supplier_id = $2
+	`
+
+	result, err := r.db.pool.Exec(ctx, query, notificationID, supplierID)
+	if err != nil {
+		return fmt.Errorf("failed to mark bidder notification read: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotificationNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListPastDeadline retrieves published RFQs of all tenants whose response deadline has passed
+func (r *RFQRepository) ListPastDeadline(ctx context.Context, now time.Time) ([]*domain.RFQ, error) {
+	query := `
+		SELECT id, tenant_id FROM rfqs
+		WHERE status = 'published' AND response_deadline <= $1
+		ORDER BY response_deadline ASC
+	`
+
+	rows, err := r.db.pool.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list RFQs past deadline: %w", err)
+	}
+
+	type key struct{ id, tenantID string }
+	keys := []key{}
+	for rows.Next() {
+		var k key
+		if err := rows.Scan(&k.id, &k.tenantID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan RFQ: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list RFQs past deadline: %w", err)
+	}
+
+	rfqs := make([]*domain.RFQ, 0, len(keys))
+	for _, k := range keys {
+		rfq, err := r.GetByID(ctx, k.id, k.tenantID)
+		if err != nil {
+			return nil, err
+		}
+		rfqs = append(rfqs, rfq)
+	}
+
+	return rfqs, nil
+}
+
+// RecordBidOpening atomically unseals the RFQ's bids and stores the audit record
+func (r *RFQRepository) RecordBidOpening(ctx context.Context, rfq *domain.RFQ, opening *domain.BidOpening) error {
+	tx, err := r.db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Guard against two buyers opening the same bids concurrently
+	result, err := tx.Exec(ctx, `
+		UPDATE rfqs SET
+			status = $1, closed_at = $2, bids_opened_at = $3, bids_opened_by = $4, updated_at = $5
+		WHERE id = $6 AND tenant_id = $7 AND bids_opened_at IS NULL
+	`, string(rfq.Status), rfq.ClosedAt, rfq.BidsOpenedAt, rfq.BidsOpenedBy, rfq.UpdatedAt, rfq.ID, rfq.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to open bids: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrBidsAlreadyOpened
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rfq_bid_openings (id, rfq_id, tenant_id, opened_by, opened_at, quote_count)
+		VALUES ($1, $2, $3, $4, $5, (
+			SELECT COUNT(*) FROM quotes
+			WHERE rfq_id = $2 AND tenant_id = $3 AND status NOT IN ('draft', 'withdrawn')
+		))
+		RETURNING quote_count
+	`, opening.ID, opening.RFQID, opening.TenantID, opening.OpenedBy, opening.OpenedAt).Scan(&opening.QuoteCount)
+	if err != nil {
+		r.logger.Error("Failed to record bid opening",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", rfq.ID))
+		return fmt.Errorf("failed to record bid opening: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetBidOpening retrieves the bid opening audit record of an RFQ
+func (r *RFQRepository) GetBidOpening(ctx context.Context, rfqID string) (*domain.BidOpening, error) {
+	query := `
+		SELECT id, rfq_id, tenant_id, opened_by, opened_at, quote_count
+		FROM rfq_bid_openings
+		WHERE rfq_id = $1
+	`
+
+	var o domain.BidOpening
+	err := r.db.pool.QueryRow(ctx, query, rfqID).Scan(
+		&o.ID,
+		&o.RFQID,
+		&o.TenantID,
+		&o.OpenedBy,
+		&o.OpenedAt,
+		&o.QuoteCount,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrBidOpeningNotFound
+		}
+		return nil, fmt.Errorf("failed to get bid opening: %w", err)
+	}
+
+	return &o, nil
+}
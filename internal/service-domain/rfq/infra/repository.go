@@ -32,9 +32,10 @@ func (r *RFQRepository) Create(ctx context.Context, rfq *domain.RFQ) error {
 		INSERT INTO rfqs (
 			id, rfq_number, tenant_id, title, description, priority, status,
 			delivery_terms, payment_terms, response_deadline,
-			created_by, created_at, updated_at, internal_notes
+			created_by, created_at, updated_at, internal_notes, version, sealed_bid,
+			currency, rate_date
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 		)
 	`
 
@@ -66,6 +67,10 @@ func (r *RFQRepository) Create(ctx context.Context, rfq *domain.RFQ) error {
 		rfq.CreatedAt,
 		rfq.UpdatedAt,
 		rfq.InternalNotes,
+		rfq.Version,
+		rfq.SealedBid,
+		rfq.Currency,
+		rfq.RateDate,
 	)
 
 	if err != nil {
@@ -88,7 +93,9 @@ func (r *RFQRepository) GetByID(ctx context.Context, id string, tenantID string)
 		SELECT 
 			id, rfq_number, tenant_id, title, description, priority, status,
 			delivery_terms, payment_terms, published_at, response_deadline, closed_at,
-			created_by, created_at, updated_at, internal_notes
+			created_by, created_at, updated_at, internal_notes, version,
+			sealed_bid, bids_opened_at, COALESCE(bids_opened_by, ''),
+			currency, rate_date
 		FROM rfqs
 		WHERE id = $1 AND tenant_id = $2
 	`
@@ -116,6 +123,12 @@ func (r *RFQRepository) GetByID(ctx context.Context, id string, tenantID string)
 		&rfq.CreatedAt,
 		&rfq.UpdatedAt,
 		&rfq.InternalNotes,
+		&rfq.Version,
+		&rfq.SealedBid,
+		&rfq.BidsOpenedAt,
+		&rfq.BidsOpenedBy,
+		&rfq.Currency,
+		&rfq.RateDate,
 	)
 
 	if err != nil {
@@ -191,8 +204,12 @@ func (r *RFQRepository) Update(ctx context.Context, rfq *domain.RFQ) error {
 			response_deadline = $8,
 			closed_at = $9,
 			updated_at = $10,
-			internal_notes = $11
-		WHERE id = $12 AND tenant_id = $13
+			internal_notes = $11,
+			version = $12,
+			sealed_bid = $13,
+			currency = $14,
+			rate_date = $15
+		WHERE id = $16 AND tenant_id = $17
 	`
 
 	deliveryTermsJSON, err := json.Marshal(rfq.DeliveryTerms)
@@ -219,6 +236,10 @@ func (r *RFQRepository) Update(ctx context.Context, rfq *domain.RFQ) error {
 		rfq.ClosedAt,
 		rfq.UpdatedAt,
 		rfq.InternalNotes,
+		rfq.Version,
+		rfq.SealedBid,
+		rfq.Currency,
+		rfq.RateDate,
 		rfq.ID,
 		rfq.TenantID,
 	)
@@ -263,7 +284,9 @@ func (r *RFQRepository) List(ctx context.Context, criteria domain.ListCriteria)
 		SELECT 
 			id, rfq_number, tenant_id, title, description, priority, status,
 			delivery_terms, payment_terms, published_at, response_deadline, closed_at,
-			created_by, created_at, updated_at, internal_notes
+			created_by, created_at, updated_at, internal_notes, version,
+			sealed_bid, bids_opened_at, COALESCE(bids_opened_by, ''),
+			currency, rate_date
 		FROM rfqs
 		WHERE tenant_id = $1
 	`}
@@ -375,6 +398,12 @@ func (r *RFQRepository) List(ctx context.Context, criteria domain.ListCriteria)
 			&rfq.CreatedAt,
 			&rfq.UpdatedAt,
 			&rfq.InternalNotes,
+			&rfq.Version,
+			&rfq.SealedBid,
+			&rfq.BidsOpenedAt,
+			&rfq.BidsOpenedBy,
+			&rfq.Currency,
+			&rfq.RateDate,
 		)
 
 		if err != nil {
@@ -599,7 +628,8 @@ func (r *RFQRepository) AddInvitation(ctx context.Context, invitation *domain.RF
 func (r *RFQRepository) GetInvitations(ctx context.Context, rfqID string) ([]domain.RFQInvitation, error) {
 	query := `
 		SELECT 
-			id, rfq_id, supplier_id, status, invited_at, viewed_at, responded_at, message
+			id, rfq_id, supplier_id, status, invited_at, viewed_at, responded_at,
+			COALESCE(message, ''), COALESCE(decline_reason, '')
 		FROM rfq_invitations
 		WHERE rfq_id = $1
 		ORDER BY invited_at DESC
@@ -611,6 +641,31 @@ func (r *RFQRepository) GetInvitations(ctx context.Context, rfqID string) ([]dom
 	}
 	defer rows.Close()
 
+	return scanInvitations(rows)
+}
+
+// ListInvitationsBySupplier retrieves a supplier's invitations across the tenant's RFQs
+func (r *RFQRepository) ListInvitationsBySupplier(ctx context.Context, tenantID, supplierID string) ([]domain.RFQInvitation, error) {
+	query := `
+		SELECT 
+			i.id, i.rfq_id, i.supplier_id, i.status, i.invited_at, i.viewed_at, i.responded_at,
+			COALESCE(i.message, ''), COALESCE(i.decline_reason, '')
+		FROM rfq_invitations i
+		JOIN rfqs r ON r.id = i.rfq_id
+		WHERE i.supplier_id = $1 AND r.tenant_id = $2
+		ORDER BY i.invited_at DESC
+	`
+
+	rows, err := r.db.pool.Query(ctx, query, supplierID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list supplier invitations: %w", err)
+	}
+	defer rows.Close()
+
+	return scanInvitations(rows)
+}
+
+func scanInvitations(rows pgx.Rows) ([]domain.RFQInvitation, error) {
 	invitations := []domain.RFQInvitation{}
 	for rows.Next() {
 		var inv domain.RFQInvitation
@@ -624,6 +679,7 @@ func (r *RFQRepository) GetInvitations(ctx context.Context, rfqID string) ([]dom
 			&inv.ViewedAt,
 			&inv.RespondedAt,
 			&inv.Message,
+			&inv.DeclineReason,
 		)
 
 		if err != nil {
@@ -633,7 +689,7 @@ func (r *RFQRepository) GetInvitations(ctx context.Context, rfqID string) ([]dom
 		invitations = append(invitations, inv)
 	}
 
-	return invitations, nil
+	return invitations, rows.Err()
 }
 
 // UpdateInvitation updates an invitation status
@@ -642,8 +698,9 @@ func (r *RFQRepository) UpdateInvitation(ctx context.Context, invitation *domain
 		UPDATE rfq_invitations SET
 			status = $1,
 			viewed_at = $2,
-			responded_at = $3
-		WHERE id = $4
+			responded_at = $3,
+			decline_reason = NULLIF($4, '')
+		WHERE id = $5
 	`
 
 	result, err := r.db.pool.Exec(
@@ -652,6 +709,7 @@ func (r *RFQRepository) UpdateInvitation(ctx context.Context, invitation *domain
 		invitation.Status,
 		invitation.ViewedAt,
 		invitation.RespondedAt,
+		invitation.DeclineReason,
 		invitation.ID,
 	)
 
@@ -660,7 +718,149 @@ func (r *RFQRepository) UpdateInvitation(ctx context.Context, invitation *domain
 	}
 
 	if result.RowsAffected() == 0 {
-		return errors.New("RFQ invitation not found")
+		return domain.ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+// AddQuestion stores a supplier clarification question
+func (r *RFQRepository) AddQuestion(ctx context.Context, question *domain.RFQQuestion) error {
+	query := `
+		INSERT INTO rfq_questions (
+			id, rfq_id, supplier_id, question, asked_by, asked_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+	`
+
+	_, err := r.db.pool.Exec(
+		ctx,
+		query,
+		question.ID,
+		question.RFQID,
+		question.SupplierID,
+		question.Question,
+		question.AskedBy,
+		question.AskedAt,
+	)
+
+	if err != nil {
+		r.logger.Error("Failed to add RFQ question",
+			slog.String("error", err.Error()),
+			slog.String("rfq_id", question.RFQID))
+		return fmt.Errorf("failed to add RFQ question: %w", err)
+	}
+
+	return nil
+}
+
+// GetQuestion retrieves a clarification question of an RFQ
+func (r *RFQRepository) GetQuestion(ctx context.Context, rfqID, questionID string) (*domain.RFQQuestion, error) {
+	query := `
+		SELECT 
+			id, rfq_id, supplier_id, question, asked_by, asked_at,
+			COALESCE(answer, ''), COALESCE(answered_by, ''), answered_at, public
+		FROM rfq_questions
+		WHERE id = $1 AND rfq_id = $2
+	`
+
+	var q domain.RFQQuestion
+	err := r.db.pool.QueryRow(ctx, query, questionID, rfqID).Scan(
+		&q.ID,
+		&q.RFQID,
+		&q.SupplierID,
+		&q.Question,
+		&q.AskedBy,
+		&q.AskedAt,
+		&q.Answer,
+		&q.AnsweredBy,
+		&q.AnsweredAt,
+		&q.Public,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get RFQ question: %w", err)
+	}
+
+	return &q, nil
+}
+
+// GetQuestions retrieves the Q&A thread of an RFQ, oldest first
+func (r *RFQRepository) GetQuestions(ctx context.Context, rfqID string) ([]domain.RFQQuestion, error) {
+	query := `
+		SELECT 
+			id, rfq_id, supplier_id, question, asked_by, asked_at,
+			COALESCE(answer, ''), COALESCE(answered_by, ''), answered_at, public
+		FROM rfq_questions
+		WHERE rfq_id = $1
+		ORDER BY asked_at ASC
+	`
+
+	rows, err := r.db.pool.Query(ctx, query, rfqID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RFQ questions: %w", err)
+	}
+	defer rows.Close()
+
+	questions := []domain.RFQQuestion{}
+	for rows.Next() {
+		var q domain.RFQQuestion
+
+		err := rows.Scan(
+			&q.ID,
+			&q.RFQID,
+			&q.SupplierID,
+			&q.Question,
+			&q.AskedBy,
+			&q.AskedAt,
+			&q.Answer,
+			&q.AnsweredBy,
+			&q.AnsweredAt,
+			&q.Public,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan RFQ question: %w", err)
+		}
+
+		questions = append(questions, q)
+	}
+
+	return questions, rows.Err()
+}
+
+// UpdateQuestion saves the answer to a clarification question
+func (r *RFQRepository) UpdateQuestion(ctx context.Context, question *domain.RFQQuestion) error {
+	query := `
+		UPDATE rfq_questions SET
+			answer = $1,
+			answered_by = $2,
+			answered_at = $3,
+			public = $4
+		WHERE id = $5 AND rfq_id = $6
+	`
+
+	result, err := r.db.pool.Exec(
+		ctx,
+		query,
+		question.Answer,
+		question.AnsweredBy,
+		question.AnsweredAt,
+		question.Public,
+		question.ID,
+		question.RFQID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update RFQ question: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrQuestionNotFound
 	}
 
 	return nil
@@ -0,0 +1,46 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/rfq/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// SupplierEligibilityRepository implements domain.SupplierEligibility over the suppliers table
+type SupplierEligibilityRepository struct {
+	db *PostgresDB
+}
+
+// NewSupplierEligibilityRepository creates a new supplier eligibility checker
+func NewSupplierEligibilityRepository(db *PostgresDB) *SupplierEligibilityRepository {
+	return &SupplierEligibilityRepository{db: db}
+}
+
+// CheckInvitable returns domain.ErrSupplierNotEligible unless the supplier is
+// verified and active
+func (r *SupplierEligibilityRepository) CheckInvitable(ctx context.Context, tenantID, supplierID string) error {
+	var status, verification string
+	var reason *string
+	err := r.db.pool.QueryRow(ctx, `
+		SELECT status, verification_status, restriction_reason
+		FROM suppliers
+		WHERE id = $1 AND tenant_id = $2
+	`, supplierID, tenantID).Scan(&status, &verification, &reason)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: supplier not found", domain.ErrSupplierNotEligible)
+		}
+		return fmt.Errorf("failed to check supplier eligibility: %w", err)
+	}
+
+	switch {
+	case status == "restricted" && reason != nil && *reason != "":
+		return fmt.Errorf("%w: supplier is restricted (%s)", domain.ErrSupplierNotEligible, *reason)
+	case status != "active" || verification != "approved":
+		return fmt.Errorf("%w: supplier is %s", domain.ErrSupplierNotEligible, status)
+	}
+	return nil
+}
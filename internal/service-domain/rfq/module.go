@@ -23,6 +23,7 @@ type Module struct {
 	eventBus    domain.EventPublisher
 	appService  *app.RFQService
 	httpHandler *api.RFQHandler
+	closer      *app.DeadlineCloser
 }
 
 // Config holds configuration for the RFQ module
@@ -62,7 +63,9 @@ func (m *Module) Initialize(ctx context.Context) error {
 	m.eventBus = infra.NewKafkaEventPublisher(m.config.KafkaBrokers, m.logger)
 
 	// Initialize application service
-	m.appService = app.NewRFQService(m.repository, m.eventBus, m.logger)
+	suppliers := infra.NewSupplierEligibilityRepository(db)
+	m.appService = app.NewRFQService(m.repository, m.eventBus, suppliers, m.logger)
+	m.closer = app.NewDeadlineCloser(m.appService, m.logger)
 
 	// Initialize HTTP handler
 	m.httpHandler = api.NewRFQHandler(m.appService, m.logger)
@@ -91,9 +94,22 @@ func (m *Module) MountRoutes(router chi.Router) {
 		r.Post("/{id}/close", m.httpHandler.CloseRFQ)
 		r.Post("/{id}/cancel", m.httpHandler.CancelRFQ)
 
+		// Sealed-bid opening
+		r.Post("/{id}/open-bids", m.httpHandler.OpenBids)
+		r.Get("/{id}/bid-opening", m.httpHandler.GetBidOpening)
+
 		// RFQ items endpoints
 		r.Post("/{id}/items", m.httpHandler.AddItem)
 		r.Delete("/{id}/items/{item_id}", m.httpHandler.RemoveItem)
+
+		// Supplier invitations and clarification Q&A
+		r.Post("/{id}/invitations", m.httpHandler.InviteSupplier)
+		r.Get("/{id}/questions", m.httpHandler.ListQuestions)
+		r.Post("/{id}/questions/{question_id}/answer", m.httpHandler.AnswerQuestion)
+
+		// Addenda to published RFQs
+		r.Get("/{id}/addenda", m.httpHandler.ListAddenda)
+		r.Post("/{id}/addenda", m.httpHandler.IssueAddendum)
 	})
 
 	m.logger.Info("RFQ routes mounted successfully")
@@ -102,7 +118,11 @@ func (m *Module) MountRoutes(router chi.Router) {
 // Start starts the module background services
 func (m *Module) Start(ctx context.Context) error {
 	m.logger.Info("Starting RFQ module")
-	// No background services to start for now
+
+	// Close RFQs automatically once their response deadline passes
+	if m.closer != nil {
+		go m.closer.Run(ctx)
+	}
 	return nil
 }
 
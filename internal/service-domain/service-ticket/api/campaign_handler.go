@@ -0,0 +1,298 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// CampaignHandler handles HTTP requests for recall and field safety campaigns
+type CampaignHandler struct {
+	service *app.CampaignService
+	logger  *slog.Logger
+}
+
+// NewCampaignHandler creates a new campaign HTTP handler
+func NewCampaignHandler(service *app.CampaignService, logger *slog.Logger) *CampaignHandler {
+	return &CampaignHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "campaign_handler")),
+	}
+}
+
+// CreateCampaign handles POST /campaigns
+func (h *CampaignHandler) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	var campaign domain.Campaign
+	if err := json.NewDecoder(r.Body).Decode(&campaign); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	created, err := h.service.CreateCampaign(r.Context(), &campaign)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to create campaign")
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, created)
+}
+
+// ListCampaigns handles GET /campaigns?status=active,draft
+func (h *CampaignHandler) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	var status []domain.CampaignStatus
+	for _, s := range splitQuery(r.URL.Query().Get("status")) {
+		status = append(status, domain.CampaignStatus(s))
+	}
+
+	campaigns, err := h.service.ListCampaigns(r.Context(), status)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to list campaigns")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"campaigns": campaigns, "total": len(campaigns)})
+}
+
+// GetCampaign handles GET /campaigns/{id}
+func (h *CampaignHandler) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	campaign, err := h.service.GetCampaign(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to get campaign")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, campaign)
+}
+
+// CancelCampaign handles POST /campaigns/{id}/cancel
+func (h *CampaignHandler) CancelCampaign(w http.ResponseWriter, r *http.Request) {
+	campaign, err := h.service.CancelCampaign(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to cancel campaign")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, campaign)
+}
+
+// PreviewCampaign handles POST /campaigns/{id}/preview
+func (h *CampaignHandler) PreviewCampaign(w http.ResponseWriter, r *http.Request) {
+	preview, err := h.service.PreviewMatches(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to match installed base")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, preview)
+}
+
+// campaignActorRequest identifies who performs a campaign operation
+type campaignActorRequest struct {
+	PerformedBy string `json:"performed_by"`
+}
+
+// LaunchCampaign handles POST /campaigns/{id}/launch
+func (h *CampaignHandler) LaunchCampaign(w http.ResponseWriter, r *http.Request) {
+	var req campaignActorRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	result, err := h.service.Launch(r.Context(), chi.URLParam(r, "id"), req.PerformedBy)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to launch campaign")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// GenerateTickets handles POST /campaigns/{id}/tickets
+func (h *CampaignHandler) GenerateTickets(w http.ResponseWriter, r *http.Request) {
+	var req campaignActorRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	result, err := h.service.GenerateTickets(r.Context(), chi.URLParam(r, "id"), req.PerformedBy)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to generate campaign tickets")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// SyncTickets handles POST /campaigns/{id}/sync
+func (h *CampaignHandler) SyncTickets(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.SyncTicketStatuses(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to sync campaign tickets")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// NotifyCustomersRequest holds the recipients of a field safety notice, keyed by customer ID
+type NotifyCustomersRequest struct {
+	Contacts map[string]app.CustomerContact `json:"contacts"`
+	SentBy   string                         `json:"sent_by"`
+}
+
+// NotifyCustomers handles POST /campaigns/{id}/notify
+func (h *CampaignHandler) NotifyCustomers(w http.ResponseWriter, r *http.Request) {
+	var req NotifyCustomersRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	result, err := h.service.NotifyCustomers(r.Context(), chi.URLParam(r, "id"), req.Contacts, req.SentBy)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to notify customers")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// ListNotifications handles GET /campaigns/{id}/notifications
+func (h *CampaignHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	notifications, err := h.service.ListNotifications(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to list notifications")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"notifications": notifications, "total": len(notifications)})
+}
+
+// ListUnits handles GET /campaigns/{id}/units?status=&customer_id=&limit=&offset=
+func (h *CampaignHandler) ListUnits(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.CampaignUnitFilter{CustomerID: query.Get("customer_id")}
+	for _, s := range splitQuery(query.Get("status")) {
+		filter.Status = append(filter.Status, domain.CampaignUnitStatus(s))
+	}
+	filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+	filter.Offset, _ = strconv.Atoi(query.Get("offset"))
+
+	units, err := h.service.ListUnits(r.Context(), chi.URLParam(r, "id"), filter)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to list campaign units")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"units": units, "total": len(units)})
+}
+
+// GetUnit handles GET /campaigns/{id}/units/{unit_id}
+func (h *CampaignHandler) GetUnit(w http.ResponseWriter, r *http.Request) {
+	unit, err := h.service.GetUnit(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "unit_id"))
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to get campaign unit")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, unit)
+}
+
+// UpdateUnit handles PATCH /campaigns/{id}/units/{unit_id}
+func (h *CampaignHandler) UpdateUnit(w http.ResponseWriter, r *http.Request) {
+	var req app.UpdateUnitStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	unit, err := h.service.UpdateUnitStatus(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "unit_id"), req)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to update campaign unit")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, unit)
+}
+
+// AddEvidence handles POST /campaigns/{id}/units/{unit_id}/evidence
+func (h *CampaignHandler) AddEvidence(w http.ResponseWriter, r *http.Request) {
+	var req app.AddEvidenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	evidence, err := h.service.AddEvidence(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "unit_id"), req)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to add evidence")
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, evidence)
+}
+
+// ListEquipmentCampaigns handles GET /campaigns/equipment/{equipment_id}
+func (h *CampaignHandler) ListEquipmentCampaigns(w http.ResponseWriter, r *http.Request) {
+	units, err := h.service.ListEquipmentCampaigns(r.Context(), chi.URLParam(r, "equipment_id"))
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to list equipment campaigns")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"units": units, "total": len(units)})
+}
+
+// splitQuery splits a comma separated query value
+func splitQuery(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// respondServiceError maps campaign errors to HTTP status codes
+func (h *CampaignHandler) respondServiceError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrCampaignNotFound), errors.Is(err, domain.ErrCampaignUnitNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidCampaign):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrCampaignNotActive), errors.Is(err, domain.ErrInvalidUnitTransition),
+		errors.Is(err, domain.ErrEvidenceRequired):
+		h.respondError(w, http.StatusConflict, err.Error())
+	default:
+		h.logger.Error(message, slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, message)
+	}
+}
+
+// respondJSON writes JSON response
+func (h *CampaignHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError writes error response
+func (h *CampaignHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
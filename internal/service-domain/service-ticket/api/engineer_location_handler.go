@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// UpdateEngineerBaseLocation handles PUT /engineers/{id}/location
+func (h *AssignmentHandler) UpdateEngineerBaseLocation(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req app.EngineerBaseLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	engineer, err := h.service.UpdateEngineerBaseLocation(r.Context(), id, req)
+	if err != nil {
+		h.respondLocationError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, engineer)
+}
+
+// ReportEngineerLocationRequest is a position report from an engineer's device
+type ReportEngineerLocationRequest struct {
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	RecordedAt time.Time `json:"recorded_at,omitempty"`
+}
+
+// ReportEngineerLocation handles POST /engineers/{id}/location/current
+func (h *AssignmentHandler) ReportEngineerLocation(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req ReportEngineerLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	point := geo.Point{Lat: req.Latitude, Lng: req.Longitude}
+	if err := h.service.ReportEngineerLocation(r.Context(), id, point, req.RecordedAt); err != nil {
+		h.respondLocationError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{"message": "Location recorded"})
+}
+
+// respondLocationError maps engineer location errors to HTTP status codes
+func (h *AssignmentHandler) respondLocationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEngineerNotFound):
+		h.respondError(w, http.StatusNotFound, "Engineer not found")
+	case errors.Is(err, domain.ErrInvalidLocation), errors.Is(err, domain.ErrPostalCodeNotFound):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error("Failed to update engineer location", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to update engineer location")
+	}
+}
@@ -3,6 +3,7 @@
 import (
     "database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -123,6 +124,19 @@ func (h *TicketHandler) CreateTicket(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 
+			// Use the current part of a superseded part number; skip parts
+			// that do not fit the equipment
+			selection, err := h.service.SelectTicketPart(ctx, ticket.ID, sparePartID)
+			if errors.Is(err, domain.ErrIncompatiblePart) {
+				h.logger.Warn("Part not compatible with equipment, skipping",
+					slog.String("part_number", part.PartNumber),
+					slog.String("error", err.Error()))
+				continue
+			}
+			if selection != nil {
+				sparePartID = selection.SparePartID
+			}
+
 			// Insert into ticket_parts
 			_, err = h.pool.Exec(ctx, `
 				INSERT INTO ticket_parts (
@@ -715,8 +729,8 @@ func (h *TicketHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 		
-		// Apply parts overrides if they exist
-		if len(partsOverride) > 0 {
+		// Apply parts overrides if they exist; parts requisitions take precedence
+		if len(partsOverride) > 0 && timeline.PartsStatus == "" {
 			var partsData map[string]interface{}
 			if err := json.Unmarshal(partsOverride, &partsData); err == nil {
 				if etaStr, ok := partsData["eta"].(string); ok {
@@ -1010,12 +1024,14 @@ func (h *TicketHandler) AddTicketPart(w http.ResponseWriter, r *http.Request) {
     // Parse request body
     var req struct {
         SparePartID      string   `json:"spare_part_id"`
+        PartNumber       string   `json:"part_number"` // Alternative to spare_part_id
         QuantityRequired int      `json:"quantity_required"`
         UnitPrice        *float64 `json:"unit_price"`
         TotalPrice       *float64 `json:"total_price"`
         IsCritical       bool     `json:"is_critical"`
         Status           string   `json:"status"`
         Notes            string   `json:"notes"`
+        LocationID       string   `json:"location_id"` // Stock location to reserve from; defaults to the engineer's van
     }
     
     if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -1024,8 +1040,8 @@ func (h *TicketHandler) AddTicketPart(w http.ResponseWriter, r *http.Request) {
     }
     
     // Validate required fields
-    if req.SparePartID == "" {
-        h.respondError(w, http.StatusBadRequest, "spare_part_id is required")
+    if req.SparePartID == "" && req.PartNumber == "" {
+        h.respondError(w, http.StatusBadRequest, "spare_part_id or part_number is required")
         return
     }
     if req.QuantityRequired <= 0 {
@@ -1034,6 +1050,30 @@ func (h *TicketHandler) AddTicketPart(w http.ResponseWriter, r *http.Request) {
     if req.Status == "" {
         req.Status = "pending"
     }
+
+    // Resolve superseded parts and refuse parts that do not fit the equipment
+    partRef := req.SparePartID
+    if partRef == "" {
+        partRef = req.PartNumber
+    }
+    selection, err := h.service.SelectTicketPart(ctx, ticketID, partRef)
+    if err != nil {
+        if errors.Is(err, domain.ErrIncompatiblePart) {
+            h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+            return
+        }
+        h.logger.Warn("Failed to check part compatibility",
+            slog.String("ticket_id", ticketID),
+            slog.String("part", partRef),
+            slog.String("error", err.Error()))
+    }
+    if selection != nil {
+        req.SparePartID = selection.SparePartID
+    }
+    if req.SparePartID == "" {
+        h.respondError(w, http.StatusNotFound, "Spare part not found: "+req.PartNumber)
+        return
+    }
     
     // Insert into ticket_parts table
     const insertQuery = `
@@ -1047,7 +1087,7 @@ func (h *TicketHandler) AddTicketPart(w http.ResponseWriter, r *http.Request) {
     
     var partID string
     var assignedAt time.Time
-    err := h.pool.QueryRow(ctx, insertQuery,
+    err = h.pool.QueryRow(ctx, insertQuery,
         ticketID, req.SparePartID, req.QuantityRequired,
         req.UnitPrice, req.TotalPrice, req.IsCritical,
         req.Status, req.Notes,
@@ -1065,6 +1105,17 @@ func (h *TicketHandler) AddTicketPart(w http.ResponseWriter, r *http.Request) {
     h.logger.Info("Part added to ticket",
         slog.String("ticket_id", ticketID),
         slog.String("part_id", partID))
+
+    // Reserve stock for the part; the part stays attached when none is available
+    var stockWarning string
+    reservation, err := h.service.ReserveTicketPart(ctx, ticketID, partID, req.SparePartID, req.QuantityRequired, req.LocationID, r.Header.Get("X-User-ID"))
+    if err != nil {
+        h.logger.Warn("Failed to reserve stock for ticket part",
+            slog.String("ticket_id", ticketID),
+            slog.String("part_id", partID),
+            slog.String("error", err.Error()))
+        stockWarning = err.Error()
+    }
     
     h.respondJSON(w, http.StatusCreated, map[string]interface{}{
         "id":                partID,
@@ -1077,9 +1128,40 @@ func (h *TicketHandler) AddTicketPart(w http.ResponseWriter, r *http.Request) {
         "status":            req.Status,
         "notes":             req.Notes,
         "assigned_at":       assignedAt,
+        "reservation":       reservation,
+        "stock_warning":     stockWarning,
+        "selection":         selection,
     })
 }
 
+// ListCompatibleParts handles GET /tickets/{id}/compatible-parts?q=
+// Lists the current, non-obsolete parts fitting the ticket's equipment
+func (h *TicketHandler) ListCompatibleParts(w http.ResponseWriter, r *http.Request) {
+	ticketID := chi.URLParam(r, "id")
+	if ticketID == "" {
+		h.respondError(w, http.StatusBadRequest, "Ticket ID is required")
+		return
+	}
+
+	parts, err := h.service.CompatibleTicketParts(r.Context(), ticketID, r.URL.Query().Get("q"))
+	if err != nil {
+		if err == domain.ErrTicketNotFound {
+			h.respondError(w, http.StatusNotFound, "Ticket not found")
+			return
+		}
+		h.logger.Error("Failed to list compatible parts",
+			slog.String("ticket_id", ticketID),
+			slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list compatible parts")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"parts": parts,
+		"total": len(parts),
+	})
+}
+
 // DeleteTicketPart handles DELETE /tickets/{ticket_id}/parts/{part_id}
 // Removes a specific part from the ticket
 func (h *TicketHandler) DeleteTicketPart(w http.ResponseWriter, r *http.Request) {
@@ -1116,6 +1198,14 @@ func (h *TicketHandler) DeleteTicketPart(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	
+	// Give any stock reserved for the part back
+	if err := h.service.ReleaseTicketPart(ctx, partID, r.Header.Get("X-User-ID")); err != nil {
+		h.logger.Error("Failed to release stock for ticket part",
+			slog.String("ticket_id", ticketID),
+			slog.String("part_id", partID),
+			slog.String("error", err.Error()))
+	}
+	
 	h.logger.Info("Ticket part deleted successfully",
 		slog.String("ticket_id", ticketID),
 		slog.String("part_id", partID))
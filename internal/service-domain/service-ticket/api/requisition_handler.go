@@ -0,0 +1,274 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/aby-med/medical-platform/internal/middleware"
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// RequisitionHandler handles HTTP requests for ticket parts requisitions
+type RequisitionHandler struct {
+	service *app.RequisitionService
+	logger  *slog.Logger
+}
+
+// NewRequisitionHandler creates a new parts requisition HTTP handler
+func NewRequisitionHandler(service *app.RequisitionService, logger *slog.Logger) *RequisitionHandler {
+	return &RequisitionHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "requisition_handler")),
+	}
+}
+
+// createRequisitionRequest raises a requisition from a ticket
+type createRequisitionRequest struct {
+	app.CreateRequisitionRequest
+	RequestedBy string `json:"requested_by"`
+}
+
+// CreateRequisition handles POST /tickets/{id}/requisitions
+func (h *RequisitionHandler) CreateRequisition(w http.ResponseWriter, r *http.Request) {
+	var req createRequisitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	requisition, err := h.service.CreateRequisition(r.Context(), chi.URLParam(r, "id"), req.CreateRequisitionRequest, req.RequestedBy)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to raise parts requisition")
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, requisition)
+}
+
+// ListTicketRequisitions handles GET /tickets/{id}/requisitions
+func (h *RequisitionHandler) ListTicketRequisitions(w http.ResponseWriter, r *http.Request) {
+	requisitions, err := h.service.ListTicketRequisitions(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to list parts requisitions")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"requisitions": requisitions, "total": len(requisitions)})
+}
+
+// ListRequisitions handles GET /parts-requisitions?status=requested
+func (h *RequisitionHandler) ListRequisitions(w http.ResponseWriter, r *http.Request) {
+	var status []domain.RequisitionStatus
+	for _, s := range splitQuery(r.URL.Query().Get("status")) {
+		status = append(status, domain.RequisitionStatus(s))
+	}
+
+	requisitions, err := h.service.ListRequisitions(r.Context(), status)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to list parts requisitions")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"requisitions": requisitions, "total": len(requisitions)})
+}
+
+// GetRequisition handles GET /parts-requisitions/{id}
+func (h *RequisitionHandler) GetRequisition(w http.ResponseWriter, r *http.Request) {
+	requisition, err := h.service.GetRequisition(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to get parts requisition")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, requisition)
+}
+
+// requisitionDecisionRequest approves, rejects or cancels a requisition.
+// PerformedBy is only used for cancellation; approvals take the approver
+// from the authenticated user.
+type requisitionDecisionRequest struct {
+	PerformedBy string `json:"performed_by"`
+	Reason      string `json:"reason"`
+}
+
+// approver returns the authenticated user's ID and role. The role comes from
+// the user's token, never from a client header.
+func (h *RequisitionHandler) approver(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	role, ok := middleware.GetUserRole(r.Context())
+	if !ok || role == "" {
+		h.respondError(w, http.StatusForbidden, "authenticated user role required")
+		return "", "", false
+	}
+	userID := r.Header.Get("X-User-ID")
+	if id, ok := middleware.GetUserID(r.Context()); ok {
+		userID = id.String()
+	}
+	if userID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-User-ID header required")
+		return "", "", false
+	}
+	return userID, role, true
+}
+
+// ApproveRequisition handles POST /parts-requisitions/{id}/approve
+func (h *RequisitionHandler) ApproveRequisition(w http.ResponseWriter, r *http.Request) {
+	approvedBy, role, ok := h.approver(w, r)
+	if !ok {
+		return
+	}
+	var req requisitionDecisionRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	requisition, err := h.service.Approve(r.Context(), chi.URLParam(r, "id"), approvedBy, role, req.Reason)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to approve parts requisition")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, requisition)
+}
+
+// RejectRequisition handles POST /parts-requisitions/{id}/reject
+func (h *RequisitionHandler) RejectRequisition(w http.ResponseWriter, r *http.Request) {
+	rejectedBy, role, ok := h.approver(w, r)
+	if !ok {
+		return
+	}
+	var req requisitionDecisionRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	requisition, err := h.service.Reject(r.Context(), chi.URLParam(r, "id"), rejectedBy, role, req.Reason)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to reject parts requisition")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, requisition)
+}
+
+// placeOrderRequest places the internal purchase order with the supplier
+type placeOrderRequest struct {
+	domain.PartsOrder
+	OrderedBy string `json:"ordered_by"`
+}
+
+// PlaceOrder handles POST /parts-requisitions/{id}/order
+func (h *RequisitionHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
+	var req placeOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	requisition, err := h.service.PlaceOrder(r.Context(), chi.URLParam(r, "id"), req.PartsOrder, req.OrderedBy)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to place parts order")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, requisition)
+}
+
+// ShipRequisition handles POST /parts-requisitions/{id}/ship
+func (h *RequisitionHandler) ShipRequisition(w http.ResponseWriter, r *http.Request) {
+	var shipment domain.Shipment
+	if err := json.NewDecoder(r.Body).Decode(&shipment); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	requisition, err := h.service.Ship(r.Context(), chi.URLParam(r, "id"), shipment)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to record parts shipment")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, requisition)
+}
+
+// receiveRequisitionRequest records the parts' arrival
+type receiveRequisitionRequest struct {
+	LocationID string `json:"location_id"` // Optional stock location to book the parts into
+	ReceivedBy string `json:"received_by"`
+}
+
+// ReceiveRequisition handles POST /parts-requisitions/{id}/receive
+func (h *RequisitionHandler) ReceiveRequisition(w http.ResponseWriter, r *http.Request) {
+	var req receiveRequisitionRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	requisition, err := h.service.Receive(r.Context(), chi.URLParam(r, "id"), req.LocationID, req.ReceivedBy)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to receive parts")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, requisition)
+}
+
+// CancelRequisition handles POST /parts-requisitions/{id}/cancel
+func (h *RequisitionHandler) CancelRequisition(w http.ResponseWriter, r *http.Request) {
+	var req requisitionDecisionRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	requisition, err := h.service.Cancel(r.Context(), chi.URLParam(r, "id"), req.PerformedBy, req.Reason)
+	if err != nil {
+		h.respondServiceError(w, err, "Failed to cancel parts requisition")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, requisition)
+}
+
+// decode decodes an optional request body
+func (h *RequisitionHandler) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.ContentLength == 0 {
+		return true
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// respondServiceError maps requisition errors to HTTP status codes
+func (h *RequisitionHandler) respondServiceError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrRequisitionNotFound), errors.Is(err, domain.ErrTicketNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidRequisition):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrRequisitionApprovalRole):
+		h.respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrInvalidRequisitionTransition):
+		h.respondError(w, http.StatusConflict, err.Error())
+	default:
+		h.logger.Error(message, slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, message)
+	}
+}
+
+// respondJSON writes JSON response
+func (h *RequisitionHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondError writes error response
+func (h *RequisitionHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
@@ -15,6 +15,7 @@ type AssignmentService struct {
 	assignRepo domain.EngineerSuggestionRepository
 	ticketRepo domain.TicketRepository
 	pool       *pgxpool.Pool
+	postal     PostalCodeLookup
 	logger     *slog.Logger
 }
 
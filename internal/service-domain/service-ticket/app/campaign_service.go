@@ -0,0 +1,739 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	equipmentDomain "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+)
+
+// campaignScanPageSize is the page size used when scanning the installed base
+const campaignScanPageSize = 500
+
+// softwareVersionKeys are the equipment specification keys holding the installed software version
+var softwareVersionKeys = []string{"software_version", "firmware_version", "sw_version", "software"}
+
+// CampaignService runs recall and field safety campaigns across the installed base
+type CampaignService struct {
+	campaignRepo  domain.CampaignRepository
+	ticketService *TicketService
+	equipmentRepo equipmentDomain.Repository
+	eventRepo     domain.EventRepository
+	emailService  EmailService
+	normalizer    *equipmentDomain.ManufacturerNormalizer
+	logger        *slog.Logger
+}
+
+// NewCampaignService creates a new field safety campaign service
+func NewCampaignService(
+	campaignRepo domain.CampaignRepository,
+	ticketService *TicketService,
+	equipmentRepo equipmentDomain.Repository,
+	eventRepo domain.EventRepository,
+	emailService EmailService,
+	logger *slog.Logger,
+) *CampaignService {
+	return &CampaignService{
+		campaignRepo:  campaignRepo,
+		ticketService: ticketService,
+		equipmentRepo: equipmentRepo,
+		eventRepo:     eventRepo,
+		emailService:  emailService,
+		normalizer:    equipmentDomain.NewManufacturerNormalizer(nil),
+		logger:        logger.With(slog.String("component", "campaign_service")),
+	}
+}
+
+// CreateCampaign creates a draft campaign
+func (s *CampaignService) CreateCampaign(ctx context.Context, campaign *domain.Campaign) (*domain.Campaign, error) {
+	if err := campaign.Validate(); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	campaign.ID = ""
+	campaign.CampaignNumber = domain.GenerateCampaignNumber(campaign.Type)
+	campaign.Status = domain.CampaignStatusDraft
+	campaign.LaunchedAt = nil
+	campaign.CompletedAt = nil
+	campaign.CreatedAt = now
+	campaign.UpdatedAt = now
+
+	if err := s.campaignRepo.Create(ctx, campaign); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Campaign created",
+		slog.String("campaign_id", campaign.ID),
+		slog.String("campaign_number", campaign.CampaignNumber),
+		slog.String("manufacturer", campaign.ManufacturerName))
+	return campaign, nil
+}
+
+// GetCampaign retrieves a campaign with its progress. It has no side
+// effects; campaigns are completed by the unit transitions that finish them.
+func (s *CampaignService) GetCampaign(ctx context.Context, id string) (*domain.Campaign, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Progress, err = s.campaignRepo.Progress(ctx, campaign.ID); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// ListCampaigns lists campaigns, optionally filtered by status
+func (s *CampaignService) ListCampaigns(ctx context.Context, status []domain.CampaignStatus) ([]*domain.Campaign, error) {
+	campaigns, err := s.campaignRepo.List(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range campaigns {
+		if c.Progress, err = s.campaignRepo.Progress(ctx, c.ID); err != nil {
+			return nil, err
+		}
+	}
+	return campaigns, nil
+}
+
+// CancelCampaign cancels a draft or active campaign. Generated tickets are left untouched.
+func (s *CampaignService) CancelCampaign(ctx context.Context, id string) (*domain.Campaign, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status == domain.CampaignStatusCompleted || campaign.Status == domain.CampaignStatusCancelled {
+		return nil, fmt.Errorf("%w: campaign is %s", domain.ErrCampaignNotActive, campaign.Status)
+	}
+	campaign.Status = domain.CampaignStatusCancelled
+	campaign.UpdatedAt = time.Now()
+	if err := s.campaignRepo.Update(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// MatchInstalledBase scans the installed base and returns the units matching
+// the campaign criteria. Decommissioned equipment is excluded.
+func (s *CampaignService) MatchInstalledBase(ctx context.Context, campaign *domain.Campaign) ([]*domain.CampaignUnit, error) {
+	canonical, _ := s.normalizer.Canonical(campaign.Criteria.Manufacturer)
+	now := time.Now()
+	units := []*domain.CampaignUnit{}
+
+	for page := 1; ; page++ {
+		result, err := s.equipmentRepo.List(ctx, equipmentDomain.ListCriteria{
+			Status: []equipmentDomain.EquipmentStatus{
+				equipmentDomain.StatusOperational,
+				equipmentDomain.StatusDown,
+				equipmentDomain.StatusUnderMaintenance,
+			},
+			SortBy:        "serial_number",
+			SortDirection: "asc",
+			Page:          page,
+			PageSize:      campaignScanPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan installed base: %w", err)
+		}
+
+		for _, eq := range result.Equipment {
+			manufacturer, _ := s.normalizer.Canonical(eq.ManufacturerName)
+			candidate := domain.CampaignCandidate{
+				EquipmentID:      eq.ID,
+				SerialNumber:     eq.SerialNumber,
+				ManufacturerName: manufacturer,
+				ModelNumber:      eq.ModelNumber,
+				SoftwareVersion:  softwareVersion(eq),
+				CustomerID:       eq.CustomerID,
+				CustomerName:     eq.CustomerName,
+			}
+			match, ok := campaign.Criteria.Match(candidate, canonical)
+			if !ok {
+				continue
+			}
+			units = append(units, &domain.CampaignUnit{
+				CampaignID:        campaign.ID,
+				EquipmentID:       eq.ID,
+				SerialNumber:      eq.SerialNumber,
+				ModelNumber:       eq.ModelNumber,
+				SoftwareVersion:   candidate.SoftwareVersion,
+				CustomerID:        eq.CustomerID,
+				CustomerName:      eq.CustomerName,
+				MatchReasons:      match.Reasons,
+				VersionUnverified: match.VersionUnverified,
+				Status:            domain.UnitStatusPending,
+				CreatedAt:         now,
+				UpdatedAt:         now,
+			})
+		}
+
+		if page >= result.TotalPages || len(result.Equipment) == 0 {
+			break
+		}
+	}
+	return units, nil
+}
+
+// softwareVersion reads the installed software version from the equipment specifications
+func softwareVersion(eq *equipmentDomain.Equipment) string {
+	for _, key := range softwareVersionKeys {
+		if v, ok := eq.Specifications[key]; ok && v != nil {
+			if s := strings.TrimSpace(fmt.Sprint(v)); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// CampaignPreview summarizes the units a campaign would affect
+type CampaignPreview struct {
+	Units             []*domain.CampaignUnit `json:"units"`
+	TotalUnits        int                    `json:"total_units"`
+	Customers         int                    `json:"customers"`
+	VersionUnverified int                    `json:"version_unverified"`
+}
+
+// PreviewMatches matches the installed base without persisting anything
+func (s *CampaignService) PreviewMatches(ctx context.Context, id string) (*CampaignPreview, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	units, err := s.MatchInstalledBase(ctx, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &CampaignPreview{Units: units, TotalUnits: len(units)}
+	customers := map[string]bool{}
+	for _, u := range units {
+		customers[customerKey(u)] = true
+		if u.VersionUnverified {
+			preview.VersionUnverified++
+		}
+	}
+	preview.Customers = len(customers)
+	return preview, nil
+}
+
+// CampaignLaunchResult summarizes a campaign launch or re-match
+type CampaignLaunchResult struct {
+	Campaign       *domain.Campaign `json:"campaign"`
+	Matched        int              `json:"matched"`
+	UnitsAdded     int              `json:"units_added"`
+	TicketsCreated int              `json:"tickets_created"`
+	TicketErrors   []string         `json:"ticket_errors,omitempty"`
+}
+
+// Launch matches the installed base, records the affected units and, for
+// ticket campaigns, generates a service ticket per unit. Launching an active
+// campaign again adds units registered since the last launch.
+func (s *CampaignService) Launch(ctx context.Context, id, launchedBy string) (*CampaignLaunchResult, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != domain.CampaignStatusDraft && campaign.Status != domain.CampaignStatusActive {
+		return nil, fmt.Errorf("%w: campaign is %s", domain.ErrCampaignNotActive, campaign.Status)
+	}
+
+	units, err := s.MatchInstalledBase(ctx, campaign)
+	if err != nil {
+		return nil, err
+	}
+	added, err := s.campaignRepo.AddUnits(ctx, units)
+	if err != nil {
+		return nil, err
+	}
+
+	firstLaunch := campaign.Status == domain.CampaignStatusDraft
+	if firstLaunch {
+		now := time.Now()
+		campaign.Status = domain.CampaignStatusActive
+		campaign.LaunchedAt = &now
+		campaign.UpdatedAt = now
+		if err := s.campaignRepo.Update(ctx, campaign); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &CampaignLaunchResult{Campaign: campaign, Matched: len(units), UnitsAdded: added}
+	if campaign.Action == domain.CampaignActionTicket {
+		created, errs, err := s.generateTickets(ctx, campaign, launchedBy)
+		if err != nil {
+			return nil, err
+		}
+		result.TicketsCreated = created
+		result.TicketErrors = errs
+	}
+
+	if firstLaunch {
+		s.emitEvent(ctx, domain.EventCampaignLaunched, campaign.ID, map[string]any{
+			"campaign_id":     campaign.ID,
+			"campaign_number": campaign.CampaignNumber,
+			"type":            campaign.Type,
+			"manufacturer":    campaign.ManufacturerName,
+			"units":           len(units),
+		})
+	}
+
+	s.logger.Info("Campaign launched",
+		slog.String("campaign_id", campaign.ID),
+		slog.Int("matched", len(units)),
+		slog.Int("units_added", added),
+		slog.Int("tickets_created", result.TicketsCreated))
+
+	if err := s.refreshProgress(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GenerateTickets creates service tickets for units that have none yet
+func (s *CampaignService) GenerateTickets(ctx context.Context, id, createdBy string) (*CampaignLaunchResult, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != domain.CampaignStatusActive {
+		return nil, domain.ErrCampaignNotActive
+	}
+	created, errs, err := s.generateTickets(ctx, campaign, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	return &CampaignLaunchResult{Campaign: campaign, TicketsCreated: created, TicketErrors: errs}, nil
+}
+
+// generateTickets creates a ticket per open unit without one. Failures are
+// reported per unit so one bad record doesn't stop the campaign.
+func (s *CampaignService) generateTickets(ctx context.Context, campaign *domain.Campaign, createdBy string) (int, []string, error) {
+	units, err := s.campaignRepo.ListUnits(ctx, campaign.ID, domain.CampaignUnitFilter{
+		Status: []domain.CampaignUnitStatus{domain.UnitStatusPending, domain.UnitStatusNotified, domain.UnitStatusScheduled},
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	created := 0
+	var errs []string
+	for _, unit := range units {
+		if unit.TicketID != "" {
+			continue
+		}
+		ticket, err := s.ticketService.CreateTicket(ctx, CreateTicketRequest{
+			EquipmentID:      unit.EquipmentID,
+			SerialNumber:     unit.SerialNumber,
+			EquipmentName:    strings.TrimSpace(campaign.ManufacturerName + " " + unit.ModelNumber),
+			CustomerID:       unit.CustomerID,
+			CustomerName:     unit.CustomerName,
+			IssueCategory:    string(campaign.Type),
+			IssueDescription: campaignTicketDescription(campaign, unit),
+			Priority:         campaign.Priority,
+			Source:           domain.SourceCampaign,
+			SourceMessageID:  campaign.ID,
+			CreatedBy:        createdBy,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", unit.SerialNumber, err))
+			continue
+		}
+
+		unit.TicketID = ticket.ID
+		unit.UpdatedAt = time.Now()
+		if err := s.campaignRepo.UpdateUnit(ctx, unit); err != nil {
+			return created, errs, err
+		}
+		created++
+	}
+	return created, errs, nil
+}
+
+// campaignTicketDescription builds the issue description of a campaign ticket
+func campaignTicketDescription(campaign *domain.Campaign, unit *domain.CampaignUnit) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: %s", strings.ToUpper(string(campaign.Type)), campaign.CampaignNumber, campaign.Title)
+	if campaign.ManufacturerRef != "" {
+		fmt.Fprintf(&b, " (manufacturer ref %s)", campaign.ManufacturerRef)
+	}
+	if campaign.Description != "" {
+		b.WriteString("\n\n" + campaign.Description)
+	}
+	if unit.VersionUnverified {
+		b.WriteString("\n\nSoftware version not on record: verify on site before applying the corrective action.")
+	}
+	return b.String()
+}
+
+// CustomerContact is the recipient of a field safety notice
+type CustomerContact struct {
+	Email string `json:"email"`
+}
+
+// CampaignNotifyResult summarizes a customer notification run
+type CampaignNotifyResult struct {
+	Customers     int                            `json:"customers"`
+	Emailed       int                            `json:"emailed"`
+	Failed        int                            `json:"failed"`
+	UnitsNotified int                            `json:"units_notified"`
+	Notifications []*domain.CampaignNotification `json:"notifications"`
+}
+
+// NotifyCustomers sends the field safety notice to every customer with pending
+// units. Each customer is emailed when a contact is given and an email service
+// is configured; a customer_notified webhook event is always emitted so
+// external channels can deliver the notice too.
+func (s *CampaignService) NotifyCustomers(ctx context.Context, id string, contacts map[string]CustomerContact, sentBy string) (*CampaignNotifyResult, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != domain.CampaignStatusActive {
+		return nil, domain.ErrCampaignNotActive
+	}
+
+	units, err := s.campaignRepo.ListUnits(ctx, campaign.ID, domain.CampaignUnitFilter{
+		Status: []domain.CampaignUnitStatus{domain.UnitStatusPending},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byCustomer := map[string][]*domain.CampaignUnit{}
+	order := []string{}
+	for _, u := range units {
+		key := customerKey(u)
+		if _, ok := byCustomer[key]; !ok {
+			order = append(order, key)
+		}
+		byCustomer[key] = append(byCustomer[key], u)
+	}
+
+	result := &CampaignNotifyResult{Customers: len(order), Notifications: []*domain.CampaignNotification{}}
+	for _, customer := range order {
+		customerUnits := byCustomer[customer]
+		notification := &domain.CampaignNotification{
+			CampaignID: campaign.ID,
+			CustomerID: customer,
+			Channel:    "webhook",
+			UnitCount:  len(customerUnits),
+			Status:     "sent",
+			SentBy:     sentBy,
+		}
+
+		if contact, ok := contacts[customer]; ok && contact.Email != "" && s.emailService != nil {
+			notification.Channel = "email"
+			notification.Recipient = contact.Email
+			subject, body := campaignNoticeEmail(campaign, customerUnits)
+			if err := s.emailService.Send(contact.Email, subject, body); err != nil {
+				notification.Status = "failed"
+				notification.Error = err.Error()
+			}
+		}
+		if err := s.campaignRepo.AddNotification(ctx, notification); err != nil {
+			return nil, err
+		}
+		result.Notifications = append(result.Notifications, notification)
+
+		if notification.Status == "failed" {
+			result.Failed++
+			continue
+		}
+		if notification.Channel == "email" {
+			result.Emailed++
+		}
+
+		serials := make([]string, len(customerUnits))
+		for i, u := range customerUnits {
+			serials[i] = u.SerialNumber
+		}
+		s.emitEvent(ctx, domain.EventCampaignCustomerNotified, campaign.ID, map[string]any{
+			"campaign_id":     campaign.ID,
+			"campaign_number": campaign.CampaignNumber,
+			"title":           campaign.Title,
+			"customer_id":     customer,
+			"customer_name":   customerUnits[0].CustomerName,
+			"serial_numbers":  serials,
+			"instructions":    campaign.CustomerInstructions,
+		})
+
+		for _, u := range customerUnits {
+			if err := u.TransitionTo(domain.UnitStatusNotified, sentBy, false); err != nil {
+				return nil, err
+			}
+			if err := s.campaignRepo.UpdateUnit(ctx, u); err != nil {
+				return nil, err
+			}
+			result.UnitsNotified++
+		}
+	}
+
+	s.logger.Info("Campaign customers notified",
+		slog.String("campaign_id", campaign.ID),
+		slog.Int("customers", result.Customers),
+		slog.Int("emailed", result.Emailed),
+		slog.Int("failed", result.Failed))
+	return result, nil
+}
+
+// campaignNoticeEmail renders the field safety notice for one customer
+func campaignNoticeEmail(campaign *domain.Campaign, units []*domain.CampaignUnit) (string, string) {
+	subject := fmt.Sprintf("Field Safety Notice %s: %s", campaign.CampaignNumber, campaign.Title)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Dear %s,\n\n", units[0].CustomerName)
+	fmt.Fprintf(&b, "%s has issued a field safety notice", campaign.ManufacturerName)
+	if campaign.ManufacturerRef != "" {
+		fmt.Fprintf(&b, " (reference %s)", campaign.ManufacturerRef)
+	}
+	b.WriteString(" that affects the following equipment at your site:\n\n")
+	for _, u := range units {
+		fmt.Fprintf(&b, "  - %s, serial number %s\n", u.ModelNumber, u.SerialNumber)
+	}
+	if campaign.CustomerInstructions != "" {
+		b.WriteString("\n" + campaign.CustomerInstructions + "\n")
+	} else if campaign.Description != "" {
+		b.WriteString("\n" + campaign.Description + "\n")
+	}
+	if campaign.DueDate != nil {
+		fmt.Fprintf(&b, "\nThe corrective action is to be completed by %s.\n", campaign.DueDate.Format("02 Jan 2006"))
+	}
+	b.WriteString("\nOur service team will contact you to schedule the work.\n")
+	return subject, b.String()
+}
+
+// customerKey groups units by customer, falling back to the customer name
+func customerKey(u *domain.CampaignUnit) string {
+	if u.CustomerID != "" {
+		return u.CustomerID
+	}
+	return u.CustomerName
+}
+
+// ListUnits lists the units of a campaign
+func (s *CampaignService) ListUnits(ctx context.Context, id string, filter domain.CampaignUnitFilter) ([]*domain.CampaignUnit, error) {
+	if _, err := s.campaignRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.campaignRepo.ListUnits(ctx, id, filter)
+}
+
+// GetUnit retrieves a unit with its evidence
+func (s *CampaignService) GetUnit(ctx context.Context, campaignID, unitID string) (*domain.CampaignUnit, error) {
+	return s.campaignRepo.GetUnit(ctx, campaignID, unitID)
+}
+
+// UpdateUnitStatusRequest changes the remediation status of a unit
+type UpdateUnitStatusRequest struct {
+	Status          domain.CampaignUnitStatus `json:"status"`
+	Notes           string                    `json:"notes"`
+	SoftwareVersion string                    `json:"software_version"` // Version verified on site
+	UpdatedBy       string                    `json:"updated_by"`
+}
+
+// UpdateUnitStatus records remediation progress of one unit
+func (s *CampaignService) UpdateUnitStatus(ctx context.Context, campaignID, unitID string, req UpdateUnitStatusRequest) (*domain.CampaignUnit, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != domain.CampaignStatusActive {
+		return nil, domain.ErrCampaignNotActive
+	}
+	unit, err := s.campaignRepo.GetUnit(ctx, campaignID, unitID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.transitionUnit(ctx, campaign, unit, req.Status, req.UpdatedBy, func(u *domain.CampaignUnit) {
+		if req.Notes != "" {
+			u.Notes = req.Notes
+		}
+		if req.SoftwareVersion != "" {
+			u.SoftwareVersion = req.SoftwareVersion
+			u.VersionUnverified = false
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return unit, nil
+}
+
+// transitionUnit applies a status change, persists the unit and emits completion events
+func (s *CampaignService) transitionUnit(ctx context.Context, campaign *domain.Campaign, unit *domain.CampaignUnit, status domain.CampaignUnitStatus, by string, apply func(*domain.CampaignUnit)) error {
+	if err := unit.TransitionTo(status, by, campaign.RequireEvidence); err != nil {
+		return err
+	}
+	if apply != nil {
+		apply(unit)
+	}
+	unit.UpdatedAt = time.Now()
+	if err := s.campaignRepo.UpdateUnit(ctx, unit); err != nil {
+		return err
+	}
+
+	if status == domain.UnitStatusCompleted {
+		s.emitEvent(ctx, domain.EventCampaignUnitCompleted, campaign.ID, map[string]any{
+			"campaign_id":   campaign.ID,
+			"unit_id":       unit.ID,
+			"equipment_id":  unit.EquipmentID,
+			"serial_number": unit.SerialNumber,
+			"customer_id":   unit.CustomerID,
+			"completed_by":  unit.CompletedBy,
+		})
+	}
+	return s.refreshProgress(ctx, campaign)
+}
+
+// AddEvidenceRequest attaches proof of remediation to a unit
+type AddEvidenceRequest struct {
+	AttachmentID string `json:"attachment_id"`
+	URL          string `json:"url"`
+	Description  string `json:"description"`
+	UploadedBy   string `json:"uploaded_by"`
+}
+
+// AddEvidence attaches evidence to a unit
+func (s *CampaignService) AddEvidence(ctx context.Context, campaignID, unitID string, req AddEvidenceRequest) (*domain.CampaignEvidence, error) {
+	if req.AttachmentID == "" && req.URL == "" {
+		return nil, fmt.Errorf("%w: attachment_id or url is required", domain.ErrInvalidCampaign)
+	}
+	unit, err := s.campaignRepo.GetUnit(ctx, campaignID, unitID)
+	if err != nil {
+		return nil, err
+	}
+	evidence := &domain.CampaignEvidence{
+		UnitID:       unit.ID,
+		AttachmentID: req.AttachmentID,
+		URL:          req.URL,
+		Description:  req.Description,
+		UploadedBy:   req.UploadedBy,
+	}
+	if err := s.campaignRepo.AddEvidence(ctx, evidence); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
+// CampaignSyncResult summarizes a ticket status synchronization
+type CampaignSyncResult struct {
+	Checked   int      `json:"checked"`
+	Completed int      `json:"completed"`
+	Started   int      `json:"started"`
+	Skipped   []string `json:"skipped,omitempty"` // Units that could not be completed, e.g. missing evidence
+}
+
+// SyncTicketStatuses moves units along with their campaign tickets: a ticket
+// in progress starts the unit and a resolved or closed ticket completes it.
+func (s *CampaignService) SyncTicketStatuses(ctx context.Context, id string) (*CampaignSyncResult, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != domain.CampaignStatusActive {
+		return nil, domain.ErrCampaignNotActive
+	}
+
+	units, err := s.campaignRepo.ListUnits(ctx, campaign.ID, domain.CampaignUnitFilter{
+		Status: []domain.CampaignUnitStatus{domain.UnitStatusPending, domain.UnitStatusNotified, domain.UnitStatusScheduled, domain.UnitStatusInProgress},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CampaignSyncResult{}
+	for _, unit := range units {
+		if unit.TicketID == "" {
+			continue
+		}
+		ticket, err := s.ticketService.GetTicket(ctx, unit.TicketID)
+		if err != nil {
+			continue
+		}
+		result.Checked++
+
+		var next domain.CampaignUnitStatus
+		switch ticket.Status {
+		case domain.StatusResolved, domain.StatusClosed:
+			next = domain.UnitStatusCompleted
+		case domain.StatusInProgress:
+			next = domain.UnitStatusInProgress
+		default:
+			continue
+		}
+		if next == unit.Status {
+			continue
+		}
+
+		if next == domain.UnitStatusCompleted {
+			if unit.Evidence, err = s.campaignRepo.ListEvidence(ctx, unit.ID); err != nil {
+				return nil, err
+			}
+		}
+		by := ticket.AssignedEngineerName
+		if err := s.transitionUnit(ctx, campaign, unit, next, by, nil); err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %v", unit.SerialNumber, err))
+			continue
+		}
+		if next == domain.UnitStatusCompleted {
+			result.Completed++
+		} else {
+			result.Started++
+		}
+	}
+	return result, nil
+}
+
+// ListNotifications lists the notices sent for a campaign
+func (s *CampaignService) ListNotifications(ctx context.Context, id string) ([]*domain.CampaignNotification, error) {
+	return s.campaignRepo.ListNotifications(ctx, id)
+}
+
+// ListEquipmentCampaigns lists the campaign units affecting one equipment
+func (s *CampaignService) ListEquipmentCampaigns(ctx context.Context, equipmentID string) ([]*domain.CampaignUnit, error) {
+	return s.campaignRepo.ListUnitsForEquipment(ctx, equipmentID)
+}
+
+// refreshProgress loads progress and completes an active campaign without outstanding units
+func (s *CampaignService) refreshProgress(ctx context.Context, campaign *domain.Campaign) error {
+	progress, err := s.campaignRepo.Progress(ctx, campaign.ID)
+	if err != nil {
+		return err
+	}
+	campaign.Progress = progress
+
+	if campaign.Status != domain.CampaignStatusActive || progress.TotalUnits == 0 || progress.Outstanding > 0 {
+		return nil
+	}
+	now := time.Now()
+	campaign.Status = domain.CampaignStatusCompleted
+	campaign.CompletedAt = &now
+	campaign.UpdatedAt = now
+	if err := s.campaignRepo.Update(ctx, campaign); err != nil {
+		return err
+	}
+	s.emitEvent(ctx, domain.EventCampaignCompleted, campaign.ID, map[string]any{
+		"campaign_id":        campaign.ID,
+		"campaign_number":    campaign.CampaignNumber,
+		"completed_units":    progress.Completed,
+		"completion_percent": progress.CompletionPercent,
+	})
+	s.logger.Info("Campaign completed", slog.String("campaign_id", campaign.ID))
+	return nil
+}
+
+// emitEvent is a best-effort outbox writer (no-op if repo is nil)
+func (s *CampaignService) emitEvent(ctx context.Context, eventType, aggregateID string, payload map[string]any) {
+	if s.eventRepo == nil {
+		return
+	}
+	b, _ := json.Marshal(payload)
+	if id, err := s.eventRepo.CreateEvent(ctx, eventType, "campaign", aggregateID, b); err == nil {
+		_ = s.eventRepo.EnqueueDeliveriesForEvent(ctx, id, eventType)
+	}
+}
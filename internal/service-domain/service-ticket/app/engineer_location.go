@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+)
+
+// PostalCodeLookup resolves postal codes through the gazetteer
+type PostalCodeLookup interface {
+	LookupPostalCode(ctx context.Context, country, postalCode string) (geo.PostalPlace, bool, error)
+}
+
+// SetPostalCodeLookup sets the gazetteer used to geocode engineer base postal codes
+func (s *AssignmentService) SetPostalCodeLookup(lookup PostalCodeLookup) {
+	s.postal = lookup
+}
+
+// EngineerBaseLocationRequest sets an engineer's home base, either as
+// coordinates or as a postal code geocoded through the gazetteer
+type EngineerBaseLocationRequest struct {
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+	PostalCode string   `json:"postal_code,omitempty"`
+	Country    string   `json:"country,omitempty"`
+}
+
+// UpdateEngineerBaseLocation sets an engineer's base location. A request
+// without coordinates or postal code clears it.
+func (s *AssignmentService) UpdateEngineerBaseLocation(ctx context.Context, engineerID string, req EngineerBaseLocationRequest) (*domain.Engineer, error) {
+	postalCode := strings.TrimSpace(req.PostalCode)
+
+	var location *geo.Point
+	switch {
+	case req.Latitude != nil || req.Longitude != nil:
+		if req.Latitude == nil || req.Longitude == nil {
+			return nil, fmt.Errorf("%w: latitude and longitude must be set together", domain.ErrInvalidLocation)
+		}
+		location = &geo.Point{Lat: *req.Latitude, Lng: *req.Longitude}
+		if !location.Valid() {
+			return nil, fmt.Errorf("%w: %s", domain.ErrInvalidLocation, location)
+		}
+	case postalCode != "":
+		if s.postal == nil {
+			return nil, domain.ErrPostalCodeNotFound
+		}
+		place, ok, err := s.postal.LookupPostalCode(ctx, req.Country, postalCode)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrPostalCodeNotFound, postalCode)
+		}
+		location = &place.Point
+		postalCode = place.PostalCode
+	}
+
+	if err := s.assignRepo.UpdateEngineerBaseLocation(ctx, engineerID, location, postalCode); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Engineer base location updated",
+		slog.String("engineer_id", engineerID),
+		slog.String("postal_code", postalCode))
+	return s.assignRepo.GetEngineerByID(ctx, engineerID)
+}
+
+// ReportEngineerLocation records an engineer's current position, e.g. from the mobile app.
+// Reports older than the stored position are ignored.
+func (s *AssignmentService) ReportEngineerLocation(ctx context.Context, engineerID string, location geo.Point, at time.Time) error {
+	if !location.Valid() {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidLocation, location)
+	}
+	now := time.Now()
+	if at.IsZero() || at.After(now) {
+		at = now
+	}
+	return s.assignRepo.UpdateEngineerCurrentLocation(ctx, engineerID, location, at)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
 	equipmentDomain "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
 	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -16,6 +17,7 @@ type MultiModelAssignmentService struct {
 	ticketRepo    domain.TicketRepository
 	equipmentRepo equipmentDomain.Repository
 	pool          *pgxpool.Pool
+	bands         geo.Bands
 	logger        *slog.Logger
 }
 
@@ -32,6 +34,7 @@ func NewMultiModelAssignmentService(
 		ticketRepo:    ticketRepo,
 		equipmentRepo: equipmentRepo,
 		pool:          pool,
+		bands:         geo.DefaultBands(),
 		logger:        logger.With(slog.String("component", "multi_model_assignment")),
 	}
 }
@@ -158,9 +161,13 @@ func (s *MultiModelAssignmentService) GetMultiModelSuggestions(ctx context.Conte
 		ModelNumber:  equipment.ModelNumber,
 		Location: &Location{
 			Region:  equipment.InstallationLocation,
-			Address: "", // TODO: extract from installation_address map
+			Address: formatInstallationAddress(equipment.InstallationAddress),
 		},
 	}
+	if site, ok := equipment.InstallationPoint(); ok {
+		equipmentCtx.Location.Lat = &site.Lat
+		equipmentCtx.Location.Lng = &site.Lng
+	}
 	
 	minLevel := s.getMinLevelFromPriority(ticket.Priority)
 	ticketCtx := &TicketContext{
@@ -208,7 +215,7 @@ func (s *MultiModelAssignmentService) GetMultiModelSuggestions(ctx context.Conte
 	modelResults["high_seniority"] = s.getHighSeniorityModel(allEngineers, equipment)
 	
 	// Model 6: Geographic Proximity (if location data available)
-	// modelResults["geographic_proximity"] = s.getGeographicModel(allEngineers, equipment)
+	modelResults["geographic_proximity"] = s.getGeographicModel(allEngineers, equipment, minLevel)
 	
 	// 8. Get tier information
 	tierInfo := s.getTierInformation(ctx, allEngineers)
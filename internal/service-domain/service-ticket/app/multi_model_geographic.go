@@ -0,0 +1,116 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
+	equipmentDomain "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+)
+
+const (
+	// currentLocationMaxAge is how long a reported engineer position is trusted over the base location
+	currentLocationMaxAge = geo.CurrentLocationMaxAge
+
+	// averageTravelSpeedKmh is the average road speed used for travel time estimates
+	averageTravelSpeedKmh = 40.0
+)
+
+// SetRadiusBands configures the distance bands used to score geographic proximity
+func (s *MultiModelAssignmentService) SetRadiusBands(bands geo.Bands) {
+	s.bands = bands
+}
+
+// getGeographicModel ranks engineers by straight-line distance from their
+// current (or base) location to the installation site
+func (s *MultiModelAssignmentService) getGeographicModel(
+	engineers []*domain.Engineer,
+	equipment *equipmentDomain.Equipment,
+	minLevel int,
+) *AssignmentModel {
+	model := &AssignmentModel{
+		ModelName:   "Geographic Proximity",
+		Description: "Engineers closest to the installation site by travel distance",
+		Engineers:   []*EngineerSuggestion{},
+	}
+
+	site, ok := equipment.InstallationPoint()
+	if !ok {
+		model.Description += " (installation site has no coordinates)"
+		return model
+	}
+
+	now := time.Now()
+	for _, eng := range engineers {
+		level := getLevelInt(eng.EngineerLevel)
+		if level < minLevel {
+			continue
+		}
+
+		position, source, ok := eng.Position(now, currentLocationMaxAge)
+		if !ok {
+			continue
+		}
+
+		distance := geo.DistanceKm(position, site)
+		distanceKm := math.Round(distance*10) / 10
+		travelMins := geo.TravelMinutes(distance, averageTravelSpeedKmh)
+		band, _ := s.bands.Classify(distance)
+
+		reasons := []string{
+			fmt.Sprintf("%.1f km from site (%s band)", distanceKm, band.Name),
+			fmt.Sprintf("About %d min travel", travelMins),
+		}
+		if source == domain.EngineerLocationCurrent {
+			reasons = append(reasons, fmt.Sprintf("Current location reported %s ago", now.Sub(*eng.CurrentLocationAt).Round(time.Minute)))
+		} else {
+			reasons = append(reasons, "Measured from base location")
+		}
+		reasons = append(reasons, fmt.Sprintf("Level %d engineer", level))
+
+		model.Engineers = append(model.Engineers, &EngineerSuggestion{
+			ID:               eng.ID,
+			Name:             eng.Name,
+			Email:            eng.Email,
+			Phone:            eng.Phone,
+			EngineerLevel:    level,
+			HomeRegion:       eng.HomeRegion,
+			OrganizationID:   eng.OrganizationID,
+			OrganizationName: eng.OrganizationName,
+			MatchScore:       int(math.Round(band.Score)),
+			MatchReasons:     reasons,
+			DistanceKm:       &distanceKm,
+			TravelTimeMins:   &travelMins,
+		})
+	}
+
+	// Sort by distance ascending
+	sort.SliceStable(model.Engineers, func(i, j int) bool {
+		return *model.Engineers[i].DistanceKm < *model.Engineers[j].DistanceKm
+	})
+
+	// Return top 10
+	if len(model.Engineers) > 10 {
+		model.Engineers = model.Engineers[:10]
+	}
+	model.Count = len(model.Engineers)
+	return model
+}
+
+// installationAddressKeys are the installation address fields shown, in order
+var installationAddressKeys = []string{"line1", "street", "address", "line2", "area", "city", "district", "state", "postal_code", "pincode", "zip", "country"}
+
+// formatInstallationAddress renders the installation address map as a single line
+func formatInstallationAddress(address map[string]interface{}) string {
+	parts := []string{}
+	for _, key := range installationAddressKeys {
+		if v, ok := address[key].(string); ok && strings.TrimSpace(v) != "" {
+			parts = append(parts, strings.TrimSpace(v))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -23,6 +23,7 @@ type NotificationService struct {
 	ticketRepo       domain.TicketRepository
 	notificationRepo domain.NotificationRepository
 	emailService     EmailService
+	timelineService  *TimelineService
 	logger           *slog.Logger
 	baseURL          string
 }
@@ -62,6 +63,11 @@ func NewNotificationService(
 	}
 }
 
+// SetTimelineService adds the ticket timeline to the public tracking view
+func (s *NotificationService) SetTimelineService(timelineService *TimelineService) {
+	s.timelineService = timelineService
+}
+
 // SendManualEmail sends a manual email notification for a ticket
 // TODO: Implement when email service and customer_email field are available
 func (s *NotificationService) SendManualEmail(ctx context.Context, ticketID string, includeComments bool) error {
@@ -204,6 +210,15 @@ func (s *NotificationService) GetPublicTicketView(ctx context.Context, token str
 		AssignedEngineer: ticket.AssignedEngineerName,
 	}
 
+	// Add milestones, parts status and ETAs
+	if s.timelineService != nil {
+		if timeline, err := s.timelineService.GenerateTimeline(ctx, ticket); err == nil {
+			publicView.Timeline = s.timelineService.ConvertToPublicTimeline(timeline, ticket)
+		} else {
+			s.logger.Warn("Failed to generate timeline for public view", slog.String("error", err.Error()))
+		}
+	}
+
 	return publicView, nil
 }
 
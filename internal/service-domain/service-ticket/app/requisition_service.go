@@ -0,0 +1,229 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+)
+
+// CreateRequisitionRequest raises a parts requisition from a ticket
+type CreateRequisitionRequest struct {
+	Urgent bool                     `json:"urgent"`
+	Reason string                   `json:"reason"`
+	Lines  []domain.RequisitionLine `json:"lines"`
+}
+
+// RequisitionService runs the parts requisition workflow of tickets: request,
+// approval, internal purchase order, shipment and receipt
+type RequisitionService struct {
+	repo          domain.RequisitionRepository
+	ticketService *TicketService
+	eventRepo     domain.EventRepository
+	logger        *slog.Logger
+}
+
+// NewRequisitionService creates a new parts requisition service
+func NewRequisitionService(
+	repo domain.RequisitionRepository,
+	ticketService *TicketService,
+	eventRepo domain.EventRepository,
+	logger *slog.Logger,
+) *RequisitionService {
+	return &RequisitionService{
+		repo:          repo,
+		ticketService: ticketService,
+		eventRepo:     eventRepo,
+		logger:        logger.With(slog.String("component", "requisition_service")),
+	}
+}
+
+// CreateRequisition raises a requisition for a ticket that is still being worked on
+func (s *RequisitionService) CreateRequisition(ctx context.Context, ticketID string, req CreateRequisitionRequest, requestedBy string) (*domain.PartsRequisition, error) {
+	ticket, err := s.ticketService.GetTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	switch ticket.Status {
+	case domain.StatusResolved, domain.StatusClosed, domain.StatusCancelled:
+		return nil, fmt.Errorf("%w: ticket is %s", domain.ErrInvalidRequisition, ticket.Status)
+	}
+
+	existing, err := s.repo.ListByTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	requisition := &domain.PartsRequisition{
+		RequisitionNumber: domain.GenerateRequisitionNumber(ticket.TicketNumber, len(existing)+1),
+		TicketID:          ticket.ID,
+		Status:            domain.RequisitionRequested,
+		Urgent:            req.Urgent,
+		Reason:            req.Reason,
+		Lines:             req.Lines,
+		RequestedBy:       requestedBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := requisition.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, requisition); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Parts requisition raised",
+		slog.String("ticket_id", ticketID),
+		slog.String("requisition_number", requisition.RequisitionNumber),
+		slog.Int("lines", len(requisition.Lines)))
+	s.emitEvent(ctx, domain.EventRequisitionRequested, requisition)
+	return requisition, nil
+}
+
+// GetRequisition retrieves a requisition
+func (s *RequisitionService) GetRequisition(ctx context.Context, id string) (*domain.PartsRequisition, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListTicketRequisitions retrieves a ticket's requisitions, oldest first
+func (s *RequisitionService) ListTicketRequisitions(ctx context.Context, ticketID string) ([]*domain.PartsRequisition, error) {
+	return s.repo.ListByTicket(ctx, ticketID)
+}
+
+// ListRequisitions retrieves requisitions, e.g. those awaiting approval
+func (s *RequisitionService) ListRequisitions(ctx context.Context, status []domain.RequisitionStatus) ([]*domain.PartsRequisition, error) {
+	return s.repo.List(ctx, status)
+}
+
+// Approve records the service manager's approval
+func (s *RequisitionService) Approve(ctx context.Context, id, approvedBy, role, notes string) (*domain.PartsRequisition, error) {
+	return s.transition(ctx, id, domain.EventRequisitionApproved, func(r *domain.PartsRequisition, now time.Time) error {
+		return r.Approve(approvedBy, role, notes, now)
+	})
+}
+
+// Reject records the service manager's rejection
+func (s *RequisitionService) Reject(ctx context.Context, id, rejectedBy, role, reason string) (*domain.PartsRequisition, error) {
+	return s.transition(ctx, id, domain.EventRequisitionRejected, func(r *domain.PartsRequisition, now time.Time) error {
+		return r.Reject(rejectedBy, role, reason, now)
+	})
+}
+
+// PlaceOrder places the internal purchase order for an approved requisition
+// with the supplier
+func (s *RequisitionService) PlaceOrder(ctx context.Context, id string, order domain.PartsOrder, orderedBy string) (*domain.PartsRequisition, error) {
+	requisition, err := s.transition(ctx, id, domain.EventRequisitionOrdered, func(r *domain.PartsRequisition, now time.Time) error {
+		return r.PlaceOrder(order, domain.OrderNumberFor(r.RequisitionNumber), orderedBy, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.comment(ctx, requisition, "Required parts have been ordered from the supplier"+s.expected(requisition)+".")
+	return requisition, nil
+}
+
+// Ship records the supplier's dispatch, or updates the tracking details and
+// ETA of a shipped requisition
+func (s *RequisitionService) Ship(ctx context.Context, id string, shipment domain.Shipment) (*domain.PartsRequisition, error) {
+	requisition, err := s.transition(ctx, id, domain.EventRequisitionShipped, func(r *domain.PartsRequisition, now time.Time) error {
+		return r.Ship(shipment, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.comment(ctx, requisition, "Required parts are on their way"+s.expected(requisition)+".")
+	return requisition, nil
+}
+
+// Receive records that the parts arrived. With a location the parts are
+// booked into that stock location first.
+func (s *RequisitionService) Receive(ctx context.Context, id, locationID, receivedBy string) (*domain.PartsRequisition, error) {
+	requisition, err := s.transition(ctx, id, domain.EventRequisitionReceived, func(r *domain.PartsRequisition, now time.Time) error {
+		if err := r.Receive(receivedBy, locationID, now); err != nil {
+			return err
+		}
+		if locationID == "" {
+			return nil
+		}
+		if s.ticketService.partsStock == nil {
+			return fmt.Errorf("%w: no parts stock is configured to receive into", domain.ErrInvalidRequisition)
+		}
+		return s.ticketService.partsStock.ReceiveParts(ctx, locationID, r.RequisitionNumber, r.Lines, receivedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.comment(ctx, requisition, "Required parts have arrived. Our engineer will complete the repair.")
+	return requisition, nil
+}
+
+// Cancel withdraws a requisition that has not been received yet
+func (s *RequisitionService) Cancel(ctx context.Context, id, cancelledBy, reason string) (*domain.PartsRequisition, error) {
+	return s.transition(ctx, id, domain.EventRequisitionCancelled, func(r *domain.PartsRequisition, now time.Time) error {
+		return r.Cancel(cancelledBy, reason, now)
+	})
+}
+
+// transition loads a requisition, applies one workflow step and saves it
+func (s *RequisitionService) transition(ctx context.Context, id, eventType string, step func(*domain.PartsRequisition, time.Time) error) (*domain.PartsRequisition, error) {
+	requisition, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := step(requisition, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, requisition); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Parts requisition updated",
+		slog.String("ticket_id", requisition.TicketID),
+		slog.String("requisition_number", requisition.RequisitionNumber),
+		slog.String("status", string(requisition.Status)))
+	s.emitEvent(ctx, eventType, requisition)
+	return requisition, nil
+}
+
+// comment adds a customer-visible note to the ticket; failures are logged only
+func (s *RequisitionService) comment(ctx context.Context, requisition *domain.PartsRequisition, text string) {
+	err := s.ticketService.AddComment(ctx, AddCommentRequest{
+		TicketID:    requisition.TicketID,
+		CommentType: "system",
+		AuthorName:  "System",
+		Comment:     text,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to add parts requisition comment",
+			slog.String("ticket_id", requisition.TicketID),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (s *RequisitionService) expected(requisition *domain.PartsRequisition) string {
+	if requisition.ExpectedDelivery == nil {
+		return ""
+	}
+	return ", expected by " + requisition.ExpectedDelivery.Format("Jan 2, 2006")
+}
+
+// emitEvent is a best-effort outbox writer (no-op if repo is nil)
+func (s *RequisitionService) emitEvent(ctx context.Context, eventType string, requisition *domain.PartsRequisition) {
+	if s.eventRepo == nil {
+		return
+	}
+	b, _ := json.Marshal(map[string]any{
+		"requisition_id":     requisition.ID,
+		"requisition_number": requisition.RequisitionNumber,
+		"status":             requisition.Status,
+		"order_number":       requisition.OrderNumber,
+		"expected_delivery":  requisition.ExpectedDelivery,
+	})
+	if id, err := s.eventRepo.CreateEvent(ctx, eventType, "ticket", requisition.TicketID, b); err == nil {
+		_ = s.eventRepo.EnqueueDeliveriesForEvent(ctx, id, eventType)
+	}
+}
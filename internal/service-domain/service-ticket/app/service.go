@@ -18,6 +18,8 @@ type TicketService struct {
 	equipmentRepo  equipmentDomain.Repository
     policyRepo     ticketDomain.PolicyRepository
     eventRepo      ticketDomain.EventRepository
+	partsStock     ticketDomain.PartsStock
+	partsCompat    ticketDomain.PartsCompatibility
 	logger         *slog.Logger
 	defaultSLA     SLAConfig
 }
@@ -66,6 +68,17 @@ func NewTicketService(
 	}
 }
 
+// SetPartsStock sets the spare parts stock that parts attached to tickets are reserved from
+func (s *TicketService) SetPartsStock(stock ticketDomain.PartsStock) {
+	s.partsStock = stock
+}
+
+// SetPartsCompatibility sets the compatibility graph that parts attached to
+// tickets are checked against
+func (s *TicketService) SetPartsCompatibility(compat ticketDomain.PartsCompatibility) {
+	s.partsCompat = compat
+}
+
 // CreateTicket creates a new service ticket
 func (s *TicketService) CreateTicket(ctx context.Context, req CreateTicketRequest) (*ticketDomain.ServiceTicket, error) {
 	s.logger.Info("Creating service ticket",
@@ -510,6 +523,19 @@ func (s *TicketService) ResolveTicket(ctx context.Context, ticketID string, req
 		return err
 	}
 
+	// Take the parts reserved for the ticket out of stock before saving the
+	// resolution. Consumption only affects open reservations, so resolving
+	// again after a failure is safe.
+	if s.partsStock != nil {
+		consumed, err := s.partsStock.ConsumeTicket(ctx, ticketID, req.ResolvedBy)
+		if err != nil {
+			return fmt.Errorf("failed to consume reserved parts: %w", err)
+		}
+		if consumed > 0 {
+			s.logger.Info("Reserved parts consumed", slog.String("ticket_id", ticketID), slog.Int("reservations", consumed))
+		}
+	}
+
 	if err := s.repo.Update(ctx, ticket); err != nil {
 		return err
 	}
@@ -602,6 +628,15 @@ func (s *TicketService) CancelTicket(ctx context.Context, ticketID, reason, canc
 	}
 	s.repo.AddStatusHistory(ctx, history)
 
+	// Give the parts reserved for the ticket back to stock
+	if s.partsStock != nil {
+		if err := s.partsStock.ReleaseTicket(ctx, ticketID, cancelledBy); err != nil {
+			s.logger.Error("Failed to release reserved parts",
+				slog.String("ticket_id", ticketID),
+				slog.String("error", err.Error()))
+		}
+	}
+
     // Emit event: ticket.cancelled
     s.emitEvent(ctx, ticketDomain.EventTicketCancelled, "ticket", ticketID, map[string]any{"reason": reason})
 	return nil
@@ -674,6 +709,54 @@ func (s *TicketService) UpdateParts(ctx context.Context, ticketID string, parts
 	return nil
 }
 
+// ReserveTicketPart reserves stock for a part attached to a ticket, from the
+// given location or else preferring the assigned engineer's van. It returns
+// nil when no parts stock is configured.
+func (s *TicketService) ReserveTicketPart(ctx context.Context, ticketID, ticketPartID, sparePartID string, quantity int, locationID, reservedBy string) (*ticketDomain.PartReservation, error) {
+	if s.partsStock == nil {
+		return nil, nil
+	}
+	ticket, err := s.repo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return s.partsStock.ReservePart(ctx, ticketID, ticketPartID, sparePartID, quantity, ticket.AssignedEngineerID, locationID, reservedBy)
+}
+
+// ReleaseTicketPart gives the stock reserved for a part removed from a ticket back
+func (s *TicketService) ReleaseTicketPart(ctx context.Context, ticketPartID, releasedBy string) error {
+	if s.partsStock == nil {
+		return nil
+	}
+	return s.partsStock.ReleasePart(ctx, ticketPartID, releasedBy)
+}
+
+// SelectTicketPart resolves a requested part ID or number to the current,
+// non-obsolete part fitting the ticket's equipment. It returns nil when no
+// compatibility graph is configured.
+func (s *TicketService) SelectTicketPart(ctx context.Context, ticketID, partRef string) (*ticketDomain.PartSelection, error) {
+	if s.partsCompat == nil {
+		return nil, nil
+	}
+	ticket, err := s.repo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return s.partsCompat.SelectPart(ctx, partRef, ticket.EquipmentID)
+}
+
+// CompatibleTicketParts lists the current parts fitting the ticket's equipment
+func (s *TicketService) CompatibleTicketParts(ctx context.Context, ticketID, query string) ([]*ticketDomain.PartSelection, error) {
+	ticket, err := s.repo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if s.partsCompat == nil || ticket.EquipmentID == "" {
+		return []*ticketDomain.PartSelection{}, nil
+	}
+	return s.partsCompat.CompatibleParts(ctx, ticket.EquipmentID, query)
+}
+
 // emitEvent is a best-effort outbox writer (no-op if repo is nil)
 func (s *TicketService) emitEvent(ctx context.Context, eventType, aggregateType, aggregateID string, payload map[string]any) {
     if s.eventRepo == nil { return }
@@ -27,6 +27,8 @@ func (f *fakeTicketRepo) AddComment(ctx context.Context, c *ticketDomain.TicketC
 func (f *fakeTicketRepo) GetComments(ctx context.Context, id string) ([]*ticketDomain.TicketComment, error) { return nil, nil }
 func (f *fakeTicketRepo) AddStatusHistory(ctx context.Context, h *ticketDomain.StatusHistory) error { return nil }
 func (f *fakeTicketRepo) GetStatusHistory(ctx context.Context, id string) ([]*ticketDomain.StatusHistory, error) { return nil, nil }
+func (f *fakeTicketRepo) DeleteComment(ctx context.Context, commentID string, ticketID string) error { return nil }
+func (f *fakeTicketRepo) UpdateTicketParts(ctx context.Context, ticketID string, parts []map[string]interface{}) error { return nil }
 
 type fakeEquipRepo struct{}
 func (f *fakeEquipRepo) Create(ctx context.Context, e *equipmentDomain.Equipment) error { return nil }
@@ -38,6 +40,10 @@ func (f *fakeEquipRepo) Update(ctx context.Context, e *equipmentDomain.Equipment
 func (f *fakeEquipRepo) Delete(ctx context.Context, id string) error { return nil }
 func (f *fakeEquipRepo) BulkCreate(ctx context.Context, equipment []*equipmentDomain.Equipment) error { return nil }
 func (f *fakeEquipRepo) UpdateQRCode(ctx context.Context, equipmentID string, qrImage []byte, format string) error { return nil }
+func (f *fakeEquipRepo) SetQRCodeByID(ctx context.Context, id, qrCode, qrURL string) error { return nil }
+func (f *fakeEquipRepo) SetQRCodeBySerial(ctx context.Context, serial, qrCode, qrURL string) error { return nil }
+func (f *fakeEquipRepo) GetBySerialNumbers(ctx context.Context, scope equipmentDomain.OrgScope, serialNumbers []string) (map[string]*equipmentDomain.Equipment, error) { return map[string]*equipmentDomain.Equipment{}, nil }
+func (f *fakeEquipRepo) BulkUpsert(ctx context.Context, scope equipmentDomain.OrgScope, creates, updates []*equipmentDomain.Equipment) error { return nil }
 
 type fakePolicyRepo struct{ rules *ticketDomain.SLARules; respOrg *string }
 func (f *fakePolicyRepo) GetDefaultResponsibleOrg(ctx context.Context) (*string, error) { return f.respOrg, nil }
@@ -10,8 +10,9 @@ import (
 )
 
 type TimelineService struct {
-	ticketRepo ticketDomain.TicketRepository
-	logger     *slog.Logger
+	ticketRepo      ticketDomain.TicketRepository
+	requisitionRepo ticketDomain.RequisitionRepository
+	logger          *slog.Logger
 }
 
 func NewTimelineService(ticketRepo ticketDomain.TicketRepository, logger *slog.Logger) *TimelineService {
@@ -21,21 +22,39 @@ func NewTimelineService(ticketRepo ticketDomain.TicketRepository, logger *slog.L
 	}
 }
 
+// SetRequisitionRepository makes the parts milestones follow the ticket's parts requisitions
+func (s *TimelineService) SetRequisitionRepository(repo ticketDomain.RequisitionRepository) {
+	s.requisitionRepo = repo
+}
+
 // GenerateTimeline creates a timeline based on current ticket state
 func (s *TimelineService) GenerateTimeline(ctx context.Context, ticket *ticketDomain.ServiceTicket) (*ticketDomain.TicketTimeline, error) {
 	slaConfig := ticketDomain.GetSLAConfig(ticket.Priority)
+	progress := s.partsProgress(ctx, ticket.ID)
 	
 	timeline := &ticketDomain.TicketTimeline{
 		TicketID:      ticket.ID,
-		CurrentStage:  s.determineCurrentStage(ticket),
-		RequiresParts: s.requiresParts(ticket),
+		CurrentStage:  s.determineCurrentStage(ticket, progress),
+		RequiresParts: s.requiresParts(ticket, progress),
 		LastUpdated:   time.Now(),
 	}
 
 	// Build milestones based on workflow
-	milestones := s.buildMilestones(ticket, slaConfig, timeline.RequiresParts)
+	milestones := s.buildMilestones(ticket, slaConfig, timeline.CurrentStage, timeline.RequiresParts, progress)
 	timeline.Milestones = milestones
 
+	// Parts status and ETA follow the requisitions
+	if progress != nil {
+		timeline.PartsStatus = progress.Status
+		if progress.ReceivedAt == nil {
+			for _, m := range milestones {
+				if m.Stage == ticketDomain.MilestonePartsDelivery {
+					timeline.PartsETA = m.EstimatedComplete
+				}
+			}
+		}
+	}
+
 	// Calculate overall status and ETA
 	timeline.OverallStatus = s.calculateOverallStatus(milestones)
 	timeline.EstimatedResolution = s.calculateFinalETA(milestones)
@@ -61,7 +80,10 @@ func (s *TimelineService) ConvertToPublicTimeline(timeline *ticketDomain.TicketT
 
 	// Add parts info if applicable
 	if timeline.RequiresParts {
-		publicTimeline.PartsStatus = s.getPartsStatus(timeline.Milestones)
+		publicTimeline.PartsStatus = timeline.PartsStatus
+		if publicTimeline.PartsStatus == "" {
+			publicTimeline.PartsStatus = s.getPartsStatus(timeline.Milestones)
+		}
 		publicTimeline.PartsETA = timeline.PartsETA
 	}
 
@@ -78,8 +100,37 @@ func (s *TimelineService) ConvertToPublicTimeline(timeline *ticketDomain.TicketT
 	return publicTimeline
 }
 
-// determineCurrentStage figures out what stage the ticket is in
-func (s *TimelineService) determineCurrentStage(ticket *ticketDomain.ServiceTicket) ticketDomain.MilestoneStage {
+// partsProgress summarises the ticket's parts requisitions, nil without any
+func (s *TimelineService) partsProgress(ctx context.Context, ticketID string) *ticketDomain.PartsProgress {
+	if s.requisitionRepo == nil {
+		return nil
+	}
+	requisitions, err := s.requisitionRepo.ListByTicket(ctx, ticketID)
+	if err != nil {
+		s.logger.Warn("Failed to load parts requisitions for timeline",
+			slog.String("ticket_id", ticketID),
+			slog.String("error", err.Error()))
+		return nil
+	}
+	return ticketDomain.SummarizeRequisitions(requisitions)
+}
+
+// determineCurrentStage figures out what stage the ticket is in. While parts
+// are requisitioned for a ticket being worked on, the requisitions decide;
+// an assigned ticket the engineer has not acknowledged yet stays at
+// acknowledgment.
+func (s *TimelineService) determineCurrentStage(ticket *ticketDomain.ServiceTicket, progress *ticketDomain.PartsProgress) ticketDomain.MilestoneStage {
+	awaitingAcknowledgment := ticket.Status == ticketDomain.StatusAssigned && ticket.AcknowledgedAt == nil
+	if progress != nil && !awaitingAcknowledgment {
+		switch ticket.Status {
+		case ticketDomain.StatusAssigned, ticketDomain.StatusInProgress, ticketDomain.StatusOnHold:
+			if progress.Stage == ticketDomain.MilestonePartsReceived {
+				return ticketDomain.MilestoneRepairStart
+			}
+			return progress.Stage
+		}
+	}
+
 	switch ticket.Status {
 	case ticketDomain.StatusNew:
 		return ticketDomain.MilestoneAcknowledgment
@@ -89,8 +140,6 @@ func (s *TimelineService) determineCurrentStage(ticket *ticketDomain.ServiceTick
 		}
 		return ticketDomain.MilestoneAcknowledgment
 	case ticketDomain.StatusInProgress:
-		// Check if parts are needed
-		// TODO: Check if parts are assigned to ticket
 		return ticketDomain.MilestoneRepairStart
 	case ticketDomain.StatusOnHold:
 		// Usually on hold means waiting for parts
@@ -104,15 +153,14 @@ func (s *TimelineService) determineCurrentStage(ticket *ticketDomain.ServiceTick
 	}
 }
 
-// requiresParts checks if ticket needs parts (stub for now)
-func (s *TimelineService) requiresParts(ticket *ticketDomain.ServiceTicket) bool {
-	// TODO: Check if parts are assigned to ticket from database
-	// For now, check status
-	return ticket.Status == ticketDomain.StatusOnHold
+// requiresParts checks if ticket needs parts: it has live parts requisitions,
+// or is on hold, which usually means waiting for parts
+func (s *TimelineService) requiresParts(ticket *ticketDomain.ServiceTicket, progress *ticketDomain.PartsProgress) bool {
+	return progress != nil || ticket.Status == ticketDomain.StatusOnHold
 }
 
 // buildMilestones creates milestone list based on workflow
-func (s *TimelineService) buildMilestones(ticket *ticketDomain.ServiceTicket, config ticketDomain.SLAConfig, needsParts bool) []ticketDomain.TicketMilestone {
+func (s *TimelineService) buildMilestones(ticket *ticketDomain.ServiceTicket, config ticketDomain.SLAConfig, currentStage ticketDomain.MilestoneStage, needsParts bool, progress *ticketDomain.PartsProgress) []ticketDomain.TicketMilestone {
 	milestones := []ticketDomain.TicketMilestone{}
 	baseTime := ticket.CreatedAt
 
@@ -120,7 +168,7 @@ func (s *TimelineService) buildMilestones(ticket *ticketDomain.ServiceTicket, co
 	ackETA := baseTime.Add(time.Duration(config.ResponseHours) * time.Hour)
 	milestones = append(milestones, ticketDomain.TicketMilestone{
 		Stage:             ticketDomain.MilestoneAcknowledgment,
-		Status:            s.getMilestoneStatus(ticket, ticketDomain.MilestoneAcknowledgment),
+		Status:            s.getMilestoneStatus(currentStage, ticketDomain.MilestoneAcknowledgment),
 		EstimatedComplete: &ackETA,
 		ActualComplete:    ticket.AcknowledgedAt,
 		Description:       "Engineer acknowledges ticket and reviews details",
@@ -135,7 +183,7 @@ func (s *TimelineService) buildMilestones(ticket *ticketDomain.ServiceTicket, co
 	diagnosisETA := diagnosisStart.Add(time.Duration(config.DiagnosisHours) * time.Hour)
 	milestones = append(milestones, ticketDomain.TicketMilestone{
 		Stage:             ticketDomain.MilestoneDiagnosis,
-		Status:            s.getMilestoneStatus(ticket, ticketDomain.MilestoneDiagnosis),
+		Status:            s.getMilestoneStatus(currentStage, ticketDomain.MilestoneDiagnosis),
 		EstimatedStart:    &diagnosisStart,
 		EstimatedComplete: &diagnosisETA,
 		Description:       "Engineer diagnoses the issue and determines solution",
@@ -147,13 +195,21 @@ func (s *TimelineService) buildMilestones(ticket *ticketDomain.ServiceTicket, co
 		// Parts ordering
 		partsOrderStart := diagnosisETA
 		partsOrderETA := partsOrderStart.Add(time.Duration(config.PartsOrderHours) * time.Hour)
-		milestones = append(milestones, ticketDomain.TicketMilestone{
+		orderMilestone := ticketDomain.TicketMilestone{
 			Stage:             ticketDomain.MilestonePartsOrdered,
 			Status:            ticketDomain.MilestoneStatusPending,
 			EstimatedStart:    &partsOrderStart,
 			EstimatedComplete: &partsOrderETA,
 			Description:       "Required parts ordered from supplier",
-		})
+		}
+		if progress != nil {
+			orderMilestone.Status = s.getMilestoneStatus(currentStage, ticketDomain.MilestonePartsOrdered)
+			orderMilestone.ActualComplete = progress.OrderedAt
+			if progress.OrderedAt != nil {
+				partsOrderETA = *progress.OrderedAt
+			}
+		}
+		milestones = append(milestones, orderMilestone)
 
 		// Parts delivery
 		deliveryDays := config.StandardPartsDelivery
@@ -161,28 +217,62 @@ func (s *TimelineService) buildMilestones(ticket *ticketDomain.ServiceTicket, co
 			deliveryDays = config.UrgentPartsDelivery
 		}
 		partsDeliveryETA := partsOrderETA.Add(time.Duration(deliveryDays) * 24 * time.Hour)
-		milestones = append(milestones, ticketDomain.TicketMilestone{
+		deliveryMilestone := ticketDomain.TicketMilestone{
 			Stage:             ticketDomain.MilestonePartsDelivery,
 			Status:            ticketDomain.MilestoneStatusBlocked,
 			EstimatedComplete: &partsDeliveryETA,
 			Description:       fmt.Sprintf("Waiting for parts delivery (%d business days)", deliveryDays),
 			BlockerReason:     "Waiting for parts from supplier",
-		})
+		}
+		if progress != nil {
+			// Supplier ETA, then the actual arrival, replace the SLA estimate
+			if progress.ETA != nil {
+				partsDeliveryETA = *progress.ETA
+				deliveryMilestone.Description = "Waiting for parts delivery"
+			}
+			if progress.ReceivedAt != nil {
+				partsDeliveryETA = *progress.ReceivedAt
+			}
+			deliveryMilestone.ActualStart = progress.ShippedAt
+			deliveryMilestone.ActualComplete = progress.ReceivedAt
+			deliveryMilestone.Status = s.getMilestoneStatus(currentStage, ticketDomain.MilestonePartsDelivery)
+			deliveryMilestone.BlockerReason = ""
+			if deliveryMilestone.Status == ticketDomain.MilestoneStatusInProgress {
+				deliveryMilestone.Status = ticketDomain.MilestoneStatusBlocked
+				deliveryMilestone.BlockerReason = "Waiting for parts from supplier"
+				if progress.ETA != nil && progress.ETA.Before(time.Now()) {
+					deliveryMilestone.Status = ticketDomain.MilestoneStatusDelayed
+					deliveryMilestone.BlockerReason = "Parts delivery is overdue"
+				}
+			}
+		}
+		milestones = append(milestones, deliveryMilestone)
 
 		// Parts received
+		receivedStatus := ticketDomain.MilestoneStatusPending
+		var receivedAt *time.Time
+		if progress != nil {
+			receivedStatus = s.getMilestoneStatus(currentStage, ticketDomain.MilestonePartsReceived)
+			receivedAt = progress.ReceivedAt
+		}
 		milestones = append(milestones, ticketDomain.TicketMilestone{
 			Stage:             ticketDomain.MilestonePartsReceived,
-			Status:            ticketDomain.MilestoneStatusPending,
+			Status:            receivedStatus,
 			EstimatedComplete: &partsDeliveryETA,
+			ActualComplete:    receivedAt,
 			Description:       "Parts received and ready for installation",
 		})
 
 		// Repair after parts
+		repairStatus := ticketDomain.MilestoneStatusPending
+		if progress != nil {
+			repairStatus = s.getMilestoneStatus(currentStage, ticketDomain.MilestoneRepairStart)
+		}
 		repairStart := partsDeliveryETA
 		repairETA := repairStart.Add(time.Duration(config.RepairAfterPartsHours) * time.Hour)
 		milestones = append(milestones, ticketDomain.TicketMilestone{
 			Stage:             ticketDomain.MilestoneRepairStart,
-			Status:            ticketDomain.MilestoneStatusPending,
+			Status:            repairStatus,
 			EstimatedStart:    &repairStart,
 			EstimatedComplete: &repairETA,
 			Description:       "Engineer installs parts and completes repair",
@@ -194,7 +284,7 @@ func (s *TimelineService) buildMilestones(ticket *ticketDomain.ServiceTicket, co
 		repairETA := repairStart.Add(time.Duration(config.SimpleRepairHours) * time.Hour)
 		milestones = append(milestones, ticketDomain.TicketMilestone{
 			Stage:             ticketDomain.MilestoneRepairStart,
-			Status:            s.getMilestoneStatus(ticket, ticketDomain.MilestoneRepairStart),
+			Status:            s.getMilestoneStatus(currentStage, ticketDomain.MilestoneRepairStart),
 			EstimatedStart:    &repairStart,
 			EstimatedComplete: &repairETA,
 			Description:       "Engineer performs repair",
@@ -208,7 +298,7 @@ func (s *TimelineService) buildMilestones(ticket *ticketDomain.ServiceTicket, co
 	verifyETA := verifyStart.Add(time.Duration(config.VerificationHours) * time.Hour)
 	milestones = append(milestones, ticketDomain.TicketMilestone{
 		Stage:             ticketDomain.MilestoneVerification,
-		Status:            s.getMilestoneStatus(ticket, ticketDomain.MilestoneVerification),
+		Status:            s.getMilestoneStatus(currentStage, ticketDomain.MilestoneVerification),
 		EstimatedStart:    &verifyStart,
 		EstimatedComplete: &verifyETA,
 		Description:       "Testing and verification of the fix",
@@ -218,7 +308,7 @@ func (s *TimelineService) buildMilestones(ticket *ticketDomain.ServiceTicket, co
 	// 8. Final resolution
 	milestones = append(milestones, ticketDomain.TicketMilestone{
 		Stage:             ticketDomain.MilestoneResolution,
-		Status:            s.getMilestoneStatus(ticket, ticketDomain.MilestoneResolution),
+		Status:            s.getMilestoneStatus(currentStage, ticketDomain.MilestoneResolution),
 		EstimatedComplete: &verifyETA,
 		Description:       "Ticket closed and resolved",
 	})
@@ -226,10 +316,8 @@ func (s *TimelineService) buildMilestones(ticket *ticketDomain.ServiceTicket, co
 	return milestones
 }
 
-// getMilestoneStatus determines status based on ticket state
-func (s *TimelineService) getMilestoneStatus(ticket *ticketDomain.ServiceTicket, stage ticketDomain.MilestoneStage) ticketDomain.MilestoneStatus {
-	currentStage := s.determineCurrentStage(ticket)
-	
+// getMilestoneStatus determines status based on the ticket's current stage
+func (s *TimelineService) getMilestoneStatus(currentStage, stage ticketDomain.MilestoneStage) ticketDomain.MilestoneStatus {
 	// Compare stages to determine status
 	stageOrder := map[ticketDomain.MilestoneStage]int{
 		ticketDomain.MilestoneAcknowledgment: 0,
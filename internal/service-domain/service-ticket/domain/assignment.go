@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
 )
 
 // ==================== ERROR DEFINITIONS ====================
@@ -12,6 +14,9 @@ var (
 	ErrAssignmentNotFound      = errors.New("assignment not found")
 	ErrInvalidAssignmentStatus = errors.New("invalid assignment status transition")
 	ErrTicketAlreadyAssigned   = errors.New("ticket already has an active assignment")
+	ErrEngineerNotFound        = errors.New("engineer not found")
+	ErrInvalidLocation         = errors.New("invalid location coordinates")
+	ErrPostalCodeNotFound      = errors.New("postal code not found in gazetteer")
 )
 
 // ==================== ENGINEER LEVEL ====================
@@ -294,10 +299,29 @@ type Engineer struct {
 	CreatedAt            time.Time     `json:"created_at"`
 	UpdatedAt            time.Time     `json:"updated_at"`
 
+	// Location for proximity-based assignment
+	HomeRegion        string     `json:"home_region,omitempty"`
+	BasePostalCode    string     `json:"base_postal_code,omitempty"`
+	BaseLocation      *geo.Point `json:"base_location,omitempty"`    // Home base or depot
+	CurrentLocation   *geo.Point `json:"current_location,omitempty"` // Last reported position
+	CurrentLocationAt *time.Time `json:"current_location_at,omitempty"`
+
 	// For eligible engineers list
 	EligibleEquipmentTypes []string `json:"eligible_equipment_types,omitempty"`
 }
 
+// Engineer location sources
+const (
+	EngineerLocationCurrent = geo.LocationCurrent
+	EngineerLocationBase    = geo.LocationBase
+)
+
+// Position returns the location to measure travel from: the current location
+// when it was reported within maxAge, otherwise the base location
+func (e *Engineer) Position(now time.Time, maxAge time.Duration) (geo.Point, string, bool) {
+	return geo.TravelOrigin(e.BaseLocation, e.CurrentLocation, e.CurrentLocationAt, now, maxAge)
+}
+
 // EngineerEquipmentType maps engineers to equipment types they can service
 type EngineerEquipmentType struct {
 	ID           string    `json:"id"`
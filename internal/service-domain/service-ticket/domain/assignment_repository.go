@@ -1,6 +1,11 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
+)
 
 // EngineerSuggestionRepository defines data access operations for engineer suggestion system
 // This is separate from AssignmentRepository (workflow system) in assignment.go
@@ -10,6 +15,8 @@ type EngineerSuggestionRepository interface {
 	ListEngineers(ctx context.Context, organizationID *string, includePartners bool, limit, offset int) ([]*Engineer, error)
 	GetEngineerByID(ctx context.Context, engineerID string) (*Engineer, error)
 	UpdateEngineerLevel(ctx context.Context, engineerID string, level EngineerLevel) error
+	UpdateEngineerBaseLocation(ctx context.Context, engineerID string, location *geo.Point, postalCode string) error
+	UpdateEngineerCurrentLocation(ctx context.Context, engineerID string, location geo.Point, at time.Time) error
 	
 	// Engineer equipment types (capabilities)
 	ListEngineerEquipmentTypes(ctx context.Context, engineerID string) ([]*EngineerEquipmentType, error)
@@ -0,0 +1,572 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCampaignNotFound      = errors.New("campaign not found")
+	ErrCampaignUnitNotFound  = errors.New("campaign unit not found")
+	ErrInvalidCampaign       = errors.New("invalid campaign")
+	ErrCampaignNotActive     = errors.New("campaign is not active")
+	ErrInvalidUnitTransition = errors.New("invalid campaign unit status transition")
+	ErrEvidenceRequired      = errors.New("evidence is required to complete a campaign unit")
+)
+
+// CampaignType classifies a field safety campaign
+type CampaignType string
+
+const (
+	CampaignTypeRecall         CampaignType = "recall"
+	CampaignTypeFSCA           CampaignType = "fsca"          // Field safety corrective action
+	CampaignTypeSafetyNotice   CampaignType = "safety_notice" // Field safety notice without corrective work
+	CampaignTypeSoftwareUpdate CampaignType = "software_update"
+)
+
+// CampaignStatus represents the lifecycle of a campaign
+type CampaignStatus string
+
+const (
+	CampaignStatusDraft     CampaignStatus = "draft"
+	CampaignStatusActive    CampaignStatus = "active"
+	CampaignStatusCompleted CampaignStatus = "completed"
+	CampaignStatusCancelled CampaignStatus = "cancelled"
+)
+
+// CampaignAction decides what is generated per affected unit
+type CampaignAction string
+
+const (
+	CampaignActionTicket CampaignAction = "ticket" // A service ticket per unit
+	CampaignActionTask   CampaignAction = "task"   // A campaign task tracked on the unit only
+)
+
+// SourceCampaign marks tickets generated by a field safety campaign
+const SourceCampaign TicketSource = "campaign"
+
+// Campaign event types
+const (
+	EventCampaignLaunched         = "campaign.launched"
+	EventCampaignCustomerNotified = "campaign.customer_notified"
+	EventCampaignUnitCompleted    = "campaign.unit_completed"
+	EventCampaignCompleted        = "campaign.completed"
+)
+
+// Campaign is a manufacturer recall or field safety corrective action
+// tracked across the affected installed base
+type Campaign struct {
+	ID                   string           `json:"id"`
+	CampaignNumber       string           `json:"campaign_number"`
+	Title                string           `json:"title"`
+	Type                 CampaignType     `json:"type"`
+	ManufacturerName     string           `json:"manufacturer_name"`
+	ManufacturerRef      string           `json:"manufacturer_reference,omitempty"` // Manufacturer FSCA/recall number
+	Description          string           `json:"description"`
+	CustomerInstructions string           `json:"customer_instructions,omitempty"` // Text of the field safety notice
+	Criteria             CampaignCriteria `json:"criteria"`
+	Action               CampaignAction   `json:"action"`
+	Priority             TicketPriority   `json:"priority"`
+	RequireEvidence      bool             `json:"require_evidence"` // Units need evidence before they can be completed
+	Status               CampaignStatus   `json:"status"`
+	DueDate              *time.Time       `json:"due_date,omitempty"`
+	LaunchedAt           *time.Time       `json:"launched_at,omitempty"`
+	CompletedAt          *time.Time       `json:"completed_at,omitempty"`
+	CreatedBy            string           `json:"created_by"`
+	CreatedAt            time.Time        `json:"created_at"`
+	UpdatedAt            time.Time        `json:"updated_at"`
+
+	// Populated on reads
+	Progress *CampaignProgress `json:"progress,omitempty"`
+}
+
+// Validate checks required fields and defaults optional ones
+func (c *Campaign) Validate() error {
+	if strings.TrimSpace(c.Title) == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidCampaign)
+	}
+	switch c.Type {
+	case CampaignTypeRecall, CampaignTypeFSCA, CampaignTypeSafetyNotice, CampaignTypeSoftwareUpdate:
+	case "":
+		c.Type = CampaignTypeFSCA
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidCampaign, c.Type)
+	}
+	switch c.Action {
+	case CampaignActionTicket, CampaignActionTask:
+	case "":
+		c.Action = CampaignActionTicket
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidCampaign, c.Action)
+	}
+	if c.Priority == "" {
+		c.Priority = PriorityHigh
+	}
+	if c.ManufacturerName == "" {
+		c.ManufacturerName = c.Criteria.Manufacturer
+	}
+	return c.Criteria.Validate()
+}
+
+// CampaignCriteria selects the affected units of the installed base. Each
+// populated criterion must match; values within a criterion are alternatives.
+type CampaignCriteria struct {
+	Manufacturer     string        `json:"manufacturer"`
+	Models           []string      `json:"models,omitempty"`
+	SerialRanges     []SerialRange `json:"serial_ranges,omitempty"`
+	Serials          []string      `json:"serials,omitempty"`           // Individually listed serial numbers
+	SoftwareVersions []string      `json:"software_versions,omitempty"` // e.g. "2.4.1", "2.4.*", "<2.5", ">=3.0,<3.2"
+}
+
+// SerialRange is an inclusive range of serial numbers sharing a prefix, e.g. SN10000-SN10999
+type SerialRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Validate checks that the criteria select something specific
+func (c CampaignCriteria) Validate() error {
+	if strings.TrimSpace(c.Manufacturer) == "" {
+		return fmt.Errorf("%w: criteria.manufacturer is required", ErrInvalidCampaign)
+	}
+	if len(c.Models) == 0 && len(c.SerialRanges) == 0 && len(c.Serials) == 0 {
+		return fmt.Errorf("%w: criteria need models, serial ranges or serials", ErrInvalidCampaign)
+	}
+	for _, r := range c.SerialRanges {
+		from, okFrom := parseSerial(r.From)
+		to, okTo := parseSerial(r.To)
+		if !okFrom || !okTo || from.prefix != to.prefix || from.suffix != to.suffix || from.number > to.number {
+			return fmt.Errorf("%w: invalid serial range %s-%s", ErrInvalidCampaign, r.From, r.To)
+		}
+	}
+	for _, v := range c.SoftwareVersions {
+		if _, err := parseVersionConstraint(v); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidCampaign, err)
+		}
+	}
+	return nil
+}
+
+// CampaignCandidate is an installed unit evaluated against campaign criteria
+type CampaignCandidate struct {
+	EquipmentID      string
+	SerialNumber     string
+	ManufacturerName string // Canonical manufacturer name
+	ModelNumber      string
+	SoftwareVersion  string
+	CustomerID       string
+	CustomerName     string
+}
+
+// CampaignMatch explains why a unit is affected
+type CampaignMatch struct {
+	Reasons           []string `json:"reasons"`
+	VersionUnverified bool     `json:"version_unverified"` // Criteria restrict software versions but the unit's version is unknown
+}
+
+// Match evaluates a candidate. The manufacturer of the candidate must already
+// be canonicalized by the caller. Units with an unknown software version match
+// with VersionUnverified set, so the field team confirms the version on site.
+func (c CampaignCriteria) Match(candidate CampaignCandidate, canonicalManufacturer string) (CampaignMatch, bool) {
+	match := CampaignMatch{}
+	if !strings.EqualFold(candidate.ManufacturerName, canonicalManufacturer) {
+		return match, false
+	}
+
+	if len(c.Models) > 0 {
+		model := serialKey(candidate.ModelNumber)
+		found := false
+		for _, m := range c.Models {
+			if model != "" && serialKey(m) == model {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return match, false
+		}
+		match.Reasons = append(match.Reasons, "model "+candidate.ModelNumber)
+	}
+
+	if len(c.SerialRanges) > 0 || len(c.Serials) > 0 {
+		reason, ok := c.matchSerial(candidate.SerialNumber)
+		if !ok {
+			return match, false
+		}
+		match.Reasons = append(match.Reasons, reason)
+	}
+
+	if len(c.SoftwareVersions) > 0 {
+		if strings.TrimSpace(candidate.SoftwareVersion) == "" {
+			match.VersionUnverified = true
+			match.Reasons = append(match.Reasons, "software version unknown")
+		} else {
+			found := false
+			for _, spec := range c.SoftwareVersions {
+				constraint, _ := parseVersionConstraint(spec)
+				if constraint.matches(candidate.SoftwareVersion) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return match, false
+			}
+			match.Reasons = append(match.Reasons, "software version "+candidate.SoftwareVersion)
+		}
+	}
+
+	return match, true
+}
+
+func (c CampaignCriteria) matchSerial(serial string) (string, bool) {
+	key := serialKey(serial)
+	for _, s := range c.Serials {
+		if key != "" && serialKey(s) == key {
+			return "serial listed", true
+		}
+	}
+
+	parsed, ok := parseSerial(serial)
+	if !ok {
+		return "", false
+	}
+	for _, r := range c.SerialRanges {
+		from, _ := parseSerial(r.From)
+		to, _ := parseSerial(r.To)
+		if parsed.prefix == from.prefix && parsed.suffix == from.suffix &&
+			parsed.number >= from.number && parsed.number <= to.number {
+			return fmt.Sprintf("serial in range %s-%s", r.From, r.To), true
+		}
+	}
+	return "", false
+}
+
+var nonAlphanumericSerial = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// serialKey compares serials and model numbers ignoring case and separators
+func serialKey(s string) string {
+	return nonAlphanumericSerial.ReplaceAllString(strings.ToUpper(s), "")
+}
+
+// parsedSerial splits a serial into a prefix, its last number and a trailing suffix
+type parsedSerial struct {
+	prefix string
+	number uint64
+	suffix string
+}
+
+var serialNumberPart = regexp.MustCompile(`^(.*?)(\d+)([A-Z]*)$`)
+
+func parseSerial(serial string) (parsedSerial, bool) {
+	m := serialNumberPart.FindStringSubmatch(serialKey(serial))
+	if m == nil {
+		return parsedSerial{}, false
+	}
+	n, err := strconv.ParseUint(m[2], 10, 64)
+	if err != nil {
+		return parsedSerial{}, false
+	}
+	return parsedSerial{prefix: m[1], number: n, suffix: m[3]}, true
+}
+
+// versionConstraint is a conjunction of version comparisons or a wildcard pattern
+type versionConstraint struct {
+	clauses []versionClause
+}
+
+type versionClause struct {
+	op      string // "=", "<", "<=", ">", ">=", "*" (prefix match)
+	version []int
+}
+
+func parseVersionConstraint(spec string) (versionConstraint, error) {
+	constraint := versionConstraint{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		clause := versionClause{op: "="}
+		for _, op := range []string{"<=", ">=", "<", ">", "="} {
+			if strings.HasPrefix(part, op) {
+				clause.op = op
+				part = strings.TrimSpace(strings.TrimPrefix(part, op))
+				break
+			}
+		}
+		if strings.HasSuffix(part, ".*") || part == "*" {
+			if clause.op != "=" {
+				return constraint, fmt.Errorf("invalid software version %q", spec)
+			}
+			clause.op = "*"
+			part = strings.TrimSuffix(strings.TrimSuffix(part, "*"), ".")
+		}
+		version, ok := parseVersion(part)
+		if !ok && !(clause.op == "*" && part == "") {
+			return constraint, fmt.Errorf("invalid software version %q", spec)
+		}
+		clause.version = version
+		constraint.clauses = append(constraint.clauses, clause)
+	}
+	if len(constraint.clauses) == 0 {
+		return constraint, fmt.Errorf("empty software version")
+	}
+	return constraint, nil
+}
+
+func (c versionConstraint) matches(v string) bool {
+	version, ok := parseVersion(v)
+	if !ok {
+		return false
+	}
+	for _, clause := range c.clauses {
+		cmp := compareVersions(version, clause.version)
+		var ok bool
+		switch clause.op {
+		case "=":
+			ok = cmp == 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "*":
+			ok = len(version) >= len(clause.version) && compareVersions(version[:len(clause.version)], clause.version) == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// parseVersion reads dotted numeric versions such as "v2.4.1" or "2.4.1-b3" (build suffix ignored)
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(v)), "v")
+	if i := strings.IndexAny(v, "-+ _"); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return nil, false
+	}
+	parts := strings.Split(v, ".")
+	version := make([]int, 0, len(parts))
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		version = append(version, n)
+	}
+	return version, true
+}
+
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// CampaignUnitStatus tracks remediation of one affected unit
+type CampaignUnitStatus string
+
+const (
+	UnitStatusPending       CampaignUnitStatus = "pending"
+	UnitStatusNotified      CampaignUnitStatus = "notified"
+	UnitStatusScheduled     CampaignUnitStatus = "scheduled"
+	UnitStatusInProgress    CampaignUnitStatus = "in_progress"
+	UnitStatusCompleted     CampaignUnitStatus = "completed"
+	UnitStatusNotApplicable CampaignUnitStatus = "not_applicable" // Verified on site as not affected
+	UnitStatusDeclined      CampaignUnitStatus = "declined"       // Customer declined the corrective action
+)
+
+// campaignUnitTransitions lists allowed status changes
+var campaignUnitTransitions = map[CampaignUnitStatus][]CampaignUnitStatus{
+	UnitStatusPending:       {UnitStatusNotified, UnitStatusScheduled, UnitStatusInProgress, UnitStatusCompleted, UnitStatusNotApplicable, UnitStatusDeclined},
+	UnitStatusNotified:      {UnitStatusScheduled, UnitStatusInProgress, UnitStatusCompleted, UnitStatusNotApplicable, UnitStatusDeclined},
+	UnitStatusScheduled:     {UnitStatusInProgress, UnitStatusCompleted, UnitStatusNotApplicable, UnitStatusDeclined},
+	UnitStatusInProgress:    {UnitStatusScheduled, UnitStatusCompleted, UnitStatusNotApplicable},
+	UnitStatusDeclined:      {UnitStatusScheduled, UnitStatusInProgress, UnitStatusCompleted},
+	UnitStatusNotApplicable: {UnitStatusPending},
+	UnitStatusCompleted:     {},
+}
+
+// CampaignUnit is an affected unit and its remediation state
+type CampaignUnit struct {
+	ID                string             `json:"id"`
+	CampaignID        string             `json:"campaign_id"`
+	EquipmentID       string             `json:"equipment_id"`
+	SerialNumber      string             `json:"serial_number"`
+	ModelNumber       string             `json:"model_number"`
+	SoftwareVersion   string             `json:"software_version,omitempty"`
+	CustomerID        string             `json:"customer_id"`
+	CustomerName      string             `json:"customer_name"`
+	MatchReasons      []string           `json:"match_reasons"`
+	VersionUnverified bool               `json:"version_unverified"`
+	Status            CampaignUnitStatus `json:"status"`
+	TicketID          string             `json:"ticket_id,omitempty"`
+	NotifiedAt        *time.Time         `json:"notified_at,omitempty"`
+	CompletedAt       *time.Time         `json:"completed_at,omitempty"`
+	CompletedBy       string             `json:"completed_by,omitempty"`
+	Notes             string             `json:"notes,omitempty"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+
+	// Populated on reads
+	Evidence []*CampaignEvidence `json:"evidence,omitempty"`
+}
+
+// TransitionTo changes the unit status. Completion records who completed the
+// unit and, when the campaign requires it, needs at least one evidence item.
+func (u *CampaignUnit) TransitionTo(status CampaignUnitStatus, by string, requireEvidence bool) error {
+	if status == u.Status {
+		return nil
+	}
+	allowed := false
+	for _, next := range campaignUnitTransitions[u.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidUnitTransition, u.Status, status)
+	}
+	if status == UnitStatusCompleted && requireEvidence && len(u.Evidence) == 0 {
+		return ErrEvidenceRequired
+	}
+
+	now := time.Now()
+	switch status {
+	case UnitStatusCompleted:
+		u.CompletedAt = &now
+		u.CompletedBy = by
+	case UnitStatusNotified:
+		u.NotifiedAt = &now
+	}
+	u.Status = status
+	u.UpdatedAt = now
+	return nil
+}
+
+// CampaignEvidence is proof of remediation attached to a unit, e.g. a photo of
+// the updated label or a service report
+type CampaignEvidence struct {
+	ID           string    `json:"id"`
+	UnitID       string    `json:"unit_id"`
+	AttachmentID string    `json:"attachment_id,omitempty"` // Uploaded through the attachments module
+	URL          string    `json:"url,omitempty"`
+	Description  string    `json:"description"`
+	UploadedBy   string    `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CampaignProgress summarizes remediation across all units of a campaign
+type CampaignProgress struct {
+	TotalUnits        int                        `json:"total_units"`
+	ByStatus          map[CampaignUnitStatus]int `json:"by_status"`
+	Completed         int                        `json:"completed"`
+	Outstanding       int                        `json:"outstanding"`
+	Customers         int                        `json:"customers"`
+	CompletionPercent float64                    `json:"completion_percent"` // Completed of units still in scope (excludes not applicable)
+}
+
+// NewCampaignProgress computes progress from unit counts per status
+func NewCampaignProgress(counts map[CampaignUnitStatus]int, customers int) *CampaignProgress {
+	p := &CampaignProgress{ByStatus: counts, Customers: customers}
+	for _, n := range counts {
+		p.TotalUnits += n
+	}
+	p.Completed = counts[UnitStatusCompleted]
+	inScope := p.TotalUnits - counts[UnitStatusNotApplicable]
+	p.Outstanding = inScope - p.Completed - counts[UnitStatusDeclined]
+	if inScope > 0 {
+		p.CompletionPercent = float64(int(float64(p.Completed)/float64(inScope)*1000+0.5)) / 10
+	}
+	return p
+}
+
+// CampaignNotification records a field safety notice sent to a customer
+type CampaignNotification struct {
+	ID         string    `json:"id"`
+	CampaignID string    `json:"campaign_id"`
+	CustomerID string    `json:"customer_id"`
+	Channel    string    `json:"channel"` // email, webhook
+	Recipient  string    `json:"recipient,omitempty"`
+	UnitCount  int       `json:"unit_count"`
+	Status     string    `json:"status"` // sent, failed
+	Error      string    `json:"error,omitempty"`
+	SentBy     string    `json:"sent_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CampaignUnitFilter filters campaign units
+type CampaignUnitFilter struct {
+	Status     []CampaignUnitStatus
+	CustomerID string
+	Limit      int
+	Offset     int
+}
+
+// CampaignRepository defines persistence for field safety campaigns
+type CampaignRepository interface {
+	Create(ctx context.Context, campaign *Campaign) error
+	Update(ctx context.Context, campaign *Campaign) error
+	GetByID(ctx context.Context, id string) (*Campaign, error)
+	List(ctx context.Context, status []CampaignStatus) ([]*Campaign, error)
+
+	// AddUnits inserts affected units, skipping units already part of the campaign; returns the number added
+	AddUnits(ctx context.Context, units []*CampaignUnit) (int, error)
+	GetUnit(ctx context.Context, campaignID, unitID string) (*CampaignUnit, error)
+	ListUnits(ctx context.Context, campaignID string, filter CampaignUnitFilter) ([]*CampaignUnit, error)
+	UpdateUnit(ctx context.Context, unit *CampaignUnit) error
+
+	AddEvidence(ctx context.Context, evidence *CampaignEvidence) error
+	ListEvidence(ctx context.Context, unitID string) ([]*CampaignEvidence, error)
+
+	// Progress counts units per status and distinct customers
+	Progress(ctx context.Context, campaignID string) (*CampaignProgress, error)
+
+	AddNotification(ctx context.Context, notification *CampaignNotification) error
+	ListNotifications(ctx context.Context, campaignID string) ([]*CampaignNotification, error)
+
+	// ListUnitsForEquipment retrieves campaign units affecting one equipment
+	ListUnitsForEquipment(ctx context.Context, equipmentID string) ([]*CampaignUnit, error)
+}
+
+// GenerateCampaignNumber generates a campaign number such as FSCA-20261018-153045
+func GenerateCampaignNumber(t CampaignType) string {
+	prefix := "FSCA"
+	switch t {
+	case CampaignTypeRecall:
+		prefix = "RCL"
+	case CampaignTypeSafetyNotice:
+		prefix = "FSN"
+	case CampaignTypeSoftwareUpdate:
+		prefix = "SWU"
+	}
+	now := time.Now()
+	return prefix + "-" + now.Format("20060102") + "-" + now.Format("150405")
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "testing"
+
+func TestCampaignCriteriaMatch(t *testing.T) {
+	criteria := CampaignCriteria{
+		Manufacturer:     "GE Healthcare",
+		Models:           []string{"Vivid E95"},
+		SerialRanges:     []SerialRange{{From: "VE-10000", To: "VE-10999"}},
+		SoftwareVersions: []string{">=2.0,<2.4", "3.1.*"},
+	}
+	if err := criteria.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		candidate  CampaignCandidate
+		want       bool
+		unverified bool
+	}{
+		{"in range and version", CampaignCandidate{ManufacturerName: "GE Healthcare", ModelNumber: "vivid-e95", SerialNumber: "ve10500", SoftwareVersion: "2.3.9"}, true, false},
+		{"wildcard version", CampaignCandidate{ManufacturerName: "GE Healthcare", ModelNumber: "Vivid E95", SerialNumber: "VE-10000", SoftwareVersion: "3.1.2"}, true, false},
+		{"unknown version", CampaignCandidate{ManufacturerName: "GE Healthcare", ModelNumber: "Vivid E95", SerialNumber: "VE-10999"}, true, true},
+		{"fixed version", CampaignCandidate{ManufacturerName: "GE Healthcare", ModelNumber: "Vivid E95", SerialNumber: "VE-10500", SoftwareVersion: "2.4"}, false, false},
+		{"serial outside range", CampaignCandidate{ManufacturerName: "GE Healthcare", ModelNumber: "Vivid E95", SerialNumber: "VE-11000", SoftwareVersion: "2.1"}, false, false},
+		{"other prefix", CampaignCandidate{ManufacturerName: "GE Healthcare", ModelNumber: "Vivid E95", SerialNumber: "VX-10500", SoftwareVersion: "2.1"}, false, false},
+		{"other model", CampaignCandidate{ManufacturerName: "GE Healthcare", ModelNumber: "Vivid T8", SerialNumber: "VE-10500", SoftwareVersion: "2.1"}, false, false},
+		{"other manufacturer", CampaignCandidate{ManufacturerName: "Philips", ModelNumber: "Vivid E95", SerialNumber: "VE-10500", SoftwareVersion: "2.1"}, false, false},
+	}
+	for _, tt := range tests {
+		match, ok := criteria.Match(tt.candidate, "GE Healthcare")
+		if ok != tt.want || match.VersionUnverified != tt.unverified {
+			t.Errorf("%s: Match() = %v (unverified %v), want %v (unverified %v)", tt.name, ok, match.VersionUnverified, tt.want, tt.unverified)
+		}
+	}
+}
+
+func TestCampaignUnitCompletionRequiresEvidence(t *testing.T) {
+	unit := &CampaignUnit{Status: UnitStatusNotified}
+	if err := unit.TransitionTo(UnitStatusCompleted, "eng-1", true); err != ErrEvidenceRequired {
+		t.Fatalf("TransitionTo() = %v, want ErrEvidenceRequired", err)
+	}
+	unit.Evidence = []*CampaignEvidence{{URL: "https://example.com/report.pdf"}}
+	if err := unit.TransitionTo(UnitStatusCompleted, "eng-1", true); err != nil {
+		t.Fatalf("TransitionTo() = %v", err)
+	}
+	if unit.CompletedAt == nil || unit.CompletedBy != "eng-1" {
+		t.Errorf("completion not recorded: %+v", unit)
+	}
+	if err := unit.TransitionTo(UnitStatusPending, "eng-1", false); err == nil {
+		t.Error("completed unit reopened")
+	}
+
+	progress := NewCampaignProgress(map[CampaignUnitStatus]int{UnitStatusCompleted: 3, UnitStatusPending: 1, UnitStatusNotApplicable: 2}, 2)
+	if progress.TotalUnits != 6 || progress.Outstanding != 1 || progress.CompletionPercent != 75 {
+		t.Errorf("progress = %+v", progress)
+	}
+}
@@ -54,6 +54,7 @@ type PublicTicketView struct {
 	PublicComments    []PublicComment     `json:"comments"`
 	StatusHistory     []PublicStatusEvent `json:"status_history"`
 	AssignedEngineer  string              `json:"assigned_engineer,omitempty"`
+	Timeline          *PublicTimeline     `json:"timeline,omitempty"` // Milestones, parts status and ETAs
 }
 
 // PublicComment represents a comment visible to customers
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrIncompatiblePart is returned when a part does not fit the ticket's equipment
+// and no current alternate does either
+var ErrIncompatiblePart = errors.New("part is not compatible with the ticket equipment")
+
+// PartSelection is the spare part to use on a ticket for a requested part,
+// after following supersessions and alternates
+type PartSelection struct {
+	SparePartID     string   `json:"spare_part_id"`
+	PartNumber      string   `json:"part_number"`
+	PartName        string   `json:"part_name"`
+	RequestedPartID string   `json:"requested_part_id"`
+	Superseded      []string `json:"superseded,omitempty"` // Replaced part numbers, oldest first
+	Alternate       bool     `json:"alternate,omitempty"`
+	Fit             string   `json:"fit"` // confirmed or unknown
+}
+
+// PartsCompatibility checks spare parts against the equipment of tickets
+type PartsCompatibility interface {
+	// SelectPart resolves a part ID or number to the current, non-obsolete
+	// part fitting the equipment
+	SelectPart(ctx context.Context, partRef, equipmentID string) (*PartSelection, error)
+
+	// CompatibleParts lists the current parts fitting the equipment,
+	// optionally matching a text query
+	CompatibleParts(ctx context.Context, equipmentID, query string) ([]*PartSelection, error)
+}
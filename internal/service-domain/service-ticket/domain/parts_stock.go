@@ -0,0 +1,29 @@
+package domain
+
+import "context"
+
+// PartReservation is stock held at one location for a part attached to a ticket
+type PartReservation struct {
+	ID         string `json:"id"`
+	LocationID string `json:"location_id"`
+	Quantity   int    `json:"quantity"`
+}
+
+// PartsStock reserves, releases and consumes spare parts stock for tickets
+type PartsStock interface {
+	// ReservePart reserves stock for a part attached to a ticket, at
+	// locationID or else preferring the engineer's van
+	ReservePart(ctx context.Context, ticketID, ticketPartID, sparePartID string, quantity int, engineerID, locationID, reservedBy string) (*PartReservation, error)
+
+	// ReleasePart releases the stock held for a part removed from a ticket
+	ReleasePart(ctx context.Context, ticketPartID, releasedBy string) error
+
+	// ReleaseTicket releases all stock held for a ticket
+	ReleaseTicket(ctx context.Context, ticketID, releasedBy string) error
+
+	// ConsumeTicket takes all stock held for a ticket out of stock
+	ConsumeTicket(ctx context.Context, ticketID, consumedBy string) (int, error)
+
+	// ReceiveParts books parts delivered against a requisition into a stock location
+	ReceiveParts(ctx context.Context, locationID, reference string, lines []RequisitionLine, receivedBy string) error
+}
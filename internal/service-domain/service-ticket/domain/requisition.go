@@ -0,0 +1,350 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRequisitionNotFound          = errors.New("parts requisition not found")
+	ErrInvalidRequisition           = errors.New("invalid parts requisition")
+	ErrInvalidRequisitionTransition = errors.New("invalid parts requisition status transition")
+	ErrRequisitionApprovalRole      = errors.New("parts requisitions must be approved by a service manager")
+)
+
+// RequisitionStatus represents the lifecycle of a parts requisition
+type RequisitionStatus string
+
+const (
+	RequisitionRequested RequisitionStatus = "requested" // Raised by the engineer, awaiting approval
+	RequisitionApproved  RequisitionStatus = "approved"  // Approved by the service manager
+	RequisitionRejected  RequisitionStatus = "rejected"
+	RequisitionOrdered   RequisitionStatus = "ordered"  // Internal PO placed with the supplier
+	RequisitionShipped   RequisitionStatus = "shipped"  // Supplier dispatched the parts
+	RequisitionReceived  RequisitionStatus = "received" // Parts arrived
+	RequisitionCancelled RequisitionStatus = "cancelled"
+)
+
+// Parts requisition event types
+const (
+	EventRequisitionRequested = "ticket.parts_requisition.requested"
+	EventRequisitionApproved  = "ticket.parts_requisition.approved"
+	EventRequisitionRejected  = "ticket.parts_requisition.rejected"
+	EventRequisitionOrdered   = "ticket.parts_requisition.ordered"
+	EventRequisitionShipped   = "ticket.parts_requisition.shipped"
+	EventRequisitionReceived  = "ticket.parts_requisition.received"
+	EventRequisitionCancelled = "ticket.parts_requisition.cancelled"
+)
+
+// requisitionApproverRoles may approve or reject parts requisitions
+var requisitionApproverRoles = map[string]bool{
+	"service_manager": true,
+	"admin":           true,
+	"super_admin":     true,
+	"system_admin":    true,
+}
+
+// CanApproveRequisition reports whether a role may approve parts requisitions
+func CanApproveRequisition(role string) bool {
+	return requisitionApproverRoles[strings.ToLower(strings.TrimSpace(role))]
+}
+
+// PartsRequisition is a request raised from a ticket for parts that are not
+// in stock, followed through approval, ordering, shipment and receipt
+type PartsRequisition struct {
+	ID                string            `json:"id"`
+	RequisitionNumber string            `json:"requisition_number"`
+	TicketID          string            `json:"ticket_id"`
+	Status            RequisitionStatus `json:"status"`
+	Urgent            bool              `json:"urgent"`
+	Reason            string            `json:"reason,omitempty"`
+	Lines             []RequisitionLine `json:"lines"`
+	RequestedBy       string            `json:"requested_by"`
+
+	DecidedBy      string     `json:"decided_by,omitempty"` // Manager who approved or rejected
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	DecisionReason string     `json:"decision_reason,omitempty"`
+
+	// Internal purchase order placed with the supplier
+	SupplierID       string     `json:"supplier_id,omitempty"`
+	SupplierName     string     `json:"supplier_name,omitempty"`
+	OrderNumber      string     `json:"order_number,omitempty"`
+	OrderedBy        string     `json:"ordered_by,omitempty"`
+	OrderedAt        *time.Time `json:"ordered_at,omitempty"`
+	ExpectedDelivery *time.Time `json:"expected_delivery,omitempty"`
+
+	// Shipment tracking
+	Carrier        string     `json:"carrier,omitempty"`
+	TrackingNumber string     `json:"tracking_number,omitempty"`
+	ShippedAt      *time.Time `json:"shipped_at,omitempty"`
+
+	ReceivedBy        string     `json:"received_by,omitempty"`
+	ReceivedAt        *time.Time `json:"received_at,omitempty"`
+	ReceiveLocationID string     `json:"receive_location_id,omitempty"` // Stock location the parts were received into
+
+	CancelledBy string     `json:"cancelled_by,omitempty"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RequisitionLine is one part requested
+type RequisitionLine struct {
+	SparePartID string  `json:"spare_part_id,omitempty"` // Catalog part, if known
+	PartNumber  string  `json:"part_number"`
+	Description string  `json:"description,omitempty"`
+	Quantity    int     `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price,omitempty"`
+}
+
+// PartsOrder is the internal purchase order placed for an approved requisition
+type PartsOrder struct {
+	SupplierID       string     `json:"supplier_id"`
+	SupplierName     string     `json:"supplier_name"`
+	ExpectedDelivery *time.Time `json:"expected_delivery"`
+}
+
+// Shipment is the supplier's dispatch of an ordered requisition
+type Shipment struct {
+	Carrier          string     `json:"carrier"`
+	TrackingNumber   string     `json:"tracking_number"`
+	ExpectedDelivery *time.Time `json:"expected_delivery"` // Revised ETA, keeps the order ETA when empty
+}
+
+// Validate checks the requested lines
+func (r *PartsRequisition) Validate() error {
+	if strings.TrimSpace(r.TicketID) == "" {
+		return fmt.Errorf("%w: ticket is required", ErrInvalidRequisition)
+	}
+	if len(r.Lines) == 0 {
+		return fmt.Errorf("%w: at least one part is required", ErrInvalidRequisition)
+	}
+	for i, line := range r.Lines {
+		if strings.TrimSpace(line.PartNumber) == "" && strings.TrimSpace(line.SparePartID) == "" {
+			return fmt.Errorf("%w: line %d needs a part number", ErrInvalidRequisition, i+1)
+		}
+		if line.Quantity <= 0 {
+			return fmt.Errorf("%w: line %d quantity must be positive", ErrInvalidRequisition, i+1)
+		}
+	}
+	return nil
+}
+
+// IsOpen reports whether the requisition still holds up the ticket
+func (r *PartsRequisition) IsOpen() bool {
+	switch r.Status {
+	case RequisitionRequested, RequisitionApproved, RequisitionOrdered, RequisitionShipped:
+		return true
+	}
+	return false
+}
+
+// IsLive reports whether the requisition counts towards the ticket's parts
+// workflow, i.e. it was neither rejected nor cancelled
+func (r *PartsRequisition) IsLive() bool {
+	return r.Status != RequisitionRejected && r.Status != RequisitionCancelled
+}
+
+// Approve records the service manager's approval
+func (r *PartsRequisition) Approve(by, role, notes string, now time.Time) error {
+	if !CanApproveRequisition(role) {
+		return ErrRequisitionApprovalRole
+	}
+	if r.Status != RequisitionRequested {
+		return r.transitionError(RequisitionApproved)
+	}
+	r.Status = RequisitionApproved
+	r.DecidedBy = by
+	r.DecidedAt = &now
+	r.DecisionReason = notes
+	r.UpdatedAt = now
+	return nil
+}
+
+// Reject records the service manager's rejection
+func (r *PartsRequisition) Reject(by, role, reason string, now time.Time) error {
+	if !CanApproveRequisition(role) {
+		return ErrRequisitionApprovalRole
+	}
+	if r.Status != RequisitionRequested {
+		return r.transitionError(RequisitionRejected)
+	}
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("%w: a rejection reason is required", ErrInvalidRequisition)
+	}
+	r.Status = RequisitionRejected
+	r.DecidedBy = by
+	r.DecidedAt = &now
+	r.DecisionReason = reason
+	r.UpdatedAt = now
+	return nil
+}
+
+// PlaceOrder records the internal purchase order placed with the supplier
+func (r *PartsRequisition) PlaceOrder(order PartsOrder, orderNumber, by string, now time.Time) error {
+	if r.Status != RequisitionApproved {
+		return r.transitionError(RequisitionOrdered)
+	}
+	if strings.TrimSpace(order.SupplierID) == "" && strings.TrimSpace(order.SupplierName) == "" {
+		return fmt.Errorf("%w: supplier is required", ErrInvalidRequisition)
+	}
+	r.Status = RequisitionOrdered
+	r.SupplierID = order.SupplierID
+	r.SupplierName = order.SupplierName
+	r.OrderNumber = orderNumber
+	r.OrderedBy = by
+	r.OrderedAt = &now
+	r.ExpectedDelivery = order.ExpectedDelivery
+	r.UpdatedAt = now
+	return nil
+}
+
+// Ship records the supplier's dispatch. A shipped requisition may be shipped
+// again to update the tracking details or ETA.
+func (r *PartsRequisition) Ship(shipment Shipment, now time.Time) error {
+	if r.Status != RequisitionOrdered && r.Status != RequisitionShipped {
+		return r.transitionError(RequisitionShipped)
+	}
+	if r.ShippedAt == nil {
+		r.ShippedAt = &now
+	}
+	r.Status = RequisitionShipped
+	if shipment.Carrier != "" {
+		r.Carrier = shipment.Carrier
+	}
+	if shipment.TrackingNumber != "" {
+		r.TrackingNumber = shipment.TrackingNumber
+	}
+	if shipment.ExpectedDelivery != nil {
+		r.ExpectedDelivery = shipment.ExpectedDelivery
+	}
+	r.UpdatedAt = now
+	return nil
+}
+
+// Receive records that the parts arrived
+func (r *PartsRequisition) Receive(by, locationID string, now time.Time) error {
+	if r.Status != RequisitionOrdered && r.Status != RequisitionShipped {
+		return r.transitionError(RequisitionReceived)
+	}
+	r.Status = RequisitionReceived
+	r.ReceivedBy = by
+	r.ReceivedAt = &now
+	r.ReceiveLocationID = locationID
+	r.UpdatedAt = now
+	return nil
+}
+
+// Cancel withdraws a requisition that has not been received yet
+func (r *PartsRequisition) Cancel(by, reason string, now time.Time) error {
+	if !r.IsOpen() {
+		return r.transitionError(RequisitionCancelled)
+	}
+	r.Status = RequisitionCancelled
+	r.CancelledBy = by
+	r.CancelledAt = &now
+	if reason != "" {
+		r.DecisionReason = reason
+	}
+	r.UpdatedAt = now
+	return nil
+}
+
+func (r *PartsRequisition) transitionError(to RequisitionStatus) error {
+	return fmt.Errorf("%w: %s to %s", ErrInvalidRequisitionTransition, r.Status, to)
+}
+
+// PartsProgress summarises a ticket's live requisitions for its timeline
+type PartsProgress struct {
+	Stage      MilestoneStage // Parts milestone the ticket is waiting on, MilestonePartsReceived once all arrived
+	Status     string         // Customer-facing parts status: awaiting_approval, ordering, ordered, in_transit, received
+	OrderedAt  *time.Time     // When the last requisition was ordered, once all are ordered
+	ShippedAt  *time.Time     // When the last requisition shipped, once all have shipped or arrived
+	ReceivedAt *time.Time     // When the last requisition arrived, once all have arrived
+	ETA        *time.Time     // Latest expected delivery of the requisitions still on their way
+}
+
+// SummarizeRequisitions derives the ticket's parts progress from its
+// requisitions. It returns nil when no requisition is live. The least
+// advanced requisition decides the stage.
+func SummarizeRequisitions(requisitions []*PartsRequisition) *PartsProgress {
+	var live []*PartsRequisition
+	for _, r := range requisitions {
+		if r.IsLive() {
+			live = append(live, r)
+		}
+	}
+	if len(live) == 0 {
+		return nil
+	}
+
+	counts := map[RequisitionStatus]int{}
+	progress := &PartsProgress{}
+	for _, r := range live {
+		counts[r.Status]++
+		progress.OrderedAt = latest(progress.OrderedAt, r.OrderedAt)
+		progress.ShippedAt = latest(progress.ShippedAt, r.ShippedAt)
+		progress.ReceivedAt = latest(progress.ReceivedAt, r.ReceivedAt)
+		if r.Status != RequisitionReceived {
+			progress.ETA = latest(progress.ETA, r.ExpectedDelivery)
+		}
+	}
+
+	switch {
+	case counts[RequisitionRequested] > 0:
+		progress.Stage, progress.Status = MilestonePartsOrdered, "awaiting_approval"
+	case counts[RequisitionApproved] > 0:
+		progress.Stage, progress.Status = MilestonePartsOrdered, "ordering"
+	case counts[RequisitionOrdered] > 0:
+		progress.Stage, progress.Status = MilestonePartsDelivery, "ordered"
+	case counts[RequisitionShipped] > 0:
+		progress.Stage, progress.Status = MilestonePartsDelivery, "in_transit"
+	default:
+		progress.Stage, progress.Status = MilestonePartsReceived, "received"
+	}
+
+	// Milestone times only count once every live requisition got there
+	if progress.Stage == MilestonePartsOrdered {
+		progress.OrderedAt = nil
+	}
+	if progress.Stage == MilestonePartsOrdered || counts[RequisitionOrdered] > 0 {
+		progress.ShippedAt = nil
+	}
+	if progress.Stage != MilestonePartsReceived {
+		progress.ReceivedAt = nil
+	}
+	return progress
+}
+
+func latest(current, candidate *time.Time) *time.Time {
+	if candidate == nil || (current != nil && !candidate.After(*current)) {
+		return current
+	}
+	return candidate
+}
+
+// RequisitionRepository persists parts requisitions
+type RequisitionRepository interface {
+	Create(ctx context.Context, requisition *PartsRequisition) error
+	Update(ctx context.Context, requisition *PartsRequisition) error
+	GetByID(ctx context.Context, id string) (*PartsRequisition, error)
+	// ListByTicket retrieves a ticket's requisitions, oldest first
+	ListByTicket(ctx context.Context, ticketID string) ([]*PartsRequisition, error)
+	// List retrieves requisitions, newest first, optionally filtered by status
+	List(ctx context.Context, status []RequisitionStatus) ([]*PartsRequisition, error)
+}
+
+// GenerateRequisitionNumber generates a requisition number such as
+// PRQ-TKT-20261018-153045-2 from the ticket number and its requisition count
+func GenerateRequisitionNumber(ticketNumber string, sequence int) string {
+	return fmt.Sprintf("PRQ-%s-%d", ticketNumber, sequence)
+}
+
+// OrderNumberFor derives the internal purchase order number from the requisition number
+func OrderNumberFor(requisitionNumber string) string {
+	return "IPO-" + strings.TrimPrefix(requisitionNumber, "PRQ-")
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestRequisition() *PartsRequisition {
+	return &PartsRequisition{
+		TicketID: "ticket-1",
+		Status:   RequisitionRequested,
+		Lines:    []RequisitionLine{{PartNumber: "XR-100", Quantity: 1}},
+	}
+}
+
+func TestRequisitionWorkflow(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	eta := now.Add(72 * time.Hour)
+	r := newTestRequisition()
+
+	if err := r.Approve("eng-1", "engineer", "", now); !errors.Is(err, ErrRequisitionApprovalRole) {
+		t.Fatalf("expected engineers not to approve, got %v", err)
+	}
+	if err := r.PlaceOrder(PartsOrder{SupplierName: "Acme"}, "IPO-1", "buyer", now); !errors.Is(err, ErrInvalidRequisitionTransition) {
+		t.Fatalf("expected ordering before approval to fail, got %v", err)
+	}
+	if err := r.Approve("mgr-1", "Service_Manager", "", now); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if err := r.PlaceOrder(PartsOrder{}, "IPO-1", "buyer", now); !errors.Is(err, ErrInvalidRequisition) {
+		t.Fatalf("expected a supplier to be required, got %v", err)
+	}
+	if err := r.PlaceOrder(PartsOrder{SupplierName: "Acme", ExpectedDelivery: &eta}, "IPO-1", "buyer", now); err != nil {
+		t.Fatalf("order: %v", err)
+	}
+
+	revised := eta.Add(24 * time.Hour)
+	if err := r.Ship(Shipment{Carrier: "DHL", TrackingNumber: "123"}, now.Add(time.Hour)); err != nil {
+		t.Fatalf("ship: %v", err)
+	}
+	if err := r.Ship(Shipment{ExpectedDelivery: &revised}, now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("update shipment: %v", err)
+	}
+	if !r.ExpectedDelivery.Equal(revised) || r.Carrier != "DHL" || !r.ShippedAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected shipment: %+v", r)
+	}
+	if err := r.Receive("eng-1", "", now.Add(48*time.Hour)); err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+	if err := r.Cancel("mgr-1", "", now); !errors.Is(err, ErrInvalidRequisitionTransition) {
+		t.Fatalf("expected received requisitions not to be cancelled, got %v", err)
+	}
+}
+
+func TestSummarizeRequisitions(t *testing.T) {
+	day := func(d int) *time.Time {
+		v := time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	req := func(status RequisitionStatus, orderedAt, shippedAt, receivedAt, eta *time.Time) *PartsRequisition {
+		return &PartsRequisition{Status: status, OrderedAt: orderedAt, ShippedAt: shippedAt, ReceivedAt: receivedAt, ExpectedDelivery: eta}
+	}
+
+	tests := []struct {
+		name         string
+		requisitions []*PartsRequisition
+		wantNil      bool
+		wantStage    MilestoneStage
+		wantStatus   string
+		wantOrdered  *time.Time
+		wantETA      *time.Time
+	}{
+		{
+			name:         "rejected and cancelled only",
+			requisitions: []*PartsRequisition{req(RequisitionRejected, nil, nil, nil, nil), req(RequisitionCancelled, nil, nil, nil, nil)},
+			wantNil:      true,
+		},
+		{
+			name:         "one awaiting approval holds up the ordering milestone",
+			requisitions: []*PartsRequisition{req(RequisitionRequested, nil, nil, nil, nil), req(RequisitionShipped, day(1), day(2), nil, day(5))},
+			wantStage:    MilestonePartsOrdered,
+			wantStatus:   "awaiting_approval",
+			wantETA:      day(5),
+		},
+		{
+			name:         "all ordered waits for the latest delivery",
+			requisitions: []*PartsRequisition{req(RequisitionOrdered, day(1), nil, nil, day(6)), req(RequisitionShipped, day(3), day(4), nil, day(5))},
+			wantStage:    MilestonePartsDelivery,
+			wantStatus:   "ordered",
+			wantOrdered:  day(3),
+			wantETA:      day(6),
+		},
+		{
+			name:         "received ignores the cancelled one",
+			requisitions: []*PartsRequisition{req(RequisitionReceived, day(1), day(2), day(4), day(3)), req(RequisitionCancelled, nil, nil, nil, nil)},
+			wantStage:    MilestonePartsReceived,
+			wantStatus:   "received",
+			wantOrdered:  day(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := SummarizeRequisitions(tt.requisitions)
+			if tt.wantNil {
+				if progress != nil {
+					t.Fatalf("expected no progress, got %+v", progress)
+				}
+				return
+			}
+			if progress.Stage != tt.wantStage || progress.Status != tt.wantStatus {
+				t.Fatalf("expected %s/%s, got %s/%s", tt.wantStage, tt.wantStatus, progress.Stage, progress.Status)
+			}
+			if !sameTime(progress.OrderedAt, tt.wantOrdered) || !sameTime(progress.ETA, tt.wantETA) {
+				t.Fatalf("unexpected times: ordered %v eta %v", progress.OrderedAt, progress.ETA)
+			}
+		})
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -50,6 +50,7 @@ type TicketTimeline struct {
 	Milestones          []TicketMilestone  `json:"milestones"`
 	EstimatedResolution *time.Time         `json:"estimated_resolution"`
 	RequiresParts       bool               `json:"requires_parts"`
+	PartsStatus         string             `json:"parts_status,omitempty"` // From parts requisitions, see PartsProgress
 	PartsETA            *time.Time         `json:"parts_eta,omitempty"`
 	LastUpdated         time.Time          `json:"last_updated"`
 }
@@ -65,7 +66,7 @@ type PublicTimeline struct {
 	EstimatedResolution *time.Time            `json:"estimated_resolution"`
 	TimeRemaining       string                `json:"time_remaining"`        // Human readable: "2 days, 3 hours"
 	RequiresParts       bool                  `json:"requires_parts"`
-	PartsStatus         string                `json:"parts_status,omitempty"` // "ordering", "in_transit", "received"
+	PartsStatus         string                `json:"parts_status,omitempty"` // "awaiting_approval", "ordering", "ordered", "in_transit", "received"
 	PartsETA            *time.Time            `json:"parts_eta,omitempty"`
 	AssignedEngineer    string                `json:"assigned_engineer,omitempty"`
 	Priority            string                `json:"priority"`
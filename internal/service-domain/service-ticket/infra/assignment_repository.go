@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/aby-med/medical-platform/internal/middleware"
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
 	"github.com/aby-med/medical-platform/internal/pkg/orgfilter"
 	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -45,7 +46,11 @@ func (r *AssignmentRepository) ListEngineers(ctx context.Context, organizationID
 			COALESCE(e.engineer_level, 1) as engineer_level,
 			true as is_active, 
 			e.created_at, 
-			e.updated_at
+			e.updated_at,
+			COALESCE(e.home_region, '') as home_region,
+			COALESCE(e.base_postal_code, '') as base_postal_code,
+			e.base_latitude, e.base_longitude,
+			e.current_latitude, e.current_longitude, e.current_location_at
 		FROM engineers e
 		LEFT JOIN engineer_org_memberships eom ON e.id = eom.engineer_id
 		LEFT JOIN organizations o ON eom.org_id = o.id
@@ -101,14 +106,18 @@ func (r *AssignmentRepository) ListEngineers(ctx context.Context, organizationID
 	for rows.Next() {
 		var eng domain.Engineer
 		var level int
+		var loc engineerLocationColumns
 		err := rows.Scan(
 			&eng.ID, &eng.OrganizationID, &eng.OrganizationName,
 			&eng.Name, &eng.Email, &eng.Phone,
 			&level, &eng.IsActive, &eng.CreatedAt, &eng.UpdatedAt,
+			&eng.HomeRegion, &eng.BasePostalCode,
+			&loc.baseLat, &loc.baseLng, &loc.currentLat, &loc.currentLng, &eng.CurrentLocationAt,
 		)
 		if err != nil {
 			return nil, err
 		}
+		loc.applyTo(&eng)
 		// Convert int level to string format (L1, L2, L3)
 		eng.EngineerLevel = domain.EngineerLevel(fmt.Sprintf("L%d", level))
 		engineers = append(engineers, &eng)
@@ -130,7 +139,11 @@ func (r *AssignmentRepository) GetEngineerByID(ctx context.Context, engineerID s
 			COALESCE(e.engineer_level, 1) as engineer_level,
 			true as is_active,
 			e.created_at,
-			e.updated_at
+			e.updated_at,
+			COALESCE(e.home_region, '') as home_region,
+			COALESCE(e.base_postal_code, '') as base_postal_code,
+			e.base_latitude, e.base_longitude,
+			e.current_latitude, e.current_longitude, e.current_location_at
 		FROM engineers e
 		LEFT JOIN engineer_org_memberships eom ON e.id = eom.engineer_id
 		LEFT JOIN organizations o ON eom.org_id = o.id
@@ -140,14 +153,18 @@ func (r *AssignmentRepository) GetEngineerByID(ctx context.Context, engineerID s
 	
 	var eng domain.Engineer
 	var level int
+	var loc engineerLocationColumns
 	err := r.pool.QueryRow(ctx, query, engineerID).Scan(
 		&eng.ID, &eng.OrganizationID, &eng.OrganizationName,
 		&eng.Name, &eng.Email, &eng.Phone,
 		&level, &eng.IsActive, &eng.CreatedAt, &eng.UpdatedAt,
+		&eng.HomeRegion, &eng.BasePostalCode,
+		&loc.baseLat, &loc.baseLng, &loc.currentLat, &loc.currentLng, &eng.CurrentLocationAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("engineer not found: %w", err)
 	}
+	loc.applyTo(&eng)
 	
 	// Convert int level to string format (L1, L2, L3)
 	eng.EngineerLevel = domain.EngineerLevel(fmt.Sprintf("L%d", level))
@@ -161,6 +178,53 @@ func (r *AssignmentRepository) UpdateEngineerLevel(ctx context.Context, engineer
 	return err
 }
 
+// engineerLocationColumns holds the nullable coordinate columns of an engineer row
+type engineerLocationColumns struct {
+	baseLat, baseLng       *float64
+	currentLat, currentLng *float64
+}
+
+func (c engineerLocationColumns) applyTo(eng *domain.Engineer) {
+	if c.baseLat != nil && c.baseLng != nil {
+		eng.BaseLocation = &geo.Point{Lat: *c.baseLat, Lng: *c.baseLng}
+	}
+	if c.currentLat != nil && c.currentLng != nil {
+		eng.CurrentLocation = &geo.Point{Lat: *c.currentLat, Lng: *c.currentLng}
+	}
+}
+
+// UpdateEngineerBaseLocation sets an engineer's home base; a nil location clears it
+func (r *AssignmentRepository) UpdateEngineerBaseLocation(ctx context.Context, engineerID string, location *geo.Point, postalCode string) error {
+	var lat, lng *float64
+	if location != nil {
+		lat, lng = &location.Lat, &location.Lng
+	}
+	query := `
+		UPDATE engineers
+		SET base_latitude = $2, base_longitude = $3, base_postal_code = NULLIF($4, ''), updated_at = $5
+		WHERE id = $1
+	`
+	tag, err := r.pool.Exec(ctx, query, engineerID, lat, lng, postalCode, time.Now())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrEngineerNotFound
+	}
+	return nil
+}
+
+// UpdateEngineerCurrentLocation records an engineer's last reported position
+func (r *AssignmentRepository) UpdateEngineerCurrentLocation(ctx context.Context, engineerID string, location geo.Point, at time.Time) error {
+	query := `
+		UPDATE engineers
+		SET current_latitude = $2, current_longitude = $3, current_location_at = $4
+		WHERE id = $1 AND (current_location_at IS NULL OR current_location_at <= $4)
+	`
+	_, err := r.pool.Exec(ctx, query, engineerID, location.Lat, location.Lng, at)
+	return err
+}
+
 // ListEngineerEquipmentTypes retrieves all equipment types an engineer can service
 func (r *AssignmentRepository) ListEngineerEquipmentTypes(ctx context.Context, engineerID string) ([]*domain.EngineerEquipmentType, error) {
 	query := `
@@ -0,0 +1,433 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/segmentio/ksuid"
+)
+
+// CampaignRepository implements domain.CampaignRepository
+type CampaignRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCampaignRepository creates a new field safety campaign repository
+func NewCampaignRepository(pool *pgxpool.Pool) *CampaignRepository {
+	return &CampaignRepository{pool: pool}
+}
+
+const campaignColumns = `
+	id, campaign_number, title, type, manufacturer_name, COALESCE(manufacturer_reference,''),
+	COALESCE(description,''), COALESCE(customer_instructions,''), criteria, action, priority,
+	require_evidence, status, due_date, launched_at, completed_at, COALESCE(created_by,''),
+	created_at, updated_at`
+
+// Create creates a campaign
+func (r *CampaignRepository) Create(ctx context.Context, c *domain.Campaign) error {
+	if c.ID == "" {
+		c.ID = ksuid.New().String()
+	}
+	criteria, err := json.Marshal(c.Criteria)
+	if err != nil {
+		return fmt.Errorf("failed to encode campaign criteria: %w", err)
+	}
+
+	query := `
+		INSERT INTO field_safety_campaigns (
+			id, campaign_number, title, type, manufacturer_name, manufacturer_reference,
+			description, customer_instructions, criteria, action, priority,
+			require_evidence, status, due_date, launched_at, completed_at, created_by,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+	`
+	_, err = r.pool.Exec(ctx, query,
+		c.ID, c.CampaignNumber, c.Title, c.Type, c.ManufacturerName, c.ManufacturerRef,
+		c.Description, c.CustomerInstructions, criteria, c.Action, c.Priority,
+		c.RequireEvidence, c.Status, c.DueDate, c.LaunchedAt, c.CompletedAt, c.CreatedBy,
+		c.CreatedAt, c.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create campaign: %w", err)
+	}
+	return nil
+}
+
+// Update updates a campaign
+func (r *CampaignRepository) Update(ctx context.Context, c *domain.Campaign) error {
+	criteria, err := json.Marshal(c.Criteria)
+	if err != nil {
+		return fmt.Errorf("failed to encode campaign criteria: %w", err)
+	}
+
+	query := `
+		UPDATE field_safety_campaigns SET
+			title = $2, type = $3, manufacturer_name = $4, manufacturer_reference = $5,
+			description = $6, customer_instructions = $7, criteria = $8, action = $9, priority = $10,
+			require_evidence = $11, status = $12, due_date = $13, launched_at = $14, completed_at = $15,
+			updated_at = $16
+		WHERE id = $1
+	`
+	tag, err := r.pool.Exec(ctx, query,
+		c.ID, c.Title, c.Type, c.ManufacturerName, c.ManufacturerRef,
+		c.Description, c.CustomerInstructions, criteria, c.Action, c.Priority,
+		c.RequireEvidence, c.Status, c.DueDate, c.LaunchedAt, c.CompletedAt,
+		c.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update campaign: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCampaignNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves a campaign
+func (r *CampaignRepository) GetByID(ctx context.Context, id string) (*domain.Campaign, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+campaignColumns+` FROM field_safety_campaigns WHERE id = $1`, id)
+	c, err := scanCampaign(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCampaignNotFound
+		}
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+	return c, nil
+}
+
+// List retrieves campaigns, newest first, optionally filtered by status
+func (r *CampaignRepository) List(ctx context.Context, status []domain.CampaignStatus) ([]*domain.Campaign, error) {
+	query := `SELECT ` + campaignColumns + ` FROM field_safety_campaigns`
+	args := []interface{}{}
+	if len(status) > 0 {
+		values := make([]string, len(status))
+		for i, s := range status {
+			values[i] = string(s)
+		}
+		query += ` WHERE status = ANY($1)`
+		args = append(args, values)
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	campaigns := []*domain.Campaign{}
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan campaign: %w", err)
+		}
+		campaigns = append(campaigns, c)
+	}
+	return campaigns, rows.Err()
+}
+
+func scanCampaign(row pgx.Row) (*domain.Campaign, error) {
+	var c domain.Campaign
+	var criteria []byte
+	err := row.Scan(
+		&c.ID, &c.CampaignNumber, &c.Title, &c.Type, &c.ManufacturerName, &c.ManufacturerRef,
+		&c.Description, &c.CustomerInstructions, &criteria, &c.Action, &c.Priority,
+		&c.RequireEvidence, &c.Status, &c.DueDate, &c.LaunchedAt, &c.CompletedAt, &c.CreatedBy,
+		&c.CreatedAt, &c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(criteria) > 0 {
+		json.Unmarshal(criteria, &c.Criteria)
+	}
+	return &c, nil
+}
+
+const campaignUnitColumns = `
+	id, campaign_id, equipment_id, serial_number, COALESCE(model_number,''), COALESCE(software_version,''),
+	COALESCE(customer_id,''), COALESCE(customer_name,''), match_reasons, version_unverified, status,
+	COALESCE(ticket_id,''), notified_at, completed_at, COALESCE(completed_by,''), COALESCE(notes,''),
+	created_at, updated_at`
+
+// AddUnits inserts affected units, skipping units already part of the campaign
+func (r *CampaignRepository) AddUnits(ctx context.Context, units []*domain.CampaignUnit) (int, error) {
+	if len(units) == 0 {
+		return 0, nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, u := range units {
+		if u.ID == "" {
+			u.ID = ksuid.New().String()
+		}
+		reasons, _ := json.Marshal(u.MatchReasons)
+		batch.Queue(`
+			INSERT INTO field_safety_campaign_units (
+				id, campaign_id, equipment_id, serial_number, model_number, software_version,
+				customer_id, customer_name, match_reasons, version_unverified, status,
+				created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			ON CONFLICT (campaign_id, equipment_id) DO NOTHING`,
+			u.ID, u.CampaignID, u.EquipmentID, u.SerialNumber, u.ModelNumber, u.SoftwareVersion,
+			u.CustomerID, u.CustomerName, reasons, u.VersionUnverified, u.Status,
+			u.CreatedAt, u.UpdatedAt,
+		)
+	}
+
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+
+	added := 0
+	for range units {
+		tag, err := results.Exec()
+		if err != nil {
+			return added, fmt.Errorf("failed to add campaign units: %w", err)
+		}
+		added += int(tag.RowsAffected())
+	}
+	return added, nil
+}
+
+// GetUnit retrieves one unit of a campaign with its evidence
+func (r *CampaignRepository) GetUnit(ctx context.Context, campaignID, unitID string) (*domain.CampaignUnit, error) {
+	row := r.pool.QueryRow(ctx,
+		`SELECT `+campaignUnitColumns+` FROM field_safety_campaign_units WHERE campaign_id = $1 AND id = $2`,
+		campaignID, unitID,
+	)
+	u, err := scanCampaignUnit(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCampaignUnitNotFound
+		}
+		return nil, fmt.Errorf("failed to get campaign unit: %w", err)
+	}
+	if u.Evidence, err = r.ListEvidence(ctx, u.ID); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// ListUnits retrieves units of a campaign
+func (r *CampaignRepository) ListUnits(ctx context.Context, campaignID string, filter domain.CampaignUnitFilter) ([]*domain.CampaignUnit, error) {
+	query := `SELECT ` + campaignUnitColumns + ` FROM field_safety_campaign_units WHERE campaign_id = $1`
+	args := []interface{}{campaignID}
+	if len(filter.Status) > 0 {
+		values := make([]string, len(filter.Status))
+		for i, s := range filter.Status {
+			values[i] = string(s)
+		}
+		args = append(args, values)
+		query += fmt.Sprintf(` AND status = ANY($%d)`, len(args))
+	}
+	if filter.CustomerID != "" {
+		args = append(args, filter.CustomerID)
+		query += fmt.Sprintf(` AND customer_id = $%d`, len(args))
+	}
+	query += ` ORDER BY customer_name, serial_number`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+	}
+
+	return r.queryUnits(ctx, query, args...)
+}
+
+// ListUnitsForEquipment retrieves campaign units affecting one equipment
+func (r *CampaignRepository) ListUnitsForEquipment(ctx context.Context, equipmentID string) ([]*domain.CampaignUnit, error) {
+	query := `SELECT ` + campaignUnitColumns + ` FROM field_safety_campaign_units WHERE equipment_id = $1 ORDER BY created_at DESC`
+	return r.queryUnits(ctx, query, equipmentID)
+}
+
+func (r *CampaignRepository) queryUnits(ctx context.Context, query string, args ...interface{}) ([]*domain.CampaignUnit, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaign units: %w", err)
+	}
+	defer rows.Close()
+
+	units := []*domain.CampaignUnit{}
+	for rows.Next() {
+		u, err := scanCampaignUnit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan campaign unit: %w", err)
+		}
+		units = append(units, u)
+	}
+	return units, rows.Err()
+}
+
+func scanCampaignUnit(row pgx.Row) (*domain.CampaignUnit, error) {
+	var u domain.CampaignUnit
+	var reasons []byte
+	err := row.Scan(
+		&u.ID, &u.CampaignID, &u.EquipmentID, &u.SerialNumber, &u.ModelNumber, &u.SoftwareVersion,
+		&u.CustomerID, &u.CustomerName, &reasons, &u.VersionUnverified, &u.Status,
+		&u.TicketID, &u.NotifiedAt, &u.CompletedAt, &u.CompletedBy, &u.Notes,
+		&u.CreatedAt, &u.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(reasons) > 0 {
+		json.Unmarshal(reasons, &u.MatchReasons)
+	}
+	return &u, nil
+}
+
+// UpdateUnit updates the remediation state of a unit
+func (r *CampaignRepository) UpdateUnit(ctx context.Context, u *domain.CampaignUnit) error {
+	query := `
+		UPDATE field_safety_campaign_units SET
+			status = $2, ticket_id = NULLIF($3, ''), notified_at = $4, completed_at = $5,
+			completed_by = NULLIF($6, ''), notes = $7, software_version = $8, version_unverified = $9,
+			updated_at = $10
+		WHERE id = $1
+	`
+	tag, err := r.pool.Exec(ctx, query,
+		u.ID, u.Status, u.TicketID, u.NotifiedAt, u.CompletedAt,
+		u.CompletedBy, u.Notes, u.SoftwareVersion, u.VersionUnverified,
+		u.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update campaign unit: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCampaignUnitNotFound
+	}
+	return nil
+}
+
+// AddEvidence attaches remediation evidence to a unit
+func (r *CampaignRepository) AddEvidence(ctx context.Context, e *domain.CampaignEvidence) error {
+	if e.ID == "" {
+		e.ID = ksuid.New().String()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO field_safety_campaign_evidence (id, unit_id, attachment_id, url, description, uploaded_by, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7)`,
+		e.ID, e.UnitID, e.AttachmentID, e.URL, e.Description, e.UploadedBy, e.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add campaign evidence: %w", err)
+	}
+	return nil
+}
+
+// ListEvidence retrieves the evidence of a unit
+func (r *CampaignRepository) ListEvidence(ctx context.Context, unitID string) ([]*domain.CampaignEvidence, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, unit_id, COALESCE(attachment_id,''), COALESCE(url,''), COALESCE(description,''),
+			COALESCE(uploaded_by,''), created_at
+		FROM field_safety_campaign_evidence
+		WHERE unit_id = $1
+		ORDER BY created_at`, unitID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaign evidence: %w", err)
+	}
+	defer rows.Close()
+
+	evidence := []*domain.CampaignEvidence{}
+	for rows.Next() {
+		var e domain.CampaignEvidence
+		if err := rows.Scan(&e.ID, &e.UnitID, &e.AttachmentID, &e.URL, &e.Description, &e.UploadedBy, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan campaign evidence: %w", err)
+		}
+		evidence = append(evidence, &e)
+	}
+	return evidence, rows.Err()
+}
+
+// Progress counts units per status and distinct customers
+func (r *CampaignRepository) Progress(ctx context.Context, campaignID string) (*domain.CampaignProgress, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT status, COUNT(*)
+		FROM field_safety_campaign_units
+		WHERE campaign_id = $1
+		GROUP BY status`, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute campaign progress: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[domain.CampaignUnitStatus]int{}
+	for rows.Next() {
+		var status domain.CampaignUnitStatus
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan campaign progress: %w", err)
+		}
+		counts[status] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var customers int
+	err = r.pool.QueryRow(ctx, `
+		SELECT COUNT(DISTINCT COALESCE(NULLIF(customer_id,''), customer_name))
+		FROM field_safety_campaign_units
+		WHERE campaign_id = $1`, campaignID).Scan(&customers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count campaign customers: %w", err)
+	}
+
+	return domain.NewCampaignProgress(counts, customers), nil
+}
+
+// AddNotification records a field safety notice sent to a customer
+func (r *CampaignRepository) AddNotification(ctx context.Context, n *domain.CampaignNotification) error {
+	if n.ID == "" {
+		n.ID = ksuid.New().String()
+	}
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO field_safety_campaign_notifications (
+			id, campaign_id, customer_id, channel, recipient, unit_count, status, error, sent_by, created_at
+		) VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, NULLIF($8, ''), $9, $10)`,
+		n.ID, n.CampaignID, n.CustomerID, n.Channel, n.Recipient, n.UnitCount, n.Status, n.Error, n.SentBy, n.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record campaign notification: %w", err)
+	}
+	return nil
+}
+
+// ListNotifications retrieves notices sent for a campaign
+func (r *CampaignRepository) ListNotifications(ctx context.Context, campaignID string) ([]*domain.CampaignNotification, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, campaign_id, customer_id, channel, COALESCE(recipient,''), unit_count, status,
+			COALESCE(error,''), COALESCE(sent_by,''), created_at
+		FROM field_safety_campaign_notifications
+		WHERE campaign_id = $1
+		ORDER BY created_at DESC`, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaign notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []*domain.CampaignNotification{}
+	for rows.Next() {
+		var n domain.CampaignNotification
+		if err := rows.Scan(&n.ID, &n.CampaignID, &n.CustomerID, &n.Channel, &n.Recipient, &n.UnitCount,
+			&n.Status, &n.Error, &n.SentBy, &n.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan campaign notification: %w", err)
+		}
+		notifications = append(notifications, &n)
+	}
+	return notifications, rows.Err()
+}
+
+var _ domain.CampaignRepository = (*CampaignRepository)(nil)
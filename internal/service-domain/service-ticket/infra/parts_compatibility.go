@@ -0,0 +1,65 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	inventoryApp "github.com/aby-med/medical-platform/internal/service-domain/inventory/app"
+	inventoryDomain "github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+)
+
+// PartsCompatibility implements domain.PartsCompatibility over the inventory module
+type PartsCompatibility struct {
+	compatibility *inventoryApp.CompatibilityService
+}
+
+// NewPartsCompatibility creates a ticket view of the parts compatibility graph
+func NewPartsCompatibility(compatibility *inventoryApp.CompatibilityService) *PartsCompatibility {
+	return &PartsCompatibility{compatibility: compatibility}
+}
+
+// SelectPart resolves a part ID or number to the part to use on the equipment
+func (p *PartsCompatibility) SelectPart(ctx context.Context, partRef, equipmentID string) (*domain.PartSelection, error) {
+	selection, err := p.compatibility.SelectPart(ctx, partRef, equipmentID)
+	if err != nil {
+		if errors.Is(err, inventoryDomain.ErrIncompatiblePart) {
+			return nil, fmt.Errorf("%w: %v", domain.ErrIncompatiblePart, err)
+		}
+		return nil, err
+	}
+	return toPartSelection(selection), nil
+}
+
+// CompatibleParts lists the current parts fitting the equipment. Equipment
+// missing from the registry has no known compatible parts.
+func (p *PartsCompatibility) CompatibleParts(ctx context.Context, equipmentID, query string) ([]*domain.PartSelection, error) {
+	selections, err := p.compatibility.CompatibleParts(ctx, inventoryApp.CompatiblePartsQuery{
+		EquipmentID: equipmentID,
+		Query:       query,
+	})
+	if errors.Is(err, inventoryDomain.ErrEquipmentNotFound) {
+		return []*domain.PartSelection{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]*domain.PartSelection, 0, len(selections))
+	for _, selection := range selections {
+		parts = append(parts, toPartSelection(selection))
+	}
+	return parts, nil
+}
+
+func toPartSelection(selection *inventoryDomain.PartSelection) *domain.PartSelection {
+	return &domain.PartSelection{
+		SparePartID:     selection.SparePartID,
+		PartNumber:      selection.PartNumber,
+		PartName:        selection.PartName,
+		RequestedPartID: selection.RequestedPartID,
+		Superseded:      selection.Superseded,
+		Alternate:       selection.Alternate,
+		Fit:             string(selection.Fit),
+	}
+}
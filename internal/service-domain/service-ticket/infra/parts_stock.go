@@ -0,0 +1,78 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	inventoryApp "github.com/aby-med/medical-platform/internal/service-domain/inventory/app"
+	inventoryDomain "github.com/aby-med/medical-platform/internal/service-domain/inventory/domain"
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+)
+
+// PartsStock implements domain.PartsStock over the inventory module
+type PartsStock struct {
+	stock *inventoryApp.StockService
+}
+
+// NewPartsStock creates a ticket view of the spare parts stock
+func NewPartsStock(stock *inventoryApp.StockService) *PartsStock {
+	return &PartsStock{stock: stock}
+}
+
+// ReservePart reserves stock for a part attached to a ticket
+func (p *PartsStock) ReservePart(ctx context.Context, ticketID, ticketPartID, sparePartID string, quantity int, engineerID, locationID, reservedBy string) (*domain.PartReservation, error) {
+	reservation, err := p.stock.ReserveForTicket(ctx, inventoryApp.TicketReservationRequest{
+		TicketID:     ticketID,
+		TicketPartID: ticketPartID,
+		SparePartID:  sparePartID,
+		Quantity:     quantity,
+		LocationID:   locationID,
+		EngineerID:   engineerID,
+	}, reservedBy)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PartReservation{ID: reservation.ID, LocationID: reservation.LocationID, Quantity: reservation.Quantity}, nil
+}
+
+// ReleasePart releases the stock held for a part removed from a ticket
+func (p *PartsStock) ReleasePart(ctx context.Context, ticketPartID, releasedBy string) error {
+	_, err := p.stock.ReleaseTicketPart(ctx, ticketPartID, releasedBy)
+	return err
+}
+
+// ReleaseTicket releases all stock held for a ticket
+func (p *PartsStock) ReleaseTicket(ctx context.Context, ticketID, releasedBy string) error {
+	_, err := p.stock.ReleaseForTicket(ctx, ticketID, releasedBy)
+	return err
+}
+
+// ConsumeTicket takes all stock held for a ticket out of stock
+func (p *PartsStock) ConsumeTicket(ctx context.Context, ticketID, consumedBy string) (int, error) {
+	consumed, err := p.stock.ConsumeForTicket(ctx, ticketID, consumedBy)
+	return len(consumed), err
+}
+
+// ReceiveParts books requisitioned parts into a stock location. Every line
+// needs a catalog spare part.
+func (p *PartsStock) ReceiveParts(ctx context.Context, locationID, reference string, lines []domain.RequisitionLine, receivedBy string) error {
+	stockLines := make([]inventoryDomain.StockLine, 0, len(lines))
+	for _, line := range lines {
+		if line.SparePartID == "" {
+			return fmt.Errorf("%w: part %s is not in the spare parts catalog", domain.ErrInvalidRequisition, line.PartNumber)
+		}
+		stockLines = append(stockLines, inventoryDomain.StockLine{SparePartID: line.SparePartID, Quantity: line.Quantity})
+	}
+	_, err := p.stock.ReceiveStock(ctx, inventoryApp.ReceiveStockRequest{
+		LocationID: locationID,
+		Lines:      stockLines,
+		Reference:  reference,
+		Notes:      "Parts requisition " + reference,
+	}, receivedBy)
+	if errors.Is(err, inventoryDomain.ErrLocationNotFound) || errors.Is(err, inventoryDomain.ErrInvalidLocation) ||
+		errors.Is(err, inventoryDomain.ErrInvalidMovement) {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidRequisition, err)
+	}
+	return err
+}
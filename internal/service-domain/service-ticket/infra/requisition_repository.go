@@ -0,0 +1,158 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/segmentio/ksuid"
+)
+
+// RequisitionRepository implements domain.RequisitionRepository
+type RequisitionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRequisitionRepository creates a new parts requisition repository
+func NewRequisitionRepository(pool *pgxpool.Pool) *RequisitionRepository {
+	return &RequisitionRepository{pool: pool}
+}
+
+const requisitionColumns = `
+	id, requisition_number, ticket_id, status, urgent, COALESCE(reason,''), lines, COALESCE(requested_by,''),
+	COALESCE(decided_by,''), decided_at, COALESCE(decision_reason,''),
+	COALESCE(supplier_id,''), COALESCE(supplier_name,''), COALESCE(order_number,''), COALESCE(ordered_by,''),
+	ordered_at, expected_delivery, COALESCE(carrier,''), COALESCE(tracking_number,''), shipped_at,
+	COALESCE(received_by,''), received_at, COALESCE(receive_location_id,''),
+	COALESCE(cancelled_by,''), cancelled_at, created_at, updated_at`
+
+// Create creates a requisition
+func (r *RequisitionRepository) Create(ctx context.Context, req *domain.PartsRequisition) error {
+	if req.ID == "" {
+		req.ID = ksuid.New().String()
+	}
+	lines, err := json.Marshal(req.Lines)
+	if err != nil {
+		return fmt.Errorf("failed to encode requisition lines: %w", err)
+	}
+
+	query := `
+		INSERT INTO ticket_parts_requisitions (
+			id, requisition_number, ticket_id, status, urgent, reason, lines, requested_by,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err = r.pool.Exec(ctx, query,
+		req.ID, req.RequisitionNumber, req.TicketID, req.Status, req.Urgent, req.Reason, lines, req.RequestedBy,
+		req.CreatedAt, req.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create parts requisition: %w", err)
+	}
+	return nil
+}
+
+// Update updates the workflow state of a requisition
+func (r *RequisitionRepository) Update(ctx context.Context, req *domain.PartsRequisition) error {
+	query := `
+		UPDATE ticket_parts_requisitions SET
+			status = $2, decided_by = $3, decided_at = $4, decision_reason = $5,
+			supplier_id = $6, supplier_name = $7, order_number = $8, ordered_by = $9, ordered_at = $10,
+			expected_delivery = $11, carrier = $12, tracking_number = $13, shipped_at = $14,
+			received_by = $15, received_at = $16, receive_location_id = $17,
+			cancelled_by = $18, cancelled_at = $19, updated_at = $20
+		WHERE id = $1
+	`
+	tag, err := r.pool.Exec(ctx, query,
+		req.ID, req.Status, req.DecidedBy, req.DecidedAt, req.DecisionReason,
+		req.SupplierID, req.SupplierName, req.OrderNumber, req.OrderedBy, req.OrderedAt,
+		req.ExpectedDelivery, req.Carrier, req.TrackingNumber, req.ShippedAt,
+		req.ReceivedBy, req.ReceivedAt, req.ReceiveLocationID,
+		req.CancelledBy, req.CancelledAt, req.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update parts requisition: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrRequisitionNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves a requisition
+func (r *RequisitionRepository) GetByID(ctx context.Context, id string) (*domain.PartsRequisition, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+requisitionColumns+` FROM ticket_parts_requisitions WHERE id = $1`, id)
+	req, err := scanRequisition(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRequisitionNotFound
+		}
+		return nil, fmt.Errorf("failed to get parts requisition: %w", err)
+	}
+	return req, nil
+}
+
+// ListByTicket retrieves a ticket's requisitions, oldest first
+func (r *RequisitionRepository) ListByTicket(ctx context.Context, ticketID string) ([]*domain.PartsRequisition, error) {
+	return r.query(ctx, `SELECT `+requisitionColumns+` FROM ticket_parts_requisitions
+		WHERE ticket_id = $1 ORDER BY created_at ASC`, ticketID)
+}
+
+// List retrieves requisitions, newest first, optionally filtered by status
+func (r *RequisitionRepository) List(ctx context.Context, status []domain.RequisitionStatus) ([]*domain.PartsRequisition, error) {
+	query := `SELECT ` + requisitionColumns + ` FROM ticket_parts_requisitions`
+	args := []interface{}{}
+	if len(status) > 0 {
+		values := make([]string, len(status))
+		for i, s := range status {
+			values[i] = string(s)
+		}
+		query += ` WHERE status = ANY($1)`
+		args = append(args, values)
+	}
+	query += ` ORDER BY created_at DESC`
+	return r.query(ctx, query, args...)
+}
+
+func (r *RequisitionRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.PartsRequisition, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parts requisitions: %w", err)
+	}
+	defer rows.Close()
+
+	requisitions := []*domain.PartsRequisition{}
+	for rows.Next() {
+		req, err := scanRequisition(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan parts requisition: %w", err)
+		}
+		requisitions = append(requisitions, req)
+	}
+	return requisitions, rows.Err()
+}
+
+func scanRequisition(row pgx.Row) (*domain.PartsRequisition, error) {
+	var req domain.PartsRequisition
+	var lines []byte
+	err := row.Scan(
+		&req.ID, &req.RequisitionNumber, &req.TicketID, &req.Status, &req.Urgent, &req.Reason, &lines, &req.RequestedBy,
+		&req.DecidedBy, &req.DecidedAt, &req.DecisionReason,
+		&req.SupplierID, &req.SupplierName, &req.OrderNumber, &req.OrderedBy,
+		&req.OrderedAt, &req.ExpectedDelivery, &req.Carrier, &req.TrackingNumber, &req.ShippedAt,
+		&req.ReceivedBy, &req.ReceivedAt, &req.ReceiveLocationID,
+		&req.CancelledBy, &req.CancelledAt, &req.CreatedAt, &req.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Lines = []domain.RequisitionLine{}
+	if len(lines) > 0 {
+		json.Unmarshal(lines, &req.Lines)
+	}
+	return &req, nil
+}
@@ -243,6 +243,135 @@ CREATE TABLE IF NOT EXISTS webhook_deliveries (
     delivered_at TIMESTAMP WITH TIME ZONE NULL
 );
 CREATE INDEX IF NOT EXISTS idx_deliveries_status ON webhook_deliveries(status);
+
+-- Engineer base and current locations for proximity-based assignment
+ALTER TABLE IF EXISTS engineers ADD COLUMN IF NOT EXISTS base_latitude DOUBLE PRECISION;
+ALTER TABLE IF EXISTS engineers ADD COLUMN IF NOT EXISTS base_longitude DOUBLE PRECISION;
+ALTER TABLE IF EXISTS engineers ADD COLUMN IF NOT EXISTS base_postal_code VARCHAR(20);
+ALTER TABLE IF EXISTS engineers ADD COLUMN IF NOT EXISTS current_latitude DOUBLE PRECISION;
+ALTER TABLE IF EXISTS engineers ADD COLUMN IF NOT EXISTS current_longitude DOUBLE PRECISION;
+ALTER TABLE IF EXISTS engineers ADD COLUMN IF NOT EXISTS current_location_at TIMESTAMP WITH TIME ZONE;
+`
+
+    _, err := pool.Exec(ctx, schema)
+    return err
+}
+
+// EnsureCampaignSchema creates the field safety campaign tables if they don't exist.
+func EnsureCampaignSchema(ctx context.Context, pool *pgxpool.Pool) error {
+    schema := `
+CREATE TABLE IF NOT EXISTS field_safety_campaigns (
+    id VARCHAR(32) PRIMARY KEY,
+    campaign_number VARCHAR(50) UNIQUE NOT NULL,
+    title VARCHAR(500) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    manufacturer_name VARCHAR(255) NOT NULL,
+    manufacturer_reference VARCHAR(255),
+    description TEXT,
+    customer_instructions TEXT,
+    criteria JSONB NOT NULL DEFAULT '{}'::jsonb,
+    action VARCHAR(20) NOT NULL DEFAULT 'ticket', -- ticket|task
+    priority VARCHAR(20) NOT NULL DEFAULT 'high',
+    require_evidence BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft', -- draft|active|completed|cancelled
+    due_date TIMESTAMP WITH TIME ZONE,
+    launched_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_fsc_status ON field_safety_campaigns(status);
+
+CREATE TABLE IF NOT EXISTS field_safety_campaign_units (
+    id VARCHAR(32) PRIMARY KEY,
+    campaign_id VARCHAR(32) NOT NULL REFERENCES field_safety_campaigns(id) ON DELETE CASCADE,
+    equipment_id VARCHAR(32) NOT NULL,
+    serial_number VARCHAR(255) NOT NULL,
+    model_number VARCHAR(255),
+    software_version VARCHAR(100),
+    customer_id VARCHAR(255),
+    customer_name VARCHAR(500),
+    match_reasons JSONB NOT NULL DEFAULT '[]'::jsonb,
+    version_unverified BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(30) NOT NULL DEFAULT 'pending',
+    ticket_id VARCHAR(32),
+    notified_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    completed_by VARCHAR(255),
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (campaign_id, equipment_id)
+);
+CREATE INDEX IF NOT EXISTS idx_fsc_units_campaign_status ON field_safety_campaign_units(campaign_id, status);
+CREATE INDEX IF NOT EXISTS idx_fsc_units_equipment ON field_safety_campaign_units(equipment_id);
+CREATE INDEX IF NOT EXISTS idx_fsc_units_ticket ON field_safety_campaign_units(ticket_id);
+
+CREATE TABLE IF NOT EXISTS field_safety_campaign_evidence (
+    id VARCHAR(32) PRIMARY KEY,
+    unit_id VARCHAR(32) NOT NULL REFERENCES field_safety_campaign_units(id) ON DELETE CASCADE,
+    attachment_id VARCHAR(64),
+    url TEXT,
+    description TEXT,
+    uploaded_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_fsc_evidence_unit ON field_safety_campaign_evidence(unit_id);
+
+CREATE TABLE IF NOT EXISTS field_safety_campaign_notifications (
+    id VARCHAR(32) PRIMARY KEY,
+    campaign_id VARCHAR(32) NOT NULL REFERENCES field_safety_campaigns(id) ON DELETE CASCADE,
+    customer_id VARCHAR(255) NOT NULL,
+    channel VARCHAR(20) NOT NULL, -- email|webhook
+    recipient VARCHAR(255),
+    unit_count INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL, -- sent|failed
+    error TEXT,
+    sent_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_fsc_notifications_campaign ON field_safety_campaign_notifications(campaign_id);
+`
+
+    _, err := pool.Exec(ctx, schema)
+    return err
+}
+
+// EnsureRequisitionSchema creates the parts requisition table if it doesn't exist.
+func EnsureRequisitionSchema(ctx context.Context, pool *pgxpool.Pool) error {
+    schema := `
+CREATE TABLE IF NOT EXISTS ticket_parts_requisitions (
+    id VARCHAR(32) PRIMARY KEY,
+    requisition_number VARCHAR(80) UNIQUE NOT NULL,
+    ticket_id VARCHAR(32) NOT NULL REFERENCES service_tickets(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'requested', -- requested|approved|rejected|ordered|shipped|received|cancelled
+    urgent BOOLEAN NOT NULL DEFAULT false,
+    reason TEXT,
+    lines JSONB NOT NULL DEFAULT '[]'::jsonb,
+    requested_by VARCHAR(255),
+    decided_by VARCHAR(255),
+    decided_at TIMESTAMP WITH TIME ZONE,
+    decision_reason TEXT,
+    supplier_id VARCHAR(255),
+    supplier_name VARCHAR(500),
+    order_number VARCHAR(80),
+    ordered_by VARCHAR(255),
+    ordered_at TIMESTAMP WITH TIME ZONE,
+    expected_delivery TIMESTAMP WITH TIME ZONE,
+    carrier VARCHAR(255),
+    tracking_number VARCHAR(255),
+    shipped_at TIMESTAMP WITH TIME ZONE,
+    received_by VARCHAR(255),
+    received_at TIMESTAMP WITH TIME ZONE,
+    receive_location_id VARCHAR(64),
+    cancelled_by VARCHAR(255),
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_tpr_ticket ON ticket_parts_requisitions(ticket_id);
+CREATE INDEX IF NOT EXISTS idx_tpr_status ON ticket_parts_requisitions(status);
 `
 
     _, err := pool.Exec(ctx, schema)
@@ -10,7 +10,10 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/aby-med/medical-platform/internal/pkg/geo"
 	equipmentInfra "github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/infra"
+	inventoryApp "github.com/aby-med/medical-platform/internal/service-domain/inventory/app"
+	inventoryInfra "github.com/aby-med/medical-platform/internal/service-domain/inventory/infra"
 	"github.com/aby-med/medical-platform/internal/service-domain/equipment-registry/qrcode"
 	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/api"
 	"github.com/aby-med/medical-platform/internal/service-domain/service-ticket/app"
@@ -30,6 +33,8 @@ type Module struct {
 	ticketHandler              *api.TicketHandler
 	assignmentHandler          *api.AssignmentHandler
 	multiModelAssignmentHandler *api.MultiModelAssignmentHandler
+	campaignHandler            *api.CampaignHandler
+	requisitionHandler         *api.RequisitionHandler
 	whatsappHandler            *whatsapp.WebhookHandler
 	logger                     *slog.Logger
 	dispatcher                 *app.WebhookDispatcher
@@ -53,6 +58,7 @@ type ModuleConfig struct {
 	WhatsAppAccessToken string
 	WhatsAppPhoneID     string
 	WhatsAppMediaDir    string
+	AssignmentRadiusBands string // Proximity bands, e.g. "local:10:90,metro:30:75,outside:5"
 }
 
 // NewModule creates a new service ticket module
@@ -85,6 +91,12 @@ func (m *Module) Initialize(ctx context.Context) error {
     if err := infra.EnsureServiceTicketSchema(ctx, pool); err != nil {
         return fmt.Errorf("failed to ensure service ticket schema: %w", err)
     }
+    if err := infra.EnsureCampaignSchema(ctx, pool); err != nil {
+        return fmt.Errorf("failed to ensure campaign schema: %w", err)
+    }
+    if err := infra.EnsureRequisitionSchema(ctx, pool); err != nil {
+        return fmt.Errorf("failed to ensure parts requisition schema: %w", err)
+    }
 
 	// Create repositories
 	ticketRepo := infra.NewTicketRepository(pool)
@@ -98,6 +110,15 @@ func (m *Module) Initialize(ctx context.Context) error {
     policyRepo := infra.NewPolicyRepository(pool)
     eventRepo := infra.NewEventRepository(pool)
     ticketService := app.NewTicketService(ticketRepo, equipmentRepo, policyRepo, eventRepo, m.logger)
+
+	// Reserve stock for parts attached to tickets, consumed on resolve
+	if err := inventoryInfra.EnsureInventorySchema(ctx, pool); err != nil {
+		return fmt.Errorf("failed to ensure inventory schema: %w", err)
+	}
+	stockService := inventoryApp.NewStockService(inventoryInfra.NewStockRepository(pool), m.logger)
+	ticketService.SetPartsStock(infra.NewPartsStock(stockService))
+	compatibilityService := inventoryApp.NewCompatibilityService(inventoryInfra.NewCompatibilityRepository(pool), m.logger)
+	ticketService.SetPartsCompatibility(infra.NewPartsCompatibility(compatibilityService))
 	
 	// Create notification service
 	// TODO: Replace nil with actual email service when configured
@@ -114,13 +135,22 @@ func (m *Module) Initialize(ctx context.Context) error {
 
 	// Create timeline service for SLA/ETA tracking
 	timelineService := app.NewTimelineService(ticketRepo, m.logger)
+	requisitionRepo := infra.NewRequisitionRepository(pool)
+	timelineService.SetRequisitionRepository(requisitionRepo)
+	notificationService.SetTimelineService(timelineService)
 	m.logger.Info("Timeline service initialized")
 
 	// Create assignment service
 	assignmentService := app.NewAssignmentService(assignmentRepo, ticketRepo, pool, m.logger)
+	assignmentService.SetPostalCodeLookup(equipmentInfra.NewGeoRepository(pool))
 	
 	// Create multi-model assignment service
 	multiModelService := app.NewMultiModelAssignmentService(assignmentRepo, ticketRepo, equipmentRepo, pool, m.logger)
+	if bands, err := geo.ParseBands(m.config.AssignmentRadiusBands); err != nil {
+		m.logger.Warn("Invalid assignment radius bands, using defaults", slog.String("error", err.Error()))
+	} else {
+		multiModelService.SetRadiusBands(bands)
+	}
 
     // Create dispatcher (started conditionally)
     m.dispatcher = app.NewWebhookDispatcher(pool, m.logger)
@@ -141,6 +171,14 @@ func (m *Module) Initialize(ctx context.Context) error {
 	m.assignmentHandler = api.NewAssignmentHandler(assignmentService, m.logger)
 	m.multiModelAssignmentHandler = api.NewMultiModelAssignmentHandler(multiModelService, m.logger)
 
+	// Create field safety campaign service (customers are notified by webhook until email is configured)
+	campaignService := app.NewCampaignService(infra.NewCampaignRepository(pool), ticketService, equipmentRepo, eventRepo, nil, m.logger)
+	m.campaignHandler = api.NewCampaignHandler(campaignService, m.logger)
+
+	// Create parts requisition service (drives the parts milestones of the timeline)
+	requisitionService := app.NewRequisitionService(requisitionRepo, ticketService, eventRepo, m.logger)
+	m.requisitionHandler = api.NewRequisitionHandler(requisitionService, m.logger)
+
 	// Create QR generator for WhatsApp
 	qrGenerator := qrcode.NewGenerator(m.config.BaseURL, m.config.QROutputDir)
 
@@ -221,6 +259,9 @@ func (m *Module) MountRoutes(r chi.Router) {
 		r.Post("/{id}/parts", m.ticketHandler.AddTicketPart)       // Add single part to ticket
 		r.Delete("/{id}/parts/{partId}", m.ticketHandler.DeleteTicketPart) // Delete specific part
 		r.Patch("/{id}/parts", m.ticketHandler.UpdateParts)        // Update parts for ticket
+		r.Get("/{id}/compatible-parts", m.ticketHandler.ListCompatibleParts) // Current parts fitting the ticket's equipment
+		r.Post("/{id}/requisitions", m.requisitionHandler.CreateRequisition)     // Raise parts requisition
+		r.Get("/{id}/requisitions", m.requisitionHandler.ListTicketRequisitions) // Parts requisitions of ticket
 		
 		// Admin-only: Update ticket priority
 		// NOTE: In production, add proper JWT auth middleware to verify admin role
@@ -265,6 +306,8 @@ func (m *Module) MountRoutes(r chi.Router) {
 		r.Post("/import", m.assignmentHandler.ImportEngineersCSV) // CSV import
 		r.Get("/{id}", m.assignmentHandler.GetEngineer)         // Get engineer details
 		r.Put("/{id}/level", m.assignmentHandler.UpdateEngineerLevel) // Update engineer level
+		r.Put("/{id}/location", m.assignmentHandler.UpdateEngineerBaseLocation)        // Set base location (coordinates or postal code)
+		r.Post("/{id}/location/current", m.assignmentHandler.ReportEngineerLocation)  // Report current position
 		
 		// Engineer equipment type capabilities
 		r.Get("/{id}/equipment-types", m.assignmentHandler.ListEngineerEquipmentTypes)    // List capabilities
@@ -272,6 +315,37 @@ func (m *Module) MountRoutes(r chi.Router) {
 		r.Delete("/{id}/equipment-types", m.assignmentHandler.RemoveEngineerEquipmentType) // Remove capability
 	})
 
+	// Recall and field safety campaign routes
+	r.Route("/campaigns", func(r chi.Router) {
+		r.Post("/", m.campaignHandler.CreateCampaign)                          // Create draft campaign
+		r.Get("/", m.campaignHandler.ListCampaigns)                            // List campaigns with progress
+		r.Get("/equipment/{equipment_id}", m.campaignHandler.ListEquipmentCampaigns) // Campaigns affecting a unit
+		r.Get("/{id}", m.campaignHandler.GetCampaign)                          // Get campaign with progress
+		r.Post("/{id}/preview", m.campaignHandler.PreviewCampaign)             // Match installed base (dry run)
+		r.Post("/{id}/launch", m.campaignHandler.LaunchCampaign)               // Match, record units, generate tickets
+		r.Post("/{id}/tickets", m.campaignHandler.GenerateTickets)             // Generate missing tickets
+		r.Post("/{id}/sync", m.campaignHandler.SyncTickets)                    // Update units from ticket status
+		r.Post("/{id}/notify", m.campaignHandler.NotifyCustomers)              // Send field safety notices
+		r.Post("/{id}/cancel", m.campaignHandler.CancelCampaign)               // Cancel campaign
+		r.Get("/{id}/notifications", m.campaignHandler.ListNotifications)      // Notices sent
+		r.Get("/{id}/units", m.campaignHandler.ListUnits)                      // Affected units
+		r.Get("/{id}/units/{unit_id}", m.campaignHandler.GetUnit)              // Unit with evidence
+		r.Patch("/{id}/units/{unit_id}", m.campaignHandler.UpdateUnit)         // Update unit status
+		r.Post("/{id}/units/{unit_id}/evidence", m.campaignHandler.AddEvidence) // Attach evidence
+	})
+
+	// Parts requisition workflow routes
+	r.Route("/parts-requisitions", func(r chi.Router) {
+		r.Get("/", m.requisitionHandler.ListRequisitions)                // List, e.g. ?status=requested for approval
+		r.Get("/{id}", m.requisitionHandler.GetRequisition)              // Get requisition
+		r.Post("/{id}/approve", m.requisitionHandler.ApproveRequisition) // Service manager approval
+		r.Post("/{id}/reject", m.requisitionHandler.RejectRequisition)   // Service manager rejection
+		r.Post("/{id}/order", m.requisitionHandler.PlaceOrder)           // Place internal PO with supplier
+		r.Post("/{id}/ship", m.requisitionHandler.ShipRequisition)       // Record shipment, tracking and ETA
+		r.Post("/{id}/receive", m.requisitionHandler.ReceiveRequisition) // Record arrival, optionally into stock
+		r.Post("/{id}/cancel", m.requisitionHandler.CancelRequisition)   // Cancel requisition
+	})
+
 	// Equipment service configuration routes (under service-tickets to avoid conflict)
 	r.Route("/equipment-service-config", func(r chi.Router) {
 		r.Get("/{id}", m.assignmentHandler.GetEquipmentServiceConfig)    // Get config
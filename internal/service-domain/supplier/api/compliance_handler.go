@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/supplier/app"
+	"github.com/aby-med/medical-platform/internal/service-domain/supplier/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetCompliance handles GET /suppliers/{id}/compliance?within_days=30
+func (h *SupplierHandler) GetCompliance(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header is required")
+		return
+	}
+
+	days := app.DefaultCertificationReminderDays
+	if value := r.URL.Query().Get("within_days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			h.respondError(w, http.StatusBadRequest, "within_days must be a non-negative number")
+			return
+		}
+		days = parsed
+	}
+
+	response, err := h.service.GetCompliance(r.Context(), tenantID, chi.URLParam(r, "id"), time.Duration(days)*24*time.Hour)
+	if err != nil {
+		if err == domain.ErrSupplierNotFound {
+			h.respondError(w, http.StatusNotFound, "Supplier not found")
+			return
+		}
+		h.logger.Error("Failed to get supplier compliance", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to get supplier compliance")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// ListComplianceNotifications handles GET /suppliers/compliance/notifications?recipient=&supplier_id=&unread=true
+func (h *SupplierHandler) ListComplianceNotifications(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header is required")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := domain.NotificationFilter{
+		Recipient:  query.Get("recipient"),
+		SupplierID: query.Get("supplier_id"),
+		UnreadOnly: query.Get("unread") == "true",
+	}
+	if filter.Recipient != "" && filter.Recipient != domain.RecipientSupplier && filter.Recipient != domain.RecipientBuyer {
+		h.respondError(w, http.StatusBadRequest, "recipient must be supplier or buyer")
+		return
+	}
+
+	notifications, err := h.service.ListComplianceNotifications(r.Context(), tenantID, filter)
+	if err != nil {
+		h.logger.Error("Failed to list compliance notifications", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list compliance notifications")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"notifications": notifications,
+		"total":         len(notifications),
+	})
+}
+
+// MarkComplianceNotificationRead handles POST /suppliers/compliance/notifications/{notification_id}/read
+func (h *SupplierHandler) MarkComplianceNotificationRead(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header is required")
+		return
+	}
+
+	err := h.service.MarkComplianceNotificationRead(r.Context(), tenantID, chi.URLParam(r, "notification_id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			h.respondError(w, http.StatusNotFound, "Notification not found")
+			return
+		}
+		h.logger.Error("Failed to mark compliance notification read", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to mark notification read")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,9 +2,11 @@
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
+	"github.com/aby-med/medical-platform/internal/middleware"
 	"github.com/aby-med/medical-platform/internal/service-domain/supplier/app"
 	"github.com/aby-med/medical-platform/internal/service-domain/supplier/domain"
 	"github.com/go-chi/chi/v5"
@@ -33,7 +35,7 @@ func (h *SupplierHandler) RegisterRoutes(r chi.Router) {
 	r.Delete("/{id}", h.DeleteSupplier)
 	
 	// Lifecycle operations
-	r.Post("/{id}/verify", h.VerifySupplier)
+	r.With(h.requireAdmin).Post("/{id}/verify", h.VerifySupplier)
 	r.Post("/{id}/reject", h.RejectSupplier)
 	r.Post("/{id}/suspend", h.SuspendSupplier)
 	r.Post("/{id}/activate", h.ActivateSupplier)
@@ -41,6 +43,15 @@ func (h *SupplierHandler) RegisterRoutes(r chi.Router) {
 	// Additional operations
 	r.Post("/{id}/certifications", h.AddCertification)
 	r.Get("/category/{categoryId}", h.GetSuppliersByCategory)
+
+	// Performance scorecards
+	r.Get("/scorecards", h.ListScorecards)
+	r.Get("/{id}/scorecard", h.GetScorecard)
+
+	// Certification compliance
+	r.Get("/{id}/compliance", h.GetCompliance)
+	r.Get("/compliance/notifications", h.ListComplianceNotifications)
+	r.Post("/compliance/notifications/{notification_id}/read", h.MarkComplianceNotificationRead)
 }
 
 // CreateSupplier handles supplier creation
@@ -199,6 +210,29 @@ func (h *SupplierHandler) ListSuppliers(w http.ResponseWriter, r *http.Request)
 	h.respondJSON(w, http.StatusOK, response)
 }
 
+// adminRoles may verify suppliers
+var adminRoles = map[string]bool{
+	"admin":        true,
+	"super_admin":  true,
+	"system_admin": true,
+}
+
+// requireAdmin admits authenticated admins only, taking identity and role
+// from the auth context rather than client headers
+func (h *SupplierHandler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := middleware.GetUserID(r.Context()); !ok {
+			h.respondError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+		if role, _ := middleware.GetUserRole(r.Context()); !adminRoles[role] {
+			h.respondError(w, http.StatusForbidden, "Admin role required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // VerifySupplier handles verifying a supplier
 func (h *SupplierHandler) VerifySupplier(w http.ResponseWriter, r *http.Request) {
 	tenantID := r.Header.Get("X-Tenant-ID")
@@ -213,15 +247,18 @@ func (h *SupplierHandler) VerifySupplier(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// TODO: Get user from auth context
-	verifiedBy := "system"
+	userID, _ := middleware.GetUserID(r.Context())
 
-	response, err := h.service.VerifySupplier(r.Context(), tenantID, supplierID, verifiedBy)
+	response, err := h.service.VerifySupplier(r.Context(), tenantID, supplierID, userID.String())
 	if err != nil {
 		if err == domain.ErrSupplierNotFound {
 			h.respondError(w, http.StatusNotFound, "Supplier not found")
 			return
 		}
+		if errors.Is(err, domain.ErrMandatoryCertificationLapsed) {
+			h.respondError(w, http.StatusConflict, err.Error())
+			return
+		}
 		h.logger.Error("Failed to verify supplier", slog.String("error", err.Error()))
 		h.respondError(w, http.StatusInternalServerError, "Failed to verify supplier")
 		return
@@ -309,6 +346,10 @@ func (h *SupplierHandler) ActivateSupplier(w http.ResponseWriter, r *http.Reques
 			h.respondError(w, http.StatusNotFound, "Supplier not found")
 			return
 		}
+		if errors.Is(err, domain.ErrSupplierRestricted) {
+			h.respondError(w, http.StatusConflict, domain.ErrSupplierRestricted.Error())
+			return
+		}
 		h.logger.Error("Failed to activate supplier", slog.String("error", err.Error()))
 		h.respondError(w, http.StatusInternalServerError, "Failed to activate supplier")
 		return
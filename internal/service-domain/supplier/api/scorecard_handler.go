@@ -0,0 +1,87 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/supplier/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetScorecard handles GET /suppliers/{id}/scorecard?since=YYYY-MM-DD
+func (h *SupplierHandler) GetScorecard(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header is required")
+		return
+	}
+
+	since, ok := h.parseSince(w, r)
+	if !ok {
+		return
+	}
+
+	scorecard, err := h.service.GetScorecard(r.Context(), tenantID, chi.URLParam(r, "id"), since)
+	if err != nil {
+		if err == domain.ErrSupplierNotFound {
+			h.respondError(w, http.StatusNotFound, "Supplier not found")
+			return
+		}
+		h.logger.Error("Failed to get supplier scorecard", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to get supplier scorecard")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, scorecard)
+}
+
+// ListScorecards handles GET /suppliers/scorecards?supplier_id=&since=YYYY-MM-DD
+func (h *SupplierHandler) ListScorecards(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	if tenantID == "" {
+		h.respondError(w, http.StatusBadRequest, "X-Tenant-ID header is required")
+		return
+	}
+
+	since, ok := h.parseSince(w, r)
+	if !ok {
+		return
+	}
+
+	var supplierIDs []string
+	for _, value := range r.URL.Query()["supplier_id"] {
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				supplierIDs = append(supplierIDs, id)
+			}
+		}
+	}
+
+	scorecards, err := h.service.ListScorecards(r.Context(), tenantID, supplierIDs, since)
+	if err != nil {
+		h.logger.Error("Failed to list supplier scorecards", slog.String("error", err.Error()))
+		h.respondError(w, http.StatusInternalServerError, "Failed to list supplier scorecards")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"scorecards": scorecards,
+		"total":      len(scorecards),
+	})
+}
+
+// parseSince reads the optional since date of the scorecard period
+func (h *SupplierHandler) parseSince(w http.ResponseWriter, r *http.Request) (*time.Time, bool) {
+	value := r.URL.Query().Get("since")
+	if value == "" {
+		return nil, true
+	}
+	since, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "since must be a date (YYYY-MM-DD)")
+		return nil, false
+	}
+	return &since, true
+}
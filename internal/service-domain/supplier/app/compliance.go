@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/supplier/domain"
+)
+
+// GetCompliance reports which of a supplier's mandatory certifications have
+// lapsed and which certifications expire within the window
+func (s *SupplierService) GetCompliance(ctx context.Context, tenantID, supplierID string, window time.Duration) (*ComplianceResponse, error) {
+	supplier, err := s.repo.GetByID(ctx, supplierID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lapsed := supplier.LapsedMandatoryCertifications(now)
+	response := &ComplianceResponse{
+		SupplierID:        supplier.ID,
+		Status:            string(supplier.Status),
+		Compliant:         len(lapsed) == 0,
+		Lapsed:            make([]CertificationDTO, 0, len(lapsed)),
+		Expiring:          []CertificationDTO{},
+		RestrictedAt:      supplier.RestrictedAt,
+		RestrictionReason: supplier.RestrictionReason,
+	}
+	for _, cert := range lapsed {
+		response.Lapsed = append(response.Lapsed, ToCertificationDTO(cert))
+	}
+	for _, cert := range supplier.Certifications {
+		if cert.ExpiresWithin(now, window) {
+			response.Expiring = append(response.Expiring, ToCertificationDTO(cert))
+		}
+	}
+	return response, nil
+}
+
+// MonitorCompliance warns suppliers and buyers of certifications expiring
+// within the window and of expired ones, once per certification expiry, and
+// restricts active suppliers whose mandatory certifications lapsed. It
+// returns how many warnings were sent and suppliers restricted.
+func (s *SupplierService) MonitorCompliance(ctx context.Context, now time.Time, window time.Duration) (int, int, error) {
+	suppliers, err := s.compliance.ListWithCertificationsExpiringBefore(ctx, now.Add(window))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	warned, restricted := 0, 0
+	for _, supplier := range suppliers {
+		for i := range supplier.Certifications {
+			cert := &supplier.Certifications[i]
+			var notifications []domain.ComplianceNotification
+			switch {
+			case cert.IsExpired(now):
+				notifications = supplier.Notify(domain.NotificationKindCertificationExpired, cert,
+					fmt.Sprintf("%s: %s has expired", supplier.CompanyName, cert.Name),
+					fmt.Sprintf("Certificate %s expired on %s. Upload the renewed certificate for re-verification.",
+						cert.CertNumber, cert.ExpiryDate.Format("2006-01-02")),
+					domain.RecipientSupplier, domain.RecipientBuyer)
+			case cert.ExpiresWithin(now, window):
+				notifications = supplier.Notify(domain.NotificationKindCertificationExpiring, cert,
+					fmt.Sprintf("%s: %s expires on %s", supplier.CompanyName, cert.Name, cert.ExpiryDate.Format("2006-01-02")),
+					fmt.Sprintf("Certificate %s expires soon. Upload the renewed certificate before then to avoid restriction.",
+						cert.CertNumber),
+					domain.RecipientSupplier, domain.RecipientBuyer)
+			default:
+				continue
+			}
+
+			stored, err := s.compliance.AddNotifications(ctx, notifications)
+			if err != nil {
+				s.logger.Error("Failed to send certification expiry warning",
+					slog.String("error", err.Error()),
+					slog.String("supplier_id", supplier.ID))
+				continue
+			}
+			if stored > 0 {
+				warned++
+			}
+		}
+
+		lapsed := supplier.LapsedMandatoryCertifications(now)
+		if len(lapsed) == 0 || supplier.Status != domain.SupplierStatusActive {
+			continue
+		}
+		names := make([]string, len(lapsed))
+		for i, cert := range lapsed {
+			names[i] = cert.Name
+		}
+		reason := "Mandatory certification lapsed: " + strings.Join(names, ", ")
+		if err := supplier.Restrict(reason, now); err != nil {
+			continue
+		}
+		if err := s.repo.Update(ctx, supplier); err != nil {
+			s.logger.Error("Failed to restrict supplier",
+				slog.String("error", err.Error()),
+				slog.String("supplier_id", supplier.ID))
+			continue
+		}
+		restricted++
+
+		s.logger.Warn("Supplier restricted",
+			slog.String("supplier_id", supplier.ID),
+			slog.String("reason", reason))
+		s.notifyCompliance(ctx, supplier.Notify(domain.NotificationKindRestricted, nil,
+			fmt.Sprintf("%s restricted", supplier.CompanyName),
+			reason+". The supplier cannot be invited to new RFQs until renewed certificates are re-verified.",
+			domain.RecipientSupplier, domain.RecipientBuyer))
+	}
+
+	return warned, restricted, nil
+}
+
+// ListComplianceNotifications lists compliance notifications, newest first
+func (s *SupplierService) ListComplianceNotifications(ctx context.Context, tenantID string, filter domain.NotificationFilter) ([]domain.ComplianceNotification, error) {
+	return s.compliance.ListNotifications(ctx, tenantID, filter)
+}
+
+// MarkComplianceNotificationRead marks a compliance notification as read
+func (s *SupplierService) MarkComplianceNotificationRead(ctx context.Context, tenantID, notificationID string) error {
+	return s.compliance.MarkNotificationRead(ctx, tenantID, notificationID)
+}
+
+// notifyCompliance stores notifications; failures are logged so the change itself is not rolled back
+func (s *SupplierService) notifyCompliance(ctx context.Context, notifications []domain.ComplianceNotification) {
+	if len(notifications) == 0 {
+		return
+	}
+	if _, err := s.compliance.AddNotifications(ctx, notifications); err != nil {
+		s.logger.Error("Failed to store compliance notifications",
+			slog.String("error", err.Error()),
+			slog.String("supplier_id", notifications[0].SupplierID))
+	}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/shared/config"
+)
+
+// DefaultCertificationReminderDays is how far ahead of expiry suppliers and buyers are warned
+const DefaultCertificationReminderDays = 30
+
+// ComplianceMonitor periodically warns suppliers and buyers ahead of
+// certification expiry and restricts suppliers whose mandatory certifications
+// lapsed, so they cannot be invited to new RFQs
+type ComplianceMonitor struct {
+	service        *SupplierService
+	interval       time.Duration
+	reminderWindow time.Duration
+	now            func() time.Time
+	logger         *slog.Logger
+}
+
+// NewComplianceMonitor creates a new compliance monitor. The reminder window
+// can be changed with SUPPLIER_CERT_REMINDER_DAYS.
+func NewComplianceMonitor(service *SupplierService, logger *slog.Logger) *ComplianceMonitor {
+	days := DefaultCertificationReminderDays
+	if value, err := strconv.Atoi(os.Getenv("SUPPLIER_CERT_REMINDER_DAYS")); err == nil && value > 0 {
+		days = value
+	}
+	return &ComplianceMonitor{
+		service:        service,
+		interval:       time.Hour,
+		reminderWindow: time.Duration(days) * 24 * time.Hour,
+		now:            time.Now,
+		logger:         logger.With(slog.String("component", "supplier_compliance_monitor")),
+	}
+}
+
+// Run checks compliance until the context is cancelled.
+// Disabled with ENABLE_SUPPLIER_COMPLIANCE_MONITOR=false.
+func (m *ComplianceMonitor) Run(ctx context.Context) {
+	if !config.Enabled("ENABLE_SUPPLIER_COMPLIANCE_MONITOR") {
+		m.logger.Info("Supplier compliance monitor disabled; skipping run")
+		return
+	}
+
+	m.RunOnce(ctx)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce sends due certification warnings and restricts non-compliant suppliers once
+func (m *ComplianceMonitor) RunOnce(ctx context.Context) {
+	warned, restricted, err := m.service.MonitorCompliance(ctx, m.now(), m.reminderWindow)
+	if err != nil {
+		m.logger.Error("Failed to monitor supplier compliance", slog.String("error", err.Error()))
+		return
+	}
+	if warned > 0 || restricted > 0 {
+		m.logger.Info("Supplier compliance checked",
+			slog.Int("warned", warned),
+			slog.Int("restricted", restricted))
+	}
+}
@@ -16,6 +16,7 @@ type CreateSupplierRequest struct {
 	ContactInfo             ContactInfoDTO        `json:"contact_info"`
 	Address                 AddressDTO            `json:"address"`
 	Specializations         []string              `json:"specializations,omitempty"`
+	OrganizationID          string                `json:"organization_id,omitempty"`
 }
 
 // UpdateSupplierRequest represents a request to update an existing supplier
@@ -28,6 +29,7 @@ type UpdateSupplierRequest struct {
 	ContactInfo             *ContactInfoDTO       `json:"contact_info,omitempty"`
 	Address                 *AddressDTO           `json:"address,omitempty"`
 	Specializations         []string              `json:"specializations,omitempty"`
+	OrganizationID          string                `json:"organization_id,omitempty"`
 }
 
 // ContactInfoDTO represents contact information
@@ -52,19 +54,23 @@ type AddressDTO struct {
 
 // CertificationDTO represents a certification
 type CertificationDTO struct {
-	ID          string    `json:"id,omitempty"`
-	Name        string    `json:"name"`
-	IssuingBody string    `json:"issuing_body"`
-	CertNumber  string    `json:"cert_number"`
-	IssueDate   time.Time `json:"issue_date"`
-	ExpiryDate  time.Time `json:"expiry_date"`
-	DocumentURL string    `json:"document_url,omitempty"`
+	ID          string     `json:"id,omitempty"`
+	Name        string     `json:"name"`
+	IssuingBody string     `json:"issuing_body"`
+	CertNumber  string     `json:"cert_number"`
+	IssueDate   time.Time  `json:"issue_date"`
+	ExpiryDate  time.Time  `json:"expiry_date"`
+	DocumentURL string     `json:"document_url,omitempty"`
+	Mandatory   bool       `json:"mandatory,omitempty"` // Inferred for ISO 13485 and regulatory registrations
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+	VerifiedBy  string     `json:"verified_by,omitempty"`
 }
 
 // SupplierResponse represents a supplier response
 type SupplierResponse struct {
 	ID                      string                 `json:"id"`
 	TenantID                string                 `json:"tenant_id"`
+	OrganizationID          string                 `json:"organization_id,omitempty"`
 	CompanyName             string                 `json:"company_name"`
 	BusinessRegistrationNum string                 `json:"business_registration_number,omitempty"`
 	TaxID                   string                 `json:"tax_id,omitempty"`
@@ -81,12 +87,25 @@ type SupplierResponse struct {
 	VerificationStatus      string                 `json:"verification_status"`
 	VerifiedAt              *time.Time             `json:"verified_at,omitempty"`
 	VerifiedBy              string                 `json:"verified_by,omitempty"`
+	RestrictedAt            *time.Time             `json:"restricted_at,omitempty"`
+	RestrictionReason       string                 `json:"restriction_reason,omitempty"`
 	Metadata                map[string]interface{} `json:"metadata,omitempty"`
 	CreatedBy               string                 `json:"created_by"`
 	CreatedAt               time.Time              `json:"created_at"`
 	UpdatedAt               time.Time              `json:"updated_at"`
 }
 
+// ComplianceResponse summarizes a supplier's certification compliance
+type ComplianceResponse struct {
+	SupplierID        string             `json:"supplier_id"`
+	Status            string             `json:"status"`
+	Compliant         bool               `json:"compliant"` // No mandatory certification has lapsed
+	Lapsed            []CertificationDTO `json:"lapsed"`
+	Expiring          []CertificationDTO `json:"expiring"` // Valid but expiring within the reminder window
+	RestrictedAt      *time.Time         `json:"restricted_at,omitempty"`
+	RestrictionReason string             `json:"restriction_reason,omitempty"`
+}
+
 // ListSuppliersRequest represents filters for listing suppliers
 type ListSuppliersRequest struct {
 	Status             []string `json:"status,omitempty"`
@@ -142,6 +161,22 @@ func ToCertification(dto CertificationDTO) domain.Certification {
 		IssueDate:   dto.IssueDate,
 		ExpiryDate:  dto.ExpiryDate,
 		DocumentURL: dto.DocumentURL,
+		Mandatory:   dto.Mandatory,
+	}
+}
+
+func ToCertificationDTO(cert domain.Certification) CertificationDTO {
+	return CertificationDTO{
+		ID:          cert.ID,
+		Name:        cert.Name,
+		IssuingBody: cert.IssuingBody,
+		CertNumber:  cert.CertNumber,
+		IssueDate:   cert.IssueDate,
+		ExpiryDate:  cert.ExpiryDate,
+		DocumentURL: cert.DocumentURL,
+		Mandatory:   cert.Mandatory,
+		VerifiedAt:  cert.VerifiedAt,
+		VerifiedBy:  cert.VerifiedBy,
 	}
 }
 
@@ -166,20 +201,13 @@ func ToSupplierResponse(s *domain.Supplier) SupplierResponse {
 
 	certifications := make([]CertificationDTO, len(s.Certifications))
 	for i, cert := range s.Certifications {
-		certifications[i] = CertificationDTO{
-			ID:          cert.ID,
-			Name:        cert.Name,
-			IssuingBody: cert.IssuingBody,
-			CertNumber:  cert.CertNumber,
-			IssueDate:   cert.IssueDate,
-			ExpiryDate:  cert.ExpiryDate,
-			DocumentURL: cert.DocumentURL,
-		}
+		certifications[i] = ToCertificationDTO(cert)
 	}
 
 	return SupplierResponse{
 		ID:                      s.ID,
 		TenantID:                s.TenantID,
+		OrganizationID:          s.OrganizationID,
 		CompanyName:             s.CompanyName,
 		BusinessRegistrationNum: s.BusinessRegistrationNum,
 		TaxID:                   s.TaxID,
@@ -196,6 +224,8 @@ func ToSupplierResponse(s *domain.Supplier) SupplierResponse {
 		VerificationStatus:      string(s.VerificationStatus),
 		VerifiedAt:              s.VerifiedAt,
 		VerifiedBy:              s.VerifiedBy,
+		RestrictedAt:            s.RestrictedAt,
+		RestrictionReason:       s.RestrictionReason,
 		Metadata:                s.Metadata,
 		CreatedBy:               s.CreatedBy,
 		CreatedAt:               s.CreatedAt,
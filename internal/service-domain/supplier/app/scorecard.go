@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/supplier/domain"
+)
+
+// maxRankedSuppliers caps how many active suppliers are ranked when no IDs are given
+const maxRankedSuppliers = 200
+
+// GetScorecard computes a supplier's performance scorecard over activity since
+// the given time (nil for all history)
+func (s *SupplierService) GetScorecard(ctx context.Context, tenantID, supplierID string, since *time.Time) (*domain.Scorecard, error) {
+	scorecards, err := s.scorecards.Scorecards(ctx, tenantID, []string{supplierID}, since)
+	if err != nil {
+		s.logger.Error("Failed to compute supplier scorecard",
+			slog.String("error", err.Error()),
+			slog.String("supplier_id", supplierID))
+		return nil, fmt.Errorf("failed to compute scorecard: %w", err)
+	}
+
+	scorecard, ok := scorecards[supplierID]
+	if !ok {
+		return nil, domain.ErrSupplierNotFound
+	}
+	return scorecard, nil
+}
+
+// ListScorecards computes the scorecards of the given suppliers, or of all
+// active suppliers when none are given, ranked by overall score. Suppliers
+// without any scored activity come last.
+func (s *SupplierService) ListScorecards(ctx context.Context, tenantID string, supplierIDs []string, since *time.Time) ([]*domain.Scorecard, error) {
+	if len(supplierIDs) == 0 {
+		suppliers, _, err := s.repo.List(ctx, domain.ListCriteria{
+			TenantID: tenantID,
+			Status:   []domain.SupplierStatus{domain.SupplierStatusActive},
+			PageSize: maxRankedSuppliers,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list suppliers: %w", err)
+		}
+		for _, supplier := range suppliers {
+			supplierIDs = append(supplierIDs, supplier.ID)
+		}
+	}
+	if len(supplierIDs) == 0 {
+		return []*domain.Scorecard{}, nil
+	}
+
+	byID, err := s.scorecards.Scorecards(ctx, tenantID, supplierIDs, since)
+	if err != nil {
+		s.logger.Error("Failed to compute supplier scorecards", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to compute scorecards: %w", err)
+	}
+
+	scorecards := make([]*domain.Scorecard, 0, len(byID))
+	for _, scorecard := range byID {
+		scorecards = append(scorecards, scorecard)
+	}
+	sort.Slice(scorecards, func(i, j int) bool {
+		a, b := scorecards[i].OverallScore, scorecards[j].OverallScore
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		if a != nil && *a != *b {
+			return *a > *b
+		}
+		return scorecards[i].CompanyName < scorecards[j].CompanyName
+	})
+	return scorecards, nil
+}
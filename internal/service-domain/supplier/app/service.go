@@ -11,15 +11,19 @@ import (
 
 // SupplierService provides application-level supplier operations
 type SupplierService struct {
-	repo   domain.SupplierRepository
-	logger *slog.Logger
+	repo       domain.SupplierRepository
+	scorecards domain.ScorecardRepository
+	compliance domain.ComplianceRepository
+	logger     *slog.Logger
 }
 
 // NewSupplierService creates a new supplier service
-func NewSupplierService(repo domain.SupplierRepository, logger *slog.Logger) *SupplierService {
+func NewSupplierService(repo domain.SupplierRepository, scorecards domain.ScorecardRepository, compliance domain.ComplianceRepository, logger *slog.Logger) *SupplierService {
 	return &SupplierService{
-		repo:   repo,
-		logger: logger.With(slog.String("component", "supplier_service")),
+		repo:       repo,
+		scorecards: scorecards,
+		compliance: compliance,
+		logger:     logger.With(slog.String("component", "supplier_service")),
 	}
 }
 
@@ -53,6 +57,7 @@ func (s *SupplierService) CreateSupplier(ctx context.Context, tenantID string, r
 	supplier.YearEstablished = req.YearEstablished
 	supplier.Description = req.Description
 	supplier.Specializations = req.Specializations
+	supplier.OrganizationID = req.OrganizationID
 
 	// Check if tax ID already exists
 	if req.TaxID != "" {
@@ -133,6 +138,9 @@ func (s *SupplierService) UpdateSupplier(ctx context.Context, tenantID string, s
 	if req.Specializations != nil {
 		supplier.Specializations = req.Specializations
 	}
+	if req.OrganizationID != "" {
+		supplier.OrganizationID = req.OrganizationID
+	}
 
 	// Save updated supplier
 	if err := s.repo.Update(ctx, supplier); err != nil {
@@ -229,12 +237,15 @@ func (s *SupplierService) ListSuppliers(ctx context.Context, tenantID string, re
 	}, nil
 }
 
-// VerifySupplier verifies a supplier for business
+// VerifySupplier verifies a supplier for business. Verifying a restricted
+// supplier records the re-verification of its renewed certifications and
+// reinstates it.
 func (s *SupplierService) VerifySupplier(ctx context.Context, tenantID string, supplierID string, verifiedBy string) (*SupplierResponse, error) {
 	supplier, err := s.repo.GetByID(ctx, supplierID, tenantID)
 	if err != nil {
 		return nil, err
 	}
+	restricted := supplier.Status == domain.SupplierStatusRestricted
 
 	if err := supplier.Verify(verifiedBy); err != nil {
 		return nil, fmt.Errorf("failed to verify supplier: %w", err)
@@ -251,6 +262,13 @@ func (s *SupplierService) VerifySupplier(ctx context.Context, tenantID string, s
 		slog.String("supplier_id", supplierID),
 		slog.String("verified_by", verifiedBy))
 
+	if restricted {
+		s.notifyCompliance(ctx, supplier.Notify(domain.NotificationKindReinstated, nil,
+			fmt.Sprintf("%s reinstated", supplier.CompanyName),
+			"Renewed certifications were re-verified; the supplier can be invited to RFQs again.",
+			domain.RecipientSupplier, domain.RecipientBuyer))
+	}
+
 	response := ToSupplierResponse(supplier)
 	return &response, nil
 }
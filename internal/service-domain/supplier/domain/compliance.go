@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Compliance errors
+var (
+	ErrMandatoryCertificationLapsed = errors.New("mandatory certification lapsed")
+	ErrSupplierRestricted           = errors.New("supplier is restricted until its certifications are re-verified")
+	ErrNotificationNotFound         = errors.New("compliance notification not found")
+)
+
+// MandatoryCertifications are matched against certification names, case
+// insensitively, to mark certifications mandatory when they are added
+var MandatoryCertifications = []string{"ISO 13485", "CDSCO", "FDA"}
+
+// IsMandatoryCertification reports whether a certification of that name is mandatory
+func IsMandatoryCertification(name string) bool {
+	name = strings.ToUpper(name)
+	for _, mandatory := range MandatoryCertifications {
+		if strings.Contains(name, strings.ToUpper(mandatory)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired reports whether the certification has expired. Certifications
+// without an expiry date never expire.
+func (c Certification) IsExpired(now time.Time) bool {
+	return !c.ExpiryDate.IsZero() && !c.ExpiryDate.After(now)
+}
+
+// ExpiresWithin reports whether the certification is valid but expires within the window
+func (c Certification) ExpiresWithin(now time.Time, window time.Duration) bool {
+	return !c.ExpiryDate.IsZero() && c.ExpiryDate.After(now) && !c.ExpiryDate.After(now.Add(window))
+}
+
+// LapsedMandatoryCertifications returns, per mandatory certification name,
+// the latest expired certification when no valid one of that name is held.
+// A renewed certificate added under the same name satisfies the requirement.
+// Certifications stored before the mandatory flag existed are matched by name.
+func (s *Supplier) LapsedMandatoryCertifications(now time.Time) []Certification {
+	latest := map[string]Certification{}
+	valid := map[string]bool{}
+	names := []string{}
+	for _, c := range s.Certifications {
+		if !c.Mandatory && !IsMandatoryCertification(c.Name) {
+			continue
+		}
+		name := strings.ToUpper(strings.TrimSpace(c.Name))
+		if _, seen := latest[name]; !seen {
+			names = append(names, name)
+		}
+		if !c.IsExpired(now) {
+			valid[name] = true
+		}
+		if prev, seen := latest[name]; !seen || c.ExpiryDate.After(prev.ExpiryDate) {
+			latest[name] = c
+		}
+	}
+
+	lapsed := []Certification{}
+	for _, name := range names {
+		if !valid[name] {
+			lapsed = append(lapsed, latest[name])
+		}
+	}
+	return lapsed
+}
+
+// Restrict moves an active supplier whose mandatory certifications lapsed to
+// the restricted state, in which it cannot be invited to new RFQs
+func (s *Supplier) Restrict(reason string, now time.Time) error {
+	if s.Status != SupplierStatusActive {
+		return ErrCannotModifySupplier
+	}
+
+	s.Status = SupplierStatusRestricted
+	s.RestrictedAt = &now
+	s.RestrictionReason = reason
+	s.UpdatedAt = now
+	return nil
+}
+
+// verifyCertifications records the review of the unexpired certifications
+// that have not been verified yet
+func (s *Supplier) verifyCertifications(verifiedBy string, now time.Time) {
+	for i := range s.Certifications {
+		c := &s.Certifications[i]
+		if c.VerifiedAt == nil && !c.IsExpired(now) {
+			c.VerifiedAt = &now
+			c.VerifiedBy = verifiedBy
+		}
+	}
+}
+
+// Notification recipients
+const (
+	RecipientSupplier = "supplier"
+	RecipientBuyer    = "buyer"
+)
+
+// Compliance notification kinds
+const (
+	NotificationKindCertificationExpiring = "certification_expiring"
+	NotificationKindCertificationExpired  = "certification_expired"
+	NotificationKindRestricted            = "supplier_restricted"
+	NotificationKindReinstated            = "supplier_reinstated"
+)
+
+// ComplianceNotification warns a supplier or the buyer about certification
+// expiry and the supplier's resulting compliance state
+type ComplianceNotification struct {
+	ID                string     `json:"id"`
+	TenantID          string     `json:"tenant_id"`
+	SupplierID        string     `json:"supplier_id"`
+	CertificationID   string     `json:"certification_id,omitempty"`
+	CertificationName string     `json:"certification_name,omitempty"`
+	ExpiryDate        *time.Time `json:"expiry_date,omitempty"`
+	Recipient         string     `json:"recipient"`
+	Kind              string     `json:"kind"`
+	Subject           string     `json:"subject"`
+	Message           string     `json:"message"`
+	CreatedAt         time.Time  `json:"created_at"`
+	ReadAt            *time.Time `json:"read_at,omitempty"`
+}
+
+// NotificationFilter narrows a compliance notification listing
+type NotificationFilter struct {
+	Recipient  string
+	SupplierID string
+	UnreadOnly bool
+}
+
+// Notify builds one notification per recipient, about a certification when one is given
+func (s *Supplier) Notify(kind string, cert *Certification, subject, message string, recipients ...string) []ComplianceNotification {
+	now := time.Now()
+	notifications := make([]ComplianceNotification, 0, len(recipients))
+	for _, recipient := range recipients {
+		n := ComplianceNotification{
+			ID:         ksuid.New().String(),
+			TenantID:   s.TenantID,
+			SupplierID: s.ID,
+			Recipient:  recipient,
+			Kind:       kind,
+			Subject:    subject,
+			Message:    message,
+			CreatedAt:  now,
+		}
+		if cert != nil {
+			expiry := cert.ExpiryDate
+			n.CertificationID = cert.ID
+			n.CertificationName = cert.Name
+			n.ExpiryDate = &expiry
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications
+}
+
+// ComplianceRepository finds suppliers due for compliance checks and stores
+// compliance notifications
+type ComplianceRepository interface {
+	// ListWithCertificationsExpiringBefore retrieves suppliers of all tenants,
+	// other than inactive ones, holding a certification that expires before cutoff
+	ListWithCertificationsExpiringBefore(ctx context.Context, cutoff time.Time) ([]*Supplier, error)
+
+	// AddNotifications stores notifications, skipping repeats about the same
+	// certification expiry, and returns how many were stored
+	AddNotifications(ctx context.Context, notifications []ComplianceNotification) (int, error)
+
+	// ListNotifications retrieves notifications, newest first
+	ListNotifications(ctx context.Context, tenantID string, filter NotificationFilter) ([]ComplianceNotification, error)
+
+	// MarkNotificationRead marks a notification as read
+	MarkNotificationRead(ctx context.Context, tenantID, id string) error
+}
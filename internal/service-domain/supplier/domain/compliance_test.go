@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLapsedMandatoryCertificationRestrictsUntilReverified(t *testing.T) {
+	now := time.Now()
+	s := &Supplier{Status: SupplierStatusActive, VerificationStatus: VerificationApproved}
+	if err := s.AddCertification(Certification{ID: "c1", Name: "ISO 13485:2016", CertNumber: "Q1", ExpiryDate: now.AddDate(0, 0, -1)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddCertification(Certification{ID: "c2", Name: "ISO 9001", CertNumber: "Q2", ExpiryDate: now.AddDate(0, 0, -1)}); err != nil {
+		t.Fatal(err)
+	}
+
+	lapsed := s.LapsedMandatoryCertifications(now)
+	if len(lapsed) != 1 || lapsed[0].ID != "c1" {
+		t.Fatalf("lapsed = %+v, want only the ISO 13485 certificate", lapsed)
+	}
+
+	if err := s.Restrict("lapsed", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Activate(); !errors.Is(err, ErrSupplierRestricted) {
+		t.Fatalf("Activate() = %v, want ErrSupplierRestricted", err)
+	}
+	if err := s.Verify("admin"); !errors.Is(err, ErrMandatoryCertificationLapsed) {
+		t.Fatalf("Verify() = %v, want ErrMandatoryCertificationLapsed", err)
+	}
+
+	// A renewed certificate under the same name satisfies the requirement
+	s.AddCertification(Certification{ID: "c3", Name: "ISO 13485:2016", CertNumber: "Q3", ExpiryDate: now.AddDate(3, 0, 0)})
+	if err := s.Verify("admin"); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if s.Status != SupplierStatusActive || s.RestrictedAt != nil {
+		t.Fatalf("status = %s, restricted at %v; want active and unrestricted", s.Status, s.RestrictedAt)
+	}
+	if c := s.Certifications[2]; c.VerifiedBy != "admin" || c.VerifiedAt == nil {
+		t.Fatalf("renewed certificate not recorded as re-verified: %+v", c)
+	}
+	if s.Certifications[0].VerifiedAt != nil {
+		t.Fatal("expired certificate should not be recorded as verified")
+	}
+}
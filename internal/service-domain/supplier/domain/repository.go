@@ -13,6 +13,9 @@ type SupplierRepository interface {
 	// GetByTaxID retrieves a supplier by Tax ID
 	GetByTaxID(ctx context.Context, taxID string, tenantID string) (*Supplier, error)
 	
+	// GetByOrganizationID retrieves the supplier linked to a platform organization
+	GetByOrganizationID(ctx context.Context, organizationID string, tenantID string) (*Supplier, error)
+	
 	// Update updates an existing supplier
 	Update(ctx context.Context, supplier *Supplier) error
 	
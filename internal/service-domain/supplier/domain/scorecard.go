@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// certExpiringWindow is how soon a certification must expire to be flagged as expiring
+const certExpiringWindow = 30 * 24 * time.Hour
+
+// Scorecard weights of the overall score. Metrics without data are left out
+// and the remaining weights rescaled.
+const (
+	weightOnTimeDelivery   = 0.30
+	weightQuality          = 0.20
+	weightPrice            = 0.20
+	weightWinRate          = 0.10
+	weightCertifications   = 0.10
+	weightContractComplete = 0.10
+)
+
+// DeliveryPerformance aggregates contract delivery milestones
+type DeliveryPerformance struct {
+	OnTime     int      `json:"on_time"`      // Completed on or before the milestone date
+	Late       int      `json:"late"`         // Completed after the milestone date
+	Overdue    int      `json:"overdue"`      // Past the milestone date and not completed
+	OnTimeRate *float64 `json:"on_time_rate"` // Share of due milestones delivered on time, 0-100
+}
+
+// QuotePerformance aggregates the supplier's quotes
+type QuotePerformance struct {
+	Submitted int      `json:"submitted"`
+	Decided   int      `json:"decided"` // Accepted, rejected or expired
+	Won       int      `json:"won"`
+	WinRate   *float64 `json:"win_rate"` // Share of decided quotes accepted, 0-100
+}
+
+// PricePerformance compares the supplier's quotes with the lowest quote on the same RFQs
+type PricePerformance struct {
+	ComparedRFQs    int      `json:"compared_rfqs"` // RFQs with at least one competing quote in the same currency
+	TimesLowest     int      `json:"times_lowest"`
+	Competitiveness *float64 `json:"competitiveness"` // Mean of lowest/own price, 0-100; 100 means always lowest
+}
+
+// QualityPerformance counts service tickets raised on equipment the supplier delivered
+type QualityPerformance struct {
+	EquipmentSupplied int      `json:"equipment_supplied"`
+	ServiceTickets    int      `json:"service_tickets"`
+	WarrantyDefects   int      `json:"warranty_defects"` // Tickets raised while the equipment was under warranty
+	DefectsPerUnit    *float64 `json:"defects_per_unit"`
+	Score             *float64 `json:"score"` // 100 with no warranty defects, 50 at one per unit
+}
+
+// CertificationStatus summarizes the validity of the supplier's certifications
+type CertificationStatus struct {
+	Total        int      `json:"total"`
+	Valid        int      `json:"valid"`
+	ExpiringSoon int      `json:"expiring_soon"` // Valid but expiring within 30 days
+	Expired      int      `json:"expired"`
+	ValidityRate *float64 `json:"validity_rate"` // Share of certifications valid, 0-100
+}
+
+// ContractPerformance counts finished contracts
+type ContractPerformance struct {
+	Completed      int      `json:"completed"`
+	Finished       int      `json:"finished"` // Completed, cancelled or suspended
+	CompletionRate *float64 `json:"completion_rate"`
+}
+
+// Scorecard is a supplier's performance computed from its contracts,
+// deliveries, quotes, service history and certifications
+type Scorecard struct {
+	SupplierID     string              `json:"supplier_id"`
+	CompanyName    string              `json:"company_name"`
+	Since          *time.Time          `json:"since,omitempty"` // Start of the period; nil covers all history
+	Delivery       DeliveryPerformance `json:"delivery"`
+	Quotes         QuotePerformance    `json:"quotes"`
+	Pricing        PricePerformance    `json:"pricing"`
+	Quality        QualityPerformance  `json:"quality"`
+	Certifications CertificationStatus `json:"certifications"`
+	Contracts      ContractPerformance `json:"contracts"`
+	OverallScore   *float64            `json:"overall_score"` // Weighted 0-100; nil without any data
+	ComputedAt     time.Time           `json:"computed_at"`
+}
+
+// Compute derives the rates and overall score from the aggregated counts and
+// the supplier's certifications
+func (sc *Scorecard) Compute(certs []Certification, now time.Time) {
+	sc.Delivery.OnTimeRate = percent(sc.Delivery.OnTime, sc.Delivery.OnTime+sc.Delivery.Late+sc.Delivery.Overdue)
+	sc.Quotes.WinRate = percent(sc.Quotes.Won, sc.Quotes.Decided)
+	sc.Contracts.CompletionRate = percent(sc.Contracts.Completed, sc.Contracts.Finished)
+
+	if sc.Pricing.Competitiveness != nil {
+		v := round2(*sc.Pricing.Competitiveness)
+		sc.Pricing.Competitiveness = &v
+	}
+
+	sc.Quality.DefectsPerUnit, sc.Quality.Score = nil, nil
+	if sc.Quality.EquipmentSupplied > 0 {
+		perUnit := float64(sc.Quality.WarrantyDefects) / float64(sc.Quality.EquipmentSupplied)
+		score := round2(100 / (1 + perUnit))
+		perUnit = round2(perUnit)
+		sc.Quality.DefectsPerUnit, sc.Quality.Score = &perUnit, &score
+	}
+
+	sc.Certifications = CertificationStatus{Total: len(certs)}
+	for _, c := range certs {
+		switch {
+		case !c.ExpiryDate.IsZero() && !c.ExpiryDate.After(now):
+			sc.Certifications.Expired++
+		case !c.ExpiryDate.IsZero() && c.ExpiryDate.Before(now.Add(certExpiringWindow)):
+			sc.Certifications.ExpiringSoon++
+			sc.Certifications.Valid++
+		default:
+			sc.Certifications.Valid++
+		}
+	}
+	sc.Certifications.ValidityRate = percent(sc.Certifications.Valid, sc.Certifications.Total)
+
+	weighted, weights := 0.0, 0.0
+	for _, m := range []struct {
+		value  *float64
+		weight float64
+	}{
+		{sc.Delivery.OnTimeRate, weightOnTimeDelivery},
+		{sc.Quality.Score, weightQuality},
+		{sc.Pricing.Competitiveness, weightPrice},
+		{sc.Quotes.WinRate, weightWinRate},
+		{sc.Certifications.ValidityRate, weightCertifications},
+		{sc.Contracts.CompletionRate, weightContractComplete},
+	} {
+		if m.value != nil {
+			weighted += *m.value * m.weight
+			weights += m.weight
+		}
+	}
+	sc.OverallScore = nil
+	if weights > 0 {
+		overall := round2(weighted / weights)
+		sc.OverallScore = &overall
+	}
+	sc.ComputedAt = now
+}
+
+func percent(part, whole int) *float64 {
+	if whole == 0 {
+		return nil
+	}
+	v := round2(float64(part) / float64(whole) * 100)
+	return &v
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// ScorecardRepository aggregates the performance data scorecards are computed from
+type ScorecardRepository interface {
+	// Scorecards computes the scorecards of the given suppliers, keyed by
+	// supplier ID, over activity since the given time (nil for all history)
+	Scorecards(ctx context.Context, tenantID string, supplierIDs []string, since *time.Time) (map[string]*Scorecard, error)
+}
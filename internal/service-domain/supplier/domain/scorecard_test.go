@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScorecardComputeWeightsAvailableMetrics(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	sc := &Scorecard{
+		Delivery:  DeliveryPerformance{OnTime: 3, Late: 1},
+		Quotes:    QuotePerformance{Submitted: 5, Decided: 4, Won: 1},
+		Quality:   QualityPerformance{EquipmentSupplied: 2, WarrantyDefects: 2},
+		Contracts: ContractPerformance{},
+	}
+	certs := []Certification{
+		{ExpiryDate: now.AddDate(1, 0, 0)},
+		{ExpiryDate: now.AddDate(0, 0, 10)},
+		{ExpiryDate: now.AddDate(0, 0, -1)},
+		{},
+	}
+
+	sc.Compute(certs, now)
+
+	if sc.Delivery.OnTimeRate == nil || *sc.Delivery.OnTimeRate != 75 {
+		t.Fatalf("on-time rate = %v, want 75", sc.Delivery.OnTimeRate)
+	}
+	if sc.Quality.Score == nil || *sc.Quality.Score != 50 {
+		t.Fatalf("quality score = %v, want 50", sc.Quality.Score)
+	}
+	if c := sc.Certifications; c.Valid != 3 || c.ExpiringSoon != 1 || c.Expired != 1 {
+		t.Fatalf("certifications = %+v, want 3 valid, 1 expiring, 1 expired", c)
+	}
+	if sc.Pricing.Competitiveness != nil || sc.Contracts.CompletionRate != nil {
+		t.Fatal("metrics without data should stay unset")
+	}
+
+	// Delivery 75, quality 50, win rate 25 and certifications 75 over weights 0.7
+	want := round2((75*weightOnTimeDelivery + 50*weightQuality + 25*weightWinRate + 75*weightCertifications) /
+		(weightOnTimeDelivery + weightQuality + weightWinRate + weightCertifications))
+	if sc.OverallScore == nil || *sc.OverallScore != want {
+		t.Fatalf("overall score = %v, want %v", sc.OverallScore, want)
+	}
+}
+
+func TestScorecardComputeWithoutData(t *testing.T) {
+	sc := &Scorecard{}
+	sc.Compute(nil, time.Now())
+	if sc.OverallScore != nil {
+		t.Fatalf("overall score = %v, want nil", *sc.OverallScore)
+	}
+}
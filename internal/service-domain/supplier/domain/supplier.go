@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -13,6 +14,7 @@ const (
 	SupplierStatusActive   SupplierStatus = "active"    // Verified and active
 	SupplierStatusSuspended SupplierStatus = "suspended" // Temporarily suspended
 	SupplierStatusInactive SupplierStatus = "inactive"  // Deactivated
+	SupplierStatusRestricted SupplierStatus = "restricted" // Mandatory certification lapsed; cannot be invited to new RFQs
 )
 
 // Verification Status
@@ -63,6 +65,7 @@ type Certification struct {
 	DocumentURL   string    `json:"document_url,omitempty"`
 	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
 	VerifiedBy    string    `json:"verified_by,omitempty"`
+	Mandatory     bool      `json:"mandatory,omitempty"` // Lapsing restricts the supplier
 }
 
 // Supplier represents a medical equipment supplier
@@ -70,6 +73,9 @@ type Supplier struct {
 	// Identity
 	ID       string `json:"id"`
 	TenantID string `json:"tenant_id"`
+
+	// Platform organization whose users act for this supplier in the supplier portal
+	OrganizationID string `json:"organization_id,omitempty"`
 	
 	// Company Information
 	CompanyName             string `json:"company_name"`
@@ -97,6 +103,8 @@ type Supplier struct {
 	VerificationStatus VerificationStatus `json:"verification_status"`
 	VerifiedAt         *time.Time         `json:"verified_at,omitempty"`
 	VerifiedBy         string             `json:"verified_by,omitempty"`
+	RestrictedAt       *time.Time         `json:"restricted_at,omitempty"`
+	RestrictionReason  string             `json:"restriction_reason,omitempty"`
 	
 	// Additional Information
 	Description string                 `json:"description"`
@@ -150,17 +158,26 @@ func NewSupplier(
 	}, nil
 }
 
-// Verify approves the supplier for business
+// Verify approves the supplier for business, recording the review of its
+// certification documents. A restricted supplier is re-verified this way once
+// its lapsed mandatory certifications have been renewed.
 func (s *Supplier) Verify(verifiedBy string) error {
-	if s.VerificationStatus == VerificationApproved {
+	if s.VerificationStatus == VerificationApproved && s.Status != SupplierStatusRestricted {
 		return errors.New("supplier is already verified")
 	}
 	
 	now := time.Now()
+	if lapsed := s.LapsedMandatoryCertifications(now); len(lapsed) > 0 {
+		return fmt.Errorf("%w: %s", ErrMandatoryCertificationLapsed, lapsed[0].Name)
+	}
+	s.verifyCertifications(verifiedBy, now)
+	
 	s.VerificationStatus = VerificationApproved
 	s.VerifiedAt = &now
 	s.VerifiedBy = verifiedBy
 	s.Status = SupplierStatusActive
+	s.RestrictedAt = nil
+	s.RestrictionReason = ""
 	s.UpdatedAt = now
 	
 	return nil
@@ -199,6 +216,9 @@ func (s *Supplier) Activate() error {
 	if s.VerificationStatus != VerificationApproved {
 		return errors.New("supplier must be verified to be activated")
 	}
+	if s.Status == SupplierStatusRestricted {
+		return ErrSupplierRestricted
+	}
 	
 	s.Status = SupplierStatusActive
 	s.UpdatedAt = time.Now()
@@ -218,6 +238,9 @@ func (s *Supplier) AddCertification(cert Certification) error {
 	if cert.Name == "" || cert.CertNumber == "" {
 		return errors.New("certification name and number are required")
 	}
+	if IsMandatoryCertification(cert.Name) {
+		cert.Mandatory = true
+	}
 	
 	s.Certifications = append(s.Certifications, cert)
 	s.UpdatedAt = time.Now()
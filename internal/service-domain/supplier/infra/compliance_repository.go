@@ -0,0 +1,137 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/supplier/domain"
+)
+
+// ListWithCertificationsExpiringBefore retrieves suppliers of all tenants,
+// other than inactive ones, holding a certification that expires before cutoff
+func (r *SupplierRepository) ListWithCertificationsExpiringBefore(ctx context.Context, cutoff time.Time) ([]*domain.Supplier, error) {
+	// Certifications without an expiry date carry Go's zero time
+	rows, err := r.db.pool.Query(ctx, `
+		SELECT s.id, s.tenant_id FROM suppliers s
+		WHERE s.status <> 'inactive'
+		  AND EXISTS (
+			SELECT 1 FROM jsonb_array_elements(COALESCE(s.certifications, '[]'::jsonb)) c
+			WHERE (c->>'expiry_date')::timestamptz > '0001-01-01T00:00:00Z'
+			  AND (c->>'expiry_date')::timestamptz <= $1
+		  )
+	`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suppliers with expiring certifications: %w", err)
+	}
+
+	type key struct{ id, tenantID string }
+	keys := []key{}
+	for rows.Next() {
+		var k key
+		if err := rows.Scan(&k.id, &k.tenantID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan supplier: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list suppliers with expiring certifications: %w", err)
+	}
+
+	suppliers := make([]*domain.Supplier, 0, len(keys))
+	for _, k := range keys {
+		supplier, err := r.GetByID(ctx, k.id, k.tenantID)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, supplier)
+	}
+	return suppliers, nil
+}
+
+// AddNotifications stores notifications, skipping repeats about the same
+// certification expiry, and returns how many were stored
+func (r *SupplierRepository) AddNotifications(ctx context.Context, notifications []domain.ComplianceNotification) (int, error) {
+	query := `
+		INSERT INTO supplier_compliance_notifications (
+			id, tenant_id, supplier_id, certification_id, certification_name, expiry_date,
+			recipient, kind, subject, message, created_at
+		) VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, $9, $10, $11)
+		ON CONFLICT DO NOTHING
+	`
+	stored := 0
+	for _, n := range notifications {
+		result, err := r.db.pool.Exec(ctx, query,
+			n.ID, n.TenantID, n.SupplierID, n.CertificationID, n.CertificationName, n.ExpiryDate,
+			n.Recipient, n.Kind, n.Subject, n.Message, n.CreatedAt)
+		if err != nil {
+			r.logger.Error("Failed to add compliance notification",
+				slog.String("error", err.Error()),
+				slog.String("supplier_id", n.SupplierID))
+			return stored, fmt.Errorf("failed to add compliance notification: %w", err)
+		}
+		stored += int(result.RowsAffected())
+	}
+	return stored, nil
+}
+
+// ListNotifications retrieves compliance notifications, newest first
+func (r *SupplierRepository) ListNotifications(ctx context.Context, tenantID string, filter domain.NotificationFilter) ([]domain.ComplianceNotification, error) {
+	query := `
+		SELECT id, tenant_id, supplier_id, COALESCE(certification_id, ''), COALESCE(certification_name, ''),
+			expiry_date, recipient, kind, subject, COALESCE(message, ''), created_at, read_at
+		FROM supplier_compliance_notifications
+		WHERE tenant_id = $1
+	`
+	args := []interface{}{tenantID}
+	if filter.Recipient != "" {
+		args = append(args, filter.Recipient)
+		query += fmt.Sprintf(" AND recipient = $%d", len(args))
+	}
+	if filter.SupplierID != "" {
+		args = append(args, filter.SupplierID)
+		query += fmt.Sprintf(" AND supplier_id = $%d", len(args))
+	}
+	if filter.UnreadOnly {
+		query += " AND read_at IS NULL"
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := r.db.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compliance notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []domain.ComplianceNotification{}
+	for rows.Next() {
+		var n domain.ComplianceNotification
+		err := rows.Scan(
+			&n.ID, &n.TenantID, &n.SupplierID, &n.CertificationID, &n.CertificationName,
+			&n.ExpiryDate, &n.Recipient, &n.Kind, &n.Subject, &n.Message, &n.CreatedAt, &n.ReadAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan compliance notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+// MarkNotificationRead marks a compliance notification as read
+func (r *SupplierRepository) MarkNotificationRead(ctx context.Context, tenantID, id string) error {
+	result, err := r.db.pool.Exec(ctx, `
+		UPDATE supplier_compliance_notifications SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+		WHERE id = $1 AND tenant_id = $2
+	`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to mark compliance notification read: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotificationNotFound
+	}
+	return nil
+}
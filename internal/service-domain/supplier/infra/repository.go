@@ -34,9 +34,9 @@ func (r *SupplierRepository) Create(ctx context.Context, supplier *domain.Suppli
 			year_established, description, contact_info, address, specializations,
 			certifications, performance_rating, total_orders, completed_orders,
 			status, verification_status, verified_at, verified_by, metadata,
-			created_by, created_at, updated_at
+			created_by, created_at, updated_at, organization_id, restricted_at, restriction_reason
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, NULLIF($23, ''), $24, NULLIF($25, '')
 		)
 	`
 
@@ -92,6 +92,9 @@ func (r *SupplierRepository) Create(ctx context.Context, supplier *domain.Suppli
 		supplier.CreatedBy,
 		supplier.CreatedAt,
 		supplier.UpdatedAt,
+		supplier.OrganizationID,
+		supplier.RestrictedAt,
+		supplier.RestrictionReason,
 	)
 
 	if err != nil {
@@ -116,7 +119,8 @@ func (r *SupplierRepository) GetByID(ctx context.Context, id string, tenantID st
 			year_established, description, contact_info, address, specializations,
 			certifications, performance_rating, total_orders, completed_orders,
 			status, verification_status, verified_at, verified_by, metadata,
-			created_by, created_at, updated_at
+			created_by, created_at, updated_at, COALESCE(organization_id, ''),
+			restricted_at, COALESCE(restriction_reason, '')
 		FROM suppliers
 		WHERE id = $1 AND tenant_id = $2
 	`
@@ -133,7 +137,8 @@ func (r *SupplierRepository) GetByTaxID(ctx context.Context, taxID string, tenan
 			year_established, description, contact_info, address, specializations,
 			certifications, performance_rating, total_orders, completed_orders,
 			status, verification_status, verified_at, verified_by, metadata,
-			created_by, created_at, updated_at
+			created_by, created_at, updated_at, COALESCE(organization_id, ''),
+			restricted_at, COALESCE(restriction_reason, '')
 		FROM suppliers
 		WHERE tax_id = $1 AND tenant_id = $2
 	`
@@ -143,6 +148,25 @@ func (r *SupplierRepository) GetByTaxID(ctx context.Context, taxID string, tenan
 	return r.scanSupplier(row)
 }
 
+// GetByOrganizationID retrieves the supplier linked to a platform organization
+func (r *SupplierRepository) GetByOrganizationID(ctx context.Context, organizationID string, tenantID string) (*domain.Supplier, error) {
+	query := `
+		SELECT 
+			id, tenant_id, company_name, business_registration_number, tax_id,
+			year_established, description, contact_info, address, specializations,
+			certifications, performance_rating, total_orders, completed_orders,
+			status, verification_status, verified_at, verified_by, metadata,
+			created_by, created_at, updated_at, COALESCE(organization_id, ''),
+			restricted_at, COALESCE(restriction_reason, '')
+		FROM suppliers
+		WHERE organization_id = $1 AND tenant_id = $2
+	`
+
+	row := r.db.pool.QueryRow(ctx, query, organizationID, tenantID)
+
+	return r.scanSupplier(row)
+}
+
 // Update updates an existing supplier
 func (r *SupplierRepository) Update(ctx context.Context, supplier *domain.Supplier) error {
 	query := `
@@ -164,7 +188,10 @@ func (r *SupplierRepository) Update(ctx context.Context, supplier *domain.Suppli
 			verified_at = $15,
 			verified_by = $16,
 			metadata = $17,
-			updated_at = $18
+			updated_at = $18,
+			organization_id = NULLIF($21, ''),
+			restricted_at = $22,
+			restriction_reason = NULLIF($23, '')
 		WHERE id = $19 AND tenant_id = $20
 	`
 
@@ -196,6 +223,9 @@ func (r *SupplierRepository) Update(ctx context.Context, supplier *domain.Suppli
 		supplier.UpdatedAt,
 		supplier.ID,
 		supplier.TenantID,
+		supplier.OrganizationID,
+		supplier.RestrictedAt,
+		supplier.RestrictionReason,
 	)
 
 	if err != nil {
@@ -240,7 +270,8 @@ func (r *SupplierRepository) List(ctx context.Context, criteria domain.ListCrite
 			year_established, description, contact_info, address, specializations,
 			certifications, performance_rating, total_orders, completed_orders,
 			status, verification_status, verified_at, verified_by, metadata,
-			created_by, created_at, updated_at
+			created_by, created_at, updated_at, COALESCE(organization_id, ''),
+			restricted_at, COALESCE(restriction_reason, '')
 		FROM suppliers
 		WHERE tenant_id = $1
 	`}
@@ -354,7 +385,8 @@ func (r *SupplierRepository) GetByCategory(ctx context.Context, categoryID strin
 			year_established, description, contact_info, address, specializations,
 			certifications, performance_rating, total_orders, completed_orders,
 			status, verification_status, verified_at, verified_by, metadata,
-			created_by, created_at, updated_at
+			created_by, created_at, updated_at, COALESCE(organization_id, ''),
+			restricted_at, COALESCE(restriction_reason, '')
 		FROM suppliers
 		WHERE tenant_id = $1
 		  AND status = 'active'
@@ -412,6 +444,9 @@ func (r *SupplierRepository) scanSupplier(row pgx.Row) (*domain.Supplier, error)
 		&supplier.CreatedBy,
 		&supplier.CreatedAt,
 		&supplier.UpdatedAt,
+		&supplier.OrganizationID,
+		&supplier.RestrictedAt,
+		&supplier.RestrictionReason,
 	)
 
 	if err != nil {
@@ -476,6 +511,9 @@ func (r *SupplierRepository) scanSupplierFromRows(rows pgx.Rows) (*domain.Suppli
 		&supplier.CreatedBy,
 		&supplier.CreatedAt,
 		&supplier.UpdatedAt,
+		&supplier.OrganizationID,
+		&supplier.RestrictedAt,
+		&supplier.RestrictionReason,
 	)
 
 	if err != nil {
@@ -0,0 +1,246 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aby-med/medical-platform/internal/service-domain/supplier/domain"
+)
+
+// ScorecardRepository implements domain.ScorecardRepository by aggregating
+// contracts, quotes, equipment and service tickets
+type ScorecardRepository struct {
+	db     *PostgresDB
+	logger *slog.Logger
+}
+
+// NewScorecardRepository creates a new scorecard repository
+func NewScorecardRepository(db *PostgresDB, logger *slog.Logger) *ScorecardRepository {
+	return &ScorecardRepository{
+		db:     db,
+		logger: logger.With(slog.String("component", "scorecard_repository")),
+	}
+}
+
+// Scorecards computes the scorecards of the given suppliers over activity since the given time
+func (r *ScorecardRepository) Scorecards(ctx context.Context, tenantID string, supplierIDs []string, since *time.Time) (map[string]*domain.Scorecard, error) {
+	scorecards := make(map[string]*domain.Scorecard, len(supplierIDs))
+	certs := make(map[string][]domain.Certification, len(supplierIDs))
+
+	rows, err := r.db.pool.Query(ctx, `
+		SELECT id, company_name, COALESCE(certifications, '[]'::jsonb)
+		FROM suppliers
+		WHERE tenant_id = $1 AND id = ANY($2)
+	`, tenantID, supplierIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load suppliers: %w", err)
+	}
+	for rows.Next() {
+		sc := &domain.Scorecard{Since: since}
+		var certsJSON []byte
+		if err := rows.Scan(&sc.SupplierID, &sc.CompanyName, &certsJSON); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan supplier: %w", err)
+		}
+		var list []domain.Certification
+		if err := json.Unmarshal(certsJSON, &list); err != nil {
+			r.logger.Warn("Ignoring unreadable certifications", slog.String("supplier_id", sc.SupplierID))
+		}
+		scorecards[sc.SupplierID] = sc
+		certs[sc.SupplierID] = list
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load suppliers: %w", err)
+	}
+	if len(scorecards) == 0 {
+		return scorecards, nil
+	}
+
+	for _, aggregate := range []func(context.Context, string, []string, *time.Time, map[string]*domain.Scorecard) error{
+		r.deliveries, r.contracts, r.quotes, r.pricing, r.quality,
+	} {
+		if err := aggregate(ctx, tenantID, supplierIDs, since, scorecards); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	for id, sc := range scorecards {
+		sc.Compute(certs[id], now)
+	}
+	return scorecards, nil
+}
+
+// deliveries counts delivery milestones by timeliness. A milestone completed
+// on its due day is on time; one due before now and not completed is overdue.
+func (r *ScorecardRepository) deliveries(ctx context.Context, tenantID string, ids []string, since *time.Time, out map[string]*domain.Scorecard) error {
+	rows, err := r.db.pool.Query(ctx, `
+		SELECT c.supplier_id,
+			COUNT(*) FILTER (WHERE m.completed AND m.completed_date::date <= m.milestone_date::date),
+			COUNT(*) FILTER (WHERE m.completed AND m.completed_date::date > m.milestone_date::date),
+			COUNT(*) FILTER (WHERE NOT m.completed AND m.milestone_date < NOW())
+		FROM contracts c
+		CROSS JOIN LATERAL (
+			SELECT COALESCE((e->>'completed')::boolean, false) AS completed,
+				(e->>'completed_date')::timestamptz AS completed_date,
+				(e->>'milestone_date')::timestamptz AS milestone_date
+			FROM jsonb_array_elements(c.delivery_schedule) e
+		) m
+		WHERE c.tenant_id = $1 AND c.supplier_id = ANY($2)
+		  AND c.status NOT IN ('draft', 'cancelled')
+		  AND ($3::timestamptz IS NULL OR m.milestone_date >= $3)
+		GROUP BY c.supplier_id
+	`, tenantID, ids, since)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var d domain.DeliveryPerformance
+		if err := rows.Scan(&id, &d.OnTime, &d.Late, &d.Overdue); err != nil {
+			return fmt.Errorf("failed to scan deliveries: %w", err)
+		}
+		if sc, ok := out[id]; ok {
+			sc.Delivery = d
+		}
+	}
+	return rows.Err()
+}
+
+// contracts counts finished contracts and how many were completed
+func (r *ScorecardRepository) contracts(ctx context.Context, tenantID string, ids []string, since *time.Time, out map[string]*domain.Scorecard) error {
+	rows, err := r.db.pool.Query(ctx, `
+		SELECT supplier_id,
+			COUNT(*) FILTER (WHERE status = 'completed'),
+			COUNT(*) FILTER (WHERE status IN ('completed', 'cancelled', 'suspended'))
+		FROM contracts
+		WHERE tenant_id = $1 AND supplier_id = ANY($2)
+		  AND ($3::timestamptz IS NULL OR created_at >= $3)
+		GROUP BY supplier_id
+	`, tenantID, ids, since)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate contracts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var c domain.ContractPerformance
+		if err := rows.Scan(&id, &c.Completed, &c.Finished); err != nil {
+			return fmt.Errorf("failed to scan contracts: %w", err)
+		}
+		if sc, ok := out[id]; ok {
+			sc.Contracts = c
+		}
+	}
+	return rows.Err()
+}
+
+// quotes counts submitted, decided and accepted quotes
+func (r *ScorecardRepository) quotes(ctx context.Context, tenantID string, ids []string, since *time.Time, out map[string]*domain.Scorecard) error {
+	rows, err := r.db.pool.Query(ctx, `
+		SELECT supplier_id,
+			COUNT(*) FILTER (WHERE status NOT IN ('draft', 'withdrawn')),
+			COUNT(*) FILTER (WHERE status IN ('accepted', 'rejected', 'expired')),
+			COUNT(*) FILTER (WHERE status = 'accepted')
+		FROM quotes
+		WHERE tenant_id = $1 AND supplier_id = ANY($2)
+		  AND ($3::timestamptz IS NULL OR created_at >= $3)
+		GROUP BY supplier_id
+	`, tenantID, ids, since)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate quotes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var q domain.QuotePerformance
+		if err := rows.Scan(&id, &q.Submitted, &q.Decided, &q.Won); err != nil {
+			return fmt.Errorf("failed to scan quotes: %w", err)
+		}
+		if sc, ok := out[id]; ok {
+			sc.Quotes = q
+		}
+	}
+	return rows.Err()
+}
+
+// pricing compares each submitted quote with the lowest quote in the same
+// currency on its RFQ; RFQs without competition are left out
+func (r *ScorecardRepository) pricing(ctx context.Context, tenantID string, ids []string, since *time.Time, out map[string]*domain.Scorecard) error {
+	rows, err := r.db.pool.Query(ctx, `
+		WITH submitted AS (
+			SELECT rfq_id, currency, supplier_id, total_amount
+			FROM quotes
+			WHERE tenant_id = $1 AND status NOT IN ('draft', 'withdrawn') AND total_amount > 0
+			  AND ($3::timestamptz IS NULL OR created_at >= $3)
+		), lowest AS (
+			SELECT rfq_id, currency, MIN(total_amount) AS amount, COUNT(DISTINCT supplier_id) AS bidders
+			FROM submitted
+			GROUP BY rfq_id, currency
+		)
+		SELECT s.supplier_id, COUNT(DISTINCT s.rfq_id),
+			COUNT(*) FILTER (WHERE s.total_amount = l.amount),
+			(AVG(l.amount / s.total_amount) * 100)::float8
+		FROM submitted s
+		JOIN lowest l ON l.rfq_id = s.rfq_id AND l.currency = s.currency
+		WHERE s.supplier_id = ANY($2) AND l.bidders > 1
+		GROUP BY s.supplier_id
+	`, tenantID, ids, since)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate pricing: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var p domain.PricePerformance
+		if err := rows.Scan(&id, &p.ComparedRFQs, &p.TimesLowest, &p.Competitiveness); err != nil {
+			return fmt.Errorf("failed to scan pricing: %w", err)
+		}
+		if sc, ok := out[id]; ok {
+			sc.Pricing = p
+		}
+	}
+	return rows.Err()
+}
+
+// quality counts service tickets on registered equipment bought under the
+// supplier's contracts, and those raised while it was under warranty
+func (r *ScorecardRepository) quality(ctx context.Context, tenantID string, ids []string, since *time.Time, out map[string]*domain.Scorecard) error {
+	rows, err := r.db.pool.Query(ctx, `
+		SELECT c.supplier_id,
+			COUNT(DISTINCT e.id),
+			COUNT(t.id),
+			COUNT(t.id) FILTER (WHERE e.warranty_expiry IS NOT NULL AND t.created_at::date <= e.warranty_expiry)
+		FROM contracts c
+		JOIN equipment_registry e ON e.contract_id = c.id
+		LEFT JOIN service_tickets t ON t.equipment_id = e.id
+		  AND ($3::timestamptz IS NULL OR t.created_at >= $3)
+		WHERE c.tenant_id = $1 AND c.supplier_id = ANY($2)
+		GROUP BY c.supplier_id
+	`, tenantID, ids, since)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate service history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var q domain.QualityPerformance
+		if err := rows.Scan(&id, &q.EquipmentSupplied, &q.ServiceTickets, &q.WarrantyDefects); err != nil {
+			return fmt.Errorf("failed to scan service history: %w", err)
+		}
+		if sc, ok := out[id]; ok {
+			sc.Quality = q
+		}
+	}
+	return rows.Err()
+}
@@ -21,6 +21,7 @@ type Module struct {
 	config  *Config
 	db      *infra.PostgresDB
 	handler *api.SupplierHandler
+	monitor *app.ComplianceMonitor
 	logger  *slog.Logger
 }
 
@@ -51,8 +52,11 @@ func (m *Module) Initialize(ctx context.Context) error {
 	// Create repository
 	repo := infra.NewSupplierRepository(db, m.logger)
 
+	scorecards := infra.NewScorecardRepository(db, m.logger)
+
 	// Create application service
-	supplierService := app.NewSupplierService(repo, m.logger)
+	supplierService := app.NewSupplierService(repo, scorecards, repo, m.logger)
+	m.monitor = app.NewComplianceMonitor(supplierService, m.logger)
 
 	// Create HTTP handler
 	m.handler = api.NewSupplierHandler(supplierService, m.logger)
@@ -72,7 +76,11 @@ func (m *Module) MountRoutes(router chi.Router) {
 // Start starts the supplier module background processes
 func (m *Module) Start(ctx context.Context) error {
 	m.logger.Info("Starting supplier module")
-	// No background processes to start for now
+
+	// Warn ahead of certification expiry and restrict suppliers whose mandatory certifications lapsed
+	if m.monitor != nil {
+		go m.monitor.Run(ctx)
+	}
 	return nil
 }
 
@@ -275,6 +275,12 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// Enabled reports whether a background job flag such as ENABLE_RFQ_DEADLINE_CLOSER
+// is switched on. Jobs run by default; set the flag to false to opt out.
+func Enabled(key string) bool {
+	return getEnvAsBool(key, true)
+}
+
 func getEnvAsSlice(key string, defaultValue []string) []string {
 	valueStr := getEnv(key, "")
 	if valueStr == "" {